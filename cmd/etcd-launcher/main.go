@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/exec"
+	"os/signal"
 	"strconv"
 	"strings"
 	"syscall"
@@ -14,6 +16,7 @@ const (
 	initialStateEnvName      = "INITIAL_STATE"
 	initialClusterEnvName    = "INITIAL_CLUSTER"
 	defaultClusterSize       = 3
+	maxClusterSize           = 5
 	defaultEtcdctlAPIVersion = "3"
 )
 
@@ -35,28 +38,53 @@ func main() {
 		log.Fatalf("failed to get launcher configuration: %v", err)
 	}
 
+	initialState, initialCluster, err := joinCluster(config)
+	if err != nil {
+		log.Fatalf("failed to join the etcd cluster: %v", err)
+	}
+
 	// not required, will leave it for now.
-	os.Setenv(initialStateEnvName, "new")
-	os.Setenv(initialClusterEnvName, initialMemberList(config.clusterSize, config.namespace))
+	os.Setenv(initialStateEnvName, initialState)
+	os.Setenv(initialClusterEnvName, initialCluster)
 
 	log.Print("initializing etcd..")
 	log.Printf("initial-state: %s", os.Getenv(initialStateEnvName))
 	log.Printf("initial-cluster: %s", os.Getenv(initialClusterEnvName))
 
-	err = syscall.Exec(
-		"/usr/local/bin/etcd",
-		etcdCmd(config),
-		os.Environ())
-	if err != nil {
+	// etcd runs as child process so the members can be reconciled once it is running
+	cmd := exec.Command(etcdBinary)
+	cmd.Args = etcdCmd(config, initialState, initialCluster)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
 		log.Fatal(err)
 	}
 
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		for sig := range signals {
+			if err := cmd.Process.Signal(sig); err != nil {
+				log.Printf("failed to forward %v to etcd: %v", sig, err)
+			}
+		}
+	}()
+
+	go reconcileMembers(config)
+
+	if err := cmd.Wait(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			os.Exit(exitErr.ExitCode())
+		}
+		log.Fatal(err)
+	}
 }
 
 func initialMemberList(n int, namespace string) string {
 	members := []string{}
 	for i := 0; i < n; i++ {
-		members = append(members, fmt.Sprintf("etcd-%d=http://etcd-%d.etcd.%s.svc.cluster.local:2380", i, i, namespace))
+		podName := fmt.Sprintf("etcd-%d", i)
+		members = append(members, fmt.Sprintf("%s=%s", podName, peerURL(podName, namespace)))
 	}
 	return strings.Join(members, ",")
 }
@@ -75,8 +103,8 @@ func getConfigFromEnv() (*envConfig, error) {
 		if config.clusterSize, err = strconv.Atoi(s); err != nil {
 			return nil, fmt.Errorf("failed to read ECTD_CLUSTER_SIZE: %v", err)
 		}
-		if config.clusterSize > defaultClusterSize {
-			return nil, fmt.Errorf("ECTD_CLUSTER_SIZE is smaller then %d", defaultClusterSize)
+		if config.clusterSize < defaultClusterSize || config.clusterSize > maxClusterSize || config.clusterSize%2 == 0 {
+			return nil, fmt.Errorf("ECTD_CLUSTER_SIZE has to be an odd number between %d and %d", defaultClusterSize, maxClusterSize)
 		}
 	}
 
@@ -105,18 +133,18 @@ func getConfigFromEnv() (*envConfig, error) {
 	return config, nil
 }
 
-func etcdCmd(config *envConfig) []string {
+func etcdCmd(config *envConfig, initialState, initialCluster string) []string {
 	cmd := []string{
 		"etcd",
 		fmt.Sprintf("--name=%s", config.podName),
 		fmt.Sprintf("--data-dir=%s", config.dataDir),
-		fmt.Sprintf("--initial-cluster=%s", initialMemberList(config.clusterSize, config.namespace)),
+		fmt.Sprintf("--initial-cluster=%s", initialCluster),
 		fmt.Sprintf("--initial-cluster-token=%s", config.token),
-		"--initial-cluster-state=new",
+		fmt.Sprintf("--initial-cluster-state=%s", initialState),
 		fmt.Sprintf("--advertise-client-urls=https://%s.etcd.%s.svc.cluster.local:2379,https://%s:2379", config.podName, config.namespace, config.podIP),
 		fmt.Sprintf("--listen-client-urls=https://%s:2379,https://127.0.0.1:2379", config.podIP),
 		fmt.Sprintf("--listen-peer-urls=http://%s:2380", config.podIP),
-		fmt.Sprintf("--initial-advertise-peer-urls=http://%s.etcd.%s.svc.cluster.local:2380", config.podName, config.namespace),
+		fmt.Sprintf("--initial-advertise-peer-urls=%s", peerURL(config.podName, config.namespace)),
 		"--trusted-ca-file=/etc/etcd/pki/ca/ca.crt",
 		"--client-cert-auth",
 		"--cert-file=/etc/etcd/pki/tls/etcd-tls.crt",
@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const testMemberAddOutput = `{
+  "header": {"cluster_id": 14841639068965178418, "member_id": 10276657743932975437, "raft_term": 2},
+  "member": {"ID": 11215893577428290412, "peerURLs": ["http://etcd-3.etcd.cluster-abc.svc.cluster.local:2380"], "isLearner": true},
+  "members": [
+    {"ID": 10276657743932975437, "name": "etcd-0", "peerURLs": ["http://etcd-0.etcd.cluster-abc.svc.cluster.local:2380"], "clientURLs": ["https://etcd-0.etcd.cluster-abc.svc.cluster.local:2379"]},
+    {"ID": 2, "name": "etcd-1", "peerURLs": ["http://etcd-1.etcd.cluster-abc.svc.cluster.local:2380"], "clientURLs": ["https://etcd-1.etcd.cluster-abc.svc.cluster.local:2379"]},
+    {"ID": 3, "name": "etcd-2", "peerURLs": ["http://etcd-2.etcd.cluster-abc.svc.cluster.local:2380"], "clientURLs": ["https://etcd-2.etcd.cluster-abc.svc.cluster.local:2379"]},
+    {"ID": 11215893577428290412, "peerURLs": ["http://etcd-3.etcd.cluster-abc.svc.cluster.local:2380"], "isLearner": true}
+  ]
+}`
+
+func TestInitialClusterFromMembers(t *testing.T) {
+	resp := &memberAddResponse{}
+	if err := json.Unmarshal([]byte(testMemberAddOutput), resp); err != nil {
+		t.Fatalf("failed to parse the member add output: %v", err)
+	}
+	if resp.Member.hexID() != "9ba6dbc8c932cb6c" {
+		t.Errorf("expected the added member to have the ID 9ba6dbc8c932cb6c, got %s", resp.Member.hexID())
+	}
+
+	config := &envConfig{namespace: "cluster-abc", podName: "etcd-3", clusterSize: 5}
+	expected := "etcd-0=http://etcd-0.etcd.cluster-abc.svc.cluster.local:2380," +
+		"etcd-1=http://etcd-1.etcd.cluster-abc.svc.cluster.local:2380," +
+		"etcd-2=http://etcd-2.etcd.cluster-abc.svc.cluster.local:2380," +
+		"etcd-3=http://etcd-3.etcd.cluster-abc.svc.cluster.local:2380"
+	if initialCluster := initialClusterFromMembers(resp.Members, config); initialCluster != expected {
+		t.Errorf("expected the initial cluster\n%s\ngot\n%s", expected, initialCluster)
+	}
+}
+
+func TestStaleMembers(t *testing.T) {
+	members := []member{
+		{ID: 1, Name: "etcd-0"},
+		{ID: 2, Name: "etcd-1"},
+		{ID: 3, Name: "etcd-2"},
+		{ID: 4, Name: "etcd-3"},
+		{ID: 5, PeerURLs: []string{"http://etcd-4.etcd.cluster-abc.svc.cluster.local:2380"}},
+		{ID: 6, Name: "restored"},
+	}
+
+	stale := staleMembers(members, 3)
+	if len(stale) != 2 || stale[0].ID != 4 || stale[1].ID != 5 {
+		t.Errorf("expected the members of etcd-3 and etcd-4 to be stale, got %+v", stale)
+	}
+	if stale := staleMembers(members, 5); len(stale) != 0 {
+		t.Errorf("expected no stale members for a cluster size of 5, got %+v", stale)
+	}
+}
+
+func TestVersionSupportsLearners(t *testing.T) {
+	testCases := []struct {
+		output   string
+		expected bool
+	}{
+		{output: "etcd Version: 3.4.3\nGit SHA: 3cf2f69b5\nGo Version: go1.12.12", expected: true},
+		{output: "etcd Version: 3.3.17\nGit SHA: 6d8052314\nGo Version: go1.12.9", expected: false},
+		{output: "etcd Version: 3.10.0", expected: true},
+		{output: "unknown", expected: false},
+	}
+
+	for _, tc := range testCases {
+		if result := versionSupportsLearners(tc.output); result != tc.expected {
+			t.Errorf("expected %v for %q, got %v", tc.expected, tc.output, result)
+		}
+	}
+}
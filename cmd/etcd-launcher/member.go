@@ -0,0 +1,311 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	etcdBinary    = "/usr/local/bin/etcd"
+	etcdctlBinary = "/usr/local/bin/etcdctl"
+
+	initialStateNew      = "new"
+	initialStateExisting = "existing"
+
+	memberReconcileInterval = 10 * time.Second
+)
+
+var (
+	memberNameRegexp  = regexp.MustCompile(`^etcd-(\d+)$`)
+	etcdVersionRegexp = regexp.MustCompile(`etcd Version: (\d+)\.(\d+)`)
+)
+
+// member is a member of the etcd cluster as returned by "etcdctl member list -w json"
+type member struct {
+	ID         uint64   `json:"ID"`
+	Name       string   `json:"name"`
+	PeerURLs   []string `json:"peerURLs"`
+	ClientURLs []string `json:"clientURLs"`
+	IsLearner  bool     `json:"isLearner"`
+}
+
+type memberListResponse struct {
+	Members []member `json:"members"`
+}
+
+type memberAddResponse struct {
+	Member  member   `json:"member"`
+	Members []member `json:"members"`
+}
+
+// hexID returns the ID in the format the etcdctl member commands expect
+func (m member) hexID() string {
+	return strconv.FormatUint(m.ID, 16)
+}
+
+// hasPeerURL returns whether the member is reachable at the given peer URL
+func (m member) hasPeerURL(peerURL string) bool {
+	for _, u := range m.PeerURLs {
+		if u == peerURL {
+			return true
+		}
+	}
+	return false
+}
+
+// memberName returns the name of the member. Members which have been added but did not start yet
+// have no name, their name is derived from the hostname of their peer URL.
+func (m member) memberName() string {
+	if m.Name != "" || len(m.PeerURLs) == 0 {
+		return m.Name
+	}
+	u, err := url.Parse(m.PeerURLs[0])
+	if err != nil {
+		return ""
+	}
+	return strings.Split(u.Hostname(), ".")[0]
+}
+
+// memberIndex returns the ordinal of the StatefulSet pod which runs the member, or -1 if the member
+// does not belong to a pod
+func memberIndex(name string) int {
+	match := memberNameRegexp.FindStringSubmatch(name)
+	if match == nil {
+		return -1
+	}
+	index, err := strconv.Atoi(match[1])
+	if err != nil {
+		return -1
+	}
+	return index
+}
+
+func peerURL(podName, namespace string) string {
+	return fmt.Sprintf("http://%s.etcd.%s.svc.cluster.local:2380", podName, namespace)
+}
+
+func clientURL(podName, namespace string) string {
+	return fmt.Sprintf("https://%s.etcd.%s.svc.cluster.local:2379", podName, namespace)
+}
+
+// peerClientURLs returns the client URLs of all members except the one of the pod
+func peerClientURLs(config *envConfig) []string {
+	var urls []string
+	for i := 0; i < config.clusterSize; i++ {
+		podName := fmt.Sprintf("etcd-%d", i)
+		if podName != config.podName {
+			urls = append(urls, clientURL(podName, config.namespace))
+		}
+	}
+	return urls
+}
+
+// initialClusterFromMembers builds the value of --initial-cluster for a member joining a running cluster
+func initialClusterFromMembers(members []member, config *envConfig) string {
+	ownPeerURL := peerURL(config.podName, config.namespace)
+	var entries []string
+	for _, m := range members {
+		name := m.memberName()
+		if m.hasPeerURL(ownPeerURL) {
+			name = config.podName
+		}
+		for _, u := range m.PeerURLs {
+			entries = append(entries, fmt.Sprintf("%s=%s", name, u))
+		}
+	}
+	return strings.Join(entries, ",")
+}
+
+// staleMembers returns the members which belong to pods beyond the size of the cluster
+func staleMembers(members []member, clusterSize int) []member {
+	var stale []member
+	for _, m := range members {
+		if memberIndex(m.memberName()) >= clusterSize {
+			stale = append(stale, m)
+		}
+	}
+	return stale
+}
+
+func hasData(dataDir string) bool {
+	_, err := os.Stat(filepath.Join(dataDir, "member"))
+	return err == nil
+}
+
+// supportsLearners checks whether the etcd version is at least 3.4, which introduced learner members
+func supportsLearners() bool {
+	out, err := exec.Command(etcdBinary, "--version").Output()
+	if err != nil {
+		log.Printf("failed to get the etcd version, not using learners: %v", err)
+		return false
+	}
+	return versionSupportsLearners(string(out))
+}
+
+func versionSupportsLearners(versionOutput string) bool {
+	match := etcdVersionRegexp.FindStringSubmatch(versionOutput)
+	if match == nil {
+		return false
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	return major > 3 || (major == 3 && minor >= 4)
+}
+
+// etcdctl runs etcdctl against the given endpoints. The certificates are configured through the
+// ETCDCTL_* environment variables of the pod.
+func etcdctl(endpoints []string, args ...string) ([]byte, error) {
+	cmdArgs := append([]string{
+		"--endpoints=" + strings.Join(endpoints, ","),
+		"--dial-timeout=2s",
+		"--command-timeout=10s",
+	}, args...)
+	cmd := exec.Command(etcdctlBinary, cmdArgs...)
+	for _, env := range os.Environ() {
+		// the endpoints are always given as flag
+		if !strings.HasPrefix(env, "ETCDCTL_ENDPOINTS=") {
+			cmd.Env = append(cmd.Env, env)
+		}
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("etcdctl %s failed: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("etcdctl %s failed: %v", strings.Join(args, " "), err)
+	}
+	return out, nil
+}
+
+func listMembers(endpoints []string) ([]member, error) {
+	out, err := etcdctl(endpoints, "member", "list", "-w", "json")
+	if err != nil {
+		return nil, err
+	}
+	resp := &memberListResponse{}
+	if err := json.Unmarshal(out, resp); err != nil {
+		return nil, fmt.Errorf("failed to parse the member list: %v", err)
+	}
+	return resp.Members, nil
+}
+
+// joinCluster prepares the start of the member. It returns the initial cluster state and member list etcd
+// is started with. Members which already have data are simply restarted. Members without data join
+// the running cluster through the member API, only if no cluster is running a new one is bootstrapped.
+func joinCluster(config *envConfig) (string, string, error) {
+	if hasData(config.dataDir) {
+		log.Print("found existing data, restarting the member")
+		return initialStateNew, initialMemberList(config.clusterSize, config.namespace), nil
+	}
+
+	endpoints := peerClientURLs(config)
+	members, err := listMembers(endpoints)
+	if err != nil {
+		log.Printf("no running etcd cluster found, bootstrapping a new one: %v", err)
+		return initialStateNew, initialMemberList(config.clusterSize, config.namespace), nil
+	}
+
+	ownPeerURL := peerURL(config.podName, config.namespace)
+	for _, m := range members {
+		if !m.hasPeerURL(ownPeerURL) {
+			continue
+		}
+		// The member got added but never started, e.g. while the cluster gets bootstrapped
+		// or because the previous attempt to join failed after adding it.
+		if m.Name == "" {
+			log.Printf("joining the running etcd cluster as the already added member %s", m.hexID())
+			return initialStateExisting, initialClusterFromMembers(members, config), nil
+		}
+
+		// The member started before but its data is gone, it is replaced by a new member.
+		log.Printf("removing the stale member %s of the pod as its data is gone", m.hexID())
+		if _, err := etcdctl(endpoints, "member", "remove", m.hexID()); err != nil {
+			return "", "", fmt.Errorf("failed to remove the stale member: %v", err)
+		}
+	}
+
+	args := []string{"member", "add", config.podName, "--peer-urls=" + ownPeerURL, "-w", "json"}
+	if supportsLearners() {
+		args = append(args, "--learner")
+	}
+	out, err := etcdctl(endpoints, args...)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to add the member: %v", err)
+	}
+	resp := &memberAddResponse{}
+	if err := json.Unmarshal(out, resp); err != nil {
+		return "", "", fmt.Errorf("failed to parse the added member: %v", err)
+	}
+	log.Printf("added the member %s to the running etcd cluster", resp.Member.hexID())
+
+	return initialStateExisting, initialClusterFromMembers(resp.Members, config), nil
+}
+
+// reconcileMembers runs next to etcd until the member is a voting member and the members of pods
+// beyond the size of the cluster are removed. Learners can only be promoted once they caught up
+// with the leader, so this is retried until it succeeds.
+func reconcileMembers(config *envConfig) {
+	endpoints := []string{"https://127.0.0.1:2379"}
+	ownPeerURL := peerURL(config.podName, config.namespace)
+
+	for {
+		time.Sleep(memberReconcileInterval)
+
+		members, err := listMembers(endpoints)
+		if err != nil {
+			log.Printf("failed to list the members: %v", err)
+			continue
+		}
+
+		done := true
+		for _, m := range members {
+			if m.hasPeerURL(ownPeerURL) && m.IsLearner {
+				if _, err := etcdctl(endpoints, "member", "promote", m.hexID()); err != nil {
+					log.Printf("failed to promote the member, it probably did not catch up yet: %v", err)
+					done = false
+					continue
+				}
+				log.Printf("promoted the member %s to a voting member", m.hexID())
+			}
+		}
+
+		for _, m := range staleMembers(members, config.clusterSize) {
+			if _, err := etcdctl(endpoints, "member", "remove", m.hexID()); err != nil {
+				log.Printf("failed to remove the member %s of pod %s: %v", m.hexID(), m.memberName(), err)
+				done = false
+				continue
+			}
+			log.Printf("removed the member %s of pod %s which is beyond the cluster size of %d", m.hexID(), m.memberName(), config.clusterSize)
+		}
+
+		if done {
+			return
+		}
+	}
+}
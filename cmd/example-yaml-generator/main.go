@@ -149,8 +149,9 @@ func createExampleSeed() *kubermaticv1.Seed {
 						GCP: &kubermaticv1.DatacenterSpecGCP{
 							ZoneSuffixes: []string{},
 						},
-						Kubevirt: &kubermaticv1.DatacenterSpecKubevirt{},
-						Alibaba:  &kubermaticv1.DatacenterSpecAlibaba{},
+						Kubevirt:              &kubermaticv1.DatacenterSpecKubevirt{},
+						Alibaba:               &kubermaticv1.DatacenterSpecAlibaba{},
+						OperatingSystemImages: []kubermaticv1.OperatingSystemImage{{}},
 					},
 				},
 			},
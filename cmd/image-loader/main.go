@@ -182,8 +182,6 @@ func getImagesFromCreators(templateData *resources.TemplateData) (images []strin
 	deploymentCreators = append(deploymentCreators, monitoring.GetDeploymentCreators(templateData)...)
 	deploymentCreators = append(deploymentCreators, containerlinux.GetDeploymentCreators("", kubermaticv1.UpdateWindow{})...)
 
-	cronjobCreators := kubernetescontroller.GetCronJobCreators(templateData)
-
 	daemonSetCreators := containerlinux.GetDaemonSetCreators("")
 
 	for _, creatorGetter := range statefulsetCreators {
@@ -204,15 +202,6 @@ func getImagesFromCreators(templateData *resources.TemplateData) (images []strin
 		images = append(images, getImagesFromPodSpec(deployment.Spec.Template.Spec)...)
 	}
 
-	for _, createFunc := range cronjobCreators {
-		_, creator := createFunc()
-		cronJob, err := creator(&batchv1beta1.CronJob{})
-		if err != nil {
-			return nil, err
-		}
-		images = append(images, getImagesFromPodSpec(cronJob.Spec.JobTemplate.Spec.Template.Spec)...)
-	}
-
 	for _, createFunc := range daemonSetCreators {
 		_, creator := createFunc()
 		daemonSet, err := creator(&appsv1.DaemonSet{})
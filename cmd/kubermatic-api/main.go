@@ -50,12 +50,12 @@ import (
 
 	cmdutil "github.com/kubermatic/kubermatic/cmd/util"
 	"github.com/kubermatic/kubermatic/pkg/cluster/client"
-	"github.com/kubermatic/kubermatic/pkg/controller/master-controller-manager/rbac"
 	kubermaticclientset "github.com/kubermatic/kubermatic/pkg/crd/client/clientset/versioned"
 	kubermaticinformers "github.com/kubermatic/kubermatic/pkg/crd/client/informers/externalversions"
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/features"
 	"github.com/kubermatic/kubermatic/pkg/handler"
+	"github.com/kubermatic/kubermatic/pkg/handler/audit"
 	"github.com/kubermatic/kubermatic/pkg/handler/auth"
 	"github.com/kubermatic/kubermatic/pkg/handler/v1/common"
 	kubermaticlog "github.com/kubermatic/kubermatic/pkg/log"
@@ -189,7 +189,8 @@ func createInitProviders(options serverRunOptions) (providers, error) {
 	}
 
 	seedClientGetter := provider.SeedClientGetterFactory(seedKubeconfigGetter)
-	clusterProviderGetter := clusterProviderFactory(mgr.GetRESTMapper(), seedKubeconfigGetter, seedClientGetter, options.workerName, options.featureGates.Enabled(features.OIDCKubeCfgEndpoint))
+	projectRoleProvider := kubernetesprovider.NewProjectRoleProvider(context.Background(), mgr.GetClient())
+	clusterProviderGetter := clusterProviderFactory(mgr.GetRESTMapper(), seedKubeconfigGetter, seedClientGetter, options.workerName, projectRoleProvider, options.featureGates.Enabled(features.OIDCKubeCfgEndpoint))
 
 	presetsProvider, err := kubernetesprovider.NewPresetsProvider(context.Background(), mgr.GetClient(), options.presetsFile, options.dynamicPresets)
 	if err != nil {
@@ -215,6 +216,11 @@ func createInitProviders(options serverRunOptions) (providers, error) {
 	if err != nil {
 		return providers{}, fmt.Errorf("failed to create privileged SSH key provider due to %v", err)
 	}
+	clusterTemplateProvider := kubernetesprovider.NewClusterTemplateProvider(defaultImpersonationClient.CreateImpersonatedClient, mgr.GetClient())
+	privilegedClusterTemplateProvider, err := kubernetesprovider.NewPrivilegedClusterTemplateProvider(mgr.GetClient())
+	if err != nil {
+		return providers{}, fmt.Errorf("failed to create privileged cluster template provider due to %v", err)
+	}
 	userProvider := kubernetesprovider.NewUserProvider(mgr.GetClient(), kubernetesprovider.IsServiceAccount)
 	settingsProvider := kubernetesprovider.NewSettingsProvider(kubermaticMasterClient, mgr.GetClient())
 	addonConfigProvider := kubernetesprovider.NewAddonConfigProvider(mgr.GetClient())
@@ -259,6 +265,8 @@ func createInitProviders(options serverRunOptions) (providers, error) {
 	return providers{
 		sshKey:                                sshKeyProvider,
 		privilegedSSHKeyProvider:              privilegedSSHKeyProvider,
+		clusterTemplateProvider:               clusterTemplateProvider,
+		privilegedClusterTemplateProvider:     privilegedClusterTemplateProvider,
 		user:                                  userProvider,
 		serviceAccountProvider:                serviceAccountProvider,
 		privilegedServiceAccountProvider:      serviceAccountProvider,
@@ -280,6 +288,8 @@ func createInitProviders(options serverRunOptions) (providers, error) {
 		adminProvider:                         adminProvider,
 		presetProvider:                        presetsProvider,
 		admissionPluginProvider:               admissionPluginProvider,
+		projectRoleProvider:                   projectRoleProvider,
+		pricingCatalogProvider:                kubernetesprovider.NewPricingCatalogProvider(context.Background(), mgr.GetClient()),
 		settingsWatcher:                       settingsWatcher,
 	}, nil
 }
@@ -346,6 +356,11 @@ func createAPIHandler(options serverRunOptions, prov providers, oidcIssuerVerifi
 	}
 	serviceAccountTokenAuth := serviceaccount.JWTTokenAuthenticator([]byte(options.serviceAccountSigningKey))
 
+	auditLogger, err := createAuditLogger(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audit logger: %v", err)
+	}
+
 	r := handler.NewRouting(
 		kubermaticlog.New(options.log.Debug, options.log.Format).Sugar(),
 		prov.presetProvider,
@@ -356,6 +371,8 @@ func createAPIHandler(options serverRunOptions, prov providers, oidcIssuerVerifi
 		prov.addonConfigProvider,
 		prov.sshKey,
 		prov.privilegedSSHKeyProvider,
+		prov.clusterTemplateProvider,
+		prov.privilegedClusterTemplateProvider,
 		prov.user,
 		prov.serviceAccountProvider,
 		prov.privilegedServiceAccountProvider,
@@ -380,7 +397,10 @@ func createAPIHandler(options serverRunOptions, prov providers, oidcIssuerVerifi
 		prov.settingsProvider,
 		prov.adminProvider,
 		prov.admissionPluginProvider,
+		prov.projectRoleProvider,
+		prov.pricingCatalogProvider,
 		prov.settingsWatcher,
+		auditLogger,
 	)
 
 	registerMetrics()
@@ -463,7 +483,7 @@ func setSecureHeaders(next http.Handler) http.Handler {
 	})
 }
 
-func clusterProviderFactory(mapper meta.RESTMapper, seedKubeconfigGetter provider.SeedKubeconfigGetter, seedClientGetter provider.SeedClientGetter, workerName string, oidcKubeCfgEndpointEnabled bool) provider.ClusterProviderGetter {
+func clusterProviderFactory(mapper meta.RESTMapper, seedKubeconfigGetter provider.SeedKubeconfigGetter, seedClientGetter provider.SeedClientGetter, workerName string, projectRoleProvider *kubernetesprovider.ProjectRoleProvider, oidcKubeCfgEndpointEnabled bool) provider.ClusterProviderGetter {
 	return func(seed *kubermaticv1.Seed) (provider.ClusterProvider, error) {
 		cfg, err := seedKubeconfigGetter(seed)
 		if err != nil {
@@ -490,10 +510,34 @@ func clusterProviderFactory(mapper meta.RESTMapper, seedKubeconfigGetter provide
 			defaultImpersonationClientForSeed.CreateImpersonatedClient,
 			userClusterConnectionProvider,
 			workerName,
-			rbac.ExtractGroupPrefix,
+			projectRoleProvider.UserClusterGroups,
 			seedCtrlruntimeClient,
 			kubeClient,
 			oidcKubeCfgEndpointEnabled,
 		), nil
 	}
 }
+
+func createAuditLogger(options serverRunOptions) (*audit.Logger, error) {
+	var store audit.Store
+	if options.auditLogDir != "" {
+		// the hostname is the name of the pod, so every replica writes its own file
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hostname: %v", err)
+		}
+		store, err = audit.NewDirectoryStore(options.auditLogDir, hostname)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var sinks []audit.Sink
+	if options.auditLogStdout {
+		sinks = append(sinks, audit.NewWriterSink(os.Stdout))
+	}
+	if options.auditLogWebhookURL != "" {
+		sinks = append(sinks, audit.NewWebhookSink(kubermaticlog.Logger, options.auditLogWebhookURL))
+	}
+	return audit.NewLogger(kubermaticlog.Logger, store, sinks...), nil
+}
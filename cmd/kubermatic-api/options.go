@@ -23,6 +23,7 @@ import (
 	"io/ioutil"
 	"strings"
 
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/features"
 	kubermaticlog "github.com/kubermatic/kubermatic/pkg/log"
 	"github.com/kubermatic/kubermatic/pkg/provider"
@@ -66,6 +67,11 @@ type serverRunOptions struct {
 	//service account configuration
 	serviceAccountSigningKey string
 
+	// audit trail configuration
+	auditLogStdout     bool
+	auditLogDir        string
+	auditLogWebhookURL string
+
 	featureGates features.FeatureGate
 }
 
@@ -105,9 +111,12 @@ func newServerRunOptions() (serverRunOptions, error) {
 	flag.StringVar(&rawFeatureGates, "feature-gates", "", "A set of key=value pairs that describe feature gates for various features.")
 	flag.StringVar(&s.domain, "domain", "localhost", "A domain name on which the server is deployed")
 	flag.StringVar(&s.serviceAccountSigningKey, "service-account-signing-key", "", "Signing key authenticates the service account's token value using HMAC. It is recommended to use a key with 32 bytes or longer.")
-	flag.StringVar(&rawExposeStrategy, "expose-strategy", "NodePort", "The strategy to expose the controlplane with, either \"NodePort\" which creates NodePorts with a \"nodeport-proxy.k8s.io/expose: true\" annotation or \"LoadBalancer\", which creates a LoadBalancer")
+	flag.StringVar(&rawExposeStrategy, "expose-strategy", "NodePort", "The strategy to expose the controlplane with, either \"NodePort\" which creates NodePorts with a \"nodeport-proxy.k8s.io/expose: true\" annotation, \"LoadBalancer\", which creates a LoadBalancer or \"SNI\", which additionally routes the apiserver by its hostname on port 443 of the nodeport-proxy")
 	flag.BoolVar(&s.dynamicPresets, "dynamic-presets", false, "Whether to enable dynamic presets")
 	flag.StringVar(&s.namespace, "namespace", "kubermatic", "The namespace kubermatic runs in, uses to determine where to look for datacenter custom resources")
+	flag.BoolVar(&s.auditLogStdout, "audit-log-stdout", false, "Write the audit trail of all API actions as JSON to stdout")
+	flag.StringVar(&s.auditLogDir, "audit-log-dir", "", "The optional directory every API replica appends the audit trail of all API actions to as JSON, in a file named after its hostname. It must be shared by all replicas, the admin audit endpoint reads the audit trail from it")
+	flag.StringVar(&s.auditLogWebhookURL, "audit-log-webhook-url", "", "The optional URL every entry of the audit trail of all API actions gets posted to as JSON")
 	addFlags(flag.CommandLine)
 	flag.Parse()
 
@@ -122,8 +131,10 @@ func newServerRunOptions() (serverRunOptions, error) {
 		s.exposeStrategy = corev1.ServiceTypeNodePort
 	case "LoadBalancer":
 		s.exposeStrategy = corev1.ServiceTypeLoadBalancer
+	case "SNI":
+		s.exposeStrategy = kubermaticv1.ExposeStrategySNI
 	default:
-		return s, fmt.Errorf("--expose-strategy must be either `NodePort` or `LoadBalancer`, got %q", rawExposeStrategy)
+		return s, fmt.Errorf("--expose-strategy must be one of `NodePort`, `LoadBalancer` or `SNI`, got %q", rawExposeStrategy)
 	}
 
 	s.accessibleAddons = sets.NewString(strings.Split(rawAccessibleAddons, ",")...)
@@ -176,6 +187,8 @@ func (o serverRunOptions) validate() error {
 type providers struct {
 	sshKey                                provider.SSHKeyProvider
 	privilegedSSHKeyProvider              provider.PrivilegedSSHKeyProvider
+	clusterTemplateProvider               provider.ClusterTemplateProvider
+	privilegedClusterTemplateProvider     provider.PrivilegedClusterTemplateProvider
 	user                                  provider.UserProvider
 	serviceAccountProvider                provider.ServiceAccountProvider
 	privilegedServiceAccountProvider      provider.PrivilegedServiceAccountProvider
@@ -197,5 +210,7 @@ type providers struct {
 	adminProvider                         provider.AdminProvider
 	presetProvider                        provider.PresetProvider
 	admissionPluginProvider               provider.AdmissionPluginsProvider
+	projectRoleProvider                   provider.ProjectRoleProvider
+	pricingCatalogProvider                provider.PricingCatalogProvider
 	settingsWatcher                       watcher.SettingsWatcher
 }
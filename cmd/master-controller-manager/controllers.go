@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 
+	clustermigration "github.com/kubermatic/kubermatic/pkg/controller/master-controller-manager/cluster-migration"
 	projectlabelsynchronizer "github.com/kubermatic/kubermatic/pkg/controller/master-controller-manager/project-label-synchronizer"
 	"github.com/kubermatic/kubermatic/pkg/controller/master-controller-manager/rbac"
 	seedproxy "github.com/kubermatic/kubermatic/pkg/controller/master-controller-manager/seed-proxy"
@@ -49,6 +50,7 @@ func createAllControllers(ctrlCtx *controllerContext) error {
 	)
 	projectLabelSynchronizerFactory := projectLabelSynchronizerFactoryCreator(ctrlCtx)
 	userSSHKeysSynchronizerFactory := userSSHKeysSynchronizerFactoryCreator(ctrlCtx)
+	clusterMigrationFactory := clusterMigrationFactoryCreator(ctrlCtx)
 
 	if err := seedcontrollerlifecycle.Add(ctrlCtx.ctx,
 		kubermaticlog.Logger,
@@ -58,7 +60,8 @@ func createAllControllers(ctrlCtx *controllerContext) error {
 		ctrlCtx.seedKubeconfigGetter,
 		rbacControllerFactory,
 		projectLabelSynchronizerFactory,
-		userSSHKeysSynchronizerFactory); err != nil {
+		userSSHKeysSynchronizerFactory,
+		clusterMigrationFactory); err != nil {
 		//TODO: Find a better name
 		return fmt.Errorf("failed to create seedcontrollerlifecycle: %v", err)
 	}
@@ -122,3 +125,17 @@ func userSSHKeysSynchronizerFactoryCreator(ctrlCtx *controllerContext) seedcontr
 		)
 	}
 }
+
+func clusterMigrationFactoryCreator(ctrlCtx *controllerContext) seedcontrollerlifecycle.ControllerFactory {
+	return func(ctx context.Context, mgr manager.Manager, seedManagerMap map[string]manager.Manager) (string, error) {
+		return clustermigration.ControllerName, clustermigration.Add(
+			ctx,
+			mgr,
+			seedManagerMap,
+			ctrlCtx.log,
+			ctrlCtx.workerName,
+			ctrlCtx.workerCount,
+			ctrlCtx.seedsGetter,
+		)
+	}
+}
@@ -51,6 +51,7 @@ func setupSeedValidationWebhook(ctx context.Context, mgr manager.Manager, log *z
 		opt.workerName,
 		ctrlCtx.seedsGetter,
 		provider.SeedClientGetterFactory(ctrlCtx.seedKubeconfigGetter),
+		mgr.GetAPIReader(),
 		false)
 	if err != nil {
 		return fmt.Errorf("failed to create seed validation webhook server: %v", err)
@@ -19,12 +19,14 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/Masterminds/semver"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -210,12 +212,25 @@ func (r *reconciler) sync() error {
 		return errors.Wrap(err, "failed to get initial config")
 	}
 
+	// The SNI routes by hostname, there can only be one route per hostname
+	sniRoutes := map[string]*sniRoute{}
+
 	for _, service := range services.Items {
 		serviceKey := ServiceKey(&service)
 		serviceLog := r.log.With("service", serviceKey)
 
-		// Only cover services which have the annotation: true
-		if strings.ToLower(service.Annotations[exposeAnnotationKey]) != "true" {
+		// Services of clusters using SNI are not exposed by their NodePorts on the LoadBalancer. They get
+		// routed on the SNI listener and exposed on the in-cluster LoadBalancer, which both only exist
+		// when the SNI listener is enabled.
+		var sniHostname string
+		exposeNodePorts := strings.ToLower(service.Annotations[exposeAnnotationKey]) == "true"
+		if envoySNIListenerPort != 0 {
+			sniHostname = service.Annotations[sniHostnameAnnotationKey]
+			exposeNodePorts = exposeNodePorts || strings.ToLower(service.Annotations[exposeInClusterAnnotationKey]) == "true"
+		}
+
+		// Only cover services which have the annotation: true or get routed on the SNI listener
+		if !exposeNodePorts && sniHostname == "" {
 			serviceLog.Debugf("Skipping service: it does not have the annotation %s=true", exposeAnnotationKey)
 			continue
 		}
@@ -237,7 +252,14 @@ func (r *reconciler) sync() error {
 			continue
 		}
 
-		for _, servicePort := range service.Spec.Ports {
+		// Connections from other sources do not match any filter chain and get closed by envoy
+		sourceRanges, err := sourcePrefixRanges(&service)
+		if err != nil {
+			serviceLog.Errorw("Skipping service: it has invalid allowed source ranges", zap.Error(err))
+			continue
+		}
+
+		for portIdx, servicePort := range service.Spec.Ports {
 			serviceNodePortName := fmt.Sprintf("%s-%d", serviceKey, servicePort.NodePort)
 			servicePortLog := serviceLog.With("port", servicePort.NodePort)
 
@@ -311,6 +333,22 @@ func (r *reconciler) sync() error {
 				return errors.Wrap(err, "failed to marshal tcpProxyConfig")
 			}
 
+			// Only the first port of a service is reachable via SNI
+			if sniHostname != "" && portIdx == 0 {
+				existing, ok := sniRoutes[sniHostname]
+				if ok {
+					serviceLog.Warnw("The SNI hostname is used by multiple services, only the first one by name gets routed", "hostname", sniHostname, "otherCluster", existing.cluster)
+				}
+				// Pick the same service no matter in which order they got listed
+				if !ok || serviceNodePortName < existing.cluster {
+					sniRoutes[sniHostname] = &sniRoute{cluster: serviceNodePortName, tcpProxyConfig: tcpProxyConfigMarshalled, sourceRanges: sourceRanges}
+				}
+			}
+
+			if !exposeNodePorts {
+				continue
+			}
+
 			r.log.Debugf("Using a listener on port %d", servicePort.NodePort)
 
 			listener := &envoyv2.Listener{
@@ -328,6 +366,7 @@ func (r *reconciler) sync() error {
 				},
 				FilterChains: []*envoylistenerv2.FilterChain{
 					{
+						FilterChainMatch: filterChainMatch(nil, sourceRanges),
 						Filters: []*envoylistenerv2.Filter{
 							{
 								Name: envoywellknown.TCPProxy,
@@ -343,6 +382,10 @@ func (r *reconciler) sync() error {
 		}
 	}
 
+	if len(sniRoutes) > 0 {
+		listeners = append(listeners, sniListener(sniRoutes))
+	}
+
 	lastUsedVersion, err := semver.NewVersion(r.lastAppliedSnapshot.GetVersion(envoycache.ClusterType))
 	if err != nil {
 		return errors.Wrap(err, "failed to parse version from last snapshot")
@@ -401,3 +444,91 @@ func (r *reconciler) getReadyServicePods(service *corev1.Service) ([]*corev1.Pod
 
 	return readyPods, nil
 }
+
+// sniRoute is a route of the SNI listener to the cluster of a service port
+type sniRoute struct {
+	cluster        string
+	tcpProxyConfig *any.Any
+	sourceRanges   []*envoycorev2.CidrRange
+}
+
+// sniListener returns a listener which inspects the TLS handshake of a connection and proxies it to
+// the cluster of the route with the matching hostname
+func sniListener(routes map[string]*sniRoute) *envoyv2.Listener {
+	hostnames := make([]string, 0, len(routes))
+	for hostname := range routes {
+		hostnames = append(hostnames, hostname)
+	}
+	// Must be sorted, otherwise we get into trouble when doing the snapshot diff later
+	sort.Strings(hostnames)
+
+	var filterChains []*envoylistenerv2.FilterChain
+	for _, hostname := range hostnames {
+		filterChains = append(filterChains, &envoylistenerv2.FilterChain{
+			FilterChainMatch: filterChainMatch([]string{hostname}, routes[hostname].sourceRanges),
+			Filters: []*envoylistenerv2.Filter{
+				{
+					Name: envoywellknown.TCPProxy,
+					ConfigType: &envoylistenerv2.Filter_TypedConfig{
+						TypedConfig: routes[hostname].tcpProxyConfig,
+					},
+				},
+			},
+		})
+	}
+
+	return &envoyv2.Listener{
+		Name: sniListenerName,
+		Address: &envoycorev2.Address{
+			Address: &envoycorev2.Address_SocketAddress{
+				SocketAddress: &envoycorev2.SocketAddress{
+					Protocol: envoycorev2.SocketAddress_TCP,
+					Address:  "0.0.0.0",
+					PortSpecifier: &envoycorev2.SocketAddress_PortValue{
+						PortValue: uint32(envoySNIListenerPort),
+					},
+				},
+			},
+		},
+		ListenerFilters: []*envoylistenerv2.ListenerFilter{
+			{
+				Name: envoywellknown.TlsInspector,
+			},
+		},
+		FilterChains: filterChains,
+	}
+}
+
+// filterChainMatch returns the match for a filter chain which only applies to connections with one
+// of the given server names from one of the given source ranges. Empty lists match everything.
+func filterChainMatch(serverNames []string, sourceRanges []*envoycorev2.CidrRange) *envoylistenerv2.FilterChainMatch {
+	if len(serverNames) == 0 && len(sourceRanges) == 0 {
+		return nil
+	}
+	return &envoylistenerv2.FilterChainMatch{
+		ServerNames:        serverNames,
+		SourcePrefixRanges: sourceRanges,
+	}
+}
+
+// sourcePrefixRanges returns the source ranges a service is restricted to or nil if it is not restricted
+func sourcePrefixRanges(service *corev1.Service) ([]*envoycorev2.CidrRange, error) {
+	annotation := strings.TrimSpace(service.Annotations[allowedSourceRangesAnnotationKey])
+	if annotation == "" {
+		return nil, nil
+	}
+
+	var ranges []*envoycorev2.CidrRange
+	for _, cidr := range strings.Split(annotation, ",") {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %v", cidr, err)
+		}
+		prefixLen, _ := ipNet.Mask.Size()
+		ranges = append(ranges, &envoycorev2.CidrRange{
+			AddressPrefix: ipNet.IP.String(),
+			PrefixLen:     &wrappers.UInt32Value{Value: uint32(prefixLen)},
+		})
+	}
+	return ranges, nil
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
 
 	envoyv2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
 	envoycorev2 "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
@@ -625,6 +626,284 @@ func TestSync(t *testing.T) {
 	}
 }
 
+func TestSyncSNI(t *testing.T) {
+	envoySNIListenerPort = 6443
+	defer func() { envoySNIListenerPort = 0 }()
+
+	genService := func(namespace, hostname string, nodePort int32) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "apiserver-external",
+				Namespace: namespace,
+				Annotations: map[string]string{
+					exposeInClusterAnnotationKey: "true",
+					sniHostnameAnnotationKey:     hostname,
+				},
+			},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeNodePort,
+				Ports: []corev1.ServicePort{
+					{
+						Name:       "secure",
+						TargetPort: intstr.FromInt(int(nodePort)),
+						NodePort:   nodePort,
+						Protocol:   corev1.ProtocolTCP,
+						Port:       nodePort,
+					},
+				},
+				Selector: map[string]string{
+					"app": "apiserver",
+				},
+			},
+		}
+	}
+	genPod := func(namespace, ip string, port int32) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "apiserver",
+				Namespace: namespace,
+				Labels: map[string]string{
+					"app": "apiserver",
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "apiserver",
+						Ports: []corev1.ContainerPort{
+							{
+								Protocol:      corev1.ProtocolTCP,
+								ContainerPort: port,
+							},
+						},
+					},
+				},
+			},
+			Status: corev1.PodStatus{
+				PodIP: ip,
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   corev1.PodReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		}
+	}
+
+	sniOnlyService := genService("cluster-d", "d.dev.kubermatic.io", 32003)
+	delete(sniOnlyService.Annotations, exposeInClusterAnnotationKey)
+
+	log := zap.NewNop().Sugar()
+	client := fakectrlruntimeclient.NewFakeClient(
+		genService("cluster-b", "b.dev.kubermatic.io", 32001),
+		genPod("cluster-b", "172.16.0.2", 32001),
+		genService("cluster-a", "a.dev.kubermatic.io", 32000),
+		genPod("cluster-a", "172.16.0.1", 32000),
+		// Uses the hostname of cluster-a, which comes first by name
+		genService("cluster-c", "a.dev.kubermatic.io", 32002),
+		genPod("cluster-c", "172.16.0.3", 32002),
+		// Only routed on the SNI listener
+		sniOnlyService,
+		genPod("cluster-d", "172.16.0.4", 32003),
+	)
+	c := reconciler{
+		Client:              client,
+		envoySnapshotCache:  envoycache.NewSnapshotCache(true, hasher{}, log),
+		log:                 log,
+		lastAppliedSnapshot: envoycache.NewSnapshot("v0.0.0", nil, nil, nil, nil, nil),
+	}
+	if err := c.sync(); err != nil {
+		t.Fatalf("failed to execute controller sync func: %v", err)
+	}
+
+	listeners := c.lastAppliedSnapshot.Resources[envoycache.Listener].Items
+	// The NodePorts of services exposed on the in-cluster LoadBalancer get a listener
+	for _, name := range []string{"cluster-a/apiserver-external-32000", "cluster-b/apiserver-external-32001", "cluster-c/apiserver-external-32002"} {
+		if _, ok := listeners[name]; !ok {
+			t.Errorf("expected a listener for %s", name)
+		}
+	}
+	if _, ok := listeners["cluster-d/apiserver-external-32003"]; ok {
+		t.Error("expected no listener for a service which is only routed on the SNI listener")
+	}
+	if _, ok := c.lastAppliedSnapshot.Resources[envoycache.Cluster].Items["cluster-d/apiserver-external-32003"]; !ok {
+		t.Error("expected a cluster for a service which is only routed on the SNI listener")
+	}
+
+	res, ok := listeners[sniListenerName]
+	if !ok {
+		t.Fatal("expected a SNI listener")
+	}
+	listener := res.(*envoyv2.Listener)
+	if port := listener.Address.GetSocketAddress().GetPortValue(); port != uint32(envoySNIListenerPort) {
+		t.Errorf("expected the SNI listener on port %d, got %d", envoySNIListenerPort, port)
+	}
+	if len(listener.ListenerFilters) != 1 || listener.ListenerFilters[0].Name != envoywellknown.TlsInspector {
+		t.Errorf("expected the SNI listener to inspect TLS, got listener filters %v", listener.ListenerFilters)
+	}
+
+	expectedRoutes := []struct {
+		hostname string
+		cluster  string
+	}{
+		{hostname: "a.dev.kubermatic.io", cluster: "cluster-a/apiserver-external-32000"},
+		{hostname: "b.dev.kubermatic.io", cluster: "cluster-b/apiserver-external-32001"},
+		{hostname: "d.dev.kubermatic.io", cluster: "cluster-d/apiserver-external-32003"},
+	}
+	if len(listener.FilterChains) != len(expectedRoutes) {
+		t.Fatalf("expected %d filter chains, got %d", len(expectedRoutes), len(listener.FilterChains))
+	}
+	for i, expected := range expectedRoutes {
+		filterChain := listener.FilterChains[i]
+		if diff := deep.Equal(filterChain.FilterChainMatch.ServerNames, []string{expected.hostname}); diff != nil {
+			t.Errorf("got unexpected server names for filter chain %d, diff: %v", i, diff)
+		}
+		expectedFilters := []*envoylistenerv2.Filter{
+			{
+				Name: envoywellknown.TCPProxy,
+				ConfigType: &envoylistenerv2.Filter_TypedConfig{
+					TypedConfig: marshalMessage(t, &envoytcpfilterv2.TcpProxy{
+						StatPrefix: "ingress_tcp",
+						ClusterSpecifier: &envoytcpfilterv2.TcpProxy_Cluster{
+							Cluster: expected.cluster,
+						},
+					}),
+				},
+			},
+		}
+		if diff := deep.Equal(filterChain.Filters, expectedFilters); diff != nil {
+			t.Errorf("got unexpected filters for %s, diff: %v", expected.hostname, diff)
+		}
+	}
+}
+
+func TestSyncAllowedSourceRanges(t *testing.T) {
+	envoySNIListenerPort = 6443
+	defer func() { envoySNIListenerPort = 0 }()
+
+	genService := func(namespace, allowedSourceRanges string, nodePort int32) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "apiserver-external",
+				Namespace: namespace,
+				Annotations: map[string]string{
+					exposeAnnotationKey:              "true",
+					sniHostnameAnnotationKey:         namespace + ".dev.kubermatic.io",
+					allowedSourceRangesAnnotationKey: allowedSourceRanges,
+				},
+			},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeNodePort,
+				Ports: []corev1.ServicePort{
+					{
+						Name:       "secure",
+						TargetPort: intstr.FromInt(int(nodePort)),
+						NodePort:   nodePort,
+						Protocol:   corev1.ProtocolTCP,
+						Port:       nodePort,
+					},
+				},
+				Selector: map[string]string{
+					"app": "apiserver",
+				},
+			},
+		}
+	}
+	genPod := func(namespace, ip string, port int32) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "apiserver",
+				Namespace: namespace,
+				Labels: map[string]string{
+					"app": "apiserver",
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "apiserver",
+						Ports: []corev1.ContainerPort{
+							{
+								Protocol:      corev1.ProtocolTCP,
+								ContainerPort: port,
+							},
+						},
+					},
+				},
+			},
+			Status: corev1.PodStatus{
+				PodIP: ip,
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   corev1.PodReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		}
+	}
+
+	log := zap.NewNop().Sugar()
+	client := fakectrlruntimeclient.NewFakeClient(
+		genService("cluster-a", "10.0.0.0/8, 2001:db8::1/128", 32000),
+		genPod("cluster-a", "172.16.0.1", 32000),
+		genService("cluster-b", "", 32001),
+		genPod("cluster-b", "172.16.0.2", 32001),
+		// Must not become reachable from everywhere
+		genService("cluster-c", "10.0.0.0/33", 32002),
+		genPod("cluster-c", "172.16.0.3", 32002),
+	)
+	c := reconciler{
+		Client:              client,
+		envoySnapshotCache:  envoycache.NewSnapshotCache(true, hasher{}, log),
+		log:                 log,
+		lastAppliedSnapshot: envoycache.NewSnapshot("v0.0.0", nil, nil, nil, nil, nil),
+	}
+	if err := c.sync(); err != nil {
+		t.Fatalf("failed to execute controller sync func: %v", err)
+	}
+
+	expectedRanges := []*envoycorev2.CidrRange{
+		{AddressPrefix: "10.0.0.0", PrefixLen: &wrappers.UInt32Value{Value: 8}},
+		{AddressPrefix: "2001:db8::1", PrefixLen: &wrappers.UInt32Value{Value: 128}},
+	}
+
+	listeners := c.lastAppliedSnapshot.Resources[envoycache.Listener].Items
+	if _, ok := listeners["cluster-c/apiserver-external-32002"]; ok {
+		t.Error("expected no listener for a service with invalid source ranges")
+	}
+	res, ok := listeners["cluster-b/apiserver-external-32001"]
+	if !ok {
+		t.Fatal("expected a listener for cluster-b")
+	}
+	if match := res.(*envoyv2.Listener).FilterChains[0].FilterChainMatch; match != nil {
+		t.Errorf("expected the listener of an unrestricted service to match everything, got %v", match)
+	}
+	res, ok = listeners["cluster-a/apiserver-external-32000"]
+	if !ok {
+		t.Fatal("expected a listener for cluster-a")
+	}
+	if diff := deep.Equal(res.(*envoyv2.Listener).FilterChains[0].FilterChainMatch.GetSourcePrefixRanges(), expectedRanges); diff != nil {
+		t.Errorf("got unexpected source ranges for the NodePort listener, diff: %v", diff)
+	}
+
+	res, ok = listeners[sniListenerName]
+	if !ok {
+		t.Fatal("expected a SNI listener")
+	}
+	sniFilterChains := res.(*envoyv2.Listener).FilterChains
+	if len(sniFilterChains) != 2 {
+		t.Fatalf("expected 2 filter chains, got %d", len(sniFilterChains))
+	}
+	if diff := deep.Equal(sniFilterChains[0].FilterChainMatch.SourcePrefixRanges, expectedRanges); diff != nil {
+		t.Errorf("got unexpected source ranges for the SNI route of cluster-a, diff: %v", diff)
+	}
+	if ranges := sniFilterChains[1].FilterChainMatch.SourcePrefixRanges; len(ranges) != 0 {
+		t.Errorf("expected the SNI route of cluster-b to be unrestricted, got %v", ranges)
+	}
+}
+
 func marshalMessage(t *testing.T, msg proto.Message) *any.Any {
 	marshalled, err := ptypes.MarshalAny(msg)
 	if err != nil {
@@ -50,13 +50,22 @@ var (
 	envoyNodeName       string
 	exposeAnnotationKey string
 
-	envoyStatsPort int
-	envoyAdminPort int
+	envoyStatsPort       int
+	envoyAdminPort       int
+	envoySNIListenerPort int
 )
 
 const (
 	defaultExposeAnnotationKey = "nodeport-proxy.k8s.io/expose"
-	clusterConnectTimeout      = 1 * time.Second
+	sniHostnameAnnotationKey   = "nodeport-proxy.k8s.io/sni-hostname"
+	// exposeInClusterAnnotationKey exposes the NodePorts of a service on the in-cluster LoadBalancer,
+	// which is only used together with the SNI listener
+	exposeInClusterAnnotationKey = "nodeport-proxy.k8s.io/expose-in-cluster"
+	// allowedSourceRangesAnnotationKey restricts the source addresses of connections to a service
+	// to a comma-separated list of CIDRs
+	allowedSourceRangesAnnotationKey = "nodeport-proxy.k8s.io/allowed-source-ranges"
+	sniListenerName                  = "sni_listener"
+	clusterConnectTimeout            = 1 * time.Second
 )
 
 func main() {
@@ -67,6 +76,7 @@ func main() {
 	flag.StringVar(&envoyNodeName, "envoy-node-name", "kube", "Name of the envoy nodes to apply the config to via xds")
 	flag.IntVar(&envoyAdminPort, "envoy-admin-port", 9001, "Envoys admin port")
 	flag.IntVar(&envoyStatsPort, "envoy-stats-port", 8002, "Limited port which should be opened on envoy to expose metrics and the health check. Endpoints are: /healthz & /stats")
+	flag.IntVar(&envoySNIListenerPort, "envoy-sni-listener-port", 0, "Port on which envoy routes TLS connections by their server name to the services with the "+sniHostnameAnnotationKey+" annotation. 0 disables the SNI listener")
 	flag.StringVar(&namespace, "namespace", "", "The namespace we should use for pods and services. Leave empty for all namespaces.")
 	flag.StringVar(&exposeAnnotationKey, "expose-annotation-key", defaultExposeAnnotationKey, "The annotation key used to determine if a service should be exposed")
 	flag.Parse()
@@ -46,6 +46,9 @@ import (
 const (
 	defaultExposeAnnotationKey = "nodeport-proxy.k8s.io/expose"
 	healthCheckPort            = 8002
+	healthCheckPortName        = "healthz"
+	sniPort                    = 443
+	sniPortName                = "sni"
 )
 
 var (
@@ -53,6 +56,7 @@ var (
 	lbNamespace         string
 	namespaced          bool
 	exposeAnnotationKey string
+	sniListenerPort     int
 )
 
 func main() {
@@ -63,6 +67,7 @@ func main() {
 	flag.StringVar(&lbNamespace, "lb-namespace", "nodeport-proxy", "namespace of the LoadBalancer service to manage. Needs to exist")
 	flag.BoolVar(&namespaced, "namespaced", false, "Whether this controller should only watch services in the lbNamespace")
 	flag.StringVar(&exposeAnnotationKey, "expose-annotation-key", defaultExposeAnnotationKey, "The annotation key used to determine if a Service should be exposed")
+	flag.IntVar(&sniListenerPort, "sni-listener-port", 0, "Port of the SNI listener of envoy which should be exposed on port 443 of the LoadBalancer. 0 disables it")
 	flag.Parse()
 
 	// setup signal handler
@@ -100,12 +105,13 @@ func main() {
 	}
 
 	r := &LBUpdater{
-		ctx:         ctx,
-		client:      mgr.GetClient(),
-		lbNamespace: lbNamespace,
-		lbName:      lbName,
-		namespace:   namespace,
-		log:         log,
+		ctx:             ctx,
+		client:          mgr.GetClient(),
+		lbNamespace:     lbNamespace,
+		lbName:          lbName,
+		namespace:       namespace,
+		sniListenerPort: sniListenerPort,
+		log:             log,
 	}
 
 	ctrl, err := controller.New("lb-updater", mgr,
@@ -126,10 +132,11 @@ type LBUpdater struct {
 	ctx    context.Context
 	client ctrlruntimeclient.Client
 
-	lbNamespace string
-	lbName      string
-	namespace   string
-	log         *zap.SugaredLogger
+	lbNamespace     string
+	lbName          string
+	namespace       string
+	sniListenerPort int
+	log             *zap.SugaredLogger
 }
 
 func (u *LBUpdater) Reconcile(request reconcile.Request) (reconcile.Result, error) {
@@ -151,11 +158,19 @@ func (u *LBUpdater) syncLB(s string) error {
 
 	var wantLBPorts []corev1.ServicePort
 	wantLBPorts = append(wantLBPorts, corev1.ServicePort{
-		Name:       "healthz",
+		Name:       healthCheckPortName,
 		Port:       healthCheckPort,
 		TargetPort: intstr.FromInt(healthCheckPort),
 		Protocol:   corev1.ProtocolTCP,
 	})
+	if u.sniListenerPort != 0 {
+		wantLBPorts = append(wantLBPorts, corev1.ServicePort{
+			Name:       sniPortName,
+			Port:       sniPort,
+			TargetPort: intstr.FromInt(u.sniListenerPort),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
 
 	for _, service := range services.Items {
 		serviceLog := u.log.With("namespace", service.Namespace).With("name", service.Name)
@@ -245,7 +260,7 @@ func setNodePortAndName(portToSet *corev1.ServicePort, lbPorts []corev1.ServiceP
 	// needed because some LB implementations cannot cope with a config change where only the
 	// nodeport differs.
 	// Additionally we have to compare the name directly, because in the case of the healthCheckPort
-	// the NodePort or Port is not part of the name.
+	// and the sniPort the NodePort or Port is not part of the name.
 	oldSchemaName := fmt.Sprintf("%s-%d-%d", portToSet.Name, portToSet.NodePort, portToSet.Port)
 	newSchemaName := fmt.Sprintf("%s-%d", portToSet.Name, portToSet.Port)
 	for _, lbPort := range lbPorts {
@@ -255,7 +270,7 @@ func setNodePortAndName(portToSet *corev1.ServicePort, lbPorts []corev1.ServiceP
 			return
 		}
 	}
-	if portToSet.Name != "healthz" {
+	if portToSet.Name != healthCheckPortName && portToSet.Name != sniPortName {
 		portToSet.Name = fmt.Sprintf("%s-%d", portToSet.Name, portToSet.Port)
 	}
 	// We must reset the NodePort, it is being abused to carry over the port of the target service
@@ -19,6 +19,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/go-test/deep"
@@ -28,7 +29,9 @@ import (
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -41,6 +44,7 @@ func TestReconciliation(t *testing.T) {
 	testCases := []struct {
 		name             string
 		initialServices  []runtime.Object
+		sniListenerPort  int
 		expectedServices corev1.ServiceList
 	}{
 		{
@@ -515,16 +519,64 @@ func TestReconciliation(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "SNI listener gets exposed on port 443",
+			initialServices: []runtime.Object{
+				&corev1.Service{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "v1",
+						Kind:       "Service",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "lb-ns",
+						Name:      "lb",
+					},
+				},
+			},
+			sniListenerPort: 6443,
+			expectedServices: corev1.ServiceList{
+				Items: []corev1.Service{
+					{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: "v1",
+							Kind:       "Service",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:       "lb-ns",
+							Name:            "lb",
+							ResourceVersion: "1",
+						},
+						Spec: corev1.ServiceSpec{
+							Ports: []corev1.ServicePort{
+								{
+									Name:       "healthz",
+									Port:       8002,
+									TargetPort: intstr.FromInt(8002),
+									Protocol:   corev1.ProtocolTCP,
+								},
+								{
+									Name:       "sni",
+									Port:       443,
+									TargetPort: intstr.FromInt(6443),
+									Protocol:   corev1.ProtocolTCP,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			client := fake.NewFakeClient(tc.initialServices...)
 			updater := &LBUpdater{
-				lbNamespace: "lb-ns",
-				lbName:      "lb",
-				client:      client,
-				log:         zap.NewNop().Sugar(),
+				lbNamespace:     "lb-ns",
+				lbName:          "lb",
+				sniListenerPort: tc.sniListenerPort,
+				client:          client,
+				log:             zap.NewNop().Sugar(),
 			}
 
 			if _, err := updater.Reconcile(reconcile.Request{}); err != nil {
@@ -617,3 +669,82 @@ func TestFillNodePortsAndNames(t *testing.T) {
 		})
 	}
 }
+
+func TestSNIClustersDoNotGrowLoadBalancer(t *testing.T) {
+	// genClusterServices returns the services of clusters using the SNI expose strategy, which are
+	// routed on the SNI listener and exposed on the in-cluster LoadBalancer
+	genClusterServices := func(clusters int) []runtime.Object {
+		objects := []runtime.Object{
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "lb-ns", Name: "lb"}},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "lb-ns", Name: "lb-in-cluster"}},
+		}
+		for i := 0; i < clusters; i++ {
+			namespace := fmt.Sprintf("cluster-%d", i)
+			objects = append(objects,
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "apiserver-external",
+						Annotations: map[string]string{
+							"nodeport-proxy.k8s.io/expose-in-cluster": "true",
+							"nodeport-proxy.k8s.io/sni-hostname":      namespace + ".dev.kubermatic.io",
+						},
+					},
+					Spec: corev1.ServiceSpec{
+						ClusterIP: "1.2.3.4",
+						Ports:     []corev1.ServicePort{{Port: int32(30000 + i), NodePort: int32(30000 + i)}},
+					},
+				},
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   namespace,
+						Name:        "openvpn-server",
+						Annotations: map[string]string{"nodeport-proxy.k8s.io/expose-in-cluster": "true"},
+					},
+					Spec: corev1.ServiceSpec{
+						ClusterIP: "1.2.3.5",
+						Ports:     []corev1.ServicePort{{Port: 1194, NodePort: int32(31000 + i)}},
+					},
+				},
+			)
+		}
+		return objects
+	}
+
+	// syncLBPorts reconciles the given LoadBalancer and returns the number of its ports
+	syncLBPorts := func(t *testing.T, client ctrlruntimeclient.Client, lbName, annotationKey string, sniListenerPort int) int {
+		exposeAnnotationKey = annotationKey
+		defer func() { exposeAnnotationKey = defaultExposeAnnotationKey }()
+
+		updater := &LBUpdater{
+			ctx:             context.Background(),
+			lbNamespace:     "lb-ns",
+			lbName:          lbName,
+			sniListenerPort: sniListenerPort,
+			client:          client,
+			log:             zap.NewNop().Sugar(),
+		}
+		if _, err := updater.Reconcile(reconcile.Request{}); err != nil {
+			t.Fatalf("error reconciling: %v", err)
+		}
+
+		lb := &corev1.Service{}
+		if err := client.Get(context.Background(), types.NamespacedName{Namespace: "lb-ns", Name: lbName}, lb); err != nil {
+			t.Fatalf("failed to get LoadBalancer service: %v", err)
+		}
+		return len(lb.Spec.Ports)
+	}
+
+	for _, clusters := range []int{1, 10} {
+		client := fake.NewFakeClient(genClusterServices(clusters)...)
+
+		// Only the healthz and the SNI port
+		if ports := syncLBPorts(t, client, "lb", defaultExposeAnnotationKey, 6443); ports != 2 {
+			t.Errorf("expected the LoadBalancer to have 2 ports with %d SNI clusters, got %d", clusters, ports)
+		}
+		// The healthz port and the apiserver and openVPN port of every cluster
+		if ports := syncLBPorts(t, client, "lb-in-cluster", "nodeport-proxy.k8s.io/expose-in-cluster", 0); ports != 1+2*clusters {
+			t.Errorf("expected the in-cluster LoadBalancer to have %d ports with %d SNI clusters, got %d", 1+2*clusters, clusters, ports)
+		}
+	}
+}
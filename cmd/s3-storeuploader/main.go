@@ -75,6 +75,11 @@ func main() {
 		Name:  "create-bucket",
 		Usage: "creates the bucket if it does not exist yet",
 	}
+	objectFlag := cli.StringFlag{
+		Name:  "object, o",
+		Value: "",
+		Usage: "Name of the object in S3 to download",
+	}
 	maxRevisionsFlag := cli.IntFlag{
 		Name:  "max-revisions",
 		Value: 20,
@@ -114,6 +119,20 @@ func main() {
 				createBucketFlag,
 			},
 		},
+		{
+			Name:   "download",
+			Usage:  "Downloads the given object from S3 to the given file",
+			Action: download,
+			Flags: []cli.Flag{
+				endpointFlag,
+				secureFlag,
+				accessKeyIDFlag,
+				secretAccessKeyFlag,
+				bucketFlag,
+				objectFlag,
+				fileFlag,
+			},
+		},
 		{
 			Name:   "delete-old-revisions",
 			Usage:  "Deletes backups which are older than max-revisions",
@@ -198,6 +217,18 @@ func store(c *cli.Context) error {
 		c.Bool("create-bucket"),
 	)
 }
+func download(c *cli.Context) error {
+	uploader, err := getUploaderFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	return uploader.Download(
+		c.String("bucket"),
+		c.String("object"),
+		c.String("file"),
+	)
+}
 func deleteOldRevisions(c *cli.Context) error {
 	uploader, err := getUploaderFromCtx(c)
 	if err != nil {
@@ -23,11 +23,15 @@ import (
 	"io/ioutil"
 	"time"
 
+	"github.com/kubermatic/kubermatic/pkg/controller/operator/common"
 	"github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/addon"
 	"github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/addoninstaller"
 	backupcontroller "github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/backup"
 	cloudcontroller "github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/cloud"
 	"github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/clustercomponentdefaulter"
+	"github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/etcdmaintenance"
+	"github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/etcdrestore"
+	"github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/hibernation"
 	kubernetescontroller "github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/kubernetes"
 	"github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/monitoring"
 	openshiftcontroller "github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/openshift"
@@ -52,12 +56,15 @@ var AllControllers = map[string]controllerCreator{
 	addon.ControllerName:                          createAddonController,
 	addoninstaller.ControllerName:                 createAddonInstallerController,
 	backupcontroller.ControllerName:               createBackupController,
+	etcdrestore.ControllerName:                    createEtcdRestoreController,
+	etcdmaintenance.ControllerName:                createEtcdMaintenanceController,
 	monitoring.ControllerName:                     createMonitoringController,
 	cloudcontroller.ControllerName:                createCloudController,
 	openshiftcontroller.ControllerName:            createOpenshiftController,
 	clustercomponentdefaulter.ControllerName:      createClusterComponentDefaulter,
 	seedresourcesuptodatecondition.ControllerName: createSeedConditionUpToDateController,
 	rancher.ControllerName:                        createRancherController,
+	hibernation.ControllerName:                    createHibernationController,
 }
 
 type controllerCreator func(*controllerContext) error
@@ -200,6 +207,40 @@ func createBackupController(ctrlCtx *controllerContext) error {
 	)
 }
 
+func createEtcdRestoreController(ctrlCtx *controllerContext) error {
+	// The restore container got added after the backup containers, seed-controller-managers
+	// deployed without it use the default one downloading from the shipped minio
+	var restoreContainer *corev1.Container
+	var err error
+	if ctrlCtx.runOptions.restoreContainerFile == "" {
+		restoreContainer, err = getContainerFromManifest([]byte(common.DefaultBackupRestoreContainer))
+	} else {
+		restoreContainer, err = getContainerFromFile(ctrlCtx.runOptions.restoreContainerFile)
+	}
+	if err != nil {
+		return err
+	}
+	return etcdrestore.Add(
+		ctrlCtx.log,
+		ctrlCtx.mgr,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		*restoreContainer,
+		ctrlCtx.runOptions.backupContainerImage,
+	)
+}
+
+func createEtcdMaintenanceController(ctrlCtx *controllerContext) error {
+	return etcdmaintenance.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.log,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.runOptions.etcdMaintenanceInterval,
+		ctrlCtx.runOptions.etcdDefragmentationThreshold,
+	)
+}
+
 func createMonitoringController(ctrlCtx *controllerContext) error {
 	dockerPullConfigJSON, err := ioutil.ReadFile(ctrlCtx.runOptions.dockerPullConfigJSONFile)
 	if err != nil {
@@ -235,8 +276,12 @@ func getContainerFromFile(path string) (*corev1.Container, error) {
 	if err != nil {
 		return nil, err
 	}
+	return getContainerFromManifest(fileContents)
+}
+
+func getContainerFromManifest(manifest []byte) (*corev1.Container, error) {
 	container := &corev1.Container{}
-	manifestReader := bytes.NewReader(fileContents)
+	manifestReader := bytes.NewReader(manifest)
 	manifestDecoder := yaml.NewYAMLToJSONDecoder(manifestReader)
 	if err := manifestDecoder.Decode(container); err != nil {
 		return nil, err
@@ -261,7 +306,7 @@ func createUpdateController(ctrlCtx *controllerContext) error {
 	}
 
 	return updatecontroller.Add(ctrlCtx.mgr, ctrlCtx.runOptions.workerCount, ctrlCtx.runOptions.workerName, updateManager,
-		ctrlCtx.clientProvider, ctrlCtx.log)
+		ctrlCtx.clientProvider, ctrlCtx.runOptions.upgradeStageTimeout, ctrlCtx.log)
 }
 
 func createAddonController(ctrlCtx *controllerContext) error {
@@ -278,6 +323,7 @@ func createAddonController(ctrlCtx *controllerContext) error {
 		},
 		ctrlCtx.runOptions.kubernetesAddonsPath,
 		ctrlCtx.runOptions.openshiftAddonsPath,
+		ctrlCtx.runOptions.addonChartsPath,
 		ctrlCtx.runOptions.overwriteRegistry,
 		ctrlCtx.runOptions.nodeLocalDNSCacheEnabled(),
 		ctrlCtx.clientProvider,
@@ -300,3 +346,13 @@ func createRancherController(ctrlCtx *controllerContext) error {
 		ctrlCtx.log,
 		ctrlCtx.clientProvider)
 }
+
+func createHibernationController(ctrlCtx *controllerContext) error {
+	return hibernation.Add(
+		ctrlCtx.mgr,
+		ctrlCtx.log,
+		ctrlCtx.runOptions.workerCount,
+		ctrlCtx.runOptions.workerName,
+		ctrlCtx.clientProvider,
+	)
+}
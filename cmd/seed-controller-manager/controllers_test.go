@@ -24,6 +24,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/kubermatic/kubermatic/pkg/controller/operator/common"
 	kubermaticlog "github.com/kubermatic/kubermatic/pkg/log"
 )
 
@@ -69,3 +70,13 @@ spec: bar`,
 	}
 
 }
+
+func TestDefaultRestoreContainer(t *testing.T) {
+	container, err := getContainerFromManifest([]byte(common.DefaultBackupRestoreContainer))
+	if err != nil {
+		t.Fatalf("Failed to parse the default restore container: %v", err)
+	}
+	if container.Name != "restore-container" {
+		t.Errorf("Expected the default restore container to be named restore-container, got %q", container.Name)
+	}
+}
@@ -181,6 +181,7 @@ Please install the VerticalPodAutoscaler according to the documentation: https:/
 				}
 				return restMapperCache.Client(mgr.GetConfig())
 			},
+			mgr.GetAPIReader(),
 			false)
 		if err != nil {
 			log.Fatalw("Failed to get seedValidationWebhookServer", zap.Error(err))
@@ -211,6 +212,8 @@ Please install the VerticalPodAutoscaler according to the documentation: https:/
 	collectors.MustRegisterClusterCollector(prometheus.DefaultRegisterer, ctrlCtx.mgr.GetAPIReader())
 	log.Debug("Starting addons collector")
 	collectors.MustRegisterAddonCollector(prometheus.DefaultRegisterer, ctrlCtx.mgr.GetAPIReader())
+	collectors.MustRegisterClusterLifecycleMetrics(prometheus.DefaultRegisterer)
+	collectors.MustRegisterEtcdMaintenanceMetrics(prometheus.DefaultRegisterer)
 
 	var g run.Group
 	// This group is forever waiting in a goroutine for signals to stop
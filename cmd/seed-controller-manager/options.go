@@ -25,12 +25,15 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/kubermatic/kubermatic/pkg/cluster/client"
 	"github.com/kubermatic/kubermatic/pkg/controller/operator/common"
 	backupcontroller "github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/backup"
+	"github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/etcdmaintenance"
+	updatecontroller "github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/update"
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/features"
 	"github.com/kubermatic/kubermatic/pkg/provider"
@@ -61,10 +64,12 @@ type controllerRunOptions struct {
 	nodeAccessNetwork                                string
 	kubernetesAddonsPath                             string
 	openshiftAddonsPath                              string
+	addonChartsPath                                  string
 	kubernetesAddons                                 kubermaticv1.AddonList
 	openshiftAddons                                  kubermaticv1.AddonList
 	backupContainerFile                              string
 	cleanupContainerFile                             string
+	restoreContainerFile                             string
 	backupContainerImage                             string
 	backupInterval                                   string
 	etcdDiskSize                                     resource.Quantity
@@ -84,6 +89,9 @@ type controllerRunOptions struct {
 	seedValidationHook                               seedvalidation.WebhookOpts
 	concurrentClusterUpdate                          int
 	addonEnforceInterval                             int
+	upgradeStageTimeout                              time.Duration
+	etcdMaintenanceInterval                          time.Duration
+	etcdDefragmentationThreshold                     float64
 
 	// OIDC configuration
 	oidcCAFile             string
@@ -117,12 +125,14 @@ func newControllerRunOptions() (controllerRunOptions, error) {
 	flag.StringVar(&c.nodeAccessNetwork, "node-access-network", kubermaticv1.DefaultNodeAccessNetwork, "A network which allows direct access to nodes via VPN. Uses CIDR notation.")
 	flag.StringVar(&c.kubernetesAddonsPath, "kubernetes-addons-path", "/opt/addons/kubernetes", "Path to addon manifests. Should contain sub-folders for each addon")
 	flag.StringVar(&c.openshiftAddonsPath, "openshift-addons-path", "/opt/addons/openshift", "Path to addon manifests. Should contain sub-folders for each addon")
+	flag.StringVar(&c.addonChartsPath, "addon-charts-path", "/opt/addons/charts", "Path to a local chart repository containing packaged Helm charts for addons which reference a chart version")
 	flag.StringVar(&defaultKubernetesAddonsList, "kubernetes-addons-list", "", "Comma separated list of Addons to install into every user-cluster. Mutually exclusive with `--kubernetes-addons-file`")
 	flag.StringVar(&defaultKubernetesAddonsFile, "kubernetes-addons-file", "", "File that contains a list of default kubernetes addons. Mutually exclusive with `--kubernetes-addons-list`")
 	flag.StringVar(&defaultOpenshiftAddonList, "openshift-addons-list", "", "Comma separated list of addons to install into every openshift user cluster. Mutually exclusive with `--openshift-addons-file`")
 	flag.StringVar(&defaultOpenshiftAddonsFile, "openshift-addons-file", "", "File that contains a list of default openshift addons. Mutually exclusive with `--openshift-addons-list`")
 	flag.StringVar(&c.backupContainerFile, "backup-container", "", fmt.Sprintf("[Required] Filepath of a backup container yaml. It must mount a volume named %s from which it reads the etcd backups", backupcontroller.SharedVolumeName))
 	flag.StringVar(&c.cleanupContainerFile, "cleanup-container", "", "[Required] Filepath of a cleanup container yaml. The container will be used to cleanup the backup directory for a cluster after it got deleted.")
+	flag.StringVar(&c.restoreContainerFile, "restore-container", "", fmt.Sprintf("Filepath of a restore container yaml. It must mount a volume named %s to which it downloads the etcd snapshot named in $BACKUP_TO_RESTORE as snapshot.db. Defaults to a container downloading from the minio shipped with the kubermatic chart", backupcontroller.SharedVolumeName))
 	flag.StringVar(&c.backupContainerImage, "backup-container-init-image", backupcontroller.DefaultBackupContainerImage, "Docker image to use for the init container in the backup job, must be an etcd v3 image. Only set this if your cluster can not use the public quay.io registry")
 	flag.StringVar(&c.backupInterval, "backup-interval", backupcontroller.DefaultBackupInterval, "Interval in which the etcd gets backed up")
 	flag.StringVar(&rawEtcdDiskSize, "etcd-disk-size", "5Gi", "Size for the etcd PV's. Only applies to new clusters.")
@@ -146,6 +156,9 @@ func newControllerRunOptions() (controllerRunOptions, error) {
 	flag.IntVar(&c.schedulerDefaultReplicas, "scheduler-default-replicas", 1, "The default number of replicas for usercluster schedulers")
 	flag.IntVar(&c.concurrentClusterUpdate, "max-parallel-reconcile", 10, "The default number of resources updates per cluster")
 	flag.IntVar(&c.addonEnforceInterval, "addon-enforce-interval", 5, "Check and ensure default usercluster addons are deployed every interval in minutes. Set to 0 to disable.")
+	flag.DurationVar(&c.upgradeStageTimeout, "control-plane-upgrade-stage-timeout", updatecontroller.DefaultUpgradeStageTimeout, "Time a single stage of a control plane upgrade may take to become healthy before the upgrade gets rolled back")
+	flag.DurationVar(&c.etcdMaintenanceInterval, "etcd-maintenance-interval", etcdmaintenance.DefaultInterval, "Interval in which the etcd members of every cluster are checked for fragmentation")
+	flag.Float64Var(&c.etcdDefragmentationThreshold, "etcd-defragmentation-threshold", etcdmaintenance.DefaultFragmentationThreshold, "Share of free space in the database of an etcd member from which on the member gets defragmented")
 	c.seedValidationHook.AddFlags(flag.CommandLine)
 	addFlags(flag.CommandLine)
 	flag.Parse()
@@ -228,6 +241,12 @@ func (o controllerRunOptions) validate() error {
 	if o.schedulerDefaultReplicas < 1 {
 		return fmt.Errorf("--scheduler-default-replicas must be > 0 (was %d)", o.schedulerDefaultReplicas)
 	}
+	if o.etcdMaintenanceInterval <= 0 {
+		return fmt.Errorf("--etcd-maintenance-interval must be > 0 (was %v)", o.etcdMaintenanceInterval)
+	}
+	if o.etcdDefragmentationThreshold <= 0 || o.etcdDefragmentationThreshold >= 1 {
+		return fmt.Errorf("--etcd-defragmentation-threshold must be between 0 and 1 (was %v)", o.etcdDefragmentationThreshold)
+	}
 	if o.concurrentClusterUpdate < 1 {
 		return fmt.Errorf("--max-parallel-reconcile must be > 0 (was %d)", o.concurrentClusterUpdate)
 	}
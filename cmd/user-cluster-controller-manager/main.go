@@ -71,6 +71,7 @@ type controllerRunOptions struct {
 	networks                      networkFlags
 	namespace                     string
 	clusterURL                    string
+	openvpnServerHost             string
 	openvpnServerPort             int
 	overwriteRegistry             string
 	cloudProviderName             string
@@ -100,6 +101,7 @@ func main() {
 	flag.StringVar(&runOp.namespace, "namespace", "", "Namespace in which the cluster is running in")
 	flag.StringVar(&runOp.clusterURL, "cluster-url", "", "Cluster URL")
 	flag.StringVar(&runOp.dnsClusterIP, "dns-cluster-ip", "", "KubeDNS service IP for the cluster")
+	flag.StringVar(&runOp.openvpnServerHost, "openvpn-server-host", "", "OpenVPN server host, defaults to the host of the cluster URL")
 	flag.IntVar(&runOp.openvpnServerPort, "openvpn-server-port", 0, "OpenVPN server port")
 	flag.StringVar(&runOp.overwriteRegistry, "overwrite-registry", "", "registry to use for all images")
 	flag.StringVar(&runOp.cloudProviderName, "cloud-provider-name", "", "Name of the cloudprovider")
@@ -136,6 +138,9 @@ func main() {
 	if runOp.openvpnServerPort == 0 {
 		log.Fatal("-openvpn-server-port must be set")
 	}
+	if runOp.openvpnServerHost == "" {
+		runOp.openvpnServerHost = clusterURL.Hostname()
+	}
 
 	var cloudCredentialSecretTemplate *corev1.Secret
 	if runOp.cloudCredentialSecretTemplate != "" {
@@ -221,6 +226,7 @@ func main() {
 		runOp.namespace,
 		runOp.cloudProviderName,
 		clusterURL,
+		runOp.openvpnServerHost,
 		runOp.openvpnServerPort,
 		healthHandler.AddReadinessCheck,
 		cloudCredentialSecretTemplate,
@@ -33,6 +33,7 @@ import (
 func main() {
 	writeYAML(common.DefaultBackupStoreContainer, "charts/kubermatic/static/store-container.yaml")
 	writeYAML(common.DefaultBackupCleanupContainer, "charts/kubermatic/static/cleanup-container.yaml")
+	writeYAML(common.DefaultBackupRestoreContainer, "charts/kubermatic/static/restore-container.yaml")
 	writeYAML(common.DefaultKubernetesAddons, "charts/kubermatic/static/master/kubernetes-addons.yaml")
 	writeYAML(common.DefaultOpenshiftAddons, "charts/kubermatic/static/master/openshift-addons.yaml")
 	writeJSON(common.DefaultUIConfig, "charts/kubermatic/static/master/ui-config.json")
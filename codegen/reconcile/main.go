@@ -55,6 +55,11 @@ func main() {
 				ImportAlias:  "corev1",
 				// Don't specify ResourceImportPath so this block does not create a new import line in the generated code
 			},
+			{
+				ResourceName: "PersistentVolumeClaim",
+				ImportAlias:  "corev1",
+				// Don't specify ResourceImportPath so this block does not create a new import line in the generated code
+			},
 			{
 				ResourceName: "ServiceAccount",
 				ImportAlias:  "corev1",
@@ -146,6 +151,11 @@ func main() {
 				ImportAlias:        "kubermaticv1",
 				ResourceImportPath: "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1",
 			},
+			{
+				ResourceName:       "AddonConfig",
+				ImportAlias:        "kubermaticv1",
+				ResourceImportPath: "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1",
+			},
 			{
 				ResourceName:       "Certificate",
 				ImportAlias:        "certmanagerv1alpha2",
@@ -0,0 +1,36 @@
+#!/usr/bin/env bash
+
+# Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+set -euo pipefail
+
+cd $(dirname $0)/..
+
+export REGISTRY="${DOCKER_REPO:-quay.io/kubermatic}/s3-storer"
+export TAG=v0.1.6
+
+# The image is versioned independently of Kubermatic, published tags must not change anymore
+if docker manifest inspect $REGISTRY:$TAG > /dev/null 2>&1; then
+  echo "$REGISTRY:$TAG is already published"
+  exit 0
+fi
+
+GOOS=linux GOARCH=amd64 make s3-storeuploader
+
+mv _build/s3-storeuploader cmd/s3-storeuploader/
+cd cmd/s3-storeuploader/
+
+docker build -t $REGISTRY:$TAG .
+docker push $REGISTRY:$TAG
@@ -53,6 +53,9 @@ for ETCD_TAG in $ETCD_TAGS; do
   docker build --build-arg ECTD_VERSION=${ETCD_TAG} -t ${DOCKER_REPO}/etcd-launcher-${BASE_TAG}:${1} -f cmd/etcd-launcher/Dockerfile .
 done
 
+# the backup, cleanup and restore containers use the s3-storer image, it is only pushed when its tag got bumped
+DOCKER_REPO=${DOCKER_REPO} ./hack/publish-s3-storer.sh
+
 # keep a mirror of the EE version in the old repo
 if [ "$KUBERMATIC_EDITION" == "ee" ]; then
   docker tag ${DOCKER_REPO}/kubermatic${REPOSUFFIX}:${1} ${DOCKER_REPO}/api:${1}
@@ -74,6 +74,7 @@ set -x
   -external-url=dev.kubermatic.io \
   -backup-container=charts/kubermatic/static/store-container.yaml \
   -cleanup-container=charts/kubermatic/static/cleanup-container.yaml \
+  -restore-container=charts/kubermatic/static/restore-container.yaml \
   -docker-pull-config-json-file=$DOCKERCONFIGJSON \
   -oidc-issuer-url=$OIDC_ISSUER_URL \
   -oidc-issuer-client-id=$OIDC_ISSUER_CLIENT_ID \
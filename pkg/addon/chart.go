@@ -0,0 +1,780 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"go.uber.org/zap"
+
+	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	chartMetadataFile     = "Chart.yaml"
+	chartRequirementsFile = "requirements.yaml"
+	chartValuesFile       = "values.yaml"
+	chartTemplatesDir     = "templates/"
+	chartChartsDir        = "charts/"
+
+	// chartLibraryType is the type of charts which only provide partials for other charts
+	chartLibraryType = "library"
+	// chartHookAnnotation marks the objects of a chart which Helm only creates at certain points
+	// of the release lifecycle
+	chartHookAnnotation = "helm.sh/hook"
+
+	// KubermaticValuesKey is the key in the chart values under which the information about
+	// the cluster is made available to the chart templates.
+	KubermaticValuesKey = "kubermatic"
+)
+
+// Chart is a chart in the Helm chart format that can be rendered as an addon. Charts are not
+// rendered by Helm, but by a restricted reimplementation of its template engine. Charts using
+// Helm features which are not implemented are rejected instead of being rendered differently,
+// see addons/README.md for the supported subset.
+type Chart struct {
+	Metadata ChartMetadata
+	Values   map[string]interface{}
+	// Templates contains all files from the templates folder, including partials
+	Templates map[string][]byte
+	// Files contains all files that are neither templates nor part of a subchart
+	Files        ChartFiles
+	Dependencies []*Chart
+}
+
+// ChartMetadata is the content of the Chart.yaml. It gets exposed as `.Chart` to the templates.
+type ChartMetadata struct {
+	APIVersion   string            `json:"apiVersion,omitempty"`
+	Name         string            `json:"name"`
+	Version      string            `json:"version"`
+	AppVersion   string            `json:"appVersion,omitempty"`
+	Description  string            `json:"description,omitempty"`
+	Type         string            `json:"type,omitempty"`
+	Dependencies []ChartDependency `json:"dependencies,omitempty"`
+}
+
+// ChartDependency describes a subchart. Only the condition is evaluated, the subchart
+// itself must be part of the charts folder. Aliases, tags and imported values are rejected.
+type ChartDependency struct {
+	Name         string        `json:"name"`
+	Version      string        `json:"version,omitempty"`
+	Condition    string        `json:"condition,omitempty"`
+	Alias        string        `json:"alias,omitempty"`
+	Tags         []string      `json:"tags,omitempty"`
+	ImportValues []interface{} `json:"import-values,omitempty"`
+}
+
+// unsupportedFeature returns the first field of the dependency which is not supported for
+// addon charts.
+func (d ChartDependency) unsupportedFeature() string {
+	switch {
+	case d.Alias != "":
+		return "alias"
+	case len(d.Tags) > 0:
+		return "tags"
+	case len(d.ImportValues) > 0:
+		return "import-values"
+	}
+	return ""
+}
+
+// ChartFiles gives templates access to the non-template files of a chart via `.Files`.
+type ChartFiles map[string][]byte
+
+// Get returns the content of the given file or an empty string if it does not exist.
+func (f ChartFiles) Get(name string) string {
+	return string(f[name])
+}
+
+// GetBytes returns the content of the given file or nil if it does not exist.
+func (f ChartFiles) GetBytes(name string) []byte {
+	return f[name]
+}
+
+// ParseChart renders the Helm chart at chartPath, which is either an unpacked chart folder or a
+// packaged chart archive. The chart values are built from the template data variables and the
+// cluster information, which is available as `.Values.kubermatic`. Namespaced objects without
+// a namespace are put into the release namespace.
+func ParseChart(log *zap.SugaredLogger, overwriteRegistry string, chartPath string, releaseName string, releaseNamespace string, data *TemplateData) ([]runtime.RawExtension, error) {
+	chart, err := LoadChart(chartPath)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{}
+	for k, v := range data.Variables {
+		values[k] = v
+	}
+	values[KubermaticValuesKey] = kubermaticValues(data)
+
+	renderer := &chartRenderer{
+		release: map[string]interface{}{
+			"Name":      releaseName,
+			"Namespace": releaseNamespace,
+			"Service":   "Helm",
+			"IsInstall": true,
+			"IsUpgrade": false,
+			"Revision":  1,
+		},
+		capabilities: map[string]interface{}{},
+	}
+	if version := data.Cluster.Version; version != nil {
+		renderer.capabilities["KubeVersion"] = map[string]interface{}{
+			"Version":    "v" + version.String(),
+			"GitVersion": "v" + version.String(),
+			"Major":      fmt.Sprint(version.Major()),
+			"Minor":      fmt.Sprint(version.Minor()),
+		}
+	}
+	renderer.tpl = template.New(chart.Metadata.Name).Funcs(renderer.funcMap(overwriteRegistry)).Option("missingkey=zero")
+
+	if err := renderer.addChart(chart, chart.Metadata.Name, coalesceValues(values, chart.Values)); err != nil {
+		return nil, err
+	}
+	if err := validateChartTemplates(renderer.tpl); err != nil {
+		return nil, err
+	}
+
+	manifests, err := renderer.render(log)
+	if err != nil {
+		return nil, err
+	}
+	return defaultNamespace(manifests, releaseNamespace)
+}
+
+// clusterScopedKinds are the kinds of the built-in cluster scoped resources. The
+// namespace of these must not be defaulted.
+var clusterScopedKinds = sets.NewString(
+	"APIService",
+	"CertificateSigningRequest",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"ComponentStatus",
+	"CSIDriver",
+	"CSINode",
+	"CustomResourceDefinition",
+	"IngressClass",
+	"MutatingWebhookConfiguration",
+	"Namespace",
+	"Node",
+	"PersistentVolume",
+	"PodSecurityPolicy",
+	"PriorityClass",
+	"RuntimeClass",
+	"StorageClass",
+	"ValidatingWebhookConfiguration",
+	"VolumeAttachment",
+)
+
+// defaultNamespace puts all namespaced objects without a namespace into the release namespace,
+// like Helm does. Besides the built-in cluster scoped kinds, the kinds of cluster scoped
+// CustomResourceDefinitions which are part of the chart are left alone.
+// Hooks are rejected, as addons have no release lifecycle they could be run at.
+func defaultNamespace(manifests []runtime.RawExtension, namespace string) ([]runtime.RawExtension, error) {
+	objects := make([]*metav1unstructured.Unstructured, len(manifests))
+	clusterScoped := sets.NewString(clusterScopedKinds.List()...)
+	for i, manifest := range manifests {
+		object := &metav1unstructured.Unstructured{}
+		if err := object.UnmarshalJSON(manifest.Raw); err != nil {
+			return nil, fmt.Errorf("failed to decode manifest: %v", err)
+		}
+		objects[i] = object
+
+		if hook := object.GetAnnotations()[chartHookAnnotation]; hook != "" {
+			return nil, fmt.Errorf("%s %s is a %s hook, which is not supported for addon charts", object.GetKind(), object.GetName(), hook)
+		}
+
+		if object.GetKind() != "CustomResourceDefinition" {
+			continue
+		}
+		if scope, _, _ := metav1unstructured.NestedString(object.Object, "spec", "scope"); scope == "Cluster" {
+			if kind, _, _ := metav1unstructured.NestedString(object.Object, "spec", "names", "kind"); kind != "" {
+				clusterScoped.Insert(kind)
+			}
+		}
+	}
+
+	for i, object := range objects {
+		if object.GetNamespace() != "" || clusterScoped.Has(object.GetKind()) {
+			continue
+		}
+		object.SetNamespace(namespace)
+		raw, err := object.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode manifest: %v", err)
+		}
+		manifests[i] = runtime.RawExtension{Raw: raw}
+	}
+	return manifests, nil
+}
+
+// LoadChart loads a chart either from an unpacked chart folder or a packaged chart archive.
+func LoadChart(chartPath string) (*Chart, error) {
+	info, err := os.Stat(chartPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var files map[string][]byte
+	if info.IsDir() {
+		files, err = readChartFolder(chartPath)
+	} else {
+		var archive *os.File
+		archive, err = os.Open(chartPath)
+		if err != nil {
+			return nil, err
+		}
+		defer archive.Close()
+		files, err = readChartArchive(archive)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chart %s: %v", chartPath, err)
+	}
+
+	chart, err := newChart(files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart %s: %v", chartPath, err)
+	}
+	return chart, nil
+}
+
+func readChartFolder(dir string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	err := filepath.Walk(dir, func(filename string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		name, err := filepath.Rel(dir, filename)
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(name)] = content
+		return nil
+	})
+	return files, err
+}
+
+// readChartArchive reads a chart packaged by `helm package`. All files in the archive are
+// within a folder named like the chart, which gets stripped from the file names.
+func readChartArchive(r io.Reader) (map[string][]byte, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gzipReader.Close()
+
+	files := map[string][]byte{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(filepath.ToSlash(header.Name))
+		if path.IsAbs(name) || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("invalid file name %q in chart archive", header.Name)
+		}
+		parts := strings.SplitN(name, "/", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("file %q in chart archive is not within the chart folder", header.Name)
+		}
+
+		content, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			return nil, err
+		}
+		files[parts[1]] = content
+	}
+	return files, nil
+}
+
+func newChart(files map[string][]byte) (*Chart, error) {
+	metadataFile, exists := files[chartMetadataFile]
+	if !exists {
+		return nil, fmt.Errorf("%s is missing", chartMetadataFile)
+	}
+
+	chart := &Chart{
+		Values:    map[string]interface{}{},
+		Templates: map[string][]byte{},
+		Files:     ChartFiles{},
+	}
+	if err := yaml.Unmarshal(metadataFile, &chart.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", chartMetadataFile, err)
+	}
+	if chart.Metadata.Name == "" {
+		return nil, fmt.Errorf("%s does not contain a chart name", chartMetadataFile)
+	}
+	// Charts of apiVersion v1 declare their dependencies in a separate file
+	if requirementsFile, exists := files[chartRequirementsFile]; exists {
+		requirements := struct {
+			Dependencies []ChartDependency `json:"dependencies,omitempty"`
+		}{}
+		if err := yaml.Unmarshal(requirementsFile, &requirements); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", chartRequirementsFile, err)
+		}
+		chart.Metadata.Dependencies = append(chart.Metadata.Dependencies, requirements.Dependencies...)
+	}
+	for _, dependency := range chart.Metadata.Dependencies {
+		if feature := dependency.unsupportedFeature(); feature != "" {
+			return nil, fmt.Errorf("dependency %s uses %s, which is not supported for addon charts", dependency.Name, feature)
+		}
+	}
+	if valuesFile, exists := files[chartValuesFile]; exists {
+		if err := yaml.Unmarshal(valuesFile, &chart.Values); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", chartValuesFile, err)
+		}
+		if chart.Values == nil {
+			chart.Values = map[string]interface{}{}
+		}
+	}
+
+	subchartFiles := map[string]map[string][]byte{}
+	for name, content := range files {
+		switch {
+		case name == chartMetadataFile || name == chartRequirementsFile || name == chartValuesFile:
+		case strings.HasPrefix(name, chartTemplatesDir):
+			chart.Templates[name] = content
+		case strings.HasPrefix(name, chartChartsDir):
+			subchartPath := strings.TrimPrefix(name, chartChartsDir)
+			if !strings.Contains(subchartPath, "/") {
+				if strings.HasSuffix(subchartPath, ".tgz") {
+					subchart, err := readChartArchive(bytes.NewReader(content))
+					if err != nil {
+						return nil, fmt.Errorf("failed to read subchart %s: %v", name, err)
+					}
+					subchartFiles[subchartPath] = subchart
+				}
+				continue
+			}
+			parts := strings.SplitN(subchartPath, "/", 2)
+			if subchartFiles[parts[0]] == nil {
+				subchartFiles[parts[0]] = map[string][]byte{}
+			}
+			subchartFiles[parts[0]][parts[1]] = content
+		default:
+			chart.Files[name] = content
+		}
+	}
+
+	for name, files := range subchartFiles {
+		subchart, err := newChart(files)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load subchart %s: %v", name, err)
+		}
+		chart.Dependencies = append(chart.Dependencies, subchart)
+	}
+	sort.Slice(chart.Dependencies, func(i, j int) bool {
+		return chart.Dependencies[i].Metadata.Name < chart.Dependencies[j].Metadata.Name
+	})
+
+	return chart, nil
+}
+
+// kubermaticValues converts the template data into chart values. The credentials and the kubeconfig
+// are deliberately left out, as chart values tend to end up in ConfigMaps or annotations. Charts which
+// need to access the API of the cluster have to use a ServiceAccount inside the cluster.
+func kubermaticValues(data *TemplateData) map[string]interface{} {
+	version := ""
+	if data.Cluster.Version != nil {
+		version = data.Cluster.Version.String()
+	}
+
+	return map[string]interface{}{
+		"seedName":       data.SeedName,
+		"datacenterName": data.DatacenterName,
+		"cluster": map[string]interface{}{
+			"type":                 data.Cluster.Type,
+			"name":                 data.Cluster.Name,
+			"humanReadableName":    data.Cluster.HumanReadableName,
+			"namespace":            data.Cluster.Namespace,
+			"ownerName":            data.Cluster.OwnerName,
+			"ownerEmail":           data.Cluster.OwnerEmail,
+			"labels":               stringMapToValues(data.Cluster.Labels),
+			"annotations":          stringMapToValues(data.Cluster.Annotations),
+			"apiserverExternalURL": data.Cluster.ApiserverExternalURL,
+			"apiserverInternalURL": data.Cluster.ApiserverInternalURL,
+			"cloudProviderName":    data.Cluster.CloudProviderName,
+			"version":              version,
+			"majorMinorVersion":    data.Cluster.MajorMinorVersion,
+			"features":             stringSliceToValues(data.Cluster.Features.List()),
+			"network": map[string]interface{}{
+				"dnsClusterIP":      data.Cluster.Network.DNSClusterIP,
+				"dnsResolverIP":     data.Cluster.Network.DNSResolverIP,
+				"podCIDRBlocks":     stringSliceToValues(data.Cluster.Network.PodCIDRBlocks),
+				"serviceCIDRBlocks": stringSliceToValues(data.Cluster.Network.ServiceCIDRBlocks),
+				"proxyMode":         data.Cluster.Network.ProxyMode,
+			},
+		},
+	}
+}
+
+func stringMapToValues(m map[string]string) map[string]interface{} {
+	values := map[string]interface{}{}
+	for k, v := range m {
+		values[k] = v
+	}
+	return values
+}
+
+func stringSliceToValues(s []string) []interface{} {
+	values := []interface{}{}
+	for _, v := range s {
+		values = append(values, v)
+	}
+	return values
+}
+
+// coalesceValues merges the default values of a chart into the given values, the
+// given values take precedence. Like in Helm, a null value removes a default value.
+func coalesceValues(values, defaults map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for k, v := range defaults {
+		result[k] = v
+	}
+	for k, v := range values {
+		if v == nil {
+			delete(result, k)
+			continue
+		}
+		valueMap, isMap := v.(map[string]interface{})
+		defaultMap, isDefaultMap := result[k].(map[string]interface{})
+		if isMap && isDefaultMap {
+			result[k] = coalesceValues(valueMap, defaultMap)
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// subchartValues returns the values for the given subchart, which are the values under the
+// key of the subchart name plus the global values.
+func subchartValues(parentValues map[string]interface{}, subchart *Chart) map[string]interface{} {
+	values, _ := parentValues[subchart.Metadata.Name].(map[string]interface{})
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	if globals, ok := parentValues["global"].(map[string]interface{}); ok {
+		values = coalesceValues(map[string]interface{}{"global": globals}, values)
+	}
+	return coalesceValues(values, subchart.Values)
+}
+
+// subchartEnabled evaluates the condition of the dependency for the given subchart. Like in
+// Helm, the first condition path that points to a boolean value is used.
+func subchartEnabled(chart *Chart, values map[string]interface{}, subchart *Chart) bool {
+	for _, dependency := range chart.Metadata.Dependencies {
+		if dependency.Name != subchart.Metadata.Name || dependency.Condition == "" {
+			continue
+		}
+		for _, condition := range strings.Split(dependency.Condition, ",") {
+			if enabled, ok := lookupValue(values, strings.TrimSpace(condition)).(bool); ok {
+				return enabled
+			}
+		}
+	}
+	return true
+}
+
+func lookupValue(values map[string]interface{}, valuePath string) interface{} {
+	var current interface{} = values
+	for _, key := range strings.Split(valuePath, ".") {
+		currentMap, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = currentMap[key]
+	}
+	return current
+}
+
+var (
+	// unsupportedChartFunctions are the Helm template functions which are not available to addon
+	// charts, most notably lookup, which would require access to the user cluster
+	unsupportedChartFunctions = sets.NewString("lookup", "toToml", "fromYamlArray", "fromJsonArray")
+	// unsupportedChartFields are the parts of the Helm built-in objects which are not available
+	// to addon charts, by the name of the built-in object
+	unsupportedChartFields = map[string]sets.String{
+		"Files":        sets.NewString("Glob", "AsConfig", "AsSecrets", "Lines"),
+		"Capabilities": sets.NewString("APIVersions", "HelmVersion"),
+	}
+)
+
+// validateChartTemplates rejects charts which use Helm features the addon controller does not
+// support, instead of rendering them differently than Helm would.
+func validateChartTemplates(tpl *template.Template) error {
+	for _, t := range tpl.Templates() {
+		if t.Tree == nil {
+			continue
+		}
+		if feature := unsupportedChartFeature(t.Tree.Root); feature != "" {
+			return fmt.Errorf("template %s uses %s, which is not supported for addon charts", t.Name(), feature)
+		}
+	}
+	return nil
+}
+
+// unsupportedChartFeature returns the first unsupported function or field used within the node
+func unsupportedChartFeature(node parse.Node) string {
+	var children []parse.Node
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return ""
+		}
+		children = n.Nodes
+	case *parse.ActionNode:
+		children = []parse.Node{n.Pipe}
+	case *parse.IfNode:
+		children = []parse.Node{n.Pipe, n.List, n.ElseList}
+	case *parse.RangeNode:
+		children = []parse.Node{n.Pipe, n.List, n.ElseList}
+	case *parse.WithNode:
+		children = []parse.Node{n.Pipe, n.List, n.ElseList}
+	case *parse.TemplateNode:
+		children = []parse.Node{n.Pipe}
+	case *parse.PipeNode:
+		if n == nil {
+			return ""
+		}
+		for _, cmd := range n.Cmds {
+			children = append(children, cmd)
+		}
+	case *parse.CommandNode:
+		children = n.Args
+	case *parse.ChainNode:
+		children = []parse.Node{n.Node}
+	case *parse.IdentifierNode:
+		if unsupportedChartFunctions.Has(n.Ident) {
+			return n.Ident
+		}
+	case *parse.FieldNode:
+		return unsupportedChartField(n.Ident)
+	case *parse.VariableNode:
+		if len(n.Ident) > 0 && n.Ident[0] == "$" {
+			return unsupportedChartField(n.Ident[1:])
+		}
+	}
+
+	for _, child := range children {
+		if feature := unsupportedChartFeature(child); feature != "" {
+			return feature
+		}
+	}
+	return ""
+}
+
+func unsupportedChartField(ident []string) string {
+	if len(ident) < 2 || !unsupportedChartFields[ident[0]].Has(ident[1]) {
+		return ""
+	}
+	return "." + ident[0] + "." + ident[1]
+}
+
+type chartTemplate struct {
+	name     string
+	basePath string
+	chart    *Chart
+	values   map[string]interface{}
+	render   bool
+}
+
+type chartRenderer struct {
+	tpl          *template.Template
+	templates    []chartTemplate
+	release      map[string]interface{}
+	capabilities map[string]interface{}
+}
+
+func (r *chartRenderer) funcMap(overwriteRegistry string) template.FuncMap {
+	funcs := txtFuncMap(overwriteRegistry)
+	funcs["toYaml"] = func(v interface{}) string {
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			// Swallow errors like Helm does, templates should not need to handle them
+			return ""
+		}
+		return strings.TrimSuffix(string(data), "\n")
+	}
+	funcs["fromYaml"] = func(s string) map[string]interface{} {
+		m := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(s), &m); err != nil {
+			m["Error"] = err.Error()
+		}
+		return m
+	}
+	funcs["toJson"] = func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+	funcs["fromJson"] = func(s string) map[string]interface{} {
+		m := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(s), &m); err != nil {
+			m["Error"] = err.Error()
+		}
+		return m
+	}
+	funcs["required"] = func(message string, v interface{}) (interface{}, error) {
+		if v == nil {
+			return nil, errors.New(message)
+		}
+		if s, ok := v.(string); ok && s == "" {
+			return nil, errors.New(message)
+		}
+		return v, nil
+	}
+	// Unsupported functions must be known to the parser, so validateChartTemplates can reject
+	// the chart with a clear error
+	for _, name := range unsupportedChartFunctions.List() {
+		name := name
+		funcs[name] = func(...interface{}) (interface{}, error) {
+			return nil, fmt.Errorf("%s is not supported for addon charts", name)
+		}
+	}
+	funcs["include"] = func(name string, data interface{}) (string, error) {
+		buf := &bytes.Buffer{}
+		if err := r.tpl.ExecuteTemplate(buf, name, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	funcs["tpl"] = func(text string, data interface{}) (string, error) {
+		tpl, err := r.tpl.Clone()
+		if err != nil {
+			return "", err
+		}
+		tpl, err = tpl.New("tpl").Parse(text)
+		if err != nil {
+			return "", err
+		}
+		buf := &bytes.Buffer{}
+		if err := tpl.Execute(buf, data); err != nil {
+			return "", err
+		}
+		return strings.Replace(buf.String(), "<no value>", "", -1), nil
+	}
+	return funcs
+}
+
+// addChart parses all templates of the chart and its enabled subcharts. All charts share the
+// same set of templates, so partials defined in a subchart can be used by the parent chart.
+func (r *chartRenderer) addChart(chart *Chart, basePath string, values map[string]interface{}) error {
+	names := make([]string, 0, len(chart.Templates))
+	for name := range chart.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		templateName := path.Join(basePath, name)
+		if _, err := r.tpl.New(templateName).Parse(string(chart.Templates[name])); err != nil {
+			return fmt.Errorf("failed to parse template %s: %v", templateName, err)
+		}
+		r.templates = append(r.templates, chartTemplate{
+			name:     templateName,
+			basePath: path.Join(basePath, chartTemplatesDir),
+			chart:    chart,
+			values:   values,
+			render:   chart.Metadata.Type != chartLibraryType && !strings.HasPrefix(path.Base(name), "_") && path.Base(name) != "NOTES.txt",
+		})
+	}
+
+	for _, subchart := range chart.Dependencies {
+		if !subchartEnabled(chart, values, subchart) {
+			continue
+		}
+		subchartPath := path.Join(basePath, chartChartsDir, subchart.Metadata.Name)
+		if err := r.addChart(subchart, subchartPath, subchartValues(values, subchart)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *chartRenderer) render(log *zap.SugaredLogger) ([]runtime.RawExtension, error) {
+	var allManifests []runtime.RawExtension
+	for _, t := range r.templates {
+		if !t.render {
+			continue
+		}
+
+		data := map[string]interface{}{
+			"Values":       t.values,
+			"Release":      r.release,
+			"Chart":        t.chart.Metadata,
+			"Capabilities": r.capabilities,
+			"Files":        t.chart.Files,
+			"Template": map[string]interface{}{
+				"Name":     t.name,
+				"BasePath": t.basePath,
+			},
+		}
+
+		buf := &bytes.Buffer{}
+		if err := r.tpl.ExecuteTemplate(buf, t.name, data); err != nil {
+			return nil, fmt.Errorf("failed to execute templating on file %s: %v", t.name, err)
+		}
+
+		content := strings.Replace(buf.String(), "<no value>", "", -1)
+		if len(strings.TrimSpace(content)) == 0 {
+			log.Debugw("Skipping chart template as its empty after parsing", "file", t.name)
+			continue
+		}
+
+		manifests, err := decodeManifests(strings.NewReader(content), t.name)
+		if err != nil {
+			return nil, err
+		}
+		allManifests = append(allManifests, manifests...)
+	}
+	return allManifests, nil
+}
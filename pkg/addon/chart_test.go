@@ -0,0 +1,390 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/resources"
+	"github.com/kubermatic/kubermatic/pkg/semver"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+const exampleChartPath = "testdata/charts/example"
+
+func testChartTemplateData(t *testing.T, variables map[string]interface{}) *TemplateData {
+	cluster := kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "abcd1234",
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Version: *semver.NewSemverOrDie("v1.18.2"),
+			Cloud: kubermaticv1.CloudSpec{
+				DatacenterName: "dc",
+				BringYourOwn:   &kubermaticv1.BringYourOwnCloudSpec{},
+			},
+		},
+	}
+
+	data, err := NewTemplateData(&cluster, resources.Credentials{}, "kubeconfig", "1.2.3.4", "5.6.7.8", variables)
+	if err != nil {
+		t.Fatalf("failed to create template data: %v", err)
+	}
+	return data
+}
+
+func renderedObjects(t *testing.T, manifests []runtime.RawExtension) (map[string]*appsv1.Deployment, map[string]*corev1.ConfigMap) {
+	deployments := map[string]*appsv1.Deployment{}
+	configMaps := map[string]*corev1.ConfigMap{}
+	for _, manifest := range manifests {
+		meta := &metav1.PartialObjectMetadata{}
+		if err := yaml.Unmarshal(manifest.Raw, meta); err != nil {
+			t.Fatalf("failed to decode manifest: %v", err)
+		}
+		switch meta.Kind {
+		case "Deployment":
+			deployment := &appsv1.Deployment{}
+			if err := yaml.Unmarshal(manifest.Raw, deployment); err != nil {
+				t.Fatalf("failed to decode deployment: %v", err)
+			}
+			deployments[deployment.Name] = deployment
+		case "ConfigMap":
+			configMap := &corev1.ConfigMap{}
+			if err := yaml.Unmarshal(manifest.Raw, configMap); err != nil {
+				t.Fatalf("failed to decode configmap: %v", err)
+			}
+			configMaps[configMap.Name] = configMap
+		default:
+			t.Fatalf("unexpected object of kind %q rendered", meta.Kind)
+		}
+	}
+	return deployments, configMaps
+}
+
+func TestParseChart(t *testing.T) {
+	variables := map[string]interface{}{
+		"replicas": 3,
+		"image": map[string]interface{}{
+			"tag": "v1",
+		},
+		"sub": map[string]interface{}{
+			"extra": "{{ .Release.Name }}-extra",
+		},
+	}
+
+	manifests, err := ParseChart(zap.NewNop().Sugar(), "registry.local", exampleChartPath, "my-addon", "kube-system", testChartTemplateData(t, variables))
+	if err != nil {
+		t.Fatalf("failed to render chart: %v", err)
+	}
+
+	deployments, configMaps := renderedObjects(t, manifests)
+	if len(deployments) != 1 || len(configMaps) != 1 {
+		t.Fatalf("expected one deployment and one configmap, got %d deployments and %d configmaps", len(deployments), len(configMaps))
+	}
+
+	deployment := deployments["my-addon"]
+	if deployment == nil {
+		t.Fatal("expected a deployment named like the release")
+	}
+	if deployment.Namespace != "kube-system" {
+		t.Errorf("expected deployment to be rendered for the release namespace, got %q", deployment.Namespace)
+	}
+	if deployment.Labels["app.kubernetes.io/managed-by"] != "Helm" {
+		t.Errorf("expected labels from partial to be rendered, got %v", deployment.Labels)
+	}
+	if *deployment.Spec.Replicas != 3 {
+		t.Errorf("expected variables to overwrite the chart values, got %d replicas", *deployment.Spec.Replicas)
+	}
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	if container.Image != "registry.local/example/example:v1" {
+		t.Errorf("unexpected image %q", container.Image)
+	}
+	expectedArgs := []string{"--cluster=abcd1234", "--kubernetes=v1.18.2"}
+	for i, arg := range expectedArgs {
+		if container.Args[i] != arg {
+			t.Errorf("expected argument %q, got %q", arg, container.Args[i])
+		}
+	}
+
+	configMap := configMaps["my-addon-sub"]
+	if configMap == nil {
+		t.Fatal("expected the subchart configmap to be rendered")
+	}
+	expectedData := map[string]string{
+		"greeting": "hello",
+		"team":     "platform",
+		"extra":    "my-addon-extra",
+	}
+	for key, value := range expectedData {
+		if configMap.Data[key] != value {
+			t.Errorf("expected configmap key %q to be %q, got %q", key, value, configMap.Data[key])
+		}
+	}
+}
+
+func TestKubermaticValuesLeaveOutKubeconfig(t *testing.T) {
+	values := kubermaticValues(testChartTemplateData(t, nil))
+	cluster, ok := values["cluster"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected the cluster values to be set")
+	}
+	if _, exists := cluster["kubeconfig"]; exists {
+		t.Error("expected the kubeconfig to be left out of the chart values")
+	}
+}
+
+func TestParseChartDefaultsNamespace(t *testing.T) {
+	manifests, err := ParseChart(zap.NewNop().Sugar(), "", "testdata/charts/plain", "my-addon", "kube-system", testChartTemplateData(t, nil))
+	if err != nil {
+		t.Fatalf("failed to render chart: %v", err)
+	}
+
+	expectedNamespaces := map[string]string{
+		"ConfigMap":                "kube-system",
+		"ClusterRole":              "",
+		"CustomResourceDefinition": "",
+		"Greeter":                  "",
+		"Service":                  "other",
+	}
+	if len(manifests) != len(expectedNamespaces) {
+		t.Fatalf("expected %d objects, got %d", len(expectedNamespaces), len(manifests))
+	}
+	for _, manifest := range manifests {
+		object := &metav1unstructured.Unstructured{}
+		if err := object.UnmarshalJSON(manifest.Raw); err != nil {
+			t.Fatalf("failed to decode manifest: %v", err)
+		}
+		if expected := expectedNamespaces[object.GetKind()]; object.GetNamespace() != expected {
+			t.Errorf("expected %s to be in namespace %q, got %q", object.GetKind(), expected, object.GetNamespace())
+		}
+	}
+}
+
+func TestParseChartDisablesSubchart(t *testing.T) {
+	variables := map[string]interface{}{
+		"sub": map[string]interface{}{
+			"enabled": false,
+		},
+	}
+
+	manifests, err := ParseChart(zap.NewNop().Sugar(), "", exampleChartPath, "my-addon", "kube-system", testChartTemplateData(t, variables))
+	if err != nil {
+		t.Fatalf("failed to render chart: %v", err)
+	}
+
+	_, configMaps := renderedObjects(t, manifests)
+	if len(configMaps) != 0 {
+		t.Errorf("expected no subchart configmaps to be rendered, got %d", len(configMaps))
+	}
+}
+
+func TestParseChartFromArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "addon-chart")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	archivePath := filepath.Join(dir, "example-1.0.0.tgz")
+	if err := packageChart(exampleChartPath, archivePath); err != nil {
+		t.Fatalf("failed to package chart: %v", err)
+	}
+
+	manifests, err := ParseChart(zap.NewNop().Sugar(), "", archivePath, "my-addon", "kube-system", testChartTemplateData(t, nil))
+	if err != nil {
+		t.Fatalf("failed to render packaged chart: %v", err)
+	}
+
+	deployments, configMaps := renderedObjects(t, manifests)
+	if len(deployments) != 1 || len(configMaps) != 1 {
+		t.Fatalf("expected one deployment and one configmap, got %d deployments and %d configmaps", len(deployments), len(configMaps))
+	}
+	if image := deployments["my-addon"].Spec.Template.Spec.Containers[0].Image; image != "quay.io/example/example:2.3.4" {
+		t.Errorf("expected the app version to be used as default image tag, got %q", image)
+	}
+}
+
+// writeTestChart writes the given chart files into a temporary directory, which must be removed by the caller
+func writeTestChart(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "addon-chart")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+
+	for name, content := range files {
+		filename := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestParseChartRequiredValue(t *testing.T) {
+	dir := writeTestChart(t, map[string]string{
+		"Chart.yaml":               "name: required\nversion: 0.1.0\n",
+		"templates/configmap.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ required \"name is required\" .Values.name }}\n",
+	})
+	defer os.RemoveAll(dir)
+
+	if _, err := ParseChart(zap.NewNop().Sugar(), "", dir, "my-addon", "kube-system", testChartTemplateData(t, nil)); err == nil {
+		t.Error("expected rendering to fail when a required value is missing")
+	}
+}
+
+func TestParseChartRejectsUnsupportedFeatures(t *testing.T) {
+	testCases := []struct {
+		name            string
+		template        string
+		expectedFeature string
+	}{
+		{
+			name:            "lookup",
+			template:        "{{ if lookup \"v1\" \"ConfigMap\" \"kube-system\" \"test\" }}{{ end }}",
+			expectedFeature: "lookup",
+		},
+		{
+			name:            "API versions within a partial",
+			template:        "{{ define \"test.apiVersion\" }}{{ if .Capabilities.APIVersions.Has \"apps/v1\" }}apps/v1{{ end }}{{ end }}",
+			expectedFeature: ".Capabilities.APIVersions",
+		},
+		{
+			name:            "files glob through the root variable",
+			template:        "{{ range $name, $_ := .Values.files }}{{ ($.Files.Glob $name).AsConfig }}{{ end }}",
+			expectedFeature: ".Files.Glob",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeTestChart(t, map[string]string{
+				"Chart.yaml":          "name: unsupported\nversion: 0.1.0\n",
+				"templates/test.yaml": tc.template,
+			})
+			defer os.RemoveAll(dir)
+
+			_, err := ParseChart(zap.NewNop().Sugar(), "", dir, "my-addon", "kube-system", testChartTemplateData(t, nil))
+			if err == nil || !strings.Contains(err.Error(), "uses "+tc.expectedFeature+",") {
+				t.Errorf("expected the chart to be rejected for using %s, got %v", tc.expectedFeature, err)
+			}
+		})
+	}
+}
+
+func TestParseChartRejectsUnsupportedChartContent(t *testing.T) {
+	testCases := []struct {
+		name          string
+		files         map[string]string
+		expectedError string
+	}{
+		{
+			name: "hook",
+			files: map[string]string{
+				"Chart.yaml":         "name: unsupported\nversion: 0.1.0\n",
+				"templates/job.yaml": "apiVersion: batch/v1\nkind: Job\nmetadata:\n  name: migrate\n  annotations:\n    helm.sh/hook: pre-install\n",
+			},
+			expectedError: "Job migrate is a pre-install hook",
+		},
+		{
+			name: "dependency alias",
+			files: map[string]string{
+				"Chart.yaml":            "apiVersion: v2\nname: unsupported\nversion: 0.1.0\ndependencies:\n- name: sub\n  alias: other\n",
+				"charts/sub/Chart.yaml": "name: sub\nversion: 0.1.0\n",
+			},
+			expectedError: "dependency sub uses alias",
+		},
+		{
+			name: "dependency tags in requirements.yaml",
+			files: map[string]string{
+				"Chart.yaml":            "name: unsupported\nversion: 0.1.0\n",
+				"requirements.yaml":     "dependencies:\n- name: sub\n  tags:\n  - optional\n",
+				"charts/sub/Chart.yaml": "name: sub\nversion: 0.1.0\n",
+			},
+			expectedError: "dependency sub uses tags",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeTestChart(t, tc.files)
+			defer os.RemoveAll(dir)
+
+			_, err := ParseChart(zap.NewNop().Sugar(), "", dir, "my-addon", "kube-system", testChartTemplateData(t, nil))
+			if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+				t.Errorf("expected the chart to be rejected with %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+// packageChart creates a chart archive the same way `helm package` does.
+func packageChart(chartPath, archivePath string) error {
+	archive, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	gzipWriter := gzip.NewWriter(archive)
+	defer gzipWriter.Close()
+	tarWriter := tar.NewWriter(gzipWriter)
+	defer tarWriter.Close()
+
+	return filepath.Walk(chartPath, func(filename string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(chartPath, filename)
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		header := &tar.Header{
+			Name:     filepath.ToSlash(filepath.Join("example", name)),
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err = tarWriter.Write(content)
+		return err
+	})
+}
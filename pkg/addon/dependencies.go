@@ -0,0 +1,224 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DependencyGraph maps the name of an addon to the names of the addons it depends on.
+type DependencyGraph map[string][]string
+
+// GetDependencies returns the names of the addons the given addon depends on, based on
+// its AddonConfig. Addons without an AddonConfig do not have any dependencies.
+func GetDependencies(ctx context.Context, client ctrlruntimeclient.Reader, addonName string) ([]string, error) {
+	config := &kubermaticv1.AddonConfig{}
+	if err := client.Get(ctx, types.NamespacedName{Name: addonName}, config); err != nil {
+		if kerrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get AddonConfig %s: %v", addonName, err)
+	}
+	return config.Spec.Dependencies, nil
+}
+
+// GetDependencyGraph returns the dependencies of all addons which have an AddonConfig.
+func GetDependencyGraph(ctx context.Context, client ctrlruntimeclient.Reader) (DependencyGraph, error) {
+	configs := &kubermaticv1.AddonConfigList{}
+	if err := client.List(ctx, configs); err != nil {
+		if meta.IsNoMatchError(err) {
+			return DependencyGraph{}, nil
+		}
+		return nil, fmt.Errorf("failed to list AddonConfigs: %v", err)
+	}
+
+	graph := DependencyGraph{}
+	for _, config := range configs.Items {
+		if len(config.Spec.Dependencies) > 0 {
+			graph[config.Name] = config.Spec.Dependencies
+		}
+	}
+	return graph, nil
+}
+
+// Dependents returns the names of all addons which directly depend on the given addon.
+func (g DependencyGraph) Dependents(addonName string) []string {
+	var dependents []string
+	for name, dependencies := range g {
+		for _, dependency := range dependencies {
+			if dependency == addonName {
+				dependents = append(dependents, name)
+				break
+			}
+		}
+	}
+	return dependents
+}
+
+// Sort orders the given addon names so that every addon comes after all of its dependencies.
+// Addons without an order between them keep their relative order. Dependencies which are not
+// part of the given names are ignored. Addons which are part of a dependency cycle can not be
+// ordered, they are left out of the sorted names and returned separately.
+func (g DependencyGraph) Sort(names []string) (sorted []string, cyclic []string) {
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = true
+	}
+	inCycle := g.cycles(names, wanted)
+
+	sorted = make([]string, 0, len(names))
+	visited := map[string]bool{}
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+		for _, dependency := range g[name] {
+			if wanted[dependency] && !inCycle[dependency] {
+				visit(dependency)
+			}
+		}
+		sorted = append(sorted, name)
+	}
+
+	for _, name := range names {
+		if inCycle[name] {
+			cyclic = append(cyclic, name)
+			continue
+		}
+		visit(name)
+	}
+	return sorted, cyclic
+}
+
+// cycles returns the addons among the given names which are part of a dependency cycle, using
+// Tarjan's algorithm to find the strongly connected components of the graph.
+func (g DependencyGraph) cycles(names []string, wanted map[string]bool) map[string]bool {
+	var (
+		counter int
+		stack   []string
+	)
+	index := map[string]int{}
+	lowlink := map[string]int{}
+	onStack := map[string]bool{}
+	inCycle := map[string]bool{}
+
+	var connect func(name string)
+	connect = func(name string) {
+		index[name] = counter
+		lowlink[name] = counter
+		counter++
+		stack = append(stack, name)
+		onStack[name] = true
+
+		for _, dependency := range g[name] {
+			if !wanted[dependency] {
+				continue
+			}
+			if dependency == name {
+				inCycle[name] = true
+			}
+			if _, seen := index[dependency]; !seen {
+				connect(dependency)
+				if lowlink[dependency] < lowlink[name] {
+					lowlink[name] = lowlink[dependency]
+				}
+			} else if onStack[dependency] && index[dependency] < lowlink[name] {
+				lowlink[name] = index[dependency]
+			}
+		}
+
+		if lowlink[name] != index[name] {
+			return
+		}
+		var component []string
+		for {
+			member := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[member] = false
+			component = append(component, member)
+			if member == name {
+				break
+			}
+		}
+		if len(component) > 1 {
+			for _, member := range component {
+				inCycle[member] = true
+			}
+		}
+	}
+
+	for _, name := range names {
+		if _, seen := index[name]; !seen {
+			connect(name)
+		}
+	}
+	return inCycle
+}
+
+// ValidateDependencies validates the dependencies of the AddonConfig of the given addon against the
+// dependencies of all other addons. An addon must neither depend on itself, nor on another addon
+// twice, nor be part of a dependency cycle.
+func (g DependencyGraph) ValidateDependencies(addonName string, dependencies []string) error {
+	seen := map[string]bool{}
+	for _, dependency := range dependencies {
+		if dependency == "" {
+			return errors.New("the name of a dependency must not be empty")
+		}
+		if dependency == addonName {
+			return fmt.Errorf("addon %s must not depend on itself", addonName)
+		}
+		if seen[dependency] {
+			return fmt.Errorf("addon %s depends on %s more than once", addonName, dependency)
+		}
+		seen[dependency] = true
+	}
+
+	graph := DependencyGraph{}
+	names := []string{addonName}
+	for name, nameDependencies := range g {
+		if name != addonName {
+			graph[name] = nameDependencies
+			names = append(names, name)
+		}
+	}
+	graph[addonName] = dependencies
+	for _, dependency := range dependencies {
+		if _, ok := graph[dependency]; !ok {
+			names = append(names, dependency)
+		}
+	}
+
+	// Cycles between other addons are not caused by this addon and must not block changes of it
+	_, cyclic := graph.Sort(names)
+	for _, name := range cyclic {
+		if name == addonName {
+			return fmt.Errorf("the dependencies of addon %s contain a cycle", addonName)
+		}
+	}
+	return nil
+}
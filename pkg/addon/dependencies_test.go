@@ -0,0 +1,183 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDependencyGraphSort(t *testing.T) {
+	testCases := []struct {
+		name           string
+		graph          DependencyGraph
+		names          []string
+		expectedNames  []string
+		expectedCyclic []string
+	}{
+		{
+			name:          "no dependencies keep their order",
+			graph:         DependencyGraph{},
+			names:         []string{"canal", "dns", "rbac"},
+			expectedNames: []string{"canal", "dns", "rbac"},
+		},
+		{
+			name: "dependencies come first",
+			graph: DependencyGraph{
+				"ingress":      {"cert-manager"},
+				"cert-manager": {"rbac"},
+			},
+			names:         []string{"ingress", "canal", "cert-manager", "rbac"},
+			expectedNames: []string{"rbac", "cert-manager", "ingress", "canal"},
+		},
+		{
+			name: "dependencies which are not part of the names are ignored",
+			graph: DependencyGraph{
+				"ingress": {"cert-manager"},
+			},
+			names:         []string{"ingress", "canal"},
+			expectedNames: []string{"ingress", "canal"},
+		},
+		{
+			name: "only the addons of a cycle are left out",
+			graph: DependencyGraph{
+				"a":       {"b"},
+				"b":       {"c"},
+				"c":       {"a"},
+				"ingress": {"cert-manager"},
+			},
+			names:          []string{"a", "ingress", "b", "c", "cert-manager"},
+			expectedNames:  []string{"cert-manager", "ingress"},
+			expectedCyclic: []string{"a", "b", "c"},
+		},
+		{
+			name: "addons depending on a cycle are still sorted",
+			graph: DependencyGraph{
+				"a":   {"b"},
+				"b":   {"a"},
+				"dns": {"a", "rbac"},
+			},
+			names:          []string{"dns", "a", "b", "rbac"},
+			expectedNames:  []string{"rbac", "dns"},
+			expectedCyclic: []string{"a", "b"},
+		},
+		{
+			name: "an addon depending on itself is a cycle",
+			graph: DependencyGraph{
+				"a": {"a"},
+			},
+			names:          []string{"a", "b"},
+			expectedNames:  []string{"b"},
+			expectedCyclic: []string{"a"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sorted, cyclic := tc.graph.Sort(tc.names)
+			if !reflect.DeepEqual(sorted, tc.expectedNames) {
+				t.Errorf("expected order %v, got %v", tc.expectedNames, sorted)
+			}
+			if !reflect.DeepEqual(cyclic, tc.expectedCyclic) {
+				t.Errorf("expected cyclic addons %v, got %v", tc.expectedCyclic, cyclic)
+			}
+		})
+	}
+}
+
+func TestDependencyGraphValidateDependencies(t *testing.T) {
+	graph := DependencyGraph{
+		"ingress":  {"cert-manager"},
+		"registry": {"ingress"},
+		"a":        {"b"},
+		"b":        {"a"},
+	}
+
+	testCases := []struct {
+		name         string
+		addonName    string
+		dependencies []string
+		expectedErr  bool
+	}{
+		{
+			name:         "new addon with existing dependencies",
+			addonName:    "monitoring",
+			dependencies: []string{"ingress", "cert-manager"},
+		},
+		{
+			name:         "dependency on an addon without AddonConfig",
+			addonName:    "cert-manager",
+			dependencies: []string{"rbac"},
+		},
+		{
+			name:         "dependency on itself",
+			addonName:    "monitoring",
+			dependencies: []string{"monitoring"},
+			expectedErr:  true,
+		},
+		{
+			name:         "duplicate dependency",
+			addonName:    "monitoring",
+			dependencies: []string{"ingress", "ingress"},
+			expectedErr:  true,
+		},
+		{
+			name:         "empty dependency",
+			addonName:    "monitoring",
+			dependencies: []string{""},
+			expectedErr:  true,
+		},
+		{
+			name:         "dependency closing a cycle",
+			addonName:    "cert-manager",
+			dependencies: []string{"registry"},
+			expectedErr:  true,
+		},
+		{
+			name:         "existing cycles of other addons are not blocking",
+			addonName:    "ingress",
+			dependencies: []string{"cert-manager", "rbac"},
+		},
+		{
+			name:         "removing the dependency of a cycle",
+			addonName:    "a",
+			dependencies: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := graph.ValidateDependencies(tc.addonName, tc.dependencies)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error to be %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestDependencyGraphDependents(t *testing.T) {
+	graph := DependencyGraph{
+		"ingress":  {"cert-manager"},
+		"registry": {"cert-manager", "ingress"},
+		"canal":    {"rbac"},
+	}
+
+	dependents := graph.Dependents("cert-manager")
+	if len(dependents) != 2 {
+		t.Errorf("expected ingress and registry to depend on cert-manager, got %v", dependents)
+	}
+}
@@ -220,32 +220,45 @@ func ParseFromFolder(log *zap.SugaredLogger, overwriteRegistry string, manifestP
 			continue
 		}
 
-		reader := kyaml.NewYAMLReader(bufio.NewReader(bufferAll))
-		for {
-			b, err := reader.Read()
-			if err != nil {
-				if err == io.EOF {
-					break
-				}
-				return nil, fmt.Errorf("failed reading from YAML reader for file %s: %v", filename, err)
-			}
-			b = bytes.TrimSpace(b)
-			if len(b) == 0 {
-				continue
-			}
-			decoder := kyaml.NewYAMLToJSONDecoder(bytes.NewBuffer(b))
-			raw := runtime.RawExtension{}
-			if err := decoder.Decode(&raw); err != nil {
-				return nil, fmt.Errorf("decoding failed for file %s: %v", filename, err)
-			}
-			if len(raw.Raw) == 0 {
-				// This can happen if the manifest contains only comments, e.G. because it comes from Helm
-				// something like `# Source: istio/charts/galley/templates/validatingwebhookconfiguration.yaml.tpl`
-				continue
-			}
-			allManifests = append(allManifests, raw)
+		manifests, err := decodeManifests(bufferAll, filename)
+		if err != nil {
+			return nil, err
 		}
+		allManifests = append(allManifests, manifests...)
 	}
 
 	return allManifests, nil
 }
+
+// decodeManifests splits the given multi-document YAML into its objects, skipping empty documents.
+func decodeManifests(r io.Reader, filename string) ([]runtime.RawExtension, error) {
+	var manifests []runtime.RawExtension
+
+	reader := kyaml.NewYAMLReader(bufio.NewReader(r))
+	for {
+		b, err := reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("failed reading from YAML reader for file %s: %v", filename, err)
+		}
+		b = bytes.TrimSpace(b)
+		if len(b) == 0 {
+			continue
+		}
+		decoder := kyaml.NewYAMLToJSONDecoder(bytes.NewBuffer(b))
+		raw := runtime.RawExtension{}
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("decoding failed for file %s: %v", filename, err)
+		}
+		if len(raw.Raw) == 0 {
+			// This can happen if the manifest contains only comments, e.G. because it comes from Helm
+			// something like `# Source: istio/charts/galley/templates/validatingwebhookconfiguration.yaml.tpl`
+			continue
+		}
+		manifests = append(manifests, raw)
+	}
+
+	return manifests, nil
+}
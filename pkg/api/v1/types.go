@@ -101,9 +101,21 @@ type DatacenterSpec struct {
 	// ignoring cluster-specific settings.
 	EnforceAuditLogging bool `json:"enforceAuditLogging"`
 
+	// AuditLogging contains the audit policy and sink used by clusters within the DC which have
+	// audit logging enabled but do not configure their own policy or sink.
+	AuditLogging *kubermaticv1.AuditLoggingSettings `json:"auditLogging,omitempty"`
+
 	// EnforcePodSecurityPolicy enforces pod security policy plugin on every clusters within the DC,
 	// ignoring cluster-specific settings
 	EnforcePodSecurityPolicy bool `json:"enforcePodSecurityPolicy"`
+
+	// AlternativeDatacenters are equivalent datacenters of other seeds, new clusters are created
+	// in one of them when the seed of this datacenter is full.
+	AlternativeDatacenters []string `json:"alternativeDatacenters,omitempty"`
+
+	// OperatingSystemImages is the catalog of operating system images node deployments in this
+	// datacenter can pick from.
+	OperatingSystemImages []kubermaticv1.OperatingSystemImage `json:"operatingSystemImages,omitempty"`
 }
 
 // DatacenterList represents a list of datacenters
@@ -458,8 +470,22 @@ type ServiceAccount struct {
 type PublicServiceAccountToken struct {
 	ObjectMeta
 	// Expiry is a timestamp representing the time when this token will expire.
+	// It can be set when the token is created, tokens expire after three years by default.
 	// swagger:strfmt date-time
 	Expiry Time `json:"expiry,omitempty"`
+	// Scopes restrict the requests the token can be used for, they can only be set when the token is created.
+	// A token without scopes can be used for all requests its service account is allowed to make.
+	Scopes []ServiceAccountTokenScope `json:"scopes,omitempty"`
+}
+
+// ServiceAccountTokenScope allows a token to be used for the given HTTP methods of an endpoint
+// swagger:model ServiceAccountTokenScope
+type ServiceAccountTokenScope struct {
+	// Methods are the allowed HTTP methods, e.g. GET, all methods are allowed when empty
+	Methods []string `json:"methods,omitempty"`
+	// Endpoint is the path template of the endpoint, e.g. /api/v1/projects/{project_id}/clusters,
+	// a trailing * matches all endpoints with the given prefix, all endpoints are allowed when empty
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 // ServiceAccountToken represent an API service account token
@@ -479,6 +505,49 @@ type Project struct {
 	// Owners an optional owners list for the given project
 	Owners         []User `json:"owners,omitempty"`
 	ClustersNumber int    `json:"clustersNumber,omitempty"`
+	// Quota limits the resources the clusters of the project can consume, it can only be changed by admins
+	Quota *ProjectQuota `json:"quota,omitempty"`
+	// Usage is the resource usage of the project which was observed last
+	Usage *ProjectResourceUsage `json:"usage,omitempty"`
+	// OIDCGroups grant the members of groups of the identity provider access to the project, they can only be changed by owners
+	OIDCGroups []ProjectOIDCGroup `json:"oidcGroups,omitempty"`
+}
+
+// ProjectOIDCGroup maps the members of an OIDC group to a project role
+// swagger:model ProjectOIDCGroup
+type ProjectOIDCGroup struct {
+	// Name is the name of the group as it appears in the groups claim of the token
+	Name string `json:"name"`
+	// Role is the name of the built-in or custom project role the members are mapped to
+	Role string `json:"role"`
+}
+
+// ProjectQuota limits the resources of a project, a limit which is not set is not enforced.
+// An empty quota removes the quota of the project.
+// swagger:model ProjectQuota
+type ProjectQuota struct {
+	// Clusters is the maximum number of clusters
+	Clusters *int64 `json:"clusters,omitempty"`
+	// Nodes is the maximum number of nodes of all node deployments
+	Nodes *int64 `json:"nodes,omitempty"`
+	// CPU is the maximum amount of CPU of all node deployments, e.g. "32" or "500m"
+	CPU string `json:"cpu,omitempty"`
+	// Memory is the maximum amount of memory of all node deployments, e.g. "64Gi"
+	Memory string `json:"memory,omitempty"`
+}
+
+// ProjectResourceUsage is the resource usage of a project
+// swagger:model ProjectResourceUsage
+type ProjectResourceUsage struct {
+	Clusters int64  `json:"clusters"`
+	Nodes    int64  `json:"nodes"`
+	CPU      string `json:"cpu"`
+	Memory   string `json:"memory"`
+	// UnknownSizeNodes is the number of nodes whose size can not be determined, they are
+	// neither part of the CPU nor the memory
+	UnknownSizeNodes int64 `json:"unknownSizeNodes,omitempty"`
+	// LastUpdated is the time when the usage was observed to have changed
+	LastUpdated Time `json:"lastUpdated"`
 }
 
 // Kubeconfig is a clusters kubeconfig
@@ -612,6 +681,30 @@ type CreateClusterSpec struct {
 	NodeDeployment *NodeDeployment `json:"nodeDeployment,omitempty"`
 }
 
+// ClusterTemplate represents a template to create clusters with the same specification
+// swagger:model ClusterTemplate
+type ClusterTemplate struct {
+	ObjectMeta
+
+	// Scope is either "project" or "global", global templates can be used in all projects
+	Scope string `json:"scope"`
+	// ProjectID is the ID of the project the template belongs to, it is empty for global templates
+	ProjectID string `json:"projectID,omitempty"`
+
+	// Cluster is the cluster which gets created from the template, it must not contain cloud credentials.
+	// Use a preset through its credential field instead.
+	Cluster Cluster `json:"cluster"`
+	// NodeDeployments are the initial node deployments of the clusters
+	NodeDeployments []NodeDeployment `json:"nodeDeployments,omitempty"`
+}
+
+// ClusterTemplateInstances defines the clusters which get created from a template
+// swagger:model ClusterTemplateInstances
+type ClusterTemplateInstances struct {
+	// Names are the names of the clusters, one cluster gets created for every name
+	Names []string `json:"names"`
+}
+
 const (
 	// OpenShiftClusterType defines the OpenShift cluster type
 	OpenShiftClusterType string = "openshift"
@@ -665,6 +758,19 @@ type ClusterSpec struct {
 	// AuditLogging
 	AuditLogging *kubermaticv1.AuditLoggingSettings `json:"auditLogging,omitempty"`
 
+	// EtcdBackup optionally overrides the default etcd backup schedule and retention
+	EtcdBackup *kubermaticv1.EtcdBackupSettings `json:"etcdBackup,omitempty"`
+
+	// Hibernation optionally scales the control plane and the nodes to zero, either until it
+	// gets disabled or during the given schedules
+	Hibernation *kubermaticv1.HibernationSettings `json:"hibernation,omitempty"`
+
+	// APIServerAllowedSourceRanges optionally restricts the CIDRs the apiserver is reachable from.
+	// The addresses of the nodes, the machine networks and the egress ranges of the seed are always
+	// allowed. Nodes which connect through a NAT gateway do so from its address, which must be part
+	// of the ranges.
+	APIServerAllowedSourceRanges []string `json:"apiServerAllowedSourceRanges,omitempty"`
+
 	// Openshift holds all openshift-specific settings
 	Openshift *kubermaticv1.Openshift `json:"openshift,omitempty"`
 }
@@ -682,6 +788,9 @@ func (cs *ClusterSpec) MarshalJSON() ([]byte, error) {
 		UsePodNodeSelectorAdmissionPlugin   bool                                   `json:"usePodNodeSelectorAdmissionPlugin,omitempty"`
 		AuditLogging                        *kubermaticv1.AuditLoggingSettings     `json:"auditLogging,omitempty"`
 		AdmissionPlugins                    []string                               `json:"admissionPlugins,omitempty"`
+		EtcdBackup                          *kubermaticv1.EtcdBackupSettings       `json:"etcdBackup,omitempty"`
+		Hibernation                         *kubermaticv1.HibernationSettings      `json:"hibernation,omitempty"`
+		APIServerAllowedSourceRanges        []string                               `json:"apiServerAllowedSourceRanges,omitempty"`
 	}{
 		Cloud: PublicCloudSpec{
 			DatacenterName: cs.Cloud.DatacenterName,
@@ -706,6 +815,9 @@ func (cs *ClusterSpec) MarshalJSON() ([]byte, error) {
 		UsePodNodeSelectorAdmissionPlugin:   cs.UsePodNodeSelectorAdmissionPlugin,
 		AuditLogging:                        cs.AuditLogging,
 		AdmissionPlugins:                    cs.AdmissionPlugins,
+		EtcdBackup:                          cs.EtcdBackup,
+		Hibernation:                         cs.Hibernation,
+		APIServerAllowedSourceRanges:        cs.APIServerAllowedSourceRanges,
 	})
 
 	return ret, err
@@ -886,6 +998,9 @@ type ClusterStatus struct {
 
 	// URL specifies the address at which the cluster is available
 	URL string `json:"url"`
+
+	// Hibernation is the hibernation phase of the cluster, it is empty if the cluster is not hibernated
+	Hibernation kubermaticv1.HibernationPhase `json:"hibernation,omitempty"`
 }
 
 // ClusterHealth stores health information about the cluster's components.
@@ -1041,6 +1156,11 @@ type NodeSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 	// List of taints to set on new nodes
 	Taints []TaintSpec `json:"taints,omitempty"`
+	// Name of an image from the operating system image catalog of the datacenter. It takes
+	// precedence over the image set in the cloud spec. If neither is set, the default image
+	// of the operating system from the catalog is used.
+	// required: false
+	OperatingSystemImage string `json:"operatingSystemImage,omitempty"`
 }
 
 // DigitaloceanNodeSpec digitalocean node settings
@@ -1312,6 +1432,40 @@ type NodeDeployment struct {
 
 	Spec   NodeDeploymentSpec               `json:"spec"`
 	Status v1alpha1.MachineDeploymentStatus `json:"status"`
+	// AutoscalerStatus is the status reported by the cluster-autoscaler, it is only set
+	// for node deployments with autoscaling bounds
+	AutoscalerStatus *NodeDeploymentAutoscalerStatus `json:"autoscalerStatus,omitempty"`
+	// Warnings point out problems which do not break the node deployment yet, e.g. that it
+	// uses a deprecated operating system image
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// NodeDeploymentAutoscalerStatus is the status of the cluster-autoscaler for a node deployment
+// swagger:model NodeDeploymentAutoscalerStatus
+type NodeDeploymentAutoscalerStatus struct {
+	Health    *AutoscalerCondition `json:"health,omitempty"`
+	ScaleUp   *AutoscalerCondition `json:"scaleUp,omitempty"`
+	ScaleDown *AutoscalerCondition `json:"scaleDown,omitempty"`
+	// LastScaleEvent is the last event of the cluster-autoscaler which scaled the node deployment
+	LastScaleEvent *AutoscalerScaleEvent `json:"lastScaleEvent,omitempty"`
+}
+
+// AutoscalerCondition is a condition the cluster-autoscaler reports for a node deployment
+// swagger:model AutoscalerCondition
+type AutoscalerCondition struct {
+	// Status is the short status, e.g. Healthy, NoActivity or InProgress
+	Status string `json:"status"`
+	// Message contains the details of the status
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime Time   `json:"lastTransitionTime,omitempty"`
+}
+
+// AutoscalerScaleEvent is an event of the cluster-autoscaler which scaled a node deployment
+// swagger:model AutoscalerScaleEvent
+type AutoscalerScaleEvent struct {
+	Time    Time   `json:"time"`
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
 }
 
 // NodeDeploymentSpec node deployment specification
@@ -1325,6 +1479,14 @@ type NodeDeploymentSpec struct {
 	Paused *bool `json:"paused,omitempty"`
 	// required: false
 	DynamicConfig *bool `json:"dynamicConfig,omitempty"`
+	// MinReplicas is the lower bound the cluster-autoscaler scales the node deployment to.
+	// It has to be set together with MaxReplicas.
+	// required: false
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper bound the cluster-autoscaler scales the node deployment to.
+	// It has to be set together with MinReplicas.
+	// required: false
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
 }
 
 // Event is a report of an event somewhere in the cluster.
@@ -1489,6 +1651,71 @@ type AdmissionPlugin struct {
 	FromVersion *ksemver.Semver `json:"fromVersion,omitempty"`
 }
 
+// ProjectRole represents a role members of a project can be assigned to, in addition to owners, editors and viewers
+// swagger:model ProjectRole
+type ProjectRole struct {
+	// Name is used as the group prefix of the members of the role
+	Name              string `json:"name"`
+	HumanReadableName string `json:"humanReadableName,omitempty"`
+	// Default roles are always available and can't be changed
+	Default bool                           `json:"default,omitempty"`
+	Rules   []kubermaticv1.ProjectRoleRule `json:"rules"`
+}
+
+// PricingCatalog defines the hourly costs of the machine sizes of the cloud providers
+// swagger:model PricingCatalog
+type PricingCatalog kubermaticv1.PricingCatalogSpec
+
+// ClusterCost is the estimated cost of the nodes of a cluster
+// swagger:model ClusterCost
+type ClusterCost struct {
+	// ClusterID is not set for the estimate of a cluster which is not created yet
+	ClusterID   string  `json:"clusterID,omitempty"`
+	ClusterName string  `json:"clusterName,omitempty"`
+	Currency    string  `json:"currency"`
+	HourlyCost  float64 `json:"hourlyCost"`
+	MonthlyCost float64 `json:"monthlyCost"`
+	// Incomplete is set when the price of some nodes is not part of the pricing catalog or the
+	// nodes of the cluster could not be determined
+	Incomplete      bool                 `json:"incomplete,omitempty"`
+	NodeDeployments []NodeDeploymentCost `json:"nodeDeployments"`
+}
+
+// NodeDeploymentCost is the estimated cost of the nodes of a node deployment
+// swagger:model NodeDeploymentCost
+type NodeDeploymentCost struct {
+	Name       string `json:"name,omitempty"`
+	Provider   string `json:"provider"`
+	Datacenter string `json:"datacenter"`
+	Size       string `json:"size"`
+	Replicas   int32  `json:"replicas"`
+	// Priced is false when the pricing catalog has no price for the size, the costs are zero then
+	Priced            bool    `json:"priced"`
+	HourlyCostPerNode float64 `json:"hourlyCostPerNode"`
+	HourlyCost        float64 `json:"hourlyCost"`
+	MonthlyCost       float64 `json:"monthlyCost"`
+}
+
+// ProjectCost is the estimated cost of the nodes of all clusters of a project
+// swagger:model ProjectCost
+type ProjectCost struct {
+	Currency    string  `json:"currency"`
+	HourlyCost  float64 `json:"hourlyCost"`
+	MonthlyCost float64 `json:"monthlyCost"`
+	// Incomplete is set when the price of some nodes is not part of the pricing catalog or the
+	// nodes of a cluster could not be determined, e.g. because its API server or seed is not reachable
+	Incomplete bool          `json:"incomplete,omitempty"`
+	Clusters   []ClusterCost `json:"clusters"`
+}
+
+// CostEstimate describes the nodes of a cluster which is not created yet
+// swagger:model CostEstimate
+type CostEstimate struct {
+	// Datacenter is the name of the datacenter the cluster will be created in
+	Datacenter      string           `json:"datacenter"`
+	NodeDeployments []NodeDeployment `json:"nodeDeployments"`
+}
+
 // Seed represents a seed object
 // swagger:model Seed
 type Seed struct {
@@ -1496,6 +1723,22 @@ type Seed struct {
 	Name string `json:"name"`
 
 	SeedSpec `json:"spec"`
+
+	// Usage is the current usage of the capacity of the seed
+	Usage *SeedUsage `json:"usage,omitempty"`
+}
+
+// SeedUsage is the usage of the capacity of a seed
+// swagger:model SeedUsage
+type SeedUsage struct {
+	// Clusters is the number of user clusters whose control planes run in the seed
+	Clusters int64 `json:"clusters"`
+	// ControlPlaneCPU is the amount of CPU requested by all control planes in the seed
+	ControlPlaneCPU string `json:"controlPlaneCPU"`
+	// ControlPlaneMemory is the amount of memory requested by all control planes in the seed
+	ControlPlaneMemory string `json:"controlPlaneMemory"`
+	// Full is set when the seed does not accept new clusters, because a limit of its capacity is reached
+	Full bool `json:"full"`
 }
 
 // The spec for a seed data
@@ -1522,11 +1765,47 @@ type SeedSpec struct {
 	ProxySettings *kubermaticv1.ProxySettings `json:"proxy_settings,omitempty"`
 	// Optional: ExposeStrategy explicitly sets the expose strategy for this seed cluster, if not set, the default provided by the master is used.
 	ExposeStrategy corev1.ServiceType `json:"expose_strategy,omitempty"`
+	// Optional: Capacity limits the user cluster control planes this seed accepts.
+	Capacity *SeedCapacity `json:"capacity,omitempty"`
+}
+
+// SeedCapacity limits the control planes of a seed, a limit which is not set is not enforced
+// swagger:model SeedCapacity
+type SeedCapacity struct {
+	// MaxClusters is the maximum number of user clusters whose control planes run in the seed
+	MaxClusters *int64 `json:"maxClusters,omitempty"`
+	// ControlPlaneCPU is the maximum amount of CPU requested by all control planes, e.g. "64" or "500m"
+	ControlPlaneCPU string `json:"controlPlaneCPU,omitempty"`
+	// ControlPlaneMemory is the maximum amount of memory requested by all control planes, e.g. "256Gi"
+	ControlPlaneMemory string `json:"controlPlaneMemory,omitempty"`
 }
 
 // swagger:model SeedNamesList
 type SeedNamesList []string
 
+// AuditEntry represents an action taken through the API
+// swagger:model AuditEntry
+type AuditEntry struct {
+	Time Time `json:"time"`
+	// User is the email address of the user who took the action
+	User string `json:"user"`
+	// Admin is true if the user is a Kubermatic admin
+	Admin bool `json:"admin,omitempty"`
+	// Endpoint is the HTTP method and the route of the request
+	Endpoint string `json:"endpoint"`
+	// ProjectID is the ID of the project the action was taken in
+	ProjectID string `json:"projectID,omitempty"`
+	// Resources are the IDs of the targeted resources, keyed by the route parameter. The ID
+	// of a created resource is kept under "id".
+	Resources map[string]string `json:"resources,omitempty"`
+	// Outcome is either "success" or "failure"
+	Outcome string `json:"outcome"`
+	// Code is the HTTP status code of a failed action
+	Code int `json:"code,omitempty"`
+	// Error is the error message of a failed action
+	Error string `json:"error,omitempty"`
+}
+
 const (
 	// NodeDeletionFinalizer indicates that the nodes still need cleanup
 	NodeDeletionFinalizer = "kubermatic.io/delete-nodes"
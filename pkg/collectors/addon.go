@@ -22,6 +22,7 @@ import (
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -34,8 +35,10 @@ const (
 type AddonCollector struct {
 	client ctrlruntimeclient.Reader
 
-	addonCreated *prometheus.Desc
-	addonDeleted *prometheus.Desc
+	addonCreated          *prometheus.Desc
+	addonDeleted          *prometheus.Desc
+	addonHealthy          *prometheus.Desc
+	addonUnhealthyObjects *prometheus.Desc
 }
 
 // MustRegisterAddonCollector registers the addon collector at the given prometheus registry
@@ -54,6 +57,18 @@ func MustRegisterAddonCollector(registry prometheus.Registerer, client ctrlrunti
 			[]string{"cluster", "addon"},
 			nil,
 		),
+		addonHealthy: prometheus.NewDesc(
+			addonPrefix+"healthy",
+			"Whether all objects of the addon exist, match the manifests and are ready",
+			[]string{"cluster", "addon"},
+			nil,
+		),
+		addonUnhealthyObjects: prometheus.NewDesc(
+			addonPrefix+"unhealthy_objects",
+			"Number of addon objects that are missing, drifted or not ready",
+			[]string{"cluster", "addon", "state"},
+			nil,
+		),
 	}
 
 	registry.MustRegister(cc)
@@ -63,6 +78,8 @@ func MustRegisterAddonCollector(registry prometheus.Registerer, client ctrlrunti
 func (cc AddonCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- cc.addonCreated
 	ch <- cc.addonDeleted
+	ch <- cc.addonHealthy
+	ch <- cc.addonUnhealthyObjects
 }
 
 // Collect gets called by prometheus to collect the metrics
@@ -104,4 +121,47 @@ func (cc *AddonCollector) collectAddon(ch chan<- prometheus.Metric, addon *kuber
 			addon.Name,
 		)
 	}
+
+	// The health is only known once the addon controller checked it
+	var healthCondition *kubermaticv1.AddonCondition
+	for i, condition := range addon.Status.Conditions {
+		if condition.Type == kubermaticv1.AddonHealthy {
+			healthCondition = &addon.Status.Conditions[i]
+			break
+		}
+	}
+	if healthCondition == nil {
+		return
+	}
+
+	healthy := 0.0
+	if healthCondition.Status == corev1.ConditionTrue {
+		healthy = 1.0
+	}
+	ch <- prometheus.MustNewConstMetric(
+		cc.addonHealthy,
+		prometheus.GaugeValue,
+		healthy,
+		clusterName,
+		addon.Name,
+	)
+
+	unhealthyObjects := map[kubermaticv1.AddonObjectState]int{
+		kubermaticv1.AddonObjectMissing:  0,
+		kubermaticv1.AddonObjectDrifted:  0,
+		kubermaticv1.AddonObjectNotReady: 0,
+	}
+	for _, object := range addon.Status.UnhealthyObjects {
+		unhealthyObjects[object.State]++
+	}
+	for state, count := range unhealthyObjects {
+		ch <- prometheus.MustNewConstMetric(
+			cc.addonUnhealthyObjects,
+			prometheus.GaugeValue,
+			float64(count),
+			clusterName,
+			addon.Name,
+			string(state),
+		)
+	}
 }
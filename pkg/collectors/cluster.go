@@ -138,11 +138,6 @@ func (cc *ClusterCollector) clusterLabels(cluster *kubermaticv1.Cluster) ([]stri
 		pause = "true"
 	}
 
-	clusterType := "kubernetes"
-	if cluster.Spec.Openshift != nil {
-		clusterType = "openshift"
-	}
-
 	return []string{
 		cluster.Name,
 		cluster.Spec.HumanReadableName,
@@ -151,6 +146,6 @@ func (cc *ClusterCollector) clusterLabels(cluster *kubermaticv1.Cluster) ([]stri
 		provider,
 		cluster.Spec.Cloud.DatacenterName,
 		pause,
-		clusterType,
+		clusterTypeLabel(cluster),
 	}, nil
 }
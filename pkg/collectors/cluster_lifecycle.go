@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+)
+
+const (
+	// UpgradeResultCompleted is the result of a control plane upgrade which has been completed
+	UpgradeResultCompleted = "completed"
+	// UpgradeResultRolledBack is the result of a control plane upgrade which has been rolled back
+	UpgradeResultRolledBack = "rolled_back"
+)
+
+// lifecycleBuckets range from 30 seconds to 2 hours, which covers everything from a quick
+// control plane upgrade up to the deletion of a cluster with many cloud resources
+var lifecycleBuckets = []float64{30, 60, 120, 180, 300, 450, 600, 900, 1200, 1800, 2700, 3600, 7200}
+
+var (
+	clusterInitializationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    prefix + "initialization_duration_seconds",
+			Help:    "Time from the creation of a cluster until it got initialized",
+			Buckets: lifecycleBuckets,
+		},
+		[]string{"cloud_provider", "type"},
+	)
+	clusterUpgradeDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    prefix + "controlplane_upgrade_duration_seconds",
+			Help:    "Time from the start of a control plane upgrade until it got completed or rolled back",
+			Buckets: lifecycleBuckets,
+		},
+		[]string{"result"},
+	)
+	clusterDeletionDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    prefix + "deletion_duration_seconds",
+			Help:    "Time from the deletion of a cluster until all of its resources including the cloud resources got cleaned up",
+			Buckets: lifecycleBuckets,
+		},
+		[]string{"cloud_provider", "type"},
+	)
+	clusterReconcileErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: prefix + "reconcile_errors_total",
+			Help: "Number of failed cluster reconciliations by the reconciling success condition of the controller",
+		},
+		[]string{"controller"},
+	)
+)
+
+// MustRegisterClusterLifecycleMetrics registers the metrics about the lifecycle of clusters at the given prometheus registry
+func MustRegisterClusterLifecycleMetrics(registry prometheus.Registerer) {
+	registry.MustRegister(
+		clusterInitializationDuration,
+		clusterUpgradeDuration,
+		clusterDeletionDuration,
+		clusterReconcileErrors,
+	)
+}
+
+// ObserveClusterInitialized records the time it took from the creation of the cluster until it got initialized
+func ObserveClusterInitialized(cluster *kubermaticv1.Cluster) {
+	clusterInitializationDuration.
+		WithLabelValues(cloudProviderLabel(cluster), clusterTypeLabel(cluster)).
+		Observe(time.Since(cluster.CreationTimestamp.Time).Seconds())
+}
+
+// ObserveControlPlaneUpgrade records the duration of the given control plane upgrade
+func ObserveControlPlaneUpgrade(upgrade *kubermaticv1.ControlPlaneUpgradeStatus, result string) {
+	// Upgrades started by older versions have no start time
+	if upgrade.StartTime.IsZero() {
+		return
+	}
+	clusterUpgradeDuration.
+		WithLabelValues(result).
+		Observe(time.Since(upgrade.StartTime.Time).Seconds())
+}
+
+// ObserveClusterDeleted records the time it took from the deletion of the cluster until it got cleaned up
+func ObserveClusterDeleted(cluster *kubermaticv1.Cluster) {
+	if cluster.DeletionTimestamp == nil {
+		return
+	}
+	clusterDeletionDuration.
+		WithLabelValues(cloudProviderLabel(cluster), clusterTypeLabel(cluster)).
+		Observe(time.Since(cluster.DeletionTimestamp.Time).Seconds())
+}
+
+// IncClusterReconcileErrors counts a failed reconciliation of the controller owning the given condition
+func IncClusterReconcileErrors(conditionType kubermaticv1.ClusterConditionType) {
+	clusterReconcileErrors.WithLabelValues(string(conditionType)).Inc()
+}
+
+func cloudProviderLabel(cluster *kubermaticv1.Cluster) string {
+	name, err := provider.ClusterCloudProviderName(cluster.Spec.Cloud)
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+func clusterTypeLabel(cluster *kubermaticv1.Cluster) string {
+	if cluster.Spec.Openshift != nil {
+		return "openshift"
+	}
+	return "kubernetes"
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// gatherLifecycleMetric returns the metric with the given name and labels or nil if it was not recorded
+func gatherLifecycleMetric(t *testing.T, name string, labels map[string]string) *dto.Metric {
+	registry := prometheus.NewRegistry()
+	MustRegisterClusterLifecycleMetrics(registry)
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, metric := range family.Metric {
+			for _, label := range metric.Label {
+				if labels[label.GetName()] != label.GetValue() {
+					continue metrics
+				}
+			}
+			return metric
+		}
+	}
+	return nil
+}
+
+// expectObservation verifies that the histogram has a single observation between lower and upper seconds
+func expectObservation(t *testing.T, metric *dto.Metric, lower, upper float64) {
+	if metric == nil {
+		t.Fatal("expected an observation, got none")
+	}
+	histogram := metric.GetHistogram()
+	if count := histogram.GetSampleCount(); count != 1 {
+		t.Fatalf("expected a single observation, got %d", count)
+	}
+	if sum := histogram.GetSampleSum(); sum < lower || sum > upper {
+		t.Errorf("expected an observation between %v and %v seconds, got %v", lower, upper, sum)
+	}
+	for _, bucket := range histogram.Bucket {
+		expected := uint64(0)
+		if bucket.GetUpperBound() >= upper {
+			expected = 1
+		}
+		if bucket.GetUpperBound() >= lower && bucket.GetUpperBound() < upper {
+			continue
+		}
+		if bucket.GetCumulativeCount() != expected {
+			t.Errorf("expected bucket %v to contain %d observations, got %d", bucket.GetUpperBound(), expected, bucket.GetCumulativeCount())
+		}
+	}
+}
+
+func TestObserveClusterInitialized(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(time.Now().Add(-5 * time.Minute))},
+		Spec:       kubermaticv1.ClusterSpec{Cloud: kubermaticv1.CloudSpec{Hetzner: &kubermaticv1.HetznerCloudSpec{}}},
+	}
+	ObserveClusterInitialized(cluster)
+
+	metric := gatherLifecycleMetric(t, prefix+"initialization_duration_seconds", map[string]string{"cloud_provider": "hetzner", "type": "kubernetes"})
+	expectObservation(t, metric, 300, 360)
+}
+
+func TestObserveControlPlaneUpgrade(t *testing.T) {
+	// Upgrades without a start time must not be recorded
+	ObserveControlPlaneUpgrade(&kubermaticv1.ControlPlaneUpgradeStatus{}, UpgradeResultRolledBack)
+	if metric := gatherLifecycleMetric(t, prefix+"controlplane_upgrade_duration_seconds", map[string]string{"result": UpgradeResultRolledBack}); metric != nil {
+		t.Errorf("expected upgrades without a start time to be skipped, got %v", metric)
+	}
+
+	ObserveControlPlaneUpgrade(&kubermaticv1.ControlPlaneUpgradeStatus{StartTime: metav1.NewTime(time.Now().Add(-20 * time.Minute))}, UpgradeResultCompleted)
+	metric := gatherLifecycleMetric(t, prefix+"controlplane_upgrade_duration_seconds", map[string]string{"result": UpgradeResultCompleted})
+	expectObservation(t, metric, 1200, 1260)
+}
+
+func TestObserveClusterDeleted(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud:     kubermaticv1.CloudSpec{Packet: &kubermaticv1.PacketCloudSpec{}},
+			Openshift: &kubermaticv1.Openshift{},
+		},
+	}
+	labels := map[string]string{"cloud_provider": "packet", "type": "openshift"}
+
+	// Clusters which are not deleted must not be recorded
+	ObserveClusterDeleted(cluster)
+	if metric := gatherLifecycleMetric(t, prefix+"deletion_duration_seconds", labels); metric != nil {
+		t.Errorf("expected clusters without a deletion timestamp to be skipped, got %v", metric)
+	}
+
+	deletionTimestamp := metav1.NewTime(time.Now().Add(-50 * time.Minute))
+	cluster.DeletionTimestamp = &deletionTimestamp
+	ObserveClusterDeleted(cluster)
+	metric := gatherLifecycleMetric(t, prefix+"deletion_duration_seconds", labels)
+	expectObservation(t, metric, 3000, 3060)
+}
+
+func TestIncClusterReconcileErrors(t *testing.T) {
+	labels := map[string]string{"controller": string(kubermaticv1.ClusterConditionMonitoringControllerReconcilingSuccess)}
+	if metric := gatherLifecycleMetric(t, prefix+"reconcile_errors_total", labels); metric != nil {
+		t.Fatalf("expected no reconcile errors, got %v", metric)
+	}
+
+	IncClusterReconcileErrors(kubermaticv1.ClusterConditionMonitoringControllerReconcilingSuccess)
+	IncClusterReconcileErrors(kubermaticv1.ClusterConditionMonitoringControllerReconcilingSuccess)
+	IncClusterReconcileErrors(kubermaticv1.ClusterConditionAddonControllerReconcilingSuccess)
+
+	metric := gatherLifecycleMetric(t, prefix+"reconcile_errors_total", labels)
+	if value := metric.GetCounter().GetValue(); value != 2 {
+		t.Errorf("expected 2 reconcile errors, got %v", value)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DefragmentationResultSucceeded is the result of a successful defragmentation of an etcd member
+	DefragmentationResultSucceeded = "succeeded"
+	// DefragmentationResultFailed is the result of a failed defragmentation of an etcd member
+	DefragmentationResultFailed = "failed"
+)
+
+var (
+	etcdDBSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: prefix + "etcd_db_size_bytes",
+			Help: "Size of the database of an etcd member of a cluster, including the free space not yet reclaimed by a defragmentation",
+		},
+		[]string{"cluster", "member"},
+	)
+	etcdDBSizeInUse = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: prefix + "etcd_db_size_in_use_bytes",
+			Help: "Size of the database of an etcd member of a cluster which is actually in use",
+		},
+		[]string{"cluster", "member"},
+	)
+	etcdDefragmentationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    prefix + "etcd_defragmentation_duration_seconds",
+			Help:    "Time it took to defragment an etcd member",
+			Buckets: []float64{1, 2.5, 5, 10, 20, 30, 60, 120, 300},
+		},
+		[]string{"result"},
+	)
+	etcdDefragmentationReclaimed = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: prefix + "etcd_defragmentation_reclaimed_bytes_total",
+			Help: "Disk space reclaimed by defragmenting etcd members",
+		},
+	)
+)
+
+// MustRegisterEtcdMaintenanceMetrics registers the metrics about the etcd maintenance at the given prometheus registry
+func MustRegisterEtcdMaintenanceMetrics(registry prometheus.Registerer) {
+	registry.MustRegister(
+		etcdDBSize,
+		etcdDBSizeInUse,
+		etcdDefragmentationDuration,
+		etcdDefragmentationReclaimed,
+	)
+}
+
+// SetEtcdDBSize records the size of the database of an etcd member
+func SetEtcdDBSize(cluster, member string, dbSize, dbSizeInUse int64) {
+	etcdDBSize.WithLabelValues(cluster, member).Set(float64(dbSize))
+	etcdDBSizeInUse.WithLabelValues(cluster, member).Set(float64(dbSizeInUse))
+}
+
+// DeleteEtcdDBSize removes the database sizes recorded for an etcd member
+func DeleteEtcdDBSize(cluster, member string) {
+	etcdDBSize.DeleteLabelValues(cluster, member)
+	etcdDBSizeInUse.DeleteLabelValues(cluster, member)
+}
+
+// ObserveEtcdDefragmentation records the duration of a defragmentation and the space it reclaimed
+func ObserveEtcdDefragmentation(result string, duration time.Duration, reclaimed int64) {
+	etcdDefragmentationDuration.WithLabelValues(result).Observe(duration.Seconds())
+	if reclaimed > 0 {
+		etcdDefragmentationReclaimed.Add(float64(reclaimed))
+	}
+}
@@ -0,0 +1,885 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustermigration
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	backupcontroller "github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/backup"
+	controllerutil "github.com/kubermatic/kubermatic/pkg/controller/util"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	kuberneteshelper "github.com/kubermatic/kubermatic/pkg/kubernetes"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+	"github.com/kubermatic/kubermatic/pkg/resources"
+	"github.com/kubermatic/kubermatic/pkg/util/workerlabel"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	utilpointer "k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "cluster_migration_controller"
+
+	// snapshotJobLabel defines the label we use on the jobs taking the snapshot of a migrated cluster
+	snapshotJobLabel = "kubermatic-cluster-migration"
+	// switchOverTimeout is how long we wait for the control plane in the target seed to become healthy
+	// and for the admin to confirm the DNS switch, before the migration gets rolled back
+	switchOverTimeout = time.Hour
+	// rollbackFinalizer makes sure a migration which gets deleted before it finished is rolled back
+	rollbackFinalizer = "kubermatic.io/rollback-cluster-migration"
+	// requeueAfter is the interval in which the progress of a migration is checked, as we don't
+	// watch the objects in the seeds
+	requeueAfter = 10 * time.Second
+)
+
+// Reconciler moves the control plane of user clusters between seeds
+type Reconciler struct {
+	ctx         context.Context
+	log         *zap.SugaredLogger
+	client      ctrlruntimeclient.Client
+	recorder    record.EventRecorder
+	seedsGetter provider.SeedsGetter
+	seedClients map[string]ctrlruntimeclient.Client
+	// dialAPIServer checks that an apiserver with a certificate of the given CA answers at the address
+	dialAPIServer func(address string, caCert []byte) error
+}
+
+// Add creates a new cluster migration controller that is responsible for migrating clusters
+// as requested by ClusterMigration objects
+func Add(
+	ctx context.Context,
+	mgr manager.Manager,
+	seedManagers map[string]manager.Manager,
+	log *zap.SugaredLogger,
+	workerName string,
+	numWorkers int,
+	seedsGetter provider.SeedsGetter,
+) error {
+	reconciler := &Reconciler{
+		ctx:           ctx,
+		log:           log.Named(ControllerName),
+		client:        mgr.GetClient(),
+		recorder:      mgr.GetEventRecorderFor(ControllerName),
+		seedsGetter:   seedsGetter,
+		seedClients:   map[string]ctrlruntimeclient.Client{},
+		dialAPIServer: dialAPIServer,
+	}
+	for seedName, seedManager := range seedManagers {
+		reconciler.seedClients[seedName] = seedManager.GetClient()
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers})
+	if err != nil {
+		return fmt.Errorf("failed to construct controller: %v", err)
+	}
+
+	if err := c.Watch(
+		&source.Kind{Type: &kubermaticv1.ClusterMigration{}},
+		&handler.EnqueueRequestForObject{},
+		workerlabel.Predicates(workerName),
+	); err != nil {
+		return fmt.Errorf("failed to watch ClusterMigrations: %v", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("request", request)
+	log.Debug("Processing")
+
+	migration := &kubermaticv1.ClusterMigration{}
+	if err := r.client.Get(r.ctx, request.NamespacedName, migration); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	log = log.With("cluster", migration.Spec.ClusterName)
+
+	if migration.DeletionTimestamp != nil {
+		return reconcile.Result{}, r.cleanup(log, migration)
+	}
+
+	if migration.Status.IsFinished() {
+		return reconcile.Result{}, nil
+	}
+
+	if !kuberneteshelper.HasFinalizer(migration, rollbackFinalizer) {
+		oldMigration := migration.DeepCopy()
+		kuberneteshelper.AddFinalizer(migration, rollbackFinalizer)
+		if err := r.client.Patch(r.ctx, migration, ctrlruntimeclient.MergeFrom(oldMigration)); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to add finalizer: %v", err)
+		}
+	}
+
+	result, err := r.reconcile(log, migration)
+	if controllerutil.IsCacheNotStarted(err) {
+		return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+	if err != nil {
+		log.Errorw("Reconciling failed", zap.Error(err))
+		r.recorder.Event(migration, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(log *zap.SugaredLogger, migration *kubermaticv1.ClusterMigration) (*reconcile.Result, error) {
+	switch migration.Status.Phase {
+	case "":
+		return nil, r.start(log, migration)
+	case kubermaticv1.ClusterMigrationPhaseBackingUp:
+		return r.backup(log, migration)
+	case kubermaticv1.ClusterMigrationPhaseProvisioning:
+		return r.provision(log, migration)
+	case kubermaticv1.ClusterMigrationPhaseRestoring:
+		return r.restore(log, migration)
+	case kubermaticv1.ClusterMigrationPhaseSwitchingOver:
+		return r.switchOver(log, migration)
+	default:
+		return nil, fmt.Errorf("unknown phase %q", migration.Status.Phase)
+	}
+}
+
+// start validates the migration and pauses the cluster in the source seed, so no other controller
+// touches its control plane while it gets migrated
+func (r *Reconciler) start(log *zap.SugaredLogger, migration *kubermaticv1.ClusterMigration) error {
+	if migration.Spec.ClusterName == "" || migration.Spec.TargetDatacenter == "" {
+		return r.setFailed(log, migration, "clusterName and targetDatacenter must not be empty")
+	}
+
+	seeds, err := r.seedsGetter()
+	if err != nil {
+		return fmt.Errorf("failed to get seeds: %v", err)
+	}
+	targetSeed, targetDatacenter := findDatacenter(seeds, migration.Spec.TargetDatacenter)
+	if targetSeed == nil {
+		return r.setFailed(log, migration, fmt.Sprintf("datacenter %q does not exist", migration.Spec.TargetDatacenter))
+	}
+	if _, ok := r.seedClients[targetSeed.Name]; !ok {
+		return r.setFailed(log, migration, fmt.Sprintf("seed %q of datacenter %q is not available", targetSeed.Name, migration.Spec.TargetDatacenter))
+	}
+
+	sourceSeedName, cluster, err := r.findCluster(migration.Spec.ClusterName)
+	if multipleSeedsErr, ok := err.(errMultipleSeeds); ok {
+		return r.setFailed(log, migration, multipleSeedsErr.Error())
+	}
+	if err != nil {
+		return err
+	}
+	if cluster == nil {
+		return r.setFailed(log, migration, fmt.Sprintf("cluster %q does not exist in any seed", migration.Spec.ClusterName))
+	}
+	if sourceSeedName == targetSeed.Name {
+		return r.setFailed(log, migration, fmt.Sprintf("cluster %q already runs in seed %q", cluster.Name, targetSeed.Name))
+	}
+	if cluster.DeletionTimestamp != nil {
+		return r.setFailed(log, migration, fmt.Sprintf("cluster %q is being deleted", cluster.Name))
+	}
+	if cluster.Status.Hibernation != nil {
+		return r.setFailed(log, migration, fmt.Sprintf("cluster %q is hibernated", cluster.Name))
+	}
+	if cluster.Spec.Pause && cluster.Spec.PauseReason != pauseReason(migration) {
+		return r.setFailed(log, migration, fmt.Sprintf("cluster is already paused: %s", cluster.Spec.PauseReason))
+	}
+	// The address of the cluster is the IP of its LoadBalancer, which can't be moved to another seed
+	if cluster.Spec.ExposeStrategy == corev1.ServiceTypeLoadBalancer {
+		return r.setFailed(log, migration, "clusters exposed by a LoadBalancer can not keep their address in another seed")
+	}
+
+	sourceSeed, ok := seeds[sourceSeedName]
+	if !ok {
+		return r.setFailed(log, migration, fmt.Sprintf("seed %q of the cluster does not exist", sourceSeedName))
+	}
+	if sourceSeed.Spec.BackupStore == "" || sourceSeed.Spec.BackupStore != targetSeed.Spec.BackupStore {
+		return r.setFailed(log, migration, fmt.Sprintf("the seeds %q and %q must use the same backup store", sourceSeedName, targetSeed.Name))
+	}
+	sourceDatacenter, ok := sourceSeed.Spec.Datacenters[cluster.Spec.Cloud.DatacenterName]
+	if !ok {
+		return r.setFailed(log, migration, fmt.Sprintf("datacenter %q of the cluster does not exist in seed %q", cluster.Spec.Cloud.DatacenterName, sourceSeedName))
+	}
+	sourceProvider, err := provider.DatacenterCloudProviderName(&sourceDatacenter.Spec)
+	if err != nil {
+		return r.setFailed(log, migration, err.Error())
+	}
+	targetProvider, err := provider.DatacenterCloudProviderName(&targetDatacenter.Spec)
+	if err != nil {
+		return r.setFailed(log, migration, err.Error())
+	}
+	if sourceProvider != targetProvider {
+		return r.setFailed(log, migration, fmt.Sprintf("datacenter %q uses the cloud provider %q, but the cluster uses %q", migration.Spec.TargetDatacenter, targetProvider, sourceProvider))
+	}
+
+	if !cluster.Spec.Pause {
+		oldCluster := cluster.DeepCopy()
+		cluster.Spec.Pause = true
+		cluster.Spec.PauseReason = pauseReason(migration)
+		if err := r.seedClients[sourceSeedName].Patch(r.ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return fmt.Errorf("failed to pause cluster: %v", err)
+		}
+		log.Infow("Paused cluster for the migration", "seed", sourceSeedName)
+	}
+
+	now := metav1.Now()
+	return r.setPhase(migration, kubermaticv1.ClusterMigrationPhaseBackingUp, fmt.Sprintf("Taking a snapshot of etcd in seed %s", sourceSeedName), func(status *kubermaticv1.ClusterMigrationStatus) {
+		status.SourceSeed = sourceSeedName
+		status.TargetSeed = targetSeed.Name
+		status.StartTime = &now
+	})
+}
+
+// backup stops the apiserver in the source seed, so nothing gets written to etcd anymore, and
+// takes a snapshot of etcd using the backup CronJob of the cluster
+func (r *Reconciler) backup(log *zap.SugaredLogger, migration *kubermaticv1.ClusterMigration) (*reconcile.Result, error) {
+	seedClient, cluster, err := r.getCluster(migration.Status.SourceSeed, migration.Spec.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+	if cluster == nil {
+		return nil, r.setFailed(log, migration, fmt.Sprintf("cluster %q does not exist in seed %q anymore", migration.Spec.ClusterName, migration.Status.SourceSeed))
+	}
+
+	apiserver := &appsv1.Deployment{}
+	if err := seedClient.Get(r.ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.ApiserverDeploymentName}, apiserver); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get apiserver deployment: %v", err)
+		}
+	} else {
+		if apiserver.Spec.Replicas == nil || *apiserver.Spec.Replicas != 0 {
+			oldAPIServer := apiserver.DeepCopy()
+			apiserver.Spec.Replicas = utilpointer.Int32Ptr(0)
+			if err := seedClient.Patch(r.ctx, apiserver, ctrlruntimeclient.MergeFrom(oldAPIServer)); err != nil {
+				return nil, fmt.Errorf("failed to scale down apiserver deployment: %v", err)
+			}
+			log.Debug("Scaled down apiserver")
+		}
+		if apiserver.Status.Replicas != 0 {
+			log.Debugw("Waiting for apiserver pods to terminate", "replicas", apiserver.Status.Replicas)
+			return &reconcile.Result{RequeueAfter: requeueAfter}, nil
+		}
+	}
+
+	cronJob := &batchv1beta1.CronJob{}
+	if err := seedClient.Get(r.ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: backupcontroller.CronJobName(cluster)}, cronJob); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, r.setFailed(log, migration, "the cluster has no backup cronjob, etcd backups must be enabled to migrate it")
+		}
+		return nil, fmt.Errorf("failed to get backup cronjob: %v", err)
+	}
+
+	wantJob := snapshotJob(migration, cronJob)
+	job := &batchv1.Job{}
+	if err := seedClient.Get(r.ctx, types.NamespacedName{Namespace: wantJob.Namespace, Name: wantJob.Name}, job); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get snapshot job %q: %v", wantJob.Name, err)
+		}
+		if err := seedClient.Create(r.ctx, wantJob); err != nil && !kerrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to create snapshot job %q: %v", wantJob.Name, err)
+		}
+		log.Infow("Created snapshot job", "job", wantJob.Name)
+		return &reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	// A job left over from an earlier migration of the same cluster would report an old snapshot
+	if job.Annotations[kubermaticv1.ClusterMigrationAnnotation] != migration.Name {
+		if err := deleteJob(r.ctx, seedClient, job); err != nil {
+			return nil, err
+		}
+		log.Infow("Deleted snapshot job of another migration", "job", job.Name)
+		return &reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	if jobHasCondition(job, batchv1.JobFailed) {
+		return nil, r.setFailed(log, migration, fmt.Sprintf("snapshot job %q failed", job.Name))
+	}
+	if !jobHasCondition(job, batchv1.JobComplete) {
+		log.Debugw("Waiting for snapshot job to complete", "job", job.Name)
+		return &reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	backupName, err := snapshotName(r.ctx, seedClient, job)
+	if err != nil {
+		return nil, err
+	}
+	if backupName == "" {
+		return nil, r.setFailed(log, migration, fmt.Sprintf("the store container of snapshot job %q did not report the name of the snapshot", job.Name))
+	}
+	if err := deleteJob(r.ctx, seedClient, job); err != nil {
+		return nil, err
+	}
+
+	return nil, r.setPhase(migration, kubermaticv1.ClusterMigrationPhaseProvisioning, fmt.Sprintf("Creating the cluster in seed %s", migration.Status.TargetSeed), func(status *kubermaticv1.ClusterMigrationStatus) {
+		status.BackupName = backupName
+	})
+}
+
+// provision creates the cluster in the target seed together with its namespace and the secrets
+// of the source seed, so the control plane keeps its certificate authorities, keys and tokens
+func (r *Reconciler) provision(log *zap.SugaredLogger, migration *kubermaticv1.ClusterMigration) (*reconcile.Result, error) {
+	sourceClient, sourceCluster, err := r.getCluster(migration.Status.SourceSeed, migration.Spec.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+	if sourceCluster == nil {
+		return nil, r.setFailed(log, migration, fmt.Sprintf("cluster %q does not exist in seed %q anymore", migration.Spec.ClusterName, migration.Status.SourceSeed))
+	}
+	targetClient, targetCluster, err := r.getCluster(migration.Status.TargetSeed, migration.Spec.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	if targetCluster == nil {
+		// The cluster stays paused until its secrets got copied, otherwise the cluster controller
+		// would create new certificate authorities
+		targetCluster = migratedCluster(migration, sourceCluster)
+		if err := targetClient.Create(r.ctx, targetCluster); err != nil {
+			return nil, fmt.Errorf("failed to create cluster in seed %q: %v", migration.Status.TargetSeed, err)
+		}
+		log.Infow("Created cluster", "seed", migration.Status.TargetSeed)
+	} else if targetCluster.Annotations[kubermaticv1.ClusterMigrationAnnotation] != migration.Name {
+		return nil, r.setFailed(log, migration, fmt.Sprintf("cluster %q already exists in seed %q", targetCluster.Name, migration.Status.TargetSeed))
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := targetClient.Get(r.ctx, types.NamespacedName{Name: targetCluster.Status.NamespaceName}, namespace); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get namespace: %v", err)
+		}
+		namespace = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            targetCluster.Status.NamespaceName,
+				OwnerReferences: []metav1.OwnerReference{resources.GetClusterRef(targetCluster)},
+			},
+		}
+		if err := targetClient.Create(r.ctx, namespace); err != nil && !kerrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to create namespace %q: %v", namespace.Name, err)
+		}
+	}
+
+	// The apiserver keeps its port, as it is part of the address of the cluster
+	apiserverService := &corev1.Service{}
+	if err := sourceClient.Get(r.ctx, types.NamespacedName{Namespace: sourceCluster.Status.NamespaceName, Name: resources.ApiserverExternalServiceName}, apiserverService); err != nil {
+		return nil, fmt.Errorf("failed to get apiserver service: %v", err)
+	}
+	if err := targetClient.Create(r.ctx, migratedService(apiserverService, targetCluster.Status.NamespaceName)); err != nil && !kerrors.IsAlreadyExists(err) {
+		if kerrors.IsInvalid(err) {
+			return nil, r.setFailed(log, migration, fmt.Sprintf("the port of the apiserver is not available in seed %q: %v", migration.Status.TargetSeed, err))
+		}
+		return nil, fmt.Errorf("failed to create apiserver service: %v", err)
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := sourceClient.List(r.ctx, secrets, ctrlruntimeclient.InNamespace(sourceCluster.Status.NamespaceName)); err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %v", err)
+	}
+	for _, secret := range secrets.Items {
+		// Service account tokens are issued by the target seed itself
+		if secret.Type == corev1.SecretTypeServiceAccountToken {
+			continue
+		}
+		if err := targetClient.Create(r.ctx, migratedSecret(&secret, targetCluster.Status.NamespaceName)); err != nil && !kerrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to copy secret %q: %v", secret.Name, err)
+		}
+	}
+	log.Debugw("Copied secrets", "count", len(secrets.Items))
+
+	if targetCluster.Spec.Pause {
+		oldCluster := targetCluster.DeepCopy()
+		targetCluster.Spec.Pause = false
+		targetCluster.Spec.PauseReason = ""
+		if err := targetClient.Patch(r.ctx, targetCluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return nil, fmt.Errorf("failed to unpause cluster in seed %q: %v", migration.Status.TargetSeed, err)
+		}
+	}
+
+	return nil, r.setPhase(migration, kubermaticv1.ClusterMigrationPhaseRestoring, fmt.Sprintf("Restoring the snapshot in seed %s", migration.Status.TargetSeed), nil)
+}
+
+// restore restores the snapshot in the target seed once its etcd is running, as the restore
+// needs the volumes of the etcd StatefulSet
+func (r *Reconciler) restore(log *zap.SugaredLogger, migration *kubermaticv1.ClusterMigration) (*reconcile.Result, error) {
+	targetClient, cluster, err := r.getCluster(migration.Status.TargetSeed, migration.Spec.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+	if cluster == nil {
+		return nil, r.setFailed(log, migration, fmt.Sprintf("cluster %q does not exist in seed %q anymore", migration.Spec.ClusterName, migration.Status.TargetSeed))
+	}
+
+	restore := &kubermaticv1.EtcdRestore{}
+	if err := targetClient.Get(r.ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: migration.Name}, restore); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get etcd restore: %v", err)
+		}
+		if cluster.Status.ExtendedHealth.Etcd != kubermaticv1.HealthStatusUp {
+			log.Debug("Waiting for etcd to become healthy")
+			return &reconcile.Result{RequeueAfter: requeueAfter}, nil
+		}
+		if err := targetClient.Create(r.ctx, etcdRestore(migration, cluster)); err != nil && !kerrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to create etcd restore: %v", err)
+		}
+		log.Infow("Created etcd restore", "seed", migration.Status.TargetSeed, "backup", migration.Status.BackupName)
+		return &reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	switch restore.Status.Phase {
+	case kubermaticv1.EtcdRestorePhaseFailed:
+		return nil, r.setFailed(log, migration, fmt.Sprintf("etcd restore failed: %s", restore.Status.Message))
+	case kubermaticv1.EtcdRestorePhaseCompleted:
+		now := metav1.Now()
+		return nil, r.setPhase(migration, kubermaticv1.ClusterMigrationPhaseSwitchingOver, fmt.Sprintf("Waiting for the control plane in seed %s", migration.Status.TargetSeed), func(status *kubermaticv1.ClusterMigrationStatus) {
+			status.SwitchOverStartTime = &now
+		})
+	default:
+		log.Debugw("Waiting for etcd restore to complete", "phase", restore.Status.Phase)
+		return &reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+}
+
+// switchOver removes the cluster from the source seed once the apiserver in the target seed is healthy,
+// the admin confirmed that the DNS record of the cluster points to the target seed and the apiserver
+// serves the address of the cluster. If the apiserver does not become healthy or the DNS switch does not
+// get confirmed within the switchOverTimeout, the migration gets rolled back, as the cluster is unavailable
+// until then. After the confirmation it is not rolled back anymore, as the nodes would lose the apiserver.
+func (r *Reconciler) switchOver(log *zap.SugaredLogger, migration *kubermaticv1.ClusterMigration) (*reconcile.Result, error) {
+	targetClient, targetCluster, err := r.getCluster(migration.Status.TargetSeed, migration.Spec.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+	if targetCluster == nil {
+		return nil, fmt.Errorf("cluster %q does not exist in seed %q", migration.Spec.ClusterName, migration.Status.TargetSeed)
+	}
+	timedOut := migration.Status.SwitchOverStartTime != nil && time.Since(migration.Status.SwitchOverStartTime.Time) > switchOverTimeout
+	if targetCluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+		if timedOut {
+			return nil, r.setFailed(log, migration, fmt.Sprintf("the apiserver in seed %s did not become healthy within %v", migration.Status.TargetSeed, switchOverTimeout))
+		}
+		log.Debug("Waiting for apiserver to become healthy")
+		return &reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	// We don't manage the DNS records of the clusters, so the admin has to point the external name to
+	// the target seed and confirm this before the cluster gets removed from the source seed
+	if !migration.Spec.DNSSwitchedOver {
+		if timedOut {
+			return nil, r.setFailed(log, migration, fmt.Sprintf("the DNS switch of %s to the nodeport-proxy of seed %s was not confirmed within %v", targetCluster.Address.ExternalName, migration.Status.TargetSeed, switchOverTimeout))
+		}
+		message := fmt.Sprintf("Waiting for %s to be pointed to the nodeport-proxy of seed %s and spec.dnsSwitchedOver to be set", targetCluster.Address.ExternalName, migration.Status.TargetSeed)
+		if migration.Status.Message == message {
+			return &reconcile.Result{RequeueAfter: requeueAfter}, nil
+		}
+		return &reconcile.Result{RequeueAfter: requeueAfter}, r.setPhase(migration, kubermaticv1.ClusterMigrationPhaseSwitchingOver, message, nil)
+	}
+
+	// The apiserver in the source seed is scaled down, so an apiserver answering at the address of
+	// the cluster is the one in the target seed. Until then the nodes of the cluster can't reach it.
+	caSecret := &corev1.Secret{}
+	if err := targetClient.Get(r.ctx, types.NamespacedName{Namespace: targetCluster.Status.NamespaceName, Name: resources.CASecretName}, caSecret); err != nil {
+		return nil, fmt.Errorf("failed to get ca secret: %v", err)
+	}
+	if err := r.dialAPIServer(targetCluster.Address.URL, caSecret.Data[resources.CACertSecretKey]); err != nil {
+		message := fmt.Sprintf("Waiting for %s to point to the nodeport-proxy of seed %s: %v", targetCluster.Address.ExternalName, migration.Status.TargetSeed, err)
+		log.Debugw("Waiting for the address of the cluster to point to the target seed", zap.Error(err))
+		if migration.Status.Message == message {
+			return &reconcile.Result{RequeueAfter: requeueAfter}, nil
+		}
+		return &reconcile.Result{RequeueAfter: requeueAfter}, r.setPhase(migration, kubermaticv1.ClusterMigrationPhaseSwitchingOver, message, nil)
+	}
+
+	sourceClient, sourceCluster, err := r.getCluster(migration.Status.SourceSeed, migration.Spec.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+	if sourceCluster != nil {
+		// None of the cleanups must run in the source seed, they would delete the cloud resources
+		// and backups still used by the migrated cluster. The namespace and all other objects of
+		// the cluster get garbage collected.
+		if len(sourceCluster.Finalizers) > 0 {
+			oldCluster := sourceCluster.DeepCopy()
+			sourceCluster.Finalizers = nil
+			if err := sourceClient.Patch(r.ctx, sourceCluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+				return nil, fmt.Errorf("failed to remove finalizers of cluster in seed %q: %v", migration.Status.SourceSeed, err)
+			}
+		}
+		if err := sourceClient.Delete(r.ctx, sourceCluster); err != nil && !kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to delete cluster in seed %q: %v", migration.Status.SourceSeed, err)
+		}
+		log.Infow("Deleted cluster", "seed", migration.Status.SourceSeed)
+	}
+
+	r.recorder.Eventf(migration, corev1.EventTypeNormal, "ClusterMigrated", "Migrated cluster %q to seed %q", migration.Spec.ClusterName, migration.Status.TargetSeed)
+
+	now := metav1.Now()
+	message := fmt.Sprintf("The cluster is served by seed %s at %s", migration.Status.TargetSeed, targetCluster.Address.ExternalName)
+	return nil, r.setPhase(migration, kubermaticv1.ClusterMigrationPhaseCompleted, message, func(status *kubermaticv1.ClusterMigrationStatus) {
+		status.CompletionTime = &now
+	})
+}
+
+// cleanup rolls back a migration which gets deleted before it finished, otherwise the cluster would
+// stay paused in the source seed with its apiserver scaled down
+func (r *Reconciler) cleanup(log *zap.SugaredLogger, migration *kubermaticv1.ClusterMigration) error {
+	if !kuberneteshelper.HasFinalizer(migration, rollbackFinalizer) {
+		return nil
+	}
+
+	if !migration.Status.IsFinished() {
+		if err := r.rollback(log, migration); err != nil {
+			return fmt.Errorf("failed to roll back migration: %v", err)
+		}
+		log.Info("Rolled back deleted migration")
+	}
+
+	oldMigration := migration.DeepCopy()
+	kuberneteshelper.RemoveFinalizer(migration, rollbackFinalizer)
+	if err := r.client.Patch(r.ctx, migration, ctrlruntimeclient.MergeFrom(oldMigration)); err != nil {
+		return fmt.Errorf("failed to remove finalizer: %v", err)
+	}
+	return nil
+}
+
+// rollback removes the cluster from the target seed and unpauses it in the source seed again. Once the
+// cluster got removed from the source seed, the migrated cluster is the only one left and is kept.
+func (r *Reconciler) rollback(log *zap.SugaredLogger, migration *kubermaticv1.ClusterMigration) error {
+	if migration.Status.SourceSeed != "" {
+		_, sourceCluster, err := r.getCluster(migration.Status.SourceSeed, migration.Spec.ClusterName)
+		if err != nil {
+			return err
+		}
+		if sourceCluster == nil {
+			log.Infow("Keeping the migrated cluster, the cluster does not exist in the source seed anymore", "seed", migration.Status.SourceSeed)
+			return nil
+		}
+	}
+
+	if migration.Status.TargetSeed != "" {
+		targetClient, cluster, err := r.getCluster(migration.Status.TargetSeed, migration.Spec.ClusterName)
+		if err != nil {
+			return err
+		}
+		if cluster != nil && cluster.Annotations[kubermaticv1.ClusterMigrationAnnotation] == migration.Name {
+			// The cleanups would delete the cloud resources still used by the cluster in the source seed
+			if len(cluster.Finalizers) > 0 {
+				oldCluster := cluster.DeepCopy()
+				cluster.Finalizers = nil
+				if err := targetClient.Patch(r.ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+					return fmt.Errorf("failed to remove finalizers of cluster in seed %q: %v", migration.Status.TargetSeed, err)
+				}
+			}
+			if err := targetClient.Delete(r.ctx, cluster); err != nil && !kerrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete cluster in seed %q: %v", migration.Status.TargetSeed, err)
+			}
+			log.Infow("Deleted migrated cluster", "seed", migration.Status.TargetSeed)
+		}
+	}
+
+	if migration.Status.SourceSeed != "" {
+		sourceClient, cluster, err := r.getCluster(migration.Status.SourceSeed, migration.Spec.ClusterName)
+		if err != nil {
+			return err
+		}
+		// The cluster controller will scale the apiserver up again once the cluster got unpaused
+		if cluster != nil && cluster.Spec.Pause && cluster.Spec.PauseReason == pauseReason(migration) {
+			oldCluster := cluster.DeepCopy()
+			cluster.Spec.Pause = false
+			cluster.Spec.PauseReason = ""
+			if err := sourceClient.Patch(r.ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+				return fmt.Errorf("failed to unpause cluster in seed %q: %v", migration.Status.SourceSeed, err)
+			}
+			log.Infow("Unpaused cluster", "seed", migration.Status.SourceSeed)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) setPhase(migration *kubermaticv1.ClusterMigration, phase kubermaticv1.ClusterMigrationPhase, message string, modify func(*kubermaticv1.ClusterMigrationStatus)) error {
+	oldMigration := migration.DeepCopy()
+	migration.Status.Phase = phase
+	migration.Status.Message = message
+	if modify != nil {
+		modify(&migration.Status)
+	}
+	if err := r.client.Patch(r.ctx, migration, ctrlruntimeclient.MergeFrom(oldMigration)); err != nil {
+		return fmt.Errorf("failed to set phase %q: %v", phase, err)
+	}
+	return nil
+}
+
+func (r *Reconciler) setFailed(log *zap.SugaredLogger, migration *kubermaticv1.ClusterMigration, message string) error {
+	if err := r.rollback(log, migration); err != nil {
+		return fmt.Errorf("failed to roll back migration after %q: %v", message, err)
+	}
+	r.recorder.Event(migration, corev1.EventTypeWarning, "MigrationFailed", message)
+	return r.setPhase(migration, kubermaticv1.ClusterMigrationPhaseFailed, message, nil)
+}
+
+// findCluster returns the seed the cluster with the given name lives in. A cluster which exists in
+// multiple seeds is currently being migrated by another migration.
+func (r *Reconciler) findCluster(name string) (string, *kubermaticv1.Cluster, error) {
+	var seedNames []string
+	for seedName := range r.seedClients {
+		seedNames = append(seedNames, seedName)
+	}
+	sort.Strings(seedNames)
+
+	var (
+		foundSeedName string
+		foundCluster  *kubermaticv1.Cluster
+	)
+	for _, seedName := range seedNames {
+		_, cluster, err := r.getCluster(seedName, name)
+		if err != nil {
+			return "", nil, err
+		}
+		if cluster == nil {
+			continue
+		}
+		if foundCluster != nil {
+			return "", nil, errMultipleSeeds{cluster: name, seeds: []string{foundSeedName, seedName}}
+		}
+		foundSeedName, foundCluster = seedName, cluster
+	}
+	return foundSeedName, foundCluster, nil
+}
+
+type errMultipleSeeds struct {
+	cluster string
+	seeds   []string
+}
+
+func (e errMultipleSeeds) Error() string {
+	return fmt.Sprintf("cluster %q exists in the seeds %s, another migration might be in progress", e.cluster, strings.Join(e.seeds, " and "))
+}
+
+// getCluster returns the client of the seed and the cluster with the given name, the cluster is nil
+// if it does not exist in the seed
+func (r *Reconciler) getCluster(seedName, name string) (ctrlruntimeclient.Client, *kubermaticv1.Cluster, error) {
+	seedClient, ok := r.seedClients[seedName]
+	if !ok {
+		return nil, nil, fmt.Errorf("no client for seed %q", seedName)
+	}
+	cluster := &kubermaticv1.Cluster{}
+	if err := seedClient.Get(r.ctx, types.NamespacedName{Name: name}, cluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			return seedClient, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to get cluster %q from seed %q: %v", name, seedName, err)
+	}
+	return seedClient, cluster, nil
+}
+
+func findDatacenter(seeds map[string]*kubermaticv1.Seed, name string) (*kubermaticv1.Seed, *kubermaticv1.Datacenter) {
+	for _, seed := range seeds {
+		if datacenter, ok := seed.Spec.Datacenters[name]; ok {
+			return seed, &datacenter
+		}
+	}
+	return nil, nil
+}
+
+// migratedCluster returns the cluster for the target seed. It keeps the address of the cluster, the
+// external name is pinned as the target seed would assign one within its own DNS zone otherwise.
+func migratedCluster(migration *kubermaticv1.ClusterMigration, cluster *kubermaticv1.Cluster) *kubermaticv1.Cluster {
+	migrated := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cluster.Name,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec:    *cluster.Spec.DeepCopy(),
+		Address: cluster.Address,
+		Status:  *cluster.Status.DeepCopy(),
+	}
+	for key, value := range cluster.Labels {
+		migrated.Labels[key] = value
+	}
+	for key, value := range cluster.Annotations {
+		migrated.Annotations[key] = value
+	}
+	migrated.Annotations[kubermaticv1.ClusterMigrationAnnotation] = migration.Name
+	if migrated.Annotations[kubermaticv1.ClusterExternalNameAnnotation] == "" {
+		migrated.Annotations[kubermaticv1.ClusterExternalNameAnnotation] = cluster.Address.ExternalName
+	}
+
+	migrated.Spec.Cloud.DatacenterName = migration.Spec.TargetDatacenter
+	migrated.Spec.Pause = true
+	migrated.Spec.PauseReason = pauseReason(migration)
+
+	migrated.Status.ExtendedHealth = kubermaticv1.ExtendedClusterHealth{}
+	migrated.Status.Conditions = nil
+	migrated.Status.ErrorReason = nil
+	migrated.Status.ErrorMessage = nil
+	return migrated
+}
+
+func migratedSecret(secret *corev1.Secret, namespace string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secret.Name,
+			Namespace:   namespace,
+			Labels:      secret.Labels,
+			Annotations: secret.Annotations,
+		},
+		Type: secret.Type,
+		Data: secret.Data,
+	}
+}
+
+// migratedService returns the apiserver service for the target seed, it keeps the node port
+func migratedService(service *corev1.Service, namespace string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        service.Name,
+			Namespace:   namespace,
+			Labels:      service.Labels,
+			Annotations: service.Annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     service.Spec.Type,
+			Selector: service.Spec.Selector,
+			Ports:    service.Spec.Ports,
+		},
+	}
+}
+
+// dialAPIServer opens a TLS connection to the given URL, which succeeds only if the server has a
+// certificate signed by the given CA
+func dialAPIServer(address string, caCert []byte) error {
+	u, err := url.Parse(address)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %v", address, err)
+	}
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "443")
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caCert) {
+		return fmt.Errorf("invalid ca certificate")
+	}
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 5 * time.Second}, "tcp", host, &tls.Config{RootCAs: caPool, ServerName: u.Hostname()})
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// snapshotJob returns a Job running the backup CronJob of the cluster once, like
+// `kubectl create job --from=cronjob/...` would do. The Job is not owned by the CronJob,
+// as the CronJob controller would remove it before we read the name of the snapshot.
+func snapshotJob(migration *kubermaticv1.ClusterMigration, cronJob *batchv1beta1.CronJob) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-migration", cronJob.Name),
+			Namespace: cronJob.Namespace,
+			Labels: map[string]string{
+				resources.AppLabelKey: snapshotJobLabel,
+			},
+			Annotations: map[string]string{
+				kubermaticv1.ClusterMigrationAnnotation: migration.Name,
+			},
+		},
+		Spec: *cronJob.Spec.JobTemplate.Spec.DeepCopy(),
+	}
+}
+
+// snapshotName returns the name of the uploaded snapshot, which the store container reports
+// as its termination message
+func snapshotName(ctx context.Context, seedClient ctrlruntimeclient.Client, job *batchv1.Job) (string, error) {
+	pods := &corev1.PodList{}
+	if err := seedClient.List(ctx, pods, ctrlruntimeclient.InNamespace(job.Namespace), ctrlruntimeclient.MatchingLabels{"job-name": job.Name}); err != nil {
+		return "", fmt.Errorf("failed to list pods of snapshot job %q: %v", job.Name, err)
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Terminated != nil && strings.TrimSpace(status.State.Terminated.Message) != "" {
+				return strings.TrimSpace(status.State.Terminated.Message), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+func etcdRestore(migration *kubermaticv1.ClusterMigration, cluster *kubermaticv1.Cluster) *kubermaticv1.EtcdRestore {
+	return &kubermaticv1.EtcdRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      migration.Name,
+			Namespace: cluster.Status.NamespaceName,
+		},
+		Spec: kubermaticv1.EtcdRestoreSpec{
+			Cluster: corev1.ObjectReference{
+				APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+				Kind:       kubermaticv1.ClusterKindName,
+				Name:       cluster.Name,
+				UID:        cluster.UID,
+			},
+			BackupName: migration.Status.BackupName,
+		},
+	}
+}
+
+func deleteJob(ctx context.Context, seedClient ctrlruntimeclient.Client, job *batchv1.Job) error {
+	deletePropagationBackground := metav1.DeletePropagationBackground
+	if err := seedClient.Delete(ctx, job, &ctrlruntimeclient.DeleteOptions{PropagationPolicy: &deletePropagationBackground}); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete job %q: %v", job.Name, err)
+	}
+	return nil
+}
+
+func pauseReason(migration *kubermaticv1.ClusterMigration) string {
+	return fmt.Sprintf("cluster migration %s in progress", migration.Name)
+}
+
+func jobHasCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,562 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustermigration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	kubermaticlog "github.com/kubermatic/kubermatic/pkg/log"
+	"github.com/kubermatic/kubermatic/pkg/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	utilpointer "k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const snapshot = "test-cluster-storeuploader-2020-05-01T10:00:00-snapshot.db"
+
+func testSeeds() map[string]*kubermaticv1.Seed {
+	return map[string]*kubermaticv1.Seed{
+		"seed-old": {
+			ObjectMeta: metav1.ObjectMeta{Name: "seed-old"},
+			Spec: kubermaticv1.SeedSpec{
+				Datacenters: map[string]kubermaticv1.Datacenter{
+					"dc-old": {Spec: kubermaticv1.DatacenterSpec{Fake: &kubermaticv1.DatacenterSpecFake{}}},
+				},
+				BackupStore: "s3://etcd-backups",
+			},
+		},
+		"seed-new": {
+			ObjectMeta: metav1.ObjectMeta{Name: "seed-new"},
+			Spec: kubermaticv1.SeedSpec{
+				Datacenters: map[string]kubermaticv1.Datacenter{
+					"dc-new":       {Spec: kubermaticv1.DatacenterSpec{Fake: &kubermaticv1.DatacenterSpecFake{}}},
+					"dc-openstack": {Spec: kubermaticv1.DatacenterSpec{Openstack: &kubermaticv1.DatacenterSpecOpenstack{}}},
+				},
+				BackupStore: "s3://etcd-backups",
+			},
+		},
+		"seed-other": {
+			ObjectMeta: metav1.ObjectMeta{Name: "seed-other"},
+			Spec: kubermaticv1.SeedSpec{
+				Datacenters: map[string]kubermaticv1.Datacenter{
+					"dc-other": {Spec: kubermaticv1.DatacenterSpec{Fake: &kubermaticv1.DatacenterSpecFake{}}},
+				},
+				BackupStore: "s3://other-backups",
+			},
+		},
+	}
+}
+
+func testCluster() *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-cluster",
+			Labels:     map[string]string{kubermaticv1.ProjectIDLabelKey: "my-project"},
+			Finalizers: []string{"kubermatic.io/cleanup-backups"},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Cloud:          kubermaticv1.CloudSpec{DatacenterName: "dc-old", Fake: &kubermaticv1.FakeCloudSpec{}},
+			ExposeStrategy: corev1.ServiceTypeNodePort,
+		},
+		Address: kubermaticv1.ClusterAddress{
+			ExternalName: "test-cluster.seed-old.example.com",
+			URL:          "https://test-cluster.seed-old.example.com:30443",
+			Port:         30443,
+			AdminToken:   "abcdef.0123456789abcdef",
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-test-cluster",
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+				Etcd:      kubermaticv1.HealthStatusUp,
+				Apiserver: kubermaticv1.HealthStatusUp,
+			},
+		},
+	}
+}
+
+func testMigration(targetDatacenter string) *kubermaticv1.ClusterMigration {
+	return &kubermaticv1.ClusterMigration{
+		ObjectMeta: metav1.ObjectMeta{Name: "move-test-cluster"},
+		Spec: kubermaticv1.ClusterMigrationSpec{
+			ClusterName:      "test-cluster",
+			TargetDatacenter: targetDatacenter,
+		},
+	}
+}
+
+func newTestReconciler(migration *kubermaticv1.ClusterMigration, sourceObjs ...runtime.Object) *Reconciler {
+	seeds := testSeeds()
+	return &Reconciler{
+		ctx:      context.Background(),
+		log:      kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		client:   ctrlruntimefakeclient.NewFakeClient(migration),
+		recorder: record.NewFakeRecorder(10),
+		seedsGetter: func() (map[string]*kubermaticv1.Seed, error) {
+			return seeds, nil
+		},
+		seedClients: map[string]ctrlruntimeclient.Client{
+			"seed-old":   ctrlruntimefakeclient.NewFakeClient(sourceObjs...),
+			"seed-new":   ctrlruntimefakeclient.NewFakeClient(),
+			"seed-other": ctrlruntimefakeclient.NewFakeClient(),
+		},
+		dialAPIServer: func(string, []byte) error {
+			return nil
+		},
+	}
+}
+
+func TestClusterMigration(t *testing.T) {
+	ctx := context.Background()
+	cluster := testCluster()
+	migration := testMigration("dc-new")
+	apiserver := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: resources.ApiserverDeploymentName, Namespace: cluster.Status.NamespaceName},
+		Spec:       appsv1.DeploymentSpec{Replicas: utilpointer.Int32Ptr(2)},
+	}
+	cronJob := &batchv1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd-backup-test-cluster", Namespace: metav1.NamespaceSystem},
+		Spec: batchv1beta1.CronJobSpec{JobTemplate: batchv1beta1.JobTemplateSpec{Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "store-container"}}}},
+		}}},
+	}
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: resources.CASecretName, Namespace: cluster.Status.NamespaceName},
+		Data:       map[string][]byte{resources.CACertSecretKey: []byte("ca")},
+	}
+	apiserverService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: resources.ApiserverExternalServiceName, Namespace: cluster.Status.NamespaceName},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeNodePort,
+			Ports: []corev1.ServicePort{{Name: "secure", Port: 30443, NodePort: 30443}},
+		},
+	}
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-token-abcde", Namespace: cluster.Status.NamespaceName},
+		Type:       corev1.SecretTypeServiceAccountToken,
+	}
+
+	r := newTestReconciler(migration, cluster, apiserver, cronJob, caSecret, apiserverService, tokenSecret)
+	source, target := r.seedClients["seed-old"], r.seedClients["seed-new"]
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: migration.Name}}
+
+	reconcileAndExpectPhase := func(expected kubermaticv1.ClusterMigrationPhase) {
+		t.Helper()
+		if _, err := r.Reconcile(request); err != nil {
+			t.Fatalf("failed to reconcile: %v", err)
+		}
+		if err := r.client.Get(ctx, request.NamespacedName, migration); err != nil {
+			t.Fatalf("failed to get migration: %v", err)
+		}
+		if migration.Status.Phase != expected {
+			t.Fatalf("expected phase %q, got %q (message: %q)", expected, migration.Status.Phase, migration.Status.Message)
+		}
+	}
+
+	reconcileAndExpectPhase(kubermaticv1.ClusterMigrationPhaseBackingUp)
+	if migration.Status.SourceSeed != "seed-old" || migration.Status.TargetSeed != "seed-new" {
+		t.Fatalf("expected migration from seed-old to seed-new, got %q to %q", migration.Status.SourceSeed, migration.Status.TargetSeed)
+	}
+	if len(migration.Finalizers) != 1 || migration.Finalizers[0] != rollbackFinalizer {
+		t.Errorf("expected the rollback finalizer, got %v", migration.Finalizers)
+	}
+	if err := source.Get(ctx, types.NamespacedName{Name: cluster.Name}, cluster); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	if !cluster.Spec.Pause {
+		t.Fatal("expected cluster to be paused")
+	}
+
+	// Scaled down, but the apiserver pods are still running
+	apiserver.Status.Replicas = 2
+	if err := source.Update(ctx, apiserver); err != nil {
+		t.Fatalf("failed to update apiserver: %v", err)
+	}
+	reconcileAndExpectPhase(kubermaticv1.ClusterMigrationPhaseBackingUp)
+	if err := source.Get(ctx, types.NamespacedName{Namespace: apiserver.Namespace, Name: apiserver.Name}, apiserver); err != nil {
+		t.Fatalf("failed to get apiserver: %v", err)
+	}
+	if *apiserver.Spec.Replicas != 0 {
+		t.Fatalf("expected apiserver to be scaled down, has %d replicas", *apiserver.Spec.Replicas)
+	}
+	apiserver.Status.Replicas = 0
+	if err := source.Update(ctx, apiserver); err != nil {
+		t.Fatalf("failed to update apiserver: %v", err)
+	}
+
+	// Creates the snapshot job
+	reconcileAndExpectPhase(kubermaticv1.ClusterMigrationPhaseBackingUp)
+	job := &batchv1.Job{}
+	if err := source.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: "etcd-backup-test-cluster-migration"}, job); err != nil {
+		t.Fatalf("failed to get snapshot job: %v", err)
+	}
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	if err := source.Update(ctx, job); err != nil {
+		t.Fatalf("failed to update job: %v", err)
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: job.Name + "-xyz", Namespace: job.Namespace, Labels: map[string]string{"job-name": job.Name}},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "store-container",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: snapshot}},
+			}},
+		},
+	}
+	if err := source.Create(ctx, pod); err != nil {
+		t.Fatalf("failed to create pod: %v", err)
+	}
+	reconcileAndExpectPhase(kubermaticv1.ClusterMigrationPhaseProvisioning)
+	if migration.Status.BackupName != snapshot {
+		t.Fatalf("expected backup %q, got %q", snapshot, migration.Status.BackupName)
+	}
+
+	reconcileAndExpectPhase(kubermaticv1.ClusterMigrationPhaseRestoring)
+	migrated := &kubermaticv1.Cluster{}
+	if err := target.Get(ctx, types.NamespacedName{Name: cluster.Name}, migrated); err != nil {
+		t.Fatalf("failed to get migrated cluster: %v", err)
+	}
+	if migrated.Spec.Pause {
+		t.Error("expected migrated cluster to be unpaused")
+	}
+	if migrated.Spec.Cloud.DatacenterName != "dc-new" {
+		t.Errorf("expected migrated cluster in datacenter dc-new, got %q", migrated.Spec.Cloud.DatacenterName)
+	}
+	if migrated.Address != cluster.Address {
+		t.Errorf("expected the migrated cluster to keep the address %+v, got %+v", cluster.Address, migrated.Address)
+	}
+	if pinnedName := migrated.Annotations[kubermaticv1.ClusterExternalNameAnnotation]; pinnedName != cluster.Address.ExternalName {
+		t.Errorf("expected the external name %q to be pinned, got %q", cluster.Address.ExternalName, pinnedName)
+	}
+	migratedService := &corev1.Service{}
+	if err := target.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.ApiserverExternalServiceName}, migratedService); err != nil {
+		t.Errorf("expected the apiserver service to be copied: %v", err)
+	} else if nodePort := migratedService.Spec.Ports[0].NodePort; nodePort != 30443 {
+		t.Errorf("expected the apiserver service to keep node port 30443, got %d", nodePort)
+	}
+	if err := target.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.CASecretName}, &corev1.Secret{}); err != nil {
+		t.Errorf("expected the ca secret to be copied: %v", err)
+	}
+	if err := target.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: tokenSecret.Name}, &corev1.Secret{}); err == nil {
+		t.Error("expected service account tokens not to be copied")
+	}
+
+	// Waits for etcd in the target seed before the restore gets created
+	reconcileAndExpectPhase(kubermaticv1.ClusterMigrationPhaseRestoring)
+	migrated.Status.ExtendedHealth.Etcd = kubermaticv1.HealthStatusUp
+	if err := target.Update(ctx, migrated); err != nil {
+		t.Fatalf("failed to update migrated cluster: %v", err)
+	}
+	reconcileAndExpectPhase(kubermaticv1.ClusterMigrationPhaseRestoring)
+	restore := &kubermaticv1.EtcdRestore{}
+	if err := target.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: migration.Name}, restore); err != nil {
+		t.Fatalf("failed to get etcd restore: %v", err)
+	}
+	if restore.Spec.BackupName != snapshot {
+		t.Errorf("expected restore of backup %q, got %q", snapshot, restore.Spec.BackupName)
+	}
+	restore.Status.Phase = kubermaticv1.EtcdRestorePhaseCompleted
+	if err := target.Update(ctx, restore); err != nil {
+		t.Fatalf("failed to update etcd restore: %v", err)
+	}
+	reconcileAndExpectPhase(kubermaticv1.ClusterMigrationPhaseSwitchingOver)
+	if migration.Status.SwitchOverStartTime == nil {
+		t.Error("expected the start of the switch over to be recorded")
+	}
+
+	if err := target.Get(ctx, types.NamespacedName{Name: cluster.Name}, migrated); err != nil {
+		t.Fatalf("failed to get migrated cluster: %v", err)
+	}
+	migrated.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusUp
+	if err := target.Update(ctx, migrated); err != nil {
+		t.Fatalf("failed to update migrated cluster: %v", err)
+	}
+
+	// The source is kept until the admin confirmed the DNS switch
+	r.dialAPIServer = func(string, []byte) error {
+		return nil
+	}
+	reconcileAndExpectPhase(kubermaticv1.ClusterMigrationPhaseSwitchingOver)
+	if expected := "Waiting for test-cluster.seed-old.example.com to be pointed to the nodeport-proxy of seed seed-new and spec.dnsSwitchedOver to be set"; migration.Status.Message != expected {
+		t.Errorf("expected message %q, got %q", expected, migration.Status.Message)
+	}
+	if err := source.Get(ctx, types.NamespacedName{Name: cluster.Name}, &kubermaticv1.Cluster{}); err != nil {
+		t.Errorf("expected cluster to be kept in the source seed: %v", err)
+	}
+	migration.Spec.DNSSwitchedOver = true
+	if err := r.client.Update(ctx, migration); err != nil {
+		t.Fatalf("failed to update migration: %v", err)
+	}
+
+	// The source is kept until the address of the cluster points to the target seed
+	r.dialAPIServer = func(address string, caCert []byte) error {
+		if address != cluster.Address.URL || string(caCert) != "ca" {
+			t.Errorf("unexpected dial of %q with ca %q", address, caCert)
+		}
+		return errors.New("connection refused")
+	}
+	reconcileAndExpectPhase(kubermaticv1.ClusterMigrationPhaseSwitchingOver)
+	if expected := "Waiting for test-cluster.seed-old.example.com to point to the nodeport-proxy of seed seed-new: connection refused"; migration.Status.Message != expected {
+		t.Errorf("expected message %q, got %q", expected, migration.Status.Message)
+	}
+	if err := source.Get(ctx, types.NamespacedName{Name: cluster.Name}, &kubermaticv1.Cluster{}); err != nil {
+		t.Errorf("expected cluster to be kept in the source seed: %v", err)
+	}
+
+	r.dialAPIServer = func(string, []byte) error {
+		return nil
+	}
+	reconcileAndExpectPhase(kubermaticv1.ClusterMigrationPhaseCompleted)
+	if expected := "The cluster is served by seed seed-new at test-cluster.seed-old.example.com"; migration.Status.Message != expected {
+		t.Errorf("expected message %q, got %q", expected, migration.Status.Message)
+	}
+	if err := source.Get(ctx, types.NamespacedName{Name: cluster.Name}, cluster); err == nil {
+		t.Error("expected cluster to be removed from the source seed")
+	}
+}
+
+func TestClusterMigrationValidation(t *testing.T) {
+	testCases := []struct {
+		name             string
+		targetDatacenter string
+		cluster          *kubermaticv1.Cluster
+		expectedMessage  string
+	}{
+		{
+			name:             "unknown datacenter",
+			targetDatacenter: "dc-unknown",
+			cluster:          testCluster(),
+			expectedMessage:  `datacenter "dc-unknown" does not exist`,
+		},
+		{
+			name:             "same seed",
+			targetDatacenter: "dc-old",
+			cluster:          testCluster(),
+			expectedMessage:  `cluster "test-cluster" already runs in seed "seed-old"`,
+		},
+		{
+			name:             "different cloud provider",
+			targetDatacenter: "dc-openstack",
+			cluster:          testCluster(),
+			expectedMessage:  `datacenter "dc-openstack" uses the cloud provider "openstack", but the cluster uses "fake"`,
+		},
+		{
+			name:             "different backup store",
+			targetDatacenter: "dc-other",
+			cluster:          testCluster(),
+			expectedMessage:  `the seeds "seed-old" and "seed-other" must use the same backup store`,
+		},
+		{
+			name:             "exposed by a LoadBalancer",
+			targetDatacenter: "dc-new",
+			cluster: func() *kubermaticv1.Cluster {
+				cluster := testCluster()
+				cluster.Spec.ExposeStrategy = corev1.ServiceTypeLoadBalancer
+				return cluster
+			}(),
+			expectedMessage: "clusters exposed by a LoadBalancer can not keep their address in another seed",
+		},
+		{
+			name:             "paused cluster",
+			targetDatacenter: "dc-new",
+			cluster: func() *kubermaticv1.Cluster {
+				cluster := testCluster()
+				cluster.Spec.Pause = true
+				cluster.Spec.PauseReason = "maintenance"
+				return cluster
+			}(),
+			expectedMessage: "cluster is already paused: maintenance",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			migration := testMigration(tc.targetDatacenter)
+			r := newTestReconciler(migration, tc.cluster)
+			if _, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: migration.Name}}); err != nil {
+				t.Fatalf("failed to reconcile: %v", err)
+			}
+			if err := r.client.Get(context.Background(), types.NamespacedName{Name: migration.Name}, migration); err != nil {
+				t.Fatalf("failed to get migration: %v", err)
+			}
+			if migration.Status.Phase != kubermaticv1.ClusterMigrationPhaseFailed || migration.Status.Message != tc.expectedMessage {
+				t.Errorf("expected to fail with %q, got phase %q with %q", tc.expectedMessage, migration.Status.Phase, migration.Status.Message)
+			}
+		})
+	}
+}
+
+func TestClusterMigrationDeletion(t *testing.T) {
+	ctx := context.Background()
+	now := metav1.Now()
+	migration := testMigration("dc-new")
+	migration.DeletionTimestamp = &now
+	migration.Finalizers = []string{rollbackFinalizer}
+	migration.Status = kubermaticv1.ClusterMigrationStatus{
+		Phase:      kubermaticv1.ClusterMigrationPhaseRestoring,
+		SourceSeed: "seed-old",
+		TargetSeed: "seed-new",
+	}
+	cluster := testCluster()
+	cluster.Spec.Pause = true
+	cluster.Spec.PauseReason = pauseReason(migration)
+
+	r := newTestReconciler(migration, cluster)
+	source, target := r.seedClients["seed-old"], r.seedClients["seed-new"]
+	if err := target.Create(ctx, migratedCluster(migration, cluster)); err != nil {
+		t.Fatalf("failed to create migrated cluster: %v", err)
+	}
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: migration.Name}}
+	if _, err := r.Reconcile(request); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+
+	if err := source.Get(ctx, types.NamespacedName{Name: cluster.Name}, cluster); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	if cluster.Spec.Pause {
+		t.Error("expected cluster to be unpaused in the source seed")
+	}
+	if err := target.Get(ctx, types.NamespacedName{Name: cluster.Name}, &kubermaticv1.Cluster{}); err == nil {
+		t.Error("expected migrated cluster to be removed from the target seed")
+	}
+	deleted := &kubermaticv1.ClusterMigration{}
+	if err := r.client.Get(ctx, request.NamespacedName, deleted); err != nil {
+		t.Fatalf("failed to get migration: %v", err)
+	}
+	if len(deleted.Finalizers) != 0 {
+		t.Errorf("expected the finalizer to be removed, got %v", deleted.Finalizers)
+	}
+}
+
+func TestClusterMigrationSwitchOverTimeout(t *testing.T) {
+	ctx := context.Background()
+	startTime := metav1.NewTime(time.Now().Add(-2 * switchOverTimeout))
+	migration := testMigration("dc-new")
+	migration.Status = kubermaticv1.ClusterMigrationStatus{
+		Phase:               kubermaticv1.ClusterMigrationPhaseSwitchingOver,
+		SourceSeed:          "seed-old",
+		TargetSeed:          "seed-new",
+		SwitchOverStartTime: &startTime,
+	}
+	cluster := testCluster()
+	cluster.Spec.Pause = true
+	cluster.Spec.PauseReason = pauseReason(migration)
+
+	r := newTestReconciler(migration, cluster)
+	r.dialAPIServer = func(string, []byte) error {
+		return errors.New("connection refused")
+	}
+	source, target := r.seedClients["seed-old"], r.seedClients["seed-new"]
+	migrated := migratedCluster(migration, cluster)
+	migrated.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusUp
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: resources.CASecretName, Namespace: cluster.Status.NamespaceName},
+		Data:       map[string][]byte{resources.CACertSecretKey: []byte("ca")},
+	}
+	for _, obj := range []runtime.Object{migrated, caSecret} {
+		if err := target.Create(ctx, obj); err != nil {
+			t.Fatalf("failed to create object in the target seed: %v", err)
+		}
+	}
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: migration.Name}}
+	if _, err := r.Reconcile(request); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+
+	if err := r.client.Get(ctx, request.NamespacedName, migration); err != nil {
+		t.Fatalf("failed to get migration: %v", err)
+	}
+	if expected := "the DNS switch of test-cluster.seed-old.example.com to the nodeport-proxy of seed seed-new was not confirmed within 1h0m0s"; migration.Status.Phase != kubermaticv1.ClusterMigrationPhaseFailed || migration.Status.Message != expected {
+		t.Errorf("expected to fail with %q, got phase %q with %q", expected, migration.Status.Phase, migration.Status.Message)
+	}
+	if err := source.Get(ctx, types.NamespacedName{Name: cluster.Name}, cluster); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	if cluster.Spec.Pause {
+		t.Error("expected cluster to be unpaused in the source seed")
+	}
+	if err := target.Get(ctx, types.NamespacedName{Name: cluster.Name}, &kubermaticv1.Cluster{}); err == nil {
+		t.Error("expected migrated cluster to be removed from the target seed")
+	}
+}
+
+func TestClusterMigrationIsNotRolledBackAfterDNSSwitch(t *testing.T) {
+	ctx := context.Background()
+	startTime := metav1.NewTime(time.Now().Add(-2 * switchOverTimeout))
+	migration := testMigration("dc-new")
+	migration.Spec.DNSSwitchedOver = true
+	migration.Status = kubermaticv1.ClusterMigrationStatus{
+		Phase:               kubermaticv1.ClusterMigrationPhaseSwitchingOver,
+		SourceSeed:          "seed-old",
+		TargetSeed:          "seed-new",
+		SwitchOverStartTime: &startTime,
+	}
+	cluster := testCluster()
+	cluster.Spec.Pause = true
+	cluster.Spec.PauseReason = pauseReason(migration)
+
+	r := newTestReconciler(migration, cluster)
+	r.dialAPIServer = func(string, []byte) error {
+		return errors.New("connection refused")
+	}
+	source, target := r.seedClients["seed-old"], r.seedClients["seed-new"]
+	migrated := migratedCluster(migration, cluster)
+	migrated.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusUp
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: resources.CASecretName, Namespace: cluster.Status.NamespaceName},
+		Data:       map[string][]byte{resources.CACertSecretKey: []byte("ca")},
+	}
+	for _, obj := range []runtime.Object{migrated, caSecret} {
+		if err := target.Create(ctx, obj); err != nil {
+			t.Fatalf("failed to create object in the target seed: %v", err)
+		}
+	}
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: migration.Name}}
+	if _, err := r.Reconcile(request); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	if err := r.client.Get(ctx, request.NamespacedName, migration); err != nil {
+		t.Fatalf("failed to get migration: %v", err)
+	}
+	if migration.Status.Phase != kubermaticv1.ClusterMigrationPhaseSwitchingOver {
+		t.Errorf("expected migration to keep waiting for the address of the cluster, got phase %q with %q", migration.Status.Phase, migration.Status.Message)
+	}
+	if err := source.Get(ctx, types.NamespacedName{Name: cluster.Name}, &kubermaticv1.Cluster{}); err != nil {
+		t.Errorf("expected cluster to be kept in the source seed: %v", err)
+	}
+	if err := target.Get(ctx, types.NamespacedName{Name: cluster.Name}, &kubermaticv1.Cluster{}); err != nil {
+		t.Errorf("expected migrated cluster to be kept in the target seed: %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package clustermigration contains a controller that moves the control plane of a user cluster
+to another seed, as requested by a ClusterMigration object in the master cluster.
+
+The migration pauses the cluster in the source seed and stops its apiserver, then takes a
+snapshot of its etcd with a Job created from the backup CronJob of the cluster. The store
+container reports the name of the uploaded snapshot in its termination message. Afterwards the
+cluster, its namespace and the secrets holding its certificates and tokens are created in the
+target seed and the snapshot gets restored there with an EtcdRestore. Once the control plane in
+the target seed is healthy, the cluster is removed from the source seed without running any of
+its cleanups, as the cloud resources and backups are still used by the migrated cluster. A failed
+migration, or one which gets deleted before it finished, is rolled back: the cluster is removed from
+the target seed and unpaused in the source seed again.
+
+The cluster keeps its address, so the kubelets and kubeconfigs keep working. Its external name is
+pinned with an annotation and the apiserver service keeps its node port in the target seed. The
+controller does not manage DNS records: once the control plane in the target seed is healthy, the
+admin has to point the DNS record of the external name to the nodeport-proxy of the target seed and
+confirm this by setting spec.dnsSwitchedOver on the ClusterMigration. Only then, and once the
+apiserver answers at the address of the cluster, the cluster is removed from the source seed and the
+migration counts as completed. If the control plane does not become healthy or the DNS switch is not
+confirmed within an hour, the migration is rolled back. Clusters exposed by a LoadBalancer can't keep
+their address and can't be migrated.
+
+Both seeds must use the same backup store, otherwise the target seed can not download the snapshot,
+which is why the migration is only possible between seeds with the same BackupStore.
+*/
+package clustermigration
@@ -44,7 +44,8 @@ const (
 	saSecretsNamespaceName = "kubermatic"
 )
 
-// AllGroupsPrefixes holds a list of groups with prefixes that we will generate RBAC Roles/Binding for.
+// AllGroupsPrefixes holds a list of built-in groups with prefixes that we will generate RBAC Roles/Binding for.
+// RBAC Roles/Binding are also generated for the groups of project roles, see allGroupsPrefixes function
 //
 // Note:
 // adding a new group also requires updating generateVerbsForNamedResource method.
@@ -92,8 +93,8 @@ func generateRBACRoleNameForClusterNamespaceResource(kind, groupName string) str
 //   verbs: ["get"]
 //
 // Note that for some kinds we don't want to generate ClusterRole in that case a nil cluster resource will be returned without an error
-func generateClusterRBACRoleNamedResource(kind, groupName, policyResource, policyAPIGroups, policyResourceName string, oRef metav1.OwnerReference) (*rbacv1.ClusterRole, error) {
-	verbs, err := generateVerbsForNamedResource(groupName, kind)
+func generateClusterRBACRoleNamedResource(roles *projectRoleRegistry, kind, groupName, policyResource, policyAPIGroups, policyResourceName string, oRef metav1.OwnerReference) (*rbacv1.ClusterRole, error) {
+	verbs, err := generateVerbsForNamedResource(roles, groupName, kind)
 	if err != nil {
 		return nil, err
 	}
@@ -143,8 +144,8 @@ func generateClusterRBACRoleBindingNamedResource(kind, resourceName, groupName s
 
 // generateClusterRBACRoleForResource generates ClusterRole for the given resource
 // Note that for some groups we don't want to generate ClusterRole in that case a nil will be returned
-func generateClusterRBACRoleForResource(groupName, policyResource, policyAPIGroups, kind string) (*rbacv1.ClusterRole, error) {
-	verbs, err := generateVerbsForResource(groupName, kind)
+func generateClusterRBACRoleForResource(roles *projectRoleRegistry, groupName, policyResource, policyAPIGroups, kind string) (*rbacv1.ClusterRole, error) {
+	verbs, err := generateVerbsForResource(roles, groupName, kind)
 	if err != nil {
 		return nil, err
 	}
@@ -211,8 +212,8 @@ func generateRBACRoleBindingForResource(resourceName, groupName, namespace strin
 
 // generateRBACRoleForResource generates Role for the given resource in the given namespace
 // Note that for some groups we don't want to generate Role in that case a nil will be returned
-func generateRBACRoleForResource(groupName, policyResource, policyAPIGroups, kind string, namespace string) (*rbacv1.Role, error) {
-	verbs, err := generateVerbsForNamespacedResource(groupName, kind, namespace)
+func generateRBACRoleForResource(roles *projectRoleRegistry, groupName, policyResource, policyAPIGroups, kind string, namespace string) (*rbacv1.Role, error) {
+	verbs, err := generateVerbsForNamespacedResource(roles, groupName, kind, namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -245,8 +246,8 @@ func generateRBACRoleForResource(groupName, policyResource, policyAPIGroups, kin
 //   verbs: ["get"]
 //
 // Note that for some kinds we don't want to generate Role in that case a nil cluster resource will be returned without an error
-func generateRBACRoleNamedResource(kind, groupName, policyResource, policyAPIGroups, policyResourceName string, namespace string, oRef metav1.OwnerReference) (*rbacv1.Role, error) {
-	verbs, err := generateVerbsForNamedResourceInNamespace(groupName, kind, namespace)
+func generateRBACRoleNamedResource(roles *projectRoleRegistry, kind, groupName, policyResource, policyAPIGroups, policyResourceName string, namespace string, oRef metav1.OwnerReference) (*rbacv1.Role, error) {
+	verbs, err := generateVerbsForNamedResourceInNamespace(roles, groupName, kind, namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -298,8 +299,8 @@ func generateRBACRoleBindingNamedResource(kind, resourceName, groupName, namespa
 
 // generateRBACRoleForClusterNamespaceResource generates per-cluster Role for the given cluster in the cluster namespace
 // Note that for some groups we don't want to generate Role in that case a nil will be returned
-func generateRBACRoleForClusterNamespaceResource(cluster *kubermaticv1.Cluster, groupName, policyResource, policyAPIGroups, kind string) (*rbacv1.Role, error) {
-	verbs, err := generateVerbsForClusterNamespaceResource(cluster, groupName, kind)
+func generateRBACRoleForClusterNamespaceResource(roles *projectRoleRegistry, cluster *kubermaticv1.Cluster, groupName, policyResource, policyAPIGroups, kind string) (*rbacv1.Role, error) {
+	verbs, err := generateVerbsForClusterNamespaceResource(roles, cluster, groupName, kind)
 	if err != nil {
 		return nil, err
 	}
@@ -348,7 +349,12 @@ func generateRBACRoleBindingForClusterNamespaceResource(cluster *kubermaticv1.Cl
 
 // generateVerbsForNamedResource generates a set of verbs for a named resource
 // for example a "cluster" named "beefy-john"
-func generateVerbsForNamedResource(groupName, resourceKind string) ([]string, error) {
+func generateVerbsForNamedResource(roles *projectRoleRegistry, groupName, resourceKind string) ([]string, error) {
+	// verbs for project roles
+	if role, ok := roles.roleFor(groupName); ok {
+		return generateVerbsForProjectRole(role, resourceKind, kubermaticv1.ProjectRoleVerbGet, kubermaticv1.ProjectRoleVerbUpdate, kubermaticv1.ProjectRoleVerbDelete), nil
+	}
+
 	// verbs for owners
 	//
 	// owners of a named resource
@@ -398,7 +404,12 @@ func generateVerbsForNamedResource(groupName, resourceKind string) ([]string, er
 
 // generateVerbsForResource generates verbs for a resource for example "cluster"
 // to make it even more concrete, if there is "create" verb returned for owners group, that means that the owners can create "cluster" resources.
-func generateVerbsForResource(groupName, resourceKind string) ([]string, error) {
+func generateVerbsForResource(roles *projectRoleRegistry, groupName, resourceKind string) ([]string, error) {
+	// verbs for project roles
+	if role, ok := roles.roleFor(groupName); ok {
+		return generateVerbsForProjectRole(role, resourceKind, kubermaticv1.ProjectRoleVerbCreate), nil
+	}
+
 	// special case - only the owners of a project can manipulate members
 	//
 	if strings.HasPrefix(groupName, OwnerGroupNamePrefix) && resourceKind == kubermaticv1.UserProjectBindingKind {
@@ -433,11 +444,14 @@ func generateVerbsForResource(groupName, resourceKind string) ([]string, error)
 	return nil, fmt.Errorf("unable to generate verbs, unknown group name passed in = %s", groupName)
 }
 
-func generateVerbsForNamespacedResource(groupName, resourceKind, namespace string) ([]string, error) {
+func generateVerbsForNamespacedResource(roles *projectRoleRegistry, groupName, resourceKind, namespace string) ([]string, error) {
 	// special case - only the owners of a project can create secrets in "saSecretsNamespaceName" namespace
 	//
 	if namespace == saSecretsNamespaceName {
 		secretV1Kind := "Secret"
+		if role, ok := roles.roleFor(groupName); ok && resourceKind == secretV1Kind {
+			return generateVerbsForProjectRole(role, resourceKind, kubermaticv1.ProjectRoleVerbCreate), nil
+		}
 		if strings.HasPrefix(groupName, OwnerGroupNamePrefix) && resourceKind == secretV1Kind {
 			return []string{"create"}, nil
 		} else if resourceKind == secretV1Kind {
@@ -451,11 +465,14 @@ func generateVerbsForNamespacedResource(groupName, resourceKind, namespace strin
 
 // generateVerbsForNamedResourceInNamespace generates a set of verbs for a named resource in a given namespace
 // for example a "cluster" named "beefy-john"
-func generateVerbsForNamedResourceInNamespace(groupName, resourceKind, namespace string) ([]string, error) {
+func generateVerbsForNamedResourceInNamespace(roles *projectRoleRegistry, groupName, resourceKind, namespace string) ([]string, error) {
 	// special case - only the owners of a project can manipulate secrets in "ssaSecretsNamespaceNam" namespace
 	//
 	if namespace == saSecretsNamespaceName {
 		secretV1Kind := "Secret"
+		if role, ok := roles.roleFor(groupName); ok && resourceKind == secretV1Kind {
+			return generateVerbsForProjectRole(role, resourceKind, kubermaticv1.ProjectRoleVerbGet, kubermaticv1.ProjectRoleVerbUpdate, kubermaticv1.ProjectRoleVerbDelete), nil
+		}
 		if strings.HasPrefix(groupName, OwnerGroupNamePrefix) && resourceKind == secretV1Kind {
 			return []string{"get", "update", "delete"}, nil
 		} else if resourceKind == secretV1Kind {
@@ -467,7 +484,16 @@ func generateVerbsForNamedResourceInNamespace(groupName, resourceKind, namespace
 	return nil, fmt.Errorf("unable to generate verbs for group = %s, kind = %s, namespace = %s", groupName, resourceKind, namespace)
 }
 
-func generateVerbsForClusterNamespaceResource(cluster *kubermaticv1.Cluster, groupName, kind string) ([]string, error) {
+func generateVerbsForClusterNamespaceResource(roles *projectRoleRegistry, cluster *kubermaticv1.Cluster, groupName, kind string) ([]string, error) {
+	// verbs for project roles, getting a resource includes listing them
+	if role, ok := roles.roleFor(groupName); ok {
+		verbs := generateVerbsForProjectRole(role, kind, kubermaticv1.AllProjectRoleVerbs...)
+		if len(verbs) > 0 && verbs[0] == string(kubermaticv1.ProjectRoleVerbGet) {
+			verbs = append([]string{verbs[0], "list"}, verbs[1:]...)
+		}
+		return verbs, nil
+	}
+
 	if strings.HasPrefix(groupName, ViewerGroupNamePrefix) && kind == kubermaticv1.AddonKindName {
 		return []string{"get", "list"}, nil
 	}
@@ -0,0 +1,241 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// OperatorGroupNamePrefix represents the group prefix of the built-in operators role,
+	// operators can manage the nodes of clusters but can't delete clusters or manage members
+	OperatorGroupNamePrefix = "operators"
+)
+
+// DefaultProjectRoles holds the project roles which are always available, in addition to the
+// ProjectRoles defined by admins
+var DefaultProjectRoles = []kubermaticv1.ProjectRole{
+	{
+		ObjectMeta: metav1.ObjectMeta{Name: OperatorGroupNamePrefix},
+		Spec: kubermaticv1.ProjectRoleSpec{
+			HumanReadableName: "Cluster Operator",
+			Rules: []kubermaticv1.ProjectRoleRule{
+				{
+					Resource: kubermaticv1.ProjectRoleResourceClusters,
+					Verbs:    []kubermaticv1.ProjectRoleVerb{kubermaticv1.ProjectRoleVerbGet, kubermaticv1.ProjectRoleVerbUpdate},
+				},
+				{
+					Resource: kubermaticv1.ProjectRoleResourceNodeDeployments,
+					Verbs:    kubermaticv1.AllProjectRoleVerbs,
+				},
+				{
+					Resource: kubermaticv1.ProjectRoleResourceSSHKeys,
+					Verbs:    []kubermaticv1.ProjectRoleVerb{kubermaticv1.ProjectRoleVerbGet},
+				},
+				{
+					Resource: kubermaticv1.ProjectRoleResourceAddons,
+					Verbs:    []kubermaticv1.ProjectRoleVerb{kubermaticv1.ProjectRoleVerbGet},
+				},
+			},
+		},
+	},
+}
+
+// projectRoleNameRegex restricts the names of project roles, they must not contain dashes
+// as the name is used as the group prefix, see ExtractGroupPrefix
+var projectRoleNameRegex = regexp.MustCompile(`^[a-z][a-z0-9]{0,31}$`)
+
+// ValidateProjectRoleName checks that name can be used for a custom ProjectRole
+func ValidateProjectRoleName(name string) error {
+	if !projectRoleNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid name %q, must consist of at most 32 lower case alphanumeric characters and start with a letter", name)
+	}
+	for _, prefix := range AllGroupsPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return fmt.Errorf("invalid name %q, must not start with the built-in group %q", name, prefix)
+		}
+	}
+	for _, role := range DefaultProjectRoles {
+		if name == role.Name {
+			return fmt.Errorf("invalid name %q, a default role with this name already exists", name)
+		}
+	}
+	return nil
+}
+
+// IsBuiltInGroupPrefix returns true for the owners, editors and viewers groups
+func IsBuiltInGroupPrefix(groupPrefix string) bool {
+	for _, prefix := range AllGroupsPrefixes {
+		if prefix == groupPrefix {
+			return true
+		}
+	}
+	return false
+}
+
+// projectRoleRegistry holds the project roles the generator creates RBAC Roles/Bindings for, in addition to AllGroupsPrefixes.
+// It is kept up to date by the project controller and shared with the resources controller.
+type projectRoleRegistry struct {
+	lock  sync.RWMutex
+	roles map[string]kubermaticv1.ProjectRoleSpec
+	// synced is false until the roles were set from the ProjectRoles of the cluster
+	synced bool
+}
+
+// newProjectRoleRegistry returns a registry which contains the DefaultProjectRoles
+func newProjectRoleRegistry() *projectRoleRegistry {
+	r := &projectRoleRegistry{}
+	r.set(nil)
+	r.synced = false
+	return r
+}
+
+// set replaces the custom roles with the given ones, it returns true if the roles changed since the last call
+func (r *projectRoleRegistry) set(roles []kubermaticv1.ProjectRole) bool {
+	newRoles := map[string]kubermaticv1.ProjectRoleSpec{}
+	for _, role := range roles {
+		if ValidateProjectRoleName(role.Name) != nil {
+			continue
+		}
+		newRoles[role.Name] = *role.Spec.DeepCopy()
+	}
+	for _, role := range DefaultProjectRoles {
+		newRoles[role.Name] = *role.Spec.DeepCopy()
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	changed := !r.synced || !equality.Semantic.DeepEqual(r.roles, newRoles)
+	r.roles = newRoles
+	r.synced = true
+	return changed
+}
+
+func (r *projectRoleRegistry) get(groupPrefix string) (*kubermaticv1.ProjectRoleSpec, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	spec, ok := r.roles[groupPrefix]
+	if !ok {
+		return nil, false
+	}
+	return &spec, true
+}
+
+func (r *projectRoleRegistry) groupPrefixes() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	prefixes := make([]string, 0, len(r.roles))
+	for name := range r.roles {
+		prefixes = append(prefixes, name)
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
+
+// allGroupsPrefixes returns AllGroupsPrefixes followed by the names of all project roles
+func (r *projectRoleRegistry) allGroupsPrefixes() []string {
+	return append(append([]string{}, AllGroupsPrefixes...), r.groupPrefixes()...)
+}
+
+// roleFor returns the project role of the given group, if it is not one of the built-in groups
+func (r *projectRoleRegistry) roleFor(groupName string) (*kubermaticv1.ProjectRoleSpec, bool) {
+	return r.get(ExtractGroupPrefix(groupName))
+}
+
+// isGeneratedForProjectRole returns true if name is the name of a RBAC Role/Binding generated for the group of one of
+// the given project roles, see generateRBACRoleNameForResources, generateRBACRoleNameForNamedResource and
+// generateRBACRoleNameForClusterNamespaceResource
+func isGeneratedForProjectRole(name string, roleNames sets.String) bool {
+	parts := strings.Split(name, ":")
+	if len(parts) != 3 || parts[0] != RBACResourcesNamePrefix {
+		return false
+	}
+	return roleNames.Has(ExtractGroupPrefix(parts[2]))
+}
+
+// projectRoleResourceFor maps the kind of a Kubernetes resource to the resource used in ProjectRole rules
+func projectRoleResourceFor(kind string) (kubermaticv1.ProjectRoleResource, bool) {
+	switch kind {
+	case kubermaticv1.ClusterKindName, kubermaticv1.ClusterTemplateKindName:
+		return kubermaticv1.ProjectRoleResourceClusters, true
+	case kubermaticv1.SSHKeyKind:
+		return kubermaticv1.ProjectRoleResourceSSHKeys, true
+	case kubermaticv1.AddonKindName:
+		return kubermaticv1.ProjectRoleResourceAddons, true
+	case kubermaticv1.UserProjectBindingKind:
+		return kubermaticv1.ProjectRoleResourceMembers, true
+	case kubermaticv1.UserKindName, "Secret":
+		// service accounts are users, their tokens are secrets
+		return kubermaticv1.ProjectRoleResourceServiceAccounts, true
+	}
+	return "", false
+}
+
+// generateVerbsForProjectRole returns the Kubernetes verbs the role allows on the given kind, limited to allowedVerbs
+func generateVerbsForProjectRole(role *kubermaticv1.ProjectRoleSpec, kind string, allowedVerbs ...kubermaticv1.ProjectRoleVerb) []string {
+	// members of a role can always see the project
+	if kind == kubermaticv1.ProjectKindName {
+		for _, verb := range allowedVerbs {
+			if verb == kubermaticv1.ProjectRoleVerbGet {
+				return []string{string(verb)}
+			}
+		}
+		return nil
+	}
+
+	resource, ok := projectRoleResourceFor(kind)
+	if !ok {
+		return nil
+	}
+	var verbs []string
+	for _, verb := range allowedVerbs {
+		if role.Allows(resource, verb) {
+			verbs = append(verbs, string(verb))
+		}
+	}
+	return verbs
+}
+
+// NodeDeploymentsGroupNameFor returns the group which grants the verb on node deployments inside user clusters
+func NodeDeploymentsGroupNameFor(verb kubermaticv1.ProjectRoleVerb) string {
+	return fmt.Sprintf("%s-%s", kubermaticv1.ProjectRoleResourceNodeDeployments, verb)
+}
+
+// UserClusterGroupsFor returns the groups a member of a project role is impersonated as inside user clusters.
+// Group names inside user clusters don't have the projectID in their names, the built-in groups are mapped
+// to their prefix and project roles to a group per verb they allow on node deployments.
+func UserClusterGroupsFor(groupName string, role *kubermaticv1.ProjectRoleSpec) []string {
+	groupPrefix := ExtractGroupPrefix(groupName)
+	if IsBuiltInGroupPrefix(groupPrefix) || role == nil {
+		return []string{groupPrefix}
+	}
+	groups := []string{}
+	for _, verb := range role.VerbsFor(kubermaticv1.ProjectRoleResourceNodeDeployments) {
+		groups = append(groups, NodeDeploymentsGroupNameFor(verb))
+	}
+	return groups
+}
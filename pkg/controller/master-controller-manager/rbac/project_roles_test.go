@@ -0,0 +1,302 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	kuberneteshelper "github.com/kubermatic/kubermatic/pkg/kubernetes"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeruntime "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestGenerateVerbsForProjectRoles(t *testing.T) {
+	roles := newProjectRoleRegistry()
+	roles.set([]kubermaticv1.ProjectRole{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "auditors"},
+			Spec: kubermaticv1.ProjectRoleSpec{
+				Rules: []kubermaticv1.ProjectRoleRule{
+					{
+						Resource: kubermaticv1.ProjectRoleResourceClusters,
+						Verbs:    []kubermaticv1.ProjectRoleVerb{kubermaticv1.ProjectRoleVerbGet},
+					},
+					{
+						Resource: kubermaticv1.ProjectRoleResourceMembers,
+						Verbs:    []kubermaticv1.ProjectRoleVerb{kubermaticv1.ProjectRoleVerbGet, kubermaticv1.ProjectRoleVerbCreate},
+					},
+					{
+						Resource: kubermaticv1.ProjectRoleResourceAddons,
+						Verbs:    []kubermaticv1.ProjectRoleVerb{kubermaticv1.ProjectRoleVerbGet},
+					},
+				},
+			},
+		},
+	})
+
+	tests := []struct {
+		name                  string
+		groupName             string
+		resourceKind          string
+		expectedNamedVerbs    []string
+		expectedResourceVerbs []string
+	}{
+		{
+			name:               "scenario 1: members of a custom role can get the project",
+			groupName:          "auditors-projectID",
+			resourceKind:       kubermaticv1.ProjectKindName,
+			expectedNamedVerbs: []string{"get"},
+		},
+		{
+			name:               "scenario 2: a custom role grants the verbs of its rules",
+			groupName:          "auditors-projectID",
+			resourceKind:       kubermaticv1.ClusterKindName,
+			expectedNamedVerbs: []string{"get"},
+		},
+		{
+			name:                  "scenario 3: a custom role can grant access to members",
+			groupName:             "auditors-projectID",
+			resourceKind:          kubermaticv1.UserProjectBindingKind,
+			expectedNamedVerbs:    []string{"get"},
+			expectedResourceVerbs: []string{"create"},
+		},
+		{
+			name:         "scenario 4: a custom role grants nothing on resources without a rule",
+			groupName:    "auditors-projectID",
+			resourceKind: kubermaticv1.SSHKeyKind,
+		},
+		{
+			name:               "scenario 5: operators can update but not delete clusters",
+			groupName:          "operators-projectID",
+			resourceKind:       kubermaticv1.ClusterKindName,
+			expectedNamedVerbs: []string{"get", "update"},
+		},
+		{
+			name:         "scenario 6: operators cannot manage members",
+			groupName:    "operators-projectID",
+			resourceKind: kubermaticv1.UserProjectBindingKind,
+		},
+		{
+			name:         "scenario 7: operators cannot manage service accounts",
+			groupName:    "operators-projectID",
+			resourceKind: kubermaticv1.UserKindName,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if returnedVerbs, err := generateVerbsForNamedResource(roles, test.groupName, test.resourceKind); err != nil || !equality.Semantic.DeepEqual(returnedVerbs, test.expectedNamedVerbs) {
+				t.Fatalf("incorrect verbs were returned for the named resource, got: %v, want: %v, err: %v", returnedVerbs, test.expectedNamedVerbs, err)
+			}
+			if returnedVerbs, err := generateVerbsForResource(roles, test.groupName, test.resourceKind); err != nil || !equality.Semantic.DeepEqual(returnedVerbs, test.expectedResourceVerbs) {
+				t.Fatalf("incorrect verbs were returned for the resource, got: %v, want: %v, err: %v", returnedVerbs, test.expectedResourceVerbs, err)
+			}
+		})
+	}
+
+	addonVerbs, err := generateVerbsForClusterNamespaceResource(roles, &kubermaticv1.Cluster{}, "auditors-projectID", kubermaticv1.AddonKindName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"get", "list"}; !equality.Semantic.DeepEqual(addonVerbs, expected) {
+		t.Fatalf("incorrect verbs were returned for addons, got: %v, want: %v", addonVerbs, expected)
+	}
+
+	if expected := []string{"owners", "editors", "viewers", "auditors", "operators"}; !equality.Semantic.DeepEqual(roles.allGroupsPrefixes(), expected) {
+		t.Fatalf("incorrect group prefixes, got: %v, want: %v", roles.allGroupsPrefixes(), expected)
+	}
+}
+
+func TestValidateProjectRoleName(t *testing.T) {
+	tests := []struct {
+		name        string
+		roleName    string
+		errExpected bool
+	}{
+		{
+			name:     "valid name",
+			roleName: "auditors",
+		},
+		{
+			name:        "names must not contain dashes",
+			roleName:    "cluster-auditors",
+			errExpected: true,
+		},
+		{
+			name:        "names must not start with a built-in group",
+			roleName:    "ownersandfriends",
+			errExpected: true,
+		},
+		{
+			name:        "names must not be the name of a default role",
+			roleName:    OperatorGroupNamePrefix,
+			errExpected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := ValidateProjectRoleName(test.roleName); (err != nil) != test.errExpected {
+				t.Fatalf("Expected err: %t, but got err %v", test.errExpected, err)
+			}
+		})
+	}
+}
+
+func TestProjectRoleRegistryChanges(t *testing.T) {
+	registry := newProjectRoleRegistry()
+	roles := []kubermaticv1.ProjectRole{{ObjectMeta: metav1.ObjectMeta{Name: "auditors"}}}
+
+	if !registry.set(nil) {
+		t.Fatal("expected the first set to report a change")
+	}
+	if registry.set(nil) {
+		t.Fatal("expected no change for the same roles")
+	}
+	if !registry.set(roles) {
+		t.Fatal("expected a change for a new role")
+	}
+	if !registry.set(nil) {
+		t.Fatal("expected a change for a removed role")
+	}
+}
+
+func TestGarbageCollectProjectRoleRBAC(t *testing.T) {
+	objs := []runtime.Object{
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "kubermatic:clusters:auditors"}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "kubermatic:clusters:testers"}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "kubermatic:cluster-autoscaler"}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "kubermatic:cluster-abcd:owners-thunderball"}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "kubermatic:cluster-abcd:operators-thunderball"}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "kubermatic:cluster-abcd:testers-thunderball"}},
+		&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "kubermatic:addon:testers", Namespace: "cluster-abcd"}},
+		&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "kubermatic:secrets:auditors", Namespace: "kubermatic"}},
+		&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "kubermatic:secrets:testers", Namespace: "kubermatic"}},
+	}
+	client := fakeruntime.NewFakeClient(objs...)
+	ctx := context.Background()
+
+	if err := garbageCollectProjectRoleRBAC(ctx, client, sets.NewString("testers")); err != nil {
+		t.Fatalf("failed to garbage collect: %v", err)
+	}
+
+	tests := []struct {
+		obj     runtime.Object
+		key     types.NamespacedName
+		deleted bool
+	}{
+		{obj: &rbacv1.ClusterRole{}, key: types.NamespacedName{Name: "kubermatic:clusters:auditors"}},
+		{obj: &rbacv1.ClusterRole{}, key: types.NamespacedName{Name: "kubermatic:clusters:testers"}, deleted: true},
+		{obj: &rbacv1.ClusterRole{}, key: types.NamespacedName{Name: "kubermatic:cluster-autoscaler"}},
+		{obj: &rbacv1.ClusterRoleBinding{}, key: types.NamespacedName{Name: "kubermatic:cluster-abcd:owners-thunderball"}},
+		{obj: &rbacv1.ClusterRoleBinding{}, key: types.NamespacedName{Name: "kubermatic:cluster-abcd:operators-thunderball"}},
+		{obj: &rbacv1.ClusterRoleBinding{}, key: types.NamespacedName{Name: "kubermatic:cluster-abcd:testers-thunderball"}, deleted: true},
+		{obj: &rbacv1.Role{}, key: types.NamespacedName{Name: "kubermatic:addon:testers", Namespace: "cluster-abcd"}, deleted: true},
+		{obj: &rbacv1.RoleBinding{}, key: types.NamespacedName{Name: "kubermatic:secrets:auditors", Namespace: "kubermatic"}},
+		{obj: &rbacv1.RoleBinding{}, key: types.NamespacedName{Name: "kubermatic:secrets:testers", Namespace: "kubermatic"}, deleted: true},
+	}
+	for _, test := range tests {
+		err := client.Get(ctx, test.key, test.obj)
+		if deleted := kerrors.IsNotFound(err); deleted != test.deleted {
+			t.Errorf("%T %s: expected deleted=%t, got err %v", test.obj, test.key, test.deleted, err)
+		}
+	}
+}
+
+func TestReconcileProjectRole(t *testing.T) {
+	deletionTimestamp := metav1.Now()
+	objs := []runtime.Object{
+		&kubermaticv1.ProjectRole{ObjectMeta: metav1.ObjectMeta{Name: "auditors"}},
+		&kubermaticv1.ProjectRole{ObjectMeta: metav1.ObjectMeta{Name: "testers", DeletionTimestamp: &deletionTimestamp, Finalizers: []string{CleanupFinalizerName}}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "kubermatic:clusters:auditors"}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "kubermatic:clusters:testers"}},
+	}
+	seedObjs := []runtime.Object{
+		&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "kubermatic:addon:testers", Namespace: "cluster-abcd"}},
+	}
+	masterClient := fakeruntime.NewFakeClient(objs...)
+	seedClient := fakeruntime.NewFakeClient(seedObjs...)
+	ctx := context.Background()
+
+	rolesChanged := false
+	c := &projectController{
+		projectRoles:        newProjectRoleRegistry(),
+		client:              masterClient,
+		seedClientMap:       map[string]client.Client{"europe": seedClient},
+		ctx:                 ctx,
+		projectRolesChanged: func() { rolesChanged = true },
+	}
+
+	for _, name := range []string{"auditors", "testers"} {
+		if _, err := c.reconcileProjectRole(reconcile.Request{NamespacedName: types.NamespacedName{Name: name}}); err != nil {
+			t.Fatalf("failed to reconcile project role %s: %v", name, err)
+		}
+	}
+
+	auditors := &kubermaticv1.ProjectRole{}
+	if err := masterClient.Get(ctx, types.NamespacedName{Name: "auditors"}, auditors); err != nil {
+		t.Fatal(err)
+	}
+	if !kuberneteshelper.HasFinalizer(auditors, CleanupFinalizerName) {
+		t.Error("expected the cleanup finalizer to be added to the project role")
+	}
+	testers := &kubermaticv1.ProjectRole{}
+	if err := masterClient.Get(ctx, types.NamespacedName{Name: "testers"}, testers); err != nil && !kerrors.IsNotFound(err) {
+		t.Fatal(err)
+	}
+	if kuberneteshelper.HasFinalizer(testers, CleanupFinalizerName) {
+		t.Error("expected the cleanup finalizer to be removed from the deleted project role")
+	}
+
+	if _, ok := c.projectRoles.get("testers"); ok {
+		t.Error("expected the deleted project role to be removed from the registry")
+	}
+	if _, ok := c.projectRoles.get("auditors"); !ok {
+		t.Error("expected the project role to be in the registry")
+	}
+	if !rolesChanged {
+		t.Error("expected the change of the project roles to be reported")
+	}
+
+	tests := []struct {
+		client  client.Client
+		obj     runtime.Object
+		key     types.NamespacedName
+		deleted bool
+	}{
+		{client: masterClient, obj: &rbacv1.ClusterRole{}, key: types.NamespacedName{Name: "kubermatic:clusters:auditors"}},
+		{client: masterClient, obj: &rbacv1.ClusterRole{}, key: types.NamespacedName{Name: "kubermatic:clusters:testers"}, deleted: true},
+		{client: seedClient, obj: &rbacv1.Role{}, key: types.NamespacedName{Name: "kubermatic:addon:testers", Namespace: "cluster-abcd"}, deleted: true},
+	}
+	for _, test := range tests {
+		err := test.client.Get(ctx, test.key, test.obj)
+		if deleted := kerrors.IsNotFound(err); deleted != test.deleted {
+			t.Errorf("%T %s: expected deleted=%t, got err %v", test.obj, test.key, test.deleted, err)
+		}
+	}
+}
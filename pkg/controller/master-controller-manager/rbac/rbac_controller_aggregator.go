@@ -143,6 +143,19 @@ func New(metrics *Metrics, mgr manager.Manager, seedManagerMap map[string]manage
 			kind: kubermaticv1.SSHKeyKind,
 		},
 
+		{
+			gvr: schema.GroupVersionResource{
+				Group:    kubermaticv1.GroupName,
+				Version:  kubermaticv1.GroupVersion,
+				Resource: kubermaticv1.ClusterTemplateResourceName,
+			},
+			kind: kubermaticv1.ClusterTemplateKindName,
+			shouldEnqueue: func(obj metav1.Object) bool {
+				// global templates don't belong to any project
+				return obj.GetLabels()[kubermaticv1.ClusterTemplateScopeLabelKey] != kubermaticv1.ClusterTemplateGlobalScope
+			},
+		},
+
 		{
 			gvr: schema.GroupVersionResource{
 				Group:    kubermaticv1.GroupName,
@@ -180,12 +193,16 @@ func New(metrics *Metrics, mgr manager.Manager, seedManagerMap map[string]manage
 		},
 	}
 
-	err = newProjectRBACController(metrics, mgr, seedManagerMap, masterClusterProvider, projectResources, workerPredicate)
+	// the project roles are kept up to date by the project controller and used by both controllers
+	projectRoles := newProjectRoleRegistry()
+
+	resourcesRBACCtrl, err := newResourcesController(metrics, masterClusterProvider, seedClusterProviders, projectResources, projectRoles)
 	if err != nil {
 		return nil, err
 	}
 
-	resourcesRBACCtrl, err := newResourcesController(metrics, masterClusterProvider, seedClusterProviders, projectResources)
+	// the RBAC of all named resources has to be updated when the project roles change
+	err = newProjectRBACController(metrics, mgr, seedManagerMap, masterClusterProvider, projectResources, projectRoles, workerPredicate, resourcesRBACCtrl.enqueueAllProjectResources)
 	if err != nil {
 		return nil, err
 	}
@@ -18,9 +18,12 @@ package rbac
 
 import (
 	"context"
+	"fmt"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/util/workqueue"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -44,9 +47,13 @@ type projectController struct {
 	masterClusterProvider *ClusterProvider
 
 	projectResources []projectResource
+	projectRoles     *projectRoleRegistry
 	client           client.Client
 	seedClientMap    map[string]client.Client
 	ctx              context.Context
+
+	// projectRolesChanged is called after the project roles changed
+	projectRolesChanged func()
 }
 
 // newProjectRBACController creates a new controller that is responsible for
@@ -54,7 +61,7 @@ type projectController struct {
 
 // The controller will also set proper ownership chain through OwnerReferences
 // so that whenever a project is deleted dependants object will be garbage collected.
-func newProjectRBACController(metrics *Metrics, mgr manager.Manager, seedManagerMap map[string]manager.Manager, masterClusterProvider *ClusterProvider, resources []projectResource, workerPredicate predicate.Predicate) error {
+func newProjectRBACController(metrics *Metrics, mgr manager.Manager, seedManagerMap map[string]manager.Manager, masterClusterProvider *ClusterProvider, resources []projectResource, projectRoles *projectRoleRegistry, workerPredicate predicate.Predicate, projectRolesChanged func()) error {
 	seedClientMap := make(map[string]client.Client)
 	for k, v := range seedManagerMap {
 		seedClientMap[k] = v.GetClient()
@@ -64,10 +71,12 @@ func newProjectRBACController(metrics *Metrics, mgr manager.Manager, seedManager
 		projectQueue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "rbac_generator_for_project"),
 		metrics:               metrics,
 		projectResources:      resources,
+		projectRoles:          projectRoles,
 		masterClusterProvider: masterClusterProvider,
 		client:                mgr.GetClient(),
 		seedClientMap:         seedClientMap,
 		ctx:                   context.TODO(),
+		projectRolesChanged:   projectRolesChanged,
 	}
 
 	// Create a new controller
@@ -82,7 +91,32 @@ func newProjectRBACController(metrics *Metrics, mgr manager.Manager, seedManager
 		return err
 	}
 
-	return nil
+	// Watch for changes to ProjectRoles, they affect the RBAC of all projects
+	err = cc.Watch(&source.Kind{Type: &kubermaticv1.ProjectRole{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(c.enqueueAllProjects)})
+	if err != nil {
+		return err
+	}
+
+	// The RBAC Roles/Bindings of deleted ProjectRoles get removed by a separate controller, so it also
+	// happens when there are no projects
+	rc, err := controller.New("rbac_generator_for_project_roles", mgr, controller.Options{Reconciler: reconcile.Func(c.reconcileProjectRole)})
+	if err != nil {
+		return err
+	}
+	return rc.Watch(&source.Kind{Type: &kubermaticv1.ProjectRole{}}, &handler.EnqueueRequestForObject{})
+}
+
+func (c *projectController) enqueueAllProjects(_ handler.MapObject) []reconcile.Request {
+	projects := &kubermaticv1.ProjectList{}
+	if err := c.client.List(c.ctx, projects); err != nil {
+		runtime.HandleError(fmt.Errorf("failed to list projects: %v", err))
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, project := range projects.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: project.Name}})
+	}
+	return requests
 }
 
 func (c *projectController) Reconcile(req reconcile.Request) (reconcile.Result, error) {
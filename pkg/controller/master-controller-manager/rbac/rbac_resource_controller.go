@@ -25,6 +25,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -40,6 +41,16 @@ type resourcesController struct {
 
 	metrics          *Metrics
 	projectResources []projectResource
+	projectRoles     *projectRoleRegistry
+
+	// informers holds the informers of all project resources, see enqueueAllProjectResources
+	informers []resourceInformer
+}
+
+type resourceInformer struct {
+	resource        projectResource
+	clusterProvider *ClusterProvider
+	lister          kcache.GenericLister
 }
 
 type resourceToProcess struct {
@@ -63,11 +74,12 @@ func (i *resourceToProcess) String() string {
 }
 
 // newResourcesController creates a new controller for managing RBAC for named resources that belong to project
-func newResourcesController(metrics *Metrics, masterClusterProvider *ClusterProvider, seedClusterProviders []*ClusterProvider, resources []projectResource) (*resourcesController, error) {
+func newResourcesController(metrics *Metrics, masterClusterProvider *ClusterProvider, seedClusterProviders []*ClusterProvider, resources []projectResource, projectRoles *projectRoleRegistry) (*resourcesController, error) {
 	c := &resourcesController{
 		projectResourcesQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "rbac_generator_resources"),
 		metrics:               metrics,
 		projectResources:      resources,
+		projectRoles:          projectRoles,
 	}
 
 	klog.V(4).Infof("considering %s master cluster provider for resources", masterClusterProvider.providerName)
@@ -189,6 +201,21 @@ func (c *resourcesController) enqueueProjectResource(obj interface{}, staticReso
 	c.projectResourcesQueue.Add(item)
 }
 
+// enqueueAllProjectResources adds all resources of all projects to the queue, e.g. because the RBAC Roles/Bindings generated
+// for them changed
+func (c *resourcesController) enqueueAllProjectResources() {
+	for _, informer := range c.informers {
+		objs, err := informer.lister.List(labels.Everything())
+		if err != nil {
+			runtime.HandleError(fmt.Errorf("unable to list %s resources of provider %q: %v", informer.resource.gvr.String(), informer.clusterProvider.providerName, err))
+			continue
+		}
+		for _, obj := range objs {
+			c.enqueueProjectResource(obj, informer.resource, informer.clusterProvider, informer.lister)
+		}
+	}
+}
+
 func (c *resourcesController) registerInformerIndexerForKubermaticResource(sharedInformers kubermaticsharedinformers.SharedInformerFactory, resource projectResource, clusterProvider *ClusterProvider) (kcache.Indexer, error) {
 	var genLister kcache.GenericLister
 
@@ -211,6 +238,7 @@ func (c *resourcesController) registerInformerIndexerForKubermaticResource(share
 		klog.V(4).Infof("using a shared informer and indexer for %q resource, provider %q", resource.gvr.String(), clusterProvider.providerName)
 		shared.Informer().AddEventHandlerWithResyncPeriod(handlers, projectResourcesResyncTime)
 		genLister = shared.Lister()
+		c.informers = append(c.informers, resourceInformer{resource: resource, clusterProvider: clusterProvider, lister: genLister})
 		return shared.Informer().GetIndexer(), nil
 	}
 	return nil, fmt.Errorf("uanble to create shared informer and indexer for %q resource, provider %q, err %v", resource.gvr.String(), clusterProvider.providerName, err)
@@ -238,6 +266,7 @@ func (c *resourcesController) registerInformerForKubeResource(kubeInformerProvid
 		klog.V(4).Infof("using a shared informer for %q resource, provider %q in namespace %q", resource.gvr.String(), clusterProvider.providerName, resource.namespace)
 		shared.Informer().AddEventHandlerWithResyncPeriod(handlers, projectResourcesResyncTime)
 		genLister = shared.Lister()
+		c.informers = append(c.informers, resourceInformer{resource: resource, clusterProvider: clusterProvider, lister: genLister})
 
 		if len(resource.namespace) > 0 {
 			klog.V(4).Infof("registering Roles and RoleBindings informers in %q namespace for provider %s for resource %q", resource.namespace, clusterProvider.providerName, resource.gvr.String())
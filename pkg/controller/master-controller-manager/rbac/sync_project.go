@@ -27,13 +27,16 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
@@ -48,6 +51,10 @@ func (c *projectController) sync(key client.ObjectKey) error {
 
 	project := originalProject.DeepCopy()
 
+	if err := c.syncProjectRoles(); err != nil {
+		return fmt.Errorf("failed to get the project roles: %v", err)
+	}
+
 	if c.shouldDeleteProject(project) {
 		if err := c.ensureProjectCleanup(project); err != nil {
 			return fmt.Errorf("failed to cleanup project: %v", err)
@@ -61,10 +68,10 @@ func (c *projectController) sync(key client.ObjectKey) error {
 	if err := c.ensureProjectOwner(project); err != nil {
 		return fmt.Errorf("failed to ensure that the project owner exists in the owners group: %v", err)
 	}
-	if err := ensureClusterRBACRoleForNamedResource(project.Name, kubermaticv1.ProjectResourceName, kubermaticv1.ProjectKindName, project.GetObjectMeta(), c.masterClusterProvider.kubeClient, c.masterClusterProvider.kubeInformerProvider.KubeInformerFactoryFor(metav1.NamespaceAll).Rbac().V1().ClusterRoles().Lister()); err != nil {
+	if err := ensureClusterRBACRoleForNamedResource(c.projectRoles, project.Name, kubermaticv1.ProjectResourceName, kubermaticv1.ProjectKindName, project.GetObjectMeta(), c.masterClusterProvider.kubeClient, c.masterClusterProvider.kubeInformerProvider.KubeInformerFactoryFor(metav1.NamespaceAll).Rbac().V1().ClusterRoles().Lister()); err != nil {
 		return fmt.Errorf("failed to ensure that the RBAC Role for the project exists: %v", err)
 	}
-	if err := ensureClusterRBACRoleBindingForNamedResource(project.Name, kubermaticv1.ProjectResourceName, kubermaticv1.ProjectKindName, project.GetObjectMeta(), c.masterClusterProvider.kubeClient, c.masterClusterProvider.kubeInformerProvider.KubeInformerFactoryFor(metav1.NamespaceAll).Rbac().V1().ClusterRoleBindings().Lister()); err != nil {
+	if err := ensureClusterRBACRoleBindingForNamedResource(c.projectRoles, project.Name, kubermaticv1.ProjectResourceName, kubermaticv1.ProjectKindName, project.GetObjectMeta(), c.masterClusterProvider.kubeClient, c.masterClusterProvider.kubeInformerProvider.KubeInformerFactoryFor(metav1.NamespaceAll).Rbac().V1().ClusterRoleBindings().Lister()); err != nil {
 		return fmt.Errorf("failed to ensure that the RBAC RoleBinding for the project exists: %v", err)
 	}
 	if err := c.ensureClusterRBACRoleForResources(); err != nil {
@@ -86,6 +93,108 @@ func (c *projectController) sync(key client.ObjectKey) error {
 	return nil
 }
 
+// syncProjectRoles updates the project roles the RBAC Roles/Bindings are generated for
+func (c *projectController) syncProjectRoles() error {
+	roles := &kubermaticv1.ProjectRoleList{}
+	if err := c.client.List(c.ctx, roles); err != nil {
+		return err
+	}
+	// deleted roles are kept alive by the cleanup finalizer until their RBAC Roles/Bindings are gone
+	activeRoles := []kubermaticv1.ProjectRole{}
+	for _, role := range roles.Items {
+		if role.DeletionTimestamp == nil {
+			activeRoles = append(activeRoles, role)
+		}
+	}
+	if c.projectRoles.set(activeRoles) && c.projectRolesChanged != nil {
+		c.projectRolesChanged()
+	}
+	return nil
+}
+
+// reconcileProjectRole adds the cleanup finalizer to the given ProjectRole, once it gets deleted the RBAC Roles/Bindings
+// which were generated for it are removed before the finalizer
+func (c *projectController) reconcileProjectRole(req reconcile.Request) (reconcile.Result, error) {
+	role := &kubermaticv1.ProjectRole{}
+	if err := c.client.Get(c.ctx, req.NamespacedName, role); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	// roles with invalid names are ignored by the generator, see projectRoleRegistry.set
+	if ValidateProjectRoleName(role.Name) != nil {
+		return reconcile.Result{}, nil
+	}
+
+	if role.DeletionTimestamp == nil {
+		if !kuberneteshelper.HasFinalizer(role, CleanupFinalizerName) {
+			oldRole := role.DeepCopy()
+			kuberneteshelper.AddFinalizer(role, CleanupFinalizerName)
+			if err := c.client.Patch(c.ctx, role, client.MergeFrom(oldRole)); err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to add the cleanup finalizer: %v", err)
+			}
+		}
+		return reconcile.Result{}, nil
+	}
+	if !kuberneteshelper.HasFinalizer(role, CleanupFinalizerName) {
+		return reconcile.Result{}, nil
+	}
+
+	// stop generating RBAC Roles/Bindings for the role before removing them
+	if err := c.syncProjectRoles(); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get the project roles: %v", err)
+	}
+	if err := c.garbageCollectProjectRole(role.Name); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to clean up the RBAC Roles/Bindings: %v", err)
+	}
+
+	oldRole := role.DeepCopy()
+	kuberneteshelper.RemoveFinalizer(role, CleanupFinalizerName)
+	if err := c.client.Patch(c.ctx, role, client.MergeFrom(oldRole)); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to remove the cleanup finalizer: %v", err)
+	}
+	return reconcile.Result{}, nil
+}
+
+// garbageCollectProjectRole deletes the RBAC Roles/Bindings which were generated for the given project role
+func (c *projectController) garbageCollectProjectRole(roleName string) error {
+	roleNames := sets.NewString(roleName)
+	if err := garbageCollectProjectRoleRBAC(c.ctx, c.client, roleNames); err != nil {
+		return err
+	}
+	for seedName, seedClient := range c.seedClientMap {
+		if err := garbageCollectProjectRoleRBAC(c.ctx, seedClient, roleNames); err != nil {
+			return fmt.Errorf("failed to clean up seed %q: %v", seedName, err)
+		}
+	}
+	return nil
+}
+
+func garbageCollectProjectRoleRBAC(ctx context.Context, c client.Client, roleNames sets.String) error {
+	lists := []runtime.Object{&rbacv1.ClusterRoleList{}, &rbacv1.ClusterRoleBindingList{}, &rbacv1.RoleList{}, &rbacv1.RoleBindingList{}}
+	for _, list := range lists {
+		if err := c.List(ctx, list); err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			obj, err := meta.Accessor(item)
+			if err != nil {
+				return err
+			}
+			if !isGeneratedForProjectRole(obj.GetName(), roleNames) {
+				continue
+			}
+			klog.V(2).Infof("deleting %T %s/%s of a deleted project role", item, obj.GetNamespace(), obj.GetName())
+			if err := deleteIfExists(ctx, c, item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (c *projectController) ensureCleanupFinalizerExists(project *kubermaticv1.Project) error {
 	if !kuberneteshelper.HasFinalizer(project, CleanupFinalizerName) {
 		kuberneteshelper.AddFinalizer(project, CleanupFinalizerName)
@@ -159,17 +268,17 @@ func (c *projectController) ensureClusterRBACRoleForResources() error {
 		if len(projectResource.namespace) > 0 {
 			continue
 		}
-		for _, groupPrefix := range AllGroupsPrefixes {
+		for _, groupPrefix := range c.projectRoles.allGroupsPrefixes() {
 
 			if projectResource.destination == destinationSeed {
 				for _, seedClusterRESTClient := range c.seedClientMap {
-					err := ensureClusterRBACRoleForResource(c.ctx, seedClusterRESTClient, groupPrefix, projectResource.gvr.Resource, projectResource.kind)
+					err := ensureClusterRBACRoleForResource(c.ctx, seedClusterRESTClient, c.projectRoles, groupPrefix, projectResource.gvr.Resource, projectResource.kind)
 					if err != nil {
 						return err
 					}
 				}
 			} else {
-				err := ensureClusterRBACRoleForResource(c.ctx, c.client, groupPrefix, projectResource.gvr.Resource, projectResource.kind)
+				err := ensureClusterRBACRoleForResource(c.ctx, c.client, c.projectRoles, groupPrefix, projectResource.gvr.Resource, projectResource.kind)
 				if err != nil {
 					return err
 				}
@@ -184,10 +293,10 @@ func (c *projectController) ensureClusterRBACRoleBindingForResources(projectName
 		if len(projectResource.namespace) > 0 {
 			continue
 		}
-		for _, groupPrefix := range AllGroupsPrefixes {
+		for _, groupPrefix := range c.projectRoles.allGroupsPrefixes() {
 			groupName := GenerateActualGroupNameFor(projectName, groupPrefix)
 
-			if skip, err := shouldSkipClusterRBACRoleBindingFor(groupName, projectResource.gvr.Resource, kubermaticv1.SchemeGroupVersion.Group, projectName, projectResource.kind); skip {
+			if skip, err := shouldSkipClusterRBACRoleBindingFor(c.projectRoles, groupName, projectResource.gvr.Resource, kubermaticv1.SchemeGroupVersion.Group, projectName, projectResource.kind); skip {
 				continue
 			} else if err != nil {
 				return err
@@ -219,13 +328,17 @@ func (c *projectController) ensureClusterRBACRoleBindingForResources(projectName
 	return nil
 }
 
-func ensureClusterRBACRoleForResource(ctx context.Context, c client.Client, groupName, resource, kind string) error {
-	generatedClusterRole, err := generateClusterRBACRoleForResource(groupName, resource, kubermaticv1.SchemeGroupVersion.Group, kind)
+func ensureClusterRBACRoleForResource(ctx context.Context, c client.Client, roles *projectRoleRegistry, groupName, resource, kind string) error {
+	generatedClusterRole, err := generateClusterRBACRoleForResource(roles, groupName, resource, kubermaticv1.SchemeGroupVersion.Group, kind)
 	if err != nil {
 		return err
 	}
 	if generatedClusterRole == nil {
 		klog.V(4).Infof("skipping ClusterRole generation because the resource for group %q and resource %q will not be created", groupName, resource)
+		if _, ok := roles.roleFor(groupName); ok {
+			// the project role might have allowed it before
+			return deleteIfExists(ctx, c, &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: generateRBACRoleNameForResources(resource, groupName)}})
+		}
 		return nil
 	}
 	var sharedExistingClusterRole rbacv1.ClusterRole
@@ -290,13 +403,14 @@ func (c *projectController) ensureRBACRoleForResources() error {
 		if len(projectResource.namespace) == 0 {
 			continue
 		}
-		for _, groupPrefix := range AllGroupsPrefixes {
+		for _, groupPrefix := range c.projectRoles.allGroupsPrefixes() {
 
 			if projectResource.destination == destinationSeed {
 				for _, seedClusterRESTClient := range c.seedClientMap {
 					err := ensureRBACRoleForResource(
 						c.ctx,
 						seedClusterRESTClient,
+						c.projectRoles,
 						groupPrefix,
 						projectResource.gvr,
 						projectResource.kind,
@@ -309,6 +423,7 @@ func (c *projectController) ensureRBACRoleForResources() error {
 				err := ensureRBACRoleForResource(
 					c.ctx,
 					c.client,
+					c.projectRoles,
 					groupPrefix,
 					projectResource.gvr,
 					projectResource.kind,
@@ -322,13 +437,17 @@ func (c *projectController) ensureRBACRoleForResources() error {
 	return nil
 }
 
-func ensureRBACRoleForResource(ctx context.Context, c client.Client, groupName string, gvr schema.GroupVersionResource, kind string, namespace string) error {
-	generatedRole, err := generateRBACRoleForResource(groupName, gvr.Resource, gvr.Group, kind, namespace)
+func ensureRBACRoleForResource(ctx context.Context, c client.Client, roles *projectRoleRegistry, groupName string, gvr schema.GroupVersionResource, kind string, namespace string) error {
+	generatedRole, err := generateRBACRoleForResource(roles, groupName, gvr.Resource, gvr.Group, kind, namespace)
 	if err != nil {
 		return err
 	}
 	if generatedRole == nil {
 		klog.V(4).Infof("skipping Role generation because the resource for group %q and resource %q in namespace %q will not be created", groupName, gvr.Resource, namespace)
+		if _, ok := roles.roleFor(groupName); ok {
+			// the project role might have allowed it before
+			return deleteIfExists(ctx, c, &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: generateRBACRoleNameForResources(gvr.Resource, groupName), Namespace: namespace}})
+		}
 		return nil
 	}
 	var sharedExistingRole rbacv1.Role
@@ -353,10 +472,10 @@ func (c *projectController) ensureRBACRoleBindingForResources(projectName string
 		if len(projectResource.namespace) == 0 {
 			continue
 		}
-		for _, groupPrefix := range AllGroupsPrefixes {
+		for _, groupPrefix := range c.projectRoles.allGroupsPrefixes() {
 			groupName := GenerateActualGroupNameFor(projectName, groupPrefix)
 
-			if skip, err := shouldSkipRBACRoleBindingFor(groupName, projectResource.gvr.Resource, kubermaticv1.SchemeGroupVersion.Group, projectName, projectResource.kind, projectResource.namespace); skip {
+			if skip, err := shouldSkipRBACRoleBindingFor(c.projectRoles, groupName, projectResource.gvr.Resource, kubermaticv1.SchemeGroupVersion.Group, projectName, projectResource.kind, projectResource.namespace); skip {
 				continue
 			} else if err != nil {
 				return err
@@ -454,9 +573,9 @@ func (c *projectController) ensureProjectCleanup(project *kubermaticv1.Project)
 		if len(projectResource.namespace) > 0 {
 			continue
 		}
-		for _, groupPrefix := range AllGroupsPrefixes {
+		for _, groupPrefix := range c.projectRoles.allGroupsPrefixes() {
 			groupName := GenerateActualGroupNameFor(project.Name, groupPrefix)
-			if skip, err := shouldSkipClusterRBACRoleBindingFor(groupName, projectResource.gvr.Resource, kubermaticv1.SchemeGroupVersion.Group, project.Name, projectResource.kind); skip {
+			if skip, err := shouldSkipClusterRBACRoleBindingFor(c.projectRoles, groupName, projectResource.gvr.Resource, kubermaticv1.SchemeGroupVersion.Group, project.Name, projectResource.kind); skip {
 				continue
 			} else if err != nil {
 				return err
@@ -483,9 +602,9 @@ func (c *projectController) ensureProjectCleanup(project *kubermaticv1.Project)
 		if len(projectResource.namespace) == 0 {
 			continue
 		}
-		for _, groupPrefix := range AllGroupsPrefixes {
+		for _, groupPrefix := range c.projectRoles.allGroupsPrefixes() {
 			groupName := GenerateActualGroupNameFor(project.Name, groupPrefix)
-			if skip, err := shouldSkipRBACRoleBindingFor(groupName, projectResource.gvr.Resource, kubermaticv1.SchemeGroupVersion.Group, project.Name, projectResource.kind, projectResource.namespace); skip {
+			if skip, err := shouldSkipRBACRoleBindingFor(c.projectRoles, groupName, projectResource.gvr.Resource, kubermaticv1.SchemeGroupVersion.Group, project.Name, projectResource.kind, projectResource.namespace); skip {
 				continue
 			} else if err != nil {
 				return err
@@ -574,8 +693,8 @@ func (c *projectController) shouldDeleteProject(project *kubermaticv1.Project) b
 // thus before doing something with ClusterRoleBinding check if the role was generated for the given resource and the group
 //
 // note: this method will add status to the log file
-func shouldSkipClusterRBACRoleBindingFor(groupName, policyResource, policyAPIGroups, projectName, kind string) (bool, error) {
-	generatedClusterRole, err := generateClusterRBACRoleForResource(groupName, policyResource, policyAPIGroups, kind)
+func shouldSkipClusterRBACRoleBindingFor(roles *projectRoleRegistry, groupName, policyResource, policyAPIGroups, projectName, kind string) (bool, error) {
+	generatedClusterRole, err := generateClusterRBACRoleForResource(roles, groupName, policyResource, policyAPIGroups, kind)
 	if err != nil {
 		return false, err
 	}
@@ -590,8 +709,8 @@ func shouldSkipClusterRBACRoleBindingFor(groupName, policyResource, policyAPIGro
 // thus before doing something with RoleBinding check if the role was generated for the given resource and the group
 //
 // note: this method will add status to the log file
-func shouldSkipRBACRoleBindingFor(groupName, policyResource, policyAPIGroups, projectName, kind, namespace string) (bool, error) {
-	generatedRole, err := generateRBACRoleForResource(groupName, policyResource, policyAPIGroups, kind, namespace)
+func shouldSkipRBACRoleBindingFor(roles *projectRoleRegistry, groupName, policyResource, policyAPIGroups, projectName, kind, namespace string) (bool, error) {
+	generatedRole, err := generateRBACRoleForResource(roles, groupName, policyResource, policyAPIGroups, kind, namespace)
 	if err != nil {
 		return false, err
 	}
@@ -601,3 +720,11 @@ func shouldSkipRBACRoleBindingFor(groupName, policyResource, policyAPIGroups, pr
 	}
 	return false, nil
 }
+
+// deleteIfExists deletes the given object, it is not an error if it doesn't exist
+func deleteIfExists(ctx context.Context, c client.Client, obj runtime.Object) error {
+	if err := c.Delete(ctx, obj); err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -70,8 +70,9 @@ func TestEnsureProjectIsInActivePhase(t *testing.T) {
 
 			// act
 			target := projectController{
-				ctx:    context.Background(),
-				client: masterClient,
+				projectRoles: newProjectRoleRegistry(),
+				ctx:          context.Background(),
+				client:       masterClient,
 			}
 			err := target.ensureProjectIsInActivePhase(test.projectToSync)
 			assert.Nil(t, err)
@@ -114,8 +115,9 @@ func TestEnsureProjectInitialized(t *testing.T) {
 
 			// act
 			target := projectController{
-				ctx:    context.Background(),
-				client: masterClient,
+				projectRoles: newProjectRoleRegistry(),
+				ctx:          context.Background(),
+				client:       masterClient,
 			}
 			err := target.ensureCleanupFinalizerExists(test.projectToSync)
 			assert.NoError(t, err)
@@ -496,6 +498,7 @@ func TestEnsureProjectClusterRBACRoleBindingForResources(t *testing.T) {
 
 			// act
 			target := projectController{
+				projectRoles:     newProjectRoleRegistry(),
 				ctx:              context.Background(),
 				client:           fakeMasterClient,
 				seedClientMap:    seedClientMap,
@@ -710,6 +713,7 @@ func TestEnsureClusterResourcesCleanup(t *testing.T) {
 
 			// act
 			target := projectController{
+				projectRoles:          newProjectRoleRegistry(),
 				ctx:                   context.Background(),
 				masterClusterProvider: fakeMasterClusterProvider,
 				client:                fakeMasterClusterClient,
@@ -975,6 +979,7 @@ func TestEnsureProjectCleanup(t *testing.T) {
 
 			// act
 			target := projectController{
+				projectRoles:          newProjectRoleRegistry(),
 				ctx:                   context.Background(),
 				masterClusterProvider: fakeMasterClusterProvider,
 				projectResources:      test.projectResourcesToSync,
@@ -1194,6 +1199,7 @@ func TestEnsureProjectClusterRBACRoleForResources(t *testing.T) {
 
 			// act
 			target := projectController{
+				projectRoles:          newProjectRoleRegistry(),
 				ctx:                   context.Background(),
 				masterClusterProvider: fakeMasterClusterProvider,
 				projectResources:      test.projectResourcesToSync,
@@ -1308,8 +1314,9 @@ func TestEnsureProjectOwner(t *testing.T) {
 
 			// act
 			target := projectController{
-				ctx:    context.Background(),
-				client: masterClient,
+				projectRoles: newProjectRoleRegistry(),
+				ctx:          context.Background(),
+				client:       masterClient,
 			}
 			err := target.ensureProjectOwner(test.projectToSync)
 			assert.NoError(t, err)
@@ -1512,6 +1519,7 @@ func TestEnsureProjectRBACRoleForResources(t *testing.T) {
 
 			// act
 			target := projectController{
+				projectRoles:     newProjectRoleRegistry(),
 				ctx:              context.Background(),
 				client:           fakeMasterClient,
 				seedClientMap:    seedClientMap,
@@ -1824,6 +1832,7 @@ func TestEnsureProjectRBACRoleBindingForResources(t *testing.T) {
 
 			// act
 			target := projectController{
+				projectRoles:     newProjectRoleRegistry(),
 				ctx:              context.Background(),
 				client:           fakeMasterClient,
 				seedClientMap:    seedClusterClientMap,
@@ -2061,6 +2070,7 @@ func TestEnsureProjectCleanUpForRoleBindings(t *testing.T) {
 
 			// act
 			target := projectController{
+				projectRoles:          newProjectRoleRegistry(),
 				ctx:                   context.Background(),
 				masterClusterProvider: fakeMasterClusterProvider,
 				client:                fakeMasterClusterClient,
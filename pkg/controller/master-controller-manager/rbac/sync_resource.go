@@ -57,10 +57,10 @@ func (c *resourcesController) syncProjectResource(item *resourceToProcess) error
 	}
 
 	if len(item.metaObject.GetNamespace()) == 0 {
-		if err := ensureClusterRBACRoleForNamedResource(projectName, item.gvr.Resource, item.kind, item.metaObject, item.clusterProvider.kubeClient, item.clusterProvider.kubeInformerProvider.KubeInformerFactoryFor(metav1.NamespaceAll).Rbac().V1().ClusterRoles().Lister()); err != nil {
+		if err := ensureClusterRBACRoleForNamedResource(c.projectRoles, projectName, item.gvr.Resource, item.kind, item.metaObject, item.clusterProvider.kubeClient, item.clusterProvider.kubeInformerProvider.KubeInformerFactoryFor(metav1.NamespaceAll).Rbac().V1().ClusterRoles().Lister()); err != nil {
 			return fmt.Errorf("failed to sync RBAC ClusterRole for %s resource for %s cluster provider, due to = %v", item.gvr.String(), item.clusterProvider.providerName, err)
 		}
-		if err := ensureClusterRBACRoleBindingForNamedResource(projectName, item.gvr.Resource, item.kind, item.metaObject, item.clusterProvider.kubeClient, item.clusterProvider.kubeInformerProvider.KubeInformerFactoryFor(metav1.NamespaceAll).Rbac().V1().ClusterRoleBindings().Lister()); err != nil {
+		if err := ensureClusterRBACRoleBindingForNamedResource(c.projectRoles, projectName, item.gvr.Resource, item.kind, item.metaObject, item.clusterProvider.kubeClient, item.clusterProvider.kubeInformerProvider.KubeInformerFactoryFor(metav1.NamespaceAll).Rbac().V1().ClusterRoleBindings().Lister()); err != nil {
 			return fmt.Errorf("failed to sync RBAC ClusterRoleBinding for %s resource for %s cluster provider, due to = %v", item.gvr.String(), item.clusterProvider.providerName, err)
 		}
 		if item.kind == kubermaticv1.ClusterKindName {
@@ -100,14 +100,23 @@ func (c *resourcesController) syncProjectResource(item *resourceToProcess) error
 	return nil
 }
 
-func ensureClusterRBACRoleForNamedResource(projectName string, objectResource string, objectKind string, object metav1.Object, kubeClient kubernetes.Interface, rbacClusterRoleLister rbaclister.ClusterRoleLister) error {
-	for _, groupPrefix := range AllGroupsPrefixes {
-		skip, generatedRole, err := shouldSkipClusterRBACRoleBindingForNamedResource(projectName, objectResource, objectKind, groupPrefix, object)
+func ensureClusterRBACRoleForNamedResource(roles *projectRoleRegistry, projectName string, objectResource string, objectKind string, object metav1.Object, kubeClient kubernetes.Interface, rbacClusterRoleLister rbaclister.ClusterRoleLister) error {
+	for _, groupPrefix := range roles.allGroupsPrefixes() {
+		skip, generatedRole, err := shouldSkipClusterRBACRoleBindingForNamedResource(roles, projectName, objectResource, objectKind, groupPrefix, object)
 		if err != nil {
 			return err
 		}
 		if skip {
 			klog.V(4).Infof("skipping ClusterRole generation for named resource for group \"%s\" and resource \"%s\"", groupPrefix, objectResource)
+			if _, ok := roles.get(groupPrefix); ok {
+				// the project role might have allowed it before
+				name := generateRBACRoleNameForNamedResource(objectKind, object.GetName(), GenerateActualGroupNameFor(projectName, groupPrefix))
+				if _, err := rbacClusterRoleLister.Get(name); err == nil {
+					if err := kubeClient.RbacV1().ClusterRoles().Delete(name, &metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+						return err
+					}
+				}
+			}
 			continue
 		}
 		sharedExistingRole, err := rbacClusterRoleLister.Get(generatedRole.Name)
@@ -137,10 +146,10 @@ func ensureClusterRBACRoleForNamedResource(projectName string, objectResource st
 	return nil
 }
 
-func ensureClusterRBACRoleBindingForNamedResource(projectName string, objectResource string, objectKind string, object metav1.Object, kubeClient kubernetes.Interface, rbacClusterRoleBindingLister rbaclister.ClusterRoleBindingLister) error {
-	for _, groupPrefix := range AllGroupsPrefixes {
+func ensureClusterRBACRoleBindingForNamedResource(roles *projectRoleRegistry, projectName string, objectResource string, objectKind string, object metav1.Object, kubeClient kubernetes.Interface, rbacClusterRoleBindingLister rbaclister.ClusterRoleBindingLister) error {
+	for _, groupPrefix := range roles.allGroupsPrefixes() {
 
-		skip, _, err := shouldSkipClusterRBACRoleBindingForNamedResource(projectName, objectResource, objectKind, groupPrefix, object)
+		skip, _, err := shouldSkipClusterRBACRoleBindingForNamedResource(roles, projectName, objectResource, objectKind, groupPrefix, object)
 		if err != nil {
 			return err
 		}
@@ -188,8 +197,9 @@ func ensureClusterRBACRoleBindingForNamedResource(projectName string, objectReso
 // because for some kinds we actually don't create ClusterRole
 //
 // note that this method returns generated role if is not meant to be skipped
-func shouldSkipClusterRBACRoleBindingForNamedResource(projectName string, objectResource string, objectKind string, groupPrefix string, object metav1.Object) (bool, *rbacv1.ClusterRole, error) {
+func shouldSkipClusterRBACRoleBindingForNamedResource(roles *projectRoleRegistry, projectName string, objectResource string, objectKind string, groupPrefix string, object metav1.Object) (bool, *rbacv1.ClusterRole, error) {
 	generatedRole, err := generateClusterRBACRoleNamedResource(
+		roles,
 		objectKind,
 		GenerateActualGroupNameFor(projectName, groupPrefix),
 		objectResource,
@@ -213,13 +223,22 @@ func shouldSkipClusterRBACRoleBindingForNamedResource(projectName string, object
 }
 
 func (c *resourcesController) ensureRBACRoleForNamedResource(projectName string, objectGVR schema.GroupVersionResource, objectKind string, namespace string, object metav1.Object, kubeClient kubernetes.Interface, rbacRoleLister rbaclister.RoleNamespaceLister) error {
-	for _, groupPrefix := range AllGroupsPrefixes {
-		skip, generatedRole, err := shouldSkipRBACRoleBindingForNamedResource(projectName, objectGVR, objectKind, groupPrefix, namespace, object)
+	for _, groupPrefix := range c.projectRoles.allGroupsPrefixes() {
+		skip, generatedRole, err := shouldSkipRBACRoleBindingForNamedResource(c.projectRoles, projectName, objectGVR, objectKind, groupPrefix, namespace, object)
 		if err != nil {
 			return err
 		}
 		if skip {
 			klog.V(4).Infof("skipping Role generation for named resource for group %q and resource %q in namespace %q", groupPrefix, objectGVR.Resource, namespace)
+			if _, ok := c.projectRoles.get(groupPrefix); ok {
+				// the project role might have allowed it before
+				name := generateRBACRoleNameForNamedResource(objectKind, object.GetName(), GenerateActualGroupNameFor(projectName, groupPrefix))
+				if _, err := rbacRoleLister.Get(name); err == nil {
+					if err := kubeClient.RbacV1().Roles(namespace).Delete(name, &metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+						return err
+					}
+				}
+			}
 			continue
 		}
 		sharedExistingRole, err := rbacRoleLister.Get(generatedRole.Name)
@@ -250,9 +269,9 @@ func (c *resourcesController) ensureRBACRoleForNamedResource(projectName string,
 }
 
 func (c *resourcesController) ensureRBACRoleBindingForNamedResource(projectName string, objectGVR schema.GroupVersionResource, objectKind string, namespace string, object metav1.Object, kubeClient kubernetes.Interface, rbacRoleBindingLister rbaclister.RoleBindingNamespaceLister) error {
-	for _, groupPrefix := range AllGroupsPrefixes {
+	for _, groupPrefix := range c.projectRoles.allGroupsPrefixes() {
 
-		skip, _, err := shouldSkipRBACRoleBindingForNamedResource(projectName, objectGVR, objectKind, groupPrefix, namespace, object)
+		skip, _, err := shouldSkipRBACRoleBindingForNamedResource(c.projectRoles, projectName, objectGVR, objectKind, groupPrefix, namespace, object)
 		if err != nil {
 			return err
 		}
@@ -301,8 +320,9 @@ func (c *resourcesController) ensureRBACRoleBindingForNamedResource(projectName
 // because for some kinds we actually don't create Role
 //
 // note that this method returns generated role if is not meant to be skipped
-func shouldSkipRBACRoleBindingForNamedResource(projectName string, objectGVR schema.GroupVersionResource, objectKind string, groupPrefix string, namespace string, object metav1.Object) (bool, *rbacv1.Role, error) {
+func shouldSkipRBACRoleBindingForNamedResource(roles *projectRoleRegistry, projectName string, objectGVR schema.GroupVersionResource, objectKind string, groupPrefix string, namespace string, object metav1.Object) (bool, *rbacv1.Role, error) {
 	generatedRole, err := generateRBACRoleNamedResource(
+		roles,
 		objectKind,
 		GenerateActualGroupNameFor(projectName, groupPrefix),
 		objectGVR.Resource,
@@ -334,8 +354,9 @@ func (c *resourcesController) ensureRBACRoleForClusterAddons(projectName string,
 
 	rbacRoleLister := clusterProvider.kubeClient.RbacV1().Roles(cluster.Status.NamespaceName)
 
-	for _, groupPrefix := range AllGroupsPrefixes {
+	for _, groupPrefix := range c.projectRoles.allGroupsPrefixes() {
 		skip, generatedRole, err := shouldSkipRBACRoleForClusterNamespaceResource(
+			c.projectRoles,
 			projectName,
 			cluster,
 			kubermaticv1.AddonResourceName,
@@ -347,6 +368,13 @@ func (c *resourcesController) ensureRBACRoleForClusterAddons(projectName string,
 		}
 		if skip {
 			klog.V(4).Infof("skipping Role generation for cluster addons for group %q and cluster namespace %q", groupPrefix, cluster.Status.NamespaceName)
+			if _, ok := c.projectRoles.get(groupPrefix); ok {
+				// the project role might have allowed it before
+				name := generateRBACRoleNameForClusterNamespaceResource(kubermaticv1.AddonKindName, GenerateActualGroupNameFor(projectName, groupPrefix))
+				if err := rbacRoleLister.Delete(name, &metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+					return err
+				}
+			}
 			continue
 		}
 		sharedExistingRole, err := rbacRoleLister.Get(generatedRole.Name, metav1.GetOptions{})
@@ -384,8 +412,9 @@ func (c *resourcesController) ensureRBACRoleBindingForClusterAddons(projectName
 
 	rbacRoleBindingLister := clusterProvider.kubeClient.RbacV1().RoleBindings(cluster.Status.NamespaceName)
 
-	for _, groupPrefix := range AllGroupsPrefixes {
+	for _, groupPrefix := range c.projectRoles.allGroupsPrefixes() {
 		skip, _, err := shouldSkipRBACRoleForClusterNamespaceResource(
+			c.projectRoles,
 			projectName,
 			cluster,
 			kubermaticv1.AddonResourceName,
@@ -435,8 +464,9 @@ func (c *resourcesController) ensureRBACRoleBindingForClusterAddons(projectName
 // because for some groupPrefixes we actually don't create Role
 //
 // note that this method returns generated role if is not meant to be skipped
-func shouldSkipRBACRoleForClusterNamespaceResource(projectName string, cluster *kubermaticv1.Cluster, policyResource, policyAPIGroups, kind, groupPrefix string) (bool, *rbacv1.Role, error) {
+func shouldSkipRBACRoleForClusterNamespaceResource(roles *projectRoleRegistry, projectName string, cluster *kubermaticv1.Cluster, policyResource, policyAPIGroups, kind, groupPrefix string) (bool, *rbacv1.Role, error) {
 	generatedRole, err := generateRBACRoleForClusterNamespaceResource(
+		roles,
 		cluster,
 		GenerateActualGroupNameFor(projectName, groupPrefix),
 		policyResource,
@@ -52,7 +52,7 @@ func TestSyncProjectResourcesClusterWide(t *testing.T) {
 		// scenario 1
 		{
 			name:            "scenario 1: a proper set of RBAC Role/Binding is generated for a cluster",
-			expectedActions: []string{"create", "create", "create", "create", "create", "create", "get", "create", "get", "create", "get", "create", "get", "create", "get", "create", "get", "create"},
+			expectedActions: []string{"create", "create", "create", "create", "create", "create", "create", "create", "get", "create", "get", "create", "get", "create", "get", "create", "get", "create", "get", "create", "get", "create", "get", "create"},
 
 			dependantToSync: &resourceToProcess{
 				gvr: schema.GroupVersionResource{
@@ -142,6 +142,27 @@ func TestSyncProjectResourcesClusterWide(t *testing.T) {
 						},
 					},
 				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "kubermatic:cluster-abcd:operators-thunderball",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+								Kind:       kubermaticv1.ClusterKindName,
+								Name:       "abcd",
+								UID:        "abcdID", // set manually
+							},
+						},
+					},
+					Rules: []rbacv1.PolicyRule{
+						{
+							APIGroups:     []string{kubermaticv1.SchemeGroupVersion.Group},
+							Resources:     []string{kubermaticv1.ClusterResourceName},
+							ResourceNames: []string{"abcd"},
+							Verbs:         []string{"get", "update"},
+						},
+					},
+				},
 			},
 
 			expectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
@@ -222,13 +243,38 @@ func TestSyncProjectResourcesClusterWide(t *testing.T) {
 						Name:     "kubermatic:cluster-abcd:viewers-thunderball",
 					},
 				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "kubermatic:cluster-abcd:operators-thunderball",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+								Kind:       kubermaticv1.ClusterKindName,
+								Name:       "abcd",
+								UID:        "abcdID", // set manually
+							},
+						},
+					},
+					Subjects: []rbacv1.Subject{
+						{
+							APIGroup: rbacv1.GroupName,
+							Kind:     "Group",
+							Name:     "operators-thunderball",
+						},
+					},
+					RoleRef: rbacv1.RoleRef{
+						APIGroup: rbacv1.GroupName,
+						Kind:     "ClusterRole",
+						Name:     "kubermatic:cluster-abcd:operators-thunderball",
+					},
+				},
 			},
 		},
 
 		// scenario 2
 		{
 			name:            "scenario 2: a proper set of RBAC Role/Binding is generated for an ssh key",
-			expectedActions: []string{"create", "create", "create", "create", "create", "create"},
+			expectedActions: []string{"create", "create", "create", "create", "create", "create", "create", "create"},
 
 			dependantToSync: &resourceToProcess{
 				gvr: schema.GroupVersionResource{
@@ -319,6 +365,27 @@ func TestSyncProjectResourcesClusterWide(t *testing.T) {
 						},
 					},
 				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "kubermatic:usersshkey-abcd:operators-thunderball",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+								Kind:       kubermaticv1.SSHKeyKind,
+								Name:       "abcd",
+								UID:        "abcdID", // set manually
+							},
+						},
+					},
+					Rules: []rbacv1.PolicyRule{
+						{
+							APIGroups:     []string{kubermaticv1.SchemeGroupVersion.Group},
+							Resources:     []string{kubermaticv1.SSHKeyResourceName},
+							ResourceNames: []string{"abcd"},
+							Verbs:         []string{"get"},
+						},
+					},
+				},
 			},
 
 			expectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
@@ -399,6 +466,31 @@ func TestSyncProjectResourcesClusterWide(t *testing.T) {
 						Name:     "kubermatic:usersshkey-abcd:viewers-thunderball",
 					},
 				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "kubermatic:usersshkey-abcd:operators-thunderball",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+								Kind:       kubermaticv1.SSHKeyKind,
+								Name:       "abcd",
+								UID:        "abcdID", // set manually
+							},
+						},
+					},
+					Subjects: []rbacv1.Subject{
+						{
+							APIGroup: rbacv1.GroupName,
+							Kind:     "Group",
+							Name:     "operators-thunderball",
+						},
+					},
+					RoleRef: rbacv1.RoleRef{
+						APIGroup: rbacv1.GroupName,
+						Kind:     "ClusterRole",
+						Name:     "kubermatic:usersshkey-abcd:operators-thunderball",
+					},
+				},
 			},
 		},
 
@@ -549,7 +641,7 @@ func TestSyncProjectResourcesClusterWide(t *testing.T) {
 			}
 
 			// act
-			target := resourcesController{}
+			target := resourcesController{projectRoles: newProjectRoleRegistry()}
 			test.dependantToSync.clusterProvider = fakeClusterProvider
 			err := target.syncProjectResource(test.dependantToSync)
 
@@ -741,7 +833,7 @@ func TestSyncProjectResourcesNamespaced(t *testing.T) {
 			}
 
 			// act
-			target := resourcesController{}
+			target := resourcesController{projectRoles: newProjectRoleRegistry()}
 			test.dependantToSync.clusterProvider = fakeClusterProvider
 			err := target.syncProjectResource(test.dependantToSync)
 
@@ -815,7 +907,7 @@ func TestEnsureProjectClusterRBACRoleBindingForNamedResource(t *testing.T) {
 		{
 			name:            "scenario 1: desired RBAC Role Bindings for a project resource are created",
 			projectToSync:   test.CreateProject("thunderball", test.CreateUser("James Bond")),
-			expectedActions: []string{"create", "create", "create"},
+			expectedActions: []string{"create", "create", "create", "create"},
 			expectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
 				{
 					ObjectMeta: metav1.ObjectMeta{
@@ -892,6 +984,31 @@ func TestEnsureProjectClusterRBACRoleBindingForNamedResource(t *testing.T) {
 						Name:     "kubermatic:project-thunderball:viewers-thunderball",
 					},
 				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "kubermatic:project-thunderball:operators-thunderball",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+								Kind:       kubermaticv1.ProjectKindName,
+								Name:       "thunderball",
+								UID:        "thunderballID", // set manually
+							},
+						},
+					},
+					Subjects: []rbacv1.Subject{
+						{
+							APIGroup: rbacv1.GroupName,
+							Kind:     "Group",
+							Name:     "operators-thunderball",
+						},
+					},
+					RoleRef: rbacv1.RoleRef{
+						APIGroup: rbacv1.GroupName,
+						Kind:     "ClusterRole",
+						Name:     "kubermatic:project-thunderball:operators-thunderball",
+					},
+				},
 			},
 		},
 
@@ -975,6 +1092,31 @@ func TestEnsureProjectClusterRBACRoleBindingForNamedResource(t *testing.T) {
 						Name:     "kubermatic:project-thunderball:viewers-thunderball",
 					},
 				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "kubermatic:project-thunderball:operators-thunderball",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+								Kind:       kubermaticv1.ProjectKindName,
+								Name:       "thunderball",
+								UID:        "thunderballID", // set manually
+							},
+						},
+					},
+					Subjects: []rbacv1.Subject{
+						{
+							APIGroup: rbacv1.GroupName,
+							Kind:     "Group",
+							Name:     "operators-thunderball",
+						},
+					},
+					RoleRef: rbacv1.RoleRef{
+						APIGroup: rbacv1.GroupName,
+						Kind:     "ClusterRole",
+						Name:     "kubermatic:project-thunderball:operators-thunderball",
+					},
+				},
 			},
 		},
 
@@ -1059,6 +1201,31 @@ func TestEnsureProjectClusterRBACRoleBindingForNamedResource(t *testing.T) {
 						Name:     "kubermatic:project-thunderball:viewers-thunderball",
 					},
 				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "kubermatic:project-thunderball:operators-thunderball",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+								Kind:       kubermaticv1.ProjectKindName,
+								Name:       "thunderball",
+								UID:        "thunderballID", // set manually
+							},
+						},
+					},
+					Subjects: []rbacv1.Subject{
+						{
+							APIGroup: rbacv1.GroupName,
+							Kind:     "Group",
+							Name:     "operators-thunderball",
+						},
+					},
+					RoleRef: rbacv1.RoleRef{
+						APIGroup: rbacv1.GroupName,
+						Kind:     "ClusterRole",
+						Name:     "kubermatic:project-thunderball:operators-thunderball",
+					},
+				},
 			},
 			expectedClusterRoleBindings: []*rbacv1.ClusterRoleBinding{
 				{
@@ -1130,7 +1297,7 @@ func TestEnsureProjectClusterRBACRoleBindingForNamedResource(t *testing.T) {
 			clusterRoleBindingLister := rbaclister.NewClusterRoleBindingLister(clusterRoleBindingIndexer)
 
 			// act
-			err := ensureClusterRBACRoleBindingForNamedResource(test.projectToSync.Name, kubermaticv1.ProjectResourceName, kubermaticv1.ProjectKindName, test.projectToSync.GetObjectMeta(), fakeKubeClient, clusterRoleBindingLister)
+			err := ensureClusterRBACRoleBindingForNamedResource(newProjectRoleRegistry(), test.projectToSync.Name, kubermaticv1.ProjectResourceName, kubermaticv1.ProjectKindName, test.projectToSync.GetObjectMeta(), fakeKubeClient, clusterRoleBindingLister)
 
 			// validate
 			if err != nil {
@@ -1176,7 +1343,7 @@ func TestEnsureProjectClusterRBACRoleForNamedResource(t *testing.T) {
 		{
 			name:            "scenario 1: desired RBAC Roles for a project resource are created",
 			projectToSync:   test.CreateProject("thunderball", test.CreateUser("James Bond")),
-			expectedActions: []string{"create", "create", "create"},
+			expectedActions: []string{"create", "create", "create", "create"},
 			expectedClusterRoles: []*rbacv1.ClusterRole{
 				{
 					ObjectMeta: metav1.ObjectMeta{
@@ -1242,6 +1409,27 @@ func TestEnsureProjectClusterRBACRoleForNamedResource(t *testing.T) {
 						},
 					},
 				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "kubermatic:project-thunderball:operators-thunderball",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+								Kind:       kubermaticv1.ProjectKindName,
+								Name:       "thunderball",
+								UID:        "thunderballID", // set manually
+							},
+						},
+					},
+					Rules: []rbacv1.PolicyRule{
+						{
+							APIGroups:     []string{kubermaticv1.SchemeGroupVersion.Group},
+							Resources:     []string{"projects"},
+							ResourceNames: []string{"thunderball"},
+							Verbs:         []string{"get"},
+						},
+					},
+				},
 			},
 		},
 
@@ -1314,6 +1502,27 @@ func TestEnsureProjectClusterRBACRoleForNamedResource(t *testing.T) {
 						},
 					},
 				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "kubermatic:project-thunderball:operators-thunderball",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+								Kind:       kubermaticv1.ProjectKindName,
+								Name:       "thunderball",
+								UID:        "thunderballID", // set manually
+							},
+						},
+					},
+					Rules: []rbacv1.PolicyRule{
+						{
+							APIGroups:     []string{kubermaticv1.SchemeGroupVersion.Group},
+							Resources:     []string{"projects"},
+							ResourceNames: []string{"thunderball"},
+							Verbs:         []string{"get"},
+						},
+					},
+				},
 			},
 		},
 
@@ -1388,6 +1597,27 @@ func TestEnsureProjectClusterRBACRoleForNamedResource(t *testing.T) {
 						},
 					},
 				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "kubermatic:project-thunderball:operators-thunderball",
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+								Kind:       kubermaticv1.ProjectKindName,
+								Name:       "thunderball",
+								UID:        "thunderballID", // set manually
+							},
+						},
+					},
+					Rules: []rbacv1.PolicyRule{
+						{
+							APIGroups:     []string{kubermaticv1.SchemeGroupVersion.Group},
+							Resources:     []string{"projects"},
+							ResourceNames: []string{"thunderball"},
+							Verbs:         []string{"get"},
+						},
+					},
+				},
 			},
 			expectedClusterRoles: []*rbacv1.ClusterRole{
 				{
@@ -1430,7 +1660,7 @@ func TestEnsureProjectClusterRBACRoleForNamedResource(t *testing.T) {
 			clusterRoleLister := rbaclister.NewClusterRoleLister(clusterRoleIndexer)
 
 			// act
-			err := ensureClusterRBACRoleForNamedResource(test.projectToSync.Name, kubermaticv1.ProjectResourceName, kubermaticv1.ProjectKindName, test.projectToSync.GetObjectMeta(), fakeKubeClient, clusterRoleLister)
+			err := ensureClusterRBACRoleForNamedResource(newProjectRoleRegistry(), test.projectToSync.Name, kubermaticv1.ProjectResourceName, kubermaticv1.ProjectKindName, test.projectToSync.GetObjectMeta(), fakeKubeClient, clusterRoleLister)
 
 			// validate
 			if err != nil {
@@ -26,9 +26,12 @@ import (
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/provider"
 
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
@@ -73,5 +76,25 @@ func Add(
 		return fmt.Errorf("failed to create watcher: %v", err)
 	}
 
+	// AddonConfigs are copied into every seed
+	enqueueAllSeeds := &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(func(a handler.MapObject) []reconcile.Request {
+		seeds := &kubermaticv1.SeedList{}
+		if err := mgr.GetClient().List(ctx, seeds, ctrlruntimeclient.InNamespace(namespace)); err != nil {
+			reconciler.log.Errorw("Failed to list seeds", zap.Error(err))
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, seed := range seeds.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: seed.Namespace, Name: seed.Name},
+			})
+		}
+		return requests
+	})}
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.AddonConfig{}}, enqueueAllSeeds); err != nil {
+		return fmt.Errorf("failed to create watcher for AddonConfigs: %v", err)
+	}
+
 	return nil
 }
@@ -17,5 +17,7 @@ limitations under the License.
 /*
 Package seedsync contains a controller that is responsible for synchronizing the `Seed` custom
 resources onto the corresponding seed clusters, so that the seed-controller-manager can use them.
+It also copies all `AddonConfig` resources into every seed cluster, as the addon controllers use
+them to determine the dependencies between addons.
 */
 package seedsync
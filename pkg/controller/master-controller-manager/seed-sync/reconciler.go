@@ -30,14 +30,15 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-// Reconciler copies seed CRs into their respective clusters,
-// assuming that Kubermatic and the seed CRD have already been
-// installed.
+// Reconciler copies seed CRs and all AddonConfigs into their
+// respective clusters, assuming that Kubermatic and the CRDs
+// have already been installed.
 type Reconciler struct {
 	ctrlruntimeclient.Client
 
@@ -102,6 +103,7 @@ func (r *Reconciler) reconcile(seed *kubermaticv1.Seed, client ctrlruntimeclient
 	supportedStrategies := map[corev1.ServiceType]struct{}{
 		corev1.ServiceTypeNodePort:     {},
 		corev1.ServiceTypeLoadBalancer: {},
+		kubermaticv1.ExposeStrategySNI: {},
 	}
 	if seed.Spec.ExposeStrategy != "" {
 		if _, ok := supportedStrategies[seed.Spec.ExposeStrategy]; !ok {
@@ -112,6 +114,46 @@ func (r *Reconciler) reconcile(seed *kubermaticv1.Seed, client ctrlruntimeclient
 		return fmt.Errorf("failed to reconcile seed: %v", err)
 	}
 
+	if err := r.reconcileAddonConfigs(client, logger); err != nil {
+		return fmt.Errorf("failed to reconcile addon configs: %v", err)
+	}
+
+	return nil
+}
+
+// reconcileAddonConfigs copies all AddonConfigs into the seed cluster, as the addon controllers
+// need them to determine the addon dependencies. Copies of AddonConfigs that got deleted in the
+// master cluster are removed.
+func (r *Reconciler) reconcileAddonConfigs(seedClient ctrlruntimeclient.Client, logger *zap.SugaredLogger) error {
+	addonConfigs := &kubermaticv1.AddonConfigList{}
+	if err := r.List(r.ctx, addonConfigs); err != nil {
+		return fmt.Errorf("failed to list AddonConfigs: %v", err)
+	}
+
+	var addonConfigCreators []reconciling.NamedAddonConfigCreatorGetter
+	wanted := sets.NewString()
+	for i := range addonConfigs.Items {
+		addonConfigCreators = append(addonConfigCreators, addonConfigCreator(&addonConfigs.Items[i]))
+		wanted.Insert(addonConfigs.Items[i].Name)
+	}
+	if err := reconciling.ReconcileAddonConfigs(r.ctx, addonConfigCreators, "", seedClient); err != nil {
+		return err
+	}
+
+	seedAddonConfigs := &kubermaticv1.AddonConfigList{}
+	if err := seedClient.List(r.ctx, seedAddonConfigs, ctrlruntimeclient.MatchingLabels{ManagedByLabel: ControllerName}); err != nil {
+		return fmt.Errorf("failed to list AddonConfigs in seed cluster: %v", err)
+	}
+	for i, addonConfig := range seedAddonConfigs.Items {
+		if wanted.Has(addonConfig.Name) {
+			continue
+		}
+		logger.Debugw("Deleting AddonConfig copy", "addonconfig", addonConfig.Name)
+		if err := seedClient.Delete(r.ctx, &seedAddonConfigs.Items[i]); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete AddonConfig %s: %v", addonConfig.Name, err)
+		}
+	}
+
 	return nil
 }
 
@@ -201,3 +201,57 @@ func TestReconcilingSeed(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcilingAddonConfigs(t *testing.T) {
+	seed := &kubermaticv1.Seed{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-seed",
+			Namespace: "kubermatic",
+		},
+	}
+	masterClient := ctrlruntimefake.NewFakeClient(
+		seed,
+		&kubermaticv1.AddonConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "ingress"},
+			Spec: kubermaticv1.AddonConfigSpec{
+				Dependencies: []string{"cert-manager"},
+			},
+		},
+	)
+	seedClient := ctrlruntimefake.NewFakeClient(
+		&kubermaticv1.AddonConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "removed",
+				Labels: map[string]string{ManagedByLabel: ControllerName},
+			},
+		},
+		&kubermaticv1.AddonConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "seed-only"},
+		},
+	)
+	ctx := context.Background()
+
+	reconciler := Reconciler{
+		Client:   masterClient,
+		recorder: record.NewFakeRecorder(10),
+		log:      zap.NewNop().Sugar(),
+		ctx:      ctx,
+	}
+	if err := reconciler.reconcile(seed, seedClient, reconciler.log); err != nil {
+		t.Fatalf("reconciling failed: %v", err)
+	}
+
+	addonConfig := &kubermaticv1.AddonConfig{}
+	if err := seedClient.Get(ctx, ctrlruntimeclient.ObjectKey{Name: "ingress"}, addonConfig); err != nil {
+		t.Fatalf("addon config should exist in seed cluster: %v", err)
+	}
+	if len(addonConfig.Spec.Dependencies) != 1 || addonConfig.Spec.Dependencies[0] != "cert-manager" {
+		t.Errorf("addon config spec should have been copied, got %v", addonConfig.Spec)
+	}
+	if err := seedClient.Get(ctx, ctrlruntimeclient.ObjectKey{Name: "removed"}, addonConfig); !kerrors.IsNotFound(err) {
+		t.Errorf("addon config copy should have been removed, got err=%v", err)
+	}
+	if err := seedClient.Get(ctx, ctrlruntimeclient.ObjectKey{Name: "seed-only"}, addonConfig); err != nil {
+		t.Errorf("addon config which was not copied should have been kept, got err=%v", err)
+	}
+}
@@ -37,3 +37,20 @@ func seedCreator(seed *kubermaticv1.Seed) reconciling.NamedSeedCreatorGetter {
 		}
 	}
 }
+
+func addonConfigCreator(config *kubermaticv1.AddonConfig) reconciling.NamedAddonConfigCreatorGetter {
+	return func() (string, reconciling.AddonConfigCreator) {
+		return config.Name, func(c *kubermaticv1.AddonConfig) (*kubermaticv1.AddonConfig, error) {
+			c.Labels = map[string]string{}
+			for k, v := range config.Labels {
+				c.Labels[k] = v
+			}
+			c.Labels[ManagedByLabel] = ControllerName
+
+			c.Annotations = config.Annotations
+			c.Spec = config.Spec
+
+			return c, nil
+		}
+	}
+}
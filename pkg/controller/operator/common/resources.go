@@ -27,6 +27,7 @@ import (
 	"github.com/kubermatic/kubermatic/pkg/resources/certificates/servingcerthelper"
 	"github.com/kubermatic/kubermatic/pkg/resources/certificates/triple"
 	"github.com/kubermatic/kubermatic/pkg/resources/reconciling"
+	seedvalidation "github.com/kubermatic/kubermatic/pkg/validation/seed"
 
 	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
@@ -240,6 +241,66 @@ func SeedAdmissionWebhookCreator(cfg *operatorv1alpha1.KubermaticConfiguration,
 	}
 }
 
+func AddonConfigAdmissionWebhookName(cfg *operatorv1alpha1.KubermaticConfiguration) string {
+	return fmt.Sprintf("kubermatic-addonconfigs-%s", cfg.Namespace)
+}
+
+// AddonConfigAdmissionWebhookCreator returns the webhook which validates the dependencies of AddonConfigs.
+// It is served by the seed webhook of the master-controller-manager.
+func AddonConfigAdmissionWebhookCreator(cfg *operatorv1alpha1.KubermaticConfiguration, client ctrlruntimeclient.Client) reconciling.NamedValidatingWebhookConfigurationCreatorGetter {
+	return func() (string, reconciling.ValidatingWebhookConfigurationCreator) {
+		return AddonConfigAdmissionWebhookName(cfg), func(hook *admissionregistrationv1beta1.ValidatingWebhookConfiguration) (*admissionregistrationv1beta1.ValidatingWebhookConfiguration, error) {
+			matchPolicy := admissionregistrationv1beta1.Exact
+			failurePolicy := admissionregistrationv1beta1.Fail
+			sideEffects := admissionregistrationv1beta1.SideEffectClassNone
+			scope := admissionregistrationv1beta1.ClusterScope
+
+			ca, err := seedWebhookCABundle(cfg, client)
+			if err != nil {
+				return nil, fmt.Errorf("cannot find Seed Admission CA bundle: %v", err)
+			}
+
+			hook.Webhooks = []admissionregistrationv1beta1.ValidatingWebhook{
+				{
+					Name:                    "addonconfigs.kubermatic.io", // this should be a FQDN
+					AdmissionReviewVersions: []string{admissionregistrationv1beta1.SchemeGroupVersion.Version},
+					MatchPolicy:             &matchPolicy,
+					FailurePolicy:           &failurePolicy,
+					SideEffects:             &sideEffects,
+					TimeoutSeconds:          pointer.Int32Ptr(30),
+					ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{
+						CABundle: ca,
+						Service: &admissionregistrationv1beta1.ServiceReference{
+							Name:      seedWebhookServiceName,
+							Namespace: cfg.Namespace,
+							Path:      pointer.StringPtr(seedvalidation.AddonConfigValidationPath),
+							Port:      pointer.Int32Ptr(443),
+						},
+					},
+					NamespaceSelector: &metav1.LabelSelector{},
+					ObjectSelector:    &metav1.LabelSelector{},
+					Rules: []admissionregistrationv1beta1.RuleWithOperations{
+						{
+							Rule: admissionregistrationv1beta1.Rule{
+								APIGroups:   []string{kubermaticv1.GroupName},
+								APIVersions: []string{"*"},
+								Resources:   []string{"addonconfigs"},
+								Scope:       &scope,
+							},
+							Operations: []admissionregistrationv1beta1.OperationType{
+								admissionregistrationv1beta1.Create,
+								admissionregistrationv1beta1.Update,
+							},
+						},
+					},
+				},
+			}
+
+			return hook, nil
+		}
+	}
+}
+
 func SeedAdmissionServiceCreator(cfg *operatorv1alpha1.KubermaticConfiguration, client ctrlruntimeclient.Client) reconciling.NamedServiceCreatorGetter {
 	return func() (string, reconciling.ServiceCreator) {
 		return seedWebhookServiceName, func(s *corev1.Service) (*corev1.Service, error) {
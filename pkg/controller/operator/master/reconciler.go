@@ -164,6 +164,10 @@ func (r *Reconciler) cleanupDeletedConfiguration(config *operatorv1alpha1.Kuberm
 		return fmt.Errorf("failed to clean up ValidatingWebhookConfiguration: %v", err)
 	}
 
+	if err := common.CleanupClusterResource(r, &admissionregistrationv1beta1.ValidatingWebhookConfiguration{}, common.AddonConfigAdmissionWebhookName(config)); err != nil {
+		return fmt.Errorf("failed to clean up ValidatingWebhookConfiguration: %v", err)
+	}
+
 	oldConfig := config.DeepCopy()
 	kubernetes.RemoveFinalizer(config, common.CleanupFinalizer)
 
@@ -347,6 +351,7 @@ func (r *Reconciler) reconcileAdmissionWebhooks(config *operatorv1alpha1.Kuberma
 
 	creators := []reconciling.NamedValidatingWebhookConfigurationCreatorGetter{
 		common.SeedAdmissionWebhookCreator(config, r.Client),
+		common.AddonConfigAdmissionWebhookCreator(config, r.Client),
 	}
 
 	if err := reconciling.ReconcileValidatingWebhookConfigurations(r.ctx, creators, "", r.Client); err != nil {
@@ -452,8 +452,8 @@ func (r *Reconciler) reconcileDeployments(cfg *operatorv1alpha1.KubermaticConfig
 	if !seed.Spec.NodeportProxy.Disable {
 		creators = append(
 			creators,
-			nodeportproxy.EnvoyDeploymentCreator(seed, r.versions),
-			nodeportproxy.UpdaterDeploymentCreator(seed, r.versions),
+			nodeportproxy.EnvoyDeploymentCreator(cfg, seed, r.versions),
+			nodeportproxy.UpdaterDeploymentCreator(cfg, seed, r.versions),
 		)
 	}
 
@@ -517,7 +517,10 @@ func (r *Reconciler) reconcileServices(cfg *operatorv1alpha1.KubermaticConfigura
 	// remove the entire Kubermatic namespace.
 	if !seed.Spec.NodeportProxy.Disable {
 		creators = []reconciling.NamedServiceCreatorGetter{
-			nodeportproxy.ServiceCreator(),
+			nodeportproxy.ServiceCreator(seed),
+		}
+		if nodeportproxy.SNIEnabled(cfg, seed) {
+			creators = append(creators, nodeportproxy.InClusterServiceCreator(seed))
 		}
 
 		if err := reconciling.ReconcileServices(r.ctx, creators, cfg.Namespace, client); err != nil {
@@ -22,6 +22,7 @@ import (
 
 	"github.com/kubermatic/kubermatic/pkg/controller/operator/common"
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	operatorv1alpha1 "github.com/kubermatic/kubermatic/pkg/crd/operator/v1alpha1"
 	"github.com/kubermatic/kubermatic/pkg/resources/reconciling"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -37,9 +38,21 @@ const (
 	EnvoyDeploymentName   = "nodeport-proxy-envoy"
 	UpdaterDeploymentName = "nodeport-proxy-updater"
 	EnvoyPort             = 8002
+	// EnvoySNIListenerPort is the port on which envoy routes TLS connections by their
+	// server name, it is exposed on port 443 of the LoadBalancer
+	EnvoySNIListenerPort = 6443
 )
 
-func EnvoyDeploymentCreator(seed *kubermaticv1.Seed, versions common.Versions) reconciling.NamedDeploymentCreatorGetter {
+// SNIEnabled returns whether the nodeport-proxy of the seed should route TLS connections by
+// their server name, which clusters using the SNI expose strategy require
+func SNIEnabled(cfg *operatorv1alpha1.KubermaticConfiguration, seed *kubermaticv1.Seed) bool {
+	if seed.Spec.NodeportProxy.EnableSNI || seed.Spec.ExposeStrategy == kubermaticv1.ExposeStrategySNI {
+		return true
+	}
+	return seed.Spec.ExposeStrategy == "" && cfg.Spec.ExposeStrategy == operatorv1alpha1.SNIStrategy
+}
+
+func EnvoyDeploymentCreator(cfg *operatorv1alpha1.KubermaticConfiguration, seed *kubermaticv1.Seed, versions common.Versions) reconciling.NamedDeploymentCreatorGetter {
 	return func() (string, reconciling.DeploymentCreator) {
 		return EnvoyDeploymentName, func(d *appsv1.Deployment) (*appsv1.Deployment, error) {
 			d.Spec.Replicas = pointer.Int32Ptr(3)
@@ -89,17 +102,34 @@ func EnvoyDeploymentCreator(seed *kubermaticv1.Seed, versions common.Versions) r
 				},
 			}
 
+			envoyManagerArgs := []string{
+				"-listen-address=:8001",
+				"-envoy-node-name=kube",
+				"-envoy-admin-port=9001",
+				fmt.Sprintf("-envoy-stats-port=%d", EnvoyPort),
+			}
+			envoyPorts := []corev1.ContainerPort{
+				{
+					Name:          "stats",
+					Protocol:      corev1.ProtocolTCP,
+					ContainerPort: EnvoyPort,
+				},
+			}
+			if SNIEnabled(cfg, seed) {
+				envoyManagerArgs = append(envoyManagerArgs, fmt.Sprintf("-envoy-sni-listener-port=%d", EnvoySNIListenerPort))
+				envoyPorts = append(envoyPorts, corev1.ContainerPort{
+					Name:          "sni",
+					Protocol:      corev1.ProtocolTCP,
+					ContainerPort: EnvoySNIListenerPort,
+				})
+			}
+
 			d.Spec.Template.Spec.Containers = []corev1.Container{
 				{
 					Name:    "envoy-manager",
 					Image:   seed.Spec.NodeportProxy.EnvoyManager.DockerRepository + ":" + versions.Kubermatic,
 					Command: []string{"/envoy-manager"},
-					Args: []string{
-						"-listen-address=:8001",
-						"-envoy-node-name=kube",
-						"-envoy-admin-port=9001",
-						fmt.Sprintf("-envoy-stats-port=%d", EnvoyPort),
-					},
+					Args:    envoyManagerArgs,
 					Ports: []corev1.ContainerPort{
 						{
 							Name:          "grpc",
@@ -127,13 +157,7 @@ func EnvoyDeploymentCreator(seed *kubermaticv1.Seed, versions common.Versions) r
 						"--service-node",
 						"kube",
 					},
-					Ports: []corev1.ContainerPort{
-						{
-							Name:          "stats",
-							Protocol:      corev1.ProtocolTCP,
-							ContainerPort: EnvoyPort,
-						},
-					},
+					Ports: envoyPorts,
 					VolumeMounts: []corev1.VolumeMount{
 						{
 							Name:      "envoy-config",
@@ -196,7 +220,7 @@ func EnvoyPDBCreator() reconciling.NamedPodDisruptionBudgetCreatorGetter {
 	}
 }
 
-func UpdaterDeploymentCreator(seed *kubermaticv1.Seed, versions common.Versions) reconciling.NamedDeploymentCreatorGetter {
+func UpdaterDeploymentCreator(cfg *operatorv1alpha1.KubermaticConfiguration, seed *kubermaticv1.Seed, versions common.Versions) reconciling.NamedDeploymentCreatorGetter {
 	return func() (string, reconciling.DeploymentCreator) {
 		return UpdaterDeploymentName, func(d *appsv1.Deployment) (*appsv1.Deployment, error) {
 			d.Spec.Replicas = pointer.Int32Ptr(1)
@@ -218,26 +242,49 @@ func UpdaterDeploymentCreator(seed *kubermaticv1.Seed, versions common.Versions)
 				},
 			}
 
+			env := []corev1.EnvVar{
+				{
+					Name: "NAMESPACE",
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: "metadata.namespace",
+						},
+					},
+				},
+			}
+
+			args := []string{
+				"-lb-namespace=$(NAMESPACE)",
+				fmt.Sprintf("-lb-name=%s", ServiceName),
+			}
+			if SNIEnabled(cfg, seed) {
+				args = append(args, fmt.Sprintf("-sni-listener-port=%d", EnvoySNIListenerPort))
+			}
+
 			d.Spec.Template.Spec.Containers = []corev1.Container{
 				{
 					Name:    "lb-updater",
 					Image:   seed.Spec.NodeportProxy.Updater.DockerRepository + ":" + versions.Kubermatic,
 					Command: []string{"/lb-updater"},
+					Args:    args,
+					Env:     env,
+				},
+			}
+
+			// The NodePorts of clusters using SNI are exposed on their own LoadBalancer, so that
+			// the number of ports of the LoadBalancer serving the SNI listener does not grow
+			if SNIEnabled(cfg, seed) {
+				d.Spec.Template.Spec.Containers = append(d.Spec.Template.Spec.Containers, corev1.Container{
+					Name:    "lb-updater-in-cluster",
+					Image:   seed.Spec.NodeportProxy.Updater.DockerRepository + ":" + versions.Kubermatic,
+					Command: []string{"/lb-updater"},
 					Args: []string{
 						"-lb-namespace=$(NAMESPACE)",
-						fmt.Sprintf("-lb-name=%s", ServiceName),
+						fmt.Sprintf("-lb-name=%s", InClusterServiceName),
+						fmt.Sprintf("-expose-annotation-key=%s", ExposeInClusterAnnotationKey),
 					},
-					Env: []corev1.EnvVar{
-						{
-							Name: "NAMESPACE",
-							ValueFrom: &corev1.EnvVarSource{
-								FieldRef: &corev1.ObjectFieldSelector{
-									FieldPath: "metadata.namespace",
-								},
-							},
-						},
-					},
-				},
+					Env: env,
+				})
 			}
 
 			return d, nil
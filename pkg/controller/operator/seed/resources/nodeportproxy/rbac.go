@@ -39,7 +39,7 @@ func RoleCreator() reconciling.NamedRoleCreatorGetter {
 					APIGroups:     []string{""},
 					Resources:     []string{"services"},
 					Verbs:         []string{"update"},
-					ResourceNames: []string{ServiceName},
+					ResourceNames: []string{ServiceName, InClusterServiceName},
 				},
 			}
 
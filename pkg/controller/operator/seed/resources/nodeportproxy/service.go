@@ -18,6 +18,7 @@ package nodeportproxy
 
 import (
 	"github.com/kubermatic/kubermatic/pkg/controller/operator/common"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/resources/reconciling"
 
 	corev1 "k8s.io/api/core/v1"
@@ -30,15 +31,28 @@ import (
 
 const (
 	ServiceName = "nodeport-proxy"
+	// InClusterServiceName is the LoadBalancer which exposes the NodePorts of clusters using the SNI
+	// expose strategy to the clients inside the user clusters, which do not send a server name.
+	// The seed-controller-manager looks it up by this name to determine the address of those clusters.
+	InClusterServiceName = "nodeport-proxy-in-cluster"
+	// ExposeInClusterAnnotationKey is the annotation of the services exposed on the InClusterService
+	ExposeInClusterAnnotationKey = "nodeport-proxy.k8s.io/expose-in-cluster"
 )
 
-func ServiceCreator() reconciling.NamedServiceCreatorGetter {
+func ServiceCreator(seed *kubermaticv1.Seed) reconciling.NamedServiceCreatorGetter {
 	return func() (string, reconciling.ServiceCreator) {
 		return ServiceName, func(s *corev1.Service) (*corev1.Service, error) {
 			// We don't actually manage this service, that is done by the nodeport proxy, we just
 			// must make sure that it exists
 
 			s.Spec.Type = corev1.ServiceTypeLoadBalancer
+			// Envoy can only enforce the source ranges the apiservers are restricted to if the
+			// client addresses are preserved, which is opt-in as it requires the LoadBalancer to
+			// check the health of the nodes
+			s.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
+			if seed.Spec.NodeportProxy.ExternalTrafficPolicy != "" {
+				s.Spec.ExternalTrafficPolicy = seed.Spec.NodeportProxy.ExternalTrafficPolicy
+			}
 			s.Spec.Selector = map[string]string{
 				common.NameLabel: ServiceName,
 			}
@@ -59,3 +73,14 @@ func ServiceCreator() reconciling.NamedServiceCreatorGetter {
 		}
 	}
 }
+
+// InClusterServiceCreator returns the LoadBalancer service the in-cluster lb-updater manages, it
+// is only required if SNI is enabled
+func InClusterServiceCreator(seed *kubermaticv1.Seed) reconciling.NamedServiceCreatorGetter {
+	return func() (string, reconciling.ServiceCreator) {
+		return InClusterServiceName, func(s *corev1.Service) (*corev1.Service, error) {
+			_, creator := ServiceCreator(seed)()
+			return creator(s)
+		}
+	}
+}
@@ -17,19 +17,13 @@ limitations under the License.
 package addon
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"io/ioutil"
-	"os"
-	"os/exec"
 	"path"
 	"reflect"
 	"strings"
 	"time"
 
-	"github.com/ghodss/yaml"
-
 	"go.uber.org/zap"
 
 	addonutils "github.com/kubermatic/kubermatic/pkg/addon"
@@ -45,7 +39,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
@@ -66,6 +59,10 @@ const (
 	addonLabelKey        = "kubermatic-addon"
 	cleanupFinalizerName = "cleanup-manifests"
 	addonEnsureLabelKey  = "addons.kubermatic.io/ensure"
+
+	// healthCheckInterval is the interval in which we check the health of the addon objects
+	// when the addon enforcement is disabled
+	healthCheckInterval = 5 * time.Minute
 )
 
 // KubeconfigProvider provides functionality to get a clusters admin kubeconfig
@@ -82,6 +79,7 @@ type Reconciler struct {
 	addonVariables       map[string]interface{}
 	kubernetesAddonDir   string
 	openshiftAddonDir    string
+	addonChartsDir       string
 	overwriteRegistry    string
 	ctrlruntimeclient.Client
 	recorder                 record.EventRecorder
@@ -100,6 +98,7 @@ func Add(
 	addonCtxVariables map[string]interface{},
 	kubernetesAddonDir,
 	openshiftAddonDir,
+	addonChartsDir,
 	overwriteRegistey string,
 	nodeLocalDNSCacheEnabled bool,
 	kubeconfigProvider KubeconfigProvider,
@@ -113,6 +112,7 @@ func Add(
 		addonEnforceInterval:     addonEnforceInterval,
 		kubernetesAddonDir:       kubernetesAddonDir,
 		openshiftAddonDir:        openshiftAddonDir,
+		addonChartsDir:           addonChartsDir,
 		KubeconfigProvider:       kubeconfigProvider,
 		Client:                   client,
 		workerName:               workerName,
@@ -164,7 +164,30 @@ func Add(
 		return err
 	}
 
-	return c.Watch(&source.Kind{Type: &kubermaticv1.Addon{}}, &handler.EnqueueRequestForObject{})
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Addon{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Addons must be reconciled when one of their dependencies or dependents changed, so they
+	// get unblocked or removed in the right order
+	enqueueRelatedAddons := &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(func(a handler.MapObject) []reconcile.Request {
+		addon := a.Object.(*kubermaticv1.Addon)
+
+		graph, err := addonutils.GetDependencyGraph(context.Background(), client)
+		if err != nil {
+			log.Errorw("Failed to get addon dependencies", zap.Error(err), "addon", addon.Name)
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, name := range append(graph.Dependents(addon.Spec.Name), graph[addon.Spec.Name]...) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: addon.Namespace, Name: name},
+			})
+		}
+		return requests
+	})}
+	return c.Watch(&source.Kind{Type: &kubermaticv1.Addon{}}, enqueueRelatedAddons)
 }
 
 func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
@@ -219,6 +242,8 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 	if result == nil {
 		// we check for this after the ClusterReconcileWrapper() call because otherwise the cluster would never reconcile since we always requeue
 		result = &reconcile.Result{}
+		// Requeue in any case to regularly check the health of the addon objects
+		result.RequeueAfter = healthCheckInterval
 		if r.addonEnforceInterval != 0 { // addon enforce is enabled
 			// All is well, requeue in addonEnforceInterval minutes. We do this to enforce default addons and prevent cluster admins from disabling them.
 			result.RequeueAfter = time.Duration(r.addonEnforceInterval) * time.Minute
@@ -253,6 +278,14 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, addo
 	}
 
 	if addon.DeletionTimestamp != nil {
+		dependents, err := r.getDependentsBeingDeleted(ctx, log, addon)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check for dependent addons: %v", err)
+		}
+		if len(dependents) > 0 {
+			log.Debugw("Waiting for dependent addons to be removed first", "dependents", dependents)
+			return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+		}
 		if err := r.cleanupManifests(ctx, log, addon, cluster); err != nil {
 			return nil, fmt.Errorf("failed to delete manifests from cluster: %v", err)
 		}
@@ -261,10 +294,32 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, addo
 		}
 		return nil, nil
 	}
+
+	blocking, err := r.getBlockingDependencies(ctx, addon)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check the addon dependencies: %v", err)
+	}
+	if err := r.ensureBlockedConditionIsSet(ctx, addon, blocking); err != nil {
+		return nil, fmt.Errorf("failed to set the Blocked condition: %v", err)
+	}
+	if len(blocking) > 0 {
+		// Neither install nor upgrade the addon, we get triggered again once the dependencies changed
+		log.Debugw("Addon is blocked by its dependencies", "reasons", blocking)
+		if addonResourcesCreated(addon) {
+			if err := r.ensureHealthStatus(ctx, log, addon, cluster); err != nil {
+				return nil, fmt.Errorf("failed to update the health status of the addon: %v", err)
+			}
+		}
+		return nil, nil
+	}
+
 	// This is true when the addon: 1) is fully deployed, 2) doesn't have a `addonEnsureLabelKey` set to true.
 	// we do this to allow users to "edit/delete" resources deployed by unlabeled addons,
 	// while we enfornce the labeled ones
 	if addonResourcesCreated(addon) && !hasEnsureResourcesLabel(addon) {
+		if err := r.ensureHealthStatus(ctx, log, addon, cluster); err != nil {
+			return nil, fmt.Errorf("failed to update the health status of the addon: %v", err)
+		}
 		return nil, nil
 	}
 
@@ -278,6 +333,9 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, addo
 	if err := r.ensureResourcesCreatedConditionIsSet(ctx, addon); err != nil {
 		return nil, fmt.Errorf("failed to set add ResourcesCreated Condition: %v", err)
 	}
+	if err := r.ensureHealthStatus(ctx, log, addon, cluster); err != nil {
+		return nil, fmt.Errorf("failed to update the health status of the addon: %v", err)
+	}
 	return nil, nil
 }
 
@@ -342,6 +400,22 @@ func (r *Reconciler) getAddonManifests(log *zap.SugaredLogger, addon *kubermatic
 		return nil, fmt.Errorf("failed to create template data for addon manifests: %v", err)
 	}
 
+	if chart := addon.Spec.Chart; chart != nil {
+		chartPath, err := r.getAddonChartPath(chart, addonDir)
+		if err != nil {
+			return nil, err
+		}
+		namespace := chart.Namespace
+		if namespace == "" {
+			namespace = metav1.NamespaceSystem
+		}
+		allManifests, err := addonutils.ParseChart(log, r.overwriteRegistry, chartPath, addon.Spec.Name, namespace, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render addon chart %s: %v", chartPath, err)
+		}
+		return allManifests, nil
+	}
+
 	manifestPath := path.Join(addonDir, addon.Spec.Name)
 	allManifests, err := addonutils.ParseFromFolder(log, r.overwriteRegistry, manifestPath, data)
 	if err != nil {
@@ -351,23 +425,28 @@ func (r *Reconciler) getAddonManifests(log *zap.SugaredLogger, addon *kubermatic
 	return allManifests, nil
 }
 
-// combineManifests returns all manifests combined into a multi document yaml
-func (r *Reconciler) combineManifests(manifests []*bytes.Buffer) *bytes.Buffer {
-	parts := make([]string, len(manifests))
-	for i, m := range manifests {
-		s := m.String()
-		s = strings.TrimSuffix(s, "\n")
-		s = strings.TrimSpace(s)
-		parts[i] = s
+// getAddonChartPath returns the path of a packaged chart in the chart repository if a version
+// is given, otherwise the path of the unpacked chart in the addon folder.
+func (r *Reconciler) getAddonChartPath(chart *kubermaticv1.AddonChart, addonDir string) (string, error) {
+	if chart.Name == "" {
+		return "", fmt.Errorf("chart name is empty")
+	}
+	// The chart must not be loaded from outside of the addon folders
+	for _, part := range []string{chart.Name, chart.Version} {
+		if strings.ContainsAny(part, `/\`) || strings.Contains(part, "..") {
+			return "", fmt.Errorf("invalid chart %q with version %q", chart.Name, chart.Version)
+		}
 	}
 
-	return bytes.NewBufferString(strings.Join(parts, "\n---\n") + "\n")
+	if chart.Version != "" {
+		return path.Join(r.addonChartsDir, fmt.Sprintf("%s-%s.tgz", chart.Name, chart.Version)), nil
+	}
+	return path.Join(addonDir, chart.Name), nil
 }
 
-// ensureAddonLabelOnManifests adds the addonLabelKey label to all manifests.
-// For this to happen we need to decode all yaml files to json, parse them, add the label and finally encode to yaml again
-func (r *Reconciler) ensureAddonLabelOnManifests(addon *kubermaticv1.Addon, manifests []runtime.RawExtension) ([]*bytes.Buffer, error) {
-	var rawManifests []*bytes.Buffer
+// ensureAddonLabelOnManifests decodes all manifests and adds the addonLabelKey label to them
+func (r *Reconciler) ensureAddonLabelOnManifests(addon *kubermaticv1.Addon, manifests []runtime.RawExtension) ([]*metav1unstructured.Unstructured, error) {
+	var objects []*metav1unstructured.Unstructured
 
 	wantLabels := r.getAddonLabel(addon)
 	for _, m := range manifests {
@@ -387,22 +466,10 @@ func (r *Reconciler) ensureAddonLabelOnManifests(addon *kubermaticv1.Addon, mani
 		}
 		parsedUnstructuredObj.SetLabels(existingLabels)
 
-		jsonBuffer := &bytes.Buffer{}
-		if err := metav1unstructured.UnstructuredJSONScheme.Encode(parsedUnstructuredObj, jsonBuffer); err != nil {
-			return nil, fmt.Errorf("encoding json failed: %v", err)
-		}
-
-		// Must be encoding back to yaml, otherwise kubectl fails to apply because it tries to parse the whole
-		// thing as json
-		yamlBytes, err := yaml.JSONToYAML(jsonBuffer.Bytes())
-		if err != nil {
-			return nil, err
-		}
-
-		rawManifests = append(rawManifests, bytes.NewBuffer(yamlBytes))
+		objects = append(objects, parsedUnstructuredObj)
 	}
 
-	return rawManifests, nil
+	return objects, nil
 }
 
 func (r *Reconciler) getAddonLabel(addon *kubermaticv1.Addon) map[string]string {
@@ -411,106 +478,75 @@ func (r *Reconciler) getAddonLabel(addon *kubermaticv1.Addon) map[string]string
 	}
 }
 
-type fileHandlingDone func()
-
-func getFileDeleteFinalizer(log *zap.SugaredLogger, filename string) fileHandlingDone {
-	return func() {
-		if err := os.RemoveAll(filename); err != nil {
-			log.Errorw("Failed to delete file", zap.Error(err), "file", filename)
-		}
+func (r *Reconciler) getAddonObjects(log *zap.SugaredLogger, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) ([]*metav1unstructured.Unstructured, error) {
+	manifests, err := r.getAddonManifests(log, addon, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get addon manifests: %v", err)
 	}
-}
 
-func (r *Reconciler) writeCombinedManifest(log *zap.SugaredLogger, manifest *bytes.Buffer, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) (string, fileHandlingDone, error) {
-	//Write combined Manifest to disk
-	manifestFilename := path.Join("/tmp", fmt.Sprintf("cluster-%s-%s.yaml", cluster.Name, addon.Name))
-	if err := ioutil.WriteFile(manifestFilename, manifest.Bytes(), 0644); err != nil {
-		return "", nil, fmt.Errorf("failed to write combined manifest to %s: %v", manifestFilename, err)
+	objects, err := r.ensureAddonLabelOnManifests(addon, manifests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add the addon specific label to all addon resources: %v", err)
 	}
-	log.Debugw("Wrote combined manifest", "file", manifestFilename)
 
-	return manifestFilename, getFileDeleteFinalizer(log, manifestFilename), nil
+	return objects, nil
 }
 
-func (r *Reconciler) writeAdminKubeconfig(log *zap.SugaredLogger, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) (string, fileHandlingDone, error) {
-	// Write kubeconfig to disk
-	kubeconfig, err := r.KubeconfigProvider.GetAdminKubeconfig(cluster)
+func (r *Reconciler) ensureIsInstalled(ctx context.Context, log *zap.SugaredLogger, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) error {
+	objects, err := r.getAddonObjects(log, addon, cluster)
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to get admin kubeconfig for cluster %s: %v", cluster.Name, err)
+		return err
 	}
-	kubeconfigFilename := path.Join("/tmp", fmt.Sprintf("cluster-%s-addon-%s-kubeconfig", cluster.Name, addon.Name))
-	if err := ioutil.WriteFile(kubeconfigFilename, kubeconfig, 0644); err != nil {
-		return "", nil, fmt.Errorf("failed to write admin kubeconfig for cluster %s: %v", cluster.Name, err)
+	// Addons installed using kubectl did not record their applied resources
+	installedByKubectl := addon.Status.AppliedResources == nil && addonResourcesCreated(addon)
+	if len(objects) == 0 && len(addon.Status.AppliedResources) == 0 && !installedByKubectl {
+		log.Debug("Skipping addon installation as the manifest is empty after parsing")
+		return nil
 	}
-	log.Debugw("Wrote admin kubeconfig", "file", kubeconfigFilename)
-
-	return kubeconfigFilename, getFileDeleteFinalizer(log, kubeconfigFilename), nil
-}
 
-func (r *Reconciler) setupManifestInteraction(log *zap.SugaredLogger, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) (string, string, fileHandlingDone, error) {
-	manifests, err := r.getAddonManifests(log, addon, cluster)
+	userClusterClient, err := r.KubeconfigProvider.GetClient(cluster)
 	if err != nil {
-		return "", "", nil, fmt.Errorf("failed to get addon manifests: %v", err)
+		return fmt.Errorf("failed to get client for usercluster: %v", err)
 	}
 
-	rawManifests, err := r.ensureAddonLabelOnManifests(addon, manifests)
-	if err != nil {
-		return "", "", nil, fmt.Errorf("failed to add the addon specific label to all addon resources: %v", err)
+	previouslyApplied := addon.Status.AppliedResources
+	if installedByKubectl {
+		previouslyApplied, err = kubectlAppliedObjects(ctx, userClusterClient, r.getAddonLabel(addon))
+		if err != nil {
+			return fmt.Errorf("failed to find the resources applied by kubectl: %v", err)
+		}
 	}
 
-	rawManifest := r.combineManifests(rawManifests)
-	manifestFilename, manifestDone, err := r.writeCombinedManifest(log, rawManifest, addon, cluster)
-	if err != nil {
-		return "", "", nil, fmt.Errorf("failed to write all addon resources into a combined manifest file: %v", err)
+	applied, applyErr := applyObjects(ctx, log, userClusterClient, objects)
+	var appliedResources []kubermaticv1.AddonResourceReference
+	if applyErr == nil {
+		// We delete all resources with the addon label which are not part of the addon anymore
+		notPruned, pruneErr := pruneObjects(ctx, log, userClusterClient, r.getAddonLabel(addon), resourceReferencesDiff(previouslyApplied, applied))
+		appliedResources = append(applied, notPruned...)
+		applyErr = pruneErr
+	} else {
+		// Only prune once everything got applied, but keep track of the previously applied
+		// resources, so we can still prune them later on
+		appliedResources = append(applied, resourceReferencesDiff(previouslyApplied, applied)...)
 	}
 
-	kubeconfigFilename, kubeconfigDone, err := r.writeAdminKubeconfig(log, addon, cluster)
-	if err != nil {
-		return "", "", nil, fmt.Errorf("failed to write the admin kubeconfig to the local filesystem: %v", err)
+	if err := r.ensureAppliedResourcesAreSet(ctx, addon, appliedResources); err != nil {
+		return fmt.Errorf("failed to update the applied resources of the addon: %v", err)
 	}
 
-	done := func() {
-		kubeconfigDone()
-		manifestDone()
+	if applyErr != nil {
+		return fmt.Errorf("failed to apply addon %s of cluster %s: %v", addon.Name, cluster.Name, applyErr)
 	}
-	return kubeconfigFilename, manifestFilename, done, nil
-}
-
-func (r *Reconciler) getApplyCommand(ctx context.Context, kubeconfigFilename, manifestFilename string, selector fmt.Stringer, openshift bool) *exec.Cmd {
-	// kubectl apply --prune -f manifest.yaml -l app=nginx
-	cmd := exec.CommandContext(ctx, "kubectl", "--kubeconfig", kubeconfigFilename, "apply", "--prune", "-f", manifestFilename, "-l", selector.String())
-	return cmd
+	return nil
 }
 
-func (r *Reconciler) ensureIsInstalled(ctx context.Context, log *zap.SugaredLogger, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) error {
-	kubeconfigFilename, manifestFilename, done, err := r.setupManifestInteraction(log, addon, cluster)
-	if err != nil {
-		return err
-	}
-	defer done()
-
-	d, err := ioutil.ReadFile(manifestFilename)
-	if err != nil {
-		return err
-	}
-	sd := strings.TrimSpace(string(d))
-	if len(sd) == 0 {
-		log.Debug("Skipping addon installation as the manifest is empty after parsing")
+func (r *Reconciler) ensureAppliedResourcesAreSet(ctx context.Context, addon *kubermaticv1.Addon, appliedResources []kubermaticv1.AddonResourceReference) error {
+	if reflect.DeepEqual(addon.Status.AppliedResources, appliedResources) {
 		return nil
 	}
-
-	// We delete all resources with this label which are not in the combined manifest
-	selector := labels.SelectorFromSet(r.getAddonLabel(addon))
-	cmd := r.getApplyCommand(ctx, kubeconfigFilename, manifestFilename, selector, cluster.IsOpenshift())
-	cmdLog := log.With("cmd", strings.Join(cmd.Args, " "))
-
-	cmdLog.Debug("Applying manifest...")
-	out, err := cmd.CombinedOutput()
-	cmdLog.Debugw("Finished executing command", "output", string(out))
-	if err != nil {
-		return fmt.Errorf("failed to execute '%s' for addon %s of cluster %s: %v\n%s", strings.Join(cmd.Args, " "), addon.Name, cluster.Name, err, string(out))
-	}
-	return err
+	oldAddon := addon.DeepCopy()
+	addon.Status.AppliedResources = appliedResources
+	return r.Client.Patch(ctx, addon, ctrlruntimeclient.MergeFrom(oldAddon))
 }
 
 func (r *Reconciler) ensureFinalizerIsSet(ctx context.Context, addon *kubermaticv1.Addon) error {
@@ -534,25 +570,33 @@ func (r *Reconciler) ensureResourcesCreatedConditionIsSet(ctx context.Context, a
 }
 
 func (r *Reconciler) cleanupManifests(ctx context.Context, log *zap.SugaredLogger, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) error {
-	kubeconfigFilename, manifestFilename, done, err := r.setupManifestInteraction(log, addon, cluster)
+	objects, err := r.getAddonObjects(log, addon, cluster)
 	if err != nil {
 		// FIXME: use a dedicated error type and proper error unwrapping when we have the technology to do it
-		if strings.Contains(err.Error(), "no such file or directory") { // if the manifest is already deleted, that's ok
-			log.Debugf("cleanupManifests failed for addon %s/%s: %v", addon.Namespace, addon.Name, err)
-			return nil
+		if !strings.Contains(err.Error(), "no such file or directory") {
+			return err
 		}
-		return err
+		// if the manifest is already deleted, we can only delete the resources we recorded
+		log.Debugf("Failed to get manifests for addon %s/%s, only deleting the applied resources: %v", addon.Namespace, addon.Name, err)
 	}
-	defer done()
 
-	cmd := deleteCommand(ctx, kubeconfigFilename, manifestFilename)
-	cmdLog := log.With("cmd", strings.Join(cmd.Args, " "))
+	var refs []kubermaticv1.AddonResourceReference
+	for _, object := range objects {
+		refs = append(refs, resourceReference(object))
+	}
+	refs = append(refs, resourceReferencesDiff(addon.Status.AppliedResources, refs)...)
+	if len(refs) == 0 {
+		return nil
+	}
 
-	cmdLog.Debug("Deleting resources...")
-	out, err := cmd.CombinedOutput()
-	cmdLog.Debugw("Finished executing command", "output", string(out))
+	userClusterClient, err := r.KubeconfigProvider.GetClient(cluster)
 	if err != nil {
-		return fmt.Errorf("failed to execute '%s' for addon %s of cluster %s: %v\n%s", strings.Join(cmd.Args, " "), addon.Name, cluster.Name, err, string(out))
+		return fmt.Errorf("failed to get client for usercluster: %v", err)
+	}
+
+	log.Debug("Deleting resources...")
+	if err := deleteObjects(ctx, log, userClusterClient, refs); err != nil {
+		return fmt.Errorf("failed to delete resources of addon %s of cluster %s: %v", addon.Name, cluster.Name, err)
 	}
 	return nil
 }
@@ -589,10 +633,6 @@ func (r *Reconciler) ensureRequiredResourceTypesExist(ctx context.Context, log *
 	return nil, nil
 }
 
-func deleteCommand(ctx context.Context, kubeconfigFilename, manifestFilename string) *exec.Cmd {
-	return exec.CommandContext(ctx, "kubectl", "--kubeconfig", kubeconfigFilename, "delete", "-f", manifestFilename, "--ignore-not-found")
-}
-
 func setAddonCodition(a *kubermaticv1.Addon, condType kubermaticv1.AddonConditionType, status corev1.ConditionStatus) {
 	idx, cond := getAddonCondition(a, condType)
 	if cond == nil {
@@ -22,22 +22,33 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path"
+	"reflect"
 	"strings"
 	"testing"
 
 	clusterclient "github.com/kubermatic/kubermatic/pkg/cluster/client"
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	kuberneteshelper "github.com/kubermatic/kubermatic/pkg/kubernetes"
 	kubermaticlog "github.com/kubermatic/kubermatic/pkg/log"
 	"github.com/kubermatic/kubermatic/pkg/resources"
 	"github.com/kubermatic/kubermatic/pkg/semver"
 
+	"github.com/ghodss/yaml"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
+	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	kyaml "k8s.io/apimachinery/pkg/util/yaml"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var testManifests = []string{
@@ -116,34 +127,45 @@ spec:
 `
 )
 
-var (
-	// testManifest1 & testManifest3 have a linebreak at the end, testManifest2 not
-	combinedTestManifest = fmt.Sprintf("%s---\n%s\n---\n%s", testManifests[0], testManifests[1], testManifests[2])
-)
-
-type fakeKubeconfigProvider struct{}
+type fakeKubeconfigProvider struct {
+	client ctrlruntimeclient.Client
+}
 
 func (f *fakeKubeconfigProvider) GetAdminKubeconfig(c *kubermaticv1.Cluster) ([]byte, error) {
 	return []byte("foo"), nil
 }
 
 func (f *fakeKubeconfigProvider) GetClient(c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
-	return nil, errors.New("not implemented")
+	if f.client == nil {
+		return nil, errors.New("not implemented")
+	}
+	return f.client, nil
 }
 
-func TestController_combineManifests(t *testing.T) {
-	controller := &Reconciler{}
+// noServerSideApplyClient behaves like an apiserver which has server-side apply disabled
+type noServerSideApplyClient struct {
+	ctrlruntimeclient.Client
+}
 
-	var manifests []*bytes.Buffer
-	for _, m := range testManifests {
-		manifests = append(manifests, bytes.NewBufferString(m))
+func (c *noServerSideApplyClient) Patch(ctx context.Context, obj runtime.Object, patch ctrlruntimeclient.Patch, opts ...ctrlruntimeclient.PatchOption) error {
+	if patch.Type() == types.ApplyPatchType {
+		return kerrors.NewGenericServerResponse(http.StatusUnsupportedMediaType, "patch", schema.GroupResource{}, "", "", 0, false)
 	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
 
-	manifest := controller.combineManifests(manifests)
+// applyRecordingClient records all server-side apply patches
+type applyRecordingClient struct {
+	ctrlruntimeclient.Client
+	applied []runtime.Object
+}
 
-	if manifest.String() != combinedTestManifest {
-		t.Fatalf("invalid combined manifest returned. Expected \n%s, Got \n%s", combinedTestManifest, manifest.String())
+func (c *applyRecordingClient) Patch(ctx context.Context, obj runtime.Object, patch ctrlruntimeclient.Patch, opts ...ctrlruntimeclient.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return fmt.Errorf("unexpected patch type %q", patch.Type())
 	}
+	c.applied = append(c.applied, obj)
+	return nil
 }
 
 func setupTestCluster(cidrBlock string) *kubermaticv1.Cluster {
@@ -371,6 +393,55 @@ func TestController_getAddonManifests(t *testing.T) {
 
 }
 
+func TestController_getAddonChartPath(t *testing.T) {
+	r := &Reconciler{addonChartsDir: "/opt/addons/charts"}
+
+	testCases := []struct {
+		name         string
+		chart        *kubermaticv1.AddonChart
+		expectedPath string
+		expectedErr  bool
+	}{
+		{
+			name:         "unpacked chart from the addon folder",
+			chart:        &kubermaticv1.AddonChart{Name: "example"},
+			expectedPath: "/opt/addons/kubernetes/example",
+		},
+		{
+			name:         "packaged chart from the chart repository",
+			chart:        &kubermaticv1.AddonChart{Name: "example", Version: "1.2.3"},
+			expectedPath: "/opt/addons/charts/example-1.2.3.tgz",
+		},
+		{
+			name:        "chart name escaping the addon folder",
+			chart:       &kubermaticv1.AddonChart{Name: "../../etc"},
+			expectedErr: true,
+		},
+		{
+			name:        "chart version escaping the chart repository",
+			chart:       &kubermaticv1.AddonChart{Name: "example", Version: "1/../../../secret"},
+			expectedErr: true,
+		},
+		{
+			name:        "chart name referring to the parent folder",
+			chart:       &kubermaticv1.AddonChart{Name: ".."},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			chartPath, err := r.getAddonChartPath(tc.chart, "/opt/addons/kubernetes")
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error=%v, got %v", tc.expectedErr, err)
+			}
+			if chartPath != tc.expectedPath {
+				t.Errorf("expected chart path %q, got %q", tc.expectedPath, chartPath)
+			}
+		})
+	}
+}
+
 func TestController_ensureAddonLabelOnManifests(t *testing.T) {
 	controller := &Reconciler{
 		KubeconfigProvider: &fakeKubeconfigProvider{},
@@ -390,22 +461,444 @@ func TestController_ensureAddonLabelOnManifests(t *testing.T) {
 			Name: "test",
 		},
 	}
-	labeledManifests, err := controller.ensureAddonLabelOnManifests(addon, []runtime.RawExtension{manifest})
+	labeledObjects, err := controller.ensureAddonLabelOnManifests(addon, []runtime.RawExtension{manifest})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonBytes, err := labeledObjects[0].MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	yamlBytes, err := yaml.JSONToYAML(jsonBytes)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if labeledManifests[0].String() != testManifest1WithLabel {
-		t.Fatalf("invalid labeled manifest returned. Expected \n%q, Got \n%q", testManifest1WithLabel, labeledManifests[0].String())
+	if string(yamlBytes) != testManifest1WithLabel {
+		t.Fatalf("invalid labeled manifest returned. Expected \n%q, Got \n%q", testManifest1WithLabel, string(yamlBytes))
+	}
+}
+
+func TestController_ensureIsInstalled(t *testing.T) {
+	ctx := context.Background()
+	log := kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar()
+	cluster := setupTestCluster("10.240.16.0/20")
+	addon := setupTestAddon("test")
+
+	addonDir, err := ioutil.TempDir("/tmp", "kubermatic-tests-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(addonDir)
+
+	if err := os.Mkdir(path.Join(addonDir, addon.Spec.Name), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(addonDir, addon.Spec.Name, "testManifest.yaml"), []byte(strings.Join(testManifests[:2], "\n---\n")), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// test1 was applied using kubectl before and does not have any applied resources recorded
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test1",
+			Namespace:       "kube-system",
+			ResourceVersion: "1",
+			Labels:          map[string]string{addonLabelKey: addon.Spec.Name},
+		},
+		Data: map[string]string{"foo": "baz", "other": "value"},
+	}
+	userClusterClient := &noServerSideApplyClient{Client: ctrlruntimefakeclient.NewFakeClient(existing)}
+	r := &Reconciler{
+		kubernetesAddonDir: addonDir,
+		KubeconfigProvider: &fakeKubeconfigProvider{client: userClusterClient},
+		Client:             ctrlruntimefakeclient.NewFakeClient(addon),
+	}
+
+	if err := r.ensureIsInstalled(ctx, log, addon, cluster); err != nil {
+		t.Fatalf("failed to install addon: %v", err)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := userClusterClient.Get(ctx, types.NamespacedName{Namespace: "kube-system", Name: "test1"}, configMap); err != nil {
+		t.Fatalf("failed to get configmap test1: %v", err)
+	}
+	if configMap.Data["foo"] != "bar" {
+		t.Errorf("expected configmap test1 to be updated, got data %v", configMap.Data)
+	}
+	if err := userClusterClient.Get(ctx, types.NamespacedName{Namespace: "kube-system", Name: "test2"}, configMap); err != nil {
+		t.Fatalf("failed to get configmap test2: %v", err)
+	}
+	if configMap.Labels[addonLabelKey] != addon.Spec.Name {
+		t.Errorf("expected configmap test2 to have the addon label, got labels %v", configMap.Labels)
+	}
+
+	if err := r.Get(ctx, types.NamespacedName{Name: addon.Name}, addon); err != nil {
+		t.Fatalf("failed to get addon: %v", err)
+	}
+	if len(addon.Status.AppliedResources) != 2 {
+		t.Fatalf("expected two applied resources, got %v", addon.Status.AppliedResources)
+	}
+
+	// test2 got removed from the addon, test3 got added
+	if err := ioutil.WriteFile(path.Join(addonDir, addon.Spec.Name, "testManifest.yaml"), []byte(strings.Join([]string{testManifests[0], testManifests[2]}, "\n---\n")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.ensureIsInstalled(ctx, log, addon, cluster); err != nil {
+		t.Fatalf("failed to install addon: %v", err)
+	}
+	if err := userClusterClient.Get(ctx, types.NamespacedName{Namespace: "kube-system", Name: "test2"}, configMap); !kerrors.IsNotFound(err) {
+		t.Errorf("expected configmap test2 to be pruned, got err=%v", err)
+	}
+	if err := userClusterClient.Get(ctx, types.NamespacedName{Namespace: "kube-system", Name: "test3"}, configMap); err != nil {
+		t.Errorf("failed to get configmap test3: %v", err)
+	}
+	expectedResources := []kubermaticv1.AddonResourceReference{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "test1"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "test3"},
+	}
+	if !reflect.DeepEqual(addon.Status.AppliedResources, expectedResources) {
+		t.Errorf("expected applied resources to be %v, got %v", expectedResources, addon.Status.AppliedResources)
+	}
+
+	if err := r.cleanupManifests(ctx, log, addon, cluster); err != nil {
+		t.Fatalf("failed to cleanup addon: %v", err)
+	}
+	configMaps := &corev1.ConfigMapList{}
+	if err := userClusterClient.List(ctx, configMaps); err != nil {
+		t.Fatalf("failed to list configmaps: %v", err)
+	}
+	if len(configMaps.Items) != 0 {
+		t.Errorf("expected all configmaps to be deleted, got %d", len(configMaps.Items))
+	}
+}
+
+func TestEnsureIsInstalledPrunesObjectsAppliedByKubectl(t *testing.T) {
+	ctx := context.Background()
+	log := kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar()
+	cluster := setupTestCluster("10.240.16.0/20")
+	addon := setupTestAddon("test")
+	setAddonCodition(addon, kubermaticv1.AddonResourcesCreated, corev1.ConditionTrue)
+
+	addonDir, err := ioutil.TempDir("/tmp", "kubermatic-tests-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(addonDir)
+
+	if err := os.Mkdir(path.Join(addonDir, addon.Spec.Name), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(addonDir, addon.Spec.Name, "testManifest.yaml"), []byte(testManifests[0]), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// removed got applied by kubectl and is not part of the addon anymore, the other one
+	// was only created with the addon label by somebody else
+	removed := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "removed",
+			Namespace:   "kube-system",
+			Labels:      map[string]string{addonLabelKey: addon.Spec.Name},
+			Annotations: map[string]string{corev1.LastAppliedConfigAnnotation: "{}"},
+		},
+	}
+	notApplied := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "not-applied",
+			Namespace: "kube-system",
+			Labels:    map[string]string{addonLabelKey: addon.Spec.Name},
+		},
+	}
+	userClusterClient := &noServerSideApplyClient{Client: ctrlruntimefakeclient.NewFakeClient(removed, notApplied)}
+	r := &Reconciler{
+		kubernetesAddonDir: addonDir,
+		KubeconfigProvider: &fakeKubeconfigProvider{client: userClusterClient},
+		Client:             ctrlruntimefakeclient.NewFakeClient(addon),
+	}
+
+	if err := r.ensureIsInstalled(ctx, log, addon, cluster); err != nil {
+		t.Fatalf("failed to install addon: %v", err)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := userClusterClient.Get(ctx, types.NamespacedName{Namespace: "kube-system", Name: "removed"}, configMap); !kerrors.IsNotFound(err) {
+		t.Errorf("expected configmap removed to be pruned, got err=%v", err)
+	}
+	if err := userClusterClient.Get(ctx, types.NamespacedName{Namespace: "kube-system", Name: "not-applied"}, configMap); err != nil {
+		t.Errorf("expected configmap not-applied to be kept, got err=%v", err)
+	}
+	expectedResources := []kubermaticv1.AddonResourceReference{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "test1"},
+	}
+	if !reflect.DeepEqual(addon.Status.AppliedResources, expectedResources) {
+		t.Errorf("expected applied resources to be %v, got %v", expectedResources, addon.Status.AppliedResources)
 	}
 }
 
-func TestController_getApplyCommand(t *testing.T) {
-	controller := &Reconciler{}
-	cmd := controller.getApplyCommand(context.Background(), "/opt/kubeconfig", "/opt/manifest.yaml", labels.SelectorFromSet(map[string]string{"foo": "bar"}), false)
-	expected := "kubectl --kubeconfig /opt/kubeconfig apply --prune -f /opt/manifest.yaml -l foo=bar"
-	got := strings.Join(cmd.Args, " ")
-	if got != expected {
-		t.Fatalf("invalid apply command returned. Expected \n%s, Got \n%s", expected, got)
+func TestPruneObjectsSkipsObjectsWithoutAddonLabel(t *testing.T) {
+	takenOver := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "taken-over",
+			Namespace: "kube-system",
+		},
+	}
+	client := ctrlruntimefakeclient.NewFakeClient(takenOver)
+	refs := []kubermaticv1.AddonResourceReference{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "taken-over"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "already-gone"},
+	}
+
+	remaining, err := pruneObjects(context.Background(), kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(), client, map[string]string{addonLabelKey: "test"}, refs)
+	if err != nil {
+		t.Fatalf("failed to prune objects: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("expected no remaining objects, got %v", remaining)
+	}
+	if err := client.Get(context.Background(), types.NamespacedName{Namespace: "kube-system", Name: "taken-over"}, takenOver); err != nil {
+		t.Errorf("expected configmap without addon label to be kept, got err=%v", err)
+	}
+}
+
+func TestApplyObjectUsesServerSideApply(t *testing.T) {
+	object := &metav1unstructured.Unstructured{}
+	object.SetAPIVersion("v1")
+	object.SetKind("ConfigMap")
+	object.SetNamespace("kube-system")
+	object.SetName("test")
+
+	client := &applyRecordingClient{}
+	if err := kuberneteshelper.ApplyUnstructured(context.Background(), client, object, fieldManager); err != nil {
+		t.Fatalf("failed to apply object: %v", err)
+	}
+	if len(client.applied) != 1 {
+		t.Fatalf("expected exactly one server-side apply patch, got %d", len(client.applied))
+	}
+}
+
+func TestResourceReferencesDiffIgnoresAPIVersion(t *testing.T) {
+	previous := []kubermaticv1.AddonResourceReference{
+		{APIVersion: "extensions/v1beta1", Kind: "Deployment", Namespace: "kube-system", Name: "moved"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kube-system", Name: "removed"},
+	}
+	current := []kubermaticv1.AddonResourceReference{
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "kube-system", Name: "moved"},
+	}
+
+	diff := resourceReferencesDiff(previous, current)
+	if len(diff) != 1 || diff[0].Name != "removed" {
+		t.Errorf("expected only the removed configmap to be part of the diff, got %v", diff)
+	}
+}
+
+func TestFindDrift(t *testing.T) {
+	testCases := []struct {
+		name          string
+		desired       map[string]interface{}
+		current       map[string]interface{}
+		expectedDrift string
+	}{
+		{
+			name: "defaulted fields and status are ignored",
+			desired: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "test", "creationTimestamp": nil},
+				"spec":     map[string]interface{}{"replicas": int64(1)},
+				"status":   map[string]interface{}{"replicas": int64(2)},
+			},
+			current: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "test", "uid": "1234"},
+				"spec":     map[string]interface{}{"replicas": float64(1), "revisionHistoryLimit": int64(10)},
+			},
+		},
+		{
+			name: "normalized quantities are equal",
+			desired: map[string]interface{}{
+				"spec": map[string]interface{}{"cpu": "0.5"},
+			},
+			current: map[string]interface{}{
+				"spec": map[string]interface{}{"cpu": "500m"},
+			},
+		},
+		{
+			name: "changed field is detected",
+			desired: map[string]interface{}{
+				"data": map[string]interface{}{"a": "1", "b": "2"},
+			},
+			current: map[string]interface{}{
+				"data": map[string]interface{}{"a": "1", "b": "3"},
+			},
+			expectedDrift: ".data.b",
+		},
+		{
+			name: "removed list item is detected",
+			desired: map[string]interface{}{
+				"spec": map[string]interface{}{"args": []interface{}{"--a", "--b"}},
+			},
+			current: map[string]interface{}{
+				"spec": map[string]interface{}{"args": []interface{}{"--a"}},
+			},
+			expectedDrift: ".spec.args",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if drift := findDrift(tc.desired, tc.current); drift != tc.expectedDrift {
+				t.Errorf("expected drift %q, got %q", tc.expectedDrift, drift)
+			}
+		})
+	}
+}
+
+func TestCheckObjects(t *testing.T) {
+	replicas := int32(2)
+	existingObjects := []runtime.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "healthy", Namespace: "kube-system"},
+			Data:       map[string]string{"key": "value"},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "drifted", Namespace: "kube-system"},
+			Data:       map[string]string{"key": "changed"},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "rolling", Namespace: "kube-system"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{UpdatedReplicas: 2, AvailableReplicas: 1},
+		},
+	}
+	client := ctrlruntimefakeclient.NewFakeClient(existingObjects...)
+
+	var desiredObjects []*metav1unstructured.Unstructured
+	for _, desired := range []runtime.Object{
+		&corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Name: "healthy", Namespace: "kube-system"},
+			Data:       map[string]string{"key": "value"},
+		},
+		&corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Name: "drifted", Namespace: "kube-system"},
+			Data:       map[string]string{"key": "value"},
+		},
+		&corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "kube-system"},
+		},
+		&appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Name: "rolling", Namespace: "kube-system"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		},
+	} {
+		data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+		if err != nil {
+			t.Fatalf("failed to convert object: %v", err)
+		}
+		desiredObjects = append(desiredObjects, &metav1unstructured.Unstructured{Object: data})
+	}
+
+	unhealthyObjects, err := checkObjects(context.Background(), client, desiredObjects)
+	if err != nil {
+		t.Fatalf("failed to check objects: %v", err)
+	}
+
+	states := map[string]kubermaticv1.AddonObjectState{}
+	for _, object := range unhealthyObjects {
+		states[object.Name] = object.State
+	}
+	expectedStates := map[string]kubermaticv1.AddonObjectState{
+		"drifted": kubermaticv1.AddonObjectDrifted,
+		"missing": kubermaticv1.AddonObjectMissing,
+		"rolling": kubermaticv1.AddonObjectNotReady,
+	}
+	if !reflect.DeepEqual(states, expectedStates) {
+		t.Errorf("expected unhealthy objects %v, got %v", expectedStates, states)
+	}
+}
+
+func TestBlockingDependencies(t *testing.T) {
+	healthy := setupTestAddon("cert-manager")
+	healthy.Namespace = "cluster-test"
+	healthy.Status.Conditions = []kubermaticv1.AddonCondition{{Type: kubermaticv1.AddonHealthy, Status: corev1.ConditionTrue}}
+
+	unhealthy := setupTestAddon("dns")
+	unhealthy.Namespace = "cluster-test"
+	unhealthy.Status.Conditions = []kubermaticv1.AddonCondition{{Type: kubermaticv1.AddonHealthy, Status: corev1.ConditionFalse}}
+
+	ingress := setupTestAddon("ingress")
+	ingress.Namespace = "cluster-test"
+
+	addonConfig := &kubermaticv1.AddonConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "ingress"},
+		Spec: kubermaticv1.AddonConfigSpec{
+			Dependencies: []string{"cert-manager", "dns", "rbac"},
+		},
+	}
+
+	r := &Reconciler{Client: ctrlruntimefakeclient.NewFakeClient(healthy, unhealthy, ingress, addonConfig)}
+	ctx := context.Background()
+
+	blocking, err := r.getBlockingDependencies(ctx, ingress)
+	if err != nil {
+		t.Fatalf("failed to get blocking dependencies: %v", err)
+	}
+	expected := []string{"addon dns is not healthy", "addon rbac is not installed"}
+	if !reflect.DeepEqual(blocking, expected) {
+		t.Fatalf("expected blocking dependencies %v, got %v", expected, blocking)
+	}
+
+	if err := r.ensureBlockedConditionIsSet(ctx, ingress, blocking); err != nil {
+		t.Fatalf("failed to set blocked condition: %v", err)
+	}
+	_, cond := getAddonCondition(ingress, kubermaticv1.AddonBlocked)
+	if cond == nil || cond.Status != corev1.ConditionTrue || cond.Message != "addon dns is not healthy, addon rbac is not installed" {
+		t.Errorf("expected addon to be blocked, got condition %v", cond)
+	}
+
+	if err := r.ensureBlockedConditionIsSet(ctx, ingress, nil); err != nil {
+		t.Fatalf("failed to set blocked condition: %v", err)
+	}
+	_, cond = getAddonCondition(ingress, kubermaticv1.AddonBlocked)
+	if cond == nil || cond.Status != corev1.ConditionFalse || cond.Message != "" {
+		t.Errorf("expected addon to be unblocked, got condition %v", cond)
+	}
+}
+
+func TestDependentsBeingDeleted(t *testing.T) {
+	now := metav1.Now()
+
+	certManager := setupTestAddon("cert-manager")
+	certManager.Namespace = "cluster-test"
+	certManager.DeletionTimestamp = &now
+
+	ingress := setupTestAddon("ingress")
+	ingress.Namespace = "cluster-test"
+	ingress.DeletionTimestamp = &now
+
+	registry := setupTestAddon("registry")
+	registry.Namespace = "cluster-test"
+
+	addonConfigs := []runtime.Object{
+		&kubermaticv1.AddonConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "ingress"},
+			Spec:       kubermaticv1.AddonConfigSpec{Dependencies: []string{"cert-manager"}},
+		},
+		&kubermaticv1.AddonConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry"},
+			Spec:       kubermaticv1.AddonConfigSpec{Dependencies: []string{"cert-manager"}},
+		},
+	}
+
+	r := &Reconciler{Client: ctrlruntimefakeclient.NewFakeClient(append(addonConfigs, certManager, ingress, registry)...)}
+	log := kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar()
+
+	dependents, err := r.getDependentsBeingDeleted(context.Background(), log, certManager)
+	if err != nil {
+		t.Fatalf("failed to get dependents: %v", err)
+	}
+	if !reflect.DeepEqual(dependents, []string{"ingress"}) {
+		t.Errorf("expected only the deleted ingress addon to block the removal, got %v", dependents)
 	}
 }
 
@@ -417,7 +910,7 @@ func TestHugeManifest(t *testing.T) {
 		kubernetesAddonDir: "./testdata",
 		KubeconfigProvider: &fakeKubeconfigProvider{},
 	}
-	if _, _, _, err := r.setupManifestInteraction(log, addon, cluster); err != nil {
-		t.Fatalf("failed to setup manifest interaction: %v", err)
+	if _, err := r.getAddonObjects(log, addon, cluster); err != nil {
+		t.Fatalf("failed to get addon objects: %v", err)
 	}
 }
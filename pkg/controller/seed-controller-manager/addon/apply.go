@@ -0,0 +1,202 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	kuberneteshelper "github.com/kubermatic/kubermatic/pkg/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fieldManager is the name we use for server-side apply, so the apiserver knows which
+// fields of the addon objects are owned by us
+const fieldManager = "kubermatic-addon-controller"
+
+// kubectlPruneKinds are the kinds `kubectl apply --prune` looked at by default, which got
+// used to install addons before the applied resources got recorded
+var kubectlPruneKinds = []schema.GroupVersionKind{
+	{Version: "v1", Kind: "ConfigMap"},
+	{Version: "v1", Kind: "Endpoints"},
+	{Version: "v1", Kind: "Namespace"},
+	{Version: "v1", Kind: "PersistentVolumeClaim"},
+	{Version: "v1", Kind: "PersistentVolume"},
+	{Version: "v1", Kind: "Pod"},
+	{Version: "v1", Kind: "ReplicationController"},
+	{Version: "v1", Kind: "Secret"},
+	{Version: "v1", Kind: "Service"},
+	{Group: "batch", Version: "v1", Kind: "Job"},
+	{Group: "batch", Version: "v1beta1", Kind: "CronJob"},
+	{Group: "extensions", Version: "v1beta1", Kind: "Ingress"},
+	{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+	{Group: "apps", Version: "v1", Kind: "Deployment"},
+	{Group: "apps", Version: "v1", Kind: "ReplicaSet"},
+	{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+}
+
+// applyObjects applies all given objects to the user cluster. It returns references to all
+// objects that got applied successfully and an aggregated error containing one error per
+// object that could not be applied.
+// Unlike kubectl, we do not default the namespace, so namespaced objects must have
+// their namespace set in the addon manifest. Charts get it defaulted when rendering.
+func applyObjects(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, objects []*metav1unstructured.Unstructured) ([]kubermaticv1.AddonResourceReference, error) {
+	var (
+		applied []kubermaticv1.AddonResourceReference
+		errs    []error
+	)
+	for _, object := range objects {
+		ref := resourceReference(object)
+		if err := kuberneteshelper.ApplyUnstructured(ctx, client, object, fieldManager); err != nil {
+			errs = append(errs, fmt.Errorf("failed to apply %s: %v", ref, err))
+			continue
+		}
+		log.Debugw("Applied object", "object", ref.String())
+		applied = append(applied, ref)
+	}
+	return applied, utilerrors.NewAggregate(errs)
+}
+
+// pruneObjects deletes the referenced objects from the user cluster, as long as they still
+// carry the given addon labels. It returns the references of all objects that could not be
+// deleted, so they can be pruned the next time.
+func pruneObjects(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, addonLabels map[string]string, refs []kubermaticv1.AddonResourceReference) ([]kubermaticv1.AddonResourceReference, error) {
+	var (
+		remaining []kubermaticv1.AddonResourceReference
+		errs      []error
+	)
+	selector := labels.SelectorFromSet(addonLabels)
+	for _, ref := range refs {
+		object := &metav1unstructured.Unstructured{}
+		object.SetAPIVersion(ref.APIVersion)
+		object.SetKind(ref.Kind)
+		if err := client.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, object); err != nil {
+			if kerrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("failed to get %s: %v", ref, err))
+			remaining = append(remaining, ref)
+			continue
+		}
+
+		// Somebody took over the object, so it's not ours to delete anymore
+		if !selector.Matches(labels.Set(object.GetLabels())) {
+			log.Debugw("Not pruning object as it does not have the addon label anymore", "object", ref.String())
+			continue
+		}
+
+		if err := client.Delete(ctx, object); err != nil && !kerrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to prune %s: %v", ref, err))
+			remaining = append(remaining, ref)
+			continue
+		}
+		log.Debugw("Pruned object", "object", ref.String())
+	}
+	return remaining, utilerrors.NewAggregate(errs)
+}
+
+// kubectlAppliedObjects returns references to all objects which carry the given addon labels
+// and got applied by kubectl. Addons installed before the applied resources got recorded are
+// pruned the way kubectl did it, so objects removed from them in the meantime don't stay around.
+func kubectlAppliedObjects(ctx context.Context, client ctrlruntimeclient.Client, addonLabels map[string]string) ([]kubermaticv1.AddonResourceReference, error) {
+	refs := []kubermaticv1.AddonResourceReference{}
+	for _, gvk := range kubectlPruneKinds {
+		list := &metav1unstructured.UnstructuredList{}
+		list.SetAPIVersion(gvk.GroupVersion().String())
+		list.SetKind(gvk.Kind + "List")
+		if err := client.List(ctx, list, ctrlruntimeclient.MatchingLabels(addonLabels)); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to list %s: %v", gvk.Kind, err)
+		}
+		for i := range list.Items {
+			if _, ok := list.Items[i].GetAnnotations()[corev1.LastAppliedConfigAnnotation]; !ok {
+				continue
+			}
+			list.Items[i].SetGroupVersionKind(gvk)
+			refs = append(refs, resourceReference(&list.Items[i]))
+		}
+	}
+	return refs, nil
+}
+
+// deleteObjects deletes the referenced objects from the user cluster and ignores
+// objects which are already gone.
+func deleteObjects(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, refs []kubermaticv1.AddonResourceReference) error {
+	var errs []error
+	for _, ref := range refs {
+		object := &metav1unstructured.Unstructured{}
+		object.SetAPIVersion(ref.APIVersion)
+		object.SetKind(ref.Kind)
+		object.SetNamespace(ref.Namespace)
+		object.SetName(ref.Name)
+		if err := client.Delete(ctx, object); err != nil {
+			if kerrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("failed to delete %s: %v", ref, err))
+			continue
+		}
+		log.Debugw("Deleted object", "object", ref.String())
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func resourceReference(object *metav1unstructured.Unstructured) kubermaticv1.AddonResourceReference {
+	return kubermaticv1.AddonResourceReference{
+		APIVersion: object.GetAPIVersion(),
+		Kind:       object.GetKind(),
+		Namespace:  object.GetNamespace(),
+		Name:       object.GetName(),
+	}
+}
+
+// resourceReferencesDiff returns all references from a which are not part of b. The API
+// version is ignored, as the same object might just be applied using a different version or
+// group, e.g. when a Deployment moved from extensions/v1beta1 to apps/v1.
+func resourceReferencesDiff(a, b []kubermaticv1.AddonResourceReference) []kubermaticv1.AddonResourceReference {
+	existing := sets.NewString()
+	for _, ref := range b {
+		existing.Insert(unversionedKey(ref))
+	}
+
+	var diff []kubermaticv1.AddonResourceReference
+	for _, ref := range a {
+		if !existing.Has(unversionedKey(ref)) {
+			diff = append(diff, ref)
+		}
+	}
+	return diff
+}
+
+func unversionedKey(ref kubermaticv1.AddonResourceReference) string {
+	return fmt.Sprintf("%s/%s/%s", ref.Kind, ref.Namespace, ref.Name)
+}
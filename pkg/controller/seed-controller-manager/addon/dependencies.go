@@ -0,0 +1,120 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	addonutils "github.com/kubermatic/kubermatic/pkg/addon"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// addonBlockedReason is the reason of the AddonBlocked condition when a dependency is not ready
+const addonBlockedReason = "DependenciesNotReady"
+
+// getBlockingDependencies returns a description for every dependency of the addon which is
+// not installed, being deleted or not healthy.
+func (r *Reconciler) getBlockingDependencies(ctx context.Context, addon *kubermaticv1.Addon) ([]string, error) {
+	dependencies, err := addonutils.GetDependencies(ctx, r, addon.Spec.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocking []string
+	for _, dependency := range dependencies {
+		dependencyAddon := &kubermaticv1.Addon{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: addon.Namespace, Name: dependency}, dependencyAddon); err != nil {
+			if kerrors.IsNotFound(err) {
+				blocking = append(blocking, fmt.Sprintf("addon %s is not installed", dependency))
+				continue
+			}
+			return nil, fmt.Errorf("failed to get addon %s: %v", dependency, err)
+		}
+
+		if dependencyAddon.DeletionTimestamp != nil {
+			blocking = append(blocking, fmt.Sprintf("addon %s is being deleted", dependency))
+			continue
+		}
+		if _, cond := getAddonCondition(dependencyAddon, kubermaticv1.AddonHealthy); cond == nil || cond.Status != corev1.ConditionTrue {
+			blocking = append(blocking, fmt.Sprintf("addon %s is not healthy", dependency))
+		}
+	}
+	return blocking, nil
+}
+
+// ensureBlockedConditionIsSet sets the AddonBlocked condition based on the blocking dependencies.
+// Addons which were never blocked do not get the condition at all.
+func (r *Reconciler) ensureBlockedConditionIsSet(ctx context.Context, addon *kubermaticv1.Addon, blocking []string) error {
+	status, reason, message := corev1.ConditionFalse, "", ""
+	if len(blocking) > 0 {
+		status, reason, message = corev1.ConditionTrue, addonBlockedReason, strings.Join(blocking, ", ")
+	}
+
+	_, cond := getAddonCondition(addon, kubermaticv1.AddonBlocked)
+	if cond == nil && status == corev1.ConditionFalse {
+		return nil
+	}
+	if cond != nil && cond.Status == status && cond.Reason == reason && cond.Message == message {
+		return nil
+	}
+
+	oldAddon := addon.DeepCopy()
+	setAddonCodition(addon, kubermaticv1.AddonBlocked, status)
+	idx, _ := getAddonCondition(addon, kubermaticv1.AddonBlocked)
+	addon.Status.Conditions[idx].Reason = reason
+	addon.Status.Conditions[idx].Message = message
+	return r.Client.Patch(ctx, addon, ctrlruntimeclient.MergeFrom(oldAddon))
+}
+
+// getDependentsBeingDeleted returns the names of all addons of the same cluster which depend on
+// the given addon and are being deleted as well. The addon must only be removed after them.
+func (r *Reconciler) getDependentsBeingDeleted(ctx context.Context, log *zap.SugaredLogger, addon *kubermaticv1.Addon) ([]string, error) {
+	graph, err := addonutils.GetDependencyGraph(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependents []string
+	for _, dependent := range graph.Dependents(addon.Spec.Name) {
+		dependentAddon := &kubermaticv1.Addon{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: addon.Namespace, Name: dependent}, dependentAddon); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get addon %s: %v", dependent, err)
+		}
+		if dependentAddon.DeletionTimestamp != nil {
+			dependents = append(dependents, dependent)
+		}
+	}
+
+	// Waiting for addons which in turn wait for us would block the removal forever
+	if _, cyclic := graph.Sort(append(dependents, addon.Spec.Name)); len(cyclic) > 0 {
+		log.Infow("Not waiting for dependent addons to be removed, their dependencies contain a cycle", "addons", cyclic)
+		return nil, nil
+	}
+	return dependents, nil
+}
@@ -17,7 +17,12 @@ limitations under the License.
 /*
 Package addon contains a controller that applies addons based on a Addon CRD. It needs
 a folder per addon that contains all manifests, then adds a label to all objects and applies
-the addon via `kubectl apply --purge -l $added-label`, which result in all objects that
-do have the label but are not in the on-disk manifests being removed.
+them to the user cluster using server-side apply. All applied objects are recorded in the
+status of the Addon, which results in all objects that do have the label but are not in the
+on-disk manifests anymore being removed.
+
+Instead of a folder with manifests, an addon can reference a Helm chart, either unpacked in the
+addon folder or packaged in the addon chart repository. The chart gets rendered in-process and
+the resulting objects are labeled, applied and pruned the same way.
 */
 package addon
@@ -0,0 +1,241 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ignoredTopLevelFields are not compared when checking for drift, as they are either
+// maintained by the apiserver or get converted into other fields
+var ignoredTopLevelFields = sets.NewString("status", "stringData")
+
+// ensureHealthStatus compares the addon manifests with the objects in the user cluster and
+// records all missing, drifted and not ready objects in the addon status.
+func (r *Reconciler) ensureHealthStatus(ctx context.Context, log *zap.SugaredLogger, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) error {
+	objects, err := r.getAddonObjects(log, addon, cluster)
+	if err != nil {
+		return err
+	}
+
+	userClusterClient, err := r.KubeconfigProvider.GetClient(cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get client for usercluster: %v", err)
+	}
+
+	unhealthyObjects, err := checkObjects(ctx, userClusterClient, objects)
+	if err != nil {
+		return fmt.Errorf("failed to check the health of the addon objects: %v", err)
+	}
+
+	healthy := corev1.ConditionTrue
+	if len(unhealthyObjects) > 0 {
+		healthy = corev1.ConditionFalse
+		log.Debugw("Addon is not healthy", "unhealthy-objects", len(unhealthyObjects))
+	}
+
+	// Only patch when something changed, otherwise we would trigger ourselves
+	// over and over again by updating the heartbeat of the condition
+	_, cond := getAddonCondition(addon, kubermaticv1.AddonHealthy)
+	if cond != nil && cond.Status == healthy && reflect.DeepEqual(addon.Status.UnhealthyObjects, unhealthyObjects) {
+		return nil
+	}
+
+	oldAddon := addon.DeepCopy()
+	setAddonCodition(addon, kubermaticv1.AddonHealthy, healthy)
+	addon.Status.UnhealthyObjects = unhealthyObjects
+	return r.Client.Patch(ctx, addon, ctrlruntimeclient.MergeFrom(oldAddon))
+}
+
+// checkObjects returns the status of all objects which are either missing in the user
+// cluster, differ from the desired state or are workloads which are not rolled out yet.
+func checkObjects(ctx context.Context, client ctrlruntimeclient.Client, objects []*metav1unstructured.Unstructured) ([]kubermaticv1.AddonObjectStatus, error) {
+	var unhealthyObjects []kubermaticv1.AddonObjectStatus
+	for _, desired := range objects {
+		ref := resourceReference(desired)
+
+		current := &metav1unstructured.Unstructured{}
+		current.SetAPIVersion(ref.APIVersion)
+		current.SetKind(ref.Kind)
+		if err := client.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, current); err != nil {
+			if kerrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				unhealthyObjects = append(unhealthyObjects, kubermaticv1.AddonObjectStatus{
+					AddonResourceReference: ref,
+					State:                  kubermaticv1.AddonObjectMissing,
+				})
+				continue
+			}
+			return nil, fmt.Errorf("failed to get %s: %v", ref, err)
+		}
+
+		if path := findDrift(desired.Object, current.Object); path != "" {
+			unhealthyObjects = append(unhealthyObjects, kubermaticv1.AddonObjectStatus{
+				AddonResourceReference: ref,
+				State:                  kubermaticv1.AddonObjectDrifted,
+				Message:                fmt.Sprintf("field %s differs from the addon manifest", path),
+			})
+			continue
+		}
+
+		message, err := workloadNotReadyMessage(current)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check readiness of %s: %v", ref, err)
+		}
+		if message != "" {
+			unhealthyObjects = append(unhealthyObjects, kubermaticv1.AddonObjectStatus{
+				AddonResourceReference: ref,
+				State:                  kubermaticv1.AddonObjectNotReady,
+				Message:                message,
+			})
+		}
+	}
+	return unhealthyObjects, nil
+}
+
+// findDrift returns the path of the first field that is set in the desired object but has
+// a different value in the current object. Fields which are only set in the current object,
+// e.g. because they got defaulted, are not considered a drift.
+func findDrift(desired, current map[string]interface{}) string {
+	for _, key := range sortedKeys(desired) {
+		if ignoredTopLevelFields.Has(key) {
+			continue
+		}
+		if drift := findFieldDrift("."+key, desired[key], current[key]); drift != "" {
+			return drift
+		}
+	}
+	return ""
+}
+
+func findFieldDrift(path string, desired, current interface{}) string {
+	// Fields like `creationTimestamp: null` are commonly found in manifests
+	if desired == nil {
+		return ""
+	}
+
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		currentValue, ok := current.(map[string]interface{})
+		if !ok {
+			return path
+		}
+		for _, key := range sortedKeys(desiredValue) {
+			if drift := findFieldDrift(path+"."+key, desiredValue[key], currentValue[key]); drift != "" {
+				return drift
+			}
+		}
+		return ""
+
+	case []interface{}:
+		currentValue, ok := current.([]interface{})
+		if !ok || len(currentValue) != len(desiredValue) {
+			return path
+		}
+		for i := range desiredValue {
+			if drift := findFieldDrift(fmt.Sprintf("%s[%d]", path, i), desiredValue[i], currentValue[i]); drift != "" {
+				return drift
+			}
+		}
+		return ""
+
+	default:
+		// Numbers might be decoded as int64 or float64 depending on their notation
+		desiredString, currentString := fmt.Sprint(desired), fmt.Sprint(current)
+		if desiredString == currentString || equalQuantities(desiredString, currentString) {
+			return ""
+		}
+		return path
+	}
+}
+
+// equalQuantities returns true if both values are quantities with the same value, as
+// the apiserver normalizes them, e.g. "0.5" becomes "500m"
+func equalQuantities(a, b string) bool {
+	quantityA, err := resource.ParseQuantity(a)
+	if err != nil {
+		return false
+	}
+	quantityB, err := resource.ParseQuantity(b)
+	if err != nil {
+		return false
+	}
+	return quantityA.Cmp(quantityB) == 0
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// workloadNotReadyMessage returns a message describing why the given workload is not
+// rolled out yet. It returns an empty string for all other kinds of objects.
+func workloadNotReadyMessage(object *metav1unstructured.Unstructured) (string, error) {
+	switch object.GetKind() {
+	case "Deployment":
+		deployment := &appsv1.Deployment{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, deployment); err != nil {
+			return "", err
+		}
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		if deployment.Status.ObservedGeneration < deployment.Generation {
+			return "rollout of the latest generation has not been observed yet", nil
+		}
+		if deployment.Status.UpdatedReplicas < replicas || deployment.Status.AvailableReplicas < replicas {
+			return fmt.Sprintf("%d of %d replicas are updated and %d are available", deployment.Status.UpdatedReplicas, replicas, deployment.Status.AvailableReplicas), nil
+		}
+
+	case "DaemonSet":
+		daemonSet := &appsv1.DaemonSet{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, daemonSet); err != nil {
+			return "", err
+		}
+		if daemonSet.Status.ObservedGeneration < daemonSet.Generation {
+			return "rollout of the latest generation has not been observed yet", nil
+		}
+		desired := daemonSet.Status.DesiredNumberScheduled
+		if daemonSet.Status.UpdatedNumberScheduled < desired || daemonSet.Status.NumberAvailable < desired {
+			return fmt.Sprintf("%d of %d pods are updated and %d are available", daemonSet.Status.UpdatedNumberScheduled, desired, daemonSet.Status.NumberAvailable), nil
+		}
+	}
+
+	return "", nil
+}
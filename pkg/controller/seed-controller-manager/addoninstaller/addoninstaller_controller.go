@@ -20,10 +20,12 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
 
+	addonutils "github.com/kubermatic/kubermatic/pkg/addon"
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	kubermaticv1helper "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1/helper"
 
@@ -164,8 +166,26 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 }
 
 func (r *Reconciler) ensureAddons(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, addons kubermaticv1.AddonList) error {
+	dependencies, err := addonutils.GetDependencyGraph(ctx, r)
+	if err != nil {
+		return fmt.Errorf("failed to get addon dependencies: %v", err)
+	}
+
+	// Create the addons in the order of their dependencies, so dependencies get installed first
+	sortedAddons, cyclicAddons := sortAddons(dependencies, addons.Items)
+
 	ensuredAddonsMap := map[string]struct{}{}
-	for _, addon := range addons.Items {
+	// Addons which are part of a dependency cycle can never become ready, they are neither
+	// installed nor removed until their AddonConfigs got fixed
+	if len(cyclicAddons) > 0 {
+		names := addonNames(cyclicAddons)
+		log.Warnw("Skipping addons whose dependencies contain a cycle", "addons", names)
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, "AddonDependencyCycle", "Skipping addons whose dependencies contain a cycle: %s", strings.Join(names, ", "))
+		for _, name := range names {
+			ensuredAddonsMap[name] = struct{}{}
+		}
+	}
+	for _, addon := range sortedAddons {
 		ensuredAddonsMap[addon.Name] = struct{}{}
 		name := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: addon.Name}
 		addonLog := log.With("addon", name)
@@ -180,13 +200,14 @@ func (r *Reconciler) ensureAddons(ctx context.Context, log *zap.SugaredLogger, c
 			}
 		} else {
 			addonLog.Debug("Addon already exists")
-			if !reflect.DeepEqual(addon.Labels, existingAddon.Labels) || !reflect.DeepEqual(addon.Annotations, existingAddon.Annotations) || !reflect.DeepEqual(addon.Spec.Variables, existingAddon.Spec.Variables) || !reflect.DeepEqual(addon.Spec.RequiredResourceTypes, existingAddon.Spec.RequiredResourceTypes) {
+			if !reflect.DeepEqual(addon.Labels, existingAddon.Labels) || !reflect.DeepEqual(addon.Annotations, existingAddon.Annotations) || !reflect.DeepEqual(addon.Spec.Variables, existingAddon.Spec.Variables) || !reflect.DeepEqual(addon.Spec.RequiredResourceTypes, existingAddon.Spec.RequiredResourceTypes) || !reflect.DeepEqual(addon.Spec.Chart, existingAddon.Spec.Chart) {
 				updatedAddon := existingAddon.DeepCopy()
 				updatedAddon.Labels = addon.Labels
 				updatedAddon.Annotations = addon.Annotations
 				updatedAddon.Spec.Name = addon.Name
 				updatedAddon.Spec.Variables = addon.Spec.Variables
 				updatedAddon.Spec.RequiredResourceTypes = addon.Spec.RequiredResourceTypes
+				updatedAddon.Spec.Chart = addon.Spec.Chart
 				updatedAddon.Spec.IsDefault = true
 				if err := r.Patch(ctx, updatedAddon, ctrlruntimeclient.MergeFrom(existingAddon)); err != nil {
 					return fmt.Errorf("failed to update addon %q: %v", addon.Name, err)
@@ -199,17 +220,56 @@ func (r *Reconciler) ensureAddons(ctx context.Context, log *zap.SugaredLogger, c
 	if err := r.List(ctx, &currentAddons, &ctrlruntimeclient.ListOptions{Namespace: cluster.Status.NamespaceName}); err != nil {
 		return fmt.Errorf("failed to list cluster addons: %v", err)
 	}
+	var unwantedAddons []kubermaticv1.Addon
 	for _, currentAddon := range currentAddons.Items {
 		if _, ensured := ensuredAddonsMap[currentAddon.Name]; !ensured {
 			// we found an installed Addon that shouldn't be
-			if err := r.deleteAddon(ctx, log, currentAddon); err != nil {
-				return fmt.Errorf("failed to delete cluster addon: %v", err)
-			}
+			unwantedAddons = append(unwantedAddons, currentAddon)
+		}
+	}
+
+	// Delete the addons in the reverse order of their dependencies, so dependents get deleted first.
+	// Addons which are part of a dependency cycle can't be ordered, they get deleted last.
+	sortedUnwantedAddons, cyclicUnwantedAddons := sortAddons(dependencies, unwantedAddons)
+	unwantedAddons = append(cyclicUnwantedAddons, sortedUnwantedAddons...)
+	for i := len(unwantedAddons) - 1; i >= 0; i-- {
+		if err := r.deleteAddon(ctx, log, unwantedAddons[i]); err != nil {
+			return fmt.Errorf("failed to delete cluster addon: %v", err)
 		}
 	}
 	return nil
 }
 
+// sortAddons orders the addons so that every addon comes after all of its dependencies. The
+// addons which are part of a dependency cycle are returned separately.
+func sortAddons(dependencies addonutils.DependencyGraph, addons []kubermaticv1.Addon) (sorted []kubermaticv1.Addon, cyclic []kubermaticv1.Addon) {
+	addonsByName := map[string]kubermaticv1.Addon{}
+	var names []string
+	for _, addon := range addons {
+		addonsByName[addon.Name] = addon
+		names = append(names, addon.Name)
+	}
+
+	sortedNames, cyclicNames := dependencies.Sort(names)
+
+	sorted = make([]kubermaticv1.Addon, 0, len(sortedNames))
+	for _, name := range sortedNames {
+		sorted = append(sorted, addonsByName[name])
+	}
+	for _, name := range cyclicNames {
+		cyclic = append(cyclic, addonsByName[name])
+	}
+	return sorted, cyclic
+}
+
+func addonNames(addons []kubermaticv1.Addon) []string {
+	names := make([]string, 0, len(addons))
+	for _, addon := range addons {
+		names = append(names, addon.Name)
+	}
+	return names
+}
+
 func (r *Reconciler) createAddon(ctx context.Context, log *zap.SugaredLogger, addon kubermaticv1.Addon, cluster *kubermaticv1.Cluster) error {
 	gv := kubermaticv1.SchemeGroupVersion
 
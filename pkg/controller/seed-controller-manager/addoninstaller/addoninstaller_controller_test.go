@@ -18,10 +18,12 @@ package addoninstaller
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/go-test/deep"
 
+	addonutils "github.com/kubermatic/kubermatic/pkg/addon"
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	kubermaticlog "github.com/kubermatic/kubermatic/pkg/log"
 
@@ -29,6 +31,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -317,3 +320,70 @@ func TestUpdateAddon(t *testing.T) {
 		})
 	}
 }
+
+func TestSortAddons(t *testing.T) {
+	dependencies := addonutils.DependencyGraph{
+		"Foo": {"Bar"},
+	}
+
+	sorted, cyclic := sortAddons(dependencies, addons.Items)
+	if len(cyclic) > 0 {
+		t.Fatalf("expected no addons in a dependency cycle, got %v", addonNames(cyclic))
+	}
+
+	var names []string
+	for _, addon := range sorted {
+		names = append(names, addon.Name)
+	}
+	if diff := deep.Equal(names, []string{"Bar", "Foo"}); diff != nil {
+		t.Errorf("addons are not sorted by their dependencies, diff: %v", diff)
+	}
+}
+
+func TestEnsureAddonsSkipsDependencyCycles(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		Status: kubermaticv1.ClusterStatus{
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{Apiserver: kubermaticv1.HealthStatusUp},
+			NamespaceName:  "cluster-test-cluster",
+		},
+	}
+	installedAddon := &kubermaticv1.Addon{ObjectMeta: metav1.ObjectMeta{Name: "Foo", Namespace: cluster.Status.NamespaceName}}
+	client := ctrlruntimefakeclient.NewFakeClient(
+		cluster,
+		installedAddon,
+		&kubermaticv1.AddonConfig{ObjectMeta: metav1.ObjectMeta{Name: "Foo"}, Spec: kubermaticv1.AddonConfigSpec{Dependencies: []string{"Bar"}}},
+		&kubermaticv1.AddonConfig{ObjectMeta: metav1.ObjectMeta{Name: "Bar"}, Spec: kubermaticv1.AddonConfigSpec{Dependencies: []string{"Foo"}}},
+	)
+	recorder := record.NewFakeRecorder(10)
+	reconciler := Reconciler{
+		log:    kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		Client: client,
+		kubernetesAddons: kubermaticv1.AddonList{Items: []kubermaticv1.Addon{
+			{ObjectMeta: metav1.ObjectMeta{Name: "Foo"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "Bar"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "Baz"}},
+		}},
+		recorder: recorder,
+	}
+
+	if _, err := reconciler.reconcile(context.Background(), reconciler.log, cluster); err != nil {
+		t.Fatalf("Reconciliation failed: %v", err)
+	}
+
+	for name, expectedExists := range map[string]bool{"Foo": true, "Bar": false, "Baz": true} {
+		err := client.Get(context.Background(), types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: name}, &kubermaticv1.Addon{})
+		if exists := err == nil; exists != expectedExists {
+			t.Errorf("expected addon %s to exist: %t, got err %v", name, expectedExists, err)
+		}
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, "AddonDependencyCycle") {
+			t.Errorf("expected an event about the dependency cycle, got %q", event)
+		}
+	default:
+		t.Error("expected an event about the dependency cycle")
+	}
+}
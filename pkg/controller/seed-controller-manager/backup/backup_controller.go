@@ -19,6 +19,7 @@ package backup
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -71,6 +72,9 @@ const (
 	backupCleanupJobLabel = "kubermatic-etcd-backup-cleaner"
 	// clusterEnvVarKey defines the environment variable key for the cluster name
 	clusterEnvVarKey = "CLUSTER"
+	// maxRevisionsEnvVarKey defines the environment variable key for the number of
+	// backups the store container should keep
+	maxRevisionsEnvVarKey = "MAX_REVISIONS"
 
 	ControllerName = "kubermatic_backup_controller"
 )
@@ -281,22 +285,54 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 		}
 	}
 
-	if err := r.ensureCronJobSecret(ctx, cluster); err != nil {
+	// The finalizer is kept, so backups created before they got disabled still get
+	// cleaned up when the cluster is deleted
+	if cluster.Spec.EtcdBackup.BackupsDisabled() {
+		return r.deleteCronJob(ctx, cluster)
+	}
+
+	if err := EnsureEtcdClientCertificateSecret(ctx, r.Client, cluster); err != nil {
 		return fmt.Errorf("failed to create backup secret: %v", err)
 	}
 
 	return reconciling.ReconcileCronJobs(ctx, []reconciling.NamedCronJobCreatorGetter{r.cronjob(cluster)}, metav1.NamespaceSystem, r.Client)
 }
 
-func (r *Reconciler) getEtcdSecretName(cluster *kubermaticv1.Cluster) string {
+func (r *Reconciler) deleteCronJob(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	cronJob := &batchv1beta1.CronJob{}
+	name := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: CronJobName(cluster)}
+	if err := r.Get(ctx, name, cronJob); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get backup cronjob: %v", err)
+	}
+
+	deletePropagationForeground := metav1.DeletePropagationForeground
+	if err := r.Delete(ctx, cronJob, &ctrlruntimeclient.DeleteOptions{PropagationPolicy: &deletePropagationForeground}); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete backup cronjob: %v", err)
+	}
+	return nil
+}
+
+// CronJobName returns the name of the backup cronjob of the cluster
+func CronJobName(cluster *kubermaticv1.Cluster) string {
+	return fmt.Sprintf("%s-%s", cronJobPrefix, cluster.Name)
+}
+
+// EtcdClientCertificateSecretName returns the name of the Secret in kube-system which holds the
+// client certificate for the etcd of the cluster
+func EtcdClientCertificateSecretName(cluster *kubermaticv1.Cluster) string {
 	return fmt.Sprintf("cluster-%s-etcd-client-certificate", cluster.Name)
 }
 
-func (r *Reconciler) ensureCronJobSecret(ctx context.Context, cluster *kubermaticv1.Cluster) error {
-	secretName := r.getEtcdSecretName(cluster)
+// EnsureEtcdClientCertificateSecret creates or updates the Secret with the client certificate
+// for the etcd of the cluster
+func EnsureEtcdClientCertificateSecret(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) error {
+	secretName := EtcdClientCertificateSecretName(cluster)
 
 	getCA := func() (*triple.KeyPair, error) {
-		return resources.GetClusterRootCA(ctx, cluster.Status.NamespaceName, r.Client)
+		return resources.GetClusterRootCA(ctx, cluster.Status.NamespaceName, client)
 	}
 
 	_, creator := certificates.GetClientCertificateCreator(
@@ -314,7 +350,7 @@ func (r *Reconciler) ensureCronJobSecret(ctx context.Context, cluster *kubermati
 	err := reconciling.EnsureNamedObject(
 		ctx,
 		types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: secretName},
-		wrappedCreator, r.Client, &corev1.Secret{}, false)
+		wrappedCreator, client, &corev1.Secret{}, false)
 	if err != nil {
 		return fmt.Errorf("failed to ensure Secret %q: %v", secretName, err)
 	}
@@ -364,7 +400,7 @@ func (r *Reconciler) cleanupJob(cluster *kubermaticv1.Cluster) *batchv1.Job {
 
 func (r *Reconciler) cronjob(cluster *kubermaticv1.Cluster) reconciling.NamedCronJobCreatorGetter {
 	return func() (string, reconciling.CronJobCreator) {
-		return fmt.Sprintf("%s-%s", cronJobPrefix, cluster.Name), func(cronJob *batchv1beta1.CronJob) (*batchv1beta1.CronJob, error) {
+		return CronJobName(cluster), func(cronJob *batchv1beta1.CronJob) (*batchv1beta1.CronJob, error) {
 			gv := kubermaticv1.SchemeGroupVersion
 			cronJob.OwnerReferences = []metav1.OwnerReference{
 				*metav1.NewControllerRef(cluster, gv.WithKind(kubermaticv1.ClusterKindName)),
@@ -372,11 +408,14 @@ func (r *Reconciler) cronjob(cluster *kubermaticv1.Cluster) reconciling.NamedCro
 
 			// Spec
 			cronJob.Spec.Schedule = r.backupScheduleString
+			if settings := cluster.Spec.EtcdBackup; settings != nil && settings.Schedule != "" {
+				cronJob.Spec.Schedule = settings.Schedule
+			}
 			cronJob.Spec.ConcurrencyPolicy = batchv1beta1.ForbidConcurrent
 			cronJob.Spec.Suspend = utilpointer.BoolPtr(false)
 			cronJob.Spec.SuccessfulJobsHistoryLimit = utilpointer.Int32Ptr(0)
 
-			endpoints := etcd.GetClientEndpoints(cluster.Status.NamespaceName)
+			endpoints := etcd.GetClientEndpoints(cluster)
 			image := r.backupContainerImage
 			if !strings.Contains(image, ":") {
 				image = image + ":" + etcd.ImageTag(cluster)
@@ -414,7 +453,7 @@ func (r *Reconciler) cronjob(cluster *kubermaticv1.Cluster) reconciling.NamedCro
 							MountPath: "/backup",
 						},
 						{
-							Name:      r.getEtcdSecretName(cluster),
+							Name:      EtcdClientCertificateSecretName(cluster),
 							MountPath: "/etc/etcd/client",
 						},
 					},
@@ -426,6 +465,12 @@ func (r *Reconciler) cronjob(cluster *kubermaticv1.Cluster) reconciling.NamedCro
 				Name:  clusterEnvVarKey,
 				Value: cluster.Name,
 			})
+			if settings := cluster.Spec.EtcdBackup; settings != nil && settings.RevisionsToKeep != nil {
+				storeContainer.Env = append(storeContainer.Env, corev1.EnvVar{
+					Name:  maxRevisionsEnvVarKey,
+					Value: strconv.Itoa(int(*settings.RevisionsToKeep)),
+				})
+			}
 
 			cronJob.Spec.JobTemplate.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
 			cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers = []corev1.Container{*storeContainer}
@@ -437,10 +482,10 @@ func (r *Reconciler) cronjob(cluster *kubermaticv1.Cluster) reconciling.NamedCro
 					},
 				},
 				{
-					Name: r.getEtcdSecretName(cluster),
+					Name: EtcdClientCertificateSecretName(cluster),
 					VolumeSource: corev1.VolumeSource{
 						Secret: &corev1.SecretVolumeSource{
-							SecretName: r.getEtcdSecretName(cluster),
+							SecretName: EtcdClientCertificateSecretName(cluster),
 						},
 					},
 				},
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	kuberneteshelper "github.com/kubermatic/kubermatic/pkg/kubernetes"
 	kubermaticlog "github.com/kubermatic/kubermatic/pkg/log"
 	"github.com/kubermatic/kubermatic/pkg/resources"
 	"github.com/kubermatic/kubermatic/pkg/resources/certificates/triple"
@@ -31,6 +32,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	certutil "k8s.io/client-go/util/cert"
+	utilpointer "k8s.io/utils/pointer"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -61,26 +63,7 @@ func TestEnsureBackupCronJob(t *testing.T) {
 		},
 	}
 
-	caKey, err := triple.NewPrivateKey()
-	if err != nil {
-		t.Fatalf("unable to create a private key for the CA: %v", err)
-	}
-
-	config := certutil.Config{CommonName: "foo"}
-	caCert, err := certutil.NewSelfSignedCACert(config, caKey)
-	if err != nil {
-		t.Fatalf("unable to create a self-signed certificate for a new CA: %v", err)
-	}
-	caSecret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: cluster.Status.NamespaceName,
-			Name:      resources.CASecretName,
-		},
-		Data: map[string][]byte{
-			resources.CACertSecretKey: triple.EncodeCertPEM(caCert),
-			resources.CAKeySecretKey:  triple.EncodePrivateKeyPEM(caKey),
-		},
-	}
+	caSecret := testCASecret(t, cluster.Status.NamespaceName)
 
 	reconciler := &Reconciler{
 		log:                  kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
@@ -178,3 +161,105 @@ func TestCleanupJobSpec(t *testing.T) {
 		t.Errorf("expected cleanup job to have exactly one container, got %d", containerLen)
 	}
 }
+
+func testCASecret(t *testing.T, namespace string) *corev1.Secret {
+	caKey, err := triple.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("unable to create a private key for the CA: %v", err)
+	}
+
+	config := certutil.Config{CommonName: "foo"}
+	caCert, err := certutil.NewSelfSignedCACert(config, caKey)
+	if err != nil {
+		t.Fatalf("unable to create a self-signed certificate for a new CA: %v", err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      resources.CASecretName,
+		},
+		Data: map[string][]byte{
+			resources.CACertSecretKey: triple.EncodeCertPEM(caCert),
+			resources.CAKeySecretKey:  triple.EncodePrivateKeyPEM(caKey),
+		},
+	}
+}
+
+func TestBackupSettings(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cluster",
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Version: *semver.NewSemverOrDie("1.16.3"),
+			EtcdBackup: &kubermaticv1.EtcdBackupSettings{
+				Schedule:        "0 * * * *",
+				RevisionsToKeep: utilpointer.Int32Ptr(720),
+			},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "testnamespace",
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+				Etcd: kubermaticv1.HealthStatusUp,
+			},
+		},
+	}
+
+	ctx := context.Background()
+	reconciler := &Reconciler{
+		log:                  kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		storeContainer:       testStoreContainer,
+		cleanupContainer:     testCleanupContainer,
+		backupScheduleString: "@every 20m",
+		backupContainerImage: DefaultBackupContainerImage,
+		Client:               ctrlruntimefakeclient.NewFakeClient(testCASecret(t, cluster.Status.NamespaceName), cluster),
+	}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: cluster.Name}}
+
+	if _, err := reconciler.Reconcile(request); err != nil {
+		t.Fatalf("Error syncing cluster: %v", err)
+	}
+
+	cronJob := &batchv1beta1.CronJob{}
+	if err := reconciler.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: CronJobName(cluster)}, cronJob); err != nil {
+		t.Fatalf("Error getting cronjob: %v", err)
+	}
+	if cronJob.Spec.Schedule != "0 * * * *" {
+		t.Errorf("Expected schedule to be %q but was %q", "0 * * * *", cronJob.Spec.Schedule)
+	}
+	var maxRevisions string
+	for _, env := range cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == maxRevisionsEnvVarKey {
+			maxRevisions = env.Value
+		}
+	}
+	if maxRevisions != "720" {
+		t.Errorf("Expected %s to be %q but was %q", maxRevisionsEnvVarKey, "720", maxRevisions)
+	}
+
+	if err := reconciler.Get(ctx, request.NamespacedName, cluster); err != nil {
+		t.Fatalf("Error getting cluster: %v", err)
+	}
+	cluster.Spec.EtcdBackup.Disabled = true
+	if err := reconciler.Update(ctx, cluster); err != nil {
+		t.Fatalf("Error updating cluster: %v", err)
+	}
+	if _, err := reconciler.Reconcile(request); err != nil {
+		t.Fatalf("Error syncing cluster: %v", err)
+	}
+
+	cronJobs := &batchv1beta1.CronJobList{}
+	if err := reconciler.List(ctx, cronJobs); err != nil {
+		t.Fatalf("Error listing cronjobs: %v", err)
+	}
+	if len(cronJobs.Items) != 0 {
+		t.Errorf("Expected cronjob to be deleted for a cluster with disabled backups, got %d cronjobs", len(cronJobs.Items))
+	}
+
+	if err := reconciler.Get(ctx, request.NamespacedName, cluster); err != nil {
+		t.Fatalf("Error getting cluster: %v", err)
+	}
+	if !kuberneteshelper.HasFinalizer(cluster, cleanupFinalizer) {
+		t.Error("Expected cleanup finalizer to be kept on a cluster with disabled backups")
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdmaintenance
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/backup"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/resources"
+	"github.com/kubermatic/kubermatic/pkg/resources/etcd"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// statusTimeout is the timeout for getting the status of a member
+	statusTimeout = 10 * time.Second
+	// defragmentTimeout is the timeout for defragmenting a member, which blocks the member
+	// until its whole database got rewritten
+	defragmentTimeout = 5 * time.Minute
+	// idleConnTimeout is the time after which idle connections to the members are closed, in case
+	// the client does not get closed
+	idleConnTimeout = 90 * time.Second
+)
+
+// memberStatus is the status of an etcd member as returned by the maintenance API
+type memberStatus struct {
+	Header struct {
+		MemberID uint64 `json:"member_id,string"`
+	} `json:"header"`
+	Version     string `json:"version"`
+	DBSize      int64  `json:"dbSize,string"`
+	DBSizeInUse int64  `json:"dbSizeInUse,string"`
+	Leader      uint64 `json:"leader,string"`
+}
+
+// isLeader returns whether the member is the leader of the cluster
+func (s *memberStatus) isLeader() bool {
+	return s.Header.MemberID != 0 && s.Header.MemberID == s.Leader
+}
+
+// etcdClient talks to the maintenance API of single etcd members
+type etcdClient interface {
+	Status(ctx context.Context, endpoint string) (*memberStatus, error)
+	Defragment(ctx context.Context, endpoint string) error
+	// Close closes the connections to the members, the client must not be used afterwards
+	Close()
+}
+
+// gatewayClient uses the JSON gateway of etcd, which serves the gRPC API on the client port
+type gatewayClient struct {
+	httpClient *http.Client
+	// apiPrefix is the path the gateway serves the API at, it depends on the etcd version
+	apiPrefix string
+}
+
+var _ etcdClient = &gatewayClient{}
+
+// newEtcdClient returns a client which authenticates with the same client certificate the
+// backup jobs of the cluster use. Every reconciliation creates its own client, which must be
+// closed when the reconciliation is done.
+func newEtcdClient(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) (etcdClient, error) {
+	if err := backup.EnsureEtcdClientCertificateSecret(ctx, client, cluster); err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	name := types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: backup.EtcdClientCertificateSecretName(cluster)}
+	if err := client.Get(ctx, name, secret); err != nil {
+		return nil, fmt.Errorf("failed to get etcd client certificate: %v", err)
+	}
+	cert, err := tls.X509KeyPair(
+		secret.Data[resources.BackupEtcdClientCertificateCertSecretKey],
+		secret.Data[resources.BackupEtcdClientCertificateKeySecretKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to load etcd client certificate: %v", err)
+	}
+
+	ca, err := resources.GetClusterRootCA(ctx, cluster.Status.NamespaceName, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster CA: %v", err)
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca.Cert)
+
+	return &gatewayClient{
+		apiPrefix: gatewayAPIPrefix(cluster),
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					Certificates: []tls.Certificate{cert},
+					RootCAs:      rootCAs,
+				},
+				IdleConnTimeout: idleConnTimeout,
+			},
+		},
+	}, nil
+}
+
+// gatewayAPIPrefix returns the path the JSON gateway of the etcd version of the cluster serves the
+// API at. Etcd 3.3 only serves the beta API, which got promoted to /v3 with etcd 3.4.
+func gatewayAPIPrefix(cluster *kubermaticv1.Cluster) string {
+	if strings.HasPrefix(etcd.ImageTag(cluster), "v3.3.") {
+		return "/v3beta"
+	}
+	return "/v3"
+}
+
+func (c *gatewayClient) Status(ctx context.Context, endpoint string) (*memberStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, statusTimeout)
+	defer cancel()
+
+	status := &memberStatus{}
+	if err := c.post(ctx, endpoint, c.apiPrefix+"/maintenance/status", status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+func (c *gatewayClient) Defragment(ctx context.Context, endpoint string) error {
+	ctx, cancel := context.WithTimeout(ctx, defragmentTimeout)
+	defer cancel()
+
+	return c.post(ctx, endpoint, c.apiPrefix+"/maintenance/defragment", nil)
+}
+
+func (c *gatewayClient) Close() {
+	c.httpClient.CloseIdleConnections()
+}
+
+func (c *gatewayClient) post(ctx context.Context, endpoint, path string, into interface{}) error {
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(endpoint, "/")+path, bytes.NewBufferString("{}"))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d: %s", path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if into == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, into); err != nil {
+		return fmt.Errorf("failed to parse response of %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package etcdmaintenance contains a controller that regularly checks the database of every etcd
+member of a user cluster and defragments members whose database mostly consists of free space
+which etcd does not give back on its own after a compaction.
+
+Members are defragmented one at a time and only while all members are reachable, the leader
+always comes last. After a defragmentation the controller pauses, so the member can catch up
+before the next one gets defragmented. A defragmentation blocks the member, so it only happens
+during the update window of the cluster if it has one. Etcd 3.3 does not report how much of
+its database is in use, so its members are only monitored. The database sizes are exported as metrics and every
+defragmentation is recorded as an event on the cluster.
+
+The controller does not compact the keyspace. Etcd compacts its history on its own, as it is started
+with an auto compaction retention of 8 hours, and the kube-apiserver compacts it every few minutes.
+A defragmentation only gives back the space those compactions freed.
+*/
+package etcdmaintenance
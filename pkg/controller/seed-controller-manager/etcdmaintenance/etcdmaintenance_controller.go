@@ -0,0 +1,323 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdmaintenance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coreos/locksmith/pkg/timeutil"
+	"go.uber.org/zap"
+
+	"github.com/kubermatic/kubermatic/pkg/collectors"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/resources"
+	"github.com/kubermatic/kubermatic/pkg/resources/etcd"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kubermatic_etcd_maintenance_controller"
+
+	// DefaultInterval is the default interval in which the etcd members of a cluster get checked
+	DefaultInterval = time.Hour
+	// DefaultFragmentationThreshold is the default share of free space in the database of an
+	// etcd member from which on it gets defragmented
+	DefaultFragmentationThreshold = 0.5
+
+	// minDefragmentationDBSize is the database size below which members are never defragmented,
+	// the space a defragmentation could reclaim is not worth blocking the member
+	minDefragmentationDBSize = 100 * 1024 * 1024
+	// defragmentationPause is the time after a defragmentation until the next member gets
+	// defragmented, so the member can catch up with the rest of the cluster
+	defragmentationPause = time.Minute
+)
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+	log                    *zap.SugaredLogger
+	workerName             string
+	recorder               record.EventRecorder
+	interval               time.Duration
+	fragmentationThreshold float64
+	newEtcdClient          func(context.Context, ctrlruntimeclient.Client, *kubermaticv1.Cluster) (etcdClient, error)
+	now                    func() time.Time
+
+	// lastDefragmentation is the time the last member of each cluster got defragmented
+	lastDefragmentation     map[string]time.Time
+	lastDefragmentationLock sync.Mutex
+}
+
+// Add creates a new etcd maintenance controller
+func Add(
+	mgr manager.Manager,
+	log *zap.SugaredLogger,
+	numWorkers int,
+	workerName string,
+	interval time.Duration,
+	fragmentationThreshold float64) error {
+
+	reconciler := &Reconciler{
+		Client:                 mgr.GetClient(),
+		log:                    log.Named(ControllerName),
+		workerName:             workerName,
+		recorder:               mgr.GetEventRecorderFor(ControllerName),
+		interval:               interval,
+		fragmentationThreshold: fragmentationThreshold,
+		newEtcdClient:          newEtcdClient,
+		now:                    time.Now,
+		lastDefragmentation:    map[string]time.Time{},
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, &handler.EnqueueRequestForObject{}, clusterChangedPredicate()); err != nil {
+		return fmt.Errorf("failed to create watch: %v", err)
+	}
+
+	return nil
+}
+
+// clusterChangedPredicate filters out the constant status updates of clusters, e.g. of their health.
+// Clusters are requeued regularly anyway, only the changes which affect the maintenance are relevant.
+func clusterChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCluster, ok := e.ObjectOld.(*kubermaticv1.Cluster)
+			if !ok {
+				return true
+			}
+			newCluster, ok := e.ObjectNew.(*kubermaticv1.Cluster)
+			if !ok {
+				return true
+			}
+			return !equality.Semantic.DeepEqual(oldCluster.Spec, newCluster.Spec) ||
+				oldCluster.Labels[kubermaticv1.WorkerNameLabelKey] != newCluster.Labels[kubermaticv1.WorkerNameLabelKey] ||
+				(oldCluster.DeletionTimestamp == nil) != (newCluster.DeletionTimestamp == nil) ||
+				(oldCluster.Status.Hibernation == nil) != (newCluster.Status.Hibernation == nil)
+		},
+	}
+}
+
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log := r.log.With("request", request)
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if cluster.DeletionTimestamp != nil {
+		deleteMetrics(cluster, 0)
+		r.lastDefragmentationLock.Lock()
+		delete(r.lastDefragmentation, cluster.Name)
+		r.lastDefragmentationLock.Unlock()
+		return reconcile.Result{}, nil
+	}
+	// The ClusterReconcileWrapper can not be used here, it considers every requeue a failed reconciliation
+	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] != r.workerName || cluster.Spec.Pause || cluster.Status.Hibernation != nil {
+		return reconcile.Result{}, nil
+	}
+
+	result, err := r.reconcile(ctx, log, cluster)
+	if err != nil {
+		log.Errorw("Failed to reconcile cluster", zap.Error(err))
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+	return result, err
+}
+
+// member is an etcd member of a cluster
+type member struct {
+	name     string
+	endpoint string
+	status   *memberStatus
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (reconcile.Result, error) {
+	if cluster.Status.ExtendedHealth.Etcd != kubermaticv1.HealthStatusUp {
+		log.Debug("Skipping because etcd is not healthy")
+		return reconcile.Result{RequeueAfter: r.interval}, nil
+	}
+
+	client, err := r.newEtcdClient(ctx, r.Client, cluster)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to create etcd client: %v", err)
+	}
+	defer client.Close()
+
+	// Defragmenting a member while another one is not available would leave the cluster without quorum
+	var members []*member
+	for i, endpoint := range etcd.GetClientEndpoints(cluster) {
+		m := &member{name: fmt.Sprintf("etcd-%d", i), endpoint: endpoint}
+		if m.status, err = client.Status(ctx, endpoint); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to get status of etcd member %s: %v", m.name, err)
+		}
+		collectors.SetEtcdDBSize(cluster.Name, m.name, m.status.DBSize, m.status.DBSizeInUse)
+		members = append(members, m)
+	}
+	deleteMetrics(cluster, len(members))
+
+	candidate := nextDefragmentation(members, r.fragmentationThreshold)
+	if candidate == nil {
+		return reconcile.Result{RequeueAfter: r.interval}, nil
+	}
+
+	open, untilOpen, err := inMaintenanceWindow(cluster.Spec.UpdateWindow, r.now())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !open {
+		log.Debugw("Waiting for the update window to defragment etcd", "member", candidate.name)
+		if untilOpen > r.interval {
+			untilOpen = r.interval
+		}
+		return reconcile.Result{RequeueAfter: untilOpen}, nil
+	}
+
+	// Give the previously defragmented member time to catch up, the cluster might have been
+	// requeued by a change before the pause is over
+	if untilPauseEnds := r.untilDefragmentationPauseEnds(cluster); untilPauseEnds > 0 {
+		log.Debugw("Waiting for the previously defragmented member to catch up", "member", candidate.name)
+		return reconcile.Result{RequeueAfter: untilPauseEnds}, nil
+	}
+
+	err = r.defragment(ctx, log, cluster, client, candidate)
+	r.lastDefragmentationLock.Lock()
+	r.lastDefragmentation[cluster.Name] = r.now()
+	r.lastDefragmentationLock.Unlock()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: defragmentationPause}, nil
+}
+
+// untilDefragmentationPauseEnds returns the time until the next member of the cluster may be defragmented
+func (r *Reconciler) untilDefragmentationPauseEnds(cluster *kubermaticv1.Cluster) time.Duration {
+	r.lastDefragmentationLock.Lock()
+	defer r.lastDefragmentationLock.Unlock()
+
+	last, ok := r.lastDefragmentation[cluster.Name]
+	if !ok {
+		return 0
+	}
+	return last.Add(defragmentationPause).Sub(r.now())
+}
+
+func (r *Reconciler) defragment(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, client etcdClient, m *member) error {
+	log = log.With("member", m.name)
+	log.Infow("Defragmenting etcd member", "dbSize", m.status.DBSize, "dbSizeInUse", m.status.DBSizeInUse)
+
+	start := time.Now()
+	if err := client.Defragment(ctx, m.endpoint); err != nil {
+		collectors.ObserveEtcdDefragmentation(collectors.DefragmentationResultFailed, time.Since(start), 0)
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, "EtcdDefragmentationFailed", "Failed to defragment etcd member %s: %v", m.name, err)
+		return fmt.Errorf("failed to defragment etcd member %s: %v", m.name, err)
+	}
+	duration := time.Since(start)
+
+	dbSize := m.status.DBSize
+	if status, err := client.Status(ctx, m.endpoint); err != nil {
+		log.Infow("Failed to get status of the defragmented etcd member", zap.Error(err))
+	} else {
+		dbSize = status.DBSize
+		collectors.SetEtcdDBSize(cluster.Name, m.name, status.DBSize, status.DBSizeInUse)
+	}
+
+	collectors.ObserveEtcdDefragmentation(collectors.DefragmentationResultSucceeded, duration, m.status.DBSize-dbSize)
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, "EtcdDefragmented", "Defragmented etcd member %s in %s, its database shrank from %d to %d bytes", m.name, duration.Round(time.Second), m.status.DBSize, dbSize)
+	log.Infow("Defragmented etcd member", "duration", duration, "dbSize", dbSize)
+	return nil
+}
+
+// nextDefragmentation returns the member which should be defragmented next or nil if no member needs
+// to be defragmented. Followers come first, as defragmenting the leader blocks the whole cluster.
+func nextDefragmentation(members []*member, threshold float64) *member {
+	var candidates []*member
+	for _, m := range members {
+		// Members before etcd 3.4 do not report the size in use
+		if m.status.DBSizeInUse == 0 || m.status.DBSize < minDefragmentationDBSize {
+			continue
+		}
+		if float64(m.status.DBSize-m.status.DBSizeInUse)/float64(m.status.DBSize) >= threshold {
+			candidates = append(candidates, m)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return !candidates[i].status.isLeader() && candidates[j].status.isLeader()
+	})
+	return candidates[0]
+}
+
+// inMaintenanceWindow returns whether etcd may be defragmented at the given time. Otherwise it returns
+// the duration until the window starts.
+func inMaintenanceWindow(window *kubermaticv1.UpdateWindow, now time.Time) (bool, time.Duration, error) {
+	if window == nil || window.Start == "" {
+		return true, 0, nil
+	}
+
+	periodic, err := timeutil.ParsePeriodic(window.Start, window.Length)
+	if err != nil {
+		return false, 0, fmt.Errorf("invalid update window %q/%q: %v", window.Start, window.Length, err)
+	}
+	untilStart := periodic.DurationToStart(now.UTC())
+	if untilStart <= 0 {
+		return true, 0, nil
+	}
+	// Make sure we never requeue with a zero duration, that would not requeue at all
+	if untilStart < time.Second {
+		untilStart = time.Second
+	}
+	return false, untilStart, nil
+}
+
+// deleteMetrics removes the metrics of all members from the given index on
+func deleteMetrics(cluster *kubermaticv1.Cluster, from int) {
+	for i := from; i < resources.MaxEtcdClusterSize; i++ {
+		collectors.DeleteEtcdDBSize(cluster.Name, fmt.Sprintf("etcd-%d", i))
+	}
+}
@@ -0,0 +1,417 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdmaintenance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	kubermaticlog "github.com/kubermatic/kubermatic/pkg/log"
+	"github.com/kubermatic/kubermatic/pkg/semver"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	clusterName   = "test-cluster"
+	namespaceName = "cluster-test-cluster"
+
+	mib = 1024 * 1024
+)
+
+func genStatus(memberID, leader uint64, dbSize, dbSizeInUse int64) *memberStatus {
+	status := &memberStatus{DBSize: dbSize, DBSizeInUse: dbSizeInUse, Leader: leader}
+	status.Header.MemberID = memberID
+	return status
+}
+
+func genMember(index int, status *memberStatus) *member {
+	return &member{
+		name:     fmt.Sprintf("etcd-%d", index),
+		endpoint: fmt.Sprintf("https://etcd-%d.etcd.%s.svc.cluster.local.:2379", index, namespaceName),
+		status:   status,
+	}
+}
+
+func TestNextDefragmentation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		members  []*member
+		expected string
+	}{
+		{
+			name: "No fragmented member",
+			members: []*member{
+				genMember(0, genStatus(1, 1, 400*mib, 300*mib)),
+				genMember(1, genStatus(2, 1, 400*mib, 300*mib)),
+				genMember(2, genStatus(3, 1, 400*mib, 300*mib)),
+			},
+		},
+		{
+			name: "Small databases are never defragmented",
+			members: []*member{
+				genMember(0, genStatus(1, 1, 50*mib, 5*mib)),
+				genMember(1, genStatus(2, 1, 50*mib, 5*mib)),
+				genMember(2, genStatus(3, 1, 50*mib, 5*mib)),
+			},
+		},
+		{
+			name: "Members without the size in use are skipped",
+			members: []*member{
+				genMember(0, genStatus(1, 1, 400*mib, 0)),
+			},
+		},
+		{
+			name: "Followers come before the leader",
+			members: []*member{
+				genMember(0, genStatus(1, 1, 400*mib, 100*mib)),
+				genMember(1, genStatus(2, 1, 400*mib, 300*mib)),
+				genMember(2, genStatus(3, 1, 400*mib, 100*mib)),
+			},
+			expected: "etcd-2",
+		},
+		{
+			name: "The leader gets defragmented last",
+			members: []*member{
+				genMember(0, genStatus(1, 1, 400*mib, 100*mib)),
+				genMember(1, genStatus(2, 1, 400*mib, 300*mib)),
+				genMember(2, genStatus(3, 1, 100*mib, 100*mib)),
+			},
+			expected: "etcd-0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := nextDefragmentation(tc.members, DefaultFragmentationThreshold)
+			if tc.expected == "" {
+				if result != nil {
+					t.Errorf("expected no member to be defragmented, got %s", result.name)
+				}
+				return
+			}
+			if result == nil || result.name != tc.expected {
+				t.Errorf("expected %s to be defragmented, got %+v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestInMaintenanceWindow(t *testing.T) {
+	// 2020-06-03 is a Wednesday
+	now := time.Date(2020, 6, 3, 14, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name              string
+		window            *kubermaticv1.UpdateWindow
+		expectedOpen      bool
+		expectedUntilOpen time.Duration
+	}{
+		{
+			name:         "No window",
+			expectedOpen: true,
+		},
+		{
+			name:         "Inside a daily window",
+			window:       &kubermaticv1.UpdateWindow{Start: "13:00", Length: "2h"},
+			expectedOpen: true,
+		},
+		{
+			name:              "Before a daily window",
+			window:            &kubermaticv1.UpdateWindow{Start: "22:00", Length: "2h"},
+			expectedUntilOpen: 8 * time.Hour,
+		},
+		{
+			name:              "Before a weekly window",
+			window:            &kubermaticv1.UpdateWindow{Start: "Thu 02:00", Length: "1h"},
+			expectedUntilOpen: 12 * time.Hour,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			open, untilOpen, err := inMaintenanceWindow(tc.window, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if open != tc.expectedOpen {
+				t.Errorf("expected the window to be open=%v, got %v", tc.expectedOpen, open)
+			}
+			if untilOpen != tc.expectedUntilOpen {
+				t.Errorf("expected the window to open in %v, got %v", tc.expectedUntilOpen, untilOpen)
+			}
+		})
+	}
+}
+
+type fakeEtcdClient struct {
+	statuses     map[string]*memberStatus
+	defragmented []string
+	closed       bool
+}
+
+func (f *fakeEtcdClient) Status(_ context.Context, endpoint string) (*memberStatus, error) {
+	status, ok := f.statuses[endpoint]
+	if !ok {
+		return nil, fmt.Errorf("connection refused")
+	}
+	return status, nil
+}
+
+func (f *fakeEtcdClient) Defragment(_ context.Context, endpoint string) error {
+	f.defragmented = append(f.defragmented, endpoint)
+	status := f.statuses[endpoint]
+	f.statuses[endpoint] = genStatus(status.Header.MemberID, status.Leader, status.DBSizeInUse, status.DBSizeInUse)
+	return nil
+}
+
+func (f *fakeEtcdClient) Close() {
+	f.closed = true
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName:  namespaceName,
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{Etcd: kubermaticv1.HealthStatusUp},
+		},
+	}
+	fakeClient := &fakeEtcdClient{statuses: map[string]*memberStatus{}}
+	for i, status := range []*memberStatus{
+		genStatus(1, 1, 400*mib, 100*mib),
+		genStatus(2, 1, 400*mib, 100*mib),
+		genStatus(3, 1, 400*mib, 300*mib),
+	} {
+		fakeClient.statuses[genMember(i, nil).endpoint] = status
+	}
+
+	now := time.Now()
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{
+		Client:                 ctrlruntimefakeclient.NewFakeClient(cluster),
+		log:                    kubermaticlog.Logger,
+		recorder:               recorder,
+		interval:               DefaultInterval,
+		fragmentationThreshold: DefaultFragmentationThreshold,
+		newEtcdClient: func(context.Context, ctrlruntimeclient.Client, *kubermaticv1.Cluster) (etcdClient, error) {
+			return fakeClient, nil
+		},
+		now: func() time.Time {
+			return now
+		},
+		lastDefragmentation: map[string]time.Time{},
+	}
+
+	expectedResults := []struct {
+		result       time.Duration
+		defragmented int
+	}{
+		{result: defragmentationPause, defragmented: 1},
+		{result: defragmentationPause, defragmented: 0},
+		{result: DefaultInterval, defragmented: -1},
+	}
+	for i, expected := range expectedResults {
+		if i > 0 {
+			// The next member is not defragmented before the pause is over
+			now = now.Add(defragmentationPause / 2)
+			result, err := r.Reconcile(reconcile.Request{NamespacedName: ctrlruntimeclient.ObjectKey{Name: clusterName}})
+			if err != nil {
+				t.Fatalf("failed to reconcile: %v", err)
+			}
+			if expected.defragmented >= 0 && result.RequeueAfter != defragmentationPause/2 {
+				t.Errorf("expected a requeue after the rest of the pause, got %v", result.RequeueAfter)
+			}
+			if len(fakeClient.defragmented) != 0 {
+				t.Errorf("expected no defragmentation during the pause, got %v", fakeClient.defragmented)
+			}
+			now = now.Add(defragmentationPause / 2)
+		}
+
+		result, err := r.Reconcile(reconcile.Request{NamespacedName: ctrlruntimeclient.ObjectKey{Name: clusterName}})
+		if err != nil {
+			t.Fatalf("failed to reconcile: %v", err)
+		}
+		if result.RequeueAfter != expected.result {
+			t.Errorf("expected a requeue after %v, got %v", expected.result, result.RequeueAfter)
+		}
+		if !fakeClient.closed {
+			t.Error("expected the etcd client to be closed after the reconciliation")
+		}
+		fakeClient.closed = false
+
+		if expected.defragmented < 0 {
+			if len(fakeClient.defragmented) != 0 {
+				t.Errorf("expected no defragmentation, got %v", fakeClient.defragmented)
+			}
+			continue
+		}
+		expectedMember := genMember(expected.defragmented, nil)
+		if len(fakeClient.defragmented) != 1 || fakeClient.defragmented[0] != expectedMember.endpoint {
+			t.Errorf("expected %s to be defragmented, got %v", expectedMember.name, fakeClient.defragmented)
+		}
+		fakeClient.defragmented = nil
+
+		select {
+		case event := <-recorder.Events:
+			if !strings.Contains(event, "EtcdDefragmented") || !strings.Contains(event, expectedMember.name) {
+				t.Errorf("expected a defragmentation event for %s, got %q", expectedMember.name, event)
+			}
+		default:
+			t.Errorf("expected a defragmentation event for %s", expectedMember.name)
+		}
+	}
+
+	// Nothing gets defragmented while a member is not available
+	delete(fakeClient.statuses, genMember(2, nil).endpoint)
+	if _, err := r.reconcile(ctx, kubermaticlog.Logger, cluster); err == nil {
+		t.Error("expected an error while a member is not available")
+	}
+}
+
+func TestGatewayAPIPrefix(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cluster  *kubermaticv1.Cluster
+		expected string
+	}{
+		{
+			name:     "Etcd 3.3",
+			cluster:  &kubermaticv1.Cluster{Spec: kubermaticv1.ClusterSpec{Version: *semver.NewSemverOrDie("1.16.9")}},
+			expected: "/v3beta",
+		},
+		{
+			name:     "Etcd 3.4",
+			cluster:  &kubermaticv1.Cluster{Spec: kubermaticv1.ClusterSpec{Version: *semver.NewSemverOrDie("1.17.5")}},
+			expected: "/v3",
+		},
+		{
+			name: "Openshift",
+			cluster: &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"kubermatic.io/openshift": "true"}},
+				Spec:       kubermaticv1.ClusterSpec{Version: *semver.NewSemverOrDie("4.1.18")},
+			},
+			expected: "/v3beta",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if prefix := gatewayAPIPrefix(tc.cluster); prefix != tc.expected {
+				t.Errorf("expected prefix %q, got %q", tc.expected, prefix)
+			}
+		})
+	}
+}
+
+func TestClusterChangedPredicate(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName, Labels: map[string]string{}},
+		Status:     kubermaticv1.ClusterStatus{NamespaceName: namespaceName},
+	}
+
+	testCases := []struct {
+		name     string
+		modify   func(*kubermaticv1.Cluster)
+		expected bool
+	}{
+		{
+			name: "Health update",
+			modify: func(c *kubermaticv1.Cluster) {
+				c.Status.ExtendedHealth.Etcd = kubermaticv1.HealthStatusUp
+			},
+		},
+		{
+			name: "Update window changed",
+			modify: func(c *kubermaticv1.Cluster) {
+				c.Spec.UpdateWindow = &kubermaticv1.UpdateWindow{Start: "02:00", Length: "1h"}
+			},
+			expected: true,
+		},
+		{
+			name: "Worker name changed",
+			modify: func(c *kubermaticv1.Cluster) {
+				c.Labels[kubermaticv1.WorkerNameLabelKey] = "worker"
+			},
+			expected: true,
+		},
+		{
+			name: "Hibernated",
+			modify: func(c *kubermaticv1.Cluster) {
+				c.Status.Hibernation = &kubermaticv1.HibernationStatus{}
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			newCluster := cluster.DeepCopy()
+			tc.modify(newCluster)
+			e := event.UpdateEvent{MetaOld: cluster, ObjectOld: cluster, MetaNew: newCluster, ObjectNew: newCluster}
+			if enqueued := clusterChangedPredicate().Update(e); enqueued != tc.expected {
+				t.Errorf("expected the cluster to be enqueued=%v, got %v", tc.expected, enqueued)
+			}
+		})
+	}
+}
+
+func TestGatewayClient(t *testing.T) {
+	var defragmented bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/maintenance/status":
+			fmt.Fprint(w, `{"header":{"cluster_id":"14841639068965178418","member_id":"10276657743932975437","revision":"4","raft_term":"2"},`+
+				`"version":"3.4.3","dbSize":"419430400","leader":"10276657743932975437","raftIndex":"12","raftTerm":"2","raftAppliedIndex":"12","dbSizeInUse":"104857600"}`)
+		case "/v3/maintenance/defragment":
+			defragmented = true
+			fmt.Fprint(w, `{"header":{"cluster_id":"14841639068965178418","member_id":"10276657743932975437","raft_term":"2"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := &gatewayClient{httpClient: server.Client(), apiPrefix: "/v3"}
+	status, err := client.Status(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("failed to get status: %v", err)
+	}
+	if status.DBSize != 400*mib || status.DBSizeInUse != 100*mib || !status.isLeader() {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	if err := client.Defragment(context.Background(), server.URL); err != nil {
+		t.Fatalf("failed to defragment: %v", err)
+	}
+	if !defragmented {
+		t.Error("expected the member to be defragmented")
+	}
+}
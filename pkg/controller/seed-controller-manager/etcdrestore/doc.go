@@ -0,0 +1,25 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package etcdrestore contains a controller that restores the etcd of a user cluster from a
+snapshot created by the backup controller, as requested by an EtcdRestore object.
+
+The restore pauses the cluster, scales down the apiserver and the etcd StatefulSet, restores
+the snapshot into the data volume of every etcd member using one Job per member and finally
+unpauses the cluster again, so the cluster controller brings the control plane back up.
+*/
+package etcdrestore
@@ -0,0 +1,471 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdrestore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	backupcontroller "github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/backup"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/resources"
+	"github.com/kubermatic/kubermatic/pkg/resources/etcd"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	utilpointer "k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kubermatic_etcd_restore_controller"
+
+	// restoreJobLabel defines the label we use on all restore jobs
+	restoreJobLabel = "kubermatic-etcd-restore"
+	// backupToRestoreEnvVarKey defines the environment variable key for the name of the snapshot to restore
+	backupToRestoreEnvVarKey = "BACKUP_TO_RESTORE"
+	// clusterEnvVarKey defines the environment variable key for the cluster name
+	clusterEnvVarKey = "CLUSTER"
+	// etcdDataVolumeName is the name of the volume claim template of the etcd StatefulSet
+	etcdDataVolumeName = "data"
+	// etcdDataMountPath is the path the etcd StatefulSet mounts its data volume to
+	etcdDataMountPath = "/var/run/etcd"
+)
+
+// Reconciler stores all components required for restoring the etcd of user clusters
+type Reconciler struct {
+	log              *zap.SugaredLogger
+	workerName       string
+	restoreContainer corev1.Container
+	// backupContainerImage holds the etcd image used for restoring the snapshot
+	backupContainerImage string
+
+	ctrlruntimeclient.Client
+	recorder record.EventRecorder
+}
+
+// Add creates a new etcd restore controller that is responsible for restoring the
+// etcd of user clusters as requested by EtcdRestore objects
+func Add(
+	log *zap.SugaredLogger,
+	mgr manager.Manager,
+	numWorkers int,
+	workerName string,
+	restoreContainer corev1.Container,
+	backupContainerImage string,
+) error {
+	log = log.Named(ControllerName)
+	if err := validateRestoreContainer(restoreContainer); err != nil {
+		return err
+	}
+	if backupContainerImage == "" {
+		backupContainerImage = backupcontroller.DefaultBackupContainerImage
+	}
+
+	reconciler := &Reconciler{
+		log:                  log,
+		workerName:           workerName,
+		restoreContainer:     restoreContainer,
+		backupContainerImage: backupContainerImage,
+		Client:               mgr.GetClient(),
+		recorder:             mgr.GetEventRecorderFor(ControllerName),
+	}
+	c, err := controller.New(ControllerName, mgr, controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	jobMapFn := &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(func(a handler.MapObject) []reconcile.Request {
+		if ownerRef := metav1.GetControllerOf(a.Meta); ownerRef != nil && ownerRef.Kind == kubermaticv1.EtcdRestoreKindName {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: a.Meta.GetNamespace(), Name: ownerRef.Name}}}
+		}
+		return nil
+	})}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.EtcdRestore{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to watch EtcdRestores: %v", err)
+	}
+	if err := c.Watch(&source.Kind{Type: &batchv1.Job{}}, jobMapFn); err != nil {
+		return fmt.Errorf("failed to watch Jobs: %v", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log := r.log.With("request", request)
+	log.Debug("Processing")
+
+	restore := &kubermaticv1.EtcdRestore{}
+	if err := r.Get(ctx, request.NamespacedName, restore); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if restore.Status.IsFinished() {
+		return reconcile.Result{}, nil
+	}
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, types.NamespacedName{Name: restore.Spec.Cluster.Name}, cluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, r.setFailed(ctx, restore, fmt.Sprintf("cluster %q does not exist", restore.Spec.Cluster.Name))
+		}
+		return reconcile.Result{}, err
+	}
+
+	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] != r.workerName {
+		return reconcile.Result{}, nil
+	}
+
+	log = log.With("cluster", cluster.Name)
+
+	result, err := r.reconcile(ctx, log, restore, cluster)
+	if err != nil {
+		log.Errorw("Reconciling failed", zap.Error(err))
+		r.recorder.Event(restore, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, restore *kubermaticv1.EtcdRestore, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	switch restore.Status.Phase {
+	case "":
+		return nil, r.start(ctx, log, restore, cluster)
+	case kubermaticv1.EtcdRestorePhaseStarted:
+		return r.scaleDownControlPlane(ctx, log, restore, cluster)
+	case kubermaticv1.EtcdRestorePhaseRestoring:
+		return r.restoreMembers(ctx, log, restore, cluster)
+	case kubermaticv1.EtcdRestorePhaseEtcdLaunching:
+		return r.waitForEtcd(ctx, log, restore, cluster)
+	default:
+		return nil, fmt.Errorf("unknown phase %q", restore.Status.Phase)
+	}
+}
+
+// start validates the restore and pauses the cluster, so no other controller touches its
+// control plane while we restore the etcd
+func (r *Reconciler) start(ctx context.Context, log *zap.SugaredLogger, restore *kubermaticv1.EtcdRestore, cluster *kubermaticv1.Cluster) error {
+	if restore.Spec.BackupName == "" {
+		return r.setFailed(ctx, restore, "backupName must not be empty")
+	}
+	if restore.Namespace != cluster.Status.NamespaceName {
+		return r.setFailed(ctx, restore, fmt.Sprintf("restore must be created in the cluster namespace %q", cluster.Status.NamespaceName))
+	}
+	if cluster.Spec.Pause && cluster.Spec.PauseReason != pauseReason(restore) {
+		return r.setFailed(ctx, restore, fmt.Sprintf("cluster is already paused: %s", cluster.Spec.PauseReason))
+	}
+
+	if !cluster.Spec.Pause {
+		oldCluster := cluster.DeepCopy()
+		cluster.Spec.Pause = true
+		cluster.Spec.PauseReason = pauseReason(restore)
+		if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return fmt.Errorf("failed to pause cluster: %v", err)
+		}
+		log.Info("Paused cluster for the etcd restore")
+	}
+
+	now := metav1.Now()
+	return r.setPhase(ctx, restore, kubermaticv1.EtcdRestorePhaseStarted, "Scaling down the control plane", func(status *kubermaticv1.EtcdRestoreStatus) {
+		status.StartTime = &now
+	})
+}
+
+// scaleDownControlPlane stops the apiserver and all etcd members. The apiserver must be restarted
+// after the restore anyways, as its watch caches would contain revisions the restored etcd does not know.
+func (r *Reconciler) scaleDownControlPlane(ctx context.Context, log *zap.SugaredLogger, restore *kubermaticv1.EtcdRestore, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	namespace := cluster.Status.NamespaceName
+
+	apiserver := &appsv1.Deployment{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: resources.ApiserverDeploymentName}, apiserver); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get apiserver deployment: %v", err)
+		}
+	} else if apiserver.Spec.Replicas == nil || *apiserver.Spec.Replicas != 0 {
+		oldAPIServer := apiserver.DeepCopy()
+		apiserver.Spec.Replicas = utilpointer.Int32Ptr(0)
+		if err := r.Patch(ctx, apiserver, ctrlruntimeclient.MergeFrom(oldAPIServer)); err != nil {
+			return nil, fmt.Errorf("failed to scale down apiserver deployment: %v", err)
+		}
+		log.Debug("Scaled down apiserver")
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: resources.EtcdStatefulSetName}, statefulSet); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get etcd statefulset: %v", err)
+		}
+	} else {
+		if statefulSet.Spec.Replicas == nil || *statefulSet.Spec.Replicas != 0 {
+			oldStatefulSet := statefulSet.DeepCopy()
+			statefulSet.Spec.Replicas = utilpointer.Int32Ptr(0)
+			if err := r.Patch(ctx, statefulSet, ctrlruntimeclient.MergeFrom(oldStatefulSet)); err != nil {
+				return nil, fmt.Errorf("failed to scale down etcd statefulset: %v", err)
+			}
+			log.Debug("Scaled down etcd")
+		}
+
+		if statefulSet.Status.Replicas != 0 {
+			log.Debugw("Waiting for etcd pods to terminate", "replicas", statefulSet.Status.Replicas)
+			return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+	}
+
+	return nil, r.setPhase(ctx, restore, kubermaticv1.EtcdRestorePhaseRestoring, "Restoring the snapshot on all etcd members", nil)
+}
+
+// restoreMembers runs one restore Job per etcd member and waits for all of them to succeed
+func (r *Reconciler) restoreMembers(ctx context.Context, log *zap.SugaredLogger, restore *kubermaticv1.EtcdRestore, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	clusterSize := resources.GetEtcdClusterSize(cluster)
+	succeeded := 0
+	for member := 0; member < clusterSize; member++ {
+		wantJob := r.restoreJob(restore, cluster, member)
+
+		job := &batchv1.Job{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: wantJob.Namespace, Name: wantJob.Name}, job); err != nil {
+			if !kerrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get restore job %q: %v", wantJob.Name, err)
+			}
+			if err := r.Create(ctx, wantJob); err != nil && !kerrors.IsAlreadyExists(err) {
+				return nil, fmt.Errorf("failed to create restore job %q: %v", wantJob.Name, err)
+			}
+			log.Infow("Created restore job", "job", wantJob.Name)
+			continue
+		}
+
+		if jobHasCondition(job, batchv1.JobFailed) {
+			return nil, r.setFailed(ctx, restore, fmt.Sprintf("restore job %q failed, the cluster stays paused", job.Name))
+		}
+		if jobHasCondition(job, batchv1.JobComplete) {
+			succeeded++
+		}
+	}
+
+	if succeeded < clusterSize {
+		log.Debugw("Waiting for restore jobs to complete", "succeeded", succeeded)
+		return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	// The cluster controller will scale the control plane up again once the cluster got unpaused
+	if cluster.Spec.Pause && cluster.Spec.PauseReason == pauseReason(restore) {
+		oldCluster := cluster.DeepCopy()
+		cluster.Spec.Pause = false
+		cluster.Spec.PauseReason = ""
+		if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return nil, fmt.Errorf("failed to unpause cluster: %v", err)
+		}
+		log.Info("Unpaused cluster after the etcd restore")
+	}
+
+	return nil, r.setPhase(ctx, restore, kubermaticv1.EtcdRestorePhaseEtcdLaunching, "Waiting for etcd to become healthy", nil)
+}
+
+func (r *Reconciler) waitForEtcd(ctx context.Context, log *zap.SugaredLogger, restore *kubermaticv1.EtcdRestore, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	nn := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.EtcdStatefulSetName}
+	health, err := resources.HealthyStatefulSet(ctx, r.Client, nn, int32(resources.GetEtcdClusterSize(cluster)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get etcd health: %v", err)
+	}
+	if health != kubermaticv1.HealthStatusUp {
+		log.Debugw("Waiting for etcd to become healthy", "health", health)
+		return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, "EtcdRestored", "Restored etcd from backup %q", restore.Spec.BackupName)
+
+	now := metav1.Now()
+	return nil, r.setPhase(ctx, restore, kubermaticv1.EtcdRestorePhaseCompleted, "", func(status *kubermaticv1.EtcdRestoreStatus) {
+		status.CompletionTime = &now
+	})
+}
+
+func (r *Reconciler) setPhase(ctx context.Context, restore *kubermaticv1.EtcdRestore, phase kubermaticv1.EtcdRestorePhase, message string, modify func(*kubermaticv1.EtcdRestoreStatus)) error {
+	oldRestore := restore.DeepCopy()
+	restore.Status.Phase = phase
+	restore.Status.Message = message
+	if modify != nil {
+		modify(&restore.Status)
+	}
+	if err := r.Patch(ctx, restore, ctrlruntimeclient.MergeFrom(oldRestore)); err != nil {
+		return fmt.Errorf("failed to set phase %q: %v", phase, err)
+	}
+	return nil
+}
+
+func (r *Reconciler) setFailed(ctx context.Context, restore *kubermaticv1.EtcdRestore, message string) error {
+	r.recorder.Event(restore, corev1.EventTypeWarning, "RestoreFailed", message)
+	return r.setPhase(ctx, restore, kubermaticv1.EtcdRestorePhaseFailed, message, nil)
+}
+
+func (r *Reconciler) restoreJob(restore *kubermaticv1.EtcdRestore, cluster *kubermaticv1.Cluster, member int) *batchv1.Job {
+	memberName := fmt.Sprintf("%s-%d", resources.EtcdStatefulSetName, member)
+
+	restoreContainer := r.restoreContainer.DeepCopy()
+	restoreContainer.Env = append(restoreContainer.Env,
+		corev1.EnvVar{
+			Name:  clusterEnvVarKey,
+			Value: cluster.Name,
+		},
+		corev1.EnvVar{
+			Name:  backupToRestoreEnvVarKey,
+			Value: restore.Spec.BackupName,
+		},
+	)
+
+	image := r.backupContainerImage
+	if !strings.Contains(image, ":") {
+		image = image + ":" + etcd.ImageTag(cluster)
+	}
+
+	gv := kubermaticv1.SchemeGroupVersion
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", restore.Name, memberName),
+			Namespace: restore.Namespace,
+			Labels: map[string]string{
+				resources.AppLabelKey: restoreJobLabel,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(restore, gv.WithKind(kubermaticv1.EtcdRestoreKindName)),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: utilpointer.Int32Ptr(3),
+			Completions:  utilpointer.Int32Ptr(1),
+			Parallelism:  utilpointer.Int32Ptr(1),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{*restoreContainer},
+					Containers: []corev1.Container{
+						{
+							Name:  "etcd-restore",
+							Image: image,
+							Env: []corev1.EnvVar{
+								{
+									Name:  "ETCDCTL_API",
+									Value: "3",
+								},
+							},
+							Command: restoreCommand(cluster, member),
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      backupcontroller.SharedVolumeName,
+									MountPath: "/backup",
+								},
+								{
+									Name:      etcdDataVolumeName,
+									MountPath: etcdDataMountPath,
+								},
+							},
+						},
+					},
+					RestartPolicy: corev1.RestartPolicyNever,
+					Volumes: []corev1.Volume{
+						{
+							Name: backupcontroller.SharedVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+						{
+							Name: etcdDataVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: fmt.Sprintf("%s-%s", etcdDataVolumeName, memberName),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// restoreCommand restores the snapshot into a new data dir and only replaces the existing
+// data dir of the member once the restore succeeded. The data dir and the peer URLs must
+// match the ones used by the etcd-launcher.
+func restoreCommand(cluster *kubermaticv1.Cluster, member int) []string {
+	namespace := cluster.Status.NamespaceName
+	serviceDNSName := fmt.Sprintf("%s.%s.svc.cluster.local", resources.EtcdServiceName, namespace)
+
+	var initialCluster []string
+	for i := 0; i < resources.GetEtcdClusterSize(cluster); i++ {
+		initialCluster = append(initialCluster, fmt.Sprintf("etcd-%d=http://etcd-%d.%s:2380", i, i, serviceDNSName))
+	}
+
+	memberName := fmt.Sprintf("etcd-%d", member)
+	dataDir := fmt.Sprintf("%s/pod_%s", etcdDataMountPath, memberName)
+
+	script := &strings.Builder{}
+	// Accordings to its godoc, this always returns a nil error
+	_, _ = script.WriteString(fmt.Sprintf("rm -rf %s.restore\n", dataDir))
+	_, _ = script.WriteString(fmt.Sprintf(
+		"etcdctl snapshot restore /backup/snapshot.db --name %s --initial-cluster %s --initial-cluster-token %s --initial-advertise-peer-urls http://%s.%s:2380 --data-dir %s.restore\n",
+		memberName, strings.Join(initialCluster, ","), cluster.Name, memberName, serviceDNSName, dataDir))
+	_, _ = script.WriteString(fmt.Sprintf("rm -rf %s\n", dataDir))
+	_, _ = script.WriteString(fmt.Sprintf("mv %s.restore %s", dataDir, dataDir))
+
+	return []string{"/bin/sh", "-ec", script.String()}
+}
+
+func pauseReason(restore *kubermaticv1.EtcdRestore) string {
+	return fmt.Sprintf("etcd restore %s/%s in progress", restore.Namespace, restore.Name)
+}
+
+func jobHasCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func validateRestoreContainer(restoreContainer corev1.Container) error {
+	for _, volumeMount := range restoreContainer.VolumeMounts {
+		if volumeMount.Name == backupcontroller.SharedVolumeName {
+			return nil
+		}
+	}
+	return fmt.Errorf("restoreContainer does not have a mount for the shared volume %s", backupcontroller.SharedVolumeName)
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdrestore
+
+import (
+	"context"
+	"testing"
+
+	backupcontroller "github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/backup"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	kubermaticlog "github.com/kubermatic/kubermatic/pkg/log"
+	"github.com/kubermatic/kubermatic/pkg/resources"
+	"github.com/kubermatic/kubermatic/pkg/semver"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	utilpointer "k8s.io/utils/pointer"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var testRestoreContainer = corev1.Container{
+	Name:         "kubermatic-restore",
+	Image:        "busybox",
+	VolumeMounts: []corev1.VolumeMount{{Name: backupcontroller.SharedVolumeName, MountPath: "/backup"}},
+}
+
+func testCluster() *kubermaticv1.Cluster {
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cluster",
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Version: *semver.NewSemverOrDie("1.17.3"),
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-test-cluster",
+		},
+	}
+}
+
+func testRestore() *kubermaticv1.EtcdRestore {
+	return &kubermaticv1.EtcdRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "restore",
+			Namespace: "cluster-test-cluster",
+		},
+		Spec: kubermaticv1.EtcdRestoreSpec{
+			Cluster:    corev1.ObjectReference{Name: "test-cluster"},
+			BackupName: "test-cluster-storeuploader-2020-05-01T10:00:00-snapshot.db",
+		},
+	}
+}
+
+func TestEtcdRestore(t *testing.T) {
+	cluster := testCluster()
+	restore := testRestore()
+	etcdStatefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resources.EtcdStatefulSetName,
+			Namespace: cluster.Status.NamespaceName,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: utilpointer.Int32Ptr(resources.EtcdClusterSize),
+		},
+	}
+	apiserver := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resources.ApiserverDeploymentName,
+			Namespace: cluster.Status.NamespaceName,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: utilpointer.Int32Ptr(2),
+		},
+	}
+
+	ctx := context.Background()
+	r := &Reconciler{
+		log:                  kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		restoreContainer:     testRestoreContainer,
+		backupContainerImage: backupcontroller.DefaultBackupContainerImage,
+		Client:               ctrlruntimefakeclient.NewFakeClient(cluster, restore, etcdStatefulSet, apiserver),
+		recorder:             record.NewFakeRecorder(10),
+	}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: restore.Namespace, Name: restore.Name}}
+
+	reconcileAndExpectPhase := func(expected kubermaticv1.EtcdRestorePhase) {
+		t.Helper()
+		if _, err := r.Reconcile(request); err != nil {
+			t.Fatalf("failed to reconcile: %v", err)
+		}
+		if err := r.Get(ctx, request.NamespacedName, restore); err != nil {
+			t.Fatalf("failed to get restore: %v", err)
+		}
+		if restore.Status.Phase != expected {
+			t.Fatalf("expected phase %q, got %q (message: %q)", expected, restore.Status.Phase, restore.Status.Message)
+		}
+	}
+
+	reconcileAndExpectPhase(kubermaticv1.EtcdRestorePhaseStarted)
+	if err := r.Get(ctx, types.NamespacedName{Name: cluster.Name}, cluster); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	if !cluster.Spec.Pause {
+		t.Fatal("expected cluster to be paused")
+	}
+
+	// Scaled down, but the etcd pods are still running
+	etcdStatefulSet.Status.Replicas = resources.EtcdClusterSize
+	if err := r.Update(ctx, etcdStatefulSet); err != nil {
+		t.Fatalf("failed to update statefulset: %v", err)
+	}
+	reconcileAndExpectPhase(kubermaticv1.EtcdRestorePhaseStarted)
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.EtcdStatefulSetName}, etcdStatefulSet); err != nil {
+		t.Fatalf("failed to get statefulset: %v", err)
+	}
+	if *etcdStatefulSet.Spec.Replicas != 0 {
+		t.Errorf("expected etcd to be scaled down, has %d replicas", *etcdStatefulSet.Spec.Replicas)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.ApiserverDeploymentName}, apiserver); err != nil {
+		t.Fatalf("failed to get apiserver: %v", err)
+	}
+	if *apiserver.Spec.Replicas != 0 {
+		t.Errorf("expected apiserver to be scaled down, has %d replicas", *apiserver.Spec.Replicas)
+	}
+
+	etcdStatefulSet.Status.Replicas = 0
+	if err := r.Update(ctx, etcdStatefulSet); err != nil {
+		t.Fatalf("failed to update statefulset: %v", err)
+	}
+	reconcileAndExpectPhase(kubermaticv1.EtcdRestorePhaseRestoring)
+
+	// Creates the jobs
+	reconcileAndExpectPhase(kubermaticv1.EtcdRestorePhaseRestoring)
+	jobs := &batchv1.JobList{}
+	if err := r.List(ctx, jobs); err != nil {
+		t.Fatalf("failed to list jobs: %v", err)
+	}
+	if len(jobs.Items) != resources.EtcdClusterSize {
+		t.Fatalf("expected %d restore jobs, got %d", resources.EtcdClusterSize, len(jobs.Items))
+	}
+
+	for _, job := range jobs.Items {
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		if err := r.Update(ctx, &job); err != nil {
+			t.Fatalf("failed to update job: %v", err)
+		}
+	}
+	reconcileAndExpectPhase(kubermaticv1.EtcdRestorePhaseEtcdLaunching)
+	if err := r.Get(ctx, types.NamespacedName{Name: cluster.Name}, cluster); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	if cluster.Spec.Pause {
+		t.Fatal("expected cluster to be unpaused")
+	}
+
+	// The cluster controller scaled etcd up again
+	etcdStatefulSet.Spec.Replicas = utilpointer.Int32Ptr(resources.EtcdClusterSize)
+	etcdStatefulSet.Status.Replicas = resources.EtcdClusterSize
+	etcdStatefulSet.Status.ReadyReplicas = resources.EtcdClusterSize
+	etcdStatefulSet.Status.UpdatedReplicas = resources.EtcdClusterSize
+	if err := r.Update(ctx, etcdStatefulSet); err != nil {
+		t.Fatalf("failed to update statefulset: %v", err)
+	}
+	reconcileAndExpectPhase(kubermaticv1.EtcdRestorePhaseCompleted)
+	if restore.Status.CompletionTime == nil {
+		t.Error("expected completion time to be set")
+	}
+}
+
+func TestEtcdRestoreFailsForPausedCluster(t *testing.T) {
+	cluster := testCluster()
+	cluster.Spec.Pause = true
+	cluster.Spec.PauseReason = "maintenance"
+	restore := testRestore()
+
+	r := &Reconciler{
+		log:              kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		restoreContainer: testRestoreContainer,
+		Client:           ctrlruntimefakeclient.NewFakeClient(cluster, restore),
+		recorder:         record.NewFakeRecorder(10),
+	}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: restore.Namespace, Name: restore.Name}}
+	if _, err := r.Reconcile(request); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	if err := r.Get(context.Background(), request.NamespacedName, restore); err != nil {
+		t.Fatalf("failed to get restore: %v", err)
+	}
+	if restore.Status.Phase != kubermaticv1.EtcdRestorePhaseFailed {
+		t.Errorf("expected phase %q, got %q", kubermaticv1.EtcdRestorePhaseFailed, restore.Status.Phase)
+	}
+}
+
+func TestRestoreCommand(t *testing.T) {
+	command := restoreCommand(testCluster(), 1)
+	if len(command) != 3 {
+		t.Fatalf("expected command to have 3 elements, got %d", len(command))
+	}
+
+	expected := `rm -rf /var/run/etcd/pod_etcd-1.restore
+etcdctl snapshot restore /backup/snapshot.db --name etcd-1 --initial-cluster etcd-0=http://etcd-0.etcd.cluster-test-cluster.svc.cluster.local:2380,etcd-1=http://etcd-1.etcd.cluster-test-cluster.svc.cluster.local:2380,etcd-2=http://etcd-2.etcd.cluster-test-cluster.svc.cluster.local:2380 --initial-cluster-token test-cluster --initial-advertise-peer-urls http://etcd-1.etcd.cluster-test-cluster.svc.cluster.local:2380 --data-dir /var/run/etcd/pod_etcd-1.restore
+rm -rf /var/run/etcd/pod_etcd-1
+mv /var/run/etcd/pod_etcd-1.restore /var/run/etcd/pod_etcd-1`
+	if command[2] != expected {
+		t.Errorf("unexpected restore command, expected\n%s\ngot\n%s", expected, command[2])
+	}
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package hibernation contains a controller that hibernates clusters and resumes them again.
+
+A cluster gets hibernated when its hibernation is enabled or when one of its hibernation schedules
+is active. The replica counts of the MachineDeployments of the user cluster are recorded in the
+cluster status before the MachineDeployments get scaled to zero. Once all machines are gone, the
+replica counts of all Deployments and StatefulSets in the cluster namespace are recorded as well
+and they get scaled to zero, too. While the status contains the hibernation, all other controllers
+leave the cluster alone.
+
+Resuming a cluster restores the recorded replicas in order: etcd first, then the apiserver once
+etcd is ready, then the remaining control plane components once the apiserver is available and
+finally the MachineDeployments. Clusters which get deleted are resumed so their cleanup can reach
+the user cluster. Openshift clusters are never hibernated, the API rejects hibernation settings
+for them.
+*/
+package hibernation
@@ -0,0 +1,551 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/coreos/locksmith/pkg/timeutil"
+	"go.uber.org/zap"
+
+	k8cuserclusterclient "github.com/kubermatic/kubermatic/pkg/cluster/client"
+	controllerutil "github.com/kubermatic/kubermatic/pkg/controller/util"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/resources"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	utilpointer "k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "kubermatic_hibernation_controller"
+
+	// machinePollInterval is the interval in which the machines of the user cluster are checked
+	// while waiting for them to be deleted, they can not be watched from the seed.
+	machinePollInterval = 10 * time.Second
+)
+
+type userClusterConnectionProvider interface {
+	GetClient(*kubermaticv1.Cluster, ...k8cuserclusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
+}
+
+type Reconciler struct {
+	ctrlruntimeclient.Client
+	log                           *zap.SugaredLogger
+	workerName                    string
+	recorder                      record.EventRecorder
+	userClusterConnectionProvider userClusterConnectionProvider
+	now                           func() time.Time
+}
+
+// Add creates a new hibernation controller
+func Add(
+	mgr manager.Manager,
+	log *zap.SugaredLogger,
+	numWorkers int,
+	workerName string,
+	userClusterConnectionProvider userClusterConnectionProvider) error {
+
+	reconciler := &Reconciler{
+		Client:                        mgr.GetClient(),
+		log:                           log.Named(ControllerName),
+		workerName:                    workerName,
+		recorder:                      mgr.GetEventRecorderFor(ControllerName),
+		userClusterConnectionProvider: userClusterConnectionProvider,
+		now:                           time.Now,
+	}
+
+	c, err := controller.New(ControllerName, mgr, controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: numWorkers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	if err := c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to create watch: %v", err)
+	}
+
+	// The control plane workloads are watched to continue resuming as soon as etcd and the apiserver are up
+	for _, t := range []runtime.Object{&appsv1.Deployment{}, &appsv1.StatefulSet{}} {
+		if err := c.Watch(&source.Kind{Type: t}, controllerutil.EnqueueClusterForNamespacedObject(mgr.GetClient())); err != nil {
+			return fmt.Errorf("failed to create watch for %T: %v", t, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cluster := &kubermaticv1.Cluster{}
+	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// The ClusterReconcileWrapper can not be used here, it skips all hibernated clusters
+	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] != r.workerName || cluster.Spec.Pause {
+		return reconcile.Result{}, nil
+	}
+
+	result, err := r.reconcile(ctx, cluster)
+	if err != nil {
+		r.log.Errorw("Failed to reconcile cluster", "cluster", cluster.Name, zap.Error(err))
+		r.recorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+	}
+	if result == nil {
+		result = &reconcile.Result{}
+	}
+	return *result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	hibernate, nextScheduleChange, err := HibernationDesired(cluster.Spec.Hibernation, r.now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate hibernation schedules: %v", err)
+	}
+	// Deleted clusters get resumed, the cleanup of the user cluster needs the control plane and the machine-controller
+	if cluster.DeletionTimestamp != nil || cluster.Status.NamespaceName == "" {
+		hibernate = false
+	}
+	// Openshift clusters do not support hibernation, settings which predate the API validation are ignored
+	if cluster.IsOpenshift() {
+		hibernate = false
+	}
+
+	var result *reconcile.Result
+	if nextScheduleChange > 0 {
+		result = &reconcile.Result{RequeueAfter: nextScheduleChange}
+	}
+
+	status := cluster.Status.Hibernation
+	switch {
+	case status == nil && !hibernate:
+		return result, nil
+	case status == nil && hibernate:
+		return nil, r.startHibernation(ctx, cluster)
+	case hibernate && status.Phase == kubermaticv1.HibernationPhaseResuming:
+		// The machines can only be deleted through a running control plane, so an unfinished
+		// resume has to bring it back up before the cluster gets hibernated again
+		ready, err := r.resumeControlPlane(ctx, cluster)
+		if err != nil || !ready {
+			return nil, err
+		}
+		return nil, r.setPhase(ctx, cluster, kubermaticv1.HibernationPhaseHibernating)
+	case hibernate && status.Phase == kubermaticv1.HibernationPhaseHibernating:
+		return r.hibernate(ctx, cluster)
+	case hibernate:
+		return result, nil
+	case status.Phase != kubermaticv1.HibernationPhaseResuming:
+		return nil, r.setPhase(ctx, cluster, kubermaticv1.HibernationPhaseResuming)
+	}
+
+	return r.resume(ctx, cluster)
+}
+
+// HibernationDesired returns true if the cluster should be hibernated at the given time. It also returns
+// the duration until one of the schedules starts or ends the next time, or zero if there are no schedules.
+func HibernationDesired(settings *kubermaticv1.HibernationSettings, now time.Time) (bool, time.Duration, error) {
+	if settings == nil {
+		return false, 0, nil
+	}
+
+	location := time.UTC
+	if settings.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(settings.TimeZone); err != nil {
+			return false, 0, fmt.Errorf("invalid time zone %q: %v", settings.TimeZone, err)
+		}
+	}
+	now = now.In(location)
+
+	active := settings.Enabled
+	var nextChange time.Duration
+	for _, schedule := range settings.Schedules {
+		periodic, err := timeutil.ParsePeriodic(schedule.Start, schedule.Length)
+		if err != nil {
+			return false, 0, fmt.Errorf("invalid schedule %q/%q: %v", schedule.Start, schedule.Length, err)
+		}
+
+		change := periodic.DurationToStart(now)
+		if change <= 0 {
+			active = true
+			change = periodic.Previous(now).End.Sub(now)
+		}
+		// Make sure we never requeue with a zero duration, that would not requeue at all
+		if change < time.Second {
+			change = time.Second
+		}
+		if nextChange == 0 || change < nextChange {
+			nextChange = change
+		}
+	}
+
+	return active, nextChange, nil
+}
+
+// startHibernation records the replicas of the MachineDeployments before anything gets scaled down.
+func (r *Reconciler) startHibernation(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	userClusterClient, err := r.userClusterConnectionProvider.GetClient(cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get usercluster client: %v", err)
+	}
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	// Kubermatic only creates MachineDeployments in the kube-system namespace, everything else is essentially unsupported
+	if err := userClusterClient.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return fmt.Errorf("failed to list MachineDeployments: %v", err)
+	}
+
+	oldCluster := cluster.DeepCopy()
+	cluster.Status.Hibernation = &kubermaticv1.HibernationStatus{
+		Phase:              kubermaticv1.HibernationPhaseHibernating,
+		LastTransitionTime: metav1.NewTime(r.now()),
+		MachineDeployments: map[string]int32{},
+	}
+	for _, md := range machineDeployments.Items {
+		cluster.Status.Hibernation.MachineDeployments[md.Name] = machineDeploymentReplicas(&md)
+	}
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to update cluster status: %v", err)
+	}
+
+	r.recorder.Event(cluster, corev1.EventTypeNormal, "HibernationStarted", "Started to hibernate the cluster")
+	return nil
+}
+
+// hibernate scales the MachineDeployments to zero and the control plane once all machines are gone.
+func (r *Reconciler) hibernate(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	// The control plane only gets scaled down once all machines are gone. If the phase could not be
+	// updated afterwards, the user cluster is unreachable and its MachineDeployments stay recorded.
+	apiserverScaledDown, err := r.apiserverScaledDown(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	if !apiserverScaledDown {
+		result, err := r.deleteMachines(ctx, cluster)
+		if err != nil || result != nil {
+			return result, err
+		}
+	}
+
+	return nil, r.hibernateControlPlane(ctx, cluster)
+}
+
+// apiserverScaledDown returns true if the apiserver was scaled to zero by a previous hibernation attempt.
+func (r *Reconciler) apiserverScaledDown(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, error) {
+	if _, recorded := cluster.Status.Hibernation.Deployments[resources.ApiserverDeploymentName]; !recorded {
+		return false, nil
+	}
+	apiserver := &appsv1.Deployment{}
+	if err := r.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: cluster.Status.NamespaceName, Name: resources.ApiserverDeploymentName}, apiserver); err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get apiserver Deployment: %v", err)
+	}
+	return deploymentReplicas(apiserver) == 0, nil
+}
+
+// deleteMachines scales the MachineDeployments to zero. It returns a result to requeue while
+// the machines are still being deleted.
+func (r *Reconciler) deleteMachines(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	userClusterClient, err := r.userClusterConnectionProvider.GetClient(cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usercluster client: %v", err)
+	}
+
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	if err := userClusterClient.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return nil, fmt.Errorf("failed to list MachineDeployments: %v", err)
+	}
+
+	// MachineDeployments might have been created after the hibernation started, their
+	// replicas need to be recorded before they get scaled down
+	oldCluster := cluster.DeepCopy()
+	for _, md := range machineDeployments.Items {
+		if _, recorded := cluster.Status.Hibernation.MachineDeployments[md.Name]; !recorded {
+			if cluster.Status.Hibernation.MachineDeployments == nil {
+				cluster.Status.Hibernation.MachineDeployments = map[string]int32{}
+			}
+			cluster.Status.Hibernation.MachineDeployments[md.Name] = machineDeploymentReplicas(&md)
+		}
+	}
+	if !reflect.DeepEqual(oldCluster, cluster) {
+		if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+			return nil, fmt.Errorf("failed to update cluster status: %v", err)
+		}
+	}
+
+	for _, md := range machineDeployments.Items {
+		if machineDeploymentReplicas(&md) == 0 {
+			continue
+		}
+		oldMD := md.DeepCopy()
+		md.Spec.Replicas = utilpointer.Int32Ptr(0)
+		if err := userClusterClient.Patch(ctx, &md, ctrlruntimeclient.MergeFrom(oldMD)); err != nil {
+			return nil, fmt.Errorf("failed to scale down MachineDeployment %s: %v", md.Name, err)
+		}
+	}
+
+	// The machine-controller needs the control plane to delete the instances at the cloud provider
+	machines := &clusterv1alpha1.MachineList{}
+	if err := userClusterClient.List(ctx, machines, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return nil, fmt.Errorf("failed to list Machines: %v", err)
+	}
+	if len(machines.Items) > 0 {
+		r.log.Debugw("Waiting for machines to be deleted", "cluster", cluster.Name, "machines", len(machines.Items))
+		return &reconcile.Result{RequeueAfter: machinePollInterval}, nil
+	}
+	return nil, nil
+}
+
+// hibernateControlPlane scales the control plane to zero and finishes the hibernation.
+func (r *Reconciler) hibernateControlPlane(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return fmt.Errorf("failed to list Deployments: %v", err)
+	}
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := r.List(ctx, statefulSets, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return fmt.Errorf("failed to list StatefulSets: %v", err)
+	}
+
+	// The replicas get recorded before anything is scaled down, so a failed update does not lose them.
+	// Components which already are at zero keep their recorded replicas from an aborted resume.
+	oldCluster := cluster.DeepCopy()
+	status := cluster.Status.Hibernation
+	if status.Deployments == nil {
+		status.Deployments = map[string]int32{}
+	}
+	for _, deployment := range deployments.Items {
+		if replicas := deploymentReplicas(&deployment); replicas > 0 {
+			status.Deployments[deployment.Name] = replicas
+		}
+	}
+	if status.StatefulSets == nil {
+		status.StatefulSets = map[string]int32{}
+	}
+	for _, statefulSet := range statefulSets.Items {
+		if replicas := statefulSetReplicas(&statefulSet); replicas > 0 {
+			status.StatefulSets[statefulSet.Name] = replicas
+		}
+	}
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to update cluster status: %v", err)
+	}
+
+	for _, deployment := range deployments.Items {
+		if err := r.scaleDeployment(ctx, &deployment, 0); err != nil {
+			return err
+		}
+	}
+	for _, statefulSet := range statefulSets.Items {
+		if err := r.scaleStatefulSet(ctx, &statefulSet, 0); err != nil {
+			return err
+		}
+	}
+
+	if err := r.setPhase(ctx, cluster, kubermaticv1.HibernationPhaseHibernated); err != nil {
+		return err
+	}
+	r.recorder.Event(cluster, corev1.EventTypeNormal, "Hibernated", "Scaled the nodes and the control plane to zero")
+	return nil
+}
+
+// resume restores the recorded replicas of the control plane and then of the MachineDeployments.
+func (r *Reconciler) resume(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	ready, err := r.resumeControlPlane(ctx, cluster)
+	if err != nil || !ready {
+		return nil, err
+	}
+
+	status := cluster.Status.Hibernation
+	if len(status.MachineDeployments) > 0 {
+		userClusterClient, err := r.userClusterConnectionProvider.GetClient(cluster)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get usercluster client: %v", err)
+		}
+		machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+		if err := userClusterClient.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+			return nil, fmt.Errorf("failed to list MachineDeployments: %v", err)
+		}
+		for _, md := range machineDeployments.Items {
+			replicas, recorded := status.MachineDeployments[md.Name]
+			if !recorded || machineDeploymentReplicas(&md) == replicas {
+				continue
+			}
+			oldMD := md.DeepCopy()
+			md.Spec.Replicas = utilpointer.Int32Ptr(replicas)
+			if err := userClusterClient.Patch(ctx, &md, ctrlruntimeclient.MergeFrom(oldMD)); err != nil {
+				return nil, fmt.Errorf("failed to scale up MachineDeployment %s: %v", md.Name, err)
+			}
+		}
+	}
+
+	oldCluster := cluster.DeepCopy()
+	cluster.Status.Hibernation = nil
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return nil, fmt.Errorf("failed to update cluster status: %v", err)
+	}
+	r.recorder.Event(cluster, corev1.EventTypeNormal, "Resumed", "Restored the replicas of the control plane and the nodes")
+	return nil, nil
+}
+
+// resumeControlPlane restores the recorded replicas of the control plane: etcd first, then the apiserver
+// and then the remaining components. It returns true once etcd and the apiserver are ready.
+func (r *Reconciler) resumeControlPlane(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, error) {
+	status := cluster.Status.Hibernation
+
+	etcd := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: cluster.Status.NamespaceName, Name: resources.EtcdStatefulSetName}, etcd); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get etcd StatefulSet: %v", err)
+		}
+		etcd = nil
+	}
+	if etcd != nil {
+		if replicas, recorded := status.StatefulSets[etcd.Name]; recorded {
+			if err := r.scaleStatefulSet(ctx, etcd, replicas); err != nil {
+				return false, err
+			}
+			if etcd.Status.ReadyReplicas < replicas {
+				// We get triggered again by the watch on the StatefulSet
+				return false, nil
+			}
+		}
+	}
+
+	apiserver := &appsv1.Deployment{}
+	if err := r.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: cluster.Status.NamespaceName, Name: resources.ApiserverDeploymentName}, apiserver); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get apiserver Deployment: %v", err)
+		}
+		apiserver = nil
+	}
+	if apiserver != nil {
+		if replicas, recorded := status.Deployments[apiserver.Name]; recorded {
+			if err := r.scaleDeployment(ctx, apiserver, replicas); err != nil {
+				return false, err
+			}
+			if apiserver.Status.AvailableReplicas < replicas {
+				return false, nil
+			}
+		}
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return false, fmt.Errorf("failed to list Deployments: %v", err)
+	}
+	for _, deployment := range deployments.Items {
+		if replicas, recorded := status.Deployments[deployment.Name]; recorded {
+			if err := r.scaleDeployment(ctx, &deployment, replicas); err != nil {
+				return false, err
+			}
+		}
+	}
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := r.List(ctx, statefulSets, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return false, fmt.Errorf("failed to list StatefulSets: %v", err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		if replicas, recorded := status.StatefulSets[statefulSet.Name]; recorded {
+			if err := r.scaleStatefulSet(ctx, &statefulSet, replicas); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	return true, nil
+}
+
+func (r *Reconciler) setPhase(ctx context.Context, cluster *kubermaticv1.Cluster, phase kubermaticv1.HibernationPhase) error {
+	oldCluster := cluster.DeepCopy()
+	cluster.Status.Hibernation.Phase = phase
+	cluster.Status.Hibernation.LastTransitionTime = metav1.NewTime(r.now())
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to set hibernation phase to %s: %v", phase, err)
+	}
+	return nil
+}
+
+func (r *Reconciler) scaleDeployment(ctx context.Context, deployment *appsv1.Deployment, replicas int32) error {
+	if deploymentReplicas(deployment) == replicas {
+		return nil
+	}
+	oldDeployment := deployment.DeepCopy()
+	deployment.Spec.Replicas = utilpointer.Int32Ptr(replicas)
+	if err := r.Patch(ctx, deployment, ctrlruntimeclient.MergeFrom(oldDeployment)); err != nil {
+		return fmt.Errorf("failed to scale Deployment %s to %d: %v", deployment.Name, replicas, err)
+	}
+	return nil
+}
+
+func (r *Reconciler) scaleStatefulSet(ctx context.Context, statefulSet *appsv1.StatefulSet, replicas int32) error {
+	if statefulSetReplicas(statefulSet) == replicas {
+		return nil
+	}
+	oldStatefulSet := statefulSet.DeepCopy()
+	statefulSet.Spec.Replicas = utilpointer.Int32Ptr(replicas)
+	if err := r.Patch(ctx, statefulSet, ctrlruntimeclient.MergeFrom(oldStatefulSet)); err != nil {
+		return fmt.Errorf("failed to scale StatefulSet %s to %d: %v", statefulSet.Name, replicas, err)
+	}
+	return nil
+}
+
+// The replicas of all scalable resources default to one
+func machineDeploymentReplicas(md *clusterv1alpha1.MachineDeployment) int32 {
+	if md.Spec.Replicas == nil {
+		return 1
+	}
+	return *md.Spec.Replicas
+}
+
+func deploymentReplicas(deployment *appsv1.Deployment) int32 {
+	if deployment.Spec.Replicas == nil {
+		return 1
+	}
+	return *deployment.Spec.Replicas
+}
+
+func statefulSetReplicas(statefulSet *appsv1.StatefulSet) int32 {
+	if statefulSet.Spec.Replicas == nil {
+		return 1
+	}
+	return *statefulSet.Spec.Replicas
+}
@@ -0,0 +1,481 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hibernation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	k8cuserclusterclient "github.com/kubermatic/kubermatic/pkg/cluster/client"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	kubermaticlog "github.com/kubermatic/kubermatic/pkg/log"
+	"github.com/kubermatic/kubermatic/pkg/resources"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	utilpointer "k8s.io/utils/pointer"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	clusterName   = "test-cluster"
+	namespaceName = "cluster-test-cluster"
+)
+
+func init() {
+	if err := clusterv1alpha1.SchemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+		panic(fmt.Sprintf("failed to add clusterv1alpha1 to scheme: %v", err))
+	}
+}
+
+type fakeUserClusterConnectionProvider struct {
+	client ctrlruntimeclient.Client
+	err    error
+}
+
+func (f *fakeUserClusterConnectionProvider) GetClient(*kubermaticv1.Cluster, ...k8cuserclusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
+	return f.client, f.err
+}
+
+func TestHibernationDesired(t *testing.T) {
+	// 2020-06-03 is a Wednesday
+	now := time.Date(2020, 6, 3, 20, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name               string
+		settings           *kubermaticv1.HibernationSettings
+		expectedHibernate  bool
+		expectedNextChange time.Duration
+		expectedErr        bool
+	}{
+		{
+			name: "no settings",
+		},
+		{
+			name:              "enabled without schedules",
+			settings:          &kubermaticv1.HibernationSettings{Enabled: true},
+			expectedHibernate: true,
+		},
+		{
+			name: "inside of a daily schedule",
+			settings: &kubermaticv1.HibernationSettings{
+				Schedules: []kubermaticv1.HibernationSchedule{{Start: "19:00", Length: "12h"}},
+			},
+			expectedHibernate:  true,
+			expectedNextChange: 11 * time.Hour,
+		},
+		{
+			name: "outside of a daily schedule",
+			settings: &kubermaticv1.HibernationSettings{
+				Schedules: []kubermaticv1.HibernationSchedule{{Start: "22:30", Length: "8h"}},
+			},
+			expectedNextChange: 150 * time.Minute,
+		},
+		{
+			name: "schedules are evaluated in the time zone",
+			settings: &kubermaticv1.HibernationSettings{
+				// 20:00 UTC is 22:00 in Berlin during summer time
+				TimeZone:  "Europe/Berlin",
+				Schedules: []kubermaticv1.HibernationSchedule{{Start: "21:00", Length: "10h"}},
+			},
+			expectedHibernate:  true,
+			expectedNextChange: 9 * time.Hour,
+		},
+		{
+			name: "weekly schedule over the weekend",
+			settings: &kubermaticv1.HibernationSettings{
+				Schedules: []kubermaticv1.HibernationSchedule{{Start: "Fri 19:00", Length: "60h"}},
+			},
+			expectedNextChange: 47 * time.Hour,
+		},
+		{
+			name: "the earliest change of all schedules is returned",
+			settings: &kubermaticv1.HibernationSettings{
+				Schedules: []kubermaticv1.HibernationSchedule{
+					{Start: "Fri 19:00", Length: "60h"},
+					{Start: "19:00", Length: "12h"},
+				},
+			},
+			expectedHibernate:  true,
+			expectedNextChange: 11 * time.Hour,
+		},
+		{
+			name: "invalid time zone",
+			settings: &kubermaticv1.HibernationSettings{
+				TimeZone: "Mars/Olympus",
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hibernate, nextChange, err := HibernationDesired(tc.settings, now)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error %t, got %v", tc.expectedErr, err)
+			}
+			if hibernate != tc.expectedHibernate {
+				t.Errorf("expected hibernate to be %t, got %t", tc.expectedHibernate, hibernate)
+			}
+			if nextChange != tc.expectedNextChange {
+				t.Errorf("expected next change in %v, got %v", tc.expectedNextChange, nextChange)
+			}
+		})
+	}
+}
+
+func TestHibernateAndResume(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+		Spec: kubermaticv1.ClusterSpec{
+			Hibernation: &kubermaticv1.HibernationSettings{Enabled: true},
+		},
+		Status: kubermaticv1.ClusterStatus{NamespaceName: namespaceName},
+	}
+	seedClient := ctrlruntimefakeclient.NewFakeClient(
+		cluster,
+		genStatefulSet(resources.EtcdStatefulSetName, 3),
+		genDeployment(resources.ApiserverDeploymentName, 2),
+		genDeployment(resources.MachineControllerDeploymentName, 1),
+	)
+	userClusterClient := ctrlruntimefakeclient.NewFakeClient(
+		genMachineDeployment("workers", 3),
+		genMachineDeployment("gpu", 0),
+		&clusterv1alpha1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "workers-abc", Namespace: metav1.NamespaceSystem}},
+	)
+	r := &Reconciler{
+		Client:                        seedClient,
+		log:                           kubermaticlog.Logger,
+		recorder:                      record.NewFakeRecorder(10),
+		userClusterConnectionProvider: &fakeUserClusterConnectionProvider{client: userClusterClient},
+		now:                           time.Now,
+	}
+
+	reconcile := func() {
+		t.Helper()
+		cluster := &kubermaticv1.Cluster{}
+		if err := seedClient.Get(ctx, ctrlruntimeclient.ObjectKey{Name: clusterName}, cluster); err != nil {
+			t.Fatalf("failed to get cluster: %v", err)
+		}
+		if _, err := r.reconcile(ctx, cluster); err != nil {
+			t.Fatalf("failed to reconcile: %v", err)
+		}
+	}
+
+	// The MachineDeployments get recorded and scaled down, the control plane stays up until the machines are gone
+	reconcile()
+	reconcile()
+	status := getCluster(t, seedClient).Status.Hibernation
+	if status == nil || status.Phase != kubermaticv1.HibernationPhaseHibernating {
+		t.Fatalf("expected cluster to be hibernating, got %+v", status)
+	}
+	if status.MachineDeployments["workers"] != 3 || status.MachineDeployments["gpu"] != 0 {
+		t.Errorf("expected the MachineDeployment replicas to be recorded, got %v", status.MachineDeployments)
+	}
+	expectMachineDeploymentReplicas(t, userClusterClient, "workers", 0)
+	expectDeploymentReplicas(t, seedClient, resources.ApiserverDeploymentName, 2)
+
+	if err := userClusterClient.Delete(ctx, &clusterv1alpha1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "workers-abc", Namespace: metav1.NamespaceSystem}}); err != nil {
+		t.Fatalf("failed to delete machine: %v", err)
+	}
+	reconcile()
+	status = getCluster(t, seedClient).Status.Hibernation
+	if status.Phase != kubermaticv1.HibernationPhaseHibernated {
+		t.Fatalf("expected cluster to be hibernated, got %q", status.Phase)
+	}
+	expectStatefulSetReplicas(t, seedClient, resources.EtcdStatefulSetName, 0)
+	expectDeploymentReplicas(t, seedClient, resources.ApiserverDeploymentName, 0)
+	expectDeploymentReplicas(t, seedClient, resources.MachineControllerDeploymentName, 0)
+
+	// Resuming starts with etcd and waits for it before scaling up the apiserver
+	hibernatedCluster := getCluster(t, seedClient)
+	hibernatedCluster.Spec.Hibernation.Enabled = false
+	if err := seedClient.Update(ctx, hibernatedCluster); err != nil {
+		t.Fatalf("failed to update cluster: %v", err)
+	}
+	reconcile()
+	reconcile()
+	if phase := getCluster(t, seedClient).Status.Hibernation.Phase; phase != kubermaticv1.HibernationPhaseResuming {
+		t.Fatalf("expected cluster to be resuming, got %q", phase)
+	}
+	expectStatefulSetReplicas(t, seedClient, resources.EtcdStatefulSetName, 3)
+	expectDeploymentReplicas(t, seedClient, resources.ApiserverDeploymentName, 0)
+
+	etcd := &appsv1.StatefulSet{}
+	if err := seedClient.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: namespaceName, Name: resources.EtcdStatefulSetName}, etcd); err != nil {
+		t.Fatalf("failed to get etcd: %v", err)
+	}
+	etcd.Status.ReadyReplicas = 3
+	if err := seedClient.Update(ctx, etcd); err != nil {
+		t.Fatalf("failed to update etcd: %v", err)
+	}
+	reconcile()
+	expectDeploymentReplicas(t, seedClient, resources.ApiserverDeploymentName, 2)
+	expectDeploymentReplicas(t, seedClient, resources.MachineControllerDeploymentName, 0)
+
+	apiserver := &appsv1.Deployment{}
+	if err := seedClient.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: namespaceName, Name: resources.ApiserverDeploymentName}, apiserver); err != nil {
+		t.Fatalf("failed to get apiserver: %v", err)
+	}
+	apiserver.Status.AvailableReplicas = 2
+	if err := seedClient.Update(ctx, apiserver); err != nil {
+		t.Fatalf("failed to update apiserver: %v", err)
+	}
+	reconcile()
+	expectDeploymentReplicas(t, seedClient, resources.MachineControllerDeploymentName, 1)
+	expectMachineDeploymentReplicas(t, userClusterClient, "workers", 3)
+	expectMachineDeploymentReplicas(t, userClusterClient, "gpu", 0)
+	if status := getCluster(t, seedClient).Status.Hibernation; status != nil {
+		t.Errorf("expected the hibernation status to be removed after resuming, got %+v", status)
+	}
+}
+
+func TestHibernateDuringResume(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+		Spec: kubermaticv1.ClusterSpec{
+			Hibernation: &kubermaticv1.HibernationSettings{Enabled: true},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: namespaceName,
+			Hibernation: &kubermaticv1.HibernationStatus{
+				Phase:              kubermaticv1.HibernationPhaseResuming,
+				MachineDeployments: map[string]int32{"workers": 3},
+				Deployments: map[string]int32{
+					resources.ApiserverDeploymentName:         2,
+					resources.MachineControllerDeploymentName: 1,
+				},
+				StatefulSets: map[string]int32{resources.EtcdStatefulSetName: 3},
+			},
+		},
+	}
+	seedClient := ctrlruntimefakeclient.NewFakeClient(
+		cluster,
+		genStatefulSet(resources.EtcdStatefulSetName, 0),
+		genDeployment(resources.ApiserverDeploymentName, 0),
+		genDeployment(resources.MachineControllerDeploymentName, 0),
+	)
+	// The user cluster is unreachable until the apiserver is back
+	connectionProvider := &fakeUserClusterConnectionProvider{err: errors.New("connection refused")}
+	r := &Reconciler{
+		Client:                        seedClient,
+		log:                           kubermaticlog.Logger,
+		recorder:                      record.NewFakeRecorder(10),
+		userClusterConnectionProvider: connectionProvider,
+		now:                           time.Now,
+	}
+
+	reconcile := func() {
+		t.Helper()
+		if _, err := r.reconcile(ctx, getCluster(t, seedClient)); err != nil {
+			t.Fatalf("failed to reconcile: %v", err)
+		}
+	}
+
+	// The control plane gets resumed before the cluster is hibernated again
+	reconcile()
+	if phase := getCluster(t, seedClient).Status.Hibernation.Phase; phase != kubermaticv1.HibernationPhaseResuming {
+		t.Fatalf("expected cluster to keep resuming, got %q", phase)
+	}
+	expectStatefulSetReplicas(t, seedClient, resources.EtcdStatefulSetName, 3)
+	expectDeploymentReplicas(t, seedClient, resources.ApiserverDeploymentName, 0)
+
+	etcd := &appsv1.StatefulSet{}
+	if err := seedClient.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: namespaceName, Name: resources.EtcdStatefulSetName}, etcd); err != nil {
+		t.Fatalf("failed to get etcd: %v", err)
+	}
+	etcd.Status.ReadyReplicas = 3
+	if err := seedClient.Update(ctx, etcd); err != nil {
+		t.Fatalf("failed to update etcd: %v", err)
+	}
+	reconcile()
+	expectDeploymentReplicas(t, seedClient, resources.ApiserverDeploymentName, 2)
+
+	apiserver := &appsv1.Deployment{}
+	if err := seedClient.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: namespaceName, Name: resources.ApiserverDeploymentName}, apiserver); err != nil {
+		t.Fatalf("failed to get apiserver: %v", err)
+	}
+	apiserver.Status.AvailableReplicas = 2
+	if err := seedClient.Update(ctx, apiserver); err != nil {
+		t.Fatalf("failed to update apiserver: %v", err)
+	}
+	reconcile()
+	if phase := getCluster(t, seedClient).Status.Hibernation.Phase; phase != kubermaticv1.HibernationPhaseHibernating {
+		t.Fatalf("expected cluster to be hibernating, got %q", phase)
+	}
+	expectDeploymentReplicas(t, seedClient, resources.MachineControllerDeploymentName, 1)
+
+	// With the apiserver up, the machines get deleted and the control plane scaled down again
+	userClusterClient := ctrlruntimefakeclient.NewFakeClient(genMachineDeployment("workers", 0))
+	connectionProvider.client, connectionProvider.err = userClusterClient, nil
+	reconcile()
+	status := getCluster(t, seedClient).Status.Hibernation
+	if status.Phase != kubermaticv1.HibernationPhaseHibernated {
+		t.Fatalf("expected cluster to be hibernated, got %q", status.Phase)
+	}
+	if status.MachineDeployments["workers"] != 3 {
+		t.Errorf("expected the recorded MachineDeployment replicas to be kept, got %v", status.MachineDeployments)
+	}
+	expectDeploymentReplicas(t, seedClient, resources.ApiserverDeploymentName, 0)
+}
+
+func TestHibernateWithScaledDownControlPlane(t *testing.T) {
+	ctx := context.Background()
+
+	// The control plane was scaled down, but the phase could not be updated afterwards
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+		Spec: kubermaticv1.ClusterSpec{
+			Hibernation: &kubermaticv1.HibernationSettings{Enabled: true},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: namespaceName,
+			Hibernation: &kubermaticv1.HibernationStatus{
+				Phase:              kubermaticv1.HibernationPhaseHibernating,
+				MachineDeployments: map[string]int32{"workers": 3},
+				Deployments:        map[string]int32{resources.ApiserverDeploymentName: 2},
+				StatefulSets:       map[string]int32{resources.EtcdStatefulSetName: 3},
+			},
+		},
+	}
+	seedClient := ctrlruntimefakeclient.NewFakeClient(
+		cluster,
+		genStatefulSet(resources.EtcdStatefulSetName, 0),
+		genDeployment(resources.ApiserverDeploymentName, 0),
+	)
+	r := &Reconciler{
+		Client:                        seedClient,
+		log:                           kubermaticlog.Logger,
+		recorder:                      record.NewFakeRecorder(10),
+		userClusterConnectionProvider: &fakeUserClusterConnectionProvider{err: errors.New("connection refused")},
+		now:                           time.Now,
+	}
+
+	if _, err := r.reconcile(ctx, getCluster(t, seedClient)); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	status := getCluster(t, seedClient).Status.Hibernation
+	if status.Phase != kubermaticv1.HibernationPhaseHibernated {
+		t.Fatalf("expected cluster to be hibernated, got %q", status.Phase)
+	}
+	if status.MachineDeployments["workers"] != 3 || status.Deployments[resources.ApiserverDeploymentName] != 2 {
+		t.Errorf("expected the recorded replicas to be kept, got %+v", status)
+	}
+}
+
+func TestOpenshiftClustersAreNotHibernated(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        clusterName,
+			Annotations: map[string]string{"kubermatic.io/openshift": "true"},
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Hibernation: &kubermaticv1.HibernationSettings{
+				Schedules: []kubermaticv1.HibernationSchedule{{Start: "00:00", Length: "23h59m"}},
+			},
+		},
+		Status: kubermaticv1.ClusterStatus{NamespaceName: namespaceName},
+	}
+	seedClient := ctrlruntimefakeclient.NewFakeClient(cluster, genDeployment(resources.ApiserverDeploymentName, 2))
+	r := &Reconciler{
+		Client:                        seedClient,
+		log:                           kubermaticlog.Logger,
+		recorder:                      record.NewFakeRecorder(10),
+		userClusterConnectionProvider: &fakeUserClusterConnectionProvider{client: ctrlruntimefakeclient.NewFakeClient()},
+		now:                           func() time.Time { return time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC) },
+	}
+
+	if _, err := r.reconcile(ctx, getCluster(t, seedClient)); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	if status := getCluster(t, seedClient).Status.Hibernation; status != nil {
+		t.Errorf("expected openshift cluster not to be hibernated, got %+v", status)
+	}
+	expectDeploymentReplicas(t, seedClient, resources.ApiserverDeploymentName, 2)
+}
+
+func getCluster(t *testing.T, client ctrlruntimeclient.Client) *kubermaticv1.Cluster {
+	cluster := &kubermaticv1.Cluster{}
+	if err := client.Get(context.Background(), ctrlruntimeclient.ObjectKey{Name: clusterName}, cluster); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	return cluster
+}
+
+func expectMachineDeploymentReplicas(t *testing.T, client ctrlruntimeclient.Client, name string, expected int32) {
+	md := &clusterv1alpha1.MachineDeployment{}
+	if err := client.Get(context.Background(), ctrlruntimeclient.ObjectKey{Namespace: metav1.NamespaceSystem, Name: name}, md); err != nil {
+		t.Fatalf("failed to get MachineDeployment %s: %v", name, err)
+	}
+	if replicas := machineDeploymentReplicas(md); replicas != expected {
+		t.Errorf("expected MachineDeployment %s to have %d replicas, got %d", name, expected, replicas)
+	}
+}
+
+func expectDeploymentReplicas(t *testing.T, client ctrlruntimeclient.Client, name string, expected int32) {
+	deployment := &appsv1.Deployment{}
+	if err := client.Get(context.Background(), ctrlruntimeclient.ObjectKey{Namespace: namespaceName, Name: name}, deployment); err != nil {
+		t.Fatalf("failed to get Deployment %s: %v", name, err)
+	}
+	if replicas := deploymentReplicas(deployment); replicas != expected {
+		t.Errorf("expected Deployment %s to have %d replicas, got %d", name, expected, replicas)
+	}
+}
+
+func expectStatefulSetReplicas(t *testing.T, client ctrlruntimeclient.Client, name string, expected int32) {
+	statefulSet := &appsv1.StatefulSet{}
+	if err := client.Get(context.Background(), ctrlruntimeclient.ObjectKey{Namespace: namespaceName, Name: name}, statefulSet); err != nil {
+		t.Fatalf("failed to get StatefulSet %s: %v", name, err)
+	}
+	if replicas := statefulSetReplicas(statefulSet); replicas != expected {
+		t.Errorf("expected StatefulSet %s to have %d replicas, got %d", name, expected, replicas)
+	}
+}
+
+func genMachineDeployment(name string, replicas int32) *clusterv1alpha1.MachineDeployment {
+	return &clusterv1alpha1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem},
+		Spec:       clusterv1alpha1.MachineDeploymentSpec{Replicas: utilpointer.Int32Ptr(replicas)},
+	}
+}
+
+func genDeployment(name string, replicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespaceName},
+		Spec:       appsv1.DeploymentSpec{Replicas: utilpointer.Int32Ptr(replicas)},
+	}
+}
+
+func genStatefulSet(name string, replicas int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespaceName},
+		Spec:       appsv1.StatefulSetSpec{Replicas: utilpointer.Int32Ptr(replicas)},
+	}
+}
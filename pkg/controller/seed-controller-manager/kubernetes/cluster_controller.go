@@ -26,13 +26,13 @@ import (
 
 	k8cuserclusterclient "github.com/kubermatic/kubermatic/pkg/cluster/client"
 	"github.com/kubermatic/kubermatic/pkg/clusterdeletion"
+	"github.com/kubermatic/kubermatic/pkg/collectors"
 	controllerutil "github.com/kubermatic/kubermatic/pkg/controller/util"
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	kubermaticv1helper "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1/helper"
 	"github.com/kubermatic/kubermatic/pkg/provider"
 
 	appsv1 "k8s.io/api/apps/v1"
-	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -44,8 +44,10 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -174,7 +176,6 @@ func Add(
 		&corev1.Namespace{},
 		&appsv1.StatefulSet{},
 		&appsv1.Deployment{},
-		&batchv1beta1.CronJob{},
 		&policyv1beta1.PodDisruptionBudget{},
 		&autoscalingv1beta2.VerticalPodAutoscaler{},
 		&rbacv1.Role{},
@@ -187,7 +188,20 @@ func Add(
 		}
 	}
 
-	return c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, &handler.EnqueueRequestForObject{})
+	return c.Watch(&source.Kind{Type: &kubermaticv1.Cluster{}}, &handler.EnqueueRequestForObject{}, observeClusterDeletedPredicate(workerName))
+}
+
+// observeClusterDeletedPredicate records the deletion duration of clusters. A cluster is removed once its
+// last cleanup finalizer got removed, which can happen in any of the controllers owning one of the finalizers
+func observeClusterDeletedPredicate(workerName string) predicate.Funcs {
+	return predicate.Funcs{
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			if cluster, ok := e.Object.(*kubermaticv1.Cluster); ok && cluster.Labels[kubermaticv1.WorkerNameLabelKey] == workerName {
+				collectors.ObserveClusterDeleted(cluster)
+			}
+			return true
+		},
+	}
 }
 
 func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
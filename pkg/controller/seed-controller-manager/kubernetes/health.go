@@ -23,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	"github.com/kubermatic/kubermatic/pkg/collectors"
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	kubermaticv1helper "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1/helper"
 	"github.com/kubermatic/kubermatic/pkg/provider/kubernetes"
@@ -59,7 +60,8 @@ func (r *Reconciler) clusterHealth(ctx context.Context, cluster *kubermaticv1.Cl
 	var err error
 	key := types.NamespacedName{Namespace: ns, Name: resources.EtcdStatefulSetName}
 
-	etcdHealthStatus, err := resources.HealthyStatefulSet(ctx, r, key, 2)
+	// etcd is only usable while a quorum of its members is available
+	etcdHealthStatus, err := resources.HealthyStatefulSet(ctx, r, key, int32(resources.GetEtcdQuorumSize(cluster)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get etcd health: %v", err)
 	}
@@ -93,6 +95,9 @@ func (r *Reconciler) syncHealth(ctx context.Context, cluster *kubermaticv1.Clust
 				"Cluster has been initialized successfully",
 			)
 		})
+		if err == nil {
+			collectors.ObserveClusterInitialized(cluster)
+		}
 	}
 
 	return err
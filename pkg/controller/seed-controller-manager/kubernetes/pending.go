@@ -30,6 +30,9 @@ import (
 
 const (
 	reachableCheckPeriod = 5 * time.Second
+	// allowedSourceRangesSyncPeriod is the interval in which the addresses of new machines get
+	// added to the source ranges allowed to access the apiserver
+	allowedSourceRangesSyncPeriod = time.Minute
 )
 
 func (r *Reconciler) reconcileCluster(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
@@ -72,6 +75,10 @@ func (r *Reconciler) reconcileCluster(ctx context.Context, cluster *kubermaticv1
 
 	}
 
+	if len(cluster.Spec.APIServerAllowedSourceRanges) > 0 {
+		return &reconcile.Result{RequeueAfter: allowedSourceRangesSyncPeriod}, nil
+	}
+
 	return &reconcile.Result{}, nil
 }
 
@@ -39,14 +39,23 @@ import (
 	"github.com/kubermatic/kubermatic/pkg/resources/reconciling"
 	"github.com/kubermatic/kubermatic/pkg/resources/scheduler"
 	"github.com/kubermatic/kubermatic/pkg/resources/usercluster"
+	"github.com/kubermatic/kubermatic/pkg/validation"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	// The size of the etcd cluster is used by the services and certificates as well, so it must be
+	// validated before any of them gets reconciled
+	if err := r.validateEtcdClusterSize(ctx, cluster); err != nil {
+		return err
+	}
+
 	seed, err := r.seedGetter()
 	if err != nil {
 		return err
@@ -108,13 +117,18 @@ func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *ku
 		return err
 	}
 
+	// check that all PersistentVolumeClaims are created
+	if err := r.ensurePersistentVolumeClaims(ctx, cluster, data); err != nil {
+		return err
+	}
+
 	// check that all Deployments are available
 	if err := r.ensureDeployments(ctx, cluster, data); err != nil {
 		return err
 	}
 
-	// check that all CronJobs are created
-	if err := r.ensureCronJobs(ctx, cluster, data); err != nil {
+	// remove the etcd defragger of older versions, the etcd maintenance controller took over
+	if err := etcd.CleanupDefraggerCronJob(ctx, r, cluster.Status.NamespaceName); err != nil {
 		return err
 	}
 
@@ -137,6 +151,25 @@ func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *ku
 	return nil
 }
 
+// validateEtcdClusterSize rejects invalid etcd cluster sizes and scaling etcd down. The API validates
+// the size as well, but it can be changed by editing the cluster directly.
+func (r *Reconciler) validateEtcdClusterSize(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	var currentSize int
+	set := &appsv1.StatefulSet{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.EtcdStatefulSetName}, set)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get the etcd StatefulSet: %v", err)
+	}
+	if err == nil && set.Spec.Replicas != nil {
+		currentSize = int(*set.Spec.Replicas)
+	}
+
+	if err := validation.ValidateEtcdClusterSize(cluster.Spec.ComponentsOverride.Etcd.ClusterSize, currentSize); err != nil {
+		return fmt.Errorf("invalid etcd cluster size: %v", err)
+	}
+	return nil
+}
+
 func (r *Reconciler) getClusterTemplateData(ctx context.Context, cluster *kubermaticv1.Cluster, seed *kubermaticv1.Seed) (*resources.TemplateData, error) {
 	datacenter, found := seed.Spec.Datacenters[cluster.Spec.Cloud.DatacenterName]
 	if !found {
@@ -203,10 +236,10 @@ func (r *Reconciler) ensureNamespaceExists(ctx context.Context, cluster *kuberma
 }
 
 // GetServiceCreators returns all service creators that are currently in use
-func GetServiceCreators(data *resources.TemplateData) []reconciling.NamedServiceCreatorGetter {
+func GetServiceCreators(data *resources.TemplateData, apiserverAllowedSourceRanges []string) []reconciling.NamedServiceCreatorGetter {
 	creators := []reconciling.NamedServiceCreatorGetter{
 		apiserver.InternalServiceCreator(),
-		apiserver.ExternalServiceCreator(data.Cluster().Spec.ExposeStrategy),
+		apiserver.ExternalServiceCreator(data.Cluster().Spec.ExposeStrategy, data.Cluster().Address.ExternalName, apiserverAllowedSourceRanges),
 		openvpn.ServiceCreator(data.Cluster().Spec.ExposeStrategy),
 		etcd.ServiceCreator(data),
 		dns.ServiceCreator(),
@@ -215,7 +248,7 @@ func GetServiceCreators(data *resources.TemplateData) []reconciling.NamedService
 	}
 
 	if data.Cluster().Spec.ExposeStrategy == corev1.ServiceTypeLoadBalancer {
-		creators = append(creators, nodeportproxy.FrontLoadBalancerServiceCreator())
+		creators = append(creators, nodeportproxy.FrontLoadBalancerServiceCreator(apiserverAllowedSourceRanges))
 	}
 	if flag := data.Cluster().Spec.Features[kubermaticv1.ClusterFeatureRancherIntegration]; flag {
 		creators = append(creators, rancherserver.ServiceCreator(data.Cluster().Spec.ExposeStrategy))
@@ -224,7 +257,14 @@ func GetServiceCreators(data *resources.TemplateData) []reconciling.NamedService
 }
 
 func (r *Reconciler) ensureServices(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
-	creators := GetServiceCreators(data)
+	allowedSourceRanges, err := apiserver.GetAllowedSourceRanges(ctx, r.log.With("cluster", c.Name), r, func() (ctrlruntimeclient.Client, error) {
+		return r.userClusterConnProvider.GetClient(c)
+	}, data.Seed(), c)
+	if err != nil {
+		return fmt.Errorf("failed to get the source ranges allowed to access the apiserver: %v", err)
+	}
+
+	creators := GetServiceCreators(data, allowedSourceRanges)
 	return reconciling.ReconcileServices(ctx, creators, c.Status.NamespaceName, r, reconciling.OwnerRefWrapper(resources.GetClusterRef(c)))
 }
 
@@ -254,7 +294,10 @@ func GetDeploymentCreators(data *resources.TemplateData, enableAPIserverOIDCAuth
 
 func (r *Reconciler) ensureDeployments(ctx context.Context, cluster *kubermaticv1.Cluster, data *resources.TemplateData) error {
 	creators := GetDeploymentCreators(data, r.features.KubernetesOIDCAuthentication)
-	return reconciling.ReconcileDeployments(ctx, creators, cluster.Status.NamespaceName, r, reconciling.OwnerRefWrapper(resources.GetClusterRef(cluster)))
+	if err := reconciling.ReconcileDeployments(ctx, creators, cluster.Status.NamespaceName, r, reconciling.OwnerRefWrapper(resources.GetClusterRef(cluster))); err != nil {
+		return err
+	}
+	return clusterautoscaler.CleanupDeployment(ctx, r, cluster)
 }
 
 // GetSecretCreators returns all SecretCreators that are currently in use
@@ -356,7 +399,7 @@ func GetConfigMapCreators(data *resources.TemplateData) []reconciling.NamedConfi
 		cloudconfig.ConfigMapCreator(data),
 		openvpn.ServerClientConfigsConfigMapCreator(data),
 		dns.ConfigMapCreator(data),
-		apiserver.AuditConfigMapCreator(),
+		apiserver.AuditConfigMapCreator(data),
 	}
 }
 
@@ -370,6 +413,31 @@ func (r *Reconciler) ensureConfigMaps(ctx context.Context, c *kubermaticv1.Clust
 	return nil
 }
 
+// GetPersistentVolumeClaimCreators returns all PersistentVolumeClaimCreators that are currently in use
+func GetPersistentVolumeClaimCreators(data *resources.TemplateData) []reconciling.NamedPersistentVolumeClaimCreatorGetter {
+	var creators []reconciling.NamedPersistentVolumeClaimCreatorGetter
+	if auditLogging := apiserver.AuditLoggingSettings(data); auditLogging != nil && auditLogging.Sink != nil && auditLogging.Sink.File != nil {
+		creators = append(creators, apiserver.AuditSinkPVCCreator(auditLogging.Sink.File))
+	}
+	return creators
+}
+
+func (r *Reconciler) ensurePersistentVolumeClaims(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
+	creators := GetPersistentVolumeClaimCreators(data)
+
+	if err := reconciling.ReconcilePersistentVolumeClaims(ctx, creators, c.Status.NamespaceName, r.Client, reconciling.OwnerRefWrapper(resources.GetClusterRef(c))); err != nil {
+		return fmt.Errorf("failed to ensure that the PersistentVolumeClaims exist: %v", err)
+	}
+
+	if auditLogging := apiserver.AuditLoggingSettings(data); auditLogging == nil || auditLogging.Sink == nil || auditLogging.Sink.File == nil {
+		if err := apiserver.RemoveAuditSinkPVC(ctx, r.Client, c.Status.NamespaceName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetStatefulSetCreators returns all StatefulSetCreators that are currently in use
 func GetStatefulSetCreators(data *resources.TemplateData, enableDataCorruptionChecks bool) []reconciling.NamedStatefulSetCreatorGetter {
 	creators := []reconciling.NamedStatefulSetCreatorGetter{
@@ -401,23 +469,6 @@ func (r *Reconciler) ensurePodDisruptionBudgets(ctx context.Context, c *kubermat
 	return nil
 }
 
-// GetCronJobCreators returns all CronJobCreators that are currently in use
-func GetCronJobCreators(data *resources.TemplateData) []reconciling.NamedCronJobCreatorGetter {
-	return []reconciling.NamedCronJobCreatorGetter{
-		etcd.CronJobCreator(data),
-	}
-}
-
-func (r *Reconciler) ensureCronJobs(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
-	creators := GetCronJobCreators(data)
-
-	if err := reconciling.ReconcileCronJobs(ctx, creators, c.Status.NamespaceName, r.Client, reconciling.OwnerRefWrapper(resources.GetClusterRef(c))); err != nil {
-		return fmt.Errorf("failed to ensure that the CronJobs exists: %v", err)
-	}
-
-	return nil
-}
-
 func (r *Reconciler) ensureVerticalPodAutoscalers(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
 	controlPlaneDeploymentNames := []string{
 		resources.DNSResolverDeploymentName,
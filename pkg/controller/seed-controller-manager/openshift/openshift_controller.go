@@ -27,6 +27,7 @@ import (
 	kubermaticapiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
 	clusterclient "github.com/kubermatic/kubermatic/pkg/cluster/client"
 	"github.com/kubermatic/kubermatic/pkg/clusterdeletion"
+	"github.com/kubermatic/kubermatic/pkg/collectors"
 	openshiftresources "github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/openshift/resources"
 	controllerutil "github.com/kubermatic/kubermatic/pkg/controller/util"
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
@@ -49,7 +50,6 @@ import (
 	"github.com/kubermatic/kubermatic/pkg/resources/usercluster"
 
 	appsv1 "k8s.io/api/apps/v1"
-	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -156,7 +156,6 @@ func Add(
 		&corev1.Namespace{},
 		&appsv1.StatefulSet{},
 		&appsv1.Deployment{},
-		&batchv1beta1.CronJob{},
 		&policyv1beta1.PodDisruptionBudget{},
 		&autoscalingv1beta2.VerticalPodAutoscaler{},
 		&rbacv1.Role{},
@@ -291,6 +290,11 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 		}
 	}
 
+	// Pick up the addresses of new machines
+	if len(cluster.Spec.APIServerAllowedSourceRanges) > 0 {
+		return &reconcile.Result{RequeueAfter: time.Minute}, nil
+	}
+
 	return nil, nil
 }
 
@@ -331,7 +335,7 @@ func (r *Reconciler) syncHeath(ctx context.Context, cluster *kubermaticv1.Cluste
 		*healthMapping[name].healthStatus = kubermaticv1helper.GetHealthStatus(status, cluster)
 	}
 
-	status, err := resources.HealthyStatefulSet(ctx, r.Client, nn(cluster.Status.NamespaceName, resources.EtcdStatefulSetName), 2)
+	status, err := resources.HealthyStatefulSet(ctx, r.Client, nn(cluster.Status.NamespaceName, resources.EtcdStatefulSetName), int32(resources.GetEtcdQuorumSize(cluster)))
 	if err != nil {
 		return fmt.Errorf("failed to get etcd health: %v", err)
 	}
@@ -357,6 +361,9 @@ func (r *Reconciler) syncHeath(ctx context.Context, cluster *kubermaticv1.Cluste
 				"Cluster has been initialized successfully",
 			)
 		})
+		if err == nil {
+			collectors.ObserveClusterInitialized(cluster)
+		}
 	}
 
 	return err
@@ -488,21 +495,10 @@ func (r *Reconciler) getAllDeploymentCreators(ctx context.Context, osData *opens
 }
 
 func (r *Reconciler) deployments(ctx context.Context, osData *openshiftData) error {
-	return reconciling.ReconcileDeployments(ctx, r.getAllDeploymentCreators(ctx, osData), osData.Cluster().Status.NamespaceName, r.Client)
-}
-
-func GetCronJobCreators(osData *openshiftData) []reconciling.NamedCronJobCreatorGetter {
-	return []reconciling.NamedCronJobCreatorGetter{
-		etcd.CronJobCreator(osData),
-	}
-}
-
-func (r *Reconciler) cronJobs(ctx context.Context, osData *openshiftData) error {
-	creators := GetCronJobCreators(osData)
-	if err := reconciling.ReconcileCronJobs(ctx, creators, osData.Cluster().Status.NamespaceName, r.Client); err != nil {
-		return fmt.Errorf("failed to ensure that the CronJobs exists: %v", err)
+	if err := reconciling.ReconcileDeployments(ctx, r.getAllDeploymentCreators(ctx, osData), osData.Cluster().Status.NamespaceName, r.Client); err != nil {
+		return err
 	}
-	return nil
+	return clusterautoscaler.CleanupDeployment(ctx, r.Client, osData.Cluster())
 }
 
 func GetPodDisruptionBudgetCreators(osData *openshiftData) []reconciling.NamedPodDisruptionBudgetCreatorGetter {
@@ -635,10 +631,10 @@ func (r *Reconciler) networkDefaults(ctx context.Context, cluster *kubermaticv1.
 }
 
 // GetServiceCreators returns all service creators that are currently in use
-func getAllServiceCreators(osData *openshiftData) []reconciling.NamedServiceCreatorGetter {
+func getAllServiceCreators(osData *openshiftData, apiserverAllowedSourceRanges []string) []reconciling.NamedServiceCreatorGetter {
 	creators := []reconciling.NamedServiceCreatorGetter{
 		apiserver.InternalServiceCreator(),
-		apiserver.ExternalServiceCreator(osData.Cluster().Spec.ExposeStrategy),
+		apiserver.ExternalServiceCreator(osData.Cluster().Spec.ExposeStrategy, osData.Cluster().Address.ExternalName, apiserverAllowedSourceRanges),
 		openshiftresources.OpenshiftAPIServiceCreator,
 		openvpn.ServiceCreator(osData.Cluster().Spec.ExposeStrategy),
 		etcd.ServiceCreator(osData),
@@ -649,14 +645,22 @@ func getAllServiceCreators(osData *openshiftData) []reconciling.NamedServiceCrea
 	}
 
 	if osData.Cluster().Spec.ExposeStrategy == corev1.ServiceTypeLoadBalancer {
-		creators = append(creators, nodeportproxy.FrontLoadBalancerServiceCreator())
+		creators = append(creators, nodeportproxy.FrontLoadBalancerServiceCreator(apiserverAllowedSourceRanges))
 	}
 
 	return creators
 }
 
 func (r *Reconciler) services(ctx context.Context, osData *openshiftData) error {
-	for _, namedServiceCreator := range getAllServiceCreators(osData) {
+	cluster := osData.Cluster()
+	allowedSourceRanges, err := apiserver.GetAllowedSourceRanges(ctx, r.log.With("cluster", cluster.Name), r.Client, func() (client.Client, error) {
+		return r.userClusterConnProvider.GetClient(cluster)
+	}, osData.Seed(), cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get the source ranges allowed to access the apiserver: %v", err)
+	}
+
+	for _, namedServiceCreator := range getAllServiceCreators(osData, allowedSourceRanges) {
 		serviceName, serviceCreator := namedServiceCreator()
 		if err := reconciling.EnsureNamedObject(ctx,
 			nn(osData.Cluster().Status.NamespaceName, serviceName), reconciling.ServiceObjectWrapper(serviceCreator), r.Client, &corev1.Service{}, false); err != nil {
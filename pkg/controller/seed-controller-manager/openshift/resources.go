@@ -22,6 +22,7 @@ import (
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/resources"
+	"github.com/kubermatic/kubermatic/pkg/resources/etcd"
 	"github.com/kubermatic/kubermatic/pkg/resources/nodeportproxy"
 
 	corev1 "k8s.io/api/core/v1"
@@ -119,8 +120,9 @@ func (r *Reconciler) reconcileResources(ctx context.Context, cluster *kubermatic
 		}
 	}
 
-	if err := r.cronJobs(ctx, osData); err != nil {
-		return fmt.Errorf("failed to reconcile CronJobs: %v", err)
+	// The etcd maintenance controller took over the defragmentation
+	if err := etcd.CleanupDefraggerCronJob(ctx, r.Client, osData.Cluster().Status.NamespaceName); err != nil {
+		return err
 	}
 
 	if err := r.podDisruptionBudgets(ctx, osData); err != nil {
@@ -62,7 +62,7 @@ func OpenshiftAPIServerConfigMapCreator(data openshiftAPIServerCreatorData) reco
 			templateInput := struct {
 				ETCDEndpoints []string
 			}{
-				ETCDEndpoints: etcd.GetClientEndpoints(data.Cluster().Status.NamespaceName),
+				ETCDEndpoints: etcd.GetClientEndpoints(data.Cluster()),
 			}
 			if err := openshiftAPIServerTemplate.Execute(&apiServerConfigBuffer, templateInput); err != nil {
 				return nil, fmt.Errorf("failed to execute template: %v", err)
@@ -100,7 +100,7 @@ func OpenshiftKubeAPIServerConfigMapCreator(data masterConfigData) reconciling.N
 				PodCIDR:          podCIDR,
 				ServiceCIDR:      serviceCIDR,
 				ListenPort:       fmt.Sprint(data.Cluster().Address.Port),
-				ETCDEndpoints:    etcd.GetClientEndpoints(data.Cluster().Status.NamespaceName),
+				ETCDEndpoints:    etcd.GetClientEndpoints(data.Cluster()),
 				AdvertiseAddress: data.Cluster().Address.IP,
 				CloudProvider:    data.GetKubernetesCloudProviderName(),
 			}
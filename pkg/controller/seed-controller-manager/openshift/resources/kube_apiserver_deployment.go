@@ -149,7 +149,7 @@ func APIDeploymentCreator(ctx context.Context, data openshiftData) reconciling.N
 				},
 			}
 
-			etcdEndpoints := etcd.GetClientEndpoints(data.Cluster().Status.NamespaceName)
+			etcdEndpoints := etcd.GetClientEndpoints(data.Cluster())
 
 			// Configure user cluster DNS resolver for this pod.
 			dep.Spec.Template.Spec.DNSPolicy, dep.Spec.Template.Spec.DNSConfig, err = resources.UserClusterDNSPolicyAndConfig(data)
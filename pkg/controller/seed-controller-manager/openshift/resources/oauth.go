@@ -202,12 +202,12 @@ func OauthServiceCreator(exposeStrategy corev1.ServiceType) reconciling.NamedSer
 			if se.Annotations == nil {
 				se.Annotations = map[string]string{}
 			}
-			if exposeStrategy == corev1.ServiceTypeNodePort {
-				se.Annotations["nodeport-proxy.k8s.io/expose"] = "true"
-				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
-			} else {
+			if exposeStrategy == corev1.ServiceTypeLoadBalancer {
 				se.Annotations[nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey] = "true"
 				delete(se.Annotations, "nodeport-proxy.k8s.io/expose")
+			} else {
+				se.Annotations["nodeport-proxy.k8s.io/expose"] = "true"
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
 			}
 			se.Spec.Selector = map[string]string{
 				resources.AppLabelKey: OauthName,
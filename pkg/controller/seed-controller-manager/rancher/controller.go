@@ -17,31 +17,31 @@ limitations under the License.
 package rancher
 
 import (
-	"bytes"
 	"context"
 	"crypto/tls"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"math/rand"
 	"net/http"
-	"os"
-	"os/exec"
-	"path"
 	"strings"
 	"time"
 
 	"go.uber.org/zap"
 
+	clusterclient "github.com/kubermatic/kubermatic/pkg/cluster/client"
 	rancherclient "github.com/kubermatic/kubermatic/pkg/controller/seed-controller-manager/rancher/client"
 	predicateutil "github.com/kubermatic/kubermatic/pkg/controller/util/predicate"
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	kubermaticv1helper "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1/helper"
+	kuberneteshelper "github.com/kubermatic/kubermatic/pkg/kubernetes"
 	"github.com/kubermatic/kubermatic/pkg/resources"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	kubeapierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -57,15 +57,15 @@ const (
 	// keep the linter happy
 	// trueStr                   = "true"
 	rancherRandPasswordLength = 16
+	// fieldManager is the name we use for server-side apply of the rancher registration manifest
+	fieldManager = "kubermatic-rancher-controller"
 )
 
-// KubeconfigProvider provides functionality to get a clusters admin kubeconfig
+// KubeconfigProvider provides functionality to get a client for a user cluster
 type KubeconfigProvider interface {
-	GetAdminKubeconfig(c *kubermaticv1.Cluster) ([]byte, error)
+	GetClient(c *kubermaticv1.Cluster, options ...clusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
 }
 
-type fileHandlingDone func()
-
 type Reconciler struct {
 	log *zap.SugaredLogger
 	ctrlruntimeclient.Client
@@ -325,55 +325,31 @@ func (r *Reconciler) applyRancherRegstrationCommand(ctx context.Context, log *za
 		return fmt.Errorf("failed to get HTTP client: %v", err)
 	}
 	defer resp.Body.Close()
-	manifest, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read http response: %v", err)
-	}
-	kubeconfigFilename, kubeconfigDone, err := r.writeAdminKubeconfig(log, cluster)
-	if err != nil {
-		return fmt.Errorf("failed to write the admin kubeconfig to the local filesystem: %v", err)
-	}
-	defer kubeconfigDone()
-	cmd := getApplyCommand(ctx, kubeconfigFilename)
-	buffer := bytes.Buffer{}
 
-	buffer.Write(manifest)
-	cmd.Stdin = &buffer
-
-	_, err = cmd.CombinedOutput()
+	userClusterClient, err := r.KubeconfigProvider.GetClient(cluster)
 	if err != nil {
-		return fmt.Errorf("failed to run rancher kubectl apply command: %v", err)
+		return fmt.Errorf("failed to get client for usercluster: %v", err)
 	}
-	return nil
-}
 
-func (r *Reconciler) writeAdminKubeconfig(log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (string, fileHandlingDone, error) {
-	// Write kubeconfig to disk
-	kubeconfig, err := r.KubeconfigProvider.GetAdminKubeconfig(cluster)
-	if err != nil {
-		return "", nil, fmt.Errorf("failed to get admin kubeconfig for cluster %s: %v", cluster.Name, err)
-	}
-	kubeconfigFilename := path.Join("/tmp", fmt.Sprintf("cluster-%s-rancher-kubeconfig", cluster.Name))
-	if err := ioutil.WriteFile(kubeconfigFilename, kubeconfig, 0644); err != nil {
-		return "", nil, fmt.Errorf("failed to write admin kubeconfig for cluster %s: %v", cluster.Name, err)
-	}
-	log.Debugw("Wrote admin kubeconfig", "file", kubeconfigFilename)
-
-	return kubeconfigFilename, getFileDeleteFinalizer(log, kubeconfigFilename), nil
-}
-
-func getFileDeleteFinalizer(log *zap.SugaredLogger, filename string) fileHandlingDone {
-	return func() {
-		if err := os.RemoveAll(filename); err != nil {
-			log.Errorw("Failed to delete file", zap.Error(err), "file", filename)
+	decoder := yaml.NewYAMLOrJSONDecoder(resp.Body, 4096)
+	for {
+		object := &metav1unstructured.Unstructured{}
+		if err := decoder.Decode(&object.Object); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("failed to decode rancher registration manifest: %v", err)
 		}
+		if len(object.Object) == 0 {
+			continue
+		}
+		if err := kuberneteshelper.ApplyUnstructured(ctx, userClusterClient, object, fieldManager); err != nil {
+			return fmt.Errorf("failed to apply %s %s/%s: %v", object.GetKind(), object.GetNamespace(), object.GetName(), err)
+		}
+		log.Debugw("Applied rancher registration object", "kind", object.GetKind(), "namespace", object.GetNamespace(), "name", object.GetName())
 	}
 }
 
-func getApplyCommand(ctx context.Context, kubeconfigFilename string) *exec.Cmd {
-	return exec.CommandContext(ctx, "kubectl", "--kubeconfig", kubeconfigFilename, "apply", "-f", "-")
-}
-
 func getHTTPClient(insecure bool) http.Client {
 	tr := http.DefaultTransport
 	if insecure {
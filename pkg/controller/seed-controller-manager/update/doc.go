@@ -18,8 +18,12 @@ limitations under the License.
 Package update contains a controller that auto applies updates to both the cluster version
 and the machine version based on a configuration file.
 
-TODO: Make this controller wait for successfully convergation after an update was applied. Currently,
-it may apply an update and then instantly apply another one, which is not supported, only n+1 minor
-version updates are supported.
+Changes of the cluster version are rolled out to the control plane of Kubernetes clusters in stages:
+etcd first, followed by the apiserver, the controller-manager and the scheduler. A stage only gets
+started once the previous one has been rolled out and is healthy. The progress is tracked in the
+ControlPlaneUpgraded condition of the cluster. If a stage does not become healthy within the stage
+timeout, the apiserver, controller-manager, scheduler and the cluster version are rolled back to the
+previous version. Etcd is never downgraded. Automatic updates do not retry versions which have been
+rolled back.
 */
 package update
@@ -0,0 +1,302 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kubermatic/kubermatic/pkg/collectors"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1/helper"
+	"github.com/kubermatic/kubermatic/pkg/resources"
+	"github.com/kubermatic/kubermatic/pkg/resources/etcd"
+	"github.com/kubermatic/kubermatic/pkg/semver"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// DefaultUpgradeStageTimeout is the default time a single stage of a control plane upgrade
+	// may take before the whole upgrade gets rolled back.
+	DefaultUpgradeStageTimeout = 15 * time.Minute
+)
+
+// stagedControlPlaneUpgrade rolls a changed spec version out to the control plane components one after
+// another. Every stage has to be healthy before the next one gets started. If a stage does not
+// become healthy in time, the upgrade gets rolled back. A result is returned as long as an upgrade
+// is in progress.
+func (r *Reconciler) stagedControlPlaneUpgrade(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	versions := cluster.Status.Versions
+	target := cluster.Spec.Version
+
+	// Clusters which never went through a staged upgrade run all components with the spec version
+	if versions.ControlPlane == nil || versions.ControlPlane.Version == nil {
+		return nil, r.patchCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+			c.Status.Versions.ControlPlane = copyVersion(target)
+			for _, component := range kubermaticv1.ControlPlaneUpgradeStages {
+				c.Status.Versions.SetComponentVersion(component, copyVersion(target))
+			}
+		})
+	}
+
+	upgrade := versions.Upgrade
+	if upgrade == nil || !upgrade.To.Equal(&target) {
+		if upgrade == nil && versions.ControlPlane.Equal(&target) {
+			return nil, nil
+		}
+
+		// Upgrades are also restarted when the spec version changes while an upgrade is in progress.
+		// Components which have already been upgraded get changed again in their stage.
+		if err := r.patchCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+			c.Status.Versions.Upgrade = &kubermaticv1.ControlPlaneUpgradeStatus{
+				From:      versions.ControlPlane.DeepCopy(),
+				To:        target.DeepCopy(),
+				StartTime: metav1.Now(),
+			}
+			startUpgradeStage(c, kubermaticv1.ControlPlaneUpgradeStages[0])
+		}); err != nil {
+			return nil, err
+		}
+		r.recorder.Eventf(cluster, corev1.EventTypeNormal, "ControlPlaneUpgradeStarted", "Started upgrade of the control plane from %s to %s", versions.ControlPlane.String(), target.String())
+		return &reconcile.Result{RequeueAfter: r.upgradeStageTimeout}, nil
+	}
+
+	upgraded, reason, err := r.componentUpgraded(ctx, cluster, upgrade.Stage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if the %s has been upgraded: %v", upgrade.Stage, err)
+	}
+
+	if upgraded {
+		next := nextUpgradeStage(upgrade.Stage)
+		if next == "" {
+			if err := r.patchCluster(ctx, cluster, completeUpgrade); err != nil {
+				return nil, err
+			}
+			collectors.ObserveControlPlaneUpgrade(upgrade, collectors.UpgradeResultCompleted)
+			r.recorder.Eventf(cluster, corev1.EventTypeNormal, "ControlPlaneUpgraded", "Upgraded the control plane to %s", target.String())
+			return nil, nil
+		}
+
+		if err := r.patchCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+			startUpgradeStage(c, next)
+		}); err != nil {
+			return nil, err
+		}
+		return &reconcile.Result{RequeueAfter: r.upgradeStageTimeout}, nil
+	}
+
+	elapsed := time.Since(upgrade.StageStartTime.Time)
+	if elapsed < r.upgradeStageTimeout {
+		// The workloads of the cluster are watched, we only need to requeue to enforce the timeout
+		return &reconcile.Result{RequeueAfter: r.upgradeStageTimeout - elapsed}, nil
+	}
+
+	message := fmt.Sprintf("Upgrade to %s has been rolled back because the %s did not become healthy within %v: %s", upgrade.To.String(), upgrade.Stage, r.upgradeStageTimeout, reason)
+	if err := r.patchCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		rollbackUpgrade(c, message)
+	}); err != nil {
+		return nil, err
+	}
+	collectors.ObserveControlPlaneUpgrade(upgrade, collectors.UpgradeResultRolledBack)
+	r.recorder.Event(cluster, corev1.EventTypeWarning, "ControlPlaneUpgradeRolledBack", message)
+	return nil, nil
+}
+
+// componentUpgraded checks if the workload of the given component has been rolled out with
+// its current version and is healthy. If not, the reason is returned.
+func (r *Reconciler) componentUpgraded(ctx context.Context, cluster *kubermaticv1.Cluster, component kubermaticv1.ControlPlaneComponent) (bool, string, error) {
+	nn := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: workloadName(component)}
+
+	var (
+		status kubermaticv1.HealthStatus
+		err    error
+	)
+	if component == kubermaticv1.ControlPlaneComponentEtcd {
+		statefulSet := &appsv1.StatefulSet{}
+		if err := r.Get(ctx, nn, statefulSet); err != nil {
+			if kerrors.IsNotFound(err) {
+				return false, fmt.Sprintf("StatefulSet %s does not exist", nn.Name), nil
+			}
+			return false, "", err
+		}
+		if statefulSet.Status.ObservedGeneration < statefulSet.Generation {
+			return false, fmt.Sprintf("StatefulSet %s has not been observed yet", nn.Name), nil
+		}
+		imageName, err := etcd.LauncherImageName(cluster)
+		if err != nil {
+			return false, "", err
+		}
+		if !strings.Contains(containerImage(statefulSet.Spec.Template.Spec.Containers, nn.Name), "/"+imageName+":") {
+			return false, fmt.Sprintf("StatefulSet %s does not use the image %s yet", nn.Name, imageName), nil
+		}
+		if status, err = resources.HealthyStatefulSet(ctx, r, nn, int32(resources.GetEtcdQuorumSize(cluster))); err != nil {
+			return false, "", err
+		}
+	} else {
+		deployment := &appsv1.Deployment{}
+		if err := r.Get(ctx, nn, deployment); err != nil {
+			if kerrors.IsNotFound(err) {
+				return false, fmt.Sprintf("Deployment %s does not exist", nn.Name), nil
+			}
+			return false, "", err
+		}
+		if deployment.Status.ObservedGeneration < deployment.Generation {
+			return false, fmt.Sprintf("Deployment %s has not been observed yet", nn.Name), nil
+		}
+		version := cluster.ComponentVersion(component).String()
+		if !strings.HasSuffix(containerImage(deployment.Spec.Template.Spec.Containers, nn.Name), ":v"+version) {
+			return false, fmt.Sprintf("Deployment %s does not use version %s yet", nn.Name, version), nil
+		}
+		if status, err = resources.HealthyDeployment(ctx, r, nn, 1); err != nil {
+			return false, "", err
+		}
+	}
+
+	if status != kubermaticv1.HealthStatusUp {
+		return false, fmt.Sprintf("%s is not healthy", nn.Name), nil
+	}
+	if *extendedHealthStatus(&cluster.Status.ExtendedHealth, component) != kubermaticv1.HealthStatusUp {
+		return false, fmt.Sprintf("%s is not reported as healthy", nn.Name), nil
+	}
+	return true, "", nil
+}
+
+// startUpgradeStage changes the version of the given component to the target version of the upgrade.
+func startUpgradeStage(cluster *kubermaticv1.Cluster, stage kubermaticv1.ControlPlaneComponent) {
+	upgrade := cluster.Status.Versions.Upgrade
+	upgrade.Stage = stage
+	upgrade.StageStartTime = metav1.Now()
+
+	current := cluster.Status.Versions.ComponentVersion(stage)
+	// Etcd does not support downgrades
+	skip := stage == kubermaticv1.ControlPlaneComponentEtcd && current != nil && current.Version != nil && !current.LessThan(upgrade.To.Version)
+	if !skip && (current == nil || current.Version == nil || !current.Equal(&upgrade.To)) {
+		cluster.Status.Versions.SetComponentVersion(stage, copyVersion(upgrade.To))
+		// Invalidate the health so the stage does not get completed before the health got updated
+		*extendedHealthStatus(&cluster.Status.ExtendedHealth, stage) = kubermaticv1.HealthStatusDown
+	}
+
+	kubermaticv1helper.SetClusterCondition(
+		cluster,
+		kubermaticv1.ClusterConditionControlPlaneUpgraded,
+		corev1.ConditionFalse,
+		kubermaticv1.ReasonControlPlaneUpgradeInProgress,
+		fmt.Sprintf("Upgrading the %s to %s", stage, upgrade.To.String()),
+	)
+}
+
+func completeUpgrade(cluster *kubermaticv1.Cluster) {
+	target := cluster.Status.Versions.Upgrade.To
+	cluster.Status.Versions.ControlPlane = copyVersion(target)
+	cluster.Status.Versions.Upgrade = nil
+	cluster.Status.Versions.FailedUpgrade = nil
+
+	kubermaticv1helper.SetClusterCondition(
+		cluster,
+		kubermaticv1.ClusterConditionControlPlaneUpgraded,
+		corev1.ConditionTrue,
+		kubermaticv1.ReasonControlPlaneUpgradeCompleted,
+		fmt.Sprintf("Control plane has been upgraded to %s", target.String()),
+	)
+}
+
+// rollbackUpgrade reverts all components except etcd as well as the spec to the version the
+// upgrade started from.
+func rollbackUpgrade(cluster *kubermaticv1.Cluster, message string) {
+	upgrade := cluster.Status.Versions.Upgrade
+	for _, component := range kubermaticv1.ControlPlaneUpgradeStages {
+		if component != kubermaticv1.ControlPlaneComponentEtcd {
+			cluster.Status.Versions.SetComponentVersion(component, copyVersion(upgrade.From))
+		}
+	}
+	cluster.Spec.Version = upgrade.From.DeepCopy()
+	cluster.Status.Versions.FailedUpgrade = copyVersion(upgrade.To)
+	cluster.Status.Versions.Upgrade = nil
+
+	kubermaticv1helper.SetClusterCondition(
+		cluster,
+		kubermaticv1.ClusterConditionControlPlaneUpgraded,
+		corev1.ConditionFalse,
+		kubermaticv1.ReasonControlPlaneUpgradeRolledBack,
+		message,
+	)
+}
+
+func (r *Reconciler) patchCluster(ctx context.Context, cluster *kubermaticv1.Cluster, modify func(*kubermaticv1.Cluster)) error {
+	oldCluster := cluster.DeepCopy()
+	modify(cluster)
+	if err := r.Patch(ctx, cluster, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to update cluster: %v", err)
+	}
+	return nil
+}
+
+func nextUpgradeStage(stage kubermaticv1.ControlPlaneComponent) kubermaticv1.ControlPlaneComponent {
+	for i, s := range kubermaticv1.ControlPlaneUpgradeStages {
+		if s == stage && i+1 < len(kubermaticv1.ControlPlaneUpgradeStages) {
+			return kubermaticv1.ControlPlaneUpgradeStages[i+1]
+		}
+	}
+	return ""
+}
+
+func workloadName(component kubermaticv1.ControlPlaneComponent) string {
+	switch component {
+	case kubermaticv1.ControlPlaneComponentEtcd:
+		return resources.EtcdStatefulSetName
+	case kubermaticv1.ControlPlaneComponentApiserver:
+		return resources.ApiserverDeploymentName
+	case kubermaticv1.ControlPlaneComponentControllerManager:
+		return resources.ControllerManagerDeploymentName
+	}
+	return resources.SchedulerDeploymentName
+}
+
+func extendedHealthStatus(health *kubermaticv1.ExtendedClusterHealth, component kubermaticv1.ControlPlaneComponent) *kubermaticv1.HealthStatus {
+	switch component {
+	case kubermaticv1.ControlPlaneComponentEtcd:
+		return &health.Etcd
+	case kubermaticv1.ControlPlaneComponentApiserver:
+		return &health.Apiserver
+	case kubermaticv1.ControlPlaneComponentControllerManager:
+		return &health.Controller
+	}
+	return &health.Scheduler
+}
+
+func containerImage(containers []corev1.Container, name string) string {
+	for _, container := range containers {
+		if container.Name == name {
+			return container.Image
+		}
+	}
+	return ""
+}
+
+func copyVersion(version semver.Semver) *semver.Semver {
+	copied := version.DeepCopy()
+	return &copied
+}
@@ -0,0 +1,358 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package update
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1/helper"
+	"github.com/kubermatic/kubermatic/pkg/semver"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	utilpointer "k8s.io/utils/pointer"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testNamespace = "cluster-test"
+
+func testCluster(version string, modify func(*kubermaticv1.Cluster)) *kubermaticv1.Cluster {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Version: *semver.NewSemverOrDie(version),
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: testNamespace,
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+				Apiserver:  kubermaticv1.HealthStatusUp,
+				Controller: kubermaticv1.HealthStatusUp,
+				Scheduler:  kubermaticv1.HealthStatusUp,
+				Etcd:       kubermaticv1.HealthStatusUp,
+			},
+		},
+	}
+	if modify != nil {
+		modify(cluster)
+	}
+	return cluster
+}
+
+func upgradeInProgress(from, to string, stage kubermaticv1.ControlPlaneComponent, startTime time.Time) func(*kubermaticv1.Cluster) {
+	return func(c *kubermaticv1.Cluster) {
+		c.Status.Versions = kubermaticv1.ClusterVersionsStatus{
+			ControlPlane:      semver.NewSemverOrDie(from),
+			Etcd:              semver.NewSemverOrDie(to),
+			Apiserver:         semver.NewSemverOrDie(from),
+			ControllerManager: semver.NewSemverOrDie(from),
+			Scheduler:         semver.NewSemverOrDie(from),
+			Upgrade: &kubermaticv1.ControlPlaneUpgradeStatus{
+				From:           *semver.NewSemverOrDie(from),
+				To:             *semver.NewSemverOrDie(to),
+				Stage:          stage,
+				StageStartTime: metav1.NewTime(startTime),
+			},
+		}
+		// All stages up to the current one have already been started
+		for _, component := range kubermaticv1.ControlPlaneUpgradeStages {
+			if component == kubermaticv1.ControlPlaneComponentEtcd {
+				continue
+			}
+			if stage == kubermaticv1.ControlPlaneComponentEtcd {
+				break
+			}
+			c.Status.Versions.SetComponentVersion(component, semver.NewSemverOrDie(to))
+			if component == stage {
+				break
+			}
+		}
+		kubermaticv1helper.SetClusterCondition(c, kubermaticv1.ClusterConditionControlPlaneUpgraded, corev1.ConditionFalse, kubermaticv1.ReasonControlPlaneUpgradeInProgress, "")
+	}
+}
+
+func testDeployment(name, version string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: utilpointer.Int32Ptr(1),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  name,
+						Image: "k8s.gcr.io/google_containers/hyperkube-amd64:v" + version,
+					}},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:        1,
+			UpdatedReplicas: 1,
+			ReadyReplicas:   1,
+		},
+	}
+}
+
+func testEtcd(baseTag string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "etcd",
+			Namespace: testNamespace,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: utilpointer.Int32Ptr(3),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "etcd",
+						Image: "quay.io/kubermatic/etcd-launcher-" + baseTag + ":abcdef",
+					}},
+				},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{
+			Replicas:        3,
+			UpdatedReplicas: 3,
+			ReadyReplicas:   3,
+		},
+	}
+}
+
+func TestStagedControlPlaneUpgrade(t *testing.T) {
+	testCases := []struct {
+		name              string
+		cluster           *kubermaticv1.Cluster
+		objects           []runtime.Object
+		expectRequeue     bool
+		verify            func(*testing.T, *kubermaticv1.Cluster)
+		expectedReason    string
+		expectedStatus    corev1.ConditionStatus
+		expectNoCondition bool
+	}{
+		{
+			name:              "versions of existing clusters get initialized",
+			cluster:           testCluster("1.17.3", nil),
+			expectNoCondition: true,
+			verify: func(t *testing.T, c *kubermaticv1.Cluster) {
+				for _, component := range kubermaticv1.ControlPlaneUpgradeStages {
+					if v := c.Status.Versions.ComponentVersion(component); v == nil || v.String() != "1.17.3" {
+						t.Errorf("expected %s version to be initialized with 1.17.3, got %v", component, v)
+					}
+				}
+				if c.Status.Versions.Upgrade != nil {
+					t.Error("expected no upgrade to be started")
+				}
+			},
+		},
+		{
+			name: "upgrade starts with etcd",
+			cluster: testCluster("1.17.4", func(c *kubermaticv1.Cluster) {
+				c.Status.Versions = kubermaticv1.ClusterVersionsStatus{
+					ControlPlane:      semver.NewSemverOrDie("1.17.3"),
+					Etcd:              semver.NewSemverOrDie("1.17.3"),
+					Apiserver:         semver.NewSemverOrDie("1.17.3"),
+					ControllerManager: semver.NewSemverOrDie("1.17.3"),
+					Scheduler:         semver.NewSemverOrDie("1.17.3"),
+				}
+			}),
+			expectRequeue:  true,
+			expectedReason: kubermaticv1.ReasonControlPlaneUpgradeInProgress,
+			expectedStatus: corev1.ConditionFalse,
+			verify: func(t *testing.T, c *kubermaticv1.Cluster) {
+				upgrade := c.Status.Versions.Upgrade
+				if upgrade == nil || upgrade.Stage != kubermaticv1.ControlPlaneComponentEtcd || upgrade.From.String() != "1.17.3" || upgrade.To.String() != "1.17.4" {
+					t.Fatalf("expected etcd stage of upgrade from 1.17.3 to 1.17.4 to be started, got %+v", upgrade)
+				}
+				if upgrade.StartTime.IsZero() {
+					t.Error("expected the start time of the upgrade to be set")
+				}
+				if c.Status.Versions.Etcd.String() != "1.17.4" || c.Status.Versions.Apiserver.String() != "1.17.3" {
+					t.Errorf("expected only etcd to be upgraded, got etcd %s and apiserver %s", c.Status.Versions.Etcd, c.Status.Versions.Apiserver)
+				}
+				if c.Status.ExtendedHealth.Etcd != kubermaticv1.HealthStatusDown {
+					t.Errorf("expected etcd health to be invalidated, got %v", c.Status.ExtendedHealth.Etcd)
+				}
+			},
+		},
+		{
+			name:           "etcd stage waits for the new etcd image",
+			cluster:        testCluster("1.17.4", upgradeInProgress("1.16.9", "1.17.4", kubermaticv1.ControlPlaneComponentEtcd, time.Now())),
+			objects:        []runtime.Object{testEtcd("v33")},
+			expectRequeue:  true,
+			expectedReason: kubermaticv1.ReasonControlPlaneUpgradeInProgress,
+			expectedStatus: corev1.ConditionFalse,
+			verify: func(t *testing.T, c *kubermaticv1.Cluster) {
+				if stage := c.Status.Versions.Upgrade.Stage; stage != kubermaticv1.ControlPlaneComponentEtcd {
+					t.Errorf("expected upgrade to stay in the etcd stage, got %s", stage)
+				}
+			},
+		},
+		{
+			name:           "healthy etcd stage continues with the apiserver",
+			cluster:        testCluster("1.17.4", upgradeInProgress("1.16.9", "1.17.4", kubermaticv1.ControlPlaneComponentEtcd, time.Now())),
+			objects:        []runtime.Object{testEtcd("v34")},
+			expectRequeue:  true,
+			expectedReason: kubermaticv1.ReasonControlPlaneUpgradeInProgress,
+			expectedStatus: corev1.ConditionFalse,
+			verify: func(t *testing.T, c *kubermaticv1.Cluster) {
+				if stage := c.Status.Versions.Upgrade.Stage; stage != kubermaticv1.ControlPlaneComponentApiserver {
+					t.Errorf("expected upgrade to continue with the apiserver, got %s", stage)
+				}
+				if c.Status.Versions.Apiserver.String() != "1.17.4" || c.Status.Versions.ControllerManager.String() != "1.16.9" {
+					t.Errorf("expected only the apiserver to be upgraded, got apiserver %s and controller-manager %s", c.Status.Versions.Apiserver, c.Status.Versions.ControllerManager)
+				}
+			},
+		},
+		{
+			name: "etcd stage waits for a quorum of the configured members",
+			cluster: testCluster("1.17.4", func(c *kubermaticv1.Cluster) {
+				upgradeInProgress("1.16.9", "1.17.4", kubermaticv1.ControlPlaneComponentEtcd, time.Now())(c)
+				c.Spec.ComponentsOverride.Etcd.ClusterSize = 5
+			}),
+			objects: []runtime.Object{func() *appsv1.StatefulSet {
+				etcd := testEtcd("v34")
+				etcd.Spec.Replicas = utilpointer.Int32Ptr(2)
+				etcd.Status.Replicas, etcd.Status.UpdatedReplicas, etcd.Status.ReadyReplicas = 2, 2, 2
+				return etcd
+			}()},
+			expectRequeue:  true,
+			expectedReason: kubermaticv1.ReasonControlPlaneUpgradeInProgress,
+			expectedStatus: corev1.ConditionFalse,
+			verify: func(t *testing.T, c *kubermaticv1.Cluster) {
+				if stage := c.Status.Versions.Upgrade.Stage; stage != kubermaticv1.ControlPlaneComponentEtcd {
+					t.Errorf("expected upgrade to stay in the etcd stage, got %s", stage)
+				}
+			},
+		},
+		{
+			name:           "apiserver stage waits for the new version",
+			cluster:        testCluster("1.17.4", upgradeInProgress("1.16.9", "1.17.4", kubermaticv1.ControlPlaneComponentApiserver, time.Now())),
+			objects:        []runtime.Object{testDeployment("apiserver", "1.16.9")},
+			expectRequeue:  true,
+			expectedReason: kubermaticv1.ReasonControlPlaneUpgradeInProgress,
+			expectedStatus: corev1.ConditionFalse,
+			verify: func(t *testing.T, c *kubermaticv1.Cluster) {
+				if stage := c.Status.Versions.Upgrade.Stage; stage != kubermaticv1.ControlPlaneComponentApiserver {
+					t.Errorf("expected upgrade to stay in the apiserver stage, got %s", stage)
+				}
+			},
+		},
+		{
+			name:           "healthy apiserver stage continues with the controller-manager",
+			cluster:        testCluster("1.17.4", upgradeInProgress("1.16.9", "1.17.4", kubermaticv1.ControlPlaneComponentApiserver, time.Now())),
+			objects:        []runtime.Object{testDeployment("apiserver", "1.17.4")},
+			expectRequeue:  true,
+			expectedReason: kubermaticv1.ReasonControlPlaneUpgradeInProgress,
+			expectedStatus: corev1.ConditionFalse,
+			verify: func(t *testing.T, c *kubermaticv1.Cluster) {
+				if stage := c.Status.Versions.Upgrade.Stage; stage != kubermaticv1.ControlPlaneComponentControllerManager {
+					t.Errorf("expected upgrade to continue with the controller-manager, got %s", stage)
+				}
+			},
+		},
+		{
+			name:    "unhealthy stage gets rolled back after the timeout",
+			cluster: testCluster("1.17.4", upgradeInProgress("1.16.9", "1.17.4", kubermaticv1.ControlPlaneComponentControllerManager, time.Now().Add(-time.Hour))),
+			objects: []runtime.Object{
+				testDeployment("controller-manager", "1.16.9"),
+			},
+			expectedReason: kubermaticv1.ReasonControlPlaneUpgradeRolledBack,
+			expectedStatus: corev1.ConditionFalse,
+			verify: func(t *testing.T, c *kubermaticv1.Cluster) {
+				if c.Spec.Version.String() != "1.16.9" {
+					t.Errorf("expected spec version to be rolled back to 1.16.9, got %s", c.Spec.Version.String())
+				}
+				if c.Status.Versions.Apiserver.String() != "1.16.9" || c.Status.Versions.ControllerManager.String() != "1.16.9" {
+					t.Errorf("expected components to be rolled back to 1.16.9, got apiserver %s and controller-manager %s", c.Status.Versions.Apiserver, c.Status.Versions.ControllerManager)
+				}
+				if c.Status.Versions.Etcd.String() != "1.17.4" {
+					t.Errorf("expected etcd not to be downgraded, got %s", c.Status.Versions.Etcd)
+				}
+				if c.Status.Versions.Upgrade != nil || c.Status.Versions.FailedUpgrade.String() != "1.17.4" {
+					t.Errorf("expected upgrade to be marked as failed, got upgrade %+v and failed upgrade %s", c.Status.Versions.Upgrade, c.Status.Versions.FailedUpgrade)
+				}
+			},
+		},
+		{
+			name: "healthy scheduler stage completes the upgrade",
+			cluster: testCluster("1.17.4", func(c *kubermaticv1.Cluster) {
+				upgradeInProgress("1.17.3", "1.17.4", kubermaticv1.ControlPlaneComponentScheduler, time.Now())(c)
+				c.Status.Versions.FailedUpgrade = semver.NewSemverOrDie("1.17.4")
+			}),
+			objects: []runtime.Object{
+				testDeployment("scheduler", "1.17.4"),
+			},
+			expectedReason: kubermaticv1.ReasonControlPlaneUpgradeCompleted,
+			expectedStatus: corev1.ConditionTrue,
+			verify: func(t *testing.T, c *kubermaticv1.Cluster) {
+				if c.Status.Versions.ControlPlane.String() != "1.17.4" {
+					t.Errorf("expected control plane version to be 1.17.4, got %s", c.Status.Versions.ControlPlane)
+				}
+				if c.Status.Versions.Upgrade != nil || c.Status.Versions.FailedUpgrade != nil {
+					t.Errorf("expected upgrade to be finished, got upgrade %+v and failed upgrade %s", c.Status.Versions.Upgrade, c.Status.Versions.FailedUpgrade)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := ctrlruntimefakeclient.NewFakeClient(append(tc.objects, tc.cluster)...)
+			r := &Reconciler{
+				Client:              client,
+				recorder:            record.NewFakeRecorder(10),
+				upgradeStageTimeout: 10 * time.Minute,
+			}
+
+			ctx := context.Background()
+			result, err := r.stagedControlPlaneUpgrade(ctx, tc.cluster.DeepCopy())
+			if err != nil {
+				t.Fatalf("failed to reconcile: %v", err)
+			}
+			if requeue := result != nil; requeue != tc.expectRequeue {
+				t.Errorf("expected requeue to be %t, got %t", tc.expectRequeue, requeue)
+			}
+
+			cluster := &kubermaticv1.Cluster{}
+			if err := client.Get(ctx, types.NamespacedName{Name: tc.cluster.Name}, cluster); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+
+			_, condition := kubermaticv1helper.GetClusterCondition(cluster, kubermaticv1.ClusterConditionControlPlaneUpgraded)
+			if tc.expectNoCondition {
+				if condition != nil {
+					t.Errorf("expected no %s condition, got %+v", kubermaticv1.ClusterConditionControlPlaneUpgraded, condition)
+				}
+			} else if condition == nil || condition.Reason != tc.expectedReason || condition.Status != tc.expectedStatus {
+				t.Errorf("expected %s condition with status %s and reason %s, got %+v", kubermaticv1.ClusterConditionControlPlaneUpgraded, tc.expectedStatus, tc.expectedReason, condition)
+			}
+
+			tc.verify(t, cluster)
+		})
+	}
+}
@@ -25,14 +25,17 @@ import (
 
 	v1 "github.com/kubermatic/kubermatic/pkg/api/v1"
 	"github.com/kubermatic/kubermatic/pkg/cluster/client"
+	controllerutil "github.com/kubermatic/kubermatic/pkg/controller/util"
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	kubermaticv1helper "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1/helper"
 	"github.com/kubermatic/kubermatic/pkg/semver"
 	"github.com/kubermatic/kubermatic/pkg/version"
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -52,18 +55,20 @@ type Reconciler struct {
 	ctrlruntimeclient.Client
 	recorder                      record.EventRecorder
 	userClusterConnectionProvider *client.Provider
+	upgradeStageTimeout           time.Duration
 	log                           *zap.SugaredLogger
 }
 
 // Add creates a new update controller
 func Add(mgr manager.Manager, numWorkers int, workerName string, updateManager *version.Manager,
-	userClusterConnectionProvider *client.Provider, log *zap.SugaredLogger) error {
+	userClusterConnectionProvider *client.Provider, upgradeStageTimeout time.Duration, log *zap.SugaredLogger) error {
 	reconciler := &Reconciler{
 		workerName:                    workerName,
 		updateManager:                 updateManager,
 		Client:                        mgr.GetClient(),
 		recorder:                      mgr.GetEventRecorderFor(ControllerName),
 		userClusterConnectionProvider: userClusterConnectionProvider,
+		upgradeStageTimeout:           upgradeStageTimeout,
 		log:                           log,
 	}
 
@@ -79,6 +84,13 @@ func Add(mgr manager.Manager, numWorkers int, workerName string, updateManager *
 		return fmt.Errorf("failed to create watch: %v", err)
 	}
 
+	// The control plane workloads are watched to continue staged upgrades as soon as a stage got healthy
+	for _, t := range []runtime.Object{&appsv1.Deployment{}, &appsv1.StatefulSet{}} {
+		if err := c.Watch(&source.Kind{Type: t}, controllerutil.EnqueueClusterForNamespacedObject(mgr.GetClient())); err != nil {
+			return fmt.Errorf("failed to create watch for %T: %v", t, err)
+		}
+	}
+
 	return nil
 }
 
@@ -117,6 +129,17 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 
 func (r *Reconciler) reconcile(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
 
+	// Openshift clusters do not use the per component versions and get upgraded at once
+	if !cluster.IsOpenshift() {
+		result, err := r.stagedControlPlaneUpgrade(ctx, cluster)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upgrade the controlplane: %v", err)
+		}
+		if result != nil {
+			return result, nil
+		}
+	}
+
 	if !cluster.Status.ExtendedHealth.AllHealthy() {
 		// Cluster not healthy yet. Nothing to do.
 		// If it gets healthy we'll get notified by the event. No need to requeue
@@ -187,6 +210,10 @@ func (r *Reconciler) controlPlaneUpgrade(ctx context.Context, cluster *kubermati
 	if update == nil {
 		return false, nil
 	}
+	if failed := cluster.Status.Versions.FailedUpgrade; failed != nil && failed.Version != nil && failed.Version.Equal(update.Version) {
+		// Do not retry upgrades which have been rolled back
+		return false, nil
+	}
 	oldCluster := cluster.DeepCopy()
 
 	cluster.Spec.Version = *semver.NewSemverOrDie(update.Version.String())
@@ -40,27 +40,37 @@ const (
 	ResourceOwnerName  = "system:kubermatic:owners"
 	ResourceEditorName = "system:kubermatic:editors"
 	ResourceViewerName = "system:kubermatic:viewers"
+
+	// Resources for members of project roles, they are granted access per verb on node deployments
+	ResourceNodeDeploymentsGetName    = "system:kubermatic:nodedeployments-get"
+	ResourceNodeDeploymentsCreateName = "system:kubermatic:nodedeployments-create"
+	ResourceNodeDeploymentsUpdateName = "system:kubermatic:nodedeployments-update"
+	ResourceNodeDeploymentsDeleteName = "system:kubermatic:nodedeployments-delete"
 )
 
+var resourceNames = []string{
+	ResourceOwnerName,
+	ResourceEditorName,
+	ResourceViewerName,
+	ResourceNodeDeploymentsGetName,
+	ResourceNodeDeploymentsCreateName,
+	ResourceNodeDeploymentsUpdateName,
+	ResourceNodeDeploymentsDeleteName,
+}
+
 var mapFn = handler.ToRequestsFunc(func(o handler.MapObject) []reconcile.Request {
-	return []reconcile.Request{
-		{NamespacedName: types.NamespacedName{
-			Name:      ResourceOwnerName,
-			Namespace: "",
-		}},
-		{NamespacedName: types.NamespacedName{
-			Name:      ResourceEditorName,
-			Namespace: "",
-		}},
-		{NamespacedName: types.NamespacedName{
-			Name:      ResourceViewerName,
+	requests := []reconcile.Request{}
+	for _, name := range resourceNames {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Name:      name,
 			Namespace: "",
-		}},
+		}})
 	}
+	return requests
 })
 
 // Add creates a new RBAC generator controller that is responsible for creating Cluster Roles and Cluster Role Bindings
-// for groups: `owners`, `editors` and `viewers``
+// for groups: `owners`, `editors`, `viewers` and the node deployment groups of project roles
 func Add(mgr manager.Manager, registerReconciledCheck func(name string, check healthcheck.Check)) error {
 	reconcile := &reconcileRBAC{Client: mgr.GetClient(), ctx: context.TODO(), rLock: &sync.Mutex{}}
 
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/kubermatic/kubermatic/pkg/controller/master-controller-manager/rbac"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 
 	apps "k8s.io/api/apps/v1"
 	autoscaling "k8s.io/api/autoscaling/v1"
@@ -53,6 +54,17 @@ func generateVerbsForGroup(groupName string) ([]string, error) {
 		return []string{"list", "get", "watch"}, nil
 	}
 
+	if verb, ok := nodeDeploymentsVerbFor(groupName); ok {
+		switch verb {
+		case kubermaticv1.ProjectRoleVerbGet:
+			return []string{"list", "get", "watch"}, nil
+		case kubermaticv1.ProjectRoleVerbUpdate:
+			return []string{"update", "patch"}, nil
+		default:
+			return []string{string(verb)}, nil
+		}
+	}
+
 	// unknown group passed
 	return []string{}, fmt.Errorf("unable to generate verbs, unknown group name passed in = %s", groupName)
 }
@@ -69,6 +81,10 @@ func GenerateRBACClusterRole(resourceName string) (*rbacv1.ClusterRole, error) {
 		return nil, err
 	}
 
+	if verb, ok := nodeDeploymentsVerbFor(groupName); ok {
+		return generateNodeDeploymentsClusterRole(resourceName, verb, verbs), nil
+	}
+
 	clusterRole := &rbacv1.ClusterRole{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: resourceName,
@@ -162,6 +178,48 @@ func GenerateRBACClusterRole(resourceName string) (*rbacv1.ClusterRole, error) {
 	return clusterRole, nil
 }
 
+// generateNodeDeploymentsClusterRole creates the role for a node deployment group of project roles,
+// members which are allowed to get node deployments can also see the nodes and events
+func generateNodeDeploymentsClusterRole(resourceName string, verb kubermaticv1.ProjectRoleVerb, verbs []string) *rbacv1.ClusterRole {
+	clusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: resourceName,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{clusterPolicyAPIGroup},
+				Resources: []string{machinedeployments},
+				Verbs:     verbs,
+			},
+		},
+	}
+	if verb == kubermaticv1.ProjectRoleVerbGet {
+		clusterRole.Rules = []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{clusterPolicyAPIGroup},
+				Resources: []string{machinedeployments, machinesets, machines},
+				Verbs:     verbs,
+			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"nodes", "events"},
+				Verbs:     verbs,
+			},
+		}
+	}
+	return clusterRole
+}
+
+// nodeDeploymentsVerbFor returns the verb of a node deployment group, see rbac.NodeDeploymentsGroupNameFor
+func nodeDeploymentsVerbFor(groupName string) (kubermaticv1.ProjectRoleVerb, bool) {
+	for _, verb := range kubermaticv1.AllProjectRoleVerbs {
+		if groupName == rbac.NodeDeploymentsGroupNameFor(verb) {
+			return verb, true
+		}
+	}
+	return "", false
+}
+
 // GenerateRBACClusterRoleBinding creates role binding for specific group
 func GenerateRBACClusterRoleBinding(resourceName string) (*rbacv1.ClusterRoleBinding, error) {
 	groupName, err := getGroupName(resourceName)
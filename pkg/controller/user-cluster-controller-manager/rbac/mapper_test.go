@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/kubermatic/kubermatic/pkg/controller/master-controller-manager/rbac"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 )
@@ -55,6 +56,16 @@ func TestGeneratedResourcesForGroups(t *testing.T) {
 			resurceName: rbac.ViewerGroupNamePrefix,
 			expectError: true,
 		},
+		{
+			name:              "scenario 5: check resources for members of project roles which can get node deployments",
+			resurceName:       genResourceName(rbac.NodeDeploymentsGroupNameFor(kubermaticv1.ProjectRoleVerbGet)),
+			expectedResources: []string{"machinedeployments", "machinesets", "machines"},
+		},
+		{
+			name:              "scenario 6: check resources for members of project roles which can create node deployments",
+			resurceName:       genResourceName(rbac.NodeDeploymentsGroupNameFor(kubermaticv1.ProjectRoleVerbCreate)),
+			expectedResources: []string{"machinedeployments"},
+		},
 	}
 
 	for _, test := range tests {
@@ -113,6 +124,21 @@ func TestGenerateVerbsForGroup(t *testing.T) {
 			resurceName: rbac.ViewerGroupNamePrefix,
 			expectError: true,
 		},
+		{
+			name:          "scenario 5: generate verbs for members of project roles which can get node deployments",
+			resurceName:   genResourceName(rbac.NodeDeploymentsGroupNameFor(kubermaticv1.ProjectRoleVerbGet)),
+			expectedVerbs: []string{"list", "get", "watch"},
+		},
+		{
+			name:          "scenario 6: generate verbs for members of project roles which can update node deployments",
+			resurceName:   genResourceName(rbac.NodeDeploymentsGroupNameFor(kubermaticv1.ProjectRoleVerbUpdate)),
+			expectedVerbs: []string{"update", "patch"},
+		},
+		{
+			name:          "scenario 7: generate verbs for members of project roles which can delete node deployments",
+			resurceName:   genResourceName(rbac.NodeDeploymentsGroupNameFor(kubermaticv1.ProjectRoleVerbDelete)),
+			expectedVerbs: []string{"delete"},
+		},
 	}
 
 	for _, test := range tests {
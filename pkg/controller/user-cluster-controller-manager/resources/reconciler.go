@@ -453,7 +453,7 @@ func (r *reconciler) reconcileConfigMaps(ctx context.Context, data reconcileData
 	}
 
 	creators = []reconciling.NamedConfigMapCreatorGetter{
-		openvpn.ClientConfigConfigMapCreator(r.clusterURL.Hostname(), r.openvpnServerPort),
+		openvpn.ClientConfigConfigMapCreator(r.openvpnServerHost, r.openvpnServerPort),
 	}
 	if r.openshift {
 		creators = append(creators, openshift.ControlplaneConfigCreator(r.platform))
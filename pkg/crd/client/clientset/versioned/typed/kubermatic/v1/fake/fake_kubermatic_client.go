@@ -24,14 +24,26 @@ func (c *FakeKubermaticV1) Clusters() v1.ClusterInterface {
 	return &FakeClusters{c}
 }
 
+func (c *FakeKubermaticV1) ClusterTemplates() v1.ClusterTemplateInterface {
+	return &FakeClusterTemplates{c}
+}
+
 func (c *FakeKubermaticV1) KubermaticSettings() v1.KubermaticSettingInterface {
 	return &FakeKubermaticSettings{c}
 }
 
+func (c *FakeKubermaticV1) PricingCatalogs() v1.PricingCatalogInterface {
+	return &FakePricingCatalogs{c}
+}
+
 func (c *FakeKubermaticV1) Projects() v1.ProjectInterface {
 	return &FakeProjects{c}
 }
 
+func (c *FakeKubermaticV1) ProjectRoles() v1.ProjectRoleInterface {
+	return &FakeProjectRoles{c}
+}
+
 func (c *FakeKubermaticV1) Users() v1.UserInterface {
 	return &FakeUsers{c}
 }
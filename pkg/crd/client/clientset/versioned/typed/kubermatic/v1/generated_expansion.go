@@ -8,10 +8,16 @@ type AddonConfigExpansion interface{}
 
 type ClusterExpansion interface{}
 
+type ClusterTemplateExpansion interface{}
+
 type KubermaticSettingExpansion interface{}
 
+type PricingCatalogExpansion interface{}
+
 type ProjectExpansion interface{}
 
+type ProjectRoleExpansion interface{}
+
 type UserExpansion interface{}
 
 type UserProjectBindingExpansion interface{}
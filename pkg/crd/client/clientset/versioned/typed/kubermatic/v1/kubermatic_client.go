@@ -13,8 +13,11 @@ type KubermaticV1Interface interface {
 	AddonsGetter
 	AddonConfigsGetter
 	ClustersGetter
+	ClusterTemplatesGetter
 	KubermaticSettingsGetter
+	PricingCatalogsGetter
 	ProjectsGetter
+	ProjectRolesGetter
 	UsersGetter
 	UserProjectBindingsGetter
 	UserSSHKeysGetter
@@ -37,14 +40,26 @@ func (c *KubermaticV1Client) Clusters() ClusterInterface {
 	return newClusters(c)
 }
 
+func (c *KubermaticV1Client) ClusterTemplates() ClusterTemplateInterface {
+	return newClusterTemplates(c)
+}
+
 func (c *KubermaticV1Client) KubermaticSettings() KubermaticSettingInterface {
 	return newKubermaticSettings(c)
 }
 
+func (c *KubermaticV1Client) PricingCatalogs() PricingCatalogInterface {
+	return newPricingCatalogs(c)
+}
+
 func (c *KubermaticV1Client) Projects() ProjectInterface {
 	return newProjects(c)
 }
 
+func (c *KubermaticV1Client) ProjectRoles() ProjectRoleInterface {
+	return newProjectRoles(c)
+}
+
 func (c *KubermaticV1Client) Users() UserInterface {
 	return newUsers(c)
 }
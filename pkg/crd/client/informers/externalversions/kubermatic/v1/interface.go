@@ -14,10 +14,16 @@ type Interface interface {
 	AddonConfigs() AddonConfigInformer
 	// Clusters returns a ClusterInformer.
 	Clusters() ClusterInformer
+	// ClusterTemplates returns a ClusterTemplateInformer.
+	ClusterTemplates() ClusterTemplateInformer
 	// KubermaticSettings returns a KubermaticSettingInformer.
 	KubermaticSettings() KubermaticSettingInformer
+	// PricingCatalogs returns a PricingCatalogInformer.
+	PricingCatalogs() PricingCatalogInformer
 	// Projects returns a ProjectInformer.
 	Projects() ProjectInformer
+	// ProjectRoles returns a ProjectRoleInformer.
+	ProjectRoles() ProjectRoleInformer
 	// Users returns a UserInformer.
 	Users() UserInformer
 	// UserProjectBindings returns a UserProjectBindingInformer.
@@ -52,16 +58,31 @@ func (v *version) Clusters() ClusterInformer {
 	return &clusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterTemplates returns a ClusterTemplateInformer.
+func (v *version) ClusterTemplates() ClusterTemplateInformer {
+	return &clusterTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // KubermaticSettings returns a KubermaticSettingInformer.
 func (v *version) KubermaticSettings() KubermaticSettingInformer {
 	return &kubermaticSettingInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// PricingCatalogs returns a PricingCatalogInformer.
+func (v *version) PricingCatalogs() PricingCatalogInformer {
+	return &pricingCatalogInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Projects returns a ProjectInformer.
 func (v *version) Projects() ProjectInformer {
 	return &projectInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ProjectRoles returns a ProjectRoleInformer.
+func (v *version) ProjectRoles() ProjectRoleInformer {
+	return &projectRoleInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Users returns a UserInformer.
 func (v *version) Users() UserInformer {
 	return &userInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -18,14 +18,26 @@ type AddonConfigListerExpansion interface{}
 // ClusterLister.
 type ClusterListerExpansion interface{}
 
+// ClusterTemplateListerExpansion allows custom methods to be added to
+// ClusterTemplateLister.
+type ClusterTemplateListerExpansion interface{}
+
 // KubermaticSettingListerExpansion allows custom methods to be added to
 // KubermaticSettingLister.
 type KubermaticSettingListerExpansion interface{}
 
+// PricingCatalogListerExpansion allows custom methods to be added to
+// PricingCatalogLister.
+type PricingCatalogListerExpansion interface{}
+
 // ProjectListerExpansion allows custom methods to be added to
 // ProjectLister.
 type ProjectListerExpansion interface{}
 
+// ProjectRoleListerExpansion allows custom methods to be added to
+// ProjectRoleLister.
+type ProjectRoleListerExpansion interface{}
+
 // UserListerExpansion allows custom methods to be added to
 // UserLister.
 type UserListerExpansion interface{}
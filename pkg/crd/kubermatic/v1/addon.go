@@ -17,6 +17,8 @@ limitations under the License.
 package v1
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -31,6 +33,24 @@ const (
 	AddonKindName = "Addon"
 
 	AddonResourcesCreated AddonConditionType = "AddonResourcesCreatedSuccessfully"
+	// AddonHealthy indicates that all objects of the addon exist in the user cluster, match
+	// the addon manifests and all workloads are rolled out
+	AddonHealthy AddonConditionType = "AddonHealthy"
+	// AddonBlocked indicates that the addon is not installed or upgraded because at least one
+	// of its dependencies is missing or not healthy
+	AddonBlocked AddonConditionType = "AddonBlocked"
+)
+
+// AddonObjectState describes why an object of an addon is not healthy
+type AddonObjectState string
+
+const (
+	// AddonObjectMissing means the object does not exist in the user cluster
+	AddonObjectMissing AddonObjectState = "Missing"
+	// AddonObjectDrifted means the object in the user cluster differs from the addon manifest
+	AddonObjectDrifted AddonObjectState = "Drifted"
+	// AddonObjectNotReady means the workload is not completely rolled out yet
+	AddonObjectNotReady AddonObjectState = "NotReady"
 )
 
 //+genclient
@@ -60,6 +80,22 @@ type AddonSpec struct {
 	RequiredResourceTypes []schema.GroupVersionKind `json:"requiredResourceTypes,omitempty"`
 	// IsDefault indicates whether the addon is default
 	IsDefault bool `json:"isDefault,omitempty"`
+	// Chart references a chart in the Helm chart format that is rendered instead of the addon
+	// manifests. The chart values are built from the Variables and the cluster information.
+	// Charts are rendered by the addon controller, which only supports a subset of Helm.
+	Chart *AddonChart `json:"chart,omitempty"`
+}
+
+// AddonChart references a chart in the Helm chart format which is used as source for an addon
+type AddonChart struct {
+	// Name is the name of the chart
+	Name string `json:"name"`
+	// Version is the version of the chart. If set, the packaged chart is loaded from the
+	// addon chart repository, otherwise the unpacked chart is loaded from the addon folder
+	// of the same name.
+	Version string `json:"version,omitempty"`
+	// Namespace is the namespace the chart gets rendered for. Defaults to kube-system.
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // AddonList is a list of addons
@@ -73,6 +109,36 @@ type AddonList struct {
 
 type AddonStatus struct {
 	Conditions []AddonCondition `json:"conditions,omitempty"`
+	// AppliedResources are the objects that got applied to the user cluster when the addon
+	// was installed the last time. Objects that are not part of the addon anymore get pruned
+	// based on this list.
+	AppliedResources []AddonResourceReference `json:"appliedResources,omitempty"`
+	// UnhealthyObjects lists all objects of the addon which were missing, drifted or not ready
+	// during the last health check
+	UnhealthyObjects []AddonObjectStatus `json:"unhealthyObjects,omitempty"`
+}
+
+// AddonObjectStatus describes an object of an addon which is not healthy
+type AddonObjectStatus struct {
+	AddonResourceReference `json:",inline"`
+	State                  AddonObjectState `json:"state"`
+	// Message is a human readable description of what is wrong with the object
+	Message string `json:"message,omitempty"`
+}
+
+// AddonResourceReference references an object in the user cluster that belongs to an addon
+type AddonResourceReference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+func (r AddonResourceReference) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s %s (%s)", r.Kind, r.Name, r.APIVersion)
+	}
+	return fmt.Sprintf("%s %s/%s (%s)", r.Kind, r.Namespace, r.Name, r.APIVersion)
 }
 
 type AddonConditionType string
@@ -88,4 +154,10 @@ type AddonCondition struct {
 	// Last time the condition transit from one status to another.
 	// +optional
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// (brief) reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Human readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
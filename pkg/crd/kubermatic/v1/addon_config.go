@@ -45,6 +45,10 @@ type AddonConfigSpec struct {
 	LogoFormat string `json:"logoFormat,omitempty"`
 	// Controls that can be set for configured addon
 	Controls []AddonFormControl `json:"formSpec,omitempty"`
+	// Dependencies are the names of the addons which must be installed and healthy before the
+	// configured addon gets installed or upgraded. When addons are removed together, the
+	// configured addon gets removed before its dependencies.
+	Dependencies []string `json:"dependencies,omitempty"`
 }
 
 // AddonFormControl specifies addon form control
@@ -42,6 +42,11 @@ const (
 	// enabled when this Annotation is set with any value
 	AnnotationNameClusterAutoscalerEnabled = "kubermatic.io/cluster-autoscaler-enabled"
 
+	// AnnotationValueClusterAutoscalerNodeDeployments is the value of the AnnotationNameClusterAutoscalerEnabled
+	// annotation when the API enabled the cluster-autoscaler because node deployments have autoscaling bounds.
+	// Only annotations with this value are removed again once no node deployment has bounds anymore
+	AnnotationValueClusterAutoscalerNodeDeployments = "nodedeployments"
+
 	// CredentialPrefix is the prefix used for the secrets containing cloud provider crednentials.
 	CredentialPrefix = "credential"
 )
@@ -94,8 +99,8 @@ type ClusterSpec struct {
 	// HumanReadableName is the cluster name provided by the user
 	HumanReadableName string `json:"humanReadableName"`
 
-	// ExposeStrategy is the approach we use to expose this cluster, either via NodePort
-	// or via a dedicated LoadBalancer
+	// ExposeStrategy is the approach we use to expose this cluster, either via NodePort,
+	// via a dedicated LoadBalancer or via SNI on the shared port of the nodeport-proxy
 	ExposeStrategy corev1.ServiceType `json:"exposeStrategy"`
 
 	// Pause tells that this cluster is currently not managed by the controller.
@@ -124,6 +129,19 @@ type ClusterSpec struct {
 	AdmissionPlugins                    []string `json:"admissionPlugins,omitempty"`
 
 	AuditLogging *AuditLoggingSettings `json:"auditLogging,omitempty"`
+
+	// EtcdBackup optionally overrides the seed wide etcd backup settings for this cluster
+	EtcdBackup *EtcdBackupSettings `json:"etcdBackup,omitempty"`
+
+	// Hibernation optionally scales the control plane and the nodes of this cluster to zero
+	Hibernation *HibernationSettings `json:"hibernation,omitempty"`
+
+	// APIServerAllowedSourceRanges optionally restricts the CIDRs the apiserver is reachable
+	// from. The addresses of the nodes, the machine networks and the egress ranges of the seed
+	// are always allowed. Nodes which connect through a NAT gateway do so from its address, which
+	// must be part of the ranges. Unless the cluster is exposed by a LoadBalancer, the restriction
+	// requires the nodeport-proxy of the seed to use the Local external traffic policy.
+	APIServerAllowedSourceRanges []string `json:"apiServerAllowedSourceRanges,omitempty"`
 }
 
 const (
@@ -137,6 +155,12 @@ const (
 	ClusterFeatureRancherIntegration = "rancherIntegration"
 )
 
+// ExposeStrategySNI exposes the apiserver of a cluster on port 443 of the nodeport-proxy, which
+// routes the connections by the server name the clients send. Clients inside the user cluster
+// connect to the apiserver by IP and thus send no server name, they and openVPN use the NodePorts
+// on the in-cluster LoadBalancer of the nodeport-proxy instead.
+const ExposeStrategySNI corev1.ServiceType = "SNI"
+
 // ClusterConditionType is used to indicate the type of a cluster condition. For all condition
 // types, the `true` value must indicate success. All condition types must be registered within
 // the `AllClusterConditionTypes` variable.
@@ -167,8 +191,16 @@ const (
 	ClusterConditionRancherInitialized     ClusterConditionType = "RancherInitializedSuccessfully"
 	ClusterConditionRancherClusterImported ClusterConditionType = "RancherClusterImportedSuccessfully"
 
+	// ClusterConditionControlPlaneUpgraded indicates that the last control plane upgrade has been completed.
+	// It is false while an upgrade is in progress or after an upgrade has been rolled back.
+	ClusterConditionControlPlaneUpgraded ClusterConditionType = "ControlPlaneUpgraded"
+
 	ReasonClusterUpdateSuccessful = "ClusterUpdateSuccessful"
 	ReasonClusterUpdateInProgress = "ClusterUpdateInProgress"
+
+	ReasonControlPlaneUpgradeInProgress = "ControlPlaneUpgradeInProgress"
+	ReasonControlPlaneUpgradeCompleted  = "ControlPlaneUpgradeCompleted"
+	ReasonControlPlaneUpgradeRolledBack = "ControlPlaneUpgradeRolledBack"
 )
 
 var AllClusterConditionTypes = []ClusterConditionType{
@@ -245,6 +277,99 @@ type ClusterStatus struct {
 
 	// InheritedLabels are labels the cluster inherited from the project. They are read-only for users.
 	InheritedLabels map[string]string `json:"inheritedLabels,omitempty"`
+
+	// Versions contains the versions the control plane components are running with. Changes of
+	// the spec version are rolled out to the components one after another.
+	Versions ClusterVersionsStatus `json:"versions,omitempty"`
+
+	// Hibernation is set as long as the cluster is hibernated or gets hibernated or resumed.
+	Hibernation *HibernationStatus `json:"hibernation,omitempty"`
+}
+
+// ControlPlaneComponent is a component of the control plane which gets upgraded in its own stage.
+type ControlPlaneComponent string
+
+const (
+	ControlPlaneComponentEtcd              ControlPlaneComponent = "etcd"
+	ControlPlaneComponentApiserver         ControlPlaneComponent = "apiserver"
+	ControlPlaneComponentControllerManager ControlPlaneComponent = "controller-manager"
+	ControlPlaneComponentScheduler         ControlPlaneComponent = "scheduler"
+)
+
+// ControlPlaneUpgradeStages contains the components of the control plane in the order they get upgraded.
+var ControlPlaneUpgradeStages = []ControlPlaneComponent{
+	ControlPlaneComponentEtcd,
+	ControlPlaneComponentApiserver,
+	ControlPlaneComponentControllerManager,
+	ControlPlaneComponentScheduler,
+}
+
+// ClusterVersionsStatus contains the versions of the control plane components of a cluster.
+// Components without a version run with the version from the cluster spec.
+type ClusterVersionsStatus struct {
+	// ControlPlane is the version the whole control plane has been upgraded to.
+	ControlPlane *semver.Semver `json:"controlPlane,omitempty"`
+	// Etcd is the cluster version the etcd image has been chosen for. Etcd never gets downgraded.
+	Etcd              *semver.Semver `json:"etcd,omitempty"`
+	Apiserver         *semver.Semver `json:"apiserver,omitempty"`
+	ControllerManager *semver.Semver `json:"controllerManager,omitempty"`
+	Scheduler         *semver.Semver `json:"scheduler,omitempty"`
+	// Upgrade contains the progress of the control plane upgrade which is currently in progress.
+	Upgrade *ControlPlaneUpgradeStatus `json:"upgrade,omitempty"`
+	// FailedUpgrade is the version of the last upgrade which has been rolled back.
+	// Automatic updates do not upgrade to this version again.
+	FailedUpgrade *semver.Semver `json:"failedUpgrade,omitempty"`
+}
+
+// ControlPlaneUpgradeStatus describes a control plane upgrade which is in progress.
+type ControlPlaneUpgradeStatus struct {
+	From semver.Semver `json:"from"`
+	To   semver.Semver `json:"to"`
+	// Stage is the component which currently gets upgraded.
+	Stage ControlPlaneComponent `json:"stage"`
+	// StageStartTime is the time the current stage has been started. Stages which do not become
+	// healthy in time cause the upgrade to be rolled back.
+	StageStartTime metav1.Time `json:"stageStartTime"`
+	// StartTime is the time the upgrade has been started.
+	StartTime metav1.Time `json:"startTime,omitempty"`
+}
+
+// ComponentVersion returns the version the given control plane component runs with.
+func (s *ClusterVersionsStatus) ComponentVersion(component ControlPlaneComponent) *semver.Semver {
+	switch component {
+	case ControlPlaneComponentEtcd:
+		return s.Etcd
+	case ControlPlaneComponentApiserver:
+		return s.Apiserver
+	case ControlPlaneComponentControllerManager:
+		return s.ControllerManager
+	case ControlPlaneComponentScheduler:
+		return s.Scheduler
+	}
+	return nil
+}
+
+// SetComponentVersion sets the version the given control plane component runs with.
+func (s *ClusterVersionsStatus) SetComponentVersion(component ControlPlaneComponent, version *semver.Semver) {
+	switch component {
+	case ControlPlaneComponentEtcd:
+		s.Etcd = version
+	case ControlPlaneComponentApiserver:
+		s.Apiserver = version
+	case ControlPlaneComponentControllerManager:
+		s.ControllerManager = version
+	case ControlPlaneComponentScheduler:
+		s.Scheduler = version
+	}
+}
+
+// ComponentVersion returns the version the given control plane component should run with. Components
+// which have not been handled by a staged upgrade yet run with the version from the spec.
+func (c *Cluster) ComponentVersion(component ControlPlaneComponent) *semver.Semver {
+	if version := c.Status.Versions.ComponentVersion(component); version != nil && version.Version != nil {
+		return version
+	}
+	return &c.Spec.Version
 }
 
 // HasConditionValue returns true if the cluster status has the given condition with the given status.
@@ -284,14 +409,180 @@ type OIDCSettings struct {
 
 type AuditLoggingSettings struct {
 	Enabled bool `json:"enabled,omitempty"`
+	// PolicyPreset is the audit policy used by the apiserver, one of "metadata", "recommended"
+	// or "minimal". Defaults to "metadata", which logs the metadata of all requests.
+	PolicyPreset AuditPolicyPreset `json:"policyPreset,omitempty"`
+	// PolicyConfigMap is the name of a ConfigMap in the namespace of the seed containing a custom
+	// audit policy in its "policy.yaml" key. It takes precedence over the PolicyPreset.
+	PolicyConfigMap string `json:"policyConfigMap,omitempty"`
+	// Sink optionally forwards the audit events to a destination outside of the control plane.
+	// Without a sink the events are written to the log of the audit-logs sidecar of the apiserver.
+	Sink *AuditSinkSettings `json:"sink,omitempty"`
+}
+
+// AuditPolicyPreset is a built-in audit policy.
+type AuditPolicyPreset string
+
+const (
+	// AuditPolicyPresetMetadata logs the metadata of all requests.
+	AuditPolicyPresetMetadata AuditPolicyPreset = "metadata"
+	// AuditPolicyPresetRecommended logs the bodies of all write requests and the metadata of all
+	// other requests, except for secrets, configmaps and tokenreviews whose bodies are never logged.
+	// Requests of system components which are usually not relevant are skipped.
+	AuditPolicyPresetRecommended AuditPolicyPreset = "recommended"
+	// AuditPolicyPresetMinimal only logs the metadata of write requests.
+	AuditPolicyPresetMinimal AuditPolicyPreset = "minimal"
+)
+
+// AllAuditPolicyPresets contains all supported audit policy presets.
+var AllAuditPolicyPresets = []AuditPolicyPreset{
+	AuditPolicyPresetMetadata,
+	AuditPolicyPresetRecommended,
+	AuditPolicyPresetMinimal,
+}
+
+// AuditSinkSettings configures where the audit events get forwarded to. Multiple sinks can be used at once.
+type AuditSinkSettings struct {
+	File    *AuditFileSink    `json:"file,omitempty"`
+	Syslog  *AuditSyslogSink  `json:"syslog,omitempty"`
+	Webhook *AuditWebhookSink `json:"webhook,omitempty"`
+}
+
+// AuditFileSink keeps the audit log files on a PersistentVolumeClaim in the cluster namespace, so they survive
+// restarts of the apiserver. Every apiserver replica writes its files to its own directory on the volume.
+// The volume can not be changed once it got created, the sink has to be removed to change it.
+type AuditFileSink struct {
+	// StorageClassName is the storage class of the volume. Defaults to the default storage class of the seed.
+	StorageClassName string `json:"storageClassName,omitempty"`
+	// Size is the size of the volume, e.g. "50Gi". Defaults to 10Gi. Every apiserver replica and the
+	// replica replacing it during a rollout need at least 512Mi of it.
+	Size string `json:"size,omitempty"`
+	// AccessMode of the volume, either ReadWriteOnce or ReadWriteMany. Defaults to ReadWriteOnce,
+	// in which case all apiserver replicas run on the node the volume is attached to.
+	AccessMode corev1.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
+}
+
+// AuditSyslogSink sends the audit events to a syslog server in the RFC 5424 format.
+type AuditSyslogSink struct {
+	Host string `json:"host"`
+	Port int32  `json:"port"`
+	// Protocol is either "udp" or "tcp". Defaults to "udp".
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// AuditWebhookSink sends the audit events as JSON to an HTTP endpoint.
+type AuditWebhookSink struct {
+	// URL is the http or https URL the events get posted to.
+	URL string `json:"url"`
+}
+
+// MergeAuditLoggingSettings returns the audit logging settings of a cluster. The policy and the sink
+// default to the settings of its datacenter if the cluster does not configure them. If the datacenter
+// enforces audit logging, it is enabled regardless of the cluster settings and the policy and the sink
+// of the datacenter take precedence, so clusters can not send their events elsewhere.
+func MergeAuditLoggingSettings(cluster *AuditLoggingSettings, datacenter *AuditLoggingSettings, enforce bool) *AuditLoggingSettings {
+	if enforce {
+		enforced := &AuditLoggingSettings{}
+		if cluster != nil {
+			enforced = cluster.DeepCopy()
+		}
+		enforced.Enabled = true
+		cluster = enforced
+	}
+	if cluster == nil || !cluster.Enabled {
+		return cluster
+	}
+	merged := cluster.DeepCopy()
+	if datacenter == nil {
+		return merged
+	}
+	datacenterHasPolicy := datacenter.PolicyPreset != "" || datacenter.PolicyConfigMap != ""
+	if (enforce && datacenterHasPolicy) || (merged.PolicyPreset == "" && merged.PolicyConfigMap == "") {
+		merged.PolicyPreset = datacenter.PolicyPreset
+		merged.PolicyConfigMap = datacenter.PolicyConfigMap
+	}
+	if datacenter.Sink != nil && (enforce || merged.Sink == nil) {
+		merged.Sink = datacenter.Sink.DeepCopy()
+	}
+	return merged
+}
+
+// EtcdBackupSettings configures the etcd backups of a single cluster.
+type EtcdBackupSettings struct {
+	// Disabled disables the etcd backups for this cluster. Already existing backups
+	// are kept until the cluster gets deleted.
+	Disabled bool `json:"disabled,omitempty"`
+	// Schedule is a cron expression defining when backups are created, e.g. "@every 1h"
+	// or "0 * * * *". Defaults to the backup interval of the seed controller manager.
+	Schedule string `json:"schedule,omitempty"`
+	// RevisionsToKeep is the number of backups that are kept in the backup store. Older
+	// backups get deleted. Defaults to the setting of the configured store container.
+	RevisionsToKeep *int32 `json:"revisionsToKeep,omitempty"`
+}
+
+// BackupsDisabled returns true if etcd backups are disabled for this cluster
+func (s *EtcdBackupSettings) BackupsDisabled() bool {
+	return s != nil && s.Disabled
+}
+
+// HibernationSettings configures the hibernation of a single cluster. A hibernated cluster has
+// all its MachineDeployments and all control plane components scaled to zero.
+type HibernationSettings struct {
+	// Enabled hibernates the cluster until it gets disabled again, regardless of the schedules.
+	Enabled bool `json:"enabled,omitempty"`
+	// Schedules are recurring windows during which the cluster is hibernated.
+	Schedules []HibernationSchedule `json:"schedules,omitempty"`
+	// TimeZone is the IANA time zone the schedules are evaluated in, e.g. "Europe/Berlin".
+	// Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// HibernationSchedule is a recurring hibernation window. It uses the same format as the UpdateWindow.
+type HibernationSchedule struct {
+	// Start is the start of the window, e.g. "19:00" for a daily or "Fri 19:00" for a weekly window.
+	Start string `json:"start"`
+	// Length is the duration of the window, e.g. "12h". Daily windows must be shorter than a day
+	// and weekly windows shorter than a week.
+	Length string `json:"length"`
+}
+
+// HibernationPhase describes the state of a hibernated cluster.
+type HibernationPhase string
+
+const (
+	// HibernationPhaseHibernating means the nodes and the control plane are being scaled to zero.
+	HibernationPhaseHibernating HibernationPhase = "Hibernating"
+	// HibernationPhaseHibernated means the nodes and the control plane have been scaled to zero.
+	HibernationPhaseHibernated HibernationPhase = "Hibernated"
+	// HibernationPhaseResuming means the control plane and the nodes are being scaled back up.
+	HibernationPhaseResuming HibernationPhase = "Resuming"
+)
+
+// HibernationStatus contains the replica counts of a cluster from before its hibernation,
+// they get restored when the cluster resumes.
+type HibernationStatus struct {
+	Phase HibernationPhase `json:"phase"`
+	// LastTransitionTime is the time the phase changed the last time.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// MachineDeployments contains the replicas of the MachineDeployments in the user cluster by name.
+	MachineDeployments map[string]int32 `json:"machineDeployments,omitempty"`
+	// Deployments contains the replicas of the Deployments in the cluster namespace by name.
+	Deployments map[string]int32 `json:"deployments,omitempty"`
+	// StatefulSets contains the replicas of the StatefulSets in the cluster namespace by name.
+	StatefulSets map[string]int32 `json:"statefulSets,omitempty"`
+}
+
+// HibernationEnabled returns true if the cluster has been hibernated explicitly.
+func (s *HibernationSettings) HibernationEnabled() bool {
+	return s != nil && s.Enabled
 }
 
 type ComponentSettings struct {
-	Apiserver         APIServerSettings   `json:"apiserver"`
-	ControllerManager DeploymentSettings  `json:"controllerManager"`
-	Scheduler         DeploymentSettings  `json:"scheduler"`
-	Etcd              StatefulSetSettings `json:"etcd"`
-	Prometheus        StatefulSetSettings `json:"prometheus"`
+	Apiserver         APIServerSettings       `json:"apiserver"`
+	ControllerManager DeploymentSettings      `json:"controllerManager"`
+	Scheduler         DeploymentSettings      `json:"scheduler"`
+	Etcd              EtcdStatefulSetSettings `json:"etcd"`
+	Prometheus        StatefulSetSettings     `json:"prometheus"`
 }
 
 type APIServerSettings struct {
@@ -309,6 +600,13 @@ type StatefulSetSettings struct {
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
+type EtcdStatefulSetSettings struct {
+	// ClusterSize is the number of etcd members, either 3 or 5. Defaults to 3. The etcd cluster
+	// can only be scaled up, the removed members would count towards its quorum until they got removed.
+	ClusterSize int                          `json:"clusterSize,omitempty"`
+	Resources   *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
 // ClusterNetworkingConfig specifies the different networking
 // parameters for a cluster.
 type ClusterNetworkingConfig struct {
@@ -0,0 +1,120 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterMigrationResourceName represents "Resource" defined in Kubernetes
+	ClusterMigrationResourceName = "clustermigrations"
+
+	// ClusterMigrationKindName represents "Kind" defined in Kubernetes
+	ClusterMigrationKindName = "ClusterMigration"
+
+	// ClusterMigrationAnnotation is set on the cluster created in the target seed and
+	// holds the name of the migration that created it
+	ClusterMigrationAnnotation = "kubermatic.io/cluster-migration"
+
+	// ClusterExternalNameAnnotation pins the external name of a cluster, so a cluster which was
+	// migrated from another seed keeps the address its nodes and kubeconfigs use
+	ClusterExternalNameAnnotation = "kubermatic.io/external-name"
+)
+
+// ClusterMigrationPhase is the phase a ClusterMigration is currently in.
+type ClusterMigrationPhase string
+
+const (
+	// ClusterMigrationPhaseBackingUp means the cluster got paused in the source seed and a snapshot
+	// of its etcd is being taken.
+	ClusterMigrationPhaseBackingUp ClusterMigrationPhase = "BackingUp"
+	// ClusterMigrationPhaseProvisioning means the cluster, its namespace and its secrets are being
+	// created in the target seed.
+	ClusterMigrationPhaseProvisioning ClusterMigrationPhase = "Provisioning"
+	// ClusterMigrationPhaseRestoring means the snapshot is being restored in the target seed.
+	ClusterMigrationPhaseRestoring ClusterMigrationPhase = "Restoring"
+	// ClusterMigrationPhaseSwitchingOver means we wait for the control plane in the target seed to
+	// become healthy, for the admin to confirm the DNS switch and for the control plane to answer at
+	// the address of the cluster before the cluster gets removed from the source seed. The migration
+	// fails if the control plane does not become healthy or the DNS switch is not confirmed in time.
+	ClusterMigrationPhaseSwitchingOver ClusterMigrationPhase = "SwitchingOver"
+	// ClusterMigrationPhaseCompleted means the cluster is served by the target seed.
+	ClusterMigrationPhaseCompleted ClusterMigrationPhase = "Completed"
+	// ClusterMigrationPhaseFailed means the migration failed. The cluster got unpaused in the source
+	// seed and the cluster created in the target seed got removed again.
+	ClusterMigrationPhaseFailed ClusterMigrationPhase = "Failed"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterMigration specifies the migration of the control plane of a user cluster to another seed.
+type ClusterMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterMigrationSpec   `json:"spec"`
+	Status ClusterMigrationStatus `json:"status,omitempty"`
+}
+
+// ClusterMigrationSpec specifies details of a cluster migration
+type ClusterMigrationSpec struct {
+	// ClusterName is the name of the cluster whose control plane will be migrated
+	ClusterName string `json:"clusterName"`
+	// TargetDatacenter is the datacenter the cluster will be moved to. It must belong to another
+	// seed than the current datacenter of the cluster and use the same cloud provider.
+	TargetDatacenter string `json:"targetDatacenter"`
+	// DNSSwitchedOver must be set by the admin once the DNS record of the external name of the
+	// cluster points to the nodeport-proxy of the target seed. The cluster is only removed from the
+	// source seed and the migration only completes after this got confirmed.
+	DNSSwitchedOver bool `json:"dnsSwitchedOver,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterMigrationList is a list of cluster migrations
+type ClusterMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterMigration `json:"items"`
+}
+
+// ClusterMigrationStatus stores status information about a cluster migration
+type ClusterMigrationStatus struct {
+	// Phase is the phase the migration is currently in
+	Phase ClusterMigrationPhase `json:"phase,omitempty"`
+	// Message is a human readable description of the current phase or the reason of a failure
+	Message string `json:"message,omitempty"`
+	// SourceSeed is the seed the cluster got migrated from
+	SourceSeed string `json:"sourceSeed,omitempty"`
+	// TargetSeed is the seed the cluster gets migrated to
+	TargetSeed string `json:"targetSeed,omitempty"`
+	// BackupName is the name of the etcd snapshot that is restored in the target seed
+	BackupName string `json:"backupName,omitempty"`
+	// StartTime is the time the migration has been started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// SwitchOverStartTime is the time the migration started to wait for the control plane in the target seed
+	SwitchOverStartTime *metav1.Time `json:"switchOverStartTime,omitempty"`
+	// CompletionTime is the time the migration has been completed successfully
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// IsFinished returns true if the migration has either been completed or failed
+func (s *ClusterMigrationStatus) IsFinished() bool {
+	return s.Phase == ClusterMigrationPhaseCompleted || s.Phase == ClusterMigrationPhaseFailed
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// ClusterTemplateResourceName represents "Resource" defined in Kubernetes
+	ClusterTemplateResourceName = "clustertemplates"
+
+	// ClusterTemplateKindName represents "Kind" defined in Kubernetes
+	ClusterTemplateKindName = "ClusterTemplate"
+
+	// ClusterTemplateScopeLabelKey is the label key which defines the scope of a cluster template
+	ClusterTemplateScopeLabelKey = "scope"
+	// ClusterTemplateProjectScope is the scope of templates which can only be used in their project
+	ClusterTemplateProjectScope = "project"
+	// ClusterTemplateGlobalScope is the scope of templates which can be used in all projects
+	ClusterTemplateGlobalScope = "global"
+)
+
+//+genclient
+//+genclient:nonNamespaced
+
+// ClusterTemplate is a template to create clusters with the same specification. Templates
+// with project scope are owned by their project, templates with global scope are managed
+// by admins and can be used in all projects.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ClusterTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterTemplateSpec `json:"spec"`
+}
+
+// ClusterTemplateSpec specifies the clusters which get created from a template
+type ClusterTemplateSpec struct {
+	// HumanReadableName is the name of the template
+	HumanReadableName string `json:"humanReadableName"`
+	// ClusterType is the type of the clusters, either kubernetes or openshift
+	ClusterType string `json:"clusterType,omitempty"`
+	// Credential is the name of the preset which provides the cloud credentials for the clusters.
+	// Templates never contain credentials themselves.
+	Credential string `json:"credential,omitempty"`
+	// ClusterLabels are the labels every cluster created from the template gets
+	ClusterLabels map[string]string `json:"clusterLabels,omitempty"`
+	// ClusterSpec is the specification of the clusters created from the template
+	ClusterSpec ClusterSpec `json:"clusterSpec"`
+	// NodeDeployments are the node deployments which get created in every cluster. They use
+	// the format of the node deployments in the Kubermatic API.
+	NodeDeployments []runtime.RawExtension `json:"nodeDeployments,omitempty"`
+}
+
+// ClusterTemplateList specifies a list of cluster templates
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ClusterTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterTemplate `json:"items"`
+}
+
+// IsGlobal returns true if the template can be used in all projects
+func (t *ClusterTemplate) IsGlobal() bool {
+	return t.Labels[ClusterTemplateScopeLabelKey] == ClusterTemplateGlobalScope
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestMergeAuditLoggingSettings(t *testing.T) {
+	datacenter := &AuditLoggingSettings{
+		PolicyPreset: AuditPolicyPresetRecommended,
+		Sink: &AuditSinkSettings{
+			Webhook: &AuditWebhookSink{URL: "https://audit.example.com"},
+		},
+	}
+	cluster := &AuditLoggingSettings{
+		Enabled:         true,
+		PolicyConfigMap: "custom-policy",
+		Sink: &AuditSinkSettings{
+			Syslog: &AuditSyslogSink{Host: "syslog.example.com", Port: 514},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		cluster    *AuditLoggingSettings
+		datacenter *AuditLoggingSettings
+		enforce    bool
+		expected   *AuditLoggingSettings
+	}{
+		{
+			name:       "disabled cluster is left alone",
+			cluster:    &AuditLoggingSettings{},
+			datacenter: datacenter,
+			expected:   &AuditLoggingSettings{},
+		},
+		{
+			name:       "cluster settings take precedence",
+			cluster:    cluster,
+			datacenter: datacenter,
+			expected:   cluster,
+		},
+		{
+			name:       "datacenter settings are used as defaults",
+			cluster:    &AuditLoggingSettings{Enabled: true},
+			datacenter: datacenter,
+			expected: &AuditLoggingSettings{
+				Enabled:      true,
+				PolicyPreset: datacenter.PolicyPreset,
+				Sink:         datacenter.Sink,
+			},
+		},
+		{
+			name:       "enforcing datacenter overrides the cluster policy and sink",
+			cluster:    cluster,
+			datacenter: datacenter,
+			enforce:    true,
+			expected: &AuditLoggingSettings{
+				Enabled:      true,
+				PolicyPreset: datacenter.PolicyPreset,
+				Sink:         datacenter.Sink,
+			},
+		},
+		{
+			name:       "enforcing datacenter enables audit logging",
+			cluster:    nil,
+			datacenter: datacenter,
+			enforce:    true,
+			expected: &AuditLoggingSettings{
+				Enabled:      true,
+				PolicyPreset: datacenter.PolicyPreset,
+				Sink:         datacenter.Sink,
+			},
+		},
+		{
+			name:       "enforcing datacenter without settings keeps the cluster settings",
+			cluster:    cluster,
+			datacenter: nil,
+			enforce:    true,
+			expected:   cluster,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			merged := MergeAuditLoggingSettings(tc.cluster, tc.datacenter, tc.enforce)
+			if diff := deep.Equal(merged, tc.expected); diff != nil {
+				t.Errorf("unexpected settings: %v", diff)
+			}
+		})
+	}
+}
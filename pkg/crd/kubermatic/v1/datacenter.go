@@ -18,6 +18,7 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
@@ -86,6 +87,28 @@ type SeedSpec struct {
 	ProxySettings *ProxySettings `json:"proxy_settings,omitempty"`
 	// Optional: ExposeStrategy explicitly sets the expose strategy for this seed cluster, if not set, the default provided by the master is used.
 	ExposeStrategy corev1.ServiceType `json:"expose_strategy,omitempty"`
+	// Optional: Capacity limits the user cluster control planes this seed accepts. New clusters
+	// of a full seed are created in one of the alternative datacenters of the requested datacenter.
+	Capacity *SeedCapacity `json:"capacity,omitempty"`
+	// Optional: BackupStore identifies the store the etcd backups of this seed are uploaded to,
+	// e.g. the URL of the S3 bucket. Clusters can only be migrated between seeds which use the
+	// same backup store.
+	BackupStore string `json:"backup_store,omitempty"`
+	// Optional: EgressRanges are the CIDRs the connections of the seed and the master cluster to
+	// the apiservers of user clusters originate from, e.g. the kubermatic-api and controllers which
+	// are not running inside the seed. They are always allowed to access apiservers which are
+	// restricted to certain source ranges.
+	EgressRanges []string `json:"egress_ranges,omitempty"`
+}
+
+// SeedCapacity limits the control planes of a seed, a limit which is not set is not enforced
+type SeedCapacity struct {
+	// MaxClusters is the maximum number of user clusters whose control planes run in the seed
+	MaxClusters *int64 `json:"max_clusters,omitempty"`
+	// ControlPlaneCPU is the maximum amount of CPU requested by all control planes in the seed
+	ControlPlaneCPU *resource.Quantity `json:"control_plane_cpu,omitempty"`
+	// ControlPlaneMemory is the maximum amount of memory requested by all control planes in the seed
+	ControlPlaneMemory *resource.Quantity `json:"control_plane_memory,omitempty"`
 }
 
 type NodeportProxyConfig struct {
@@ -103,6 +126,17 @@ type NodeportProxyConfig struct {
 	// Updater configures the component responsible for updating the LoadBalancer
 	// service.
 	Updater NodeportProxyComponent `json:"updater,omitempty"`
+	// EnableSNI exposes port 443 on the LoadBalancer, on which the nodeport-proxy routes TLS
+	// connections to the apiservers by their server name, and creates the additional
+	// nodeport-proxy-in-cluster LoadBalancer for the connections from inside the user clusters.
+	// It is always enabled if the SNI expose strategy is the default for the seed.
+	EnableSNI bool `json:"enable_sni,omitempty"`
+	// Optional: ExternalTrafficPolicy of the LoadBalancer services, defaults to Cluster. Local
+	// preserves the client addresses, which the nodeport-proxy requires to enforce the allowed
+	// source ranges of apiservers which are not exposed by their own LoadBalancer. With Local,
+	// connections only get routed to the nodeport-proxy pods on the node the LoadBalancer sends
+	// them to, so the LoadBalancer must check the health of the nodes, which not all do.
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicyType `json:"external_traffic_policy,omitempty"`
 }
 
 type NodeportProxyComponent struct {
@@ -160,14 +194,47 @@ type DatacenterSpec struct {
 	// ignoring cluster-specific settings.
 	EnforceAuditLogging bool `json:"enforceAuditLogging"`
 
+	// AuditLogging contains the audit policy and sink used by clusters within the DC which have
+	// audit logging enabled but do not configure their own policy or sink. If EnforceAuditLogging
+	// is set, they are used regardless of the cluster settings. Enabled is ignored.
+	AuditLogging *AuditLoggingSettings `json:"auditLogging,omitempty"`
+
 	// EnforcePodSecurityPolicy enforces pod security policy plugin on every clusters within the DC,
 	// ignoring cluster-specific settings
 	EnforcePodSecurityPolicy bool `json:"enforcePodSecurityPolicy"`
+
+	// Optional: AlternativeDatacenters are equivalent datacenters of other seeds which use the same
+	// cloud provider. When the seed of this datacenter is full, new clusters are created in the
+	// first of them whose seed has capacity left.
+	AlternativeDatacenters []string `json:"alternativeDatacenters,omitempty"`
+
+	// Optional: OperatingSystemImages is the catalog of operating system images node deployments
+	// in this datacenter can pick from. It takes precedence over the image lists of the providers.
+	OperatingSystemImages []OperatingSystemImage `json:"operatingSystemImages,omitempty"`
 }
 
 // ImageList defines a map of operating system and the image to use
 type ImageList map[providerconfig.OperatingSystem]string
 
+// OperatingSystemImage is an image in the operating system image catalog of a datacenter
+type OperatingSystemImage struct {
+	// Name uniquely identifies the image within the datacenter, e.g. "ubuntu-20.04".
+	Name string `json:"name"`
+	// OperatingSystem of the image, e.g. "ubuntu".
+	OperatingSystem providerconfig.OperatingSystem `json:"operatingSystem"`
+	// Optional: Version of the operating system, e.g. "20.04".
+	Version string `json:"version,omitempty"`
+	// Image is the provider specific image, i.e. the AMI on AWS, the image ID on Azure, the
+	// custom image on GCP, the image name on OpenStack or the template on vSphere.
+	Image string `json:"image"`
+	// Optional: Default marks the image used for node deployments with this operating system
+	// which do not pick an image. There can only be one default image per operating system.
+	Default bool `json:"default,omitempty"`
+	// Optional: Deprecated images can not be picked by new node deployments anymore, node
+	// deployments which still use them get a warning.
+	Deprecated bool `json:"deprecated,omitempty"`
+}
+
 // DatacenterSpecHetzner describes a Hetzner cloud datacenter
 type DatacenterSpecHetzner struct {
 	// Datacenter location, e.g. "nbg1-dc3". A list of existing datacenters can be found
@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// EtcdRestoreResourceName represents "Resource" defined in Kubernetes
+	EtcdRestoreResourceName = "etcdrestores"
+
+	// EtcdRestoreKindName represents "Kind" defined in Kubernetes
+	EtcdRestoreKindName = "EtcdRestore"
+)
+
+// EtcdRestorePhase is the phase an EtcdRestore is currently in.
+type EtcdRestorePhase string
+
+const (
+	// EtcdRestorePhaseStarted means the cluster got paused and its control plane is being scaled down.
+	EtcdRestorePhaseStarted EtcdRestorePhase = "Started"
+	// EtcdRestorePhaseRestoring means the snapshot is being restored on every etcd member.
+	EtcdRestorePhaseRestoring EtcdRestorePhase = "Restoring"
+	// EtcdRestorePhaseEtcdLaunching means the cluster got unpaused and we wait for etcd to come back up.
+	EtcdRestorePhaseEtcdLaunching EtcdRestorePhase = "EtcdLaunching"
+	// EtcdRestorePhaseCompleted means the restore finished successfully.
+	EtcdRestorePhaseCompleted EtcdRestorePhase = "Completed"
+	// EtcdRestorePhaseFailed means the restore failed. The cluster stays paused in case
+	// the etcd data might already have been touched, so an admin can investigate.
+	EtcdRestorePhaseFailed EtcdRestorePhase = "Failed"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EtcdRestore specifies a restore of the etcd of a user cluster from a backup snapshot.
+// It must be created in the namespace of the cluster it references.
+type EtcdRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EtcdRestoreSpec   `json:"spec"`
+	Status EtcdRestoreStatus `json:"status,omitempty"`
+}
+
+// EtcdRestoreSpec specifies details of an etcd restore
+type EtcdRestoreSpec struct {
+	// Cluster is the reference to the cluster whose etcd will be restored
+	Cluster corev1.ObjectReference `json:"cluster"`
+	// BackupName is the name of the snapshot in the backup store that should be restored,
+	// as created by the backup controller
+	BackupName string `json:"backupName"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EtcdRestoreList is a list of etcd restores
+type EtcdRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []EtcdRestore `json:"items"`
+}
+
+// EtcdRestoreStatus stores status information about an etcd restore
+type EtcdRestoreStatus struct {
+	// Phase is the phase the restore is currently in
+	Phase EtcdRestorePhase `json:"phase,omitempty"`
+	// Message is a human readable description of the current phase or the reason of a failure
+	Message string `json:"message,omitempty"`
+	// StartTime is the time the restore has been started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the restore has been completed successfully
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// IsFinished returns true if the restore has either been completed or failed
+func (s *EtcdRestoreStatus) IsFinished() bool {
+	return s.Phase == EtcdRestorePhaseCompleted || s.Phase == EtcdRestorePhaseFailed
+}
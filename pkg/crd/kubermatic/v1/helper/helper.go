@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"sort"
 
+	"github.com/kubermatic/kubermatic/pkg/collectors"
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/resources"
 
@@ -51,6 +52,11 @@ func ClusterReconcileWrapper(
 	if cluster.Spec.Pause {
 		return nil, nil
 	}
+	// The hibernation controller owns the replicas of the control plane while the cluster is
+	// hibernated or gets hibernated or resumed, reconciling it would scale it up again
+	if cluster.Status.Hibernation != nil {
+		return nil, nil
+	}
 
 	reconcilingStatus := corev1.ConditionFalse
 	result, err := reconcile()
@@ -58,6 +64,9 @@ func ClusterReconcileWrapper(
 	if err == nil && (result == nil || (!result.Requeue && result.RequeueAfter == 0)) {
 		reconcilingStatus = corev1.ConditionTrue
 	}
+	if err != nil {
+		collectors.IncClusterReconcileErrors(conditionType)
+	}
 	errs := []error{err}
 	oldCluster := cluster.DeepCopy()
 	SetClusterCondition(cluster, conditionType, reconcilingStatus, "", "")
@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PricingCatalogResourceName represents "Resource" defined in Kubernetes
+	PricingCatalogResourceName = "pricingcatalogs"
+
+	// PricingCatalogKindName represents "Kind" defined in Kubernetes
+	PricingCatalogKindName = "PricingCatalog"
+
+	// GlobalPricingCatalogName is the name of the pricing catalog used to estimate the costs of clusters
+	GlobalPricingCatalogName = "globalpricing"
+)
+
+//+genclient
+//+genclient:nonNamespaced
+
+// PricingCatalog maps the machine sizes of the cloud providers to their hourly costs. It is maintained
+// by admins and used to estimate the costs of clusters and projects from their node deployments.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type PricingCatalog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PricingCatalogSpec `json:"spec"`
+}
+
+// PricingCatalogSpec specifies the prices of machine sizes
+type PricingCatalogSpec struct {
+	// Currency all prices are given in, e.g. USD
+	Currency string `json:"currency"`
+	// Prices of the machine sizes
+	Prices []MachinePrice `json:"prices"`
+}
+
+// MachinePrice is the hourly cost of a machine size of a cloud provider
+type MachinePrice struct {
+	// Provider is the name of the cloud provider, e.g. aws or digitalocean
+	Provider string `json:"provider"`
+	// Datacenter limits the price to a single datacenter. Prices without a datacenter apply to
+	// all datacenters of the provider which have no price of their own.
+	Datacenter string `json:"datacenter,omitempty"`
+	// Size is the name of the machine size as used by the provider, e.g. t3.medium
+	Size string `json:"size"`
+	// HourlyCost is the cost of running a single machine of the size for an hour
+	HourlyCost float64 `json:"hourlyCost"`
+}
+
+// HourlyCost returns the hourly cost of the given machine size in the given datacenter. Prices
+// of the datacenter take precedence over the ones of the provider.
+func (s *PricingCatalogSpec) HourlyCost(provider, datacenter, size string) (float64, bool) {
+	var (
+		cost  float64
+		found bool
+	)
+	for _, price := range s.Prices {
+		if price.Provider != provider || price.Size != size {
+			continue
+		}
+		if price.Datacenter == datacenter {
+			return price.HourlyCost, true
+		}
+		if price.Datacenter == "" {
+			cost, found = price.HourlyCost, true
+		}
+	}
+	return cost, found
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PricingCatalogList is a list of pricing catalogs
+type PricingCatalogList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []PricingCatalog `json:"items"`
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -56,11 +57,57 @@ type Project struct {
 // ProjectSpec is a specification of a project.
 type ProjectSpec struct {
 	Name string `json:"name"`
+	// Quota limits the resources the clusters of the project can consume.
+	// No quota means that the project is not limited.
+	Quota *ProjectQuota `json:"quota,omitempty"`
+	// OIDCGroups grant the members of groups of the identity provider access to the project.
+	// Users whose token contains one of the groups are mapped to its role, unless they are
+	// members of the project through a UserProjectBinding. The first matching group wins.
+	OIDCGroups []ProjectOIDCGroup `json:"oidcGroups,omitempty"`
+}
+
+// ProjectOIDCGroup maps the members of an OIDC group to a role in the project
+type ProjectOIDCGroup struct {
+	// Name is the name of the group as it appears in the groups claim of the token
+	Name string `json:"name"`
+	// Role is the group prefix the members are mapped to, e.g. "editors" or the name of a ProjectRole
+	Role string `json:"role"`
+}
+
+// ProjectQuota limits the resources of a project, a limit which is not set is not enforced.
+type ProjectQuota struct {
+	// Clusters is the maximum number of clusters
+	Clusters *int64 `json:"clusters,omitempty"`
+	// Nodes is the maximum number of nodes of all node deployments
+	Nodes *int64 `json:"nodes,omitempty"`
+	// CPU is the maximum amount of CPU of all node deployments
+	CPU *resource.Quantity `json:"cpu,omitempty"`
+	// Memory is the maximum amount of memory of all node deployments
+	Memory *resource.Quantity `json:"memory,omitempty"`
 }
 
 // ProjectStatus represents the current status of a project.
 type ProjectStatus struct {
 	Phase string `json:"phase"`
+	// Usage is the resource usage of the project which was observed last
+	Usage *ProjectResourceUsage `json:"usage,omitempty"`
+}
+
+// ProjectResourceUsage is the resource usage of a project
+type ProjectResourceUsage struct {
+	// Clusters is the number of clusters
+	Clusters int64 `json:"clusters"`
+	// Nodes is the number of nodes of all node deployments
+	Nodes int64 `json:"nodes"`
+	// CPU is the amount of CPU of all node deployments with a known size
+	CPU resource.Quantity `json:"cpu"`
+	// Memory is the amount of memory of all node deployments with a known size
+	Memory resource.Quantity `json:"memory"`
+	// UnknownSizeNodes is the number of nodes whose size can not be determined, they are
+	// neither part of the CPU nor the memory
+	UnknownSizeNodes int64 `json:"unknownSizeNodes,omitempty"`
+	// LastUpdated is the time when the usage was observed to have changed
+	LastUpdated metav1.Time `json:"lastUpdated,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
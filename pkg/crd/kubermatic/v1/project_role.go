@@ -0,0 +1,133 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ProjectRoleResourceName represents "Resource" defined in Kubernetes
+	ProjectRoleResourceName = "projectroles"
+
+	// ProjectRoleKindName represents "Kind" defined in Kubernetes
+	ProjectRoleKindName = "ProjectRole"
+)
+
+// ProjectRoleResource is a kind of project resource a ProjectRole grants access to
+type ProjectRoleResource string
+
+const (
+	ProjectRoleResourceClusters        ProjectRoleResource = "clusters"
+	ProjectRoleResourceNodeDeployments ProjectRoleResource = "nodedeployments"
+	ProjectRoleResourceSSHKeys         ProjectRoleResource = "sshkeys"
+	ProjectRoleResourceAddons          ProjectRoleResource = "addons"
+	ProjectRoleResourceMembers         ProjectRoleResource = "members"
+	ProjectRoleResourceServiceAccounts ProjectRoleResource = "serviceaccounts"
+)
+
+// AllProjectRoleResources holds all resources a ProjectRole can grant access to
+var AllProjectRoleResources = []ProjectRoleResource{
+	ProjectRoleResourceClusters,
+	ProjectRoleResourceNodeDeployments,
+	ProjectRoleResourceSSHKeys,
+	ProjectRoleResourceAddons,
+	ProjectRoleResourceMembers,
+	ProjectRoleResourceServiceAccounts,
+}
+
+// ProjectRoleVerb is an action a ProjectRole allows on a resource
+type ProjectRoleVerb string
+
+const (
+	ProjectRoleVerbGet    ProjectRoleVerb = "get"
+	ProjectRoleVerbCreate ProjectRoleVerb = "create"
+	ProjectRoleVerbUpdate ProjectRoleVerb = "update"
+	ProjectRoleVerbDelete ProjectRoleVerb = "delete"
+)
+
+// AllProjectRoleVerbs holds all verbs a ProjectRole can allow
+var AllProjectRoleVerbs = []ProjectRoleVerb{
+	ProjectRoleVerbGet,
+	ProjectRoleVerbCreate,
+	ProjectRoleVerbUpdate,
+	ProjectRoleVerbDelete,
+}
+
+//+genclient
+//+genclient:nonNamespaced
+
+// ProjectRole is a custom role members of a project can be assigned to, in addition to
+// the built-in owners, editors and viewers. The name of the role is used as the group
+// prefix of its members, e.g. "operators-<project-id>". ProjectRoles are managed by admins.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ProjectRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ProjectRoleSpec `json:"spec"`
+}
+
+// ProjectRoleSpec specifies the permissions granted by a ProjectRole
+type ProjectRoleSpec struct {
+	// HumanReadableName is the name of the role shown to users
+	HumanReadableName string `json:"humanReadableName,omitempty"`
+	// Rules grant verbs on the resources of a project. Members of a role can always
+	// get the project itself.
+	Rules []ProjectRoleRule `json:"rules,omitempty"`
+}
+
+// ProjectRoleRule grants verbs on a kind of project resource
+type ProjectRoleRule struct {
+	Resource ProjectRoleResource `json:"resource"`
+	Verbs    []ProjectRoleVerb   `json:"verbs"`
+}
+
+// ProjectRoleList specifies a list of project roles
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ProjectRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ProjectRole `json:"items"`
+}
+
+// Allows returns true if the role allows the verb on the resource
+func (s *ProjectRoleSpec) Allows(resource ProjectRoleResource, verb ProjectRoleVerb) bool {
+	for _, rule := range s.Rules {
+		if rule.Resource != resource {
+			continue
+		}
+		for _, v := range rule.Verbs {
+			if v == verb {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// VerbsFor returns the verbs the role allows on the resource, in the order of AllProjectRoleVerbs
+func (s *ProjectRoleSpec) VerbsFor(resource ProjectRoleResource) []ProjectRoleVerb {
+	verbs := []ProjectRoleVerb{}
+	for _, verb := range AllProjectRoleVerbs {
+		if s.Allows(resource, verb) {
+			verbs = append(verbs, verb)
+		}
+	}
+	return verbs
+}
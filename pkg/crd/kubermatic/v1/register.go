@@ -73,6 +73,16 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&PresetList{},
 		&AdmissionPlugin{},
 		&AdmissionPluginList{},
+		&EtcdRestore{},
+		&EtcdRestoreList{},
+		&ClusterTemplate{},
+		&ClusterTemplateList{},
+		&ProjectRole{},
+		&ProjectRoleList{},
+		&ClusterMigration{},
+		&ClusterMigrationList{},
+		&PricingCatalog{},
+		&PricingCatalogList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
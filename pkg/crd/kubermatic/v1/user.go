@@ -51,6 +51,12 @@ type UserSpec struct {
 	IsAdmin                 bool                                    `json:"admin"`
 	Settings                *UserSettings                           `json:"settings,omitempty"`
 	TokenBlackListReference *providerconfig.GlobalSecretKeySelector `json:"tokenBlackListReference,omitempty"`
+	// Groups are the OIDC groups of the user, they are taken from the most recently issued token
+	// and grant access to the projects bound to them, see ProjectSpec.OIDCGroups
+	Groups []string `json:"groups,omitempty"`
+	// GroupsIssuedAt is the issue time of the token the Groups were taken from. The groups of
+	// tokens issued before are ignored, so that an old token can't restore revoked groups.
+	GroupsIssuedAt *metav1.Time `json:"groupsIssuedAt,omitempty"`
 }
 
 // UserSettings represent an user settings
@@ -98,6 +98,22 @@ func (in *Addon) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonChart) DeepCopyInto(out *AddonChart) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonChart.
+func (in *AddonChart) DeepCopy() *AddonChart {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonChart)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonCondition) DeepCopyInto(out *AddonCondition) {
 	*out = *in
@@ -184,6 +200,11 @@ func (in *AddonConfigSpec) DeepCopyInto(out *AddonConfigSpec) {
 		*out = make([]AddonFormControl, len(*in))
 		copy(*out, *in)
 	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -246,6 +267,39 @@ func (in *AddonList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonObjectStatus) DeepCopyInto(out *AddonObjectStatus) {
+	*out = *in
+	out.AddonResourceReference = in.AddonResourceReference
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonObjectStatus.
+func (in *AddonObjectStatus) DeepCopy() *AddonObjectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonObjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonResourceReference) DeepCopyInto(out *AddonResourceReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonResourceReference.
+func (in *AddonResourceReference) DeepCopy() *AddonResourceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonResourceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonSpec) DeepCopyInto(out *AddonSpec) {
 	*out = *in
@@ -256,6 +310,11 @@ func (in *AddonSpec) DeepCopyInto(out *AddonSpec) {
 		*out = make([]schema.GroupVersionKind, len(*in))
 		copy(*out, *in)
 	}
+	if in.Chart != nil {
+		in, out := &in.Chart, &out.Chart
+		*out = new(AddonChart)
+		**out = **in
+	}
 	return
 }
 
@@ -279,6 +338,16 @@ func (in *AddonStatus) DeepCopyInto(out *AddonStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]AddonResourceReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnhealthyObjects != nil {
+		in, out := &in.UnhealthyObjects, &out.UnhealthyObjects
+		*out = make([]AddonObjectStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -410,9 +479,30 @@ func (in *AlibabaCloudSpec) DeepCopy() *AlibabaCloudSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditFileSink) DeepCopyInto(out *AuditFileSink) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditFileSink.
+func (in *AuditFileSink) DeepCopy() *AuditFileSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditFileSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuditLoggingSettings) DeepCopyInto(out *AuditLoggingSettings) {
 	*out = *in
+	if in.Sink != nil {
+		in, out := &in.Sink, &out.Sink
+		*out = new(AuditSinkSettings)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -426,6 +516,69 @@ func (in *AuditLoggingSettings) DeepCopy() *AuditLoggingSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditSinkSettings) DeepCopyInto(out *AuditSinkSettings) {
+	*out = *in
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(AuditFileSink)
+		**out = **in
+	}
+	if in.Syslog != nil {
+		in, out := &in.Syslog, &out.Syslog
+		*out = new(AuditSyslogSink)
+		**out = **in
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(AuditWebhookSink)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditSinkSettings.
+func (in *AuditSinkSettings) DeepCopy() *AuditSinkSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditSinkSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditSyslogSink) DeepCopyInto(out *AuditSyslogSink) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditSyslogSink.
+func (in *AuditSyslogSink) DeepCopy() *AuditSyslogSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditSyslogSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditWebhookSink) DeepCopyInto(out *AuditWebhookSink) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditWebhookSink.
+func (in *AuditWebhookSink) DeepCopy() *AuditWebhookSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditWebhookSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Azure) DeepCopyInto(out *Azure) {
 	*out = *in
@@ -687,6 +840,111 @@ func (in *ClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMigration) DeepCopyInto(out *ClusterMigration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigration.
+func (in *ClusterMigration) DeepCopy() *ClusterMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMigrationList) DeepCopyInto(out *ClusterMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigrationList.
+func (in *ClusterMigrationList) DeepCopy() *ClusterMigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMigrationSpec) DeepCopyInto(out *ClusterMigrationSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigrationSpec.
+func (in *ClusterMigrationSpec) DeepCopy() *ClusterMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMigrationStatus) DeepCopyInto(out *ClusterMigrationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SwitchOverStartTime != nil {
+		in, out := &in.SwitchOverStartTime, &out.SwitchOverStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigrationStatus.
+func (in *ClusterMigrationStatus) DeepCopy() *ClusterMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterNetworkingConfig) DeepCopyInto(out *ClusterNetworkingConfig) {
 	*out = *in
@@ -745,7 +1003,22 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 	if in.AuditLogging != nil {
 		in, out := &in.AuditLogging, &out.AuditLogging
 		*out = new(AuditLoggingSettings)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EtcdBackup != nil {
+		in, out := &in.EtcdBackup, &out.EtcdBackup
+		*out = new(EtcdBackupSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hibernation != nil {
+		in, out := &in.Hibernation, &out.Hibernation
+		*out = new(HibernationSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIServerAllowedSourceRanges != nil {
+		in, out := &in.APIServerAllowedSourceRanges, &out.APIServerAllowedSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
@@ -814,6 +1087,12 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*out)[key] = val
 		}
 	}
+	in.Versions.DeepCopyInto(&out.Versions)
+	if in.Hibernation != nil {
+		in, out := &in.Hibernation, &out.Hibernation
+		*out = new(HibernationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -827,6 +1106,148 @@ func (in *ClusterStatus) DeepCopy() *ClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplate) DeepCopyInto(out *ClusterTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplate.
+func (in *ClusterTemplate) DeepCopy() *ClusterTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateList) DeepCopyInto(out *ClusterTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateList.
+func (in *ClusterTemplateList) DeepCopy() *ClusterTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateSpec) DeepCopyInto(out *ClusterTemplateSpec) {
+	*out = *in
+	if in.ClusterLabels != nil {
+		in, out := &in.ClusterLabels, &out.ClusterLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.ClusterSpec.DeepCopyInto(&out.ClusterSpec)
+	if in.NodeDeployments != nil {
+		in, out := &in.NodeDeployments, &out.NodeDeployments
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateSpec.
+func (in *ClusterTemplateSpec) DeepCopy() *ClusterTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVersionsStatus) DeepCopyInto(out *ClusterVersionsStatus) {
+	*out = *in
+	if in.ControlPlane != nil {
+		in, out := &in.ControlPlane, &out.ControlPlane
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Etcd != nil {
+		in, out := &in.Etcd, &out.Etcd
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Apiserver != nil {
+		in, out := &in.Apiserver, &out.Apiserver
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(ControlPlaneUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailedUpgrade != nil {
+		in, out := &in.FailedUpgrade, &out.FailedUpgrade
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVersionsStatus.
+func (in *ClusterVersionsStatus) DeepCopy() *ClusterVersionsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVersionsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentSettings) DeepCopyInto(out *ComponentSettings) {
 	*out = *in
@@ -848,6 +1269,26 @@ func (in *ComponentSettings) DeepCopy() *ComponentSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneUpgradeStatus) DeepCopyInto(out *ControlPlaneUpgradeStatus) {
+	*out = *in
+	out.From = in.From.DeepCopy()
+	out.To = in.To.DeepCopy()
+	in.StageStartTime.DeepCopyInto(&out.StageStartTime)
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneUpgradeStatus.
+func (in *ControlPlaneUpgradeStatus) DeepCopy() *ControlPlaneUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomLink) DeepCopyInto(out *CustomLink) {
 	*out = *in
@@ -970,6 +1411,21 @@ func (in *DatacenterSpec) DeepCopyInto(out *DatacenterSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AuditLogging != nil {
+		in, out := &in.AuditLogging, &out.AuditLogging
+		*out = new(AuditLoggingSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AlternativeDatacenters != nil {
+		in, out := &in.AlternativeDatacenters, &out.AlternativeDatacenters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OperatingSystemImages != nil {
+		in, out := &in.OperatingSystemImages, &out.OperatingSystemImages
+		*out = make([]OperatingSystemImage, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1290,6 +1746,150 @@ func (in *DigitaloceanCloudSpec) DeepCopy() *DigitaloceanCloudSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupSettings) DeepCopyInto(out *EtcdBackupSettings) {
+	*out = *in
+	if in.RevisionsToKeep != nil {
+		in, out := &in.RevisionsToKeep, &out.RevisionsToKeep
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupSettings.
+func (in *EtcdBackupSettings) DeepCopy() *EtcdBackupSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdRestore) DeepCopyInto(out *EtcdRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdRestore.
+func (in *EtcdRestore) DeepCopy() *EtcdRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdRestoreList) DeepCopyInto(out *EtcdRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EtcdRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdRestoreList.
+func (in *EtcdRestoreList) DeepCopy() *EtcdRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdRestoreSpec) DeepCopyInto(out *EtcdRestoreSpec) {
+	*out = *in
+	out.Cluster = in.Cluster
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdRestoreSpec.
+func (in *EtcdRestoreSpec) DeepCopy() *EtcdRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdRestoreStatus) DeepCopyInto(out *EtcdRestoreStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdRestoreStatus.
+func (in *EtcdRestoreStatus) DeepCopy() *EtcdRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdStatefulSetSettings) DeepCopyInto(out *EtcdStatefulSetSettings) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdStatefulSetSettings.
+func (in *EtcdStatefulSetSettings) DeepCopy() *EtcdStatefulSetSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdStatefulSetSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExtendedClusterHealth) DeepCopyInto(out *ExtendedClusterHealth) {
 	*out = *in
@@ -1402,12 +2002,87 @@ func (in *HetznerCloudSpec) DeepCopyInto(out *HetznerCloudSpec) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HetznerCloudSpec.
-func (in *HetznerCloudSpec) DeepCopy() *HetznerCloudSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HetznerCloudSpec.
+func (in *HetznerCloudSpec) DeepCopy() *HetznerCloudSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HetznerCloudSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationSchedule) DeepCopyInto(out *HibernationSchedule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationSchedule.
+func (in *HibernationSchedule) DeepCopy() *HibernationSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationSettings) DeepCopyInto(out *HibernationSettings) {
+	*out = *in
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]HibernationSchedule, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationSettings.
+func (in *HibernationSettings) DeepCopy() *HibernationSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationStatus) DeepCopyInto(out *HibernationStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.MachineDeployments != nil {
+		in, out := &in.MachineDeployments, &out.MachineDeployments
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Deployments != nil {
+		in, out := &in.Deployments, &out.Deployments
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StatefulSets != nil {
+		in, out := &in.StatefulSets, &out.StatefulSets
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationStatus.
+func (in *HibernationStatus) DeepCopy() *HibernationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(HetznerCloudSpec)
+	out := new(HibernationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1578,6 +2253,22 @@ func (in *MachineNetworkingConfig) DeepCopy() *MachineNetworkingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePrice) DeepCopyInto(out *MachinePrice) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePrice.
+func (in *MachinePrice) DeepCopy() *MachinePrice {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePrice)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkRanges) DeepCopyInto(out *NetworkRanges) {
 	*out = *in
@@ -1749,6 +2440,22 @@ func (in *OpenstackNodeSizeRequirements) DeepCopy() *OpenstackNodeSizeRequiremen
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatingSystemImage) DeepCopyInto(out *OperatingSystemImage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatingSystemImage.
+func (in *OperatingSystemImage) DeepCopy() *OperatingSystemImage {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatingSystemImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Packet) DeepCopyInto(out *Packet) {
 	*out = *in
@@ -1917,13 +2624,94 @@ func (in *PresetSpec) DeepCopy() *PresetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PricingCatalog) DeepCopyInto(out *PricingCatalog) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PricingCatalog.
+func (in *PricingCatalog) DeepCopy() *PricingCatalog {
+	if in == nil {
+		return nil
+	}
+	out := new(PricingCatalog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PricingCatalog) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PricingCatalogList) DeepCopyInto(out *PricingCatalogList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PricingCatalog, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PricingCatalogList.
+func (in *PricingCatalogList) DeepCopy() *PricingCatalogList {
+	if in == nil {
+		return nil
+	}
+	out := new(PricingCatalogList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PricingCatalogList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PricingCatalogSpec) DeepCopyInto(out *PricingCatalogSpec) {
+	*out = *in
+	if in.Prices != nil {
+		in, out := &in.Prices, &out.Prices
+		*out = make([]MachinePrice, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PricingCatalogSpec.
+func (in *PricingCatalogSpec) DeepCopy() *PricingCatalogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PricingCatalogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Project) DeepCopyInto(out *Project) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -1994,9 +2782,194 @@ func (in *ProjectList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectOIDCGroup) DeepCopyInto(out *ProjectOIDCGroup) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectOIDCGroup.
+func (in *ProjectOIDCGroup) DeepCopy() *ProjectOIDCGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectOIDCGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectQuota) DeepCopyInto(out *ProjectQuota) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectQuota.
+func (in *ProjectQuota) DeepCopy() *ProjectQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectResourceUsage) DeepCopyInto(out *ProjectResourceUsage) {
+	*out = *in
+	out.CPU = in.CPU.DeepCopy()
+	out.Memory = in.Memory.DeepCopy()
+	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectResourceUsage.
+func (in *ProjectResourceUsage) DeepCopy() *ProjectResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRole) DeepCopyInto(out *ProjectRole) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRole.
+func (in *ProjectRole) DeepCopy() *ProjectRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectRole) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRoleList) DeepCopyInto(out *ProjectRoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProjectRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleList.
+func (in *ProjectRoleList) DeepCopy() *ProjectRoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRoleRule) DeepCopyInto(out *ProjectRoleRule) {
+	*out = *in
+	if in.Verbs != nil {
+		in, out := &in.Verbs, &out.Verbs
+		*out = make([]ProjectRoleVerb, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleRule.
+func (in *ProjectRoleRule) DeepCopy() *ProjectRoleRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRoleRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRoleSpec) DeepCopyInto(out *ProjectRoleSpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ProjectRoleRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleSpec.
+func (in *ProjectRoleSpec) DeepCopy() *ProjectRoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProjectSpec) DeepCopyInto(out *ProjectSpec) {
 	*out = *in
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(ProjectQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OIDCGroups != nil {
+		in, out := &in.OIDCGroups, &out.OIDCGroups
+		*out = make([]ProjectOIDCGroup, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2013,6 +2986,11 @@ func (in *ProjectSpec) DeepCopy() *ProjectSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProjectStatus) DeepCopyInto(out *ProjectStatus) {
 	*out = *in
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(ProjectResourceUsage)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2126,6 +3104,37 @@ func (in *Seed) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedCapacity) DeepCopyInto(out *SeedCapacity) {
+	*out = *in
+	if in.MaxClusters != nil {
+		in, out := &in.MaxClusters, &out.MaxClusters
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ControlPlaneCPU != nil {
+		in, out := &in.ControlPlaneCPU, &out.ControlPlaneCPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ControlPlaneMemory != nil {
+		in, out := &in.ControlPlaneMemory, &out.ControlPlaneMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedCapacity.
+func (in *SeedCapacity) DeepCopy() *SeedCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SeedList) DeepCopyInto(out *SeedList) {
 	*out = *in
@@ -2176,6 +3185,16 @@ func (in *SeedSpec) DeepCopyInto(out *SeedSpec) {
 		*out = new(ProxySettings)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(SeedCapacity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EgressRanges != nil {
+		in, out := &in.EgressRanges, &out.EgressRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2473,6 +3492,15 @@ func (in *UserSpec) DeepCopyInto(out *UserSpec) {
 		*out = new(types.GlobalSecretKeySelector)
 		**out = **in
 	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GroupsIssuedAt != nil {
+		in, out := &in.GroupsIssuedAt, &out.GroupsIssuedAt
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -33,6 +33,9 @@ const (
 	NodePortStrategy ExposeStrategy = "NodePort"
 	// LoadBalancerStrategy creates a LoadBalancer service per cluster.
 	LoadBalancerStrategy ExposeStrategy = "LoadBalancer"
+	// SNIStrategy routes the API servers by their hostname on port 443 of the NodePort proxy.
+	// The connections from inside the user clusters use a second LoadBalancer of the NodePort proxy.
+	SNIStrategy ExposeStrategy = "SNI"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -134,6 +137,9 @@ type KubermaticSeedControllerConfiguration struct {
 	BackupStoreContainer string `json:"backupStoreContainer,omitempty"`
 	// BackupCleanupContainer is the container used for removing expired backups from the storage location.
 	BackupCleanupContainer string `json:"backupCleanupContainer,omitempty"`
+	// BackupRestoreContainer is the container used for downloading an etcd snapshot from the backup
+	// location when restoring the etcd of a user cluster.
+	BackupRestoreContainer string `json:"backupRestoreContainer,omitempty"`
 	// PProfEndpoint controls the port the seed-controller-manager should listen on to provide pprof
 	// data. This port is never exposed from the container and only available via port-forwardings.
 	PProfEndpoint *string `json:"pprofEndpoint,omitempty"`
@@ -87,6 +87,7 @@ func SetupSeedValidationWebhook(
 		workerName,
 		seedsGetter,
 		provider.SeedClientGetterFactory(seedKubeconfigGetter),
+		mgr.GetAPIReader(),
 		migrationOptions.SeedMigrationEnabled())
 	if err != nil {
 		return fmt.Errorf("failed to create server: %v", err)
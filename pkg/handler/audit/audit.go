@@ -0,0 +1,110 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Outcome is the result of an audited action
+type Outcome string
+
+const (
+	// OutcomeSuccess is used for actions that were executed
+	OutcomeSuccess Outcome = "success"
+	// OutcomeFailure is used for actions that returned an error
+	OutcomeFailure Outcome = "failure"
+)
+
+// Entry is a single action taken through the API
+type Entry struct {
+	Time time.Time `json:"time"`
+	// User is the email address of the authenticated user
+	User string `json:"user"`
+	// Admin is true if the user is a Kubermatic admin
+	Admin bool `json:"admin,omitempty"`
+	// Endpoint is the HTTP method and the route of the request, e.g.
+	// "DELETE /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}"
+	Endpoint string `json:"endpoint"`
+	// ProjectID is the ID of the project the action was taken in, if any
+	ProjectID string `json:"projectID,omitempty"`
+	// Resources are the IDs of the resources the action targeted, keyed by the route parameter
+	Resources map[string]string `json:"resources,omitempty"`
+	Outcome   Outcome           `json:"outcome"`
+	// Code is the HTTP status code of a failed action
+	Code int `json:"code,omitempty"`
+	// Error is the error message of a failed action
+	Error string `json:"error,omitempty"`
+}
+
+// Sink knows how to persist audit entries
+type Sink interface {
+	Write(entry *Entry) error
+}
+
+// Store is a sink which keeps the entries it is given so they can be queried through the API
+type Store interface {
+	Sink
+	// Recent returns up to limit of the most recent entries for the given project, newest first.
+	// A limit of zero or less returns all entries.
+	Recent(projectID string, limit int) ([]Entry, error)
+}
+
+// ErrNoStore is returned when the audit trail is queried but no store is configured
+var ErrNoStore = errors.New("the audit trail is not stored, no audit log directory is configured")
+
+// Logger writes audit entries to the store and all configured sinks
+type Logger struct {
+	log   *zap.SugaredLogger
+	store Store
+	sinks []Sink
+}
+
+// NewLogger returns a Logger that writes to the given sinks and keeps the entries in the given store.
+// The store is optional, without one the audit trail can not be queried through the API.
+func NewLogger(log *zap.SugaredLogger, store Store, sinks ...Sink) *Logger {
+	if store != nil {
+		sinks = append([]Sink{store}, sinks...)
+	}
+	return &Logger{
+		log:   log,
+		store: store,
+		sinks: sinks,
+	}
+}
+
+// Record writes the entry to all sinks. Errors of the sinks are logged but not returned,
+// as a broken sink must not fail the audited action.
+func (l *Logger) Record(entry *Entry) {
+	for _, sink := range l.sinks {
+		if err := sink.Write(entry); err != nil {
+			l.log.Errorw("failed to write audit entry", "endpoint", entry.Endpoint, "user", entry.User, zap.Error(err))
+		}
+	}
+}
+
+// Recent returns up to limit of the most recent entries for the given project from the store, newest first.
+// A limit of zero or less returns all entries.
+func (l *Logger) Recent(projectID string, limit int) ([]Entry, error) {
+	if l.store == nil {
+		return nil, ErrNoStore
+	}
+	return l.store.Recent(projectID, limit)
+}
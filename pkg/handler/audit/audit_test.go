@@ -0,0 +1,145 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	kubermaticlog "github.com/kubermatic/kubermatic/pkg/log"
+)
+
+func TestLoggerRecent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// two API replicas sharing the audit log directory
+	var loggers []*Logger
+	for _, name := range []string{"api-a", "api-b"} {
+		store, err := NewDirectoryStore(dir, name)
+		if err != nil {
+			t.Fatalf("failed to create directory store: %v", err)
+		}
+		loggers = append(loggers, NewLogger(kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(), store))
+	}
+
+	now := time.Now()
+	for i := 1; i <= 4; i++ {
+		loggers[i%2].Record(&Entry{Time: now.Add(time.Duration(i) * time.Second), Endpoint: fmt.Sprintf("action-%d", i), ProjectID: "project-a", Outcome: OutcomeSuccess})
+	}
+	loggers[0].Record(&Entry{Time: now.Add(5 * time.Second), Endpoint: "action-5", ProjectID: "project-b", Outcome: OutcomeSuccess})
+
+	// an entry which is still being written is skipped
+	f, err := os.OpenFile(filepath.Join(dir, "api-b.log"), os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatalf("failed to open audit log file: %v", err)
+	}
+	if _, err := f.WriteString(`{"time":"`); err != nil {
+		t.Fatalf("failed to write to audit log file: %v", err)
+	}
+	f.Close()
+
+	testCases := []struct {
+		name      string
+		projectID string
+		limit     int
+		expected  []string
+	}{
+		{
+			name:      "entries of all replicas are returned and the newest come first",
+			projectID: "project-a",
+			expected:  []string{"action-4", "action-3", "action-2", "action-1"},
+		},
+		{
+			name:      "limit is respected",
+			projectID: "project-a",
+			limit:     3,
+			expected:  []string{"action-4", "action-3", "action-2"},
+		},
+		{
+			name:      "entries of other projects are filtered out",
+			projectID: "project-b",
+			expected:  []string{"action-5"},
+		},
+		{
+			name:      "unknown project has no entries",
+			projectID: "project-c",
+			expected:  []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// every replica answers with the complete audit trail
+			for _, logger := range loggers {
+				entries, err := logger.Recent(tc.projectID, tc.limit)
+				if err != nil {
+					t.Fatalf("failed to get recent entries: %v", err)
+				}
+				endpoints := []string{}
+				for _, entry := range entries {
+					endpoints = append(endpoints, entry.Endpoint)
+				}
+				if fmt.Sprint(endpoints) != fmt.Sprint(tc.expected) {
+					t.Errorf("expected %v, got %v", tc.expected, endpoints)
+				}
+			}
+		})
+	}
+}
+
+func TestLoggerWithoutStore(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	logger := NewLogger(kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(), nil, NewWriterSink(buffer))
+	logger.Record(&Entry{Endpoint: "action-1", ProjectID: "project-a", Outcome: OutcomeSuccess})
+
+	entry := Entry{}
+	if err := json.NewDecoder(buffer).Decode(&entry); err != nil {
+		t.Fatalf("failed to decode entry written to the sink: %v", err)
+	}
+	if entry.Endpoint != "action-1" {
+		t.Errorf("expected entry of action-1 in the sink, got %q", entry.Endpoint)
+	}
+
+	if _, err := logger.Recent("project-a", 0); err != ErrNoStore {
+		t.Errorf("expected %v, got %v", ErrNoStore, err)
+	}
+}
+
+func TestWebhookSinkCountsDroppedEntries(t *testing.T) {
+	s := &webhookSink{queue: make(chan *Entry, 1)}
+	if err := s.Write(&Entry{}); err != nil {
+		t.Fatalf("expected the first entry to be queued, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := s.Write(&Entry{}); err == nil {
+			t.Fatal("expected an error when the queue is full")
+		}
+	}
+	if s.dropped != 2 {
+		t.Errorf("expected 2 dropped entries, got %d", s.dropped)
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// webhookQueueSize is the number of entries a webhook sink buffers before it starts dropping them
+	webhookQueueSize = 1000
+	// directoryStoreFileSuffix is the suffix of the files a directory store writes the entries to
+	directoryStoreFileSuffix = ".log"
+	// maxEntrySize is the maximum size of a single entry a directory store reads
+	maxEntrySize = 1024 * 1024
+)
+
+type writerSink struct {
+	lock    sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewWriterSink returns a sink that writes every entry as a line of JSON to w, e.g. os.Stdout
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{encoder: json.NewEncoder(w)}
+}
+
+func (s *writerSink) Write(entry *Entry) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.encoder.Encode(entry)
+}
+
+type directoryStore struct {
+	Sink
+	dir string
+}
+
+// NewDirectoryStore returns a store that appends every entry as a line of JSON to the file <name>.log in dir
+// and reads the entries of all such files in dir. When dir is a volume shared by all API replicas and every
+// replica uses its own name, e.g. its pod name, the store returns the audit trail of all replicas.
+func NewDirectoryStore(dir, name string) (Store, error) {
+	f, err := os.OpenFile(filepath.Join(dir, name+directoryStoreFileSuffix), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file: %v", err)
+	}
+	return &directoryStore{Sink: NewWriterSink(f), dir: dir}, nil
+}
+
+func (s *directoryStore) Recent(projectID string, limit int) ([]Entry, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*"+directoryStoreFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []Entry{}
+	for _, path := range paths {
+		fileEntries, err := readRecentEntries(path, projectID, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read audit log file %s: %v", path, err)
+		}
+		entries = append(entries, fileEntries...)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.After(entries[j].Time)
+	})
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// readRecentEntries returns up to limit of the last entries for the given project in the file at path.
+// Lines which can not be decoded, e.g. an entry another replica is still writing, are skipped.
+func readRecentEntries(path, projectID string, limit int) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := []Entry{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, bufio.MaxScanTokenSize), maxEntrySize)
+	for scanner.Scan() {
+		entry := Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil || entry.ProjectID != projectID {
+			continue
+		}
+		entries = append(entries, entry)
+		if limit > 0 && len(entries) > limit {
+			entries = entries[1:]
+		}
+	}
+	return entries, scanner.Err()
+}
+
+type webhookSink struct {
+	log    *zap.SugaredLogger
+	url    string
+	client *http.Client
+	queue  chan *Entry
+
+	// dropped is the number of entries which were dropped because the queue was full
+	dropped uint64
+}
+
+// NewWebhookSink returns a sink that posts every entry as JSON to url. The entries are sent
+// in the background so a slow webhook does not delay API requests.
+func NewWebhookSink(log *zap.SugaredLogger, url string) Sink {
+	s := &webhookSink{
+		log:    log,
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+		queue:  make(chan *Entry, webhookQueueSize),
+	}
+	go s.run()
+	return s
+}
+
+func (s *webhookSink) Write(entry *Entry) error {
+	select {
+	case s.queue <- entry:
+		return nil
+	default:
+		dropped := atomic.AddUint64(&s.dropped, 1)
+		return fmt.Errorf("webhook queue is full, dropped the entry (%d entries dropped in total)", dropped)
+	}
+}
+
+func (s *webhookSink) run() {
+	for entry := range s.queue {
+		if err := s.send(entry); err != nil {
+			s.log.Errorw("failed to send audit entry to webhook", "endpoint", entry.Endpoint, "user", entry.User, zap.Error(err))
+		}
+	}
+}
+
+func (s *webhookSink) send(entry *Entry) error {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -175,6 +175,9 @@ func (o *OpenIDClient) Verify(ctx context.Context, token string) (TokenClaims, e
 		nsecs := int64((exp - float64(secs)) * 1e9)
 		oidcClaims.Expiry = apiv1.NewTime(time.Unix(secs, nsecs))
 	}
+	if !idToken.IssuedAt.IsZero() {
+		oidcClaims.IssuedAt = apiv1.NewTime(idToken.IssuedAt)
+	}
 
 	return oidcClaims, nil
 }
@@ -32,6 +32,8 @@ type TokenClaims struct {
 	Subject string
 	Groups  []string
 	Expiry  apiv1.Time
+	// IssuedAt is zero if the token doesn't contain the time it was issued at
+	IssuedAt apiv1.Time
 }
 
 // TokenExtractorVerifier combines TokenVerifier and TokenExtractor interfaces
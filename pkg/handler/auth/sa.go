@@ -20,13 +20,27 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/kubermatic/kubermatic/pkg/provider"
 	"github.com/kubermatic/kubermatic/pkg/serviceaccount"
+	kubermaticcontext "github.com/kubermatic/kubermatic/pkg/util/context"
 
+	v1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
+// RequestContextKey key under which the Request is kept in the ctx, it is needed to enforce the scopes of service account tokens
+const RequestContextKey kubermaticcontext.Key = "auth-request"
+
+// Request describes the request a token is used for
+type Request struct {
+	// Method is the HTTP method of the request
+	Method string
+	// Endpoint is the path template of the route which matched the request
+	Endpoint string
+}
+
 // ServiceAccountAuthClient implements TokenExtractorVerifier interface
 type ServiceAccountAuthClient struct {
 	headerBearerTokenExtractor TokenExtractor
@@ -58,21 +72,47 @@ func (s *ServiceAccountAuthClient) Verify(ctx context.Context, token string) (To
 	if kerrors.IsNotFound(err) {
 		return TokenClaims{}, fmt.Errorf("sa: the token %s has been revoked for %s", customClaims.TokenID, customClaims.Email)
 	}
+	if err != nil {
+		return TokenClaims{}, fmt.Errorf("sa: cannot verify the token (%s): %v", customClaims.TokenID, err)
+	}
 	if len(tokenList) > 1 {
 		return TokenClaims{}, fmt.Errorf("sa: found more than one token with the given id %s", customClaims.TokenID)
 	}
 	rawToken := tokenList[0]
-	tokenFromDB, ok := rawToken.Data["token"]
+	tokenFromDB, ok := rawToken.Data[serviceaccount.TokenKey]
 	if !ok {
 		return TokenClaims{}, fmt.Errorf("sa: cannot verify the token (%s) because the corresponding token in the database is invalid", customClaims.TokenID)
 	}
-	if string(tokenFromDB) != token {
+	if string(tokenFromDB) != token && !isInRotationGracePeriod(rawToken, token) {
 		return TokenClaims{}, fmt.Errorf("sa: the token %s has been revoked for %s", customClaims.TokenID, customClaims.Email)
 	}
 
+	if len(customClaims.Scopes) > 0 {
+		request, ok := ctx.Value(RequestContextKey).(Request)
+		if !ok {
+			return TokenClaims{}, fmt.Errorf("sa: the token %s is restricted to scopes but the request is unknown", customClaims.TokenID)
+		}
+		if !serviceaccount.ScopesAllow(customClaims.Scopes, request.Method, request.Endpoint) {
+			return TokenClaims{}, fmt.Errorf("sa: the scopes of the token %s don't allow %s %s", customClaims.TokenID, request.Method, request.Endpoint)
+		}
+	}
+
 	return TokenClaims{
 		Name:    customClaims.TokenID,
 		Email:   customClaims.Email,
 		Subject: customClaims.Email,
 	}, nil
 }
+
+// isInRotationGracePeriod checks if the given token has been replaced by the latest rotation of the secret and is still accepted
+func isInRotationGracePeriod(secret *v1.Secret, token string) bool {
+	previousToken, ok := secret.Data[serviceaccount.PreviousTokenKey]
+	if !ok || string(previousToken) != token {
+		return false
+	}
+	expiry, err := time.Parse(time.RFC3339, string(secret.Data[serviceaccount.PreviousTokenExpiryKey]))
+	if err != nil {
+		return false
+	}
+	return serviceaccount.Now().Before(expiry)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	transporthttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/audit"
+	"github.com/kubermatic/kubermatic/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+	kubermaticcontext "github.com/kubermatic/kubermatic/pkg/util/context"
+	k8cerrors "github.com/kubermatic/kubermatic/pkg/util/errors"
+)
+
+// auditRequestContextKey key under which the route and the path parameters of the current request are kept in the ctx
+const auditRequestContextKey kubermaticcontext.Key = "audit-request"
+
+type auditRequest struct {
+	method   string
+	endpoint string
+	vars     map[string]string
+}
+
+// AuditRequestExtractor stores the route and the path parameters of the incoming request in the ctx,
+// they are used by the Audit middleware to identify the endpoint and the targeted resources
+func AuditRequestExtractor() transporthttp.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		path := r.URL.Path
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				path = template
+			}
+		}
+		return context.WithValue(ctx, auditRequestContextKey, auditRequest{
+			method:   r.Method,
+			endpoint: r.Method + " " + path,
+			vars:     mux.Vars(r),
+		})
+	}
+}
+
+// Audit is a middleware that records who took which action on which resources in the audit log.
+// It has to be chained after the UserSaver middleware.
+func Audit(auditLogger *audit.Logger, userInfoGetter provider.UserInfoGetter) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			response, err = next(ctx, request)
+			if auditLogger == nil {
+				return response, err
+			}
+
+			entry := &audit.Entry{
+				Time:    time.Now().UTC(),
+				Outcome: audit.OutcomeSuccess,
+			}
+			if userInfo, userErr := userInfoGetter(ctx, ""); userErr == nil {
+				entry.User = userInfo.Email
+				entry.Admin = userInfo.IsAdmin
+			}
+			req, _ := ctx.Value(auditRequestContextKey).(auditRequest)
+			if req.endpoint != "" {
+				entry.Endpoint = req.endpoint
+				entry.ProjectID = req.vars["project_id"]
+				if len(req.vars) > 0 {
+					entry.Resources = map[string]string{}
+					for name, value := range req.vars {
+						entry.Resources[name] = value
+					}
+				}
+			}
+			if projectIDGetter, ok := request.(common.ProjectIDGetter); ok && entry.ProjectID == "" {
+				entry.ProjectID = projectIDGetter.GetProjectID()
+			}
+
+			if err != nil {
+				entry.Outcome = audit.OutcomeFailure
+				entry.Code = http.StatusInternalServerError
+				if httpErr, ok := err.(k8cerrors.HTTPError); ok {
+					entry.Code = httpErr.StatusCode()
+				}
+				entry.Error = err.Error()
+			} else if id := responseObjectID(response); id != "" && req.method == http.MethodPost {
+				// Creating a resource returns it, record its ID so the entry references the new resource
+				if project, ok := reflect.Indirect(reflect.ValueOf(response)).Interface().(apiv1.Project); ok && entry.ProjectID == "" {
+					entry.ProjectID = project.ID
+				}
+				if entry.Resources == nil {
+					entry.Resources = map[string]string{}
+				}
+				if _, exists := entry.Resources["id"]; !exists {
+					entry.Resources["id"] = id
+				}
+			}
+
+			auditLogger.Record(entry)
+			return response, err
+		}
+	}
+}
+
+// responseObjectID returns the ID of the object returned by an endpoint, if it is an API object
+func responseObjectID(response interface{}) string {
+	if response == nil {
+		return ""
+	}
+	value := reflect.Indirect(reflect.ValueOf(response))
+	if value.Kind() != reflect.Struct {
+		return ""
+	}
+	field := value.FieldByName("ObjectMeta")
+	if !field.IsValid() {
+		return ""
+	}
+	meta, ok := field.Interface().(apiv1.ObjectMeta)
+	if !ok {
+		return ""
+	}
+	return meta.ID
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/go-kit/kit/endpoint"
 	transporthttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
 
 	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
 	kubermaticapiv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
@@ -35,6 +36,7 @@ import (
 	"github.com/kubermatic/kubermatic/pkg/util/hash"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -62,6 +64,12 @@ const (
 	// AuthenticatedUserContextKey key under which the current User (from OIDC provider) is kept in the ctx
 	AuthenticatedUserContextKey kubermaticcontext.Key = "authenticated-user"
 
+	// AuthenticatedUserGroupsContextKey key under which the groups of the current User (from OIDC provider) are kept in the ctx
+	AuthenticatedUserGroupsContextKey kubermaticcontext.Key = "authenticated-user-groups"
+
+	// TokenIssuedAtContextKey key under which the issue time of the token is kept in the ctx
+	TokenIssuedAtContextKey kubermaticcontext.Key = "token-issued-at"
+
 	// AddonProviderContextKey key under which the current AddonProvider is kept in the ctx
 	AddonProviderContextKey kubermaticcontext.Key = "addon-provider"
 
@@ -110,6 +118,7 @@ func SetPrivilegedClusterProvider(clusterProviderGetter provider.ClusterProvider
 
 // UserSaver is a middleware that checks if authenticated user already exists in the database
 // next it creates/retrieve an internal object (kubermaticv1.User) and stores it the ctx under UserCRContexKey
+// the OIDC groups of the user are kept up to date, they grant access to the projects bound to them
 func UserSaver(userProvider provider.UserProvider) endpoint.Middleware {
 	return func(next endpoint.Endpoint) endpoint.Endpoint {
 		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
@@ -135,11 +144,40 @@ func UserSaver(userProvider provider.UserProvider) endpoint.Middleware {
 					}
 				}
 			}
+			if groups, ok := ctx.Value(AuthenticatedUserGroupsContextKey).([]string); ok {
+				issuedAt, _ := ctx.Value(TokenIssuedAtContextKey).(apiv1.Time)
+				if user, err = syncUserGroups(userProvider, user, groups, issuedAt); err != nil {
+					return nil, common.KubernetesErrorToHTTPError(err)
+				}
+			}
 			return next(context.WithValue(ctx, kubermaticcontext.UserCRContextKey, user), request)
 		}
 	}
 }
 
+// syncUserGroups stores the groups of the token in the user, losing a group revokes the access
+// to the projects bound to it. Tokens issued before the one the groups were taken from are ignored,
+// they can't restore revoked groups. Tokens without an issue time are only taken into account as
+// long as the groups weren't taken from a token with one.
+func syncUserGroups(userProvider provider.UserProvider, user *kubermaticapiv1.User, groups []string, issuedAt apiv1.Time) (*kubermaticapiv1.User, error) {
+	if sets.NewString(user.Spec.Groups...).Equal(sets.NewString(groups...)) {
+		return user, nil
+	}
+	if user.Spec.GroupsIssuedAt != nil && (issuedAt.IsZero() || !issuedAt.After(user.Spec.GroupsIssuedAt.Time)) {
+		return user, nil
+	}
+	updatedUser := user.DeepCopy()
+	updatedUser.Spec.Groups = sets.NewString(groups...).List()
+	if len(updatedUser.Spec.Groups) == 0 {
+		updatedUser.Spec.Groups = nil
+	}
+	updatedUser.Spec.GroupsIssuedAt = nil
+	if !issuedAt.IsZero() {
+		updatedUser.Spec.GroupsIssuedAt = &metav1.Time{Time: issuedAt.Time}
+	}
+	return userProvider.UpdateUser(updatedUser)
+}
+
 // UserInfoUnauthorized tries to build userInfo for not authenticated (token) user
 // instead it reads the user_id from the request and finds the associated user in the database
 func UserInfoUnauthorized(userProjectMapper provider.ProjectMemberMapper, userProvider provider.UserProvider) endpoint.Middleware {
@@ -224,6 +262,8 @@ func TokenVerifier(tokenVerifier auth.TokenVerifier, userProvider provider.UserP
 			}
 
 			ctx = context.WithValue(ctx, TokenExpiryContextKey, claims.Expiry)
+			ctx = context.WithValue(ctx, AuthenticatedUserGroupsContextKey, claims.Groups)
+			ctx = context.WithValue(ctx, TokenIssuedAtContextKey, claims.IssuedAt)
 			return next(context.WithValue(ctx, AuthenticatedUserContextKey, user), request)
 		}
 	}
@@ -274,9 +314,18 @@ func getAddonProvider(addonProviderGetter provider.AddonProviderGetter, seedsGet
 	return addonProviderGetter(seed)
 }
 
-// TokenExtractor knows how to extract a token from the incoming request
+// TokenExtractor knows how to extract a token from the incoming request,
+// the method and the route of the request are kept in the ctx to enforce the scopes of the token
 func TokenExtractor(o auth.TokenExtractor) transporthttp.RequestFunc {
 	return func(ctx context.Context, r *http.Request) context.Context {
+		endpoint := r.URL.Path
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				endpoint = template
+			}
+		}
+		ctx = context.WithValue(ctx, auth.RequestContextKey, auth.Request{Method: r.Method, Endpoint: endpoint})
+
 		token, err := o.Extract(r)
 		if err != nil {
 			return context.WithValue(ctx, noTokenFoundKey, err)
@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/provider/kubernetes"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSyncUserGroups(t *testing.T) {
+	issuedAt := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		name             string
+		groups           []string
+		groupsIssuedAt   *metav1.Time
+		tokenGroups      []string
+		tokenIssuedAt    time.Time
+		expectedGroups   []string
+		expectedIssuedAt *metav1.Time
+	}{
+		{
+			name:             "scenario 1: the groups of a newer token are taken",
+			groups:           []string{"admins", "developers"},
+			groupsIssuedAt:   &metav1.Time{Time: issuedAt},
+			tokenGroups:      []string{"developers"},
+			tokenIssuedAt:    issuedAt.Add(time.Minute),
+			expectedGroups:   []string{"developers"},
+			expectedIssuedAt: &metav1.Time{Time: issuedAt.Add(time.Minute)},
+		},
+		{
+			name:             "scenario 2: an older token can't restore revoked groups",
+			groups:           []string{"developers"},
+			groupsIssuedAt:   &metav1.Time{Time: issuedAt},
+			tokenGroups:      []string{"admins", "developers"},
+			tokenIssuedAt:    issuedAt.Add(-time.Minute),
+			expectedGroups:   []string{"developers"},
+			expectedIssuedAt: &metav1.Time{Time: issuedAt},
+		},
+		{
+			name:             "scenario 3: a token without an issue time is ignored once the groups were taken from one with it",
+			groups:           []string{"developers"},
+			groupsIssuedAt:   &metav1.Time{Time: issuedAt},
+			tokenGroups:      []string{"admins"},
+			expectedGroups:   []string{"developers"},
+			expectedIssuedAt: &metav1.Time{Time: issuedAt},
+		},
+		{
+			name:           "scenario 4: the groups of a token without an issue time are taken if there is no issue time yet",
+			groups:         []string{"developers"},
+			tokenGroups:    []string{"admins"},
+			expectedGroups: []string{"admins"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			user := &kubermaticv1.User{
+				ObjectMeta: metav1.ObjectMeta{Name: "john"},
+				Spec: kubermaticv1.UserSpec{
+					Email:          "john@acme.com",
+					Groups:         tc.groups,
+					GroupsIssuedAt: tc.groupsIssuedAt,
+				},
+			}
+			userProvider := kubernetes.NewUserProvider(fakectrlruntimeclient.NewFakeClientWithScheme(scheme.Scheme, user), kubernetes.IsServiceAccount)
+
+			updatedUser, err := syncUserGroups(userProvider, user, tc.tokenGroups, apiv1.NewTime(tc.tokenIssuedAt))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equality.Semantic.DeepEqual(updatedUser.Spec.Groups, tc.expectedGroups) {
+				t.Errorf("expected groups %v, got %v", tc.expectedGroups, updatedUser.Spec.Groups)
+			}
+			if !equality.Semantic.DeepEqual(updatedUser.Spec.GroupsIssuedAt, tc.expectedIssuedAt) {
+				t.Errorf("expected groups issued at %v, got %v", tc.expectedIssuedAt, updatedUser.Spec.GroupsIssuedAt)
+			}
+		})
+	}
+}
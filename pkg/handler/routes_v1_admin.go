@@ -25,6 +25,7 @@ import (
 
 	"github.com/kubermatic/kubermatic/pkg/handler/middleware"
 	"github.com/kubermatic/kubermatic/pkg/handler/v1/admin"
+	"github.com/kubermatic/kubermatic/pkg/handler/v1/projectrole"
 )
 
 //RegisterV1Admin declares all router paths for the admin users
@@ -51,6 +52,14 @@ func (r Routing) RegisterV1Admin(mux *mux.Router) {
 		Path("/admin/settings").
 		Handler(r.patchKubermaticSettings())
 
+	mux.Methods(http.MethodGet).
+		Path("/admin/pricing").
+		Handler(r.getPricingCatalog())
+
+	mux.Methods(http.MethodPut).
+		Path("/admin/pricing").
+		Handler(r.updatePricingCatalog())
+
 	// Defines a set of HTTP endpoints for the admission plugins
 	mux.Methods(http.MethodGet).
 		Path("/admin/admission/plugins").
@@ -84,6 +93,24 @@ func (r Routing) RegisterV1Admin(mux *mux.Router) {
 	mux.Methods(http.MethodDelete).
 		Path("/admin/seeds/{seed_name}").
 		Handler(r.deleteSeed())
+
+	// Defines a set of HTTP endpoints for the audit trail
+	mux.Methods(http.MethodGet).
+		Path("/admin/projects/{project_id}/audit").
+		Handler(r.listProjectAuditEntries())
+
+	// Defines a set of HTTP endpoints for the project roles
+	mux.Methods(http.MethodPost).
+		Path("/admin/projectroles").
+		Handler(r.createProjectRole())
+
+	mux.Methods(http.MethodPatch).
+		Path("/admin/projectroles/{name}").
+		Handler(r.updateProjectRole())
+
+	mux.Methods(http.MethodDelete).
+		Path("/admin/projectroles/{name}").
+		Handler(r.deleteProjectRole())
 }
 
 // swagger:route GET /api/v1/admin/settings admin getKubermaticSettings
@@ -155,6 +182,58 @@ func (r Routing) patchKubermaticSettings() http.Handler {
 	)
 }
 
+// swagger:route GET /api/v1/admin/pricing admin getPricingCatalog
+//
+//     Gets the pricing catalog used to estimate the costs of clusters.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: PricingCatalog
+//       401: empty
+//       403: empty
+func (r Routing) getPricingCatalog() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(admin.PricingCatalogEndpoint(r.pricingCatalogProvider)),
+		decodeEmptyReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route PUT /api/v1/admin/pricing admin updatePricingCatalog
+//
+//     Replaces the prices of the pricing catalog. Only available for admins.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: PricingCatalog
+//       401: empty
+//       403: empty
+func (r Routing) updatePricingCatalog() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.Audit(r.auditLogger, r.userInfoGetter),
+		)(admin.UpdatePricingCatalogEndpoint(r.userInfoGetter, r.pricingCatalogProvider)),
+		admin.DecodeUpdatePricingCatalogReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v1/admin admin getAdmins
 //
 //     Returns list of admin users.
@@ -323,7 +402,7 @@ func (r Routing) listSeeds() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
 			middleware.UserSaver(r.userProvider),
-		)(admin.ListSeedEndpoint(r.userInfoGetter, r.seedsGetter)),
+		)(admin.ListSeedEndpoint(r.userInfoGetter, r.seedsGetter, r.seedsClientGetter)),
 		decodeEmptyReq,
 		encodeJSON,
 		r.defaultServerOptions()...,
@@ -347,7 +426,7 @@ func (r Routing) getSeed() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
 			middleware.UserSaver(r.userProvider),
-		)(admin.GetSeedEndpoint(r.userInfoGetter, r.seedsGetter)),
+		)(admin.GetSeedEndpoint(r.userInfoGetter, r.seedsGetter, r.seedsClientGetter)),
 		admin.DecodeSeedReq,
 		encodeJSON,
 		r.defaultServerOptions()...,
@@ -404,3 +483,109 @@ func (r Routing) deleteSeed() http.Handler {
 		r.defaultServerOptions()...,
 	)
 }
+
+// swagger:route GET /api/v1/admin/projects/{project_id}/audit admin listProjectAuditEntries
+//
+//     Lists the most recent actions taken through the API in the given project, newest first.
+//
+//     The entries are read from the audit log directory all API replicas share. Returns 501 if the API
+//     is not configured with an audit log directory.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: []AuditEntry
+//       401: empty
+//       403: empty
+func (r Routing) listProjectAuditEntries() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(admin.ListProjectAuditEntriesEndpoint(r.userInfoGetter, r.auditLogger)),
+		admin.DecodeListProjectAuditEntriesReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route POST /api/v1/admin/projectroles admin createProjectRole
+//
+//     Creates a custom project role, members of projects can be assigned to it.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       201: ProjectRole
+//       401: empty
+//       403: empty
+func (r Routing) createProjectRole() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(projectrole.CreateEndpoint(r.userInfoGetter, r.projectRoleProvider)),
+		projectrole.DecodeCreateProjectRoleReq,
+		setStatusCreatedHeader(encodeJSON),
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route PATCH /api/v1/admin/projectroles/{name} admin updateProjectRole
+//
+//     Updates the custom project role, the default roles can't be changed.
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: ProjectRole
+//       401: empty
+//       403: empty
+func (r Routing) updateProjectRole() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(projectrole.UpdateEndpoint(r.userInfoGetter, r.projectRoleProvider)),
+		projectrole.DecodeUpdateProjectRoleReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
+// swagger:route DELETE /api/v1/admin/projectroles/{name} admin deleteProjectRole
+//
+//     Deletes the custom project role, roles which are assigned to members can't be deleted.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: empty
+//       401: empty
+//       403: empty
+//       409: empty
+func (r Routing) deleteProjectRole() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(projectrole.DeleteEndpoint(r.userInfoGetter, r.projectRoleProvider)),
+		projectrole.DecodeProjectRoleReq,
+		encodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
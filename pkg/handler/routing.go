@@ -24,6 +24,7 @@ import (
 	prometheusapi "github.com/prometheus/client_golang/api"
 	"go.uber.org/zap"
 
+	"github.com/kubermatic/kubermatic/pkg/handler/audit"
 	"github.com/kubermatic/kubermatic/pkg/handler/auth"
 	"github.com/kubermatic/kubermatic/pkg/handler/middleware"
 	"github.com/kubermatic/kubermatic/pkg/handler/v1/common"
@@ -43,6 +44,8 @@ type Routing struct {
 	seedsClientGetter                     provider.SeedClientGetter
 	sshKeyProvider                        provider.SSHKeyProvider
 	privilegedSSHKeyProvider              provider.PrivilegedSSHKeyProvider
+	clusterTemplateProvider               provider.ClusterTemplateProvider
+	privilegedClusterTemplateProvider     provider.PrivilegedClusterTemplateProvider
 	userProvider                          provider.UserProvider
 	serviceAccountProvider                provider.ServiceAccountProvider
 	privilegedServiceAccountProvider      provider.PrivilegedServiceAccountProvider
@@ -71,7 +74,10 @@ type Routing struct {
 	settingsProvider                      provider.SettingsProvider
 	adminProvider                         provider.AdminProvider
 	admissionPluginProvider               provider.AdmissionPluginsProvider
+	projectRoleProvider                   provider.ProjectRoleProvider
+	pricingCatalogProvider                provider.PricingCatalogProvider
 	settingsWatcher                       watcher.SettingsWatcher
+	auditLogger                           *audit.Logger
 }
 
 // NewRouting creates a new Routing.
@@ -85,6 +91,8 @@ func NewRouting(
 	addonConfigProvider provider.AddonConfigProvider,
 	newSSHKeyProvider provider.SSHKeyProvider,
 	privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider,
+	clusterTemplateProvider provider.ClusterTemplateProvider,
+	privilegedClusterTemplateProvider provider.PrivilegedClusterTemplateProvider,
 	userProvider provider.UserProvider,
 	serviceAccountProvider provider.ServiceAccountProvider,
 	privilegedServiceAccountProvider provider.PrivilegedServiceAccountProvider,
@@ -109,7 +117,10 @@ func NewRouting(
 	settingsProvider provider.SettingsProvider,
 	adminProvider provider.AdminProvider,
 	admissionPluginProvider provider.AdmissionPluginsProvider,
+	projectRoleProvider provider.ProjectRoleProvider,
+	pricingCatalogProvider provider.PricingCatalogProvider,
 	settingsWatcher watcher.SettingsWatcher,
+	auditLogger *audit.Logger,
 ) Routing {
 	return Routing{
 		log:                                   logger,
@@ -121,6 +132,8 @@ func NewRouting(
 		addonConfigProvider:                   addonConfigProvider,
 		sshKeyProvider:                        newSSHKeyProvider,
 		privilegedSSHKeyProvider:              privilegedSSHKeyProvider,
+		clusterTemplateProvider:               clusterTemplateProvider,
+		privilegedClusterTemplateProvider:     privilegedClusterTemplateProvider,
 		userProvider:                          userProvider,
 		serviceAccountProvider:                serviceAccountProvider,
 		privilegedServiceAccountProvider:      privilegedServiceAccountProvider,
@@ -146,7 +159,10 @@ func NewRouting(
 		settingsProvider:                      settingsProvider,
 		adminProvider:                         adminProvider,
 		admissionPluginProvider:               admissionPluginProvider,
+		projectRoleProvider:                   projectRoleProvider,
+		pricingCatalogProvider:                pricingCatalogProvider,
 		settingsWatcher:                       settingsWatcher,
+		auditLogger:                           auditLogger,
 	}
 }
 
@@ -155,5 +171,6 @@ func (r Routing) defaultServerOptions() []httptransport.ServerOption {
 		httptransport.ServerErrorLogger(r.logger),
 		httptransport.ServerErrorEncoder(errorEncoder),
 		httptransport.ServerBefore(middleware.TokenExtractor(r.tokenExtractors)),
+		httptransport.ServerBefore(middleware.AuditRequestExtractor()),
 	}
 }
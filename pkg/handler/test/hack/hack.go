@@ -18,12 +18,14 @@ package hack
 
 import (
 	"net/http"
+	"sync"
 
 	"github.com/gorilla/mux"
 	prometheusapi "github.com/prometheus/client_golang/api"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/kubermatic/kubermatic/pkg/handler"
+	"github.com/kubermatic/kubermatic/pkg/handler/audit"
 	"github.com/kubermatic/kubermatic/pkg/handler/auth"
 	"github.com/kubermatic/kubermatic/pkg/handler/test"
 	"github.com/kubermatic/kubermatic/pkg/handler/v1/common"
@@ -51,6 +53,8 @@ func NewTestRouting(
 	addonConfigProvider provider.AddonConfigProvider,
 	sshKeyProvider provider.SSHKeyProvider,
 	privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider,
+	clusterTemplateProvider provider.ClusterTemplateProvider,
+	privilegedClusterTemplateProvider provider.PrivilegedClusterTemplateProvider,
 	userProvider provider.UserProvider,
 	serviceAccountProvider provider.ServiceAccountProvider,
 	privilegedServiceAccountProvider provider.PrivilegedServiceAccountProvider,
@@ -71,6 +75,8 @@ func NewTestRouting(
 	eventRecorderProvider provider.EventRecorderProvider,
 	presetsProvider provider.PresetProvider,
 	admissionPluginProvider provider.AdmissionPluginsProvider,
+	projectRoleProvider provider.ProjectRoleProvider,
+	pricingCatalogProvider provider.PricingCatalogProvider,
 	settingsWatcher watcher.SettingsWatcher) http.Handler {
 
 	updateManager := version.New(versions, updates)
@@ -84,6 +90,8 @@ func NewTestRouting(
 		addonConfigProvider,
 		sshKeyProvider,
 		privilegedSSHKeyProvider,
+		clusterTemplateProvider,
+		privilegedClusterTemplateProvider,
 		userProvider,
 		serviceAccountProvider,
 		privilegedServiceAccountProvider,
@@ -108,7 +116,10 @@ func NewTestRouting(
 		settingsProvider,
 		adminProvider,
 		admissionPluginProvider,
+		projectRoleProvider,
+		pricingCatalogProvider,
 		settingsWatcher,
+		audit.NewLogger(kubermaticlog.Logger, &auditStore{}),
 	)
 
 	mainRouter := mux.NewRouter()
@@ -145,3 +156,28 @@ func generateDefaultMetrics() common.ServerMetrics {
 		),
 	}
 }
+
+// auditStore keeps the audit trail of a test routing in memory
+type auditStore struct {
+	lock    sync.Mutex
+	entries []audit.Entry
+}
+
+func (s *auditStore) Write(entry *audit.Entry) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.entries = append(s.entries, *entry)
+	return nil
+}
+
+func (s *auditStore) Recent(projectID string, limit int) ([]audit.Entry, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	entries := []audit.Entry{}
+	for i := len(s.entries) - 1; i >= 0 && (limit <= 0 || len(entries) < limit); i-- {
+		if s.entries[i].ProjectID == projectID {
+			entries = append(entries, s.entries[i])
+		}
+	}
+	return entries, nil
+}
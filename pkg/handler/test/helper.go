@@ -35,7 +35,6 @@ import (
 
 	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
 	k8cuserclusterclient "github.com/kubermatic/kubermatic/pkg/cluster/client"
-	"github.com/kubermatic/kubermatic/pkg/controller/master-controller-manager/rbac"
 	kubermaticfakeclentset "github.com/kubermatic/kubermatic/pkg/crd/client/clientset/versioned/fake"
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/handler/auth"
@@ -142,6 +141,8 @@ type newRoutingFunc func(
 	addonConfigProvider provider.AddonConfigProvider,
 	newSSHKeyProvider provider.SSHKeyProvider,
 	privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider,
+	clusterTemplateProvider provider.ClusterTemplateProvider,
+	privilegedClusterTemplateProvider provider.PrivilegedClusterTemplateProvider,
 	userProvider provider.UserProvider,
 	serviceAccountProvider provider.ServiceAccountProvider,
 	privilegedServiceAccountProvider provider.PrivilegedServiceAccountProvider,
@@ -162,6 +163,8 @@ type newRoutingFunc func(
 	eventRecorderProvider provider.EventRecorderProvider,
 	presetsProvider provider.PresetProvider,
 	admissionPluginProvider provider.AdmissionPluginsProvider,
+	projectRoleProvider provider.ProjectRoleProvider,
+	pricingCatalogProvider provider.PricingCatalogProvider,
 	settingsWatcher watcher.SettingsWatcher) http.Handler
 
 func initTestEndpoint(user apiv1.User, seedsGetter provider.SeedsGetter, kubeObjects, machineObjects, kubermaticObjects []runtime.Object, versions []*version.Version, updates []*version.Update, routingFunc newRoutingFunc) (http.Handler, *ClientsSets, error) {
@@ -183,6 +186,11 @@ func initTestEndpoint(user apiv1.User, seedsGetter provider.SeedsGetter, kubeObj
 	if err != nil {
 		return nil, nil, err
 	}
+	clusterTemplateProvider := kubernetes.NewClusterTemplateProvider(fakeImpersonationClient, fakeClient)
+	privilegedClusterTemplateProvider, err := kubernetes.NewPrivilegedClusterTemplateProvider(fakeClient)
+	if err != nil {
+		return nil, nil, err
+	}
 	userProvider := kubernetes.NewUserProvider(fakeClient, kubernetes.IsServiceAccount)
 	adminProvider := kubernetes.NewAdminProvider(fakeClient)
 	settingsProvider := kubernetes.NewSettingsProvider(kubermaticClient, fakeClient)
@@ -238,13 +246,14 @@ func initTestEndpoint(user apiv1.User, seedsGetter provider.SeedsGetter, kubeObj
 		return nil, nil, err
 	}
 
+	projectRoleProvider := kubernetes.NewProjectRoleProvider(context.Background(), fakeClient)
 	fUserClusterConnection := &fakeUserClusterConnection{fakeClient}
 	clusterProvider := kubernetes.NewClusterProvider(
 		&restclient.Config{},
 		fakeImpersonationClient,
 		fUserClusterConnection,
 		"",
-		rbac.ExtractGroupPrefix,
+		projectRoleProvider.UserClusterGroups,
 		fakeClient,
 		kubernetesClient,
 		false,
@@ -302,6 +311,8 @@ func initTestEndpoint(user apiv1.User, seedsGetter provider.SeedsGetter, kubeObj
 		addonConfigProvider,
 		sshKeyProvider,
 		privilegedSSHKeyProvider,
+		clusterTemplateProvider,
+		privilegedClusterTemplateProvider,
 		userProvider,
 		serviceAccountProvider,
 		serviceAccountProvider,
@@ -322,6 +333,8 @@ func initTestEndpoint(user apiv1.User, seedsGetter provider.SeedsGetter, kubeObj
 		eventRecorderProvider,
 		credentialsManager,
 		admissionPluginProvider,
+		projectRoleProvider,
+		kubernetes.NewPricingCatalogProvider(context.Background(), fakeClient),
 		settingsWatcher,
 	)
 
@@ -860,7 +873,7 @@ func GenDefaultSaToken(projectID, saID, name, id string) *corev1.Secret {
 
 func GenDefaultExpiry() (apiv1.Time, error) {
 	authenticator := serviceaccount.JWTTokenAuthenticator([]byte(TestServiceAccountHashKey))
-	claim, _, err := authenticator.Authenticate(TestFakeToken)
+	claim, _, err := authenticator.Parse(TestFakeToken)
 	if err != nil {
 		return apiv1.Time{}, err
 	}
@@ -0,0 +1,113 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/audit"
+	"github.com/kubermatic/kubermatic/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+	k8cerrors "github.com/kubermatic/kubermatic/pkg/util/errors"
+)
+
+// defaultAuditEntriesLimit is the number of entries returned if the request does not specify a limit
+const defaultAuditEntriesLimit = 100
+
+// ListProjectAuditEntriesEndpoint returns the most recent audit entries of the given project
+func ListProjectAuditEntriesEndpoint(userInfoGetter provider.UserInfoGetter, auditLogger *audit.Logger) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(listProjectAuditEntriesReq)
+		if !ok {
+			return nil, k8cerrors.NewBadRequest("invalid request")
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if !userInfo.IsAdmin {
+			return nil, k8cerrors.New(http.StatusForbidden, fmt.Sprintf("forbidden: \"%s\" doesn't have admin rights", userInfo.Email))
+		}
+
+		if auditLogger == nil {
+			return nil, k8cerrors.New(http.StatusNotImplemented, audit.ErrNoStore.Error())
+		}
+		entries, err := auditLogger.Recent(req.ProjectID, req.Limit)
+		if err == audit.ErrNoStore {
+			return nil, k8cerrors.New(http.StatusNotImplemented, err.Error())
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		result := []apiv1.AuditEntry{}
+		for _, entry := range entries {
+			result = append(result, convertInternalAuditEntryToExternal(entry))
+		}
+		return result, nil
+	}
+}
+
+func convertInternalAuditEntryToExternal(entry audit.Entry) apiv1.AuditEntry {
+	return apiv1.AuditEntry{
+		Time:      apiv1.NewTime(entry.Time),
+		User:      entry.User,
+		Admin:     entry.Admin,
+		Endpoint:  entry.Endpoint,
+		ProjectID: entry.ProjectID,
+		Resources: entry.Resources,
+		Outcome:   string(entry.Outcome),
+		Code:      entry.Code,
+		Error:     entry.Error,
+	}
+}
+
+// listProjectAuditEntriesReq defines HTTP request for listProjectAuditEntries
+// swagger:parameters listProjectAuditEntries
+type listProjectAuditEntriesReq struct {
+	common.ProjectReq
+	// in: query
+	// Limit is the maximum number of returned entries, defaults to 100
+	Limit int `json:"limit,omitempty"`
+}
+
+func DecodeListProjectAuditEntriesReq(c context.Context, r *http.Request) (interface{}, error) {
+	req := listProjectAuditEntriesReq{
+		ProjectReq: common.ProjectReq{ProjectID: mux.Vars(r)["project_id"]},
+		Limit:      defaultAuditEntriesLimit,
+	}
+	if req.ProjectID == "" {
+		return nil, fmt.Errorf("'project_id' parameter is required but was not provided")
+	}
+
+	if rawLimit := r.URL.Query().Get("limit"); rawLimit != "" {
+		limit, err := strconv.Atoi(rawLimit)
+		if err != nil || limit < 1 {
+			return nil, k8cerrors.NewBadRequest("invalid limit %q, must be a positive number", rawLimit)
+		}
+		req.Limit = limit
+	}
+
+	return req, nil
+}
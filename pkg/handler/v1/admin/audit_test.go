@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/test"
+	"github.com/kubermatic/kubermatic/pkg/handler/test/hack"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestListProjectAuditEntriesEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		name                   string
+		isAdmin                bool
+		httpStatus             int
+		expectedResponse       string
+		expectedEntries        []apiv1.AuditEntry
+		existingKubermaticObjs []runtime.Object
+	}{
+		{
+			name:             "scenario 1: not authorized user lists audit entries",
+			httpStatus:       http.StatusForbidden,
+			expectedResponse: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+		},
+		{
+			name:       "scenario 2: admin lists the renaming and the failed deletion of a cluster",
+			isAdmin:    true,
+			httpStatus: http.StatusOK,
+			expectedEntries: []apiv1.AuditEntry{
+				{
+					User:      "bob@acme.com",
+					Admin:     true,
+					Endpoint:  "DELETE /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}",
+					ProjectID: "my-first-project-ID",
+					Resources: map[string]string{"project_id": "my-first-project-ID", "dc": "us-central1", "cluster_id": "missing"},
+					Outcome:   "failure",
+					Code:      http.StatusNotFound,
+					Error:     `clusters.kubermatic.k8s.io "missing" not found`,
+				},
+				{
+					User:      "bob@acme.com",
+					Admin:     true,
+					Endpoint:  "PUT /api/v1/projects/{project_id}",
+					ProjectID: "my-first-project-ID",
+					Resources: map[string]string{"project_id": "my-first-project-ID"},
+					Outcome:   "success",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			kubermaticObj := []runtime.Object{
+				test.GenProject("my-first-project", kubermaticv1.ProjectActive, test.DefaultCreationTimestamp()),
+				genUser("Bob", "bob@acme.com", tc.isAdmin),
+				test.GenDefaultOwnerBinding(),
+			}
+			ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), []runtime.Object{}, kubermaticObj, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			// take some actions which end up in the audit trail
+			actions := []*http.Request{
+				httptest.NewRequest("PUT", "/api/v1/projects/my-first-project-ID", strings.NewReader(`{"name":"renamed-project"}`)),
+				httptest.NewRequest("DELETE", "/api/v1/projects/my-first-project-ID/dc/us-central1/clusters/missing", strings.NewReader("")),
+			}
+			for _, action := range actions {
+				ep.ServeHTTP(httptest.NewRecorder(), action)
+			}
+
+			req := httptest.NewRequest("GET", "/api/v1/admin/projects/my-first-project-ID/audit", strings.NewReader(""))
+			res := httptest.NewRecorder()
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+			if tc.expectedResponse != "" {
+				test.CompareWithResult(t, res, tc.expectedResponse)
+				return
+			}
+
+			entries := []apiv1.AuditEntry{}
+			if err := json.Unmarshal(res.Body.Bytes(), &entries); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(entries) != len(tc.expectedEntries) {
+				t.Fatalf("expected %d entries, got %d: %s", len(tc.expectedEntries), len(entries), res.Body.String())
+			}
+			for i := range entries {
+				if entries[i].Time.IsZero() {
+					t.Errorf("expected entry %d to have a time", i)
+				}
+				entries[i].Time = apiv1.Time{}
+				expected, _ := json.Marshal(tc.expectedEntries[i])
+				actual, _ := json.Marshal(entries[i])
+				if string(expected) != string(actual) {
+					t.Errorf("expected entry %d to be\n%s\ngot\n%s", i, expected, actual)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	v1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+	"github.com/kubermatic/kubermatic/pkg/util/errors"
+)
+
+// PricingCatalogEndpoint returns the pricing catalog
+func PricingCatalogEndpoint(pricingCatalogProvider provider.PricingCatalogProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		catalog, err := pricingCatalogProvider.Get()
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return v1.PricingCatalog(catalog.Spec), nil
+	}
+}
+
+// UpdatePricingCatalogEndpoint replaces the prices of the pricing catalog
+func UpdatePricingCatalogEndpoint(userInfoGetter provider.UserInfoGetter, pricingCatalogProvider provider.PricingCatalogProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updatePricingCatalogReq)
+		if err := validatePricingCatalog(kubermaticv1.PricingCatalogSpec(req.Body)); err != nil {
+			return nil, errors.NewBadRequest("%v", err)
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		catalog, err := pricingCatalogProvider.Get()
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		catalog.Spec = kubermaticv1.PricingCatalogSpec(req.Body)
+		if catalog.Spec.Prices == nil {
+			catalog.Spec.Prices = []kubermaticv1.MachinePrice{}
+		}
+
+		catalog, err = pricingCatalogProvider.Update(userInfo, catalog)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return v1.PricingCatalog(catalog.Spec), nil
+	}
+}
+
+func validatePricingCatalog(spec kubermaticv1.PricingCatalogSpec) error {
+	if len(spec.Prices) > 0 && spec.Currency == "" {
+		return fmt.Errorf("the currency of the prices cannot be empty")
+	}
+	seen := map[string]bool{}
+	for _, price := range spec.Prices {
+		if price.Provider == "" || price.Size == "" {
+			return fmt.Errorf("the provider and the size of a price cannot be empty")
+		}
+		if price.HourlyCost < 0 {
+			return fmt.Errorf("the hourly cost of size %q of provider %q cannot be negative", price.Size, price.Provider)
+		}
+		key := fmt.Sprintf("%s/%s/%s", price.Provider, price.Datacenter, price.Size)
+		if seen[key] {
+			return fmt.Errorf("size %q of provider %q is priced more than once for datacenter %q", price.Size, price.Provider, price.Datacenter)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// updatePricingCatalogReq defines HTTP request for updatePricingCatalog endpoint
+// swagger:parameters updatePricingCatalog
+type updatePricingCatalogReq struct {
+	// in: body
+	Body v1.PricingCatalog
+}
+
+func DecodeUpdatePricingCatalogReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req updatePricingCatalogReq
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("unable to parse the input: %v", err)
+	}
+
+	return req, nil
+}
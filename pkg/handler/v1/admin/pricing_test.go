@@ -0,0 +1,174 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/test"
+	"github.com/kubermatic/kubermatic/pkg/handler/test/hack"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetPricingCatalog(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		name                   string
+		expectedResponse       string
+		httpStatus             int
+		existingAPIUser        *apiv1.User
+		existingKubermaticObjs []runtime.Object
+	}{
+		// scenario 1
+		{
+			name:                   "scenario 1: user gets the empty pricing catalog",
+			expectedResponse:       `{"currency":"","prices":[]}`,
+			httpStatus:             http.StatusOK,
+			existingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 2
+		{
+			name:             "scenario 2: user gets the existing pricing catalog",
+			expectedResponse: `{"currency":"USD","prices":[{"provider":"aws","size":"t3.medium","hourlyCost":0.0416}]}`,
+			httpStatus:       http.StatusOK,
+			existingKubermaticObjs: append(test.GenDefaultKubermaticObjects(),
+				genPricingCatalog()),
+			existingAPIUser: test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/admin/pricing", strings.NewReader(""))
+			res := httptest.NewRecorder()
+			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.existingAPIUser, nil, nil, nil, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.expectedResponse)
+		})
+	}
+}
+
+func TestUpdatePricingCatalog(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		name                   string
+		body                   string
+		expectedResponse       string
+		httpStatus             int
+		existingAPIUser        *apiv1.User
+		existingKubermaticObjs []runtime.Object
+	}{
+		// scenario 1
+		{
+			name:                   "scenario 1: unauthorized user updates the pricing catalog",
+			body:                   `{"currency":"USD","prices":[{"provider":"aws","size":"t3.medium","hourlyCost":0.05}]}`,
+			expectedResponse:       `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			httpStatus:             http.StatusForbidden,
+			existingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 2
+		{
+			name:                   "scenario 2: authorized user creates the pricing catalog",
+			body:                   `{"currency":"USD","prices":[{"provider":"aws","size":"t3.medium","hourlyCost":0.05}]}`,
+			expectedResponse:       `{"currency":"USD","prices":[{"provider":"aws","size":"t3.medium","hourlyCost":0.05}]}`,
+			httpStatus:             http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true)},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 3
+		{
+			name:             "scenario 3: authorized user replaces the prices of the existing pricing catalog",
+			body:             `{"currency":"EUR","prices":[{"provider":"hetzner","datacenter":"hetzner-nbg1","size":"cx21","hourlyCost":0.0095}]}`,
+			expectedResponse: `{"currency":"EUR","prices":[{"provider":"hetzner","datacenter":"hetzner-nbg1","size":"cx21","hourlyCost":0.0095}]}`,
+			httpStatus:       http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true),
+				genPricingCatalog()},
+			existingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 4
+		{
+			name:                   "scenario 4: sizes can only be priced once per datacenter",
+			body:                   `{"currency":"USD","prices":[{"provider":"aws","size":"t3.medium","hourlyCost":0.05},{"provider":"aws","size":"t3.medium","hourlyCost":0.06}]}`,
+			expectedResponse:       `{"error":{"code":400,"message":"size \"t3.medium\" of provider \"aws\" is priced more than once for datacenter \"\""}}`,
+			httpStatus:             http.StatusBadRequest,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true)},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 5
+		{
+			name:                   "scenario 5: prices require a currency",
+			body:                   `{"prices":[{"provider":"aws","size":"t3.medium","hourlyCost":0.05}]}`,
+			expectedResponse:       `{"error":{"code":400,"message":"the currency of the prices cannot be empty"}}`,
+			httpStatus:             http.StatusBadRequest,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true)},
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("PUT", "/api/v1/admin/pricing", strings.NewReader(tc.body))
+			res := httptest.NewRecorder()
+			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.existingAPIUser, nil, nil, nil, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.expectedResponse)
+		})
+	}
+}
+
+func genPricingCatalog() *kubermaticv1.PricingCatalog {
+	return &kubermaticv1.PricingCatalog{
+		ObjectMeta: v1.ObjectMeta{
+			Name: kubermaticv1.GlobalPricingCatalogName,
+		},
+		Spec: kubermaticv1.PricingCatalogSpec{
+			Currency: "USD",
+			Prices: []kubermaticv1.MachinePrice{
+				{Provider: "aws", Size: "t3.medium", HourlyCost: 0.0416},
+			},
+		},
+	}
+}
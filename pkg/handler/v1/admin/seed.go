@@ -38,8 +38,8 @@ import (
 	k8cerrors "github.com/kubermatic/kubermatic/pkg/util/errors"
 )
 
-// ListSeedsEndpoint returns seed list
-func ListSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter) endpoint.Endpoint {
+// ListSeedsEndpoint returns seed list together with the usage of their capacity
+func ListSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter, seedClientGetter provider.SeedClientGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		userInfo, err := userInfoGetter(ctx, "")
 		if err != nil {
@@ -58,6 +58,7 @@ func ListSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provid
 			resultList = append(resultList, apiv1.Seed{
 				Name:     key,
 				SeedSpec: convertSeedSpec(value.Spec, key),
+				Usage:    getSeedUsage(ctx, value, seedClientGetter),
 			})
 		}
 
@@ -65,8 +66,8 @@ func ListSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provid
 	}
 }
 
-// GetSeedEndpoint returns seed element
-func GetSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter) endpoint.Endpoint {
+// GetSeedEndpoint returns seed element together with the usage of its capacity
+func GetSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter, seedClientGetter provider.SeedClientGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(seedReq)
 		if !ok {
@@ -79,6 +80,7 @@ func GetSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter provide
 		return apiv1.Seed{
 			Name:     req.Name,
 			SeedSpec: convertSeedSpec(seed.Spec, req.Name),
+			Usage:    getSeedUsage(ctx, seed, seedClientGetter),
 		}, nil
 	}
 }
@@ -139,6 +141,21 @@ func DeleteSeedEndpoint(userInfoGetter provider.UserInfoGetter, seedsGetter prov
 	}
 }
 
+// getSeedUsage returns the usage of the capacity of the seed, seeds which can't be reached have no usage
+func getSeedUsage(ctx context.Context, seed *kubermaticv1.Seed, seedClientGetter provider.SeedClientGetter) *apiv1.SeedUsage {
+	seedClient, err := seedClientGetter(seed)
+	if err != nil {
+		log.Logger.Warnw("failed to get the client of the seed", "seed", seed.Name, "error", err)
+		return nil
+	}
+	usage, err := common.GetSeedUsage(ctx, seedClient)
+	if err != nil {
+		log.Logger.Warnw("failed to get the usage of the seed", "seed", seed.Name, "error", err)
+		return nil
+	}
+	return common.ConvertInternalSeedUsageToExternal(seed.Spec.Capacity, usage)
+}
+
 func getSeed(ctx context.Context, req seedReq, userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter) (*kubermaticv1.Seed, error) {
 	userInfo, err := userInfoGetter(ctx, "")
 	if err != nil {
@@ -229,6 +246,7 @@ func convertSeedSpec(seedSpec kubermaticv1.SeedSpec, seedName string) apiv1.Seed
 		SeedDNSOverwrite: seedSpec.SeedDNSOverwrite,
 		ProxySettings:    seedSpec.ProxySettings,
 		ExposeStrategy:   seedSpec.ExposeStrategy,
+		Capacity:         convertSeedCapacity(seedSpec.Capacity),
 	}
 	if seedSpec.Datacenters != nil {
 		resultSeedSpec.SeedDatacenters = make(map[string]apiv1.Datacenter)
@@ -250,3 +268,19 @@ func convertSeedSpec(seedSpec kubermaticv1.SeedSpec, seedName string) apiv1.Seed
 
 	return resultSeedSpec
 }
+
+func convertSeedCapacity(capacity *kubermaticv1.SeedCapacity) *apiv1.SeedCapacity {
+	if capacity == nil {
+		return nil
+	}
+	result := &apiv1.SeedCapacity{
+		MaxClusters: capacity.MaxClusters,
+	}
+	if capacity.ControlPlaneCPU != nil {
+		result.ControlPlaneCPU = capacity.ControlPlaneCPU.String()
+	}
+	if capacity.ControlPlaneMemory != nil {
+		result.ControlPlaneMemory = capacity.ControlPlaneMemory.String()
+	}
+	return result
+}
@@ -73,157 +73,215 @@ const (
 // clusterTypes holds a list of supported cluster types
 var clusterTypes = sets.NewString(apiv1.OpenShiftClusterType, apiv1.KubernetesClusterType)
 
-func CreateEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter,
+func CreateEndpoint(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter,
 	initNodeDeploymentFailures *prometheus.CounterVec, eventRecorderProvider provider.EventRecorderProvider, credentialManager provider.PresetProvider,
 	exposeStrategy corev1.ServiceType, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(CreateReq)
-		globalSettings, err := settingsProvider.GetGlobalSettings()
-		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
-		}
-		err = req.Validate(globalSettings.Spec.ClusterTypeOptions, updateManager)
-		if err != nil {
-			return nil, errors.NewBadRequest(err.Error())
+		nodeDeployments := []*apiv1.NodeDeployment{}
+		if req.Body.NodeDeployment != nil {
+			nodeDeployments = append(nodeDeployments, req.Body.NodeDeployment)
 		}
+		return CreateCluster(ctx, sshKeyProvider, projectProvider, privilegedProjectProvider, seedsGetter, clusterProviderGetter, initNodeDeploymentFailures, eventRecorderProvider, credentialManager, exposeStrategy, userInfoGetter, settingsProvider, updateManager, req, nodeDeployments)
+	}
+}
 
-		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
-		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
-		adminUserInfo, err := userInfoGetter(ctx, "")
-		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
-		}
-		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, &provider.ProjectGetOptions{IncludeUninitialized: false})
+// CreateCluster creates the cluster of the given request and its initial node deployments. The node deployments
+// get created in the background, the node deployment of the request body is ignored.
+// It expects the cluster providers to be stored in the context by the middleware.
+func CreateCluster(ctx context.Context, sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter,
+	initNodeDeploymentFailures *prometheus.CounterVec, eventRecorderProvider provider.EventRecorderProvider, credentialManager provider.PresetProvider,
+	exposeStrategy corev1.ServiceType, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager,
+	req CreateReq, nodeDeployments []*apiv1.NodeDeployment) (*apiv1.Cluster, error) {
+	globalSettings, err := settingsProvider.GetGlobalSettings()
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	err = req.Validate(globalSettings.Spec.ClusterTypeOptions, updateManager)
+	if err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+	adminUserInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, &provider.ProjectGetOptions{IncludeUninitialized: false})
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	placement, err := common.ScheduleCluster(ctx, adminUserInfo, seedsGetter, clusterProviderGetter, req.Body.Cluster.Spec.Cloud)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	seed, dc := placement.Seed, placement.Datacenter
+	if placement.Redirected {
+		// the seed of the requested datacenter is full, the cluster gets created in an equivalent datacenter of another seed
+		kubermaticlog.Logger.Infow("seed is full, scheduling cluster to an alternative datacenter", "datacenter", req.Body.Cluster.Spec.Cloud.DatacenterName, "alternative", placement.DatacenterName, "seed", seed.Name)
+		clusterProvider, err = clusterProviderGetter(seed)
 		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
+			return nil, errors.NewNotFound("cluster-provider", seed.Name)
 		}
-		k8sClient := privilegedClusterProvider.GetSeedClusterAdminClient()
+		privilegedClusterProvider = clusterProvider.(provider.PrivilegedClusterProvider)
+		req.Body.Cluster.Spec.Cloud.DatacenterName = placement.DatacenterName
+	}
+	k8sClient := privilegedClusterProvider.GetSeedClusterAdminClient()
 
-		seed, dc, err := provider.DatacenterFromSeedMap(adminUserInfo, seedsGetter, req.Body.Cluster.Spec.Cloud.DatacenterName)
+	credentialName := req.Body.Cluster.Credential
+	if len(credentialName) > 0 {
+		cloudSpec, err := credentialManager.SetCloudCredentials(adminUserInfo, credentialName, req.Body.Cluster.Spec.Cloud, dc)
 		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
+			return nil, errors.NewBadRequest("invalid credentials: %v", err)
 		}
+		req.Body.Cluster.Spec.Cloud = *cloudSpec
+	}
 
-		credentialName := req.Body.Cluster.Credential
-		if len(credentialName) > 0 {
-			cloudSpec, err := credentialManager.SetCloudCredentials(adminUserInfo, credentialName, req.Body.Cluster.Spec.Cloud, dc)
-			if err != nil {
-				return nil, errors.NewBadRequest("invalid credentials: %v", err)
-			}
-			req.Body.Cluster.Spec.Cloud = *cloudSpec
-		}
+	// Create the cluster.
+	secretKeyGetter := provider.SecretKeySelectorValueFuncFactory(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient())
+	spec, err := cluster.Spec(req.Body.Cluster, dc, secretKeyGetter)
+	if err != nil {
+		return nil, errors.NewBadRequest("invalid cluster: %v", err)
+	}
 
-		// Create the cluster.
-		secretKeyGetter := provider.SecretKeySelectorValueFuncFactory(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient())
-		spec, err := cluster.Spec(req.Body.Cluster, dc, secretKeyGetter)
-		if err != nil {
-			return nil, errors.NewBadRequest("invalid cluster: %v", err)
-		}
+	// master level ExposeStrategy is the default
+	spec.ExposeStrategy = exposeStrategy
+	if seed.Spec.ExposeStrategy != "" {
+		spec.ExposeStrategy = seed.Spec.ExposeStrategy
+	}
 
-		// master level ExposeStrategy is the default
-		spec.ExposeStrategy = exposeStrategy
-		if seed.Spec.ExposeStrategy != "" {
-			spec.ExposeStrategy = seed.Spec.ExposeStrategy
-		}
+	existingClusters, err := clusterProvider.List(project, &provider.ClusterListOptions{ClusterSpecName: spec.HumanReadableName})
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
 
-		existingClusters, err := clusterProvider.List(project, &provider.ClusterListOptions{ClusterSpecName: spec.HumanReadableName})
+	if len(existingClusters.Items) > 0 {
+		return nil, errors.NewAlreadyExists("cluster", spec.HumanReadableName)
+	}
+
+	var isBYO bool
+	if len(nodeDeployments) > 0 {
+		isBYO, err = common.IsBringYourOwnProvider(spec.Cloud)
 		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
+			return nil, errors.NewBadRequest("failed to create an initial node deployment due to an invalid spec: %v", err)
 		}
-
-		if len(existingClusters.Items) > 0 {
-			return nil, errors.NewAlreadyExists("cluster", spec.HumanReadableName)
+	}
+	quotaRequest := common.ProjectResourceRequest{Clusters: 1}
+	for _, nodeDeployment := range nodeDeployments {
+		if isBYO || nodeDeployment == nil || nodeDeployment.Spec.Replicas <= 0 {
+			continue
+		}
+		if err := quotaRequest.AddNodeDeployment(nodeDeployment); err != nil {
+			return nil, errors.NewBadRequest("invalid node deployment: %v", err)
 		}
+	}
+	if err := common.CheckProjectQuota(ctx, clusterProviderGetter, seedsGetter, privilegedProjectProvider, project, quotaRequest); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
 
-		if err = validation.ValidateUpdateWindow(spec.UpdateWindow); err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
+	if err = validation.ValidateUpdateWindow(spec.UpdateWindow); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	if err = validation.ValidateEtcdBackupSettings(spec.EtcdBackup); err != nil {
+		return nil, errors.NewBadRequest("invalid etcd backup settings: %v", err)
+	}
+	if err = validation.ValidateHibernationSettings(spec.Hibernation, req.Body.Cluster.Type == "openshift"); err != nil {
+		return nil, errors.NewBadRequest("invalid hibernation settings: %v", err)
+	}
+	if err = validation.ValidateAPIServerAllowedSourceRanges(spec.APIServerAllowedSourceRanges, spec.ExposeStrategy, seed); err != nil {
+		return nil, errors.NewBadRequest("invalid apiserver allowed source ranges: %v", err)
+	}
+	if err = validation.ValidateAuditLoggingSettings(spec.AuditLogging); err != nil {
+		return nil, errors.NewBadRequest("invalid audit logging settings: %v", err)
+	}
+	partialCluster := &kubermaticv1.Cluster{}
+	partialCluster.Labels = req.Body.Cluster.Labels
+	partialCluster.Spec = *spec
+	if req.Body.Cluster.Type == "openshift" {
+		if req.Body.Cluster.Spec.Openshift == nil || req.Body.Cluster.Spec.Openshift.ImagePullSecret == "" {
+			return nil, errors.NewBadRequest("openshift clusters must be configured with an imagePullSecret")
 		}
-		partialCluster := &kubermaticv1.Cluster{}
-		partialCluster.Labels = req.Body.Cluster.Labels
-		partialCluster.Spec = *spec
-		if req.Body.Cluster.Type == "openshift" {
-			if req.Body.Cluster.Spec.Openshift == nil || req.Body.Cluster.Spec.Openshift.ImagePullSecret == "" {
-				return nil, errors.NewBadRequest("openshift clusters must be configured with an imagePullSecret")
-			}
-			partialCluster.Annotations = map[string]string{
-				"kubermatic.io/openshift": "true",
-			}
+		partialCluster.Annotations = map[string]string{
+			"kubermatic.io/openshift": "true",
 		}
+	}
 
-		// Enforce audit logging
-		if dc.Spec.EnforceAuditLogging {
-			partialCluster.Spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{
-				Enabled: true,
-			}
+	// Enforce audit logging
+	if dc.Spec.EnforceAuditLogging {
+		if partialCluster.Spec.AuditLogging == nil {
+			partialCluster.Spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{}
 		}
+		partialCluster.Spec.AuditLogging.Enabled = true
+	}
 
-		// Enforce PodSecurityPolicy
-		if dc.Spec.EnforcePodSecurityPolicy {
-			partialCluster.Spec.UsePodSecurityPolicyAdmissionPlugin = true
-		}
+	// Enforce PodSecurityPolicy
+	if dc.Spec.EnforcePodSecurityPolicy {
+		partialCluster.Spec.UsePodSecurityPolicyAdmissionPlugin = true
+	}
 
-		// generate the name here so that it can be used in the secretName below
-		partialCluster.Name = rand.String(10)
+	// generate the name here so that it can be used in the secretName below
+	partialCluster.Name = rand.String(10)
 
-		if cloudcontroller.ExternalCloudControllerFeatureSupported(partialCluster) {
-			partialCluster.Spec.Features = map[string]bool{kubermaticv1.ClusterFeatureExternalCloudProvider: true}
-		}
+	if cloudcontroller.ExternalCloudControllerFeatureSupported(partialCluster) {
+		partialCluster.Spec.Features = map[string]bool{kubermaticv1.ClusterFeatureExternalCloudProvider: true}
+	}
 
-		if err := kubernetesprovider.CreateOrUpdateCredentialSecretForCluster(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), partialCluster); err != nil {
-			return nil, err
-		}
-		kuberneteshelper.AddFinalizer(partialCluster, apiv1.CredentialsSecretsCleanupFinalizer)
+	if err := kubernetesprovider.CreateOrUpdateCredentialSecretForCluster(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient(), partialCluster); err != nil {
+		return nil, err
+	}
+	kuberneteshelper.AddFinalizer(partialCluster, apiv1.CredentialsSecretsCleanupFinalizer)
 
-		newCluster, err := createNewCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, partialCluster)
-		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
-		}
+	newCluster, err := createNewCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, partialCluster)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
 
-		// Create the initial node deployment in the background.
-		if req.Body.NodeDeployment != nil && req.Body.NodeDeployment.Spec.Replicas > 0 {
-			// for BringYourOwn provider we don't create ND
-			isBYO, err := common.IsBringYourOwnProvider(spec.Cloud)
-			if err != nil {
-				return nil, errors.NewBadRequest("failed to create an initial node deployment due to an invalid spec: %v", err)
-			}
-			if !isBYO {
-				go func() {
-					defer utilruntime.HandleCrash()
-					ndName := getNodeDeploymentDisplayName(req.Body.NodeDeployment)
-					eventRecorderProvider.ClusterRecorderFor(k8sClient).Eventf(newCluster, corev1.EventTypeNormal, string(nodeDeploymentCreationStart), "Started creation of initial node deployment %s", ndName)
-					err := createInitialNodeDeploymentWithRetries(ctx, req.Body.NodeDeployment, newCluster, project, sshKeyProvider, seedsGetter, clusterProvider, privilegedClusterProvider, userInfoGetter)
-					if err != nil {
-						eventRecorderProvider.ClusterRecorderFor(k8sClient).Eventf(newCluster, corev1.EventTypeWarning, string(nodeDeploymentCreationFail), "Failed to create initial node deployment %s: %v", ndName, err)
-						klog.Errorf("failed to create initial node deployment for cluster %s: %v", newCluster.Name, err)
-						initNodeDeploymentFailures.With(prometheus.Labels{"cluster": newCluster.Name, "datacenter": req.Body.Cluster.Spec.Cloud.DatacenterName}).Add(1)
-					} else {
-						eventRecorderProvider.ClusterRecorderFor(k8sClient).Eventf(newCluster, corev1.EventTypeNormal, string(nodeDeploymentCreationSuccess), "Successfully created initial node deployment %s", ndName)
-						klog.V(5).Infof("created initial node deployment for cluster %s", newCluster.Name)
-					}
-				}()
-			} else {
-				klog.V(5).Infof("KubeAdm provider detected an initial node deployment won't be created for cluster %s", newCluster.Name)
-			}
+	// Create the initial node deployments in the background.
+	for _, nodeDeployment := range nodeDeployments {
+		if nodeDeployment == nil || nodeDeployment.Spec.Replicas <= 0 {
+			continue
+		}
+		// for BringYourOwn provider we don't create ND
+		if !isBYO {
+			nodeDeployment := nodeDeployment
+			go func() {
+				defer utilruntime.HandleCrash()
+				ndName := getNodeDeploymentDisplayName(nodeDeployment)
+				eventRecorderProvider.ClusterRecorderFor(k8sClient).Eventf(newCluster, corev1.EventTypeNormal, string(nodeDeploymentCreationStart), "Started creation of initial node deployment %s", ndName)
+				err := createInitialNodeDeploymentWithRetries(ctx, nodeDeployment, newCluster, project, sshKeyProvider, seedsGetter, clusterProvider, privilegedClusterProvider, userInfoGetter)
+				if err != nil {
+					eventRecorderProvider.ClusterRecorderFor(k8sClient).Eventf(newCluster, corev1.EventTypeWarning, string(nodeDeploymentCreationFail), "Failed to create initial node deployment %s: %v", ndName, err)
+					klog.Errorf("failed to create initial node deployment for cluster %s: %v", newCluster.Name, err)
+					initNodeDeploymentFailures.With(prometheus.Labels{"cluster": newCluster.Name, "datacenter": req.Body.Cluster.Spec.Cloud.DatacenterName}).Add(1)
+				} else {
+					eventRecorderProvider.ClusterRecorderFor(k8sClient).Eventf(newCluster, corev1.EventTypeNormal, string(nodeDeploymentCreationSuccess), "Successfully created initial node deployment %s", ndName)
+					klog.V(5).Infof("created initial node deployment for cluster %s", newCluster.Name)
+				}
+			}()
+		} else {
+			klog.V(5).Infof("KubeAdm provider detected an initial node deployment won't be created for cluster %s", newCluster.Name)
 		}
+	}
 
-		log := kubermaticlog.Logger.With("cluster", newCluster.Name)
+	log := kubermaticlog.Logger.With("cluster", newCluster.Name)
 
-		// Block for up to 10 seconds to give the rbac controller time to create the bindings.
-		// During that time we swallow all errors
-		if err := wait.PollImmediate(time.Second, 10*time.Second, func() (bool, error) {
-			_, err := getInternalCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, req.ProjectID, newCluster.Name, &provider.ClusterGetOptions{})
-			if err != nil {
-				log.Debugw("Error when waiting for cluster to become ready after creation", zap.Error(err))
-				return false, nil
-			}
-			return true, nil
-		}); err != nil {
-			log.Error("Timed out waiting for cluster to become ready")
-			return convertInternalClusterToExternal(newCluster, true), errors.New(http.StatusInternalServerError, "timed out waiting for cluster to become ready")
+	// Block for up to 10 seconds to give the rbac controller time to create the bindings.
+	// During that time we swallow all errors
+	if err := wait.PollImmediate(time.Second, 10*time.Second, func() (bool, error) {
+		_, err := getInternalCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, req.ProjectID, newCluster.Name, &provider.ClusterGetOptions{})
+		if err != nil {
+			log.Debugw("Error when waiting for cluster to become ready after creation", zap.Error(err))
+			return false, nil
 		}
-
-		return convertInternalClusterToExternal(newCluster, true), nil
+		return true, nil
+	}); err != nil {
+		log.Error("Timed out waiting for cluster to become ready")
+		return convertInternalClusterToExternal(newCluster, true), errors.New(http.StatusInternalServerError, "timed out waiting for cluster to become ready")
 	}
+
+	return convertInternalClusterToExternal(newCluster, true), nil
 }
 
 func createNewCluster(ctx context.Context, userInfoGetter provider.UserInfoGetter, clusterProvider provider.ClusterProvider, privilegedClusterProvider provider.PrivilegedClusterProvider, project *kubermaticv1.Project, cluster *kubermaticv1.Cluster) (*kubermaticv1.Cluster, error) {
@@ -294,6 +352,12 @@ func createInitialNodeDeployment(endpointContext context.Context, nodeDeployment
 	if err != nil {
 		return fmt.Errorf("error getting dc: %v", err)
 	}
+	if err := machineresource.ValidateOperatingSystemImage(&nd.Spec.Template, dc, ""); err != nil {
+		return fmt.Errorf("node deployment is not valid: %v", err)
+	}
+	if err := machineresource.SetDefaultOperatingSystemImage(&nd.Spec.Template, dc, nil); err != nil {
+		return fmt.Errorf("node deployment is not valid: %v", err)
+	}
 
 	assertedClusterProvider, ok := clusterProvider.(*kubernetesprovider.ClusterProvider)
 	if !ok {
@@ -462,6 +526,9 @@ func PatchEndpoint(projectProvider provider.ProjectProvider, privilegedProjectPr
 		newInternalCluster.Spec.AuditLogging = patchedCluster.Spec.AuditLogging
 		newInternalCluster.Spec.Openshift = patchedCluster.Spec.Openshift
 		newInternalCluster.Spec.UpdateWindow = patchedCluster.Spec.UpdateWindow
+		newInternalCluster.Spec.EtcdBackup = patchedCluster.Spec.EtcdBackup
+		newInternalCluster.Spec.Hibernation = patchedCluster.Spec.Hibernation
+		newInternalCluster.Spec.APIServerAllowedSourceRanges = patchedCluster.Spec.APIServerAllowedSourceRanges
 
 		incompatibleKubelets, err := common.CheckClusterVersionSkew(ctx, userInfoGetter, clusterProvider, newInternalCluster, req.ProjectID)
 		if err != nil {
@@ -475,7 +542,7 @@ func PatchEndpoint(projectProvider provider.ProjectProvider, privilegedProjectPr
 		if err != nil {
 			return nil, errors.New(http.StatusInternalServerError, err.Error())
 		}
-		_, dc, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, newInternalCluster.Spec.Cloud.DatacenterName)
+		seed, dc, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, newInternalCluster.Spec.Cloud.DatacenterName)
 		if err != nil {
 			return nil, fmt.Errorf("error getting dc: %v", err)
 		}
@@ -486,9 +553,10 @@ func PatchEndpoint(projectProvider provider.ProjectProvider, privilegedProjectPr
 
 		// Enforce audit logging
 		if dc.Spec.EnforceAuditLogging {
-			newInternalCluster.Spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{
-				Enabled: true,
+			if newInternalCluster.Spec.AuditLogging == nil {
+				newInternalCluster.Spec.AuditLogging = &kubermaticv1.AuditLoggingSettings{}
 			}
+			newInternalCluster.Spec.AuditLogging.Enabled = true
 		}
 
 		// Enforce PodSecurityPolicy
@@ -506,6 +574,18 @@ func PatchEndpoint(projectProvider provider.ProjectProvider, privilegedProjectPr
 		if err = validation.ValidateUpdateWindow(newInternalCluster.Spec.UpdateWindow); err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
+		if err = validation.ValidateEtcdBackupSettings(newInternalCluster.Spec.EtcdBackup); err != nil {
+			return nil, errors.NewBadRequest("invalid etcd backup settings: %v", err)
+		}
+		if err = validation.ValidateHibernationSettings(newInternalCluster.Spec.Hibernation, newInternalCluster.IsOpenshift()); err != nil {
+			return nil, errors.NewBadRequest("invalid hibernation settings: %v", err)
+		}
+		if err = validation.ValidateAPIServerAllowedSourceRanges(newInternalCluster.Spec.APIServerAllowedSourceRanges, newInternalCluster.Spec.ExposeStrategy, seed); err != nil {
+			return nil, errors.NewBadRequest("invalid apiserver allowed source ranges: %v", err)
+		}
+		if err = validation.ValidateAuditLoggingSettings(newInternalCluster.Spec.AuditLogging); err != nil {
+			return nil, errors.NewBadRequest("invalid audit logging settings: %v", err)
+		}
 
 		updatedCluster, err := updateCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, newInternalCluster)
 		if err != nil {
@@ -870,6 +950,9 @@ func convertInternalClusterToExternal(internalCluster *kubermaticv1.Cluster, fil
 			UsePodSecurityPolicyAdmissionPlugin: internalCluster.Spec.UsePodSecurityPolicyAdmissionPlugin,
 			UsePodNodeSelectorAdmissionPlugin:   internalCluster.Spec.UsePodNodeSelectorAdmissionPlugin,
 			AdmissionPlugins:                    internalCluster.Spec.AdmissionPlugins,
+			EtcdBackup:                          internalCluster.Spec.EtcdBackup,
+			Hibernation:                         internalCluster.Spec.Hibernation,
+			APIServerAllowedSourceRanges:        internalCluster.Spec.APIServerAllowedSourceRanges,
 		},
 		Status: apiv1.ClusterStatus{
 			Version: internalCluster.Spec.Version,
@@ -877,6 +960,9 @@ func convertInternalClusterToExternal(internalCluster *kubermaticv1.Cluster, fil
 		},
 		Type: apiv1.KubernetesClusterType,
 	}
+	if internalCluster.Status.Hibernation != nil {
+		cluster.Status.Hibernation = internalCluster.Status.Hibernation.Phase
+	}
 
 	if filterSystemLabels {
 		cluster.Labels = label.FilterLabels(label.ClusterResourceType, internalCluster.Labels)
@@ -918,6 +918,37 @@ func TestCreateClusterEndpoint(t *testing.T) {
 			ProjectToSync:          test.GenDefaultProject().Name,
 			ExistingAPIUser:        test.GenDefaultAPIUser(),
 		},
+		// scenario 15
+		{
+			Name:             "scenario 15: a cluster is rejected when the cluster quota of the project is exhausted",
+			Body:             `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse: `{"error":{"code":403,"message":"project quota exceeded: clusters (used 1, requested 1, limit 1)"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingProject:  genProjectWithQuota(1),
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: []runtime.Object{
+				test.GenDefaultUser(),
+				test.GenDefaultOwnerBinding(),
+				test.GenDefaultCluster(),
+			},
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 16
+		{
+			Name:             "scenario 16: a cluster is created when the project stays within its quota",
+			Body:             `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse: `{"id":"%s","name":"keen-snyder","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{}},"status":{"version":"1.15.0","url":""}}`,
+			RewriteClusterID: true,
+			HTTPStatus:       http.StatusCreated,
+			ExistingProject:  genProjectWithQuota(2),
+			ProjectToSync:    test.GenDefaultProject().Name,
+			ExistingKubermaticObjs: []runtime.Object{
+				test.GenDefaultUser(),
+				test.GenDefaultOwnerBinding(),
+				test.GenDefaultCluster(),
+			},
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
 	}
 
 	for _, tc := range testcases {
@@ -957,6 +988,33 @@ func TestCreateClusterEndpoint(t *testing.T) {
 	}
 }
 
+func TestCreateClusterOnFullSeed(t *testing.T) {
+	t.Parallel()
+	maxClusters := int64(1)
+	seed := test.GenTestSeed()
+	seed.Spec.Capacity = &kubermaticv1.SeedCapacity{MaxClusters: &maxClusters}
+	seedsGetter := func() (map[string]*kubermaticv1.Seed, error) {
+		return map[string]*kubermaticv1.Seed{seed.Name: seed}, nil
+	}
+	body := `{"cluster":{"name":"keen-snyder","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`
+	expectedResponse := `{"error":{"code":503,"message":"the seed of datacenter fake-dc is full and none of its alternative fake datacenters has capacity left: clusters (used 1, limit 1)"}}`
+
+	req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters", test.GenDefaultProject().Name), strings.NewReader(body))
+	res := httptest.NewRecorder()
+	kubermaticObjs := test.GenDefaultKubermaticObjects(test.GenDefaultCluster())
+	ep, _, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), seedsGetter, []runtime.Object{}, nil, kubermaticObjs, test.GenDefaultVersions(), nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+
+	ep.ServeHTTP(res, req)
+
+	if res.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusServiceUnavailable, res.Code, res.Body.String())
+	}
+	test.CompareWithResult(t, res, expectedResponse)
+}
+
 func TestGetClusterHealth(t *testing.T) {
 	t.Parallel()
 	testcases := []struct {
@@ -1223,6 +1281,23 @@ func TestPatchCluster(t *testing.T) {
 					return cluster
 				}(), genUser("John", "john@acme.com", false)),
 		},
+		// scenario 8
+		{
+			Name:             "scenario 8: hibernation schedules can not be set for openshift clusters",
+			Body:             `{"spec":{"hibernation":{"schedules":[{"start":"19:00","length":"12h"}]}}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid hibernation settings: hibernation is not supported for openshift clusters"}}`,
+			cluster:          "keen-snyder",
+			HTTPStatus:       http.StatusBadRequest,
+			project:          test.GenDefaultProject().Name,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjects: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenCluster("keen-snyder", "clusterAbc", test.GenDefaultProject().Name, time.Date(2013, 02, 03, 19, 54, 0, 0, time.UTC))
+					cluster.Spec.Cloud.DatacenterName = fakeDC
+					cluster.Annotations = map[string]string{"kubermatic.io/openshift": "true"}
+					return cluster
+				}()),
+		},
 	}
 
 	for _, tc := range testcases {
@@ -2202,3 +2277,9 @@ func genUser(name, email string, isAdmin bool) *kubermaticv1.User {
 	user.Spec.IsAdmin = isAdmin
 	return user
 }
+
+func genProjectWithQuota(clusters int64) *kubermaticv1.Project {
+	project := test.GenDefaultProject()
+	project.Spec.Quota = &kubermaticv1.ProjectQuota{Clusters: &clusters}
+	return project
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/middleware"
+	"github.com/kubermatic/kubermatic/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+	"github.com/kubermatic/kubermatic/pkg/util/errors"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// CostEndpoint estimates the cost of the node deployments of the cluster based on the pricing catalog
+func CostEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, pricingCatalogProvider provider.PricingCatalogProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(common.GetClusterReq)
+		if !ok {
+			return nil, errors.NewWrongRequest(request, common.GetClusterReq{})
+		}
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		cluster, err := getInternalCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{})
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		catalog, err := pricingCatalogProvider.Get()
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		// The nodes of hibernated clusters are scaled to zero
+		if cluster.Status.Hibernation != nil {
+			return &apiv1.ClusterCost{
+				ClusterID:       cluster.Name,
+				ClusterName:     cluster.Spec.HumanReadableName,
+				Currency:        catalog.Spec.Currency,
+				NodeDeployments: []apiv1.NodeDeploymentCost{},
+			}, nil
+		}
+
+		if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+			return nil, common.KubernetesErrorToHTTPError(kerrors.NewServiceUnavailable("the API server of the cluster is not running"))
+		}
+
+		client, err := common.GetClusterClient(ctx, userInfoGetter, clusterProvider, cluster, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		cost, err := common.GetClusterCost(ctx, client, cluster, &catalog.Spec)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return cost, nil
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/middleware"
+	"github.com/kubermatic/kubermatic/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+	"github.com/kubermatic/kubermatic/pkg/util/errors"
+)
+
+// HibernateEndpoint enables the hibernation of the cluster, the control plane and the nodes get scaled to zero
+func HibernateEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		return setHibernationEnabled(ctx, request, true, projectProvider, privilegedProjectProvider, nil, nil, userInfoGetter)
+	}
+}
+
+// ResumeEndpoint disables the hibernation of the cluster. Clusters which are hibernated by one of their
+// schedules stay hibernated until the schedule ends. The nodes of a hibernated cluster have to fit into
+// the quota of the project again.
+func ResumeEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		return setHibernationEnabled(ctx, request, false, projectProvider, privilegedProjectProvider, seedsGetter, clusterProviderGetter, userInfoGetter)
+	}
+}
+
+func setHibernationEnabled(ctx context.Context, request interface{}, enabled bool, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider,
+	seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter, userInfoGetter provider.UserInfoGetter) (interface{}, error) {
+	req, ok := request.(common.GetClusterReq)
+	if !ok {
+		return nil, errors.NewWrongRequest(request, common.GetClusterReq{})
+	}
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+	project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	cluster, err := getInternalCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, req.ProjectID, req.ClusterID, &provider.ClusterGetOptions{})
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	if cluster.IsOpenshift() {
+		return nil, errors.NewBadRequest("hibernation is not supported for openshift clusters")
+	}
+
+	if cluster.Spec.Hibernation.HibernationEnabled() != enabled {
+		if cluster.Spec.Hibernation == nil {
+			cluster.Spec.Hibernation = &kubermaticv1.HibernationSettings{}
+		}
+		if !enabled && cluster.Status.Hibernation != nil {
+			quotaRequest := common.ProjectResourceRequest{}
+			quotaRequest.ResumeCluster(cluster)
+			if err := common.CheckProjectQuota(ctx, clusterProviderGetter, seedsGetter, privilegedProjectProvider, project, quotaRequest); err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+		}
+		cluster.Spec.Hibernation.Enabled = enabled
+		if cluster, err = updateCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, cluster); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+	}
+
+	return convertInternalClusterToExternal(cluster, true), nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/test"
+	"github.com/kubermatic/kubermatic/pkg/handler/test/hack"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestHibernateAndResumeClusterEndpoint(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		name                   string
+		action                 string
+		expectedResponse       string
+		httpStatus             int
+		expectedEnabled        bool
+		existingAPIUser        *apiv1.User
+		existingKubermaticObjs []runtime.Object
+	}{
+		// scenario 1
+		{
+			name:                   "scenario 1: the owner hibernates the cluster",
+			action:                 "hibernate",
+			expectedResponse:       `{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"FakeDatacenter","fake":{}},"version":"9.9.9","oidc":{},"hibernation":{"enabled":true}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885"}}`,
+			httpStatus:             http.StatusOK,
+			expectedEnabled:        true,
+			existingKubermaticObjs: test.GenDefaultKubermaticObjects(test.GenDefaultCluster()),
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 2
+		{
+			name:             "scenario 2: the owner resumes a hibernated cluster and keeps its schedules",
+			action:           "resume",
+			expectedResponse: `{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"FakeDatacenter","fake":{}},"version":"9.9.9","oidc":{},"hibernation":{"schedules":[{"start":"19:00","length":"12h"}]}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885","hibernation":"Hibernated"}}`,
+			httpStatus:       http.StatusOK,
+			existingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				func() *kubermaticv1.Cluster {
+					cluster := test.GenDefaultCluster()
+					cluster.Spec.Hibernation = &kubermaticv1.HibernationSettings{
+						Enabled:   true,
+						Schedules: []kubermaticv1.HibernationSchedule{{Start: "19:00", Length: "12h"}},
+					}
+					cluster.Status.Hibernation = &kubermaticv1.HibernationStatus{Phase: kubermaticv1.HibernationPhaseHibernated}
+					return cluster
+				}(),
+			),
+			existingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 3
+		{
+			name:             "scenario 3: the user John can not hibernate Bob's cluster",
+			action:           "hibernate",
+			expectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			httpStatus:       http.StatusForbidden,
+			existingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genUser("John", "john@acme.com", false),
+				test.GenDefaultCluster(),
+			),
+			existingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		// scenario 4
+		{
+			name:                   "scenario 4: resuming a cluster is rejected when its nodes exceed the node quota of the project",
+			action:                 "resume",
+			expectedResponse:       `{"error":{"code":403,"message":"project quota exceeded: nodes (used 2, requested 3, limit 4)"}}`,
+			httpStatus:             http.StatusForbidden,
+			existingKubermaticObjs: genHibernatedClustersInProjectWithNodeQuota(4),
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+		// scenario 5
+		{
+			name:                   "scenario 5: a cluster is resumed when its nodes fit into the node quota of the project",
+			action:                 "resume",
+			expectedResponse:       `{"id":"defClusterID","name":"defClusterName","creationTimestamp":"2013-02-03T19:54:00Z","type":"kubernetes","spec":{"cloud":{"dc":"FakeDatacenter","fake":{}},"version":"9.9.9","oidc":{},"hibernation":{}},"status":{"version":"9.9.9","url":"https://w225mx4z66.asia-east1-a-1.cloud.kubermatic.io:31885","hibernation":"Hibernated"}}`,
+			httpStatus:             http.StatusOK,
+			existingKubermaticObjs: genHibernatedClustersInProjectWithNodeQuota(5),
+			existingAPIUser:        test.GenDefaultAPIUser(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ep, clientsSets, err := test.CreateTestEndpointAndGetClients(*tc.existingAPIUser, nil, []runtime.Object{}, []runtime.Object{}, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			res := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters/%s/%s", test.ProjectName, test.DefaultClusterID, tc.action), nil)
+			ep.ServeHTTP(res, req)
+
+			test.CheckStatusCode(tc.httpStatus, res, t)
+			test.CompareWithResult(t, res, tc.expectedResponse)
+			if tc.httpStatus == http.StatusOK {
+				updatedCluster := &kubermaticv1.Cluster{}
+				if err := clientsSets.FakeClient.Get(context.Background(), types.NamespacedName{Name: test.DefaultClusterID}, updatedCluster); err != nil {
+					t.Fatalf("failed to get cluster from fake client: %v", err)
+				}
+				if enabled := updatedCluster.Spec.Hibernation.HibernationEnabled(); enabled != tc.expectedEnabled {
+					t.Errorf("expected hibernation enabled to be %t, got %t", tc.expectedEnabled, enabled)
+				}
+			}
+		})
+	}
+}
+
+// genHibernatedClustersInProjectWithNodeQuota returns a project with the given node quota, containing the
+// hibernated default cluster with 3 nodes and another hibernated cluster with 2 nodes
+func genHibernatedClustersInProjectWithNodeQuota(nodes int64) []runtime.Object {
+	project := test.GenDefaultProject()
+	project.Spec.Quota = &kubermaticv1.ProjectQuota{Nodes: &nodes}
+
+	cluster := test.GenDefaultCluster()
+	cluster.Spec.Hibernation = &kubermaticv1.HibernationSettings{Enabled: true}
+	cluster.Status.Hibernation = &kubermaticv1.HibernationStatus{
+		Phase:              kubermaticv1.HibernationPhaseHibernated,
+		MachineDeployments: map[string]int32{"workers": 2, "gpu-workers": 1},
+	}
+
+	otherCluster := test.GenCluster("otherClusterID", "otherClusterName", project.Name, cluster.CreationTimestamp.Time)
+	otherCluster.Status.Hibernation = &kubermaticv1.HibernationStatus{
+		Phase:              kubermaticv1.HibernationPhaseHibernated,
+		MachineDeployments: map[string]int32{"workers": 2},
+	}
+
+	return []runtime.Object{
+		project,
+		test.GenDefaultUser(),
+		test.GenDefaultOwnerBinding(),
+		cluster,
+		otherCluster,
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/go-kit/kit/endpoint"
 	"github.com/gorilla/securecookie"
 
+	"github.com/kubermatic/kubermatic/pkg/controller/master-controller-manager/rbac"
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/handler/auth"
 	"github.com/kubermatic/kubermatic/pkg/handler/middleware"
@@ -77,11 +78,12 @@ func GetAdminKubeconfigEndpoint(projectProvider provider.ProjectProvider, privil
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
-		if strings.HasPrefix(userInfo.Group, "viewers") {
+		// only owners and editors get the admin kubeconfig, viewers and members of project roles get the read-only one
+		if strings.HasPrefix(userInfo.Group, rbac.OwnerGroupNamePrefix) || strings.HasPrefix(userInfo.Group, rbac.EditorGroupNamePrefix) {
+			adminClientCfg, err = clusterProvider.GetAdminKubeconfigForCustomerCluster(cluster)
+		} else {
 			filePrefix = "viewer"
 			adminClientCfg, err = clusterProvider.GetViewerKubeconfigForCustomerCluster(cluster)
-		} else {
-			adminClientCfg, err = clusterProvider.GetAdminKubeconfigForCustomerCluster(cluster)
 		}
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
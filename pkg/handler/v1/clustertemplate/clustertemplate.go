@@ -0,0 +1,508 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustertemplate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/v1/cluster"
+	"github.com/kubermatic/kubermatic/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+	"github.com/kubermatic/kubermatic/pkg/util/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// clusterTypes holds a list of supported cluster types
+var clusterTypes = sets.NewString(apiv1.OpenShiftClusterType, apiv1.KubernetesClusterType)
+
+func ListEndpoint(templateProvider provider.ClusterTemplateProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(ListReq)
+		if !ok {
+			return nil, errors.NewBadRequest("invalid request")
+		}
+
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		templates, err := templateProvider.List(project)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		apiTemplates := []apiv1.ClusterTemplate{}
+		for _, template := range templates {
+			apiTemplate, err := convertInternalClusterTemplateToExternal(template)
+			if err != nil {
+				return nil, err
+			}
+			apiTemplates = append(apiTemplates, *apiTemplate)
+		}
+		return apiTemplates, nil
+	}
+}
+
+func GetEndpoint(templateProvider provider.ClusterTemplateProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(GetReq)
+		if !ok {
+			return nil, errors.NewBadRequest("invalid request")
+		}
+
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		template, err := templateProvider.Get(project, req.TemplateID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return convertInternalClusterTemplateToExternal(template)
+	}
+}
+
+func CreateEndpoint(templateProvider provider.ClusterTemplateProvider, privilegedTemplateProvider provider.PrivilegedClusterTemplateProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(CreateReq)
+		if !ok {
+			return nil, errors.NewBadRequest("invalid request")
+		}
+		if err := req.Validate(); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		adminUserInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		template, err := convertExternalClusterTemplateToInternal(&req.Body)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid template: %v", err)
+		}
+
+		if req.Body.Scope == kubermaticv1.ClusterTemplateGlobalScope {
+			if !adminUserInfo.IsAdmin {
+				return nil, errors.New(http.StatusForbidden, fmt.Sprintf("forbidden: \"%s\" doesn't have admin rights", adminUserInfo.Email))
+			}
+			template, err = privilegedTemplateProvider.CreateUnsecured(nil, template)
+		} else if adminUserInfo.IsAdmin {
+			template, err = privilegedTemplateProvider.CreateUnsecured(project, template)
+		} else {
+			var userInfo *provider.UserInfo
+			userInfo, err = userInfoGetter(ctx, project.Name)
+			if err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+			template, err = templateProvider.Create(userInfo, project, template)
+		}
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return convertInternalClusterTemplateToExternal(template)
+	}
+}
+
+func DeleteEndpoint(templateProvider provider.ClusterTemplateProvider, privilegedTemplateProvider provider.PrivilegedClusterTemplateProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(GetReq)
+		if !ok {
+			return nil, errors.NewBadRequest("invalid request")
+		}
+
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		template, err := templateProvider.Get(project, req.TemplateID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		adminUserInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if adminUserInfo.IsAdmin {
+			return nil, common.KubernetesErrorToHTTPError(privilegedTemplateProvider.DeleteUnsecured(template.Name))
+		}
+		if template.IsGlobal() {
+			return nil, errors.New(http.StatusForbidden, fmt.Sprintf("forbidden: \"%s\" doesn't have admin rights", adminUserInfo.Email))
+		}
+		userInfo, err := userInfoGetter(ctx, project.Name)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return nil, common.KubernetesErrorToHTTPError(templateProvider.Delete(userInfo, template.Name))
+	}
+}
+
+func CreateInstancesEndpoint(templateProvider provider.ClusterTemplateProvider, sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter,
+	initNodeDeploymentFailures *prometheus.CounterVec, eventRecorderProvider provider.EventRecorderProvider, credentialManager provider.PresetProvider,
+	exposeStrategy corev1.ServiceType, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(CreateInstancesReq)
+		if !ok {
+			return nil, errors.NewBadRequest("invalid request")
+		}
+		if err := req.Validate(); err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		template, err := templateProvider.Get(project, req.TemplateID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		// the node deployments of the clusters are created in the background, so the quota
+		// must be checked for all instances before the first cluster gets created
+		if err := checkInstancesQuota(ctx, clusterProviderGetter, seedsGetter, privilegedProjectProvider, project, template, len(req.Body.Names)); err != nil {
+			return nil, err
+		}
+
+		// the clusters are created one after another, clusters which were created
+		// before an error occurred are kept
+		clusters := []*apiv1.Cluster{}
+		for _, name := range req.Body.Names {
+			// every cluster gets its own copy of the template because creating a cluster modifies its spec
+			apiTemplate, err := convertInternalClusterTemplateToExternal(template.DeepCopy())
+			if err != nil {
+				return nil, err
+			}
+			nodeDeployments := make([]*apiv1.NodeDeployment, len(apiTemplate.NodeDeployments))
+			for i := range apiTemplate.NodeDeployments {
+				nodeDeployments[i] = &apiTemplate.NodeDeployments[i]
+			}
+
+			createReq := cluster.CreateReq{
+				DCReq: req.DCReq,
+				Body: apiv1.CreateClusterSpec{
+					Cluster: apiTemplate.Cluster,
+				},
+			}
+			createReq.Body.Cluster.Name = name
+
+			newCluster, err := cluster.CreateCluster(ctx, sshKeyProvider, projectProvider, privilegedProjectProvider, seedsGetter, clusterProviderGetter, initNodeDeploymentFailures, eventRecorderProvider,
+				credentialManager, exposeStrategy, userInfoGetter, settingsProvider, updateManager, createReq, nodeDeployments)
+			if err != nil {
+				return nil, err
+			}
+			clusters = append(clusters, newCluster)
+		}
+		return clusters, nil
+	}
+}
+
+func checkInstancesQuota(ctx context.Context, clusterProviderGetter provider.ClusterProviderGetter, seedsGetter provider.SeedsGetter, privilegedProjectProvider provider.PrivilegedProjectProvider, project *kubermaticv1.Project, template *kubermaticv1.ClusterTemplate, instances int) error {
+	if project.Spec.Quota == nil {
+		return nil
+	}
+	apiTemplate, err := convertInternalClusterTemplateToExternal(template.DeepCopy())
+	if err != nil {
+		return err
+	}
+	isBYO, err := common.IsBringYourOwnProvider(apiTemplate.Cluster.Spec.Cloud)
+	if err != nil {
+		return errors.NewBadRequest("invalid template: %v", err)
+	}
+
+	quotaRequest := common.ProjectResourceRequest{}
+	for i := 0; i < instances; i++ {
+		quotaRequest.Clusters++
+		for j := range apiTemplate.NodeDeployments {
+			if isBYO || apiTemplate.NodeDeployments[j].Spec.Replicas <= 0 {
+				continue
+			}
+			if err := quotaRequest.AddNodeDeployment(&apiTemplate.NodeDeployments[j]); err != nil {
+				return errors.NewBadRequest("invalid node deployment: %v", err)
+			}
+		}
+	}
+	return common.KubernetesErrorToHTTPError(common.CheckProjectQuota(ctx, clusterProviderGetter, seedsGetter, privilegedProjectProvider, project, quotaRequest))
+}
+
+// hasCloudCredentials checks if the cloud spec contains credentials. Templates can be read by all
+// members of a project, the credentials must be provided through a preset instead.
+func hasCloudCredentials(cloud kubermaticv1.CloudSpec) bool {
+	switch {
+	case cloud.AWS != nil:
+		return cloud.AWS.AccessKeyID != "" || cloud.AWS.SecretAccessKey != ""
+	case cloud.Azure != nil:
+		return cloud.Azure.TenantID != "" || cloud.Azure.SubscriptionID != "" || cloud.Azure.ClientID != "" || cloud.Azure.ClientSecret != ""
+	case cloud.Digitalocean != nil:
+		return cloud.Digitalocean.Token != ""
+	case cloud.GCP != nil:
+		return cloud.GCP.ServiceAccount != ""
+	case cloud.Hetzner != nil:
+		return cloud.Hetzner.Token != ""
+	case cloud.Openstack != nil:
+		return cloud.Openstack.Username != "" || cloud.Openstack.Password != ""
+	case cloud.Packet != nil:
+		return cloud.Packet.APIKey != ""
+	case cloud.Kubevirt != nil:
+		return cloud.Kubevirt.Kubeconfig != ""
+	case cloud.VSphere != nil:
+		return cloud.VSphere.Username != "" || cloud.VSphere.Password != "" || cloud.VSphere.InfraManagementUser.Password != ""
+	case cloud.Alibaba != nil:
+		return cloud.Alibaba.AccessKeyID != "" || cloud.Alibaba.AccessKeySecret != ""
+	}
+	return false
+}
+
+func convertExternalClusterTemplateToInternal(apiTemplate *apiv1.ClusterTemplate) (*kubermaticv1.ClusterTemplate, error) {
+	template := &kubermaticv1.ClusterTemplate{
+		Spec: kubermaticv1.ClusterTemplateSpec{
+			HumanReadableName: apiTemplate.Name,
+			ClusterType:       apiTemplate.Cluster.Type,
+			Credential:        apiTemplate.Cluster.Credential,
+			ClusterLabels:     apiTemplate.Cluster.Labels,
+			ClusterSpec: kubermaticv1.ClusterSpec{
+				HumanReadableName:                   apiTemplate.Cluster.Name,
+				Cloud:                               apiTemplate.Cluster.Spec.Cloud,
+				MachineNetworks:                     apiTemplate.Cluster.Spec.MachineNetworks,
+				OIDC:                                apiTemplate.Cluster.Spec.OIDC,
+				UpdateWindow:                        apiTemplate.Cluster.Spec.UpdateWindow,
+				Version:                             apiTemplate.Cluster.Spec.Version,
+				UsePodSecurityPolicyAdmissionPlugin: apiTemplate.Cluster.Spec.UsePodSecurityPolicyAdmissionPlugin,
+				UsePodNodeSelectorAdmissionPlugin:   apiTemplate.Cluster.Spec.UsePodNodeSelectorAdmissionPlugin,
+				AuditLogging:                        apiTemplate.Cluster.Spec.AuditLogging,
+				Openshift:                           apiTemplate.Cluster.Spec.Openshift,
+				AdmissionPlugins:                    apiTemplate.Cluster.Spec.AdmissionPlugins,
+				EtcdBackup:                          apiTemplate.Cluster.Spec.EtcdBackup,
+				Hibernation:                         apiTemplate.Cluster.Spec.Hibernation,
+				APIServerAllowedSourceRanges:        apiTemplate.Cluster.Spec.APIServerAllowedSourceRanges,
+			},
+		},
+	}
+
+	for _, nd := range apiTemplate.NodeDeployments {
+		raw, err := json.Marshal(nd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode node deployment %q: %v", nd.Name, err)
+		}
+		template.Spec.NodeDeployments = append(template.Spec.NodeDeployments, runtime.RawExtension{Raw: raw})
+	}
+	return template, nil
+}
+
+func convertInternalClusterTemplateToExternal(template *kubermaticv1.ClusterTemplate) (*apiv1.ClusterTemplate, error) {
+	apiTemplate := &apiv1.ClusterTemplate{
+		ObjectMeta: apiv1.ObjectMeta{
+			ID:                template.Name,
+			Name:              template.Spec.HumanReadableName,
+			CreationTimestamp: apiv1.NewTime(template.CreationTimestamp.Time),
+		},
+		Scope:     template.Labels[kubermaticv1.ClusterTemplateScopeLabelKey],
+		ProjectID: template.Labels[kubermaticv1.ProjectIDLabelKey],
+		Cluster: apiv1.Cluster{
+			ObjectMeta: apiv1.ObjectMeta{
+				Name: template.Spec.ClusterSpec.HumanReadableName,
+			},
+			Labels:     template.Spec.ClusterLabels,
+			Type:       template.Spec.ClusterType,
+			Credential: template.Spec.Credential,
+			Spec: apiv1.ClusterSpec{
+				Cloud:                               template.Spec.ClusterSpec.Cloud,
+				MachineNetworks:                     template.Spec.ClusterSpec.MachineNetworks,
+				Version:                             template.Spec.ClusterSpec.Version,
+				OIDC:                                template.Spec.ClusterSpec.OIDC,
+				UpdateWindow:                        template.Spec.ClusterSpec.UpdateWindow,
+				UsePodSecurityPolicyAdmissionPlugin: template.Spec.ClusterSpec.UsePodSecurityPolicyAdmissionPlugin,
+				UsePodNodeSelectorAdmissionPlugin:   template.Spec.ClusterSpec.UsePodNodeSelectorAdmissionPlugin,
+				AdmissionPlugins:                    template.Spec.ClusterSpec.AdmissionPlugins,
+				AuditLogging:                        template.Spec.ClusterSpec.AuditLogging,
+				EtcdBackup:                          template.Spec.ClusterSpec.EtcdBackup,
+				Hibernation:                         template.Spec.ClusterSpec.Hibernation,
+				APIServerAllowedSourceRanges:        template.Spec.ClusterSpec.APIServerAllowedSourceRanges,
+				Openshift:                           template.Spec.ClusterSpec.Openshift,
+			},
+		},
+	}
+
+	for _, raw := range template.Spec.NodeDeployments {
+		nd := apiv1.NodeDeployment{}
+		if err := json.Unmarshal(raw.Raw, &nd); err != nil {
+			return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to decode node deployment of template %s: %v", template.Name, err))
+		}
+		apiTemplate.NodeDeployments = append(apiTemplate.NodeDeployments, nd)
+	}
+	return apiTemplate, nil
+}
+
+// ListReq defines HTTP request for listClusterTemplates endpoint
+// swagger:parameters listClusterTemplates
+type ListReq struct {
+	common.ProjectReq
+}
+
+func DecodeListReq(c context.Context, r *http.Request) (interface{}, error) {
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	return ListReq{ProjectReq: pr.(common.ProjectReq)}, nil
+}
+
+// GetReq defines HTTP request for getClusterTemplate and deleteClusterTemplate endpoints
+// swagger:parameters getClusterTemplate deleteClusterTemplate
+type GetReq struct {
+	common.ProjectReq
+	// in: path
+	// required: true
+	TemplateID string `json:"template_id"`
+}
+
+func DecodeGetReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req GetReq
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pr.(common.ProjectReq)
+
+	templateID, ok := mux.Vars(r)["template_id"]
+	if !ok || templateID == "" {
+		return nil, fmt.Errorf("'template_id' parameter is required")
+	}
+	req.TemplateID = templateID
+	return req, nil
+}
+
+// CreateReq defines HTTP request for createClusterTemplate endpoint
+// swagger:parameters createClusterTemplate
+type CreateReq struct {
+	common.ProjectReq
+	// in: body
+	Body apiv1.ClusterTemplate
+}
+
+// Validate validates CreateEndpoint request
+func (r CreateReq) Validate() error {
+	if len(r.Body.Name) == 0 {
+		return fmt.Errorf("the template name cannot be empty")
+	}
+	if r.Body.Scope != kubermaticv1.ClusterTemplateProjectScope && r.Body.Scope != kubermaticv1.ClusterTemplateGlobalScope {
+		return fmt.Errorf("invalid scope %q, must be either %q or %q", r.Body.Scope, kubermaticv1.ClusterTemplateProjectScope, kubermaticv1.ClusterTemplateGlobalScope)
+	}
+	if !clusterTypes.Has(r.Body.Cluster.Type) {
+		return fmt.Errorf("invalid cluster type %s", r.Body.Cluster.Type)
+	}
+	if r.Body.Cluster.Spec.Version.Version == nil {
+		return fmt.Errorf("invalid cluster: \"Version\" is required but was not specified")
+	}
+	if hasCloudCredentials(r.Body.Cluster.Spec.Cloud) {
+		return fmt.Errorf("templates must not contain cloud credentials, use a preset instead")
+	}
+	return nil
+}
+
+func DecodeCreateReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req CreateReq
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pr.(common.ProjectReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("unable to parse the input, err = %v", err.Error())
+	}
+	if len(req.Body.Scope) == 0 {
+		req.Body.Scope = kubermaticv1.ClusterTemplateProjectScope
+	}
+	if len(req.Body.Cluster.Type) == 0 {
+		req.Body.Cluster.Type = apiv1.KubernetesClusterType
+	}
+
+	return req, nil
+}
+
+// CreateInstancesReq defines HTTP request for createClusterTemplateInstances endpoint
+// swagger:parameters createClusterTemplateInstances
+type CreateInstancesReq struct {
+	common.DCReq
+	// in: path
+	// required: true
+	TemplateID string `json:"template_id"`
+	// in: body
+	Body apiv1.ClusterTemplateInstances
+}
+
+// Validate validates CreateInstancesEndpoint request
+func (r CreateInstancesReq) Validate() error {
+	if len(r.Body.Names) == 0 {
+		return fmt.Errorf("at least one cluster name is required")
+	}
+	names := sets.NewString()
+	for _, name := range r.Body.Names {
+		if len(name) == 0 {
+			return fmt.Errorf("the cluster names cannot be empty")
+		}
+		if names.Has(name) {
+			return fmt.Errorf("the cluster name %q is not unique", name)
+		}
+		names.Insert(name)
+	}
+	return nil
+}
+
+func DecodeCreateInstancesReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req CreateInstancesReq
+
+	dcr, err := common.DecodeDcReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.DCReq = dcr.(common.DCReq)
+
+	templateID, ok := mux.Vars(r)["template_id"]
+	if !ok || templateID == "" {
+		return nil, fmt.Errorf("'template_id' parameter is required")
+	}
+	req.TemplateID = templateID
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("unable to parse the input, err = %v", err.Error())
+	}
+
+	return req, nil
+}
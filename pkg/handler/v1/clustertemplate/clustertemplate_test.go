@@ -0,0 +1,359 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustertemplate_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/test"
+	"github.com/kubermatic/kubermatic/pkg/handler/test/hack"
+	"github.com/kubermatic/kubermatic/pkg/semver"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCreateClusterTemplateEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		Body                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingAPIUser        *apiv1.User
+		ExistingKubermaticObjs []runtime.Object
+		RewriteTemplateID      bool
+	}{
+		{
+			Name:                   "scenario 1: a project template is created",
+			Body:                   `{"name":"ci","cluster":{"name":"ci-cluster","credential":"fake","spec":{"version":"1.15.0","cloud":{"fake":{},"dc":"fake-dc"}}},"nodeDeployments":[{"name":"workers","spec":{"replicas":1,"template":{"cloud":{},"operatingSystem":{},"versions":{"kubelet":""}}}}]}`,
+			ExpectedResponse:       `{"id":"%s","name":"ci","creationTimestamp":"0001-01-01T00:00:00Z","scope":"project","projectID":"my-first-project-ID","cluster":{"name":"ci-cluster","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","credential":"fake","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{}},"status":{"version":"","url":""}},"nodeDeployments":[{"name":"workers","creationTimestamp":"0001-01-01T00:00:00Z","spec":{"replicas":1,"template":{"cloud":{},"operatingSystem":{},"versions":{"kubelet":""}}},"status":{}}]}`,
+			HTTPStatus:             http.StatusCreated,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+			RewriteTemplateID:      true,
+		},
+		{
+			Name:                   "scenario 2: a template with cloud credentials is rejected",
+			Body:                   `{"name":"ci","cluster":{"name":"ci-cluster","spec":{"version":"1.15.0","cloud":{"aws":{"accessKeyId":"key","secretAccessKey":"secret"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"templates must not contain cloud credentials, use a preset instead"}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+		},
+		{
+			Name:                   "scenario 3: a regular user can not create a global template",
+			Body:                   `{"name":"ci","scope":"global","cluster":{"name":"ci-cluster","credential":"fake","spec":{"version":"1.15.0","cloud":{"fake":{},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			HTTPStatus:             http.StatusForbidden,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+		},
+		{
+			Name:                   "scenario 4: the admin can create a global template",
+			Body:                   `{"name":"ci","scope":"global","cluster":{"name":"ci-cluster","credential":"fake","spec":{"version":"1.15.0","cloud":{"fake":{},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"id":"%s","name":"ci","creationTimestamp":"0001-01-01T00:00:00Z","scope":"global","cluster":{"name":"ci-cluster","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","credential":"fake","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{}},"status":{"version":"","url":""}}}`,
+			HTTPStatus:             http.StatusCreated,
+			ExistingAPIUser:        test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(genUser("John", "john@acme.com", true)),
+			RewriteTemplateID:      true,
+		},
+		{
+			Name:                   "scenario 5: a template with an unknown scope is rejected",
+			Body:                   `{"name":"ci","scope":"seed","cluster":{"name":"ci-cluster","credential":"fake","spec":{"version":"1.15.0","cloud":{"fake":{},"dc":"fake-dc"}}}}`,
+			ExpectedResponse:       `{"error":{"code":400,"message":"invalid scope \"seed\", must be either \"project\" or \"global\""}}`,
+			HTTPStatus:             http.StatusBadRequest,
+			ExistingAPIUser:        test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/projects/%s/clustertemplates", test.GenDefaultProject().Name), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			expectedResponse := tc.ExpectedResponse
+			// since the template ID is automatically generated by the system just rewrite it.
+			if tc.RewriteTemplateID {
+				actualTemplate := &apiv1.ClusterTemplate{}
+				if err := json.Unmarshal(res.Body.Bytes(), actualTemplate); err != nil {
+					t.Fatal(err)
+				}
+				expectedResponse = fmt.Sprintf(tc.ExpectedResponse, actualTemplate.ID)
+			}
+			test.CompareWithResult(t, res, expectedResponse)
+		})
+	}
+}
+
+func TestListClusterTemplatesEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingAPIUser        *apiv1.User
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: the templates of the project and the global templates are listed",
+			ExpectedResponse: `[{"id":"template-project","name":"template-project","creationTimestamp":"0001-01-01T00:00:00Z","scope":"project","projectID":"my-first-project-ID","cluster":{"name":"ci-cluster","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","credential":"fake","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{}},"status":{"version":"","url":""}}},{"id":"template-global","name":"template-global","creationTimestamp":"0001-01-01T00:00:00Z","scope":"global","cluster":{"name":"ci-cluster","creationTimestamp":"0001-01-01T00:00:00Z","type":"kubernetes","credential":"fake","spec":{"cloud":{"dc":"fake-dc","fake":{}},"version":"1.15.0","oidc":{}},"status":{"version":"","url":""}}}]`,
+			HTTPStatus:       http.StatusOK,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterTemplate("template-project", test.GenDefaultProject().Name),
+				genClusterTemplate("template-global", ""),
+				genClusterTemplate("template-other", "other-project-ID"),
+			),
+		},
+		{
+			Name:                   "scenario 2: the user who doesn't belong to the project can not list its templates",
+			ExpectedResponse:       `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:             http.StatusForbidden,
+			ExistingAPIUser:        test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(genUser("John", "john@acme.com", false)),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/projects/%s/clustertemplates", test.GenDefaultProject().Name), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func TestDeleteClusterTemplateEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		TemplateToDelete       string
+		HTTPStatus             int
+		ExistingAPIUser        *apiv1.User
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:             "scenario 1: the owner can delete a template of the project",
+			TemplateToDelete: "template-project",
+			HTTPStatus:       http.StatusOK,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterTemplate("template-project", test.GenDefaultProject().Name),
+			),
+		},
+		{
+			Name:             "scenario 2: a regular user can not delete a global template",
+			TemplateToDelete: "template-global",
+			HTTPStatus:       http.StatusForbidden,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterTemplate("template-global", ""),
+			),
+		},
+		{
+			Name:             "scenario 3: the admin can delete a global template",
+			TemplateToDelete: "template-global",
+			HTTPStatus:       http.StatusOK,
+			ExistingAPIUser:  test.GenAPIUser("John", "john@acme.com"),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genUser("John", "john@acme.com", true),
+				genClusterTemplate("template-global", ""),
+			),
+		},
+		{
+			Name:             "scenario 4: a template of another project can not be deleted",
+			TemplateToDelete: "template-other",
+			HTTPStatus:       http.StatusNotFound,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterTemplate("template-other", "other-project-ID"),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("DELETE", fmt.Sprintf("/api/v1/projects/%s/clustertemplates/%s", test.GenDefaultProject().Name, tc.TemplateToDelete), nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+		})
+	}
+}
+
+func TestCreateClusterTemplateInstancesEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                   string
+		Body                   string
+		ExpectedClusterNames   []string
+		ExpectedResponse       string
+		HTTPStatus             int
+		ExistingAPIUser        *apiv1.User
+		ExistingKubermaticObjs []runtime.Object
+	}{
+		{
+			Name:                 "scenario 1: a cluster is created for every name",
+			Body:                 `{"names":["ci-1","ci-2"]}`,
+			ExpectedClusterNames: []string{"ci-1", "ci-2"},
+			HTTPStatus:           http.StatusCreated,
+			ExistingAPIUser:      test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterTemplate("template-project", test.GenDefaultProject().Name),
+			),
+		},
+		{
+			Name:                 "scenario 2: clusters can be created from a global template",
+			Body:                 `{"names":["ci-1"]}`,
+			ExpectedClusterNames: []string{"ci-1"},
+			HTTPStatus:           http.StatusCreated,
+			ExistingAPIUser:      test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterTemplate("template-global", ""),
+			),
+		},
+		{
+			Name:             "scenario 3: duplicated names are rejected",
+			Body:             `{"names":["ci-1","ci-1"]}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"the cluster name \"ci-1\" is not unique"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingAPIUser:  test.GenDefaultAPIUser(),
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				genClusterTemplate("template-project", test.GenDefaultProject().Name),
+			),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			templateID := tc.ExistingKubermaticObjs[len(tc.ExistingKubermaticObjs)-1].(*kubermaticv1.ClusterTemplate).Name
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clustertemplates/%s/instances", test.GenDefaultProject().Name, templateID), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjs, test.GenDefaultVersions(), nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			if tc.ExpectedResponse != "" {
+				test.CompareWithResult(t, res, tc.ExpectedResponse)
+				return
+			}
+
+			clusters := []apiv1.Cluster{}
+			if err := json.Unmarshal(res.Body.Bytes(), &clusters); err != nil {
+				t.Fatal(err)
+			}
+			if len(clusters) != len(tc.ExpectedClusterNames) {
+				t.Fatalf("expected %d clusters, got %d", len(tc.ExpectedClusterNames), len(clusters))
+			}
+			for i, cluster := range clusters {
+				if cluster.Name != tc.ExpectedClusterNames[i] {
+					t.Errorf("expected cluster %d to be named %q, got %q", i, tc.ExpectedClusterNames[i], cluster.Name)
+				}
+				if cluster.Spec.Cloud.DatacenterName != "fake-dc" {
+					t.Errorf("expected cluster %q to be created in datacenter fake-dc, got %q", cluster.Name, cluster.Spec.Cloud.DatacenterName)
+				}
+			}
+		})
+	}
+}
+
+func genUser(name, email string, isAdmin bool) *kubermaticv1.User {
+	user := test.GenUser("", name, email)
+	user.Spec.IsAdmin = isAdmin
+	return user
+}
+
+// genClusterTemplate generates a template which belongs to the given project, templates without a project are global
+func genClusterTemplate(name, projectID string) *kubermaticv1.ClusterTemplate {
+	template := &kubermaticv1.ClusterTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				kubermaticv1.ClusterTemplateScopeLabelKey: kubermaticv1.ClusterTemplateGlobalScope,
+			},
+		},
+		Spec: kubermaticv1.ClusterTemplateSpec{
+			HumanReadableName: name,
+			ClusterType:       apiv1.KubernetesClusterType,
+			Credential:        "fake",
+			ClusterSpec: kubermaticv1.ClusterSpec{
+				HumanReadableName: "ci-cluster",
+				Version:           *semver.NewSemverOrDie("1.15.0"),
+				Cloud: kubermaticv1.CloudSpec{
+					DatacenterName: "fake-dc",
+					Fake:           &kubermaticv1.FakeCloudSpec{},
+				},
+			},
+		},
+	}
+	if projectID != "" {
+		template.Labels[kubermaticv1.ClusterTemplateScopeLabelKey] = kubermaticv1.ClusterTemplateProjectScope
+		template.Labels[kubermaticv1.ProjectIDLabelKey] = projectID
+		template.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+				Kind:       kubermaticv1.ProjectKindName,
+				UID:        "",
+				Name:       projectID,
+			},
+		}
+	}
+	return template
+}
@@ -249,6 +249,35 @@ func ForwardPort(log *zap.SugaredLogger, forwarder *portforward.PortForwarder) e
 	return nil
 }
 
+// VerifyGroupAssignment checks that the user is allowed to put members or service accounts of the
+// project into or out of the given groups. Besides admins, only owners can assign any group. Members
+// of a project role which allows to manage members can only assign their own group, as they could
+// otherwise grant more rights than they have, e.g. by making themselves owners.
+func VerifyGroupAssignment(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID string, groupPrefixes ...string) error {
+	adminUserInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return err
+	}
+	if adminUserInfo.IsAdmin {
+		return nil
+	}
+
+	userInfo, err := userInfoGetter(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	userGroupPrefix := rbac.ExtractGroupPrefix(userInfo.Group)
+	if userGroupPrefix == rbac.OwnerGroupNamePrefix {
+		return nil
+	}
+	for _, groupPrefix := range groupPrefixes {
+		if groupPrefix != userGroupPrefix {
+			return kubermaticerrors.New(http.StatusForbidden, fmt.Sprintf("forbidden: only owners can assign the group %s in the project %s", groupPrefix, projectID))
+		}
+	}
+	return nil
+}
+
 func GetOwnersForProject(userInfo *provider.UserInfo, project *kubermaticv1.Project, memberProvider provider.ProjectMemberProvider, userProvider provider.UserProvider) ([]apiv1.User, error) {
 	allProjectMembers, err := memberProvider.List(userInfo, project, &provider.ProjectMemberListOptions{SkipPrivilegeVerification: true})
 	if err != nil {
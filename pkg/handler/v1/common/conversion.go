@@ -17,11 +17,14 @@ limitations under the License.
 package common
 
 import (
+	"fmt"
+
 	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
 	kubermaticapiv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/handler/v1/label"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func ConvertInternalSSHKeysToExternal(internalKeys []*kubermaticapiv1.UserSSHKey) []*apiv1.SSHKey {
@@ -90,5 +93,89 @@ func ConvertInternalProjectToExternal(kubermaticProject *kubermaticapiv1.Project
 		Status:         kubermaticProject.Status.Phase,
 		Owners:         projectOwners,
 		ClustersNumber: clustersNumber,
+		Quota:          convertInternalProjectQuotaToExternal(kubermaticProject.Spec.Quota),
+		Usage:          convertInternalProjectUsageToExternal(kubermaticProject.Status.Usage),
+		OIDCGroups:     convertInternalProjectOIDCGroupsToExternal(kubermaticProject.Spec.OIDCGroups),
+	}
+}
+
+func convertInternalProjectOIDCGroupsToExternal(groups []kubermaticapiv1.ProjectOIDCGroup) []apiv1.ProjectOIDCGroup {
+	var result []apiv1.ProjectOIDCGroup
+	for _, group := range groups {
+		result = append(result, apiv1.ProjectOIDCGroup{Name: group.Name, Role: group.Role})
+	}
+	return result
+}
+
+// ConvertExternalProjectOIDCGroupsToInternal converts the OIDC groups of a project, an empty list removes all groups
+func ConvertExternalProjectOIDCGroupsToInternal(groups []apiv1.ProjectOIDCGroup) []kubermaticapiv1.ProjectOIDCGroup {
+	var result []kubermaticapiv1.ProjectOIDCGroup
+	for _, group := range groups {
+		result = append(result, kubermaticapiv1.ProjectOIDCGroup{Name: group.Name, Role: group.Role})
+	}
+	return result
+}
+
+func convertInternalProjectQuotaToExternal(quota *kubermaticapiv1.ProjectQuota) *apiv1.ProjectQuota {
+	if quota == nil {
+		return nil
+	}
+	result := &apiv1.ProjectQuota{
+		Clusters: quota.Clusters,
+		Nodes:    quota.Nodes,
+	}
+	if quota.CPU != nil {
+		result.CPU = quota.CPU.String()
+	}
+	if quota.Memory != nil {
+		result.Memory = quota.Memory.String()
+	}
+	return result
+}
+
+func convertInternalProjectUsageToExternal(usage *kubermaticapiv1.ProjectResourceUsage) *apiv1.ProjectResourceUsage {
+	if usage == nil {
+		return nil
+	}
+	return &apiv1.ProjectResourceUsage{
+		Clusters:         usage.Clusters,
+		Nodes:            usage.Nodes,
+		CPU:              usage.CPU.String(),
+		Memory:           usage.Memory.String(),
+		UnknownSizeNodes: usage.UnknownSizeNodes,
+		LastUpdated:      apiv1.NewTime(usage.LastUpdated.Time),
+	}
+}
+
+// ConvertExternalProjectQuotaToInternal converts the quota of the API to the quota of a project,
+// an empty quota results in no quota at all
+func ConvertExternalProjectQuotaToInternal(quota *apiv1.ProjectQuota) (*kubermaticapiv1.ProjectQuota, error) {
+	if quota == nil || *quota == (apiv1.ProjectQuota{}) {
+		return nil, nil
+	}
+	result := &kubermaticapiv1.ProjectQuota{
+		Clusters: quota.Clusters,
+		Nodes:    quota.Nodes,
+	}
+	if quota.Clusters != nil && *quota.Clusters < 0 {
+		return nil, fmt.Errorf("the clusters quota must not be negative")
+	}
+	if quota.Nodes != nil && *quota.Nodes < 0 {
+		return nil, fmt.Errorf("the nodes quota must not be negative")
+	}
+	if quota.CPU != "" {
+		cpu, err := resource.ParseQuantity(quota.CPU)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cpu quota %q: %v", quota.CPU, err)
+		}
+		result.CPU = &cpu
+	}
+	if quota.Memory != "" {
+		memory, err := resource.ParseQuantity(quota.Memory)
+		if err != nil {
+			return nil, fmt.Errorf("invalid memory quota %q: %v", quota.Memory, err)
+		}
+		result.Memory = &memory
 	}
+	return result, nil
 }
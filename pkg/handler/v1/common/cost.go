@@ -0,0 +1,209 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	machineconversions "github.com/kubermatic/kubermatic/pkg/machine"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HoursPerMonth is the average number of hours of a month, it is used to estimate monthly costs
+const HoursPerMonth = 730
+
+// NodeSize returns the name of the machine size of a node with the given spec. It is empty
+// for providers whose nodes are not sized by name, e.g. vSphere.
+func NodeSize(spec apiv1.NodeCloudSpec) string {
+	switch {
+	case spec.AWS != nil:
+		return spec.AWS.InstanceType
+	case spec.GCP != nil:
+		return spec.GCP.MachineType
+	case spec.Azure != nil:
+		return spec.Azure.Size
+	case spec.Digitalocean != nil:
+		return spec.Digitalocean.Size
+	case spec.Hetzner != nil:
+		return spec.Hetzner.Type
+	case spec.Packet != nil:
+		return spec.Packet.InstanceType
+	case spec.Alibaba != nil:
+		return spec.Alibaba.InstanceType
+	case spec.Openstack != nil:
+		return spec.Openstack.Flavor
+	}
+	return ""
+}
+
+// GetNodeDeploymentCost estimates the cost of the nodes of a node deployment in the given datacenter
+func GetNodeDeploymentCost(catalog *kubermaticv1.PricingCatalogSpec, datacenter, name string, spec apiv1.NodeCloudSpec, replicas int32) apiv1.NodeDeploymentCost {
+	cost := apiv1.NodeDeploymentCost{
+		Name:       name,
+		Provider:   nodeCloudProviderName(spec),
+		Datacenter: datacenter,
+		Size:       NodeSize(spec),
+		Replicas:   replicas,
+	}
+	if cost.Size == "" {
+		return cost
+	}
+	hourlyCost, found := catalog.HourlyCost(cost.Provider, datacenter, cost.Size)
+	if !found {
+		return cost
+	}
+	cost.Priced = true
+	cost.HourlyCostPerNode = hourlyCost
+	cost.HourlyCost = roundCost(hourlyCost * float64(replicas))
+	cost.MonthlyCost = roundCost(hourlyCost * float64(replicas) * HoursPerMonth)
+	return cost
+}
+
+// EstimateClusterCost estimates the cost of a cluster which is going to be created with the given node deployments
+func EstimateClusterCost(catalog *kubermaticv1.PricingCatalogSpec, datacenter string, nodeDeployments []apiv1.NodeDeployment) *apiv1.ClusterCost {
+	cost := newClusterCost(catalog)
+	for _, nd := range nodeDeployments {
+		cost.addNodeDeployment(GetNodeDeploymentCost(catalog, datacenter, nd.Name, nd.Spec.Template.Cloud, nd.Spec.Replicas))
+	}
+	return cost.ClusterCost
+}
+
+// GetClusterCost estimates the cost of the machine deployments of the given cluster, the client must
+// point to the user cluster
+func GetClusterCost(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster, catalog *kubermaticv1.PricingCatalogSpec) (*apiv1.ClusterCost, error) {
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	if err := client.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return nil, err
+	}
+
+	cost := newClusterCost(catalog)
+	cost.ClusterID = cluster.Name
+	cost.ClusterName = cluster.Spec.HumanReadableName
+	for _, md := range machineDeployments.Items {
+		var replicas int32
+		if md.Spec.Replicas != nil {
+			replicas = *md.Spec.Replicas
+		}
+		cloudSpec, err := machineconversions.GetAPIV2NodeCloudSpec(md.Spec.Template.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the node cloud spec of machine deployment %s: %v", md.Name, err)
+		}
+		cost.addNodeDeployment(GetNodeDeploymentCost(catalog, cluster.Spec.Cloud.DatacenterName, md.Name, *cloudSpec, replicas))
+	}
+	return cost.ClusterCost, nil
+}
+
+// GetProjectCost estimates the cost of all clusters of the project in all seeds. The nodes of hibernated
+// clusters don't cost anything, the ones of clusters without a running API server can't be determined.
+// Seeds and clusters which can't be reached don't fail the estimate, it is marked as incomplete instead.
+func GetProjectCost(ctx context.Context, clusterProviderGetter provider.ClusterProviderGetter, seedsGetter provider.SeedsGetter, project *kubermaticv1.Project, catalog *kubermaticv1.PricingCatalogSpec) (*apiv1.ProjectCost, error) {
+	seeds, err := seedsGetter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list seeds: %v", err)
+	}
+
+	projectCost := &apiv1.ProjectCost{
+		Currency: catalog.Currency,
+		Clusters: []apiv1.ClusterCost{},
+	}
+	for _, seed := range seeds {
+		clusterProvider, err := clusterProviderGetter(seed)
+		if err != nil {
+			projectCost.Incomplete = true
+			continue
+		}
+		clusters, err := clusterProvider.List(project, nil)
+		if err != nil {
+			projectCost.Incomplete = true
+			continue
+		}
+
+		for i := range clusters.Items {
+			clusterCost := getProjectClusterCost(ctx, clusterProvider, &clusters.Items[i], catalog)
+			projectCost.HourlyCost += clusterCost.HourlyCost
+			projectCost.Incomplete = projectCost.Incomplete || clusterCost.Incomplete
+			projectCost.Clusters = append(projectCost.Clusters, *clusterCost)
+		}
+	}
+
+	sort.Slice(projectCost.Clusters, func(i, j int) bool {
+		return projectCost.Clusters[i].ClusterID < projectCost.Clusters[j].ClusterID
+	})
+	projectCost.HourlyCost = roundCost(projectCost.HourlyCost)
+	projectCost.MonthlyCost = roundCost(projectCost.HourlyCost * HoursPerMonth)
+	return projectCost, nil
+}
+
+func getProjectClusterCost(ctx context.Context, clusterProvider provider.ClusterProvider, cluster *kubermaticv1.Cluster, catalog *kubermaticv1.PricingCatalogSpec) *apiv1.ClusterCost {
+	emptyCost := func(incomplete bool) *apiv1.ClusterCost {
+		cost := newClusterCost(catalog)
+		cost.ClusterID = cluster.Name
+		cost.ClusterName = cluster.Spec.HumanReadableName
+		cost.Incomplete = incomplete
+		return cost.ClusterCost
+	}
+
+	// The nodes of hibernated clusters are scaled to zero, the ones of clusters without a running
+	// API server can't be listed
+	if cluster.Status.Hibernation != nil || cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+		return emptyCost(cluster.Status.Hibernation == nil)
+	}
+
+	client, err := clusterProvider.GetAdminClientForCustomerCluster(cluster)
+	if err != nil {
+		return emptyCost(true)
+	}
+	cost, err := GetClusterCost(ctx, client, cluster, catalog)
+	if err != nil {
+		return emptyCost(true)
+	}
+	return cost
+}
+
+type clusterCostBuilder struct {
+	*apiv1.ClusterCost
+}
+
+func newClusterCost(catalog *kubermaticv1.PricingCatalogSpec) clusterCostBuilder {
+	return clusterCostBuilder{&apiv1.ClusterCost{
+		Currency:        catalog.Currency,
+		NodeDeployments: []apiv1.NodeDeploymentCost{},
+	}}
+}
+
+func (b clusterCostBuilder) addNodeDeployment(cost apiv1.NodeDeploymentCost) {
+	b.NodeDeployments = append(b.NodeDeployments, cost)
+	if !cost.Priced && cost.Replicas > 0 {
+		b.Incomplete = true
+	}
+	b.HourlyCost = roundCost(b.HourlyCost + cost.HourlyCost)
+	b.MonthlyCost = roundCost(b.HourlyCost * HoursPerMonth)
+}
+
+// roundCost gets rid of the floating point noise of summed up prices
+func roundCost(cost float64) float64 {
+	return math.Round(cost*10000) / 10000
+}
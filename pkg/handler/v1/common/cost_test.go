@@ -0,0 +1,203 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/test"
+	"github.com/kubermatic/kubermatic/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEstimateClusterCost(t *testing.T) {
+	t.Parallel()
+	catalog := &kubermaticv1.PricingCatalogSpec{
+		Currency: "USD",
+		Prices: []kubermaticv1.MachinePrice{
+			{Provider: "aws", Size: "t3.medium", HourlyCost: 0.0416},
+			{Provider: "aws", Datacenter: "aws-eu-central-1a", Size: "t3.medium", HourlyCost: 0.048},
+			{Provider: "digitalocean", Size: "s-2vcpu-4gb", HourlyCost: 0.03},
+		},
+	}
+
+	testcases := []struct {
+		Name                string
+		Datacenter          string
+		NodeDeployments     []v1.NodeDeployment
+		ExpectedHourlyCost  float64
+		ExpectedMonthlyCost float64
+		ExpectedIncomplete  bool
+	}{
+		{
+			Name:                "scenario 1, the price of the provider is used for datacenters without a price of their own",
+			Datacenter:          "aws-us-east-1a",
+			NodeDeployments:     []v1.NodeDeployment{genNodeDeployment(3, v1.NodeCloudSpec{AWS: &v1.AWSNodeSpec{InstanceType: "t3.medium"}})},
+			ExpectedHourlyCost:  0.1248,
+			ExpectedMonthlyCost: 91.104,
+		},
+		{
+			Name:                "scenario 2, the price of the datacenter takes precedence",
+			Datacenter:          "aws-eu-central-1a",
+			NodeDeployments:     []v1.NodeDeployment{genNodeDeployment(3, v1.NodeCloudSpec{AWS: &v1.AWSNodeSpec{InstanceType: "t3.medium"}})},
+			ExpectedHourlyCost:  0.144,
+			ExpectedMonthlyCost: 105.12,
+		},
+		{
+			Name:       "scenario 3, the costs of all node deployments are summed up",
+			Datacenter: "do-fra1",
+			NodeDeployments: []v1.NodeDeployment{
+				genNodeDeployment(1, v1.NodeCloudSpec{Digitalocean: &v1.DigitaloceanNodeSpec{Size: "s-2vcpu-4gb"}}),
+				genNodeDeployment(2, v1.NodeCloudSpec{Digitalocean: &v1.DigitaloceanNodeSpec{Size: "s-2vcpu-4gb"}}),
+			},
+			ExpectedHourlyCost:  0.09,
+			ExpectedMonthlyCost: 65.7,
+		},
+		{
+			Name:       "scenario 4, nodes without a price make the estimate incomplete",
+			Datacenter: "do-fra1",
+			NodeDeployments: []v1.NodeDeployment{
+				genNodeDeployment(1, v1.NodeCloudSpec{Digitalocean: &v1.DigitaloceanNodeSpec{Size: "s-2vcpu-4gb"}}),
+				genNodeDeployment(2, v1.NodeCloudSpec{Digitalocean: &v1.DigitaloceanNodeSpec{Size: "s-8vcpu-16gb"}}),
+			},
+			ExpectedHourlyCost:  0.03,
+			ExpectedMonthlyCost: 21.9,
+			ExpectedIncomplete:  true,
+		},
+		{
+			Name:               "scenario 5, vsphere nodes have no named size",
+			Datacenter:         "vsphere-hamburg",
+			NodeDeployments:    []v1.NodeDeployment{genNodeDeployment(2, v1.NodeCloudSpec{VSphere: &v1.VSphereNodeSpec{CPUs: 2, Memory: 4096}})},
+			ExpectedIncomplete: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			cost := common.EstimateClusterCost(catalog, tc.Datacenter, tc.NodeDeployments)
+
+			if cost.Currency != "USD" {
+				t.Errorf("expected currency USD, got %q", cost.Currency)
+			}
+			if cost.HourlyCost != tc.ExpectedHourlyCost {
+				t.Errorf("expected hourly cost %v, got %v", tc.ExpectedHourlyCost, cost.HourlyCost)
+			}
+			if cost.MonthlyCost != tc.ExpectedMonthlyCost {
+				t.Errorf("expected monthly cost %v, got %v", tc.ExpectedMonthlyCost, cost.MonthlyCost)
+			}
+			if cost.Incomplete != tc.ExpectedIncomplete {
+				t.Errorf("expected incomplete to be %t, got %t", tc.ExpectedIncomplete, cost.Incomplete)
+			}
+			if len(cost.NodeDeployments) != len(tc.NodeDeployments) {
+				t.Errorf("expected the costs of %d node deployments, got %d", len(tc.NodeDeployments), len(cost.NodeDeployments))
+			}
+		})
+	}
+}
+
+// fakeCostClusterProvider serves the clusters of a seed, the user clusters are only reachable when they have a client
+type fakeCostClusterProvider struct {
+	provider.ClusterProvider
+	clusters []kubermaticv1.Cluster
+	clients  map[string]ctrlruntimeclient.Client
+	listErr  error
+}
+
+func (p *fakeCostClusterProvider) List(*kubermaticv1.Project, *provider.ClusterListOptions) (*kubermaticv1.ClusterList, error) {
+	if p.listErr != nil {
+		return nil, p.listErr
+	}
+	return &kubermaticv1.ClusterList{Items: p.clusters}, nil
+}
+
+func (p *fakeCostClusterProvider) GetAdminClientForCustomerCluster(cluster *kubermaticv1.Cluster) (ctrlruntimeclient.Client, error) {
+	client, ok := p.clients[cluster.Name]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	return client, nil
+}
+
+func TestGetProjectCost(t *testing.T) {
+	t.Parallel()
+	catalog := &kubermaticv1.PricingCatalogSpec{
+		Currency: "USD",
+		Prices: []kubermaticv1.MachinePrice{
+			{Provider: "aws", Size: "t2.micro", HourlyCost: 0.0116},
+		},
+	}
+	genCluster := func(name string) kubermaticv1.Cluster {
+		cluster := kubermaticv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kubermaticv1.ClusterSpec{
+				HumanReadableName: name,
+				Cloud:             kubermaticv1.CloudSpec{DatacenterName: "regular-do1"},
+			},
+		}
+		cluster.Status.ExtendedHealth.Apiserver = kubermaticv1.HealthStatusUp
+		return cluster
+	}
+	machineDeployment := test.GenTestMachineDeployment("md-1", `{"cloudProvider":"aws","cloudProviderSpec":{"instanceType":"t2.micro"},"operatingSystem":"ubuntu","operatingSystemSpec":{}}`, nil, false)
+
+	clusterProviders := map[string]provider.ClusterProvider{
+		"us-central1": &fakeCostClusterProvider{
+			clusters: []kubermaticv1.Cluster{genCluster("reachable"), genCluster("unreachable")},
+			clients: map[string]ctrlruntimeclient.Client{
+				"reachable": fakectrlruntimeclient.NewFakeClientWithScheme(scheme.Scheme, machineDeployment),
+			},
+		},
+		"europe-west3": &fakeCostClusterProvider{listErr: errors.New("seed is down")},
+	}
+	seedsGetter := func() (map[string]*kubermaticv1.Seed, error) {
+		return map[string]*kubermaticv1.Seed{
+			"us-central1":  {ObjectMeta: metav1.ObjectMeta{Name: "us-central1"}},
+			"europe-west3": {ObjectMeta: metav1.ObjectMeta{Name: "europe-west3"}},
+		}, nil
+	}
+	clusterProviderGetter := func(seed *kubermaticv1.Seed) (provider.ClusterProvider, error) {
+		return clusterProviders[seed.Name], nil
+	}
+
+	cost, err := common.GetProjectCost(context.Background(), clusterProviderGetter, seedsGetter, &kubermaticv1.Project{}, catalog)
+	if err != nil {
+		t.Fatalf("expected unreachable seeds and clusters to not fail the estimate, got %v", err)
+	}
+	if !cost.Incomplete {
+		t.Error("expected the cost of the project to be incomplete")
+	}
+	if cost.HourlyCost != 0.0116 {
+		t.Errorf("expected hourly cost 0.0116, got %v", cost.HourlyCost)
+	}
+	if len(cost.Clusters) != 2 {
+		t.Fatalf("expected the costs of 2 clusters, got %d", len(cost.Clusters))
+	}
+	if reachable := cost.Clusters[0]; reachable.ClusterID != "reachable" || reachable.Incomplete || len(reachable.NodeDeployments) != 1 {
+		t.Errorf("expected the complete cost of the reachable cluster, got %+v", reachable)
+	}
+	if unreachable := cost.Clusters[1]; unreachable.ClusterID != "unreachable" || !unreachable.Incomplete || unreachable.HourlyCost != 0 {
+		t.Errorf("expected the cost of the unreachable cluster to be marked incomplete, got %+v", unreachable)
+	}
+}
@@ -0,0 +1,374 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	ec2 "github.com/cristim/ec2-instances-info"
+	"go.uber.org/zap"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	kubermaticlog "github.com/kubermatic/kubermatic/pkg/log"
+	machineconversions "github.com/kubermatic/kubermatic/pkg/machine"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+	machineresource "github.com/kubermatic/kubermatic/pkg/resources/machine"
+	kubermaticerrors "github.com/kubermatic/kubermatic/pkg/util/errors"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	awsInstanceTypesOnce sync.Once
+	awsInstanceTypes     map[string]awsInstanceType
+)
+
+type awsInstanceType struct {
+	vcpus int
+	// memory is given in GiB
+	memory float32
+}
+
+// ProjectResourceRequest holds the resources an operation is going to add to a project
+type ProjectResourceRequest struct {
+	Clusters int64
+	Nodes    int64
+	CPU      resource.Quantity
+	Memory   resource.Quantity
+	// UnknownSizeNodes is the number of requested nodes whose size could not be determined,
+	// these nodes are not part of the requested CPU and memory
+	UnknownSizeNodes int64
+	// UnknownSizes holds the providers of the requested nodes whose size could not be determined
+	UnknownSizes []string
+	// ResumedCluster is the name of a hibernated cluster whose nodes are requested again. They are
+	// left out of the usage of the project while the request is checked.
+	ResumedCluster string
+}
+
+// ResumeCluster adds the nodes the given cluster had before it got hibernated to the request. Their size
+// is not known while the cluster is hibernated, so they are only checked against the node quota.
+func (r *ProjectResourceRequest) ResumeCluster(cluster *kubermaticv1.Cluster) {
+	r.ResumedCluster = cluster.Name
+	r.Nodes += hibernatedNodes(cluster)
+}
+
+func hibernatedNodes(cluster *kubermaticv1.Cluster) int64 {
+	var nodes int64
+	if cluster.Status.Hibernation != nil {
+		for _, replicas := range cluster.Status.Hibernation.MachineDeployments {
+			nodes += int64(replicas)
+		}
+	}
+	return nodes
+}
+
+// AddNodeDeployment adds the nodes of the given node deployment to the request. Node deployments
+// scaled by the cluster-autoscaler request their upper bound.
+func (r *ProjectResourceRequest) AddNodeDeployment(nd *apiv1.NodeDeployment) error {
+	return r.addNodes(nd.Spec.Template.Cloud, nodeDeploymentReplicas(nd))
+}
+
+// RemoveNodeDeployment removes the nodes of the given node deployment from the request,
+// it is used to only account for the difference when a node deployment gets changed
+func (r *ProjectResourceRequest) RemoveNodeDeployment(nd *apiv1.NodeDeployment) error {
+	return r.addNodes(nd.Spec.Template.Cloud, -nodeDeploymentReplicas(nd))
+}
+
+func nodeDeploymentReplicas(nd *apiv1.NodeDeployment) int64 {
+	if machineresource.HasAutoscalingBounds(&nd.Spec) && *nd.Spec.MaxReplicas > nd.Spec.Replicas {
+		return int64(*nd.Spec.MaxReplicas)
+	}
+	return int64(nd.Spec.Replicas)
+}
+
+func (r *ProjectResourceRequest) addNodes(spec apiv1.NodeCloudSpec, replicas int64) error {
+	r.Nodes += replicas
+
+	cpu, memory, ok, err := NodeResources(spec)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		r.UnknownSizeNodes += replicas
+		if replicas > 0 {
+			r.UnknownSizes = append(r.UnknownSizes, nodeCloudProviderName(spec))
+		}
+		return nil
+	}
+	r.CPU.Add(multiplyQuantity(cpu, replicas))
+	r.Memory.Add(multiplyQuantity(memory, replicas))
+	return nil
+}
+
+// NodeResources returns the CPU and memory of a single node with the given spec. Only the sizes
+// which are known without asking the cloud provider can be determined, for all the others ok is false.
+func NodeResources(spec apiv1.NodeCloudSpec) (cpu, memory resource.Quantity, ok bool, err error) {
+	switch {
+	case spec.VSphere != nil:
+		return *resource.NewQuantity(int64(spec.VSphere.CPUs), resource.DecimalSI),
+			*resource.NewQuantity(int64(spec.VSphere.Memory)*1024*1024, resource.BinarySI), true, nil
+	case spec.Kubevirt != nil:
+		cpu, err = resource.ParseQuantity(spec.Kubevirt.CPUs)
+		if err != nil {
+			return cpu, memory, false, fmt.Errorf("invalid kubevirt cpus %q: %v", spec.Kubevirt.CPUs, err)
+		}
+		memory, err = resource.ParseQuantity(spec.Kubevirt.Memory)
+		if err != nil {
+			return cpu, memory, false, fmt.Errorf("invalid kubevirt memory %q: %v", spec.Kubevirt.Memory, err)
+		}
+		return cpu, memory, true, nil
+	case spec.AWS != nil:
+		instanceType, found := getAWSInstanceTypes()[spec.AWS.InstanceType]
+		if !found {
+			return cpu, memory, false, nil
+		}
+		return *resource.NewQuantity(int64(instanceType.vcpus), resource.DecimalSI),
+			*resource.NewQuantity(int64(instanceType.memory*1024)*1024*1024, resource.BinarySI), true, nil
+	}
+	return cpu, memory, false, nil
+}
+
+func getAWSInstanceTypes() map[string]awsInstanceType {
+	awsInstanceTypesOnce.Do(func() {
+		awsInstanceTypes = map[string]awsInstanceType{}
+		data, err := ec2.Data()
+		if err != nil || data == nil {
+			return
+		}
+		for _, instanceType := range *data {
+			awsInstanceTypes[instanceType.InstanceType] = awsInstanceType{vcpus: instanceType.VCPU, memory: instanceType.Memory}
+		}
+	})
+	return awsInstanceTypes
+}
+
+func nodeCloudProviderName(spec apiv1.NodeCloudSpec) string {
+	switch {
+	case spec.Digitalocean != nil:
+		return "digitalocean"
+	case spec.AWS != nil:
+		return "aws"
+	case spec.Azure != nil:
+		return "azure"
+	case spec.Openstack != nil:
+		return "openstack"
+	case spec.Packet != nil:
+		return "packet"
+	case spec.Hetzner != nil:
+		return "hetzner"
+	case spec.VSphere != nil:
+		return "vsphere"
+	case spec.GCP != nil:
+		return "gcp"
+	case spec.Kubevirt != nil:
+		return "kubevirt"
+	case spec.Alibaba != nil:
+		return "alibaba"
+	}
+	return "unknown"
+}
+
+func multiplyQuantity(q resource.Quantity, factor int64) resource.Quantity {
+	result := resource.NewMilliQuantity(q.MilliValue()*factor, q.Format)
+	return *result
+}
+
+// CheckProjectQuota makes sure that the project stays within its quota after the requested resources were added.
+// The usage which was observed during the check gets stored in the status of the project. Projects without a
+// quota are not checked at all. As the CPU and memory quota can't be enforced for nodes whose size can't be
+// determined, requesting more of these nodes is rejected for projects with a CPU or memory quota.
+func CheckProjectQuota(ctx context.Context, clusterProviderGetter provider.ClusterProviderGetter, seedsGetter provider.SeedsGetter, privilegedProjectProvider provider.PrivilegedProjectProvider, project *kubermaticv1.Project, request ProjectResourceRequest) error {
+	quota := project.Spec.Quota
+	if quota == nil {
+		return nil
+	}
+
+	if request.UnknownSizeNodes > 0 && (quota.CPU != nil || quota.Memory != nil) {
+		return kubermaticerrors.New(http.StatusForbidden, fmt.Sprintf("the size of %s nodes can not be determined, so they can not be created in a project with a cpu or memory quota", strings.Join(request.UnknownSizes, ", ")))
+	}
+
+	usage, err := getProjectResourceUsage(ctx, clusterProviderGetter, seedsGetter, project, request.ResumedCluster)
+	if err != nil {
+		return fmt.Errorf("failed to get the resource usage of the project: %v", err)
+	}
+	if request.ResumedCluster == "" {
+		if err := updateProjectResourceUsage(privilegedProjectProvider, project.Name, usage); err != nil {
+			return fmt.Errorf("failed to update the resource usage of the project: %v", err)
+		}
+	}
+
+	var exceeded []string
+	if request.Clusters > 0 && quota.Clusters != nil && usage.Clusters+request.Clusters > *quota.Clusters {
+		exceeded = append(exceeded, fmt.Sprintf("clusters (used %d, requested %d, limit %d)", usage.Clusters, request.Clusters, *quota.Clusters))
+	}
+	if request.Nodes > 0 && quota.Nodes != nil && usage.Nodes+request.Nodes > *quota.Nodes {
+		exceeded = append(exceeded, fmt.Sprintf("nodes (used %d, requested %d, limit %d)", usage.Nodes, request.Nodes, *quota.Nodes))
+	}
+	if exceedsQuantity(usage.CPU, request.CPU, quota.CPU) {
+		exceeded = append(exceeded, fmt.Sprintf("cpu (used %s, requested %s, limit %s)", usage.CPU.String(), request.CPU.String(), quota.CPU.String()))
+	}
+	if exceedsQuantity(usage.Memory, request.Memory, quota.Memory) {
+		exceeded = append(exceeded, fmt.Sprintf("memory (used %s, requested %s, limit %s)", usage.Memory.String(), request.Memory.String(), quota.Memory.String()))
+	}
+
+	if len(exceeded) > 0 {
+		return kubermaticerrors.New(http.StatusForbidden, fmt.Sprintf("project quota exceeded: %s", strings.Join(exceeded, ", ")))
+	}
+	return nil
+}
+
+func exceedsQuantity(used, requested resource.Quantity, limit *resource.Quantity) bool {
+	if limit == nil || requested.Sign() <= 0 {
+		return false
+	}
+	total := used.DeepCopy()
+	total.Add(requested)
+	return total.Cmp(*limit) > 0
+}
+
+// updateProjectResourceUsage stores the usage in the status of the project if it changed. Projects
+// get updated concurrently, e.g. by the checks of other requests, so conflicts are retried.
+func updateProjectResourceUsage(privilegedProjectProvider provider.PrivilegedProjectProvider, projectName string, usage *kubermaticv1.ProjectResourceUsage) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		project, err := privilegedProjectProvider.GetUnsecured(projectName, nil)
+		if err != nil {
+			return err
+		}
+		if equalProjectResourceUsage(project.Status.Usage, usage) {
+			return nil
+		}
+		project.Status.Usage = usage
+		_, err = privilegedProjectProvider.UpdateUnsecured(project)
+		return err
+	})
+}
+
+// equalProjectResourceUsage compares the usages without the time they got observed
+func equalProjectResourceUsage(a, b *kubermaticv1.ProjectResourceUsage) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Clusters == b.Clusters &&
+		a.Nodes == b.Nodes &&
+		a.UnknownSizeNodes == b.UnknownSizeNodes &&
+		a.CPU.Cmp(b.CPU) == 0 &&
+		a.Memory.Cmp(b.Memory) == 0
+}
+
+// GetProjectResourceUsage calculates the resources used by all clusters of the project in all seeds.
+// Nodes are only counted for clusters with a running API server, the CPU and memory only for nodes with a known size.
+// Nodes whose size is unknown are counted separately. Hibernated clusters keep the nodes they had before they
+// got hibernated, as they get them back once they are resumed. Their size is not known while the cluster is
+// hibernated. Seeds and clusters which can't be reached are skipped, so they don't prevent any changes within the project.
+func GetProjectResourceUsage(ctx context.Context, clusterProviderGetter provider.ClusterProviderGetter, seedsGetter provider.SeedsGetter, project *kubermaticv1.Project) (*kubermaticv1.ProjectResourceUsage, error) {
+	return getProjectResourceUsage(ctx, clusterProviderGetter, seedsGetter, project, "")
+}
+
+// getProjectResourceUsage calculates the resources used by the project, leaving out the nodes of the excluded cluster
+func getProjectResourceUsage(ctx context.Context, clusterProviderGetter provider.ClusterProviderGetter, seedsGetter provider.SeedsGetter, project *kubermaticv1.Project, excludedCluster string) (*kubermaticv1.ProjectResourceUsage, error) {
+	seeds, err := seedsGetter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list seeds: %v", err)
+	}
+
+	usage := &kubermaticv1.ProjectResourceUsage{
+		CPU:         *resource.NewQuantity(0, resource.DecimalSI),
+		Memory:      *resource.NewQuantity(0, resource.BinarySI),
+		LastUpdated: metav1.Now(),
+	}
+	for seedName, seed := range seeds {
+		clusterProvider, err := clusterProviderGetter(seed)
+		if err != nil {
+			kubermaticlog.Logger.Warnw("Skipping unreachable seed in the resource usage of the project", "project", project.Name, "seed", seedName, zap.Error(err))
+			continue
+		}
+		clusters, err := clusterProvider.List(project, nil)
+		if err != nil {
+			kubermaticlog.Logger.Warnw("Skipping unreachable seed in the resource usage of the project", "project", project.Name, "seed", seedName, zap.Error(err))
+			continue
+		}
+		usage.Clusters += int64(len(clusters.Items))
+
+		for i := range clusters.Items {
+			cluster := &clusters.Items[i]
+			if cluster.Name == excludedCluster {
+				continue
+			}
+			// The apiserver of hibernated clusters is scaled down and their MachineDeployments are scaled to
+			// zero, until they got resumed completely
+			if cluster.Status.Hibernation != nil {
+				nodes := hibernatedNodes(cluster)
+				usage.Nodes += nodes
+				usage.UnknownSizeNodes += nodes
+				continue
+			}
+			if cluster.Status.ExtendedHealth.Apiserver != kubermaticv1.HealthStatusUp {
+				continue
+			}
+			if err := addClusterNodeUsage(ctx, clusterProvider, cluster, usage); err != nil {
+				kubermaticlog.Logger.Warnw("Skipping the nodes of an unreachable cluster in the resource usage of the project", "project", project.Name, "cluster", cluster.Name, zap.Error(err))
+			}
+		}
+	}
+
+	return usage, nil
+}
+
+func addClusterNodeUsage(ctx context.Context, clusterProvider provider.ClusterProvider, cluster *kubermaticv1.Cluster, usage *kubermaticv1.ProjectResourceUsage) error {
+	client, err := clusterProvider.GetAdminClientForCustomerCluster(cluster)
+	if err != nil {
+		return err
+	}
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	if err := client.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return err
+	}
+
+	for _, md := range machineDeployments.Items {
+		var replicas int64
+		if md.Spec.Replicas != nil {
+			replicas = int64(*md.Spec.Replicas)
+		}
+		// the cluster-autoscaler may scale up to the upper bound at any time
+		if _, maxReplicas := machineresource.GetAutoscalingBounds(&md); maxReplicas != nil && int64(*maxReplicas) > replicas {
+			replicas = int64(*maxReplicas)
+		}
+		usage.Nodes += replicas
+
+		cloudSpec, err := machineconversions.GetAPIV2NodeCloudSpec(md.Spec.Template.Spec)
+		if err != nil {
+			return fmt.Errorf("failed to get the node cloud spec of machine deployment %s: %v", md.Name, err)
+		}
+		cpu, memory, ok, err := NodeResources(*cloudSpec)
+		if err != nil || !ok {
+			usage.UnknownSizeNodes += replicas
+			continue
+		}
+		usage.CPU.Add(multiplyQuantity(cpu, replicas))
+		usage.Memory.Add(multiplyQuantity(memory, replicas))
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common_test
+
+import (
+	"testing"
+
+	v1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/v1/common"
+)
+
+func TestProjectResourceRequest(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                     string
+		Add                      []v1.NodeDeployment
+		Remove                   []v1.NodeDeployment
+		ExpectedNodes            int64
+		ExpectedCPU              string
+		ExpectedMemory           string
+		ExpectedUnknownSizes     []string
+		ExpectedUnknownSizeNodes int64
+	}{
+		{
+			Name:           "scenario 1, the size of vsphere nodes is taken from the spec",
+			Add:            []v1.NodeDeployment{genNodeDeployment(3, v1.NodeCloudSpec{VSphere: &v1.VSphereNodeSpec{CPUs: 2, Memory: 4096}})},
+			ExpectedNodes:  3,
+			ExpectedCPU:    "6",
+			ExpectedMemory: "12Gi",
+		},
+		{
+			Name:           "scenario 2, the size of kubevirt nodes is taken from the spec",
+			Add:            []v1.NodeDeployment{genNodeDeployment(2, v1.NodeCloudSpec{Kubevirt: &v1.KubevirtNodeSpec{CPUs: "500m", Memory: "1Gi"}})},
+			ExpectedNodes:  2,
+			ExpectedCPU:    "1",
+			ExpectedMemory: "2Gi",
+		},
+		{
+			Name:                     "scenario 3, the size of digitalocean nodes is unknown",
+			Add:                      []v1.NodeDeployment{genNodeDeployment(2, v1.NodeCloudSpec{Digitalocean: &v1.DigitaloceanNodeSpec{Size: "s-2vcpu-4gb"}})},
+			ExpectedNodes:            2,
+			ExpectedCPU:              "0",
+			ExpectedMemory:           "0",
+			ExpectedUnknownSizes:     []string{"digitalocean"},
+			ExpectedUnknownSizeNodes: 2,
+		},
+		{
+			Name:           "scenario 4, only the difference of a changed node deployment is requested",
+			Add:            []v1.NodeDeployment{genNodeDeployment(5, v1.NodeCloudSpec{VSphere: &v1.VSphereNodeSpec{CPUs: 4, Memory: 8192}})},
+			Remove:         []v1.NodeDeployment{genNodeDeployment(3, v1.NodeCloudSpec{VSphere: &v1.VSphereNodeSpec{CPUs: 2, Memory: 4096}})},
+			ExpectedNodes:  2,
+			ExpectedCPU:    "14",
+			ExpectedMemory: "28Gi",
+		},
+		{
+			Name:                     "scenario 5, scaling down nodes of unknown size does not request any",
+			Add:                      []v1.NodeDeployment{genNodeDeployment(2, v1.NodeCloudSpec{Digitalocean: &v1.DigitaloceanNodeSpec{Size: "s-2vcpu-4gb"}})},
+			Remove:                   []v1.NodeDeployment{genNodeDeployment(3, v1.NodeCloudSpec{Digitalocean: &v1.DigitaloceanNodeSpec{Size: "s-2vcpu-4gb"}})},
+			ExpectedNodes:            -1,
+			ExpectedCPU:              "0",
+			ExpectedMemory:           "0",
+			ExpectedUnknownSizes:     []string{"digitalocean"},
+			ExpectedUnknownSizeNodes: -1,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			request := common.ProjectResourceRequest{}
+			for i := range tc.Add {
+				if err := request.AddNodeDeployment(&tc.Add[i]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for i := range tc.Remove {
+				if err := request.RemoveNodeDeployment(&tc.Remove[i]); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if request.Nodes != tc.ExpectedNodes {
+				t.Errorf("expected %d nodes, got %d", tc.ExpectedNodes, request.Nodes)
+			}
+			if cpu := request.CPU.String(); cpu != tc.ExpectedCPU {
+				t.Errorf("expected cpu %s, got %s", tc.ExpectedCPU, cpu)
+			}
+			if memory := request.Memory.String(); memory != tc.ExpectedMemory {
+				t.Errorf("expected memory %s, got %s", tc.ExpectedMemory, memory)
+			}
+			if request.UnknownSizeNodes != tc.ExpectedUnknownSizeNodes {
+				t.Errorf("expected %d nodes of unknown size, got %d", tc.ExpectedUnknownSizeNodes, request.UnknownSizeNodes)
+			}
+			if len(request.UnknownSizes) != len(tc.ExpectedUnknownSizes) {
+				t.Fatalf("expected unknown sizes %v, got %v", tc.ExpectedUnknownSizes, request.UnknownSizes)
+			}
+			for i := range tc.ExpectedUnknownSizes {
+				if request.UnknownSizes[i] != tc.ExpectedUnknownSizes[i] {
+					t.Errorf("expected unknown sizes %v, got %v", tc.ExpectedUnknownSizes, request.UnknownSizes)
+				}
+			}
+		})
+	}
+}
+
+func genNodeDeployment(replicas int32, cloud v1.NodeCloudSpec) v1.NodeDeployment {
+	return v1.NodeDeployment{
+		Spec: v1.NodeDeploymentSpec{
+			Replicas: replicas,
+			Template: v1.NodeSpec{
+				Cloud: cloud,
+			},
+		},
+	}
+}
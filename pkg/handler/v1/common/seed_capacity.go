@@ -0,0 +1,185 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+	kubermaticerrors "github.com/kubermatic/kubermatic/pkg/util/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SeedUsage is the usage of the capacity of a seed
+type SeedUsage struct {
+	Clusters           int64
+	ControlPlaneCPU    resource.Quantity
+	ControlPlaneMemory resource.Quantity
+}
+
+// GetSeedUsage counts the user clusters of the seed and sums up the resources requested by the pods of their
+// control planes. Pods which already terminated are not taken into account.
+func GetSeedUsage(ctx context.Context, seedClient ctrlruntimeclient.Client) (*SeedUsage, error) {
+	clusters := &kubermaticv1.ClusterList{}
+	if err := seedClient.List(ctx, clusters); err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %v", err)
+	}
+
+	usage := &SeedUsage{
+		Clusters:           int64(len(clusters.Items)),
+		ControlPlaneCPU:    *resource.NewQuantity(0, resource.DecimalSI),
+		ControlPlaneMemory: *resource.NewQuantity(0, resource.BinarySI),
+	}
+	for _, cluster := range clusters.Items {
+		if cluster.Status.NamespaceName == "" {
+			continue
+		}
+		pods := &corev1.PodList{}
+		if err := seedClient.List(ctx, pods, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+			return nil, fmt.Errorf("failed to list the control plane pods of cluster %s: %v", cluster.Name, err)
+		}
+		for _, pod := range pods.Items {
+			if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			for _, container := range pod.Spec.Containers {
+				if cpu, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
+					usage.ControlPlaneCPU.Add(cpu)
+				}
+				if memory, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
+					usage.ControlPlaneMemory.Add(memory)
+				}
+			}
+		}
+	}
+
+	return usage, nil
+}
+
+// ExceededSeedCapacity returns the limits of the capacity which are reached by the usage of the seed,
+// a seed which reached one of its limits doesn't accept new clusters
+func ExceededSeedCapacity(capacity *kubermaticv1.SeedCapacity, usage *SeedUsage) []string {
+	if capacity == nil {
+		return nil
+	}
+	var exceeded []string
+	if capacity.MaxClusters != nil && usage.Clusters >= *capacity.MaxClusters {
+		exceeded = append(exceeded, fmt.Sprintf("clusters (used %d, limit %d)", usage.Clusters, *capacity.MaxClusters))
+	}
+	if capacity.ControlPlaneCPU != nil && usage.ControlPlaneCPU.Cmp(*capacity.ControlPlaneCPU) >= 0 {
+		exceeded = append(exceeded, fmt.Sprintf("control plane cpu (used %s, limit %s)", usage.ControlPlaneCPU.String(), capacity.ControlPlaneCPU.String()))
+	}
+	if capacity.ControlPlaneMemory != nil && usage.ControlPlaneMemory.Cmp(*capacity.ControlPlaneMemory) >= 0 {
+		exceeded = append(exceeded, fmt.Sprintf("control plane memory (used %s, limit %s)", usage.ControlPlaneMemory.String(), capacity.ControlPlaneMemory.String()))
+	}
+	return exceeded
+}
+
+// ConvertInternalSeedUsageToExternal converts the usage of a seed for the API
+func ConvertInternalSeedUsageToExternal(capacity *kubermaticv1.SeedCapacity, usage *SeedUsage) *apiv1.SeedUsage {
+	return &apiv1.SeedUsage{
+		Clusters:           usage.Clusters,
+		ControlPlaneCPU:    usage.ControlPlaneCPU.String(),
+		ControlPlaneMemory: usage.ControlPlaneMemory.String(),
+		Full:               len(ExceededSeedCapacity(capacity, usage)) > 0,
+	}
+}
+
+// ClusterPlacement is the seed and datacenter a new cluster gets created in
+type ClusterPlacement struct {
+	Seed           *kubermaticv1.Seed
+	DatacenterName string
+	Datacenter     *kubermaticv1.Datacenter
+	// Redirected is set when the seed of the requested datacenter is full and an alternative datacenter was chosen
+	Redirected bool
+}
+
+// ScheduleCluster returns the seed and datacenter a new cluster with the given cloud spec gets created in.
+// When the seed of the requested datacenter is full, the first alternative datacenter which the user may use,
+// which belongs to the cloud provider of the cloud spec and whose seed has capacity left is chosen instead.
+// Seeds without a capacity are never full.
+func ScheduleCluster(ctx context.Context, userInfo *provider.UserInfo, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter, cloud kubermaticv1.CloudSpec) (*ClusterPlacement, error) {
+	datacenterName := cloud.DatacenterName
+	seed, dc, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, datacenterName)
+	if err != nil {
+		return nil, err
+	}
+	exceeded, err := checkSeedCapacity(ctx, clusterProviderGetter, seed)
+	if err != nil {
+		return nil, err
+	}
+	if len(exceeded) == 0 {
+		return &ClusterPlacement{Seed: seed, DatacenterName: datacenterName, Datacenter: dc}, nil
+	}
+
+	providerName, err := provider.ClusterCloudProviderName(cloud)
+	if err != nil {
+		return nil, kubermaticerrors.NewBadRequest("invalid cloud spec: %v", err)
+	}
+
+	for _, alternative := range dc.Spec.AlternativeDatacenters {
+		alternativeSeed, alternativeDC, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, alternative)
+		if err != nil {
+			// alternatives which don't exist anymore or which the user may not use are skipped
+			continue
+		}
+		if alternativeSeed.Name == seed.Name {
+			continue
+		}
+		// the cloud spec is only valid for datacenters of its own provider, the provider of an
+		// alternative could have been changed since the alternative was configured
+		if alternativeProviderName, err := provider.DatacenterCloudProviderName(&alternativeDC.Spec); err != nil || alternativeProviderName != providerName {
+			continue
+		}
+		alternativeExceeded, err := checkSeedCapacity(ctx, clusterProviderGetter, alternativeSeed)
+		if err != nil {
+			return nil, err
+		}
+		if len(alternativeExceeded) == 0 {
+			return &ClusterPlacement{Seed: alternativeSeed, DatacenterName: alternative, Datacenter: alternativeDC, Redirected: true}, nil
+		}
+	}
+
+	return nil, kubermaticerrors.New(http.StatusServiceUnavailable, fmt.Sprintf("the seed of datacenter %s is full and none of its alternative %s datacenters has capacity left: %s", datacenterName, providerName, strings.Join(exceeded, ", ")))
+}
+
+func checkSeedCapacity(ctx context.Context, clusterProviderGetter provider.ClusterProviderGetter, seed *kubermaticv1.Seed) ([]string, error) {
+	if seed.Spec.Capacity == nil {
+		return nil, nil
+	}
+	clusterProvider, err := clusterProviderGetter(seed)
+	if err != nil {
+		return nil, kubermaticerrors.NewNotFound("cluster-provider", seed.Name)
+	}
+	privilegedClusterProvider, ok := clusterProvider.(provider.PrivilegedClusterProvider)
+	if !ok {
+		return nil, fmt.Errorf("the cluster provider of seed %s can not access the seed", seed.Name)
+	}
+	usage, err := GetSeedUsage(ctx, privilegedClusterProvider.GetSeedClusterAdminRuntimeClient())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the usage of seed %s: %v", seed.Name, err)
+	}
+	return ExceededSeedCapacity(seed.Spec.Capacity, usage), nil
+}
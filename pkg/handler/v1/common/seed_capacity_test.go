@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeSeedClusterProvider only gives access to the seed, which is all the scheduling needs
+type fakeSeedClusterProvider struct {
+	provider.ClusterProvider
+	provider.PrivilegedClusterProvider
+	client ctrlruntimeclient.Client
+}
+
+func (p *fakeSeedClusterProvider) GetSeedClusterAdminRuntimeClient() ctrlruntimeclient.Client {
+	return p.client
+}
+
+func TestScheduleCluster(t *testing.T) {
+	t.Parallel()
+	one := int64(1)
+	full := &kubermaticv1.SeedCapacity{MaxClusters: &one}
+	genSeed := func(name string, capacity *kubermaticv1.SeedCapacity, datacenter string, alternatives ...string) *kubermaticv1.Seed {
+		return &kubermaticv1.Seed{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kubermaticv1.SeedSpec{
+				Capacity: capacity,
+				Datacenters: map[string]kubermaticv1.Datacenter{
+					datacenter: {Spec: kubermaticv1.DatacenterSpec{Fake: &kubermaticv1.DatacenterSpecFake{}, AlternativeDatacenters: alternatives}},
+				},
+			},
+		}
+	}
+	genCluster := func(name string) runtime.Object {
+		return &kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+
+	testcases := []struct {
+		Name               string
+		Seeds              []*kubermaticv1.Seed
+		SeedClusters       map[string][]runtime.Object
+		ExpectedDatacenter string
+		ExpectedSeed       string
+		ExpectedRedirect   bool
+		ExpectedError      string
+	}{
+		{
+			Name:               "scenario 1, seeds without a capacity are never full",
+			Seeds:              []*kubermaticv1.Seed{genSeed("seed-a", nil, "dc-a", "dc-b")},
+			SeedClusters:       map[string][]runtime.Object{"seed-a": {genCluster("a1"), genCluster("a2")}},
+			ExpectedDatacenter: "dc-a",
+			ExpectedSeed:       "seed-a",
+		},
+		{
+			Name:               "scenario 2, the requested datacenter is used while its seed has capacity left",
+			Seeds:              []*kubermaticv1.Seed{genSeed("seed-a", full, "dc-a", "dc-b"), genSeed("seed-b", nil, "dc-b")},
+			ExpectedDatacenter: "dc-a",
+			ExpectedSeed:       "seed-a",
+		},
+		{
+			Name:               "scenario 3, clusters of a full seed are redirected to an alternative datacenter",
+			Seeds:              []*kubermaticv1.Seed{genSeed("seed-a", full, "dc-a", "dc-b", "dc-c"), genSeed("seed-b", full, "dc-b"), genSeed("seed-c", full, "dc-c")},
+			SeedClusters:       map[string][]runtime.Object{"seed-a": {genCluster("a1")}, "seed-b": {genCluster("b1")}},
+			ExpectedDatacenter: "dc-c",
+			ExpectedSeed:       "seed-c",
+			ExpectedRedirect:   true,
+		},
+		{
+			Name:          "scenario 4, clusters are rejected when all alternatives are full as well",
+			Seeds:         []*kubermaticv1.Seed{genSeed("seed-a", full, "dc-a", "dc-b", "dc-unknown"), genSeed("seed-b", full, "dc-b")},
+			SeedClusters:  map[string][]runtime.Object{"seed-a": {genCluster("a1")}, "seed-b": {genCluster("b1")}},
+			ExpectedError: "the seed of datacenter dc-a is full and none of its alternative fake datacenters has capacity left: clusters (used 1, limit 1)",
+		},
+		{
+			Name: "scenario 5, alternative datacenters of another provider are skipped",
+			Seeds: []*kubermaticv1.Seed{
+				genSeed("seed-a", full, "dc-a", "dc-b", "dc-c"),
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "seed-b"},
+					Spec: kubermaticv1.SeedSpec{
+						Datacenters: map[string]kubermaticv1.Datacenter{
+							"dc-b": {Spec: kubermaticv1.DatacenterSpec{AWS: &kubermaticv1.DatacenterSpecAWS{Region: "eu-central-1"}}},
+						},
+					},
+				},
+				genSeed("seed-c", nil, "dc-c"),
+			},
+			SeedClusters:       map[string][]runtime.Object{"seed-a": {genCluster("a1")}},
+			ExpectedDatacenter: "dc-c",
+			ExpectedSeed:       "seed-c",
+			ExpectedRedirect:   true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			seeds := map[string]*kubermaticv1.Seed{}
+			for _, seed := range tc.Seeds {
+				seeds[seed.Name] = seed
+			}
+			seedsGetter := func() (map[string]*kubermaticv1.Seed, error) {
+				return seeds, nil
+			}
+			clusterProviderGetter := func(seed *kubermaticv1.Seed) (provider.ClusterProvider, error) {
+				if _, ok := seeds[seed.Name]; !ok {
+					return nil, fmt.Errorf("unknown seed %s", seed.Name)
+				}
+				return &fakeSeedClusterProvider{client: fakectrlruntimeclient.NewFakeClientWithScheme(scheme.Scheme, tc.SeedClusters[seed.Name]...)}, nil
+			}
+
+			placement, err := common.ScheduleCluster(context.Background(), &provider.UserInfo{Email: "bob@acme.com"}, seedsGetter, clusterProviderGetter, kubermaticv1.CloudSpec{DatacenterName: "dc-a", Fake: &kubermaticv1.FakeCloudSpec{}})
+			if tc.ExpectedError != "" {
+				if err == nil || err.Error() != tc.ExpectedError {
+					t.Fatalf("expected error %q, got %v", tc.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if placement.DatacenterName != tc.ExpectedDatacenter || placement.Seed.Name != tc.ExpectedSeed || placement.Redirected != tc.ExpectedRedirect {
+				t.Fatalf("expected the cluster in datacenter %s of seed %s (redirected %t), got datacenter %s of seed %s (redirected %t)",
+					tc.ExpectedDatacenter, tc.ExpectedSeed, tc.ExpectedRedirect, placement.DatacenterName, placement.Seed.Name, placement.Redirected)
+			}
+		})
+	}
+}
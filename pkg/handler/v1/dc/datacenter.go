@@ -35,6 +35,7 @@ import (
 	"github.com/kubermatic/kubermatic/pkg/log"
 	"github.com/kubermatic/kubermatic/pkg/provider"
 	"github.com/kubermatic/kubermatic/pkg/util/errors"
+	"github.com/kubermatic/kubermatic/pkg/validation"
 )
 
 // ListEndpoint an HTTP endpoint that returns a list of apiv1.Datacenter
@@ -362,10 +363,14 @@ func UpdateEndpoint(seedsGetter provider.SeedsGetter, userInfoGetter provider.Us
 		}
 
 		// get the dc to update
-		if _, ok := seed.Spec.Datacenters[req.DCToUpdate]; !ok {
+		currentDC, ok := seed.Spec.Datacenters[req.DCToUpdate]
+		if !ok {
 			return nil, errors.New(http.StatusBadRequest,
 				fmt.Sprintf("Bad request: datacenter %q does not exists", req.DCToUpdate))
 		}
+		if err := validation.ValidateAuditFileSinkUpdate(req.Body.Spec.AuditLogging, currentDC.Spec.AuditLogging); err != nil {
+			return nil, errors.New(http.StatusBadRequest, fmt.Sprintf("Validation error: invalid audit logging settings: %v", err))
+		}
 
 		// Do an extra check if name changed and remove old dc
 		if !strings.EqualFold(req.DCToUpdate, req.Body.Name) {
@@ -458,6 +463,15 @@ func PatchEndpoint(seedsGetter provider.SeedsGetter, userInfoGetter provider.Use
 		if err := validateProvider(&patched.Spec); err != nil {
 			return nil, errors.New(http.StatusBadRequest, fmt.Sprintf("patched dc validation failed: %v", err))
 		}
+		if err := validation.ValidateAuditLoggingSettings(patched.Spec.AuditLogging); err != nil {
+			return nil, errors.New(http.StatusBadRequest, fmt.Sprintf("patched dc validation failed: invalid audit logging settings: %v", err))
+		}
+		if err := validation.ValidateAuditFileSinkUpdate(patched.Spec.AuditLogging, currentDC.Spec.AuditLogging); err != nil {
+			return nil, errors.New(http.StatusBadRequest, fmt.Sprintf("patched dc validation failed: invalid audit logging settings: %v", err))
+		}
+		if err := validation.ValidateOperatingSystemImages(patched.Spec.OperatingSystemImages); err != nil {
+			return nil, errors.New(http.StatusBadRequest, fmt.Sprintf("patched dc validation failed: invalid operating system images: %v", err))
+		}
 		kubermaticPatched := convertExternalDCToInternal(&patched.Spec)
 
 		// As provider field is extracted from providers, we need to make sure its set properly
@@ -672,7 +686,10 @@ func ConvertInternalDCToExternalSpec(dc *kubermaticv1.Datacenter, seedName strin
 		RequiredEmailDomain:      dc.Spec.RequiredEmailDomain,
 		RequiredEmailDomains:     dc.Spec.RequiredEmailDomains,
 		EnforceAuditLogging:      dc.Spec.EnforceAuditLogging,
+		AuditLogging:             dc.Spec.AuditLogging,
 		EnforcePodSecurityPolicy: dc.Spec.EnforcePodSecurityPolicy,
+		AlternativeDatacenters:   dc.Spec.AlternativeDatacenters,
+		OperatingSystemImages:    dc.Spec.OperatingSystemImages,
 	}, nil
 }
 
@@ -697,7 +714,10 @@ func convertExternalDCToInternal(datacenter *apiv1.DatacenterSpec) kubermaticv1.
 			RequiredEmailDomain:      datacenter.RequiredEmailDomain,
 			RequiredEmailDomains:     datacenter.RequiredEmailDomains,
 			EnforceAuditLogging:      datacenter.EnforceAuditLogging,
+			AuditLogging:             datacenter.AuditLogging,
 			EnforcePodSecurityPolicy: datacenter.EnforcePodSecurityPolicy,
+			AlternativeDatacenters:   datacenter.AlternativeDatacenters,
+			OperatingSystemImages:    datacenter.OperatingSystemImages,
 		},
 	}
 }
@@ -833,6 +853,14 @@ func (req createDCReq) validate() error {
 		return err
 	}
 
+	if err := validation.ValidateAuditLoggingSettings(req.Body.Spec.AuditLogging); err != nil {
+		return fmt.Errorf("invalid audit logging settings: %v", err)
+	}
+
+	if err := validation.ValidateOperatingSystemImages(req.Body.Spec.OperatingSystemImages); err != nil {
+		return fmt.Errorf("invalid operating system images: %v", err)
+	}
+
 	if !strings.EqualFold(req.Seed, req.Body.Spec.Seed) {
 		return fmt.Errorf("path seed %q and request seed %q not equal", req.Seed, req.Body.Spec.Seed)
 	}
@@ -0,0 +1,180 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/middleware"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+	"github.com/kubermatic/kubermatic/pkg/resources/clusterautoscaler"
+	machineresource "github.com/kubermatic/kubermatic/pkg/resources/machine"
+	k8cerrors "github.com/kubermatic/kubermatic/pkg/util/errors"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// validateAutoscaling checks that the cluster-autoscaler can be deployed for the cluster
+// if the node deployment has autoscaling bounds
+func validateAutoscaling(cluster *kubermaticv1.Cluster, nd *apiv1.NodeDeployment) error {
+	if !machineresource.HasAutoscalingBounds(&nd.Spec) || clusterautoscaler.IsSupported(cluster) {
+		return nil
+	}
+	return k8cerrors.NewBadRequest("the cluster-autoscaler is not available for Kubernetes version %s", cluster.Spec.Version.String())
+}
+
+// syncClusterAutoscaler enables the cluster-autoscaler of the cluster as soon as a node deployment has
+// autoscaling bounds and disables it again once none has, unless it got enabled by someone else
+func syncClusterAutoscaler(ctx context.Context, client ctrlruntimeclient.Client, project *kubermaticv1.Project, cluster *kubermaticv1.Cluster) error {
+	machineDeployments := &clusterv1alpha1.MachineDeploymentList{}
+	if err := client.List(ctx, machineDeployments, ctrlruntimeclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return err
+	}
+
+	autoscaled := false
+	for i := range machineDeployments.Items {
+		md := &machineDeployments.Items[i]
+		if md.DeletionTimestamp != nil {
+			continue
+		}
+		if minReplicas, _ := machineresource.GetAutoscalingBounds(md); minReplicas != nil {
+			autoscaled = true
+			break
+		}
+	}
+
+	value, enabled := cluster.Annotations[kubermaticv1.AnnotationNameClusterAutoscalerEnabled]
+	switch {
+	case autoscaled && !enabled:
+		if cluster.Annotations == nil {
+			cluster.Annotations = map[string]string{}
+		}
+		cluster.Annotations[kubermaticv1.AnnotationNameClusterAutoscalerEnabled] = kubermaticv1.AnnotationValueClusterAutoscalerNodeDeployments
+	case !autoscaled && value == kubermaticv1.AnnotationValueClusterAutoscalerNodeDeployments:
+		delete(cluster.Annotations, kubermaticv1.AnnotationNameClusterAutoscalerEnabled)
+	default:
+		return nil
+	}
+
+	// the annotation is managed by the API on behalf of the user, who is not necessarily allowed to update the cluster
+	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+	if _, err := privilegedClusterProvider.UpdateUnsecured(project, cluster); err != nil {
+		return fmt.Errorf("failed to update the cluster-autoscaler annotation of the cluster: %v", err)
+	}
+	return nil
+}
+
+// autoscalerStatus holds the status of the cluster-autoscaler of a user cluster
+type autoscalerStatus struct {
+	nodeGroups map[string]clusterautoscaler.NodeGroupStatus
+	events     []corev1.Event
+}
+
+// getAutoscalerStatus reads the status the cluster-autoscaler reports in the user cluster. It returns nil
+// if the cluster-autoscaler did not report a status yet.
+func getAutoscalerStatus(ctx context.Context, client ctrlruntimeclient.Client) (*autoscalerStatus, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: clusterautoscaler.StatusConfigMapName}, configMap); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// the cluster-autoscaler records the scale events on its status ConfigMap
+	events := &corev1.EventList{}
+	listOpts := &ctrlruntimeclient.ListOptions{
+		Namespace:     metav1.NamespaceSystem,
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.uid", string(configMap.UID)),
+	}
+	if err := client.List(ctx, events, listOpts); err != nil {
+		return nil, err
+	}
+
+	return &autoscalerStatus{
+		nodeGroups: clusterautoscaler.ParseNodeGroupStatuses(configMap.Data[clusterautoscaler.StatusConfigMapKey]),
+		events:     events.Items,
+	}, nil
+}
+
+// setAutoscalerStatus sets the status of the cluster-autoscaler on node deployments with autoscaling bounds
+func setAutoscalerStatus(nd *apiv1.NodeDeployment, status *autoscalerStatus) {
+	if status == nil || !machineresource.HasAutoscalingBounds(&nd.Spec) {
+		return
+	}
+
+	ndStatus := &apiv1.NodeDeploymentAutoscalerStatus{}
+	if groupStatus, ok := clusterautoscaler.GetNodeGroupStatus(status.nodeGroups, metav1.NamespaceSystem, nd.Name); ok {
+		ndStatus.Health = convertAutoscalerCondition(groupStatus.Health)
+		ndStatus.ScaleUp = convertAutoscalerCondition(groupStatus.ScaleUp)
+		ndStatus.ScaleDown = convertAutoscalerCondition(groupStatus.ScaleDown)
+	}
+
+	var lastEvent *corev1.Event
+	for i := range status.events {
+		event := &status.events[i]
+		if !eventMentionsNodeGroup(event.Message, nd.Name) {
+			continue
+		}
+		if lastEvent == nil || lastEvent.LastTimestamp.Before(&event.LastTimestamp) {
+			lastEvent = event
+		}
+	}
+	if lastEvent != nil {
+		ndStatus.LastScaleEvent = &apiv1.AutoscalerScaleEvent{
+			Time:    apiv1.NewTime(lastEvent.LastTimestamp.Time),
+			Reason:  lastEvent.Reason,
+			Message: lastEvent.Message,
+		}
+	}
+
+	nd.AutoscalerStatus = ndStatus
+}
+
+func convertAutoscalerCondition(condition *clusterautoscaler.Condition) *apiv1.AutoscalerCondition {
+	if condition == nil {
+		return nil
+	}
+	return &apiv1.AutoscalerCondition{
+		Status:             condition.Status,
+		Message:            condition.Message,
+		LastTransitionTime: apiv1.NewTime(condition.LastTransitionTime),
+	}
+}
+
+// eventMentionsNodeGroup checks whether an event message like
+// "Scale-up: setting group MachineDeployment/kube-system/workers size to 3" is about the given MachineDeployment
+func eventMentionsNodeGroup(message, name string) bool {
+	groupName := metav1.NamespaceSystem + "/" + name
+	for _, word := range strings.Fields(message) {
+		word = strings.TrimRight(word, ",.:;")
+		if word == groupName || word == clusterautoscaler.NodeGroupName(metav1.NamespaceSystem, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/test"
+	"github.com/kubermatic/kubermatic/pkg/handler/test/hack"
+	"github.com/kubermatic/kubermatic/pkg/resources/clusterautoscaler"
+	machineresource "github.com/kubermatic/kubermatic/pkg/resources/machine"
+	"github.com/kubermatic/kubermatic/pkg/semver"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const autoscalerTestProviderSpec = `{"cloudProvider":"digitalocean","cloudProviderSpec":{"token":"dummy-token","region":"fra1","size":"2GB"}, "operatingSystem":"ubuntu", "operatingSystemSpec":{"distUpgradeOnBoot":true}}`
+
+func TestPatchNodeDeploymentAutoscaling(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		Name                       string
+		Body                       string
+		HTTPStatus                 int
+		ExpectedError              string
+		ExpectedMinReplicas        *int32
+		ExpectedMaxReplicas        *int32
+		ExpectedClusterAnnotation  string
+		ExistingCluster            *kubermaticv1.Cluster
+		ExistingMachineDeployments []runtime.Object
+	}{
+		{
+			Name:                       "scenario 1: setting bounds enables the cluster-autoscaler",
+			Body:                       `{"spec":{"minReplicas":1,"maxReplicas":5}}`,
+			HTTPStatus:                 http.StatusOK,
+			ExpectedMinReplicas:        int32Ptr(1),
+			ExpectedMaxReplicas:        int32Ptr(5),
+			ExpectedClusterAnnotation:  kubermaticv1.AnnotationValueClusterAutoscalerNodeDeployments,
+			ExistingCluster:            genAutoscalerTestCluster("1.14.8", ""),
+			ExistingMachineDeployments: []runtime.Object{genAutoscalerTestMachineDeployment("venus", "v1.14.8", nil, nil)},
+		},
+		{
+			Name:                       "scenario 2: removing the last bounds disables the cluster-autoscaler",
+			Body:                       `{"spec":{"minReplicas":null,"maxReplicas":null}}`,
+			HTTPStatus:                 http.StatusOK,
+			ExistingCluster:            genAutoscalerTestCluster("1.14.8", kubermaticv1.AnnotationValueClusterAutoscalerNodeDeployments),
+			ExistingMachineDeployments: []runtime.Object{genAutoscalerTestMachineDeployment("venus", "v1.14.8", int32Ptr(1), int32Ptr(3))},
+		},
+		{
+			Name:                      "scenario 3: the cluster-autoscaler stays enabled while other node deployments have bounds",
+			Body:                      `{"spec":{"minReplicas":null,"maxReplicas":null}}`,
+			HTTPStatus:                http.StatusOK,
+			ExpectedClusterAnnotation: kubermaticv1.AnnotationValueClusterAutoscalerNodeDeployments,
+			ExistingCluster:           genAutoscalerTestCluster("1.14.8", kubermaticv1.AnnotationValueClusterAutoscalerNodeDeployments),
+			ExistingMachineDeployments: []runtime.Object{
+				genAutoscalerTestMachineDeployment("venus", "v1.14.8", int32Ptr(1), int32Ptr(3)),
+				genAutoscalerTestMachineDeployment("mars", "v1.14.8", int32Ptr(1), int32Ptr(3)),
+			},
+		},
+		{
+			Name:                       "scenario 4: a cluster-autoscaler enabled by an admin is not disabled",
+			Body:                       `{"spec":{"minReplicas":null,"maxReplicas":null}}`,
+			HTTPStatus:                 http.StatusOK,
+			ExpectedClusterAnnotation:  "true",
+			ExistingCluster:            genAutoscalerTestCluster("1.14.8", "true"),
+			ExistingMachineDeployments: []runtime.Object{genAutoscalerTestMachineDeployment("venus", "v1.14.8", int32Ptr(1), int32Ptr(3))},
+		},
+		{
+			Name:                       "scenario 5: the replicas have to be within the bounds",
+			Body:                       `{"spec":{"minReplicas":2,"maxReplicas":5}}`,
+			HTTPStatus:                 http.StatusBadRequest,
+			ExpectedError:              `{"error":{"code":400,"message":"replicas 1 have to be between minReplicas 2 and maxReplicas 5"}}`,
+			ExistingCluster:            genAutoscalerTestCluster("1.14.8", ""),
+			ExistingMachineDeployments: []runtime.Object{genAutoscalerTestMachineDeployment("venus", "v1.14.8", nil, nil)},
+		},
+		{
+			Name:                       "scenario 6: both bounds have to be set",
+			Body:                       `{"spec":{"maxReplicas":5}}`,
+			HTTPStatus:                 http.StatusBadRequest,
+			ExpectedError:              `{"error":{"code":400,"message":"minReplicas and maxReplicas have to be set together"}}`,
+			ExistingCluster:            genAutoscalerTestCluster("1.14.8", ""),
+			ExistingMachineDeployments: []runtime.Object{genAutoscalerTestMachineDeployment("venus", "v1.14.8", nil, nil)},
+		},
+		{
+			Name:                       "scenario 7: bounds cannot be set if there is no cluster-autoscaler for the version",
+			Body:                       `{"spec":{"minReplicas":1,"maxReplicas":5}}`,
+			HTTPStatus:                 http.StatusBadRequest,
+			ExpectedError:              `{"error":{"code":400,"message":"the cluster-autoscaler is not available for Kubernetes version 9.9.9"}}`,
+			ExistingCluster:            genAutoscalerTestCluster("9.9.9", ""),
+			ExistingMachineDeployments: []runtime.Object{genAutoscalerTestMachineDeployment("venus", "v9.9.9", nil, nil)},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters/%s/nodedeployments/venus",
+				test.GenDefaultProject().Name, tc.ExistingCluster.Name), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+			ep, clientsSets, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), nil, nil, tc.ExistingMachineDeployments, test.GenDefaultKubermaticObjects(tc.ExistingCluster), nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			if tc.ExpectedError != "" {
+				test.CompareWithResult(t, res, tc.ExpectedError)
+				return
+			}
+
+			nd := &apiv1.NodeDeployment{}
+			if err := json.Unmarshal(res.Body.Bytes(), nd); err != nil {
+				t.Fatal(err)
+			}
+			if !int32PtrEqual(nd.Spec.MinReplicas, tc.ExpectedMinReplicas) || !int32PtrEqual(nd.Spec.MaxReplicas, tc.ExpectedMaxReplicas) {
+				t.Errorf("expected the bounds %v-%v, got %v-%v", tc.ExpectedMinReplicas, tc.ExpectedMaxReplicas, nd.Spec.MinReplicas, nd.Spec.MaxReplicas)
+			}
+
+			md := &clusterv1alpha1.MachineDeployment{}
+			if err := clientsSets.FakeClient.Get(context.Background(), types.NamespacedName{Namespace: metav1.NamespaceSystem, Name: "venus"}, md); err != nil {
+				t.Fatalf("failed to get the machine deployment: %v", err)
+			}
+			minReplicas, maxReplicas := machineresource.GetAutoscalingBounds(md)
+			if !int32PtrEqual(minReplicas, tc.ExpectedMinReplicas) || !int32PtrEqual(maxReplicas, tc.ExpectedMaxReplicas) {
+				t.Errorf("expected the machine deployment to have the bounds %v-%v, got %v", tc.ExpectedMinReplicas, tc.ExpectedMaxReplicas, md.Annotations)
+			}
+
+			cluster := &kubermaticv1.Cluster{}
+			if err := clientsSets.FakeClient.Get(context.Background(), types.NamespacedName{Name: tc.ExistingCluster.Name}, cluster); err != nil {
+				t.Fatalf("failed to get the cluster: %v", err)
+			}
+			if annotation := cluster.Annotations[kubermaticv1.AnnotationNameClusterAutoscalerEnabled]; annotation != tc.ExpectedClusterAnnotation {
+				t.Errorf("expected the cluster-autoscaler annotation %q, got %q", tc.ExpectedClusterAnnotation, annotation)
+			}
+		})
+	}
+}
+
+func TestGetNodeDeploymentAutoscalerStatus(t *testing.T) {
+	t.Parallel()
+
+	statusConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterautoscaler.StatusConfigMapName,
+			Namespace: metav1.NamespaceSystem,
+			UID:       "status-uid",
+		},
+		Data: map[string]string{
+			clusterautoscaler.StatusConfigMapKey: `Cluster-autoscaler status at 2020-05-12 10:20:31.452930284 +0000 UTC:
+NodeGroups:
+  Name:        MachineDeployment/kube-system/venus
+  Health:      Healthy (ready=1 unready=0 notStarted=0 longNotStarted=0 registered=1 longUnregistered=0 cloudProviderTarget=3 (minSize=1, maxSize=3))
+               LastProbeTime:      2020-05-12 10:20:31.212356703 +0000 UTC
+               LastTransitionTime: 2020-05-12 09:20:12 +0000 UTC
+  ScaleUp:     InProgress (ready=1 cloudProviderTarget=3)
+               LastProbeTime:      2020-05-12 10:20:31.212356703 +0000 UTC
+               LastTransitionTime: 2020-05-12 10:19:45 +0000 UTC
+`,
+		},
+	}
+	scaleUpEvent := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-autoscaler-status.1",
+			Namespace: metav1.NamespaceSystem,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "ConfigMap",
+			Name:      clusterautoscaler.StatusConfigMapName,
+			Namespace: metav1.NamespaceSystem,
+			UID:       "status-uid",
+		},
+		Reason:        "ScaledUpGroup",
+		Message:       "Scale-up: setting group MachineDeployment/kube-system/venus size to 3",
+		LastTimestamp: metav1.NewTime(time.Date(2020, 5, 12, 10, 19, 45, 0, time.UTC)),
+		Type:          corev1.EventTypeNormal,
+	}
+	otherGroupEvent := scaleUpEvent.DeepCopy()
+	otherGroupEvent.Name = "cluster-autoscaler-status.2"
+	otherGroupEvent.Message = "Scale-up: setting group MachineDeployment/kube-system/venus-gpu size to 2"
+	otherGroupEvent.LastTimestamp = metav1.NewTime(time.Date(2020, 5, 12, 10, 20, 0, 0, time.UTC))
+
+	expectedResponse := `{"id":"venus","name":"venus","creationTimestamp":"0001-01-01T00:00:00Z","spec":{"replicas":1,"template":{"cloud":{"digitalocean":{"size":"2GB","backups":false,"ipv6":false,"monitoring":false,"tags":null}},"operatingSystem":{"ubuntu":{"distUpgradeOnBoot":true}},"versions":{"kubelet":"v1.14.8"}},"paused":false,"dynamicConfig":false,"minReplicas":1,"maxReplicas":3},"status":{},"autoscalerStatus":{"health":{"status":"Healthy","message":"ready=1 unready=0 notStarted=0 longNotStarted=0 registered=1 longUnregistered=0 cloudProviderTarget=3 (minSize=1, maxSize=3)","lastTransitionTime":"2020-05-12T09:20:12Z"},"scaleUp":{"status":"InProgress","message":"ready=1 cloudProviderTarget=3","lastTransitionTime":"2020-05-12T10:19:45Z"},"lastScaleEvent":{"time":"2020-05-12T10:19:45Z","reason":"ScaledUpGroup","message":"Scale-up: setting group MachineDeployment/kube-system/venus size to 3"}}}`
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters/%s/nodedeployments/venus", test.GenDefaultProject().Name, test.GenDefaultCluster().Name), strings.NewReader(""))
+	res := httptest.NewRecorder()
+	kubernetesObj := []runtime.Object{statusConfigMap, scaleUpEvent, otherGroupEvent}
+	machineObj := []runtime.Object{genAutoscalerTestMachineDeployment("venus", "v1.14.8", int32Ptr(1), int32Ptr(3))}
+	kubermaticObj := test.GenDefaultKubermaticObjects(genAutoscalerTestCluster("1.14.8", kubermaticv1.AnnotationValueClusterAutoscalerNodeDeployments))
+	ep, _, err := test.CreateTestEndpointAndGetClients(*test.GenDefaultAPIUser(), nil, kubernetesObj, machineObj, kubermaticObj, nil, nil, hack.NewTestRouting)
+	if err != nil {
+		t.Fatalf("failed to create test endpoint due to %v", err)
+	}
+
+	ep.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Fatalf("Expected HTTP status code %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+	}
+	test.CompareWithResult(t, res, expectedResponse)
+}
+
+func genAutoscalerTestCluster(version, autoscalerAnnotation string) *kubermaticv1.Cluster {
+	cluster := genTestCluster(true)
+	cluster.Spec.Version = *semver.NewSemverOrDie(version)
+	if autoscalerAnnotation != "" {
+		cluster.Annotations = map[string]string{kubermaticv1.AnnotationNameClusterAutoscalerEnabled: autoscalerAnnotation}
+	}
+	return cluster
+}
+
+func genAutoscalerTestMachineDeployment(name, kubeletVersion string, minReplicas, maxReplicas *int32) *clusterv1alpha1.MachineDeployment {
+	md := genTestMachineDeployment(name, autoscalerTestProviderSpec, nil, false)
+	md.Spec.Template.Spec.Versions.Kubelet = kubeletVersion
+	machineresource.SetAutoscalingAnnotations(md, &apiv1.NodeDeploymentSpec{MinReplicas: minReplicas, MaxReplicas: maxReplicas})
+	return md
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func int32PtrEqual(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -89,7 +89,7 @@ func DecodeCreateNodeDeployment(c context.Context, r *http.Request) (interface{}
 	return req, nil
 }
 
-func CreateNodeDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func CreateNodeDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(createNodeDeploymentReq)
 		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
@@ -135,6 +135,23 @@ func CreateNodeDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvide
 		if err != nil {
 			return nil, k8cerrors.NewBadRequest(fmt.Sprintf("node deployment validation failed: %s", err.Error()))
 		}
+		if err := validateAutoscaling(cluster, nd); err != nil {
+			return nil, err
+		}
+		if err := machineresource.ValidateOperatingSystemImage(&nd.Spec.Template, dc, ""); err != nil {
+			return nil, k8cerrors.NewBadRequest("%v", err)
+		}
+		if err := machineresource.SetDefaultOperatingSystemImage(&nd.Spec.Template, dc, nil); err != nil {
+			return nil, k8cerrors.NewBadRequest("%v", err)
+		}
+
+		quotaRequest := common.ProjectResourceRequest{}
+		if err := quotaRequest.AddNodeDeployment(nd); err != nil {
+			return nil, k8cerrors.NewBadRequest("invalid node deployment: %v", err)
+		}
+		if err := common.CheckProjectQuota(ctx, clusterProviderGetter, seedsGetter, privilegedProjectProvider, project, quotaRequest); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
 
 		assertedClusterProvider, ok := clusterProvider.(*kubernetesprovider.ClusterProvider)
 		if !ok {
@@ -155,6 +172,10 @@ func CreateNodeDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvide
 			return nil, fmt.Errorf("failed to create machine deployment: %v", err)
 		}
 
+		if err := syncClusterAutoscaler(ctx, client, project, cluster); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
 		return outputMachineDeployment(md)
 	}
 }
@@ -189,6 +210,7 @@ func outputMachineDeployment(md *clusterv1alpha1.MachineDeployment) (*apiv1.Node
 	}
 
 	hasDynamicConfig := md.Spec.Template.Spec.ConfigSource != nil
+	minReplicas, maxReplicas := machineresource.GetAutoscalingBounds(md)
 
 	return &apiv1.NodeDeployment{
 		ObjectMeta: apiv1.ObjectMeta{
@@ -205,11 +227,14 @@ func outputMachineDeployment(md *clusterv1alpha1.MachineDeployment) (*apiv1.Node
 				Versions: apiv1.NodeVersionInfo{
 					Kubelet: md.Spec.Template.Spec.Versions.Kubelet,
 				},
-				OperatingSystem: *operatingSystemSpec,
-				Cloud:           *cloudSpec,
+				OperatingSystem:      *operatingSystemSpec,
+				Cloud:                *cloudSpec,
+				OperatingSystemImage: md.Annotations[machineresource.OperatingSystemImageAnnotation],
 			},
 			Paused:        &md.Spec.Paused,
 			DynamicConfig: &hasDynamicConfig,
+			MinReplicas:   minReplicas,
+			MaxReplicas:   maxReplicas,
 		},
 		Status: md.Status,
 	}, nil
@@ -240,7 +265,7 @@ func DecodeListNodeDeployments(c context.Context, r *http.Request) (interface{},
 	return req, nil
 }
 
-func ListNodeDeployments(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func ListNodeDeployments(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(listNodeDeploymentsReq)
 		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
@@ -260,12 +285,27 @@ func ListNodeDeployments(projectProvider provider.ProjectProvider, privilegedPro
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
+		var status *autoscalerStatus
+		if cluster.Annotations[kubermaticv1.AnnotationNameClusterAutoscalerEnabled] != "" {
+			if status, err = getAutoscalerStatus(ctx, client); err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+		}
+
+		// The warnings are informational only, the node deployments are listed without them if
+		// the datacenter of the cluster is gone
+		dc, _ := getDatacenter(ctx, userInfoGetter, seedsGetter, cluster)
+
 		nodeDeployments := make([]*apiv1.NodeDeployment, 0, len(machineDeployments.Items))
 		for i := range machineDeployments.Items {
 			nd, err := outputMachineDeployment(&machineDeployments.Items[i])
 			if err != nil {
 				return nil, fmt.Errorf("failed to output machine deployment %s: %v", machineDeployments.Items[i].Name, err)
 			}
+			setAutoscalerStatus(nd, status)
+			if dc != nil {
+				nd.Warnings = machineresource.OperatingSystemImageWarnings(&nd.Spec.Template, dc)
+			}
 
 			nodeDeployments = append(nodeDeployments, nd)
 		}
@@ -316,7 +356,7 @@ func DecodeGetNodeDeployment(c context.Context, r *http.Request) (interface{}, e
 	return req, nil
 }
 
-func GetNodeDeployment(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func GetNodeDeployment(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(nodeDeploymentReq)
 		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
@@ -335,8 +375,38 @@ func GetNodeDeployment(projectProvider provider.ProjectProvider, privilegedProje
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
-		return outputMachineDeployment(machineDeployment)
+		nd, err := outputMachineDeployment(machineDeployment)
+		if err != nil {
+			return nil, err
+		}
+
+		if machineresource.HasAutoscalingBounds(&nd.Spec) {
+			status, err := getAutoscalerStatus(ctx, client)
+			if err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+			setAutoscalerStatus(nd, status)
+		}
+
+		if dc, err := getDatacenter(ctx, userInfoGetter, seedsGetter, cluster); err == nil {
+			nd.Warnings = machineresource.OperatingSystemImageWarnings(&nd.Spec.Template, dc)
+		}
+
+		return nd, nil
+	}
+}
+
+// getDatacenter returns the datacenter of the cluster
+func getDatacenter(ctx context.Context, userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter, cluster *kubermaticv1.Cluster) (*kubermaticv1.Datacenter, error) {
+	userInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	_, dc, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, cluster.Spec.Cloud.DatacenterName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting dc: %v", err)
 	}
+	return dc, nil
 }
 
 // nodeDeploymentNodesReq defines HTTP request for listNodeDeploymentNodes
@@ -629,7 +699,7 @@ func DecodePatchNodeDeployment(c context.Context, r *http.Request) (interface{},
 	return req, nil
 }
 
-func PatchNodeDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func PatchNodeDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(patchNodeDeploymentReq)
 		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
@@ -688,11 +758,35 @@ func PatchNodeDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvider
 		if err = nodeupdate.EnsureVersionCompatible(cluster.Spec.Version.Semver(), kversion); err != nil {
 			return nil, k8cerrors.NewBadRequest(err.Error())
 		}
+		if err := machineresource.ValidateAutoscalingBounds(&patchedNodeDeployment.Spec); err != nil {
+			return nil, k8cerrors.NewBadRequest("%v", err)
+		}
+		if err := validateAutoscaling(cluster, patchedNodeDeployment); err != nil {
+			return nil, err
+		}
+
+		// only the difference between the existing and the patched node deployment counts against the quota
+		quotaRequest := common.ProjectResourceRequest{}
+		if err := quotaRequest.AddNodeDeployment(patchedNodeDeployment); err != nil {
+			return nil, k8cerrors.NewBadRequest("invalid node deployment: %v", err)
+		}
+		if err := quotaRequest.RemoveNodeDeployment(nodeDeployment); err != nil {
+			return nil, fmt.Errorf("invalid existing node deployment: %v", err)
+		}
+		if err := common.CheckProjectQuota(ctx, clusterProviderGetter, seedsGetter, privilegedProjectProvider, project, quotaRequest); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
 
 		_, dc, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, cluster.Spec.Cloud.DatacenterName)
 		if err != nil {
 			return nil, fmt.Errorf("error getting dc: %v", err)
 		}
+		if err := machineresource.ValidateOperatingSystemImage(&patchedNodeDeployment.Spec.Template, dc, nodeDeployment.Spec.Template.OperatingSystemImage); err != nil {
+			return nil, k8cerrors.NewBadRequest("%v", err)
+		}
+		if err := machineresource.SetDefaultOperatingSystemImage(&patchedNodeDeployment.Spec.Template, dc, &nodeDeployment.Spec.Template); err != nil {
+			return nil, k8cerrors.NewBadRequest("%v", err)
+		}
 
 		keys, err := sshKeyProvider.List(project, &provider.SSHKeyListOptions{ClusterName: req.ClusterID})
 		if err != nil {
@@ -717,12 +811,24 @@ func PatchNodeDeployment(sshKeyProvider provider.SSHKeyProvider, projectProvider
 		machineDeployment.Spec.Template.Spec = patchedMachineDeployment.Spec.Template.Spec
 		machineDeployment.Spec.Replicas = patchedMachineDeployment.Spec.Replicas
 		machineDeployment.Spec.Paused = patchedMachineDeployment.Spec.Paused
+		machineresource.SetAutoscalingAnnotations(machineDeployment, &patchedNodeDeployment.Spec)
+		machineresource.SetOperatingSystemImageAnnotation(machineDeployment, &patchedNodeDeployment.Spec.Template)
 
 		if err := client.Update(ctx, machineDeployment); err != nil {
 			return nil, fmt.Errorf("failed to update machine deployment: %v", err)
 		}
 
-		return outputMachineDeployment(machineDeployment)
+		if err := syncClusterAutoscaler(ctx, client, project, cluster); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		nd, err := outputMachineDeployment(machineDeployment)
+		if err != nil {
+			return nil, err
+		}
+		// The patched node deployment may keep a deprecated image, point that out
+		nd.Warnings = machineresource.OperatingSystemImageWarnings(&nd.Spec.Template, dc)
+		return nd, nil
 	}
 }
 
@@ -763,6 +869,11 @@ func DeleteNodeDeployment(projectProvider provider.ProjectProvider, privilegedPr
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(deleteNodeDeploymentReq)
 		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
 		cluster, err := cluster.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
 		if err != nil {
 			return nil, err
@@ -773,7 +884,11 @@ func DeleteNodeDeployment(projectProvider provider.ProjectProvider, privilegedPr
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
-		return nil, common.KubernetesErrorToHTTPError(client.Delete(ctx, &clusterv1alpha1.MachineDeployment{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: req.NodeDeploymentID}}))
+		if err := client.Delete(ctx, &clusterv1alpha1.MachineDeployment{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceSystem, Name: req.NodeDeploymentID}}); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return nil, common.KubernetesErrorToHTTPError(syncClusterAutoscaler(ctx, client, project, cluster))
 	}
 }
 
@@ -700,6 +700,24 @@ func TestCreateNodeDeployment(t *testing.T) {
 			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(genTestCluster(true)),
 			ExistingAPIUser:        test.GenDefaultAPIUser(),
 		},
+
+		// scenario 8
+		{
+			Name:             "scenario 8: nodes of unknown size are rejected by a cpu quota",
+			Body:             `{"spec":{"replicas":1,"template":{"cloud":{"digitalocean":{"size":"s-1vcpu-1gb","backups":false,"ipv6":false,"monitoring":false,"tags":[]}},"operatingSystem":{"ubuntu":{"distUpgradeOnBoot":false}}}}}`,
+			ExpectedResponse: `{"error":{"code":403,"message":"the size of digitalocean nodes can not be determined, so they can not be created in a project with a cpu or memory quota"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ProjectID:        test.GenDefaultProject().Name,
+			ClusterID:        test.GenDefaultCluster().Name,
+			ExistingKubermaticObjs: []runtime.Object{
+				genProjectWithCPUQuota("1"),
+				test.GenDefaultUser(),
+				test.GenDefaultOwnerBinding(),
+				test.GenDefaultPreset(),
+				genTestCluster(true),
+			},
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
 	}
 
 	for _, tc := range testcases {
@@ -1992,3 +2010,10 @@ func genUser(name, email string, isAdmin bool) *kubermaticv1.User {
 	user.Spec.IsAdmin = isAdmin
 	return user
 }
+
+func genProjectWithCPUQuota(cpu string) *kubermaticv1.Project {
+	project := test.GenDefaultProject()
+	quantity := resource.MustParse(cpu)
+	project.Spec.Quota = &kubermaticv1.ProjectQuota{CPU: &quantity}
+	return project
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+	"github.com/kubermatic/kubermatic/pkg/util/errors"
+)
+
+// CostEndpoint estimates the cost of the nodes of all clusters of the project based on the pricing catalog
+func CostEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, clusterProviderGetter provider.ClusterProviderGetter, seedsGetter provider.SeedsGetter, pricingCatalogProvider provider.PricingCatalogProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(common.GetProjectRq)
+		if !ok {
+			return nil, errors.NewBadRequest("invalid request")
+		}
+		if len(req.ProjectID) == 0 {
+			return nil, errors.NewBadRequest("the id of the project cannot be empty")
+		}
+
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		catalog, err := pricingCatalogProvider.Get()
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		cost, err := common.GetProjectCost(ctx, clusterProviderGetter, seedsGetter, project, &catalog.Spec)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		return cost, nil
+	}
+}
+
+// EstimateCostEndpoint estimates the cost of a cluster which is not created yet, e.g. while it is configured in the wizard
+func EstimateCostEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, seedsGetter provider.SeedsGetter, pricingCatalogProvider provider.PricingCatalogProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(estimateCostReq)
+		if !ok {
+			return nil, errors.NewBadRequest("invalid request")
+		}
+		if err := req.validate(); err != nil {
+			return nil, errors.NewBadRequest("%v", err)
+		}
+
+		if _, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		userInfo, err := userInfoGetter(ctx, req.ProjectID)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if _, _, err := provider.DatacenterFromSeedMap(userInfo, seedsGetter, req.Body.Datacenter); err != nil {
+			return nil, err
+		}
+		catalog, err := pricingCatalogProvider.Get()
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return common.EstimateClusterCost(&catalog.Spec, req.Body.Datacenter, req.Body.NodeDeployments), nil
+	}
+}
+
+// estimateCostReq defines HTTP request for estimateProjectCost
+// swagger:parameters estimateProjectCost
+type estimateCostReq struct {
+	common.ProjectReq
+	// in: body
+	Body apiv1.CostEstimate
+}
+
+func (r estimateCostReq) validate() error {
+	if len(r.ProjectID) == 0 {
+		return fmt.Errorf("the id of the project cannot be empty")
+	}
+	if len(r.Body.Datacenter) == 0 {
+		return fmt.Errorf("the datacenter cannot be empty")
+	}
+	for _, nd := range r.Body.NodeDeployments {
+		if nd.Spec.Replicas < 0 {
+			return fmt.Errorf("the replicas of node deployment %q cannot be negative", nd.Name)
+		}
+	}
+	return nil
+}
+
+// DecodeEstimateCostReq decodes an HTTP request into estimateCostReq
+func DecodeEstimateCostReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req estimateCostReq
+
+	pReq, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pReq.(common.ProjectReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, errors.NewBadRequest("unable to parse the input: %v", err)
+	}
+
+	return req, nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticapiv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/test"
+	"github.com/kubermatic/kubermatic/pkg/handler/test/hack"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestEstimateProjectCostEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		Name                      string
+		Body                      string
+		ProjectID                 string
+		ExpectedResponse          string
+		HTTPStatus                int
+		ExistingKubermaticObjects []runtime.Object
+		ExistingAPIUser           *apiv1.User
+	}{
+		{
+			Name:                      "scenario 1: the cost of the node deployments of a new cluster is estimated",
+			Body:                      `{"datacenter":"regular-do1","nodeDeployments":[{"name":"workers","spec":{"replicas":3,"template":{"cloud":{"digitalocean":{"size":"s-2vcpu-4gb"}},"operatingSystem":{"ubuntu":{}},"versions":{"kubelet":"1.17.4"}}}}]}`,
+			ProjectID:                 test.GenDefaultProject().Name,
+			ExpectedResponse:          `{"currency":"USD","hourlyCost":0.09,"monthlyCost":65.7,"nodeDeployments":[{"name":"workers","provider":"digitalocean","datacenter":"regular-do1","size":"s-2vcpu-4gb","replicas":3,"priced":true,"hourlyCostPerNode":0.03,"hourlyCost":0.09,"monthlyCost":65.7}]}`,
+			HTTPStatus:                http.StatusOK,
+			ExistingKubermaticObjects: append(test.GenDefaultKubermaticObjects(), genPricingCatalog()),
+			ExistingAPIUser:           test.GenDefaultAPIUser(),
+		},
+		{
+			Name:                      "scenario 2: sizes without a price make the estimate incomplete",
+			Body:                      `{"datacenter":"regular-do1","nodeDeployments":[{"name":"workers","spec":{"replicas":1,"template":{"cloud":{"digitalocean":{"size":"s-8vcpu-16gb"}},"operatingSystem":{"ubuntu":{}},"versions":{"kubelet":"1.17.4"}}}}]}`,
+			ProjectID:                 test.GenDefaultProject().Name,
+			ExpectedResponse:          `{"currency":"USD","hourlyCost":0,"monthlyCost":0,"incomplete":true,"nodeDeployments":[{"name":"workers","provider":"digitalocean","datacenter":"regular-do1","size":"s-8vcpu-16gb","replicas":1,"priced":false,"hourlyCostPerNode":0,"hourlyCost":0,"monthlyCost":0}]}`,
+			HTTPStatus:                http.StatusOK,
+			ExistingKubermaticObjects: append(test.GenDefaultKubermaticObjects(), genPricingCatalog()),
+			ExistingAPIUser:           test.GenDefaultAPIUser(),
+		},
+		{
+			Name:                      "scenario 3: the datacenter must exist",
+			Body:                      `{"datacenter":"unknown-dc","nodeDeployments":[]}`,
+			ProjectID:                 test.GenDefaultProject().Name,
+			ExpectedResponse:          `{"error":{"code":404,"message":"datacenter \"unknown-dc\" not found"}}`,
+			HTTPStatus:                http.StatusNotFound,
+			ExistingKubermaticObjects: append(test.GenDefaultKubermaticObjects(), genPricingCatalog()),
+			ExistingAPIUser:           test.GenDefaultAPIUser(),
+		},
+		{
+			Name:             "scenario 4: the user John can't estimate costs in Bob's project",
+			Body:             `{"datacenter":"regular-do1","nodeDeployments":[]}`,
+			ProjectID:        test.GenDefaultProject().Name,
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingKubermaticObjects: append(test.GenDefaultKubermaticObjects(),
+				test.GenUser("JohnID", "John", "john@acme.com")),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/projects/%s/costestimate", tc.ProjectID), strings.NewReader(tc.Body))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.ExistingAPIUser, []runtime.Object{}, tc.ExistingKubermaticObjects, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+			test.CompareWithResult(t, res, tc.ExpectedResponse)
+		})
+	}
+}
+
+func genPricingCatalog() *kubermaticapiv1.PricingCatalog {
+	return &kubermaticapiv1.PricingCatalog{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: kubermaticapiv1.GlobalPricingCatalogName,
+		},
+		Spec: kubermaticapiv1.PricingCatalogSpec{
+			Currency: "USD",
+			Prices: []kubermaticapiv1.MachinePrice{
+				{Provider: "digitalocean", Size: "s-2vcpu-4gb", HourlyCost: 0.03},
+			},
+		},
+	}
+}
@@ -26,13 +26,16 @@ import (
 	"github.com/go-kit/kit/endpoint"
 
 	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	"github.com/kubermatic/kubermatic/pkg/controller/master-controller-manager/rbac"
 	kubermaticapiv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/handler/middleware"
 	"github.com/kubermatic/kubermatic/pkg/handler/v1/common"
 	"github.com/kubermatic/kubermatic/pkg/provider"
 	"github.com/kubermatic/kubermatic/pkg/util/errors"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // CreateEndpoint defines an HTTP endpoint that creates a new project in the system
@@ -199,7 +202,7 @@ func deleteProjectByRegularUser(ctx context.Context, userInfoGetter provider.Use
 
 // UpdateEndpoint defines an HTTP endpoint that updates an existing project in the system
 // in the current implementation only project renaming is supported
-func UpdateEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, memberProvider provider.ProjectMemberProvider, userProvider provider.UserProvider, userInfoGetter provider.UserInfoGetter, clusterProviderGetter provider.ClusterProviderGetter, seedsGetter provider.SeedsGetter) endpoint.Endpoint {
+func UpdateEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, memberProvider provider.ProjectMemberProvider, userProvider provider.UserProvider, projectRoleProvider provider.ProjectRoleProvider, userInfoGetter provider.UserInfoGetter, clusterProviderGetter provider.ClusterProviderGetter, seedsGetter provider.SeedsGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(updateRq)
 		if !ok {
@@ -215,18 +218,52 @@ func UpdateEndpoint(projectProvider provider.ProjectProvider, privilegedProjectP
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
+		adminUserInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
 		kubermaticProject.Spec.Name = req.Body.Name
 		kubermaticProject.Labels = req.Body.Labels
 
-		project, err := updateProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, kubermaticProject)
-		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
+		// the quota is kept when it is not part of the request, only admins are allowed to change it
+		if req.Body.Quota != nil {
+			quota, err := common.ConvertExternalProjectQuotaToInternal(req.Body.Quota)
+			if err != nil {
+				return nil, errors.NewBadRequest(err.Error())
+			}
+			if !equality.Semantic.DeepEqual(quota, kubermaticProject.Spec.Quota) {
+				if !adminUserInfo.IsAdmin {
+					return nil, errors.New(http.StatusForbidden, fmt.Sprintf("forbidden: \"%s\" doesn't have admin rights", adminUserInfo.Email))
+				}
+				kubermaticProject.Spec.Quota = quota
+			}
 		}
 
-		adminUserInfo, err := userInfoGetter(ctx, "")
+		// the OIDC groups are kept when they are not part of the request, only owners are allowed to change them
+		// as they could otherwise grant more rights than they have
+		if req.Body.OIDCGroups != nil {
+			oidcGroups := common.ConvertExternalProjectOIDCGroupsToInternal(req.Body.OIDCGroups)
+			if !equality.Semantic.DeepEqual(oidcGroups, kubermaticProject.Spec.OIDCGroups) {
+				if err := validateOIDCGroups(oidcGroups, projectRoleProvider); err != nil {
+					return nil, common.KubernetesErrorToHTTPError(err)
+				}
+				userInfo, err := userInfoGetter(ctx, req.ProjectID)
+				if err != nil {
+					return nil, common.KubernetesErrorToHTTPError(err)
+				}
+				if !userInfo.IsAdmin && rbac.ExtractGroupPrefix(userInfo.Group) != rbac.OwnerGroupNamePrefix {
+					return nil, errors.New(http.StatusForbidden, fmt.Sprintf("forbidden: only owners can change the OIDC groups of the project %s", req.ProjectID))
+				}
+				kubermaticProject.Spec.OIDCGroups = oidcGroups
+			}
+		}
+
+		project, err := updateProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, kubermaticProject)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
+
 		projectOwners, err := common.GetOwnersForProject(adminUserInfo, kubermaticProject, memberProvider, userProvider)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
@@ -239,6 +276,29 @@ func UpdateEndpoint(projectProvider provider.ProjectProvider, privilegedProjectP
 	}
 }
 
+func validateOIDCGroups(oidcGroups []kubermaticapiv1.ProjectOIDCGroup, projectRoleProvider provider.ProjectRoleProvider) error {
+	seen := sets.NewString()
+	for _, oidcGroup := range oidcGroups {
+		if len(oidcGroup.Name) == 0 {
+			return errors.NewBadRequest("the name of an OIDC group can not be empty")
+		}
+		if seen.Has(oidcGroup.Name) {
+			return errors.NewBadRequest("the OIDC group %s must only be mapped once", oidcGroup.Name)
+		}
+		seen.Insert(oidcGroup.Name)
+		if rbac.IsBuiltInGroupPrefix(oidcGroup.Role) {
+			continue
+		}
+		if _, err := projectRoleProvider.Get(oidcGroup.Role); err != nil {
+			if kerrors.IsNotFound(err) {
+				return errors.NewBadRequest("invalid role %s for the OIDC group %s", oidcGroup.Role, oidcGroup.Name)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
 func updateProject(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, kubermaticProject *kubermaticapiv1.Project) (*kubermaticapiv1.Project, error) {
 	adminUserInfo, err := userInfoGetter(ctx, "")
 	if err != nil {
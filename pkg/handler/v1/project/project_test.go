@@ -28,7 +28,6 @@ import (
 
 	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
 	k8cuserclusterclient "github.com/kubermatic/kubermatic/pkg/cluster/client"
-	"github.com/kubermatic/kubermatic/pkg/controller/master-controller-manager/rbac"
 	kubermaticapiv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/handler/middleware"
 	"github.com/kubermatic/kubermatic/pkg/handler/test"
@@ -186,6 +185,100 @@ func TestRenameProjectEndpoint(t *testing.T) {
 			},
 			ExistingAPIUser: *test.GenAPIUser("John", "john@acme.com"),
 		},
+		{
+			Name:             "scenario 8: the owner John can't change the quota of the project",
+			Body:             `{"Name": "my-first-project", "quota": {"clusters": 10}}`,
+			ProjectToRename:  "my-first-project-ID",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't have admin rights"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingKubermaticObjects: []runtime.Object{
+				test.GenProject("my-first-project", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenUser("JohnID", "John", "john@acme.com"),
+				test.GenBinding("my-first-project-ID", "john@acme.com", "owners"),
+			},
+			ExistingAPIUser: *test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:             "scenario 9: the admin Bob can change the quota of John's project",
+			Body:             `{"Name": "my-first-project", "quota": {"clusters": 10, "nodes": 50, "cpu": "100", "memory": "200Gi"}}`,
+			ProjectToRename:  "my-first-project-ID",
+			ExpectedResponse: `{"id":"my-first-project-ID","name":"my-first-project","creationTimestamp":"2013-02-03T19:54:00Z","status":"Active","owners":[{"name":"John","creationTimestamp":"0001-01-01T00:00:00Z","email":"john@acme.com"}],"quota":{"clusters":10,"nodes":50,"cpu":"100","memory":"200Gi"}}`,
+			HTTPStatus:       http.StatusOK,
+			ExistingKubermaticObjects: []runtime.Object{
+				test.GenProject("my-first-project", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenUser("JohnID", "John", "john@acme.com"),
+				genUser("Bob", "bob@acme.com", true),
+				test.GenBinding("my-first-project-ID", "john@acme.com", "owners"),
+			},
+			ExistingAPIUser: *test.GenDefaultAPIUser(),
+		},
+		{
+			Name:             "scenario 10: an invalid quota is rejected",
+			Body:             `{"Name": "my-first-project", "quota": {"memory": "a lot"}}`,
+			ProjectToRename:  "my-first-project-ID",
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid memory quota \"a lot\": quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingKubermaticObjects: []runtime.Object{
+				test.GenProject("my-first-project", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenUser("JohnID", "John", "john@acme.com"),
+				genUser("Bob", "bob@acme.com", true),
+				test.GenBinding("my-first-project-ID", "john@acme.com", "owners"),
+			},
+			ExistingAPIUser: *test.GenDefaultAPIUser(),
+		},
+		{
+			Name:             "scenario 11: the owner John can map OIDC groups to roles of the project",
+			Body:             `{"Name": "my-first-project", "oidcGroups": [{"name": "developers", "role": "editors"}, {"name": "oncall", "role": "operators"}]}`,
+			ProjectToRename:  "my-first-project-ID",
+			ExpectedResponse: `{"id":"my-first-project-ID","name":"my-first-project","creationTimestamp":"2013-02-03T19:54:00Z","status":"Active","owners":[{"name":"John","creationTimestamp":"0001-01-01T00:00:00Z","email":"john@acme.com"}],"oidcGroups":[{"name":"developers","role":"editors"},{"name":"oncall","role":"operators"}]}`,
+			HTTPStatus:       http.StatusOK,
+			ExistingKubermaticObjects: []runtime.Object{
+				test.GenProject("my-first-project", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenUser("JohnID", "John", "john@acme.com"),
+				test.GenBinding("my-first-project-ID", "john@acme.com", "owners"),
+			},
+			ExistingAPIUser: *test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:             "scenario 12: the editor John can't change the OIDC groups of the project",
+			Body:             `{"Name": "my-first-project", "oidcGroups": [{"name": "developers", "role": "owners"}]}`,
+			ProjectToRename:  "my-first-project-ID",
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: only owners can change the OIDC groups of the project my-first-project-ID"}}`,
+			HTTPStatus:       http.StatusForbidden,
+			ExistingKubermaticObjects: []runtime.Object{
+				test.GenProject("my-first-project", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenUser("JohnID", "John", "john@acme.com"),
+				test.GenBinding("my-first-project-ID", "john@acme.com", "editors"),
+			},
+			ExistingAPIUser: *test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:             "scenario 13: OIDC groups can't be mapped to unknown roles",
+			Body:             `{"Name": "my-first-project", "oidcGroups": [{"name": "developers", "role": "superusers"}]}`,
+			ProjectToRename:  "my-first-project-ID",
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid role superusers for the OIDC group developers"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingKubermaticObjects: []runtime.Object{
+				test.GenProject("my-first-project", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenUser("JohnID", "John", "john@acme.com"),
+				test.GenBinding("my-first-project-ID", "john@acme.com", "owners"),
+			},
+			ExistingAPIUser: *test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			Name:             "scenario 14: the admin Bob can remove the quota of John's project",
+			Body:             `{"Name": "my-first-project", "quota": {}}`,
+			ProjectToRename:  "my-first-project-ID",
+			ExpectedResponse: `{"id":"my-first-project-ID","name":"my-first-project","creationTimestamp":"2013-02-03T19:54:00Z","status":"Active","owners":[{"name":"John","creationTimestamp":"0001-01-01T00:00:00Z","email":"john@acme.com"}]}`,
+			HTTPStatus:       http.StatusOK,
+			ExistingKubermaticObjects: []runtime.Object{
+				genProjectWithQuota("my-first-project"),
+				test.GenUser("JohnID", "John", "john@acme.com"),
+				genUser("Bob", "bob@acme.com", true),
+				test.GenBinding("my-first-project-ID", "john@acme.com", "owners"),
+			},
+			ExistingAPIUser: *test.GenDefaultAPIUser(),
+		},
 	}
 
 	for _, tc := range testcases {
@@ -485,7 +578,7 @@ func TestListProjectMethod(t *testing.T) {
 				fakeImpersonationClient,
 				fUserClusterConnection,
 				"",
-				rbac.ExtractGroupPrefix,
+				kubernetes.NewProjectRoleProvider(context.Background(), fakeClient).UserClusterGroups,
 				fakeClient,
 				kubernetesClient,
 				false,
@@ -777,3 +870,10 @@ func genUser(name, email string, isAdmin bool) *kubermaticapiv1.User {
 	user.Spec.IsAdmin = isAdmin
 	return user
 }
+
+func genProjectWithQuota(name string) *kubermaticapiv1.Project {
+	project := test.GenProject(name, kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp())
+	clusters := int64(10)
+	project.Spec.Quota = &kubermaticapiv1.ProjectQuota{Clusters: &clusters}
+	return project
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projectrole
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gorilla/mux"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	"github.com/kubermatic/kubermatic/pkg/controller/master-controller-manager/rbac"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/v1/common"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+	k8cerrors "github.com/kubermatic/kubermatic/pkg/util/errors"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ListEndpoint returns the roles members of projects can be assigned to
+func ListEndpoint(projectRoleProvider provider.ProjectRoleProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		roles, err := projectRoleProvider.List()
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		result := []apiv1.ProjectRole{}
+		for _, role := range roles {
+			result = append(result, convertInternalProjectRoleToExternal(role))
+		}
+		return result, nil
+	}
+}
+
+// GetEndpoint returns the project role
+func GetEndpoint(projectRoleProvider provider.ProjectRoleProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(projectRoleReq)
+		if !ok {
+			return nil, k8cerrors.NewBadRequest("invalid request")
+		}
+		role, err := projectRoleProvider.Get(req.Name)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertInternalProjectRoleToExternal(*role), nil
+	}
+}
+
+// CreateEndpoint creates a custom project role
+func CreateEndpoint(userInfoGetter provider.UserInfoGetter, projectRoleProvider provider.ProjectRoleProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(createProjectRoleReq)
+		if !ok {
+			return nil, k8cerrors.NewBadRequest("invalid request")
+		}
+		if err := req.Validate(); err != nil {
+			return nil, k8cerrors.NewBadRequest(err.Error())
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		role, err := projectRoleProvider.Create(userInfo, convertExternalProjectRoleToInternal(req.Body))
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertInternalProjectRoleToExternal(*role), nil
+	}
+}
+
+// UpdateEndpoint updates a custom project role
+func UpdateEndpoint(userInfoGetter provider.UserInfoGetter, projectRoleProvider provider.ProjectRoleProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(updateProjectRoleReq)
+		if !ok {
+			return nil, k8cerrors.NewBadRequest("invalid request")
+		}
+		if err := req.Validate(); err != nil {
+			return nil, k8cerrors.NewBadRequest(err.Error())
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		role, err := projectRoleProvider.Update(userInfo, convertExternalProjectRoleToInternal(req.Body))
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return convertInternalProjectRoleToExternal(*role), nil
+	}
+}
+
+// DeleteEndpoint deletes a custom project role, roles which still have members can't be deleted
+func DeleteEndpoint(userInfoGetter provider.UserInfoGetter, projectRoleProvider provider.ProjectRoleProvider) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(projectRoleReq)
+		if !ok {
+			return nil, k8cerrors.NewBadRequest("invalid request")
+		}
+		userInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		if err := projectRoleProvider.Delete(userInfo, req.Name); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		return nil, nil
+	}
+}
+
+// projectRoleReq defines HTTP request for getProjectRole and deleteProjectRole
+// swagger:parameters getProjectRole deleteProjectRole
+type projectRoleReq struct {
+	// in: path
+	// required: true
+	Name string `json:"name"`
+}
+
+// createProjectRoleReq defines HTTP request for createProjectRole
+// swagger:parameters createProjectRole
+type createProjectRoleReq struct {
+	// in: body
+	Body apiv1.ProjectRole
+}
+
+// Validate validates createProjectRoleReq request
+func (r createProjectRoleReq) Validate() error {
+	if err := rbac.ValidateProjectRoleName(r.Body.Name); err != nil {
+		return err
+	}
+	return validateRules(r.Body.Rules)
+}
+
+// updateProjectRoleReq defines HTTP request for updateProjectRole
+// swagger:parameters updateProjectRole
+type updateProjectRoleReq struct {
+	projectRoleReq
+	// in: body
+	Body apiv1.ProjectRole
+}
+
+// Validate validates updateProjectRoleReq request
+func (r updateProjectRoleReq) Validate() error {
+	if r.Name != r.Body.Name {
+		return fmt.Errorf("project role name mismatch, you requested to update ProjectRole = %s but body contains ProjectRole = %s", r.Name, r.Body.Name)
+	}
+	return validateRules(r.Body.Rules)
+}
+
+func validateRules(rules []kubermaticv1.ProjectRoleRule) error {
+	seen := map[kubermaticv1.ProjectRoleResource]bool{}
+	for _, rule := range rules {
+		if !isValidResource(rule.Resource) {
+			return fmt.Errorf("invalid resource %q, must be one of %v", rule.Resource, kubermaticv1.AllProjectRoleResources)
+		}
+		if seen[rule.Resource] {
+			return fmt.Errorf("resource %q must only be used in a single rule", rule.Resource)
+		}
+		seen[rule.Resource] = true
+		for _, verb := range rule.Verbs {
+			if !isValidVerb(verb) {
+				return fmt.Errorf("invalid verb %q for resource %q, must be one of %v", verb, rule.Resource, kubermaticv1.AllProjectRoleVerbs)
+			}
+		}
+	}
+	return nil
+}
+
+func isValidResource(resource kubermaticv1.ProjectRoleResource) bool {
+	for _, r := range kubermaticv1.AllProjectRoleResources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidVerb(verb kubermaticv1.ProjectRoleVerb) bool {
+	for _, v := range kubermaticv1.AllProjectRoleVerbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
+
+func DecodeProjectRoleReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req projectRoleReq
+	name := mux.Vars(r)["name"]
+	if name == "" {
+		return nil, fmt.Errorf("'name' parameter is required but was not provided")
+	}
+	req.Name = name
+
+	return req, nil
+}
+
+func DecodeCreateProjectRoleReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req createProjectRoleReq
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, k8cerrors.NewBadRequest("unable to parse the input, err = %v", err.Error())
+	}
+
+	return req, nil
+}
+
+func DecodeUpdateProjectRoleReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req updateProjectRoleReq
+	roleReq, err := DecodeProjectRoleReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.projectRoleReq = roleReq.(projectRoleReq)
+
+	if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+		return nil, k8cerrors.NewBadRequest("unable to parse the input, err = %v", err.Error())
+	}
+
+	return req, nil
+}
+
+func convertInternalProjectRoleToExternal(role kubermaticv1.ProjectRole) apiv1.ProjectRole {
+	result := apiv1.ProjectRole{
+		Name:              role.Name,
+		HumanReadableName: role.Spec.HumanReadableName,
+		Rules:             role.Spec.Rules,
+	}
+	for _, defaultRole := range rbac.DefaultProjectRoles {
+		if defaultRole.Name == role.Name {
+			result.Default = true
+		}
+	}
+	if result.Rules == nil {
+		result.Rules = []kubermaticv1.ProjectRoleRule{}
+	}
+	return result
+}
+
+func convertExternalProjectRoleToInternal(role apiv1.ProjectRole) *kubermaticv1.ProjectRole {
+	return &kubermaticv1.ProjectRole{
+		ObjectMeta: v1.ObjectMeta{Name: role.Name},
+		Spec: kubermaticv1.ProjectRoleSpec{
+			HumanReadableName: role.HumanReadableName,
+			Rules:             role.Rules,
+		},
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projectrole_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/handler/test"
+	"github.com/kubermatic/kubermatic/pkg/handler/test/hack"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const operatorsResponse = `{"name":"operators","humanReadableName":"Cluster Operator","default":true,"rules":[{"resource":"clusters","verbs":["get","update"]},{"resource":"nodedeployments","verbs":["get","create","update","delete"]},{"resource":"sshkeys","verbs":["get"]},{"resource":"addons","verbs":["get"]}]}`
+
+func TestListProjectRolesEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		name                   string
+		expectedResponse       string
+		httpStatus             int
+		existingKubermaticObjs []runtime.Object
+	}{
+		{
+			name:                   "scenario 1: the default roles are always listed",
+			expectedResponse:       `[` + operatorsResponse + `]`,
+			httpStatus:             http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{test.GenDefaultUser()},
+		},
+		{
+			name:             "scenario 2: custom roles are listed after the default roles",
+			expectedResponse: `[` + operatorsResponse + `,{"name":"auditors","rules":[{"resource":"members","verbs":["get"]}]}]`,
+			httpStatus:       http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{
+				test.GenDefaultUser(),
+				genProjectRole("auditors", kubermaticv1.ProjectRoleRule{Resource: kubermaticv1.ProjectRoleResourceMembers, Verbs: []kubermaticv1.ProjectRoleVerb{kubermaticv1.ProjectRoleVerbGet}}),
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/projectroles", strings.NewReader(""))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*test.GenDefaultAPIUser(), nil, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+			test.CompareWithResult(t, res, tc.expectedResponse)
+		})
+	}
+}
+
+func TestCreateProjectRoleEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		name                   string
+		body                   string
+		expectedResponse       string
+		httpStatus             int
+		existingAPIUser        *apiv1.User
+		existingKubermaticObjs []runtime.Object
+	}{
+		{
+			name:                   "scenario 1: admin can create a project role",
+			body:                   `{"name":"auditors","humanReadableName":"Auditor","rules":[{"resource":"clusters","verbs":["get"]}]}`,
+			expectedResponse:       `{"name":"auditors","humanReadableName":"Auditor","rules":[{"resource":"clusters","verbs":["get"]}]}`,
+			httpStatus:             http.StatusCreated,
+			existingAPIUser:        test.GenDefaultAdminAPIUser(),
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true)},
+		},
+		{
+			name:                   "scenario 2: regular user can't create a project role",
+			body:                   `{"name":"auditors","rules":[{"resource":"clusters","verbs":["get"]}]}`,
+			expectedResponse:       `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't have admin rights"}}`,
+			httpStatus:             http.StatusForbidden,
+			existingAPIUser:        test.GenDefaultAPIUser(),
+			existingKubermaticObjs: []runtime.Object{test.GenDefaultUser()},
+		},
+		{
+			name:                   "scenario 3: the name of a project role must not start with a built-in group",
+			body:                   `{"name":"viewersplus","rules":[{"resource":"clusters","verbs":["get"]}]}`,
+			expectedResponse:       `{"error":{"code":400,"message":"invalid name \"viewersplus\", must not start with the built-in group \"viewers\""}}`,
+			httpStatus:             http.StatusBadRequest,
+			existingAPIUser:        test.GenDefaultAdminAPIUser(),
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true)},
+		},
+		{
+			name:                   "scenario 4: rules must use known verbs",
+			body:                   `{"name":"auditors","rules":[{"resource":"clusters","verbs":["scale"]}]}`,
+			expectedResponse:       `{"error":{"code":400,"message":"invalid verb \"scale\" for resource \"clusters\", must be one of [get create update delete]"}}`,
+			httpStatus:             http.StatusBadRequest,
+			existingAPIUser:        test.GenDefaultAdminAPIUser(),
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true)},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v1/admin/projectroles", strings.NewReader(tc.body))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.existingAPIUser, nil, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+			test.CompareWithResult(t, res, tc.expectedResponse)
+		})
+	}
+}
+
+func TestDeleteProjectRoleEndpoint(t *testing.T) {
+	t.Parallel()
+	auditors := genProjectRole("auditors", kubermaticv1.ProjectRoleRule{Resource: kubermaticv1.ProjectRoleResourceClusters, Verbs: []kubermaticv1.ProjectRoleVerb{kubermaticv1.ProjectRoleVerbGet}})
+	testcases := []struct {
+		name                   string
+		roleName               string
+		httpStatus             int
+		existingKubermaticObjs []runtime.Object
+	}{
+		{
+			name:                   "scenario 1: admin can delete an unused project role",
+			roleName:               "auditors",
+			httpStatus:             http.StatusOK,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true), auditors},
+		},
+		{
+			name:       "scenario 2: a project role which is assigned to members can't be deleted",
+			roleName:   "auditors",
+			httpStatus: http.StatusConflict,
+			existingKubermaticObjs: []runtime.Object{
+				genUser("Bob", "bob@acme.com", true),
+				auditors,
+				test.GenBinding("my-first-project-ID", "john@acme.com", "auditors"),
+			},
+		},
+		{
+			name:                   "scenario 3: the default project roles can't be deleted",
+			roleName:               "operators",
+			httpStatus:             http.StatusBadRequest,
+			existingKubermaticObjs: []runtime.Object{genUser("Bob", "bob@acme.com", true)},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("DELETE", "/api/v1/admin/projectroles/"+tc.roleName, strings.NewReader(""))
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*test.GenDefaultAdminAPIUser(), nil, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+		})
+	}
+}
+
+func genUser(name, email string, isAdmin bool) *kubermaticv1.User {
+	user := test.GenUser("", name, email)
+	user.Spec.IsAdmin = isAdmin
+	return user
+}
+
+func genProjectRole(name string, rules ...kubermaticv1.ProjectRoleRule) *kubermaticv1.ProjectRole {
+	return &kubermaticv1.ProjectRole{
+		ObjectMeta: v1.ObjectMeta{Name: name},
+		Spec:       kubermaticv1.ProjectRoleSpec{Rules: rules},
+	}
+}
@@ -64,6 +64,10 @@ func CreateEndpoint(projectProvider provider.ProjectProvider, privilegedProjectP
 			return nil, errors.NewAlreadyExists("service account", saFromRequest.Name)
 		}
 
+		if err := common.VerifyGroupAssignment(ctx, userInfoGetter, project.Name, saFromRequest.Group); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
 		sa, err := createSA(ctx, serviceAccountProvider, privilegedServiceAccount, userInfoGetter, project, saFromRequest)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
@@ -204,6 +208,9 @@ func UpdateEndpoint(projectProvider provider.ProjectProvider, privilegedProjectP
 
 		newGroup := rbac.GenerateActualGroupNameFor(project.Name, saFromRequest.Group)
 		if newGroup != currentGroup {
+			if err := common.VerifyGroupAssignment(ctx, userInfoGetter, project.Name, saFromRequest.Group, rbac.ExtractGroupPrefix(currentGroup)); err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
 			if sa.Labels == nil {
 				sa.Labels = map[string]string{}
 			}
@@ -31,6 +31,7 @@ import (
 	"github.com/kubermatic/kubermatic/pkg/handler/test/hack"
 	serviceaccount "github.com/kubermatic/kubermatic/pkg/provider/kubernetes"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -159,6 +160,34 @@ func TestCreateServiceAccountProject(t *testing.T) {
 			projectToSync:    "plan9-ID",
 			expectedResponse: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't belong to the given project = plan9-ID"}}`,
 		},
+		{
+			name:       "scenario 7: john, who can manage the service accounts of the plan9 project, can not create a service account for the editors group",
+			body:       `{"name":"test", "group":"editors"}`,
+			httpStatus: http.StatusForbidden,
+			existingKubermaticObjs: []runtime.Object{
+				/*add projects*/
+				test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				/*add project roles*/
+				&kubermaticapiv1.ProjectRole{
+					ObjectMeta: metav1.ObjectMeta{Name: "managers"},
+					Spec: kubermaticapiv1.ProjectRoleSpec{
+						Rules: []kubermaticapiv1.ProjectRoleRule{
+							{
+								Resource: kubermaticapiv1.ProjectRoleResourceServiceAccounts,
+								Verbs:    kubermaticapiv1.AllProjectRoleVerbs,
+							},
+						},
+					},
+				},
+				/*add bindings*/
+				test.GenBinding("plan9-ID", "john@acme.com", "managers"),
+				/*add users*/
+				test.GenUser("", "john", "john@acme.com"),
+			},
+			existingAPIUser:  *test.GenAPIUser("john", "john@acme.com"),
+			projectToSync:    "plan9-ID",
+			expectedResponse: `{"error":{"code":403,"message":"forbidden: only owners can assign the group editors in the project plan9-ID"}}`,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -22,6 +22,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/go-kit/kit/endpoint"
@@ -38,6 +41,7 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // CreateTokenEndpoint creates a token for the given service account
@@ -69,7 +73,11 @@ func CreateTokenEndpoint(projectProvider provider.ProjectProvider, privilegedPro
 
 		tokenID := rand.String(10)
 
-		token, err := tokenGenerator.Generate(serviceaccount.Claims(sa.Spec.Email, project.Name, tokenID))
+		options := serviceaccount.TokenOptions{
+			Expiry: req.Body.Expiry.Time,
+			Scopes: convertExternalScopesToInternal(req.Body.Scopes),
+		}
+		token, err := tokenGenerator.Generate(serviceaccount.ClaimsWithOptions(sa.Spec.Email, project.Name, tokenID, options))
 		if err != nil {
 			return nil, errors.New(http.StatusInternalServerError, "can not generate token data")
 		}
@@ -188,7 +196,7 @@ func UpdateTokenEndpoint(projectProvider provider.ProjectProvider, privilegedPro
 			return nil, errors.NewBadRequest(err.Error())
 		}
 
-		secret, err := updateEndpoint(ctx, projectProvider, privilegedProjectProvider, serviceAccountProvider, privilegedServiceAccount, serviceAccountTokenProvider, privilegedServiceAccountTokenProvider, userInfoGetter, tokenGenerator, req.ProjectID, req.ServiceAccountID, req.TokenID, req.Body.Name, true)
+		secret, err := updateEndpoint(ctx, projectProvider, privilegedProjectProvider, serviceAccountProvider, privilegedServiceAccount, serviceAccountTokenProvider, privilegedServiceAccountTokenProvider, userInfoGetter, tokenAuthenticator, tokenGenerator, req.ProjectID, req.ServiceAccountID, req.TokenID, req.Body.Name, true, 0)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
@@ -219,7 +227,7 @@ func PatchTokenEndpoint(projectProvider provider.ProjectProvider, privilegedProj
 			return nil, errors.NewBadRequest("new name can not be empty")
 		}
 
-		secret, err := updateEndpoint(ctx, projectProvider, privilegedProjectProvider, serviceAccountProvider, privilegedServiceAccount, serviceAccountTokenProvider, privilegedServiceAccountTokenProvider, userInfoGetter, tokenGenerator, req.ProjectID, req.ServiceAccountID, req.TokenID, tokenReq.Name, false)
+		secret, err := updateEndpoint(ctx, projectProvider, privilegedProjectProvider, serviceAccountProvider, privilegedServiceAccount, serviceAccountTokenProvider, privilegedServiceAccountTokenProvider, userInfoGetter, tokenAuthenticator, tokenGenerator, req.ProjectID, req.ServiceAccountID, req.TokenID, tokenReq.Name, false, 0)
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
@@ -233,6 +241,34 @@ func PatchTokenEndpoint(projectProvider provider.ProjectProvider, privilegedProj
 	}
 }
 
+// RotateTokenEndpoint regenerates the token for the given service account, the previous token is still accepted during the grace period
+func RotateTokenEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, serviceAccountProvider provider.ServiceAccountProvider, privilegedServiceAccount provider.PrivilegedServiceAccountProvider, serviceAccountTokenProvider provider.ServiceAccountTokenProvider, privilegedServiceAccountTokenProvider provider.PrivilegedServiceAccountTokenProvider, tokenAuthenticator serviceaccount.TokenAuthenticator, tokenGenerator serviceaccount.TokenGenerator, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(rotateTokenReq)
+		err := req.Validate()
+		if err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+
+		gracePeriod := serviceaccount.DefaultRotationGracePeriod
+		if req.GracePeriodSeconds != nil {
+			gracePeriod = time.Duration(*req.GracePeriodSeconds) * time.Second
+		}
+
+		secret, err := updateEndpoint(ctx, projectProvider, privilegedProjectProvider, serviceAccountProvider, privilegedServiceAccount, serviceAccountTokenProvider, privilegedServiceAccountTokenProvider, userInfoGetter, tokenAuthenticator, tokenGenerator, req.ProjectID, req.ServiceAccountID, req.TokenID, "", true, gracePeriod)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		externalToken, err := convertInternalTokenToPrivateExternal(secret, tokenAuthenticator)
+		if err != nil {
+			return nil, errors.New(http.StatusInternalServerError, err.Error())
+		}
+
+		return externalToken, nil
+	}
+}
+
 // DeleteTokenEndpoint deletes the token from service account
 func DeleteTokenEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, serviceAccountProvider provider.ServiceAccountProvider, privilegedServiceAccount provider.PrivilegedServiceAccountProvider, serviceAccountTokenProvider provider.ServiceAccountTokenProvider, privilegedServiceAccountTokenProvider provider.PrivilegedServiceAccountTokenProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
@@ -292,8 +328,8 @@ func getSAToken(ctx context.Context, userInfoGetter provider.UserInfoGetter, ser
 }
 
 func updateEndpoint(ctx context.Context, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, serviceAccountProvider provider.ServiceAccountProvider,
-	privilegedServiceAccount provider.PrivilegedServiceAccountProvider, serviceAccountTokenProvider provider.ServiceAccountTokenProvider, privilegedServiceAccountTokenProvider provider.PrivilegedServiceAccountTokenProvider, userInfoGetter provider.UserInfoGetter, tokenGenerator serviceaccount.TokenGenerator,
-	projectID, saID, tokenID, newName string, regenerateToken bool) (*v1.Secret, error) {
+	privilegedServiceAccount provider.PrivilegedServiceAccountProvider, serviceAccountTokenProvider provider.ServiceAccountTokenProvider, privilegedServiceAccountTokenProvider provider.PrivilegedServiceAccountTokenProvider, userInfoGetter provider.UserInfoGetter, tokenAuthenticator serviceaccount.TokenAuthenticator, tokenGenerator serviceaccount.TokenGenerator,
+	projectID, saID, tokenID, newName string, regenerateToken bool, gracePeriod time.Duration) (*v1.Secret, error) {
 
 	project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, projectID, nil)
 	if err != nil {
@@ -313,6 +349,10 @@ func updateEndpoint(ctx context.Context, projectProvider provider.ProjectProvide
 	if !ok {
 		return nil, fmt.Errorf("can not find token name in secret %s", existingSecret.Name)
 	}
+	// the name is kept when the token only gets rotated
+	if newName == "" {
+		newName = existingName
+	}
 
 	if newName == existingName && !regenerateToken {
 		return existingSecret, nil
@@ -331,12 +371,29 @@ func updateEndpoint(ctx context.Context, projectProvider provider.ProjectProvide
 	}
 
 	if regenerateToken {
-		token, err := tokenGenerator.Generate(serviceaccount.Claims(sa.Spec.Email, project.Name, existingSecret.Name))
+		existingToken := existingSecret.Data[serviceaccount.TokenKey]
+		// the new token has the scopes and the lifetime of the existing one
+		existingClaims, existingCustomClaims, err := tokenAuthenticator.Parse(string(existingToken))
+		if err != nil {
+			return nil, fmt.Errorf("can not parse the existing token: %v", err)
+		}
+		options := serviceaccount.TokenOptions{Scopes: existingCustomClaims.Scopes}
+		if existingClaims.Expiry != 0 && existingClaims.IssuedAt != 0 {
+			options.Expiry = serviceaccount.Now().Add(existingClaims.Expiry.Time().Sub(existingClaims.IssuedAt.Time()))
+		}
+
+		token, err := tokenGenerator.Generate(serviceaccount.ClaimsWithOptions(sa.Spec.Email, project.Name, existingSecret.Name, options))
 		if err != nil {
 			return nil, fmt.Errorf("can not generate token data")
 		}
 
-		existingSecret.Data["token"] = []byte(token)
+		existingSecret.Data[serviceaccount.TokenKey] = []byte(token)
+		delete(existingSecret.Data, serviceaccount.PreviousTokenKey)
+		delete(existingSecret.Data, serviceaccount.PreviousTokenExpiryKey)
+		if gracePeriod > 0 {
+			existingSecret.Data[serviceaccount.PreviousTokenKey] = existingToken
+			existingSecret.Data[serviceaccount.PreviousTokenExpiryKey] = []byte(serviceaccount.Now().Add(gracePeriod).UTC().Format(time.RFC3339))
+		}
 	}
 
 	secret, err := updateSAToken(ctx, userInfoGetter, serviceAccountTokenProvider, privilegedServiceAccountTokenProvider, existingSecret, projectID)
@@ -402,6 +459,16 @@ type patchTokenReq struct {
 	Body []byte
 }
 
+// rotateTokenReq defines HTTP request for rotateServiceAccountToken
+// swagger:parameters rotateServiceAccountToken
+type rotateTokenReq struct {
+	commonTokenReq
+	tokenIDReq
+	// GracePeriodSeconds is the time in seconds the previous token is still accepted, it defaults to one hour
+	// in: query
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+}
+
 // deleteTokenReq defines HTTP request for deleteServiceAccountToken
 // swagger:parameters deleteServiceAccountToken
 type deleteTokenReq struct {
@@ -417,10 +484,33 @@ func (r addTokenReq) Validate() error {
 	if utf8.RuneCountInString(r.Body.Name) > 50 {
 		return fmt.Errorf("the name is too long, max 50 chars")
 	}
+	if !r.Body.Expiry.IsZero() {
+		if !r.Body.Expiry.After(serviceaccount.Now()) {
+			return fmt.Errorf("the expiry must be in the future")
+		}
+		if r.Body.Expiry.After(serviceaccount.DefaultExpiry()) {
+			return fmt.Errorf("the expiry must not be more than three years in the future")
+		}
+	}
+	for _, scope := range r.Body.Scopes {
+		if len(scope.Methods) == 0 && len(scope.Endpoint) == 0 {
+			return fmt.Errorf("a scope must restrict the methods or the endpoint")
+		}
+		for _, method := range scope.Methods {
+			if !allowedScopeMethods.Has(strings.ToUpper(method)) {
+				return fmt.Errorf("invalid method %q, must be one of %v", method, allowedScopeMethods.List())
+			}
+		}
+		if len(scope.Endpoint) > 0 && !strings.HasPrefix(scope.Endpoint, "/api/") {
+			return fmt.Errorf("invalid endpoint %q, must start with /api/", scope.Endpoint)
+		}
+	}
 
 	return nil
 }
 
+var allowedScopeMethods = sets.NewString(http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete)
+
 // Validate validates commonTokenReq request
 func (r commonTokenReq) Validate() error {
 	if len(r.ProjectID) == 0 || len(r.ServiceAccountID) == 0 {
@@ -463,6 +553,24 @@ func (r patchTokenReq) Validate() error {
 	return nil
 }
 
+// Validate validates rotateTokenReq request
+func (r rotateTokenReq) Validate() error {
+	if err := r.commonTokenReq.Validate(); err != nil {
+		return err
+	}
+	if len(r.TokenID) == 0 {
+		return fmt.Errorf("token ID cannot be empty")
+	}
+	if r.GracePeriodSeconds != nil {
+		gracePeriod := time.Duration(*r.GracePeriodSeconds) * time.Second
+		if gracePeriod < 0 || gracePeriod > serviceaccount.MaxRotationGracePeriod {
+			return fmt.Errorf("the grace period must be between 0 and %d seconds", int64(serviceaccount.MaxRotationGracePeriod.Seconds()))
+		}
+	}
+
+	return nil
+}
+
 // Validate validates updateTokenReq request
 func (r deleteTokenReq) Validate() error {
 	if err := r.commonTokenReq.Validate(); err != nil {
@@ -567,6 +675,33 @@ func DecodePatchTokenReq(c context.Context, r *http.Request) (interface{}, error
 	return req, nil
 }
 
+// DecodeRotateTokenReq  decodes an HTTP request into rotateTokenReq
+func DecodeRotateTokenReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req rotateTokenReq
+
+	rawReq, err := DecodeTokenReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.commonTokenReq = rawReq.(commonTokenReq)
+
+	tokenID, err := decodeTokenIDReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.TokenID = tokenID.TokenID
+
+	if rawGracePeriod := r.URL.Query().Get("gracePeriodSeconds"); rawGracePeriod != "" {
+		gracePeriod, err := strconv.ParseInt(rawGracePeriod, 10, 64)
+		if err != nil {
+			return nil, errors.NewBadRequest("invalid value for gracePeriodSeconds: %v", err)
+		}
+		req.GracePeriodSeconds = &gracePeriod
+	}
+
+	return req, nil
+}
+
 // DecodeDeleteTokenReq  decodes an HTTP request into deleteTokenReq
 func DecodeDeleteTokenReq(c context.Context, r *http.Request) (interface{}, error) {
 	var req deleteTokenReq
@@ -618,17 +753,19 @@ func convertInternalTokenToPrivateExternal(internal *v1.Secret, authenticator se
 
 func convertInternalTokenToPublicExternal(internal *v1.Secret, authenticator serviceaccount.TokenAuthenticator) (*apiv1.PublicServiceAccountToken, error) {
 	externalToken := &apiv1.PublicServiceAccountToken{}
-	token, ok := internal.Data["token"]
+	token, ok := internal.Data[serviceaccount.TokenKey]
 	if !ok {
 		return nil, fmt.Errorf("can not find token data")
 	}
 
-	publicClaim, _, err := authenticator.Authenticate(string(token))
+	// expired tokens are listed as well, so the token is only parsed
+	publicClaim, customClaim, err := authenticator.Parse(string(token))
 	if err != nil {
 		return nil, fmt.Errorf("unable to create a token for %s due to %v", internal.Name, err)
 	}
 
 	externalToken.Expiry = apiv1.NewTime(publicClaim.Expiry.Time())
+	externalToken.Scopes = convertInternalScopesToExternal(customClaim.Scopes)
 	externalToken.ID = internal.Name
 	name, ok := internal.Labels["name"]
 	if !ok {
@@ -639,3 +776,19 @@ func convertInternalTokenToPublicExternal(internal *v1.Secret, authenticator ser
 	externalToken.CreationTimestamp = apiv1.NewTime(internal.CreationTimestamp.Time)
 	return externalToken, nil
 }
+
+func convertExternalScopesToInternal(scopes []apiv1.ServiceAccountTokenScope) []serviceaccount.TokenScope {
+	var result []serviceaccount.TokenScope
+	for _, scope := range scopes {
+		result = append(result, serviceaccount.TokenScope{Methods: scope.Methods, Endpoint: scope.Endpoint})
+	}
+	return result
+}
+
+func convertInternalScopesToExternal(scopes []serviceaccount.TokenScope) []apiv1.ServiceAccountTokenScope {
+	var result []apiv1.ServiceAccountTokenScope
+	for _, scope := range scopes {
+		result = append(result, apiv1.ServiceAccountTokenScope{Methods: scope.Methods, Endpoint: scope.Endpoint})
+	}
+	return result
+}
@@ -29,6 +29,7 @@ import (
 	kubermaticapiv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/handler/test"
 	"github.com/kubermatic/kubermatic/pkg/handler/test/hack"
+	"github.com/kubermatic/kubermatic/pkg/serviceaccount"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -123,6 +124,40 @@ func TestCreateTokenProject(t *testing.T) {
 			saToSync:               "1",
 			expectedName:           "test",
 		},
+		{
+			name:       "scenario 4: the expiry of a token must be in the future",
+			body:       `{"name":"test","expiry":"2013-02-03T19:54:00Z"}`,
+			httpStatus: http.StatusBadRequest,
+			existingKubermaticObjs: []runtime.Object{
+				test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenBinding("plan9-ID", "john@acme.com", "owners"),
+				test.GenBinding("plan9-ID", "serviceaccount-1@sa.kubermatic.io", "editors"),
+				test.GenUser("", "john", "john@acme.com"),
+				test.GenServiceAccount("1", "test-1", "editors", "plan9-ID"),
+			},
+			existingKubernetesObjs: []runtime.Object{},
+			existingAPIUser:        *test.GenAPIUser("john", "john@acme.com"),
+			projectToSync:          "plan9-ID",
+			saToSync:               "1",
+			expectedErrorResponse:  `{"error":{"code":400,"message":"the expiry must be in the future"}}`,
+		},
+		{
+			name:       "scenario 5: the scopes of a token must use known methods",
+			body:       `{"name":"test","scopes":[{"methods":["CONNECT"]}]}`,
+			httpStatus: http.StatusBadRequest,
+			existingKubermaticObjs: []runtime.Object{
+				test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenBinding("plan9-ID", "john@acme.com", "owners"),
+				test.GenBinding("plan9-ID", "serviceaccount-1@sa.kubermatic.io", "editors"),
+				test.GenUser("", "john", "john@acme.com"),
+				test.GenServiceAccount("1", "test-1", "editors", "plan9-ID"),
+			},
+			existingKubernetesObjs: []runtime.Object{},
+			existingAPIUser:        *test.GenAPIUser("john", "john@acme.com"),
+			projectToSync:          "plan9-ID",
+			saToSync:               "1",
+			expectedErrorResponse:  `{"error":{"code":400,"message":"invalid method \"CONNECT\", must be one of [DELETE GET PATCH POST PUT]"}}`,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -353,6 +388,155 @@ func TestServiceAccountCanGetProject(t *testing.T) {
 	}
 }
 
+func TestScopedServiceAccountToken(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		name       string
+		scopes     []serviceaccount.TokenScope
+		method     string
+		httpStatus int
+	}{
+		{
+			name:       "scenario 1: a token can be used for the endpoints of its scopes",
+			scopes:     []serviceaccount.TokenScope{{Methods: []string{"GET"}, Endpoint: "/api/v1/projects/{project_id}"}},
+			method:     http.MethodGet,
+			httpStatus: http.StatusOK,
+		},
+		{
+			name:       "scenario 2: a token can't be used for methods which are not part of its scopes",
+			scopes:     []serviceaccount.TokenScope{{Methods: []string{"GET"}, Endpoint: "/api/v1/projects/{project_id}"}},
+			method:     http.MethodDelete,
+			httpStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "scenario 3: a token can't be used for endpoints which are not part of its scopes",
+			scopes:     []serviceaccount.TokenScope{{Endpoint: "/api/v1/projects/{project_id}/clusters"}},
+			method:     http.MethodGet,
+			httpStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			existingKubermaticObjs := []runtime.Object{
+				test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenBinding("plan9-ID", "serviceaccount-1@sa.kubermatic.io", "editors"),
+				test.GenServiceAccount("1", "test-1", "editors", "plan9-ID"),
+			}
+			secret, token := genSignedSaToken(t, "plan9-ID", "serviceaccount-1", "ci", "1", tc.scopes)
+			ep, _, err := test.CreateTestEndpointAndGetClients(*test.GenAPIUser("test-1", "serviceaccount-1@sa.kubermatic.io"), nil, []runtime.Object{secret}, []runtime.Object{}, existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			req := httptest.NewRequest(tc.method, "/api/v1/projects/plan9-ID", strings.NewReader(""))
+			req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+			res := httptest.NewRecorder()
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("expected HTTP status code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+		})
+	}
+}
+
+func TestRotateToken(t *testing.T) {
+	t.Parallel()
+	// the token is allowed to read the project and to rotate itself
+	scopes := []serviceaccount.TokenScope{
+		{Methods: []string{"GET"}, Endpoint: "/api/v1/projects/{project_id}"},
+		{Methods: []string{"POST"}, Endpoint: "/api/v1/projects/{project_id}/serviceaccounts/{serviceaccount_id}/tokens/{token_id}/rotate"},
+	}
+	testcases := []struct {
+		name                   string
+		gracePeriod            string
+		expectedRotationStatus int
+		expectedResponse       string
+		expectedPreviousStatus int
+	}{
+		{
+			name:                   "scenario 1: the previous token is accepted during the default grace period",
+			expectedRotationStatus: http.StatusOK,
+			expectedPreviousStatus: http.StatusOK,
+		},
+		{
+			name:                   "scenario 2: the previous token is revoked immediately without a grace period",
+			gracePeriod:            "?gracePeriodSeconds=0",
+			expectedRotationStatus: http.StatusOK,
+			expectedPreviousStatus: http.StatusUnauthorized,
+		},
+		{
+			name:                   "scenario 3: the grace period is limited",
+			gracePeriod:            "?gracePeriodSeconds=31536000",
+			expectedRotationStatus: http.StatusBadRequest,
+			expectedResponse:       `{"error":{"code":400,"message":"the grace period must be between 0 and 604800 seconds"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			existingKubermaticObjs := []runtime.Object{
+				test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenBinding("plan9-ID", "serviceaccount-1@sa.kubermatic.io", "editors"),
+				test.GenServiceAccount("1", "test-1", "editors", "plan9-ID"),
+			}
+			secret, previousToken := genSignedSaToken(t, "plan9-ID", "serviceaccount-1", "ci", "1", scopes)
+			ep, _, err := test.CreateTestEndpointAndGetClients(*test.GenAPIUser("test-1", "serviceaccount-1@sa.kubermatic.io"), nil, []runtime.Object{secret}, []runtime.Object{}, existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/projects/plan9-ID/serviceaccounts/1/tokens/sa-token-1/rotate%s", tc.gracePeriod), strings.NewReader(""))
+			req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", previousToken))
+			res := httptest.NewRecorder()
+			ep.ServeHTTP(res, req)
+			if res.Code != tc.expectedRotationStatus {
+				t.Fatalf("expected HTTP status code %d, got %d: %s", tc.expectedRotationStatus, res.Code, res.Body.String())
+			}
+			if len(tc.expectedResponse) > 0 {
+				test.CompareWithResult(t, res, tc.expectedResponse)
+				return
+			}
+			rotatedToken := &apiv1.ServiceAccountToken{}
+			if err := json.Unmarshal(res.Body.Bytes(), rotatedToken); err != nil {
+				t.Fatalf("unable to read the token from the response, err %v", err)
+			}
+			if rotatedToken.Token == previousToken {
+				t.Fatal("expected the token to be regenerated")
+			}
+			if len(rotatedToken.Scopes) != len(scopes) {
+				t.Fatalf("expected the scopes of the previous token, got %v", rotatedToken.Scopes)
+			}
+
+			for token, expectedStatus := range map[string]int{rotatedToken.Token: http.StatusOK, previousToken: tc.expectedPreviousStatus} {
+				req := httptest.NewRequest("GET", "/api/v1/projects/plan9-ID", strings.NewReader(""))
+				req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+				res := httptest.NewRecorder()
+				ep.ServeHTTP(res, req)
+				if res.Code != expectedStatus {
+					t.Fatalf("expected HTTP status code %d, got %d: %s", expectedStatus, res.Code, res.Body.String())
+				}
+			}
+		})
+	}
+}
+
+// genSignedSaToken generates a token secret which holds a valid token with the given scopes
+func genSignedSaToken(t *testing.T, projectID, saID, name, id string, scopes []serviceaccount.TokenScope) (*corev1.Secret, string) {
+	tokenGenerator, err := serviceaccount.JWTTokenGenerator([]byte(test.TestServiceAccountHashKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := tokenGenerator.Generate(serviceaccount.ClaimsWithOptions(fmt.Sprintf("%s@sa.kubermatic.io", saID), projectID, id, serviceaccount.TokenOptions{Scopes: scopes}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := test.GenDefaultSaToken(projectID, saID, name, id)
+	secret.Data[serviceaccount.TokenKey] = []byte(token)
+	return secret, token
+}
+
 func TestPatchToken(t *testing.T) {
 	t.Parallel()
 	expiry, err := test.GenDefaultExpiry()
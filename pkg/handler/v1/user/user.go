@@ -130,7 +130,7 @@ func getMemberList(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 }
 
 // EditEndpoint changes the group the given user/member belongs in the given project
-func EditEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userProvider provider.UserProvider, memberProvider provider.ProjectMemberProvider, privilegedMemberProvider provider.PrivilegedProjectMemberProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func EditEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userProvider provider.UserProvider, memberProvider provider.ProjectMemberProvider, privilegedMemberProvider provider.PrivilegedProjectMemberProvider, projectRoleProvider provider.ProjectRoleProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(EditReq)
 		if !ok {
@@ -140,7 +140,7 @@ func EditEndpoint(projectProvider provider.ProjectProvider, privilegedProjectPro
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
-		err = req.Validate(userInfo)
+		err = req.Validate(userInfo, projectRoleProvider)
 		if err != nil {
 			return nil, err
 		}
@@ -170,6 +170,9 @@ func EditEndpoint(projectProvider provider.ProjectProvider, privilegedProjectPro
 		}
 
 		currentMemberBinding := memberList[0]
+		if err := common.VerifyGroupAssignment(ctx, userInfoGetter, project.Name, projectFromRequest.GroupPrefix, rbac.ExtractGroupPrefix(currentMemberBinding.Spec.Group)); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
 		generatedGroupName := rbac.GenerateActualGroupNameFor(project.Name, projectFromRequest.GroupPrefix)
 		currentMemberBinding.Spec.Group = generatedGroupName
 		updatedMemberBinding, err := updateBinding(ctx, userInfoGetter, memberProvider, privilegedMemberProvider, req.ProjectID, currentMemberBinding)
@@ -236,14 +239,14 @@ func ListEndpoint(projectProvider provider.ProjectProvider, privilegedProjectPro
 }
 
 // AddEndpoint adds the given user to the given group within the given project
-func AddEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userProvider provider.UserProvider, memberProvider provider.ProjectMemberProvider, privilegedMemberProvider provider.PrivilegedProjectMemberProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+func AddEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userProvider provider.UserProvider, memberProvider provider.ProjectMemberProvider, privilegedMemberProvider provider.PrivilegedProjectMemberProvider, projectRoleProvider provider.ProjectRoleProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(AddReq)
 		userInfo, err := userInfoGetter(ctx, "")
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
-		err = req.Validate(userInfo)
+		err = req.Validate(userInfo, projectRoleProvider)
 		if err != nil {
 			return nil, err
 		}
@@ -268,6 +271,9 @@ func AddEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProv
 		if len(memberList) > 0 {
 			return nil, k8cerrors.New(http.StatusBadRequest, fmt.Sprintf("cannot add the user = %s to the project %s because user is already in the project", req.Body.Email, req.ProjectID))
 		}
+		if err := common.VerifyGroupAssignment(ctx, userInfoGetter, project.Name, projectFromRequest.GroupPrefix); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
 
 		generatedGroupName := rbac.GenerateActualGroupNameFor(project.Name, projectFromRequest.GroupPrefix)
 		generatedBinding, err := createBinding(ctx, userInfoGetter, memberProvider, privilegedMemberProvider, project, userToInvite.Spec.Email, generatedGroupName)
@@ -428,7 +434,7 @@ type AddReq struct {
 }
 
 // Validate validates AddReq request
-func (r AddReq) Validate(authenticatesUserInfo *provider.UserInfo) error {
+func (r AddReq) Validate(authenticatesUserInfo *provider.UserInfo, projectRoleProvider provider.ProjectRoleProvider) error {
 	if len(r.ProjectID) == 0 {
 		return k8cerrors.NewBadRequest("the name of the project cannot be empty")
 	}
@@ -460,7 +466,13 @@ func (r AddReq) Validate(authenticatesUserInfo *provider.UserInfo) error {
 		}
 	}
 	if !isRequestedGroupPrefixValid {
-		return k8cerrors.NewBadRequest("invalid group name %s", projectFromRequest.GroupPrefix)
+		// custom project roles are valid group prefixes too
+		if _, err := projectRoleProvider.Get(projectFromRequest.GroupPrefix); err != nil {
+			if errors.IsNotFound(err) {
+				return k8cerrors.NewBadRequest("invalid group name %s", projectFromRequest.GroupPrefix)
+			}
+			return common.KubernetesErrorToHTTPError(err)
+		}
 	}
 	return nil
 }
@@ -509,8 +521,8 @@ type EditReq struct {
 }
 
 // Validate validates EditUserToProject request
-func (r EditReq) Validate(authenticatesUserInfo *provider.UserInfo) error {
-	err := r.AddReq.Validate(authenticatesUserInfo)
+func (r EditReq) Validate(authenticatesUserInfo *provider.UserInfo, projectRoleProvider provider.ProjectRoleProvider) error {
+	err := r.AddReq.Validate(authenticatesUserInfo, projectRoleProvider)
 	if err != nil {
 		return err
 	}
@@ -571,6 +571,40 @@ func TestEditUserInProject(t *testing.T) {
 			ExistingAPIUser:  *genAPIUser("admin", "admin@acme.com"),
 			ExpectedResponse: `{"id":"405ac8384fa984f787f9486daf34d84d98f20c4d6a12e2cc4ed89be3bcb06ad6","name":"Bob","creationTimestamp":"0001-01-01T00:00:00Z","email":"bob@acme.com","projects":[{"id":"plan9-ID","group":"editors"}]}`,
 		},
+		{
+			Name:          "scenario 4: john, who can manage the members of the plan9 project, can not promote bob to an owner",
+			Body:          `{"id":"405ac8384fa984f787f9486daf34d84d98f20c4d6a12e2cc4ed89be3bcb06ad6", "email":"bob@acme.com", "projects":[{"id":"plan9-ID", "group":"owners"}]}`,
+			HTTPStatus:    http.StatusForbidden,
+			ProjectToSync: "plan9-ID",
+			ExistingKubermaticObjs: []runtime.Object{
+				test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				genMembersManagerRole(),
+				test.GenBinding("plan9-ID", "john@acme.com", "managers"),
+				test.GenBinding("plan9-ID", "bob@acme.com", "managers"),
+				genUser("", "john", "john@acme.com"),
+				genDefaultUser(), /*bob*/
+			},
+			UserIDToUpdate:   genDefaultUser().Name,
+			ExistingAPIUser:  *genAPIUser("john", "john@acme.com"),
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: only owners can assign the group owners in the project plan9-ID"}}`,
+		},
+		{
+			Name:          "scenario 5: john, who can manage the members of the plan9 project, can not demote bob the owner",
+			Body:          `{"id":"405ac8384fa984f787f9486daf34d84d98f20c4d6a12e2cc4ed89be3bcb06ad6", "email":"bob@acme.com", "projects":[{"id":"plan9-ID", "group":"managers"}]}`,
+			HTTPStatus:    http.StatusForbidden,
+			ProjectToSync: "plan9-ID",
+			ExistingKubermaticObjs: []runtime.Object{
+				test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				genMembersManagerRole(),
+				test.GenBinding("plan9-ID", "john@acme.com", "managers"),
+				test.GenBinding("plan9-ID", "bob@acme.com", "owners"),
+				genUser("", "john", "john@acme.com"),
+				genDefaultUser(), /*bob*/
+			},
+			UserIDToUpdate:   genDefaultUser().Name,
+			ExistingAPIUser:  *genAPIUser("john", "john@acme.com"),
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: only owners can assign the group owners in the project plan9-ID"}}`,
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -802,6 +836,72 @@ func TestAddUserToProject(t *testing.T) {
 			ExistingAPIUser:  *genAPIUser("admin", "admin@acme.com"),
 			ExpectedResponse: `{"id":"405ac8384fa984f787f9486daf34d84d98f20c4d6a12e2cc4ed89be3bcb06ad6","name":"Bob","creationTimestamp":"0001-01-01T00:00:00Z","email":"bob@acme.com","projects":[{"id":"plan9-ID","group":"editors"}]}`,
 		},
+
+		{
+			Name:          "scenario 10: john the owner of the plan9 project invites bob to the project as a cluster operator",
+			Body:          `{"email":"bob@acme.com", "projects":[{"id":"plan9-ID", "group":"operators"}]}`,
+			HTTPStatus:    http.StatusCreated,
+			ProjectToSync: "plan9-ID",
+			ExistingKubermaticObjs: []runtime.Object{
+				/*add projects*/
+				test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				/*add bindings*/
+				test.GenBinding("plan9-ID", "john@acme.com", "owners"),
+				/*add users*/
+				genUser("", "john", "john@acme.com"),
+				genDefaultUser(), /*bob*/
+			},
+			ExistingAPIUser:  *genAPIUser("john", "john@acme.com"),
+			ExpectedResponse: `{"id":"405ac8384fa984f787f9486daf34d84d98f20c4d6a12e2cc4ed89be3bcb06ad6","name":"Bob","creationTimestamp":"0001-01-01T00:00:00Z","email":"bob@acme.com","projects":[{"id":"plan9-ID","group":"operators"}]}`,
+		},
+
+		{
+			Name:          "scenario 11: john the owner of the plan9 project can't invite bob with a role which doesn't exist",
+			Body:          `{"email":"bob@acme.com", "projects":[{"id":"plan9-ID", "group":"auditors"}]}`,
+			HTTPStatus:    http.StatusBadRequest,
+			ProjectToSync: "plan9-ID",
+			ExistingKubermaticObjs: []runtime.Object{
+				/*add projects*/
+				test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				/*add bindings*/
+				test.GenBinding("plan9-ID", "john@acme.com", "owners"),
+				/*add users*/
+				genUser("", "john", "john@acme.com"),
+				genDefaultUser(), /*bob*/
+			},
+			ExistingAPIUser:  *genAPIUser("john", "john@acme.com"),
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid group name auditors"}}`,
+		},
+		{
+			Name:          "scenario 12: john, who can manage the members of the plan9 project, can not invite bob as an owner",
+			Body:          `{"email":"bob@acme.com", "projects":[{"id":"plan9-ID", "group":"owners"}]}`,
+			HTTPStatus:    http.StatusForbidden,
+			ProjectToSync: "plan9-ID",
+			ExistingKubermaticObjs: []runtime.Object{
+				test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				genMembersManagerRole(),
+				test.GenBinding("plan9-ID", "john@acme.com", "managers"),
+				genUser("", "john", "john@acme.com"),
+				genDefaultUser(), /*bob*/
+			},
+			ExistingAPIUser:  *genAPIUser("john", "john@acme.com"),
+			ExpectedResponse: `{"error":{"code":403,"message":"forbidden: only owners can assign the group owners in the project plan9-ID"}}`,
+		},
+		{
+			Name:          "scenario 13: john, who can manage the members of the plan9 project, invites bob with his own role",
+			Body:          `{"email":"bob@acme.com", "projects":[{"id":"plan9-ID", "group":"managers"}]}`,
+			HTTPStatus:    http.StatusCreated,
+			ProjectToSync: "plan9-ID",
+			ExistingKubermaticObjs: []runtime.Object{
+				test.GenProject("plan9", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				genMembersManagerRole(),
+				test.GenBinding("plan9-ID", "john@acme.com", "managers"),
+				genUser("", "john", "john@acme.com"),
+				genDefaultUser(), /*bob*/
+			},
+			ExistingAPIUser:  *genAPIUser("john", "john@acme.com"),
+			ExpectedResponse: `{"id":"405ac8384fa984f787f9486daf34d84d98f20c4d6a12e2cc4ed89be3bcb06ad6","name":"Bob","creationTimestamp":"0001-01-01T00:00:00Z","email":"bob@acme.com","projects":[{"id":"plan9-ID","group":"managers"}]}`,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -1067,3 +1167,18 @@ func genDefaultAdminUser() *kubermaticapiv1.User {
 	user.Spec.IsAdmin = true
 	return user
 }
+
+// genMembersManagerRole generates a custom ProjectRole which allows to manage the members of a project
+func genMembersManagerRole() *kubermaticapiv1.ProjectRole {
+	return &kubermaticapiv1.ProjectRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "managers"},
+		Spec: kubermaticapiv1.ProjectRoleSpec{
+			Rules: []kubermaticapiv1.ProjectRoleRule{
+				{
+					Resource: kubermaticapiv1.ProjectRoleResourceMembers,
+					Verbs:    kubermaticapiv1.AllProjectRoleVerbs,
+				},
+			},
+		},
+	}
+}
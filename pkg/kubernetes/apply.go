@@ -0,0 +1,49 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ApplyUnstructured applies the object like `kubectl apply` does, using server-side apply with
+// the given field manager.
+func ApplyUnstructured(ctx context.Context, client ctrlruntimeclient.Client, object *metav1unstructured.Unstructured, fieldManager string) error {
+	err := client.Patch(ctx, object.DeepCopy(), ctrlruntimeclient.Apply, ctrlruntimeclient.FieldOwner(fieldManager), ctrlruntimeclient.ForceOwnership)
+	if !kerrors.IsUnsupportedMediaType(err) {
+		return err
+	}
+
+	// Server-side apply is only enabled by default since Kubernetes 1.16, so fall back to a
+	// merge patch for older clusters. Fields which got removed from the manifest will not get
+	// removed from the object in that case.
+	data, err := object.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal object: %v", err)
+	}
+	err = client.Patch(ctx, object.DeepCopy(), ctrlruntimeclient.ConstantPatch(types.MergePatchType, data))
+	if !kerrors.IsNotFound(err) {
+		return err
+	}
+	return client.Create(ctx, object.DeepCopy())
+}
@@ -52,9 +52,9 @@ type UserClusterConnectionProvider interface {
 	GetClient(*kubermaticv1.Cluster, ...k8cuserclusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
 }
 
-// extractGroupPrefixFunc is a function that knows how to extract a prefix (owners, editors) from "projectID-owners" group,
-// group names inside leaf/user clusters don't have projectID in their names
-type extractGroupPrefixFunc func(groupName string) string
+// userClusterGroupsFunc is a function that knows how to map a "projectID-owners" group to the groups inside leaf/user clusters,
+// group names inside leaf/user clusters don't have projectID in their names and project roles map to a group per verb on node deployments
+type userClusterGroupsFunc func(groupName string) []string
 
 // NewClusterProvider returns a new cluster provider that respects RBAC policies
 // it uses createSeedImpersonatedClient to create a connection that uses user impersonation
@@ -63,7 +63,7 @@ func NewClusterProvider(
 	createSeedImpersonatedClient impersonationClient,
 	userClusterConnProvider UserClusterConnectionProvider,
 	workerName string,
-	extractGroupPrefix extractGroupPrefixFunc,
+	userClusterGroups userClusterGroupsFunc,
 	client ctrlruntimeclient.Client,
 	k8sClient kubernetes.Interface,
 	oidcKubeConfEndpoint bool) *ClusterProvider {
@@ -71,7 +71,7 @@ func NewClusterProvider(
 		createSeedImpersonatedClient: createSeedImpersonatedClient,
 		userClusterConnProvider:      userClusterConnProvider,
 		workerName:                   workerName,
-		extractGroupPrefix:           extractGroupPrefix,
+		userClusterGroups:            userClusterGroups,
 		client:                       client,
 		k8sClient:                    k8sClient,
 		oidcKubeConfEndpoint:         oidcKubeConfEndpoint,
@@ -91,7 +91,7 @@ type ClusterProvider struct {
 
 	oidcKubeConfEndpoint bool
 	workerName           string
-	extractGroupPrefix   extractGroupPrefixFunc
+	userClusterGroups    userClusterGroupsFunc
 	client               ctrlruntimeclient.Client
 	k8sClient            kubernetes.Interface
 	seedKubeconfig       *restclient.Config
@@ -382,7 +382,10 @@ func (p *ClusterProvider) GetTokenForCustomerCluster(userInfo *provider.UserInfo
 		return cluster.Address.AdminToken, nil
 	case "owners":
 		return cluster.Address.AdminToken, nil
-	case "viewers":
+	case "":
+		return "", fmt.Errorf("user group %s not supported", userInfo.Group)
+	default:
+		// viewers and members of project roles get read-only access
 		s := &corev1.Secret{}
 		name := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.ViewerTokenSecretName}
 
@@ -391,8 +394,6 @@ func (p *ClusterProvider) GetTokenForCustomerCluster(userInfo *provider.UserInfo
 		}
 
 		return string(s.Data[resources.ViewerTokenSecretKey]), nil
-	default:
-		return "", fmt.Errorf("user group %s not supported", userInfo.Group)
 	}
 }
 
@@ -414,7 +415,7 @@ func (p *ClusterProvider) withImpersonation(userInfo *provider.UserInfo) k8cuser
 	return func(cfg *restclient.Config) *restclient.Config {
 		cfg.Impersonate = restclient.ImpersonationConfig{
 			UserName: userInfo.Email,
-			Groups:   []string{p.extractGroupPrefix(userInfo.Group), "system:authenticated"},
+			Groups:   append(p.userClusterGroups(userInfo.Group), "system:authenticated"),
 		}
 		return cfg
 	}
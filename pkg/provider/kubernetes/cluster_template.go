@@ -0,0 +1,200 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	kubermaticapiv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PrivilegedClusterTemplateProvider represents a data structure that knows how to manage cluster templates in a privileged way
+type PrivilegedClusterTemplateProvider struct {
+	// treat clientPrivileged as a privileged user and use wisely
+	clientPrivileged ctrlruntimeclient.Client
+}
+
+// NewPrivilegedClusterTemplateProvider returns a privileged cluster template provider
+func NewPrivilegedClusterTemplateProvider(client ctrlruntimeclient.Client) (*PrivilegedClusterTemplateProvider, error) {
+	return &PrivilegedClusterTemplateProvider{
+		clientPrivileged: client,
+	}, nil
+}
+
+// NewClusterTemplateProvider returns a new cluster template provider that respects RBAC policies
+// it uses createMasterImpersonatedClient to create a connection that uses User Impersonation
+func NewClusterTemplateProvider(createMasterImpersonatedClient impersonationClient, client ctrlruntimeclient.Client) *ClusterTemplateProvider {
+	return &ClusterTemplateProvider{createMasterImpersonatedClient: createMasterImpersonatedClient, client: client}
+}
+
+// ClusterTemplateProvider struct that holds required components in order to provide
+// cluster template provider that is RBAC compliant
+type ClusterTemplateProvider struct {
+	// createMasterImpersonatedClient is used as a ground for impersonation
+	// whenever a connection to Seed API server is required
+	createMasterImpersonatedClient impersonationClient
+
+	client ctrlruntimeclient.Client
+}
+
+// Create creates a cluster template that will belong to the given project
+func (p *ClusterTemplateProvider) Create(userInfo *provider.UserInfo, project *kubermaticapiv1.Project, template *kubermaticapiv1.ClusterTemplate) (*kubermaticapiv1.ClusterTemplate, error) {
+	if userInfo == nil {
+		return nil, errors.New("a userInfo is missing but required")
+	}
+	if project == nil {
+		return nil, errors.New("a project is missing but required")
+	}
+
+	template, err := genClusterTemplate(project, template)
+	if err != nil {
+		return nil, err
+	}
+
+	masterImpersonatedClient, err := createImpersonationClientWrapperFromUserInfo(userInfo, p.createMasterImpersonatedClient)
+	if err != nil {
+		return nil, err
+	}
+	if err := masterImpersonatedClient.Create(context.Background(), template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// CreateUnsecured creates a cluster template that belongs to the given project,
+// templates without a project are global
+// This function is unsafe in a sense that it uses privileged account to create the template
+func (p *PrivilegedClusterTemplateProvider) CreateUnsecured(project *kubermaticapiv1.Project, template *kubermaticapiv1.ClusterTemplate) (*kubermaticapiv1.ClusterTemplate, error) {
+	template, err := genClusterTemplate(project, template)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.clientPrivileged.Create(context.Background(), template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func genClusterTemplate(project *kubermaticapiv1.Project, template *kubermaticapiv1.ClusterTemplate) (*kubermaticapiv1.ClusterTemplate, error) {
+	if template == nil {
+		return nil, errors.New("a template is missing but required")
+	}
+	if template.Spec.HumanReadableName == "" {
+		return nil, fmt.Errorf("the template name is missing but required")
+	}
+
+	template = template.DeepCopy()
+	template.Name = fmt.Sprintf("template-%s", rand.String(10))
+	if template.Labels == nil {
+		template.Labels = map[string]string{}
+	}
+	template.OwnerReferences = nil
+
+	if project == nil {
+		template.Labels[kubermaticapiv1.ClusterTemplateScopeLabelKey] = kubermaticapiv1.ClusterTemplateGlobalScope
+		delete(template.Labels, kubermaticapiv1.ProjectIDLabelKey)
+		return template, nil
+	}
+
+	template.Labels[kubermaticapiv1.ClusterTemplateScopeLabelKey] = kubermaticapiv1.ClusterTemplateProjectScope
+	template.Labels[kubermaticapiv1.ProjectIDLabelKey] = project.Name
+	template.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion: kubermaticapiv1.SchemeGroupVersion.String(),
+			Kind:       kubermaticapiv1.ProjectKindName,
+			UID:        project.GetUID(),
+			Name:       project.Name,
+		},
+	}
+	return template, nil
+}
+
+// List gets the templates that belong to the given project together with the global templates
+//
+// Note:
+// After we get the list of the templates we could try to get each individually using unprivileged account to see if the user have read access,
+// We don't do this because we assume that if the user was able to get the project (argument) it has to have at least read access.
+func (p *ClusterTemplateProvider) List(project *kubermaticapiv1.Project) ([]*kubermaticapiv1.ClusterTemplate, error) {
+	if project == nil {
+		return nil, errors.New("a project is missing but required")
+	}
+	allTemplates := &kubermaticapiv1.ClusterTemplateList{}
+	if err := p.client.List(context.Background(), allTemplates); err != nil {
+		return nil, err
+	}
+
+	templates := []*kubermaticapiv1.ClusterTemplate{}
+	for _, template := range allTemplates.Items {
+		if template.IsGlobal() || isOwnedByProject(&template, project) {
+			templates = append(templates, template.DeepCopy())
+		}
+	}
+	return templates, nil
+}
+
+// Get returns the template with the given name if it belongs to the given project or is global
+//
+// Note:
+// Just like List, Get assumes that the user has at least read access if they were able to get the project.
+func (p *ClusterTemplateProvider) Get(project *kubermaticapiv1.Project, templateName string) (*kubermaticapiv1.ClusterTemplate, error) {
+	if project == nil {
+		return nil, errors.New("a project is missing but required")
+	}
+	template := &kubermaticapiv1.ClusterTemplate{}
+	if err := p.client.Get(context.Background(), ctrlruntimeclient.ObjectKey{Name: templateName}, template); err != nil {
+		return nil, err
+	}
+	if !template.IsGlobal() && !isOwnedByProject(template, project) {
+		return nil, kerrors.NewNotFound(kubermaticapiv1.Resource(kubermaticapiv1.ClusterTemplateResourceName), templateName)
+	}
+	return template, nil
+}
+
+func isOwnedByProject(template *kubermaticapiv1.ClusterTemplate, project *kubermaticapiv1.Project) bool {
+	for _, owner := range template.GetOwnerReferences() {
+		if owner.APIVersion == kubermaticapiv1.SchemeGroupVersion.String() && owner.Kind == kubermaticapiv1.ProjectKindName && owner.Name == project.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// Delete simply deletes the given template
+func (p *ClusterTemplateProvider) Delete(userInfo *provider.UserInfo, templateName string) error {
+	masterImpersonatedClient, err := createImpersonationClientWrapperFromUserInfo(userInfo, p.createMasterImpersonatedClient)
+	if err != nil {
+		return err
+	}
+	return masterImpersonatedClient.Delete(context.Background(), &kubermaticapiv1.ClusterTemplate{ObjectMeta: metav1.ObjectMeta{Name: templateName}})
+}
+
+// DeleteUnsecured deletes the given template
+// This function is unsafe in a sense that it uses privileged account to delete the template
+func (p *PrivilegedClusterTemplateProvider) DeleteUnsecured(templateName string) error {
+	return p.clientPrivileged.Delete(context.Background(), &kubermaticapiv1.ClusterTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: templateName},
+	})
+}
@@ -173,6 +173,21 @@ func (p *ProjectMemberProvider) MapUserToGroup(userEmail string, projectID strin
 		}
 	}
 
+	// users without a binding can be members through the OIDC groups of the project
+	userGroups, err := p.oidcGroupsFor(userEmail)
+	if err != nil {
+		return "", err
+	}
+	if len(userGroups) > 0 {
+		project := &kubermaticapiv1.Project{}
+		if err := p.clientPrivileged.Get(context.Background(), ctrlruntimeclient.ObjectKey{Name: projectID}, project); err != nil && !kerrors.IsNotFound(err) {
+			return "", err
+		}
+		if role, ok := roleForOIDCGroups(project, userGroups); ok {
+			return rbac.GenerateActualGroupNameFor(projectID, role), nil
+		}
+	}
+
 	return "", kerrors.NewForbidden(schema.GroupResource{}, projectID, fmt.Errorf("%q doesn't belong to the given project = %s", userEmail, projectID))
 }
 
@@ -185,15 +200,84 @@ func (p *ProjectMemberProvider) MappingsFor(userEmail string) ([]*kubermaticapiv
 	}
 
 	memberMappings := []*kubermaticapiv1.UserProjectBinding{}
+	boundProjects := map[string]bool{}
 	for _, memberMapping := range allMemberMappings.Items {
 		if strings.EqualFold(memberMapping.Spec.UserEmail, userEmail) {
 			memberMappings = append(memberMappings, memberMapping.DeepCopy())
+			boundProjects[memberMapping.Spec.ProjectID] = true
+		}
+	}
+
+	userGroups, err := p.oidcGroupsFor(userEmail)
+	if err != nil {
+		return nil, err
+	}
+	if len(userGroups) == 0 {
+		return memberMappings, nil
+	}
+
+	// the mappings of OIDC groups are not persisted, they only exist as long as the user is in the group
+	allProjects := &kubermaticapiv1.ProjectList{}
+	if err := p.clientPrivileged.List(context.Background(), allProjects); err != nil {
+		return nil, err
+	}
+	for _, project := range allProjects.Items {
+		if boundProjects[project.Name] {
+			continue
+		}
+		if role, ok := roleForOIDCGroups(&project, userGroups); ok {
+			memberMappings = append(memberMappings, &kubermaticapiv1.UserProjectBinding{
+				Spec: kubermaticapiv1.UserProjectBindingSpec{
+					UserEmail: userEmail,
+					ProjectID: project.Name,
+					Group:     rbac.GenerateActualGroupNameFor(project.Name, role),
+				},
+			})
 		}
 	}
 
 	return memberMappings, nil
 }
 
+// oidcGroupsFor returns the OIDC groups of the user with the given email, see UserSpec.Groups
+func (p *ProjectMemberProvider) oidcGroupsFor(userEmail string) ([]string, error) {
+	if p.isServiceAccountFunc(userEmail) {
+		return nil, nil
+	}
+	// the name of users is derived from their email, only users which were created differently have to be searched for
+	user := &kubermaticapiv1.User{}
+	err := p.clientPrivileged.Get(context.Background(), ctrlruntimeclient.ObjectKey{Name: userNameFor(userEmail)}, user)
+	if err == nil && strings.EqualFold(user.Spec.Email, userEmail) {
+		return user.Spec.Groups, nil
+	}
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	users := &kubermaticapiv1.UserList{}
+	if err := p.clientPrivileged.List(context.Background(), users); err != nil {
+		return nil, err
+	}
+	for _, user := range users.Items {
+		if strings.EqualFold(user.Spec.Email, userEmail) {
+			return user.Spec.Groups, nil
+		}
+	}
+	return nil, nil
+}
+
+// roleForOIDCGroups returns the role of the first OIDC group of the project the user is a member of
+func roleForOIDCGroups(project *kubermaticapiv1.Project, userGroups []string) (string, bool) {
+	for _, oidcGroup := range project.Spec.OIDCGroups {
+		for _, userGroup := range userGroups {
+			if oidcGroup.Name == userGroup {
+				return oidcGroup.Role, true
+			}
+		}
+	}
+	return "", false
+}
+
 // CreateUnsecured creates a binding for the given member and the given project
 // This function is unsafe in a sense that it uses privileged account to create the resource
 func (p *ProjectMemberProvider) CreateUnsecured(project *kubermaticapiv1.Project, memberEmail, group string) (*kubermaticapiv1.UserProjectBinding, error) {
@@ -152,3 +152,80 @@ func TestListBinding(t *testing.T) {
 		})
 	}
 }
+
+func TestMapUserToGroupWithOIDCGroups(t *testing.T) {
+	testcases := []struct {
+		name             string
+		userGroups       []string
+		projectGroups    []kubermaticv1.ProjectOIDCGroup
+		existingBindings []*kubermaticv1.UserProjectBinding
+		expectedGroup    string
+		expectedError    bool
+	}{
+		{
+			name:          "scenario 1: a member of an OIDC group is mapped to the role of the group",
+			userGroups:    []string{"developers"},
+			projectGroups: []kubermaticv1.ProjectOIDCGroup{{Name: "developers", Role: "editors"}},
+			expectedGroup: "editors-my-first-project-ID",
+		},
+		{
+			name:          "scenario 2: the first matching OIDC group of the project wins",
+			userGroups:    []string{"developers", "admins"},
+			projectGroups: []kubermaticv1.ProjectOIDCGroup{{Name: "admins", Role: "owners"}, {Name: "developers", Role: "viewers"}},
+			expectedGroup: "owners-my-first-project-ID",
+		},
+		{
+			name:             "scenario 3: an explicit binding takes precedence over OIDC groups",
+			userGroups:       []string{"developers"},
+			projectGroups:    []kubermaticv1.ProjectOIDCGroup{{Name: "developers", Role: "owners"}},
+			existingBindings: []*kubermaticv1.UserProjectBinding{createBinding("binding", "my-first-project-ID", "john@acme.com", "viewers")},
+			expectedGroup:    "viewers-my-first-project-ID",
+		},
+		{
+			name:          "scenario 4: a user which left the OIDC group has no access",
+			userGroups:    []string{"testers"},
+			projectGroups: []kubermaticv1.ProjectOIDCGroup{{Name: "developers", Role: "editors"}},
+			expectedError: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			user := createAuthenitactedUser()
+			user.Name = "john"
+			user.Spec.Groups = tc.userGroups
+			project := genDefaultProject()
+			project.Spec.OIDCGroups = tc.projectGroups
+			kubermaticObjects := []runtime.Object{user, project}
+			for _, binding := range tc.existingBindings {
+				kubermaticObjects = append(kubermaticObjects, binding)
+			}
+			fakeClient := fakectrlruntimeclient.NewFakeClientWithScheme(scheme.Scheme, kubermaticObjects...)
+			fakeImpersonationClient := func(impCfg restclient.ImpersonationConfig) (ctrlruntimeclient.Client, error) {
+				return fakeClient, nil
+			}
+			target := kubernetes.NewProjectMemberProvider(fakeImpersonationClient, fakeClient, kubernetes.IsServiceAccount)
+
+			group, err := target.MapUserToGroup(user.Spec.Email, project.Name)
+			if tc.expectedError {
+				if err == nil {
+					t.Fatalf("expected an error, but got group %s", group)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if group != tc.expectedGroup {
+				t.Fatalf("expected group %s, got %s", tc.expectedGroup, group)
+			}
+
+			mappings, err := target.MappingsFor(user.Spec.Email)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(mappings) != 1 || mappings[0].Spec.Group != tc.expectedGroup {
+				t.Fatalf("expected a single mapping to %s, got %v", tc.expectedGroup, mappings)
+			}
+		})
+	}
+}
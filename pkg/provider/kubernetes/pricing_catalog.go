@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PricingCatalogProvider is a object to handle the pricing catalog
+type PricingCatalogProvider struct {
+	client ctrlruntimeclient.Client
+	ctx    context.Context
+}
+
+var _ provider.PricingCatalogProvider = &PricingCatalogProvider{}
+
+// NewPricingCatalogProvider returns a pricing catalog provider
+func NewPricingCatalogProvider(ctx context.Context, client ctrlruntimeclient.Client) *PricingCatalogProvider {
+	return &PricingCatalogProvider{client: client, ctx: ctx}
+}
+
+// Get returns the pricing catalog, it is empty as long as admins did not define any prices
+func (p *PricingCatalogProvider) Get() (*kubermaticv1.PricingCatalog, error) {
+	catalog := &kubermaticv1.PricingCatalog{}
+	if err := p.client.Get(p.ctx, ctrlruntimeclient.ObjectKey{Name: kubermaticv1.GlobalPricingCatalogName}, catalog); err != nil {
+		if kerrors.IsNotFound(err) {
+			return &kubermaticv1.PricingCatalog{
+				ObjectMeta: metav1.ObjectMeta{Name: kubermaticv1.GlobalPricingCatalogName},
+				Spec:       kubermaticv1.PricingCatalogSpec{Prices: []kubermaticv1.MachinePrice{}},
+			}, nil
+		}
+		return nil, err
+	}
+	return catalog, nil
+}
+
+// Update creates or updates the pricing catalog, only admins can change prices
+func (p *PricingCatalogProvider) Update(userInfo *provider.UserInfo, catalog *kubermaticv1.PricingCatalog) (*kubermaticv1.PricingCatalog, error) {
+	if !userInfo.IsAdmin {
+		return nil, kerrors.NewForbidden(schema.GroupResource{}, userInfo.Email, fmt.Errorf("%q doesn't have admin rights", userInfo.Email))
+	}
+	if catalog == nil {
+		return nil, fmt.Errorf("the pricing catalog can not be nil")
+	}
+	catalog.Name = kubermaticv1.GlobalPricingCatalogName
+
+	existing := &kubermaticv1.PricingCatalog{}
+	err := p.client.Get(p.ctx, ctrlruntimeclient.ObjectKey{Name: catalog.Name}, existing)
+	if kerrors.IsNotFound(err) {
+		if err := p.client.Create(p.ctx, catalog); err != nil {
+			return nil, err
+		}
+		return catalog, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	existing.Spec = catalog.Spec
+	if err := p.client.Update(p.ctx, existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubermatic/kubermatic/pkg/controller/master-controller-manager/rbac"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/provider"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ProjectRoleProvider is a object to handle project roles
+type ProjectRoleProvider struct {
+	client ctrlruntimeclient.Client
+	ctx    context.Context
+}
+
+var _ provider.ProjectRoleProvider = &ProjectRoleProvider{}
+
+// NewProjectRoleProvider returns a project role provider
+func NewProjectRoleProvider(ctx context.Context, client ctrlruntimeclient.Client) *ProjectRoleProvider {
+	return &ProjectRoleProvider{client: client, ctx: ctx}
+}
+
+// List returns the default project roles together with the roles defined by admins
+func (p *ProjectRoleProvider) List() ([]kubermaticv1.ProjectRole, error) {
+	roleList := &kubermaticv1.ProjectRoleList{}
+	if err := p.client.List(p.ctx, roleList); err != nil {
+		return nil, fmt.Errorf("failed to list project roles: %v", err)
+	}
+	roles := []kubermaticv1.ProjectRole{}
+	for _, role := range rbac.DefaultProjectRoles {
+		roles = append(roles, *role.DeepCopy())
+	}
+	for _, role := range roleList.Items {
+		if isDefaultProjectRole(role.Name) {
+			continue
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+// Get returns the default or custom project role with the given name
+func (p *ProjectRoleProvider) Get(name string) (*kubermaticv1.ProjectRole, error) {
+	for _, role := range rbac.DefaultProjectRoles {
+		if role.Name == name {
+			return role.DeepCopy(), nil
+		}
+	}
+	role := &kubermaticv1.ProjectRole{}
+	if err := p.client.Get(p.ctx, ctrlruntimeclient.ObjectKey{Name: name}, role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// Create creates a custom project role, only admins can create roles
+func (p *ProjectRoleProvider) Create(userInfo *provider.UserInfo, role *kubermaticv1.ProjectRole) (*kubermaticv1.ProjectRole, error) {
+	if !userInfo.IsAdmin {
+		return nil, kerrors.NewForbidden(schema.GroupResource{}, userInfo.Email, fmt.Errorf("%q doesn't have admin rights", userInfo.Email))
+	}
+	if role == nil {
+		return nil, fmt.Errorf("the project role can not be nil")
+	}
+	if err := rbac.ValidateProjectRoleName(role.Name); err != nil {
+		return nil, kerrors.NewBadRequest(err.Error())
+	}
+	if err := p.client.Create(p.ctx, role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// Update updates a custom project role, the default roles can't be changed
+func (p *ProjectRoleProvider) Update(userInfo *provider.UserInfo, role *kubermaticv1.ProjectRole) (*kubermaticv1.ProjectRole, error) {
+	if !userInfo.IsAdmin {
+		return nil, kerrors.NewForbidden(schema.GroupResource{}, userInfo.Email, fmt.Errorf("%q doesn't have admin rights", userInfo.Email))
+	}
+	if role == nil {
+		return nil, fmt.Errorf("the project role can not be nil")
+	}
+	if isDefaultProjectRole(role.Name) {
+		return nil, kerrors.NewBadRequest(fmt.Sprintf("the default project role %q can not be changed", role.Name))
+	}
+	oldRole, err := p.Get(role.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.client.Patch(p.ctx, role, ctrlruntimeclient.MergeFrom(oldRole)); err != nil {
+		return nil, fmt.Errorf("failed to update project role: %v", err)
+	}
+	return role, nil
+}
+
+// Delete deletes a custom project role which has no members
+func (p *ProjectRoleProvider) Delete(userInfo *provider.UserInfo, name string) error {
+	if !userInfo.IsAdmin {
+		return kerrors.NewForbidden(schema.GroupResource{}, userInfo.Email, fmt.Errorf("%q doesn't have admin rights", userInfo.Email))
+	}
+	if isDefaultProjectRole(name) {
+		return kerrors.NewBadRequest(fmt.Sprintf("the default project role %q can not be deleted", name))
+	}
+	role, err := p.Get(name)
+	if err != nil {
+		return err
+	}
+
+	bindingList := &kubermaticv1.UserProjectBindingList{}
+	if err := p.client.List(p.ctx, bindingList); err != nil {
+		return fmt.Errorf("failed to list project members: %v", err)
+	}
+	for _, binding := range bindingList.Items {
+		if rbac.ExtractGroupPrefix(binding.Spec.Group) == name {
+			return kerrors.NewConflict(schema.GroupResource{Resource: kubermaticv1.ProjectRoleResourceName}, name, fmt.Errorf("the role is assigned to %s in project %s", binding.Spec.UserEmail, binding.Spec.ProjectID))
+		}
+	}
+
+	return p.client.Delete(p.ctx, role)
+}
+
+// UserClusterGroups returns the groups the members of the given project group are impersonated as in user clusters
+func (p *ProjectRoleProvider) UserClusterGroups(groupName string) []string {
+	groupPrefix := rbac.ExtractGroupPrefix(groupName)
+	if rbac.IsBuiltInGroupPrefix(groupPrefix) {
+		return rbac.UserClusterGroupsFor(groupName, nil)
+	}
+	role, err := p.Get(groupPrefix)
+	if err != nil {
+		// members of unknown roles don't get any access to user clusters
+		return []string{}
+	}
+	return rbac.UserClusterGroupsFor(groupName, &role.Spec)
+}
+
+func isDefaultProjectRole(name string) bool {
+	for _, role := range rbac.DefaultProjectRoles {
+		if role.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -84,6 +84,11 @@ func (p *UserProvider) UserByEmail(email string) (*kubermaticv1.User, error) {
 	return nil, provider.ErrNotFound
 }
 
+// userNameFor returns the name of the User resource of the given email address, see CreateUser
+func userNameFor(email string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(email)))
+}
+
 // CreateUser creates a new user.
 //
 // Note that:
@@ -104,7 +109,7 @@ func (p *UserProvider) CreateUser(id, name, email string) (*kubermaticv1.User, e
 
 	user := &kubermaticv1.User{
 		ObjectMeta: v1.ObjectMeta{
-			Name: fmt.Sprintf("%x", sha256.Sum256([]byte(email))),
+			Name: userNameFor(email),
 		},
 		Spec: kubermaticv1.UserSpec{
 			ID:    id,
@@ -275,6 +275,38 @@ type PrivilegedSSHKeyProvider interface {
 	DeleteUnsecured(keyName string) error
 }
 
+// ClusterTemplateProvider declares the set of methods for interacting with cluster templates
+// This provider is Project and RBAC compliant
+type ClusterTemplateProvider interface {
+	// List gets the templates that belong to the given project together with the global templates
+	//
+	// Note:
+	// After we get the list of the templates we could try to get each individually using unprivileged account to see if the user have read access,
+	// We don't do this because we assume that if the user was able to get the project (argument) it has to have at least read access.
+	List(project *kubermaticv1.Project) ([]*kubermaticv1.ClusterTemplate, error)
+
+	// Get returns the template with the given name if it belongs to the given project or is global
+	Get(project *kubermaticv1.Project, templateName string) (*kubermaticv1.ClusterTemplate, error)
+
+	// Create creates a template that belongs to the given project
+	Create(userInfo *UserInfo, project *kubermaticv1.Project, template *kubermaticv1.ClusterTemplate) (*kubermaticv1.ClusterTemplate, error)
+
+	// Delete deletes the given template
+	Delete(userInfo *UserInfo, templateName string) error
+}
+
+// PrivilegedClusterTemplateProvider declares the set of methods for interacting with cluster templates and uses privileged account for it
+type PrivilegedClusterTemplateProvider interface {
+	// CreateUnsecured creates a template that belongs to the given project,
+	// templates without a project are global
+	// This function is unsafe in a sense that it uses privileged account to create the template
+	CreateUnsecured(project *kubermaticv1.Project, template *kubermaticv1.ClusterTemplate) (*kubermaticv1.ClusterTemplate, error)
+
+	// DeleteUnsecured deletes the given template
+	// This function is unsafe in a sense that it uses privileged account to delete the template
+	DeleteUnsecured(templateName string) error
+}
+
 // UserProvider declares the set of methods for interacting with kubermatic users
 type UserProvider interface {
 	UserByEmail(email string) (*kubermaticv1.User, error)
@@ -710,3 +742,25 @@ type AdmissionPluginsProvider interface {
 	Update(userInfo *UserInfo, admissionPlugin *kubermaticv1.AdmissionPlugin) (*kubermaticv1.AdmissionPlugin, error)
 	ListPluginNamesFromVersion(fromVersion string) ([]string, error)
 }
+
+// ProjectRoleProvider declares the set of methods for interacting with project roles
+type ProjectRoleProvider interface {
+	// List returns the default project roles together with the roles defined by admins
+	List() ([]kubermaticv1.ProjectRole, error)
+	// Get returns the default or custom project role with the given name
+	Get(name string) (*kubermaticv1.ProjectRole, error)
+	// Create creates a custom project role, only admins can create roles
+	Create(userInfo *UserInfo, role *kubermaticv1.ProjectRole) (*kubermaticv1.ProjectRole, error)
+	// Update updates a custom project role, the default roles can't be changed
+	Update(userInfo *UserInfo, role *kubermaticv1.ProjectRole) (*kubermaticv1.ProjectRole, error)
+	// Delete deletes a custom project role which has no members
+	Delete(userInfo *UserInfo, name string) error
+}
+
+// PricingCatalogProvider declares the set of methods for interacting with the pricing catalog
+type PricingCatalogProvider interface {
+	// Get returns the pricing catalog, it is empty as long as admins did not define any prices
+	Get() (*kubermaticv1.PricingCatalog, error)
+	// Update creates or updates the pricing catalog, only admins can change prices
+	Update(userInfo *UserInfo, catalog *kubermaticv1.PricingCatalog) (*kubermaticv1.PricingCatalog, error)
+}
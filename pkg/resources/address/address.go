@@ -25,6 +25,7 @@ import (
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/resources"
+	"github.com/kubermatic/kubermatic/pkg/resources/nodeportproxy"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -66,6 +67,8 @@ func SyncClusterAddress(ctx context.Context,
 	externalName := ""
 	if cluster.Spec.ExposeStrategy == corev1.ServiceTypeLoadBalancer {
 		externalName = frontProxyLoadBalancerServiceIP
+	} else if pinnedName := cluster.Annotations[kubermaticv1.ClusterExternalNameAnnotation]; pinnedName != "" {
+		externalName = pinnedName
 	} else {
 		externalName = fmt.Sprintf("%s.%s.%s", cluster.Name, subdomain, externalURL)
 	}
@@ -90,6 +93,14 @@ func SyncClusterAddress(ctx context.Context,
 	ip := ""
 	if cluster.Spec.ExposeStrategy == corev1.ServiceTypeLoadBalancer {
 		ip = frontProxyLoadBalancerServiceIP
+	} else if cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategySNI {
+		// The external name only serves the SNI listener, the clients in the user cluster connect
+		// to the apiserver and openVPN by this IP via the in-cluster LoadBalancer of the seed
+		var err error
+		ip, err = getInClusterLoadBalancerIPv4(ctx, log, client, seed)
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		var err error
 		// Always lookup IP address, in case it changes (IP's on AWS LB's change)
@@ -125,6 +136,10 @@ func SyncClusterAddress(ctx context.Context,
 
 	// URL
 	url := fmt.Sprintf("https://%s:%d", externalName, port)
+	if cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategySNI {
+		// The nodeport-proxy routes the connections on the default https port by the server name
+		url = fmt.Sprintf("https://%s", externalName)
+	}
 	if cluster.Address.URL != url {
 		modifiers = append(modifiers, func(c *kubermaticv1.Cluster) {
 			c.Address.URL = url
@@ -145,6 +160,29 @@ func SyncClusterAddress(ctx context.Context,
 	return modifiers, nil
 }
 
+// getInClusterLoadBalancerIPv4 returns the IP of the in-cluster LoadBalancer of the seed nodeport-proxy
+func getInClusterLoadBalancerIPv4(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, seed *kubermaticv1.Seed) (string, error) {
+	service := &corev1.Service{}
+	serviceKey := types.NamespacedName{Namespace: seed.Namespace, Name: nodeportproxy.InClusterServiceName}
+	if err := client.Get(ctx, serviceKey, service); err != nil {
+		return "", fmt.Errorf("failed to get the in-cluster nodeport-proxy service: %v", err)
+	}
+
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			return ingress.IP, nil
+		}
+		// Some implementations like the AWS ELB only provide a hostname
+		if ingress.Hostname != "" {
+			return getExternalIPv4(log, ingress.Hostname)
+		}
+	}
+	if service.Spec.LoadBalancerIP != "" {
+		return service.Spec.LoadBalancerIP, nil
+	}
+	return "", fmt.Errorf("the in-cluster nodeport-proxy service %q has no address yet", serviceKey.String())
+}
+
 func getExternalIPv4(log *zap.SugaredLogger, hostname string) (string, error) {
 	resolvedIPs, err := net.LookupIP(hostname)
 	if err != nil {
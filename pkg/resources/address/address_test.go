@@ -24,6 +24,7 @@ import (
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	kubermaticlog "github.com/kubermatic/kubermatic/pkg/log"
 	"github.com/kubermatic/kubermatic/pkg/resources"
+	"github.com/kubermatic/kubermatic/pkg/resources/nodeportproxy"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -62,8 +63,10 @@ func TestSyncClusterAddress(t *testing.T) {
 		name                 string
 		apiserverService     corev1.Service
 		frontproxyService    corev1.Service
+		inClusterService     corev1.Service
 		exposeStrategy       corev1.ServiceType
 		seedDNSOverwrite     string
+		pinnedExternalName   string
 		expectedExternalName string
 		expectedIP           string
 		expectedPort         int32
@@ -151,6 +154,63 @@ func TestSyncClusterAddress(t *testing.T) {
 			expectedPort:         int32(32000),
 			expectedURL:          fmt.Sprintf("https://%s.alias-europe-west3-c.%s:32000", fakeClusterName, fakeExternalURL),
 		},
+		{
+			name: "Verify properties for service type NodePort with SNI",
+			apiserverService: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeNodePort,
+					Ports: []corev1.ServicePort{
+						{
+							Port:       int32(32000),
+							TargetPort: intstr.FromInt(32000),
+							NodePort:   32000,
+						},
+					},
+				}},
+			inClusterService: corev1.Service{
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{
+						Ingress: []corev1.LoadBalancerIngress{{IP: "10.10.10.10"}},
+					},
+				},
+			},
+			exposeStrategy:       kubermaticv1.ExposeStrategySNI,
+			expectedExternalName: fmt.Sprintf("%s.%s.%s", fakeClusterName, fakeDCName, fakeExternalURL),
+			expectedIP:           "10.10.10.10",
+			expectedPort:         int32(32000),
+			expectedURL:          fmt.Sprintf("https://%s.%s.%s", fakeClusterName, fakeDCName, fakeExternalURL),
+		},
+		{
+			name: "Verify properties for service type NodePort with a pinned external name",
+			apiserverService: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeNodePort,
+					Ports: []corev1.ServicePort{
+						{
+							Port:       int32(32000),
+							TargetPort: intstr.FromInt(32000),
+							NodePort:   32000,
+						},
+					},
+				}},
+			exposeStrategy:       corev1.ServiceTypeNodePort,
+			seedDNSOverwrite:     "other-seed",
+			pinnedExternalName:   fmt.Sprintf("%s.alias-europe-west3-c.%s", fakeClusterName, fakeExternalURL),
+			expectedExternalName: fmt.Sprintf("%s.alias-europe-west3-c.%s", fakeClusterName, fakeExternalURL),
+			expectedIP:           externalIP,
+			expectedPort:         int32(32000),
+			expectedURL:          fmt.Sprintf("https://%s.alias-europe-west3-c.%s:32000", fakeClusterName, fakeExternalURL),
+		},
+		{
+			name: "Verify error when the in-cluster LoadBalancer of SNI has no address",
+			apiserverService: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type:  corev1.ServiceTypeNodePort,
+					Ports: []corev1.ServicePort{{NodePort: 32000}},
+				}},
+			exposeStrategy: kubermaticv1.ExposeStrategySNI,
+			errExpected:    true,
+		},
 		{
 			name: "Verify error when service has less than one ports",
 			apiserverService: corev1.Service{
@@ -178,17 +238,25 @@ func TestSyncClusterAddress(t *testing.T) {
 				},
 			}
 
+			if tc.pinnedExternalName != "" {
+				cluster.Annotations = map[string]string{kubermaticv1.ClusterExternalNameAnnotation: tc.pinnedExternalName}
+			}
+
 			apiserverService := &tc.apiserverService
 			apiserverService.Name = resources.ApiserverExternalServiceName
 			apiserverService.Namespace = fakeClusterNamespaceName
 			lbService := &tc.frontproxyService
 			lbService.Name = resources.FrontLoadBalancerServiceName
 			lbService.Namespace = fakeClusterNamespaceName
-			client := fakectrlruntimeclient.NewFakeClient(apiserverService, lbService)
+			inClusterService := &tc.inClusterService
+			inClusterService.Name = nodeportproxy.InClusterServiceName
+			inClusterService.Namespace = "kubermatic"
+			client := fakectrlruntimeclient.NewFakeClient(apiserverService, lbService, inClusterService)
 
 			seed := &kubermaticv1.Seed{
 				ObjectMeta: metav1.ObjectMeta{
-					Name: fakeDCName,
+					Name:      fakeDCName,
+					Namespace: "kubermatic",
 				},
 				Spec: kubermaticv1.SeedSpec{
 					SeedDNSOverwrite: tc.seedDNSOverwrite,
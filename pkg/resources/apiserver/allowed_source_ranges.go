@@ -0,0 +1,179 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/resources"
+	"github.com/kubermatic/kubermatic/pkg/resources/nodeportproxy"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AllowedSourceRanges returns the CIDRs the apiserver of the cluster is reachable from or nil if
+// it is reachable from everywhere. Besides the ranges from the cluster spec, the machine networks,
+// the given addresses of the nodes and the egress ranges of the seed are always allowed. Nodes
+// behind a NAT gateway connect from the address of the gateway, which is not known here and must
+// be part of the ranges from the cluster spec.
+func AllowedSourceRanges(cluster *kubermaticv1.Cluster, seed *kubermaticv1.Seed, nodeAddresses []string) []string {
+	var nodeRanges []string
+	for _, address := range nodeAddresses {
+		ip := net.ParseIP(address)
+		if ip == nil {
+			continue
+		}
+		if ip.To4() != nil {
+			nodeRanges = append(nodeRanges, ip.String()+"/32")
+		} else {
+			nodeRanges = append(nodeRanges, ip.String()+"/128")
+		}
+	}
+	return allowedSourceRanges(cluster, seed, nodeRanges)
+}
+
+// allowedSourceRanges is AllowedSourceRanges for nodes whose addresses already got converted to CIDRs
+func allowedSourceRanges(cluster *kubermaticv1.Cluster, seed *kubermaticv1.Seed, nodeRanges []string) []string {
+	if len(cluster.Spec.APIServerAllowedSourceRanges) == 0 {
+		return nil
+	}
+
+	ranges := map[string]struct{}{}
+	add := func(cidr string) {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return
+		}
+		ranges[ipNet.String()] = struct{}{}
+	}
+
+	for _, cidr := range cluster.Spec.APIServerAllowedSourceRanges {
+		add(cidr)
+	}
+	for _, network := range cluster.Spec.MachineNetworks {
+		add(network.CIDR)
+	}
+	for _, cidr := range nodeRanges {
+		add(cidr)
+	}
+	// The kubermatic-api and controllers which use a kubeconfig connect from outside of the seed
+	for _, cidr := range seed.Spec.EgressRanges {
+		add(cidr)
+	}
+
+	result := make([]string, 0, len(ranges))
+	for cidr := range ranges {
+		result = append(result, cidr)
+	}
+	// Must be sorted, otherwise the services get updated on every reconciliation
+	sort.Strings(result)
+	return result
+}
+
+// GetAllowedSourceRanges returns the CIDRs the apiserver of the cluster is reachable from, including
+// the addresses of all machines of the cluster. While the machines can not be listed, the ranges
+// which are currently applied are kept so the nodes do not get locked out.
+func GetAllowedSourceRanges(
+	ctx context.Context,
+	log *zap.SugaredLogger,
+	seedClient ctrlruntimeclient.Client,
+	userClusterClientGetter func() (ctrlruntimeclient.Client, error),
+	seed *kubermaticv1.Seed,
+	cluster *kubermaticv1.Cluster) ([]string, error) {
+
+	if len(cluster.Spec.APIServerAllowedSourceRanges) == 0 {
+		return nil, nil
+	}
+
+	var addresses []string
+	var listErr error
+	if cluster.Status.ExtendedHealth.Apiserver == kubermaticv1.HealthStatusUp {
+		addresses, listErr = machineAddresses(ctx, userClusterClientGetter)
+	} else {
+		listErr = fmt.Errorf("apiserver is not healthy")
+	}
+	if listErr == nil {
+		return AllowedSourceRanges(cluster, seed, addresses), nil
+	}
+
+	log.Debugw("Keeping the applied apiserver source ranges, failed to get the machine addresses", zap.Error(listErr))
+	applied, err := appliedAllowedSourceRanges(ctx, seedClient, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return allowedSourceRanges(cluster, seed, applied), nil
+}
+
+// machineAddresses returns the addresses of all machines of a user cluster
+func machineAddresses(ctx context.Context, userClusterClientGetter func() (ctrlruntimeclient.Client, error)) ([]string, error) {
+	client, err := userClusterClientGetter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user cluster client: %v", err)
+	}
+
+	machines := &clusterv1alpha1.MachineList{}
+	if err := client.List(ctx, machines); err != nil {
+		return nil, fmt.Errorf("failed to list machines: %v", err)
+	}
+
+	var addresses []string
+	for _, machine := range machines.Items {
+		for _, address := range machine.Status.Addresses {
+			if address.Type == corev1.NodeInternalIP || address.Type == corev1.NodeExternalIP {
+				addresses = append(addresses, address.Address)
+			}
+		}
+	}
+	return addresses, nil
+}
+
+// appliedAllowedSourceRanges returns the source ranges which are currently applied to the services
+// exposing the apiserver of a cluster
+func appliedAllowedSourceRanges(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) ([]string, error) {
+	name := resources.ApiserverExternalServiceName
+	if cluster.Spec.ExposeStrategy == corev1.ServiceTypeLoadBalancer {
+		name = resources.FrontLoadBalancerServiceName
+	}
+
+	service := &corev1.Service{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: name}, service); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get service %s: %v", name, err)
+	}
+
+	if cluster.Spec.ExposeStrategy == corev1.ServiceTypeLoadBalancer {
+		return service.Spec.LoadBalancerSourceRanges, nil
+	}
+	if annotation := service.Annotations[nodeportproxy.AllowedSourceRangesAnnotationKey]; annotation != "" {
+		return strings.Split(annotation, ","), nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"go.uber.org/zap"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/resources"
+	"github.com/kubermatic/kubermatic/pkg/resources/nodeportproxy"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAllowedSourceRanges(t *testing.T) {
+	testCases := []struct {
+		name          string
+		spec          kubermaticv1.ClusterSpec
+		egressRanges  []string
+		nodeAddresses []string
+		expected      []string
+	}{
+		{
+			name:          "Reachable from everywhere without ranges",
+			spec:          kubermaticv1.ClusterSpec{MachineNetworks: []kubermaticv1.MachineNetworkingConfig{{CIDR: "192.168.0.0/24"}}},
+			nodeAddresses: []string{"192.168.0.10"},
+		},
+		{
+			name: "Nodes and machine networks are always allowed",
+			spec: kubermaticv1.ClusterSpec{
+				APIServerAllowedSourceRanges: []string{"10.0.0.0/8", "192.168.0.0/24"},
+				MachineNetworks:              []kubermaticv1.MachineNetworkingConfig{{CIDR: "192.168.0.0/24"}},
+			},
+			nodeAddresses: []string{"172.16.0.10", "2001:db8::1", "no-ip"},
+			expected:      []string{"10.0.0.0/8", "172.16.0.10/32", "192.168.0.0/24", "2001:db8::1/128"},
+		},
+		{
+			name:         "Egress ranges of the seed are always allowed",
+			spec:         kubermaticv1.ClusterSpec{APIServerAllowedSourceRanges: []string{"10.0.0.0/8"}},
+			egressRanges: []string{"203.0.113.0/24", "10.0.0.0/8"},
+			expected:     []string{"10.0.0.0/8", "203.0.113.0/24"},
+		},
+		{
+			name:     "Ranges get normalized",
+			spec:     kubermaticv1.ClusterSpec{APIServerAllowedSourceRanges: []string{"10.1.2.3/8"}},
+			expected: []string{"10.0.0.0/8"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{Spec: tc.spec}
+			seed := &kubermaticv1.Seed{Spec: kubermaticv1.SeedSpec{EgressRanges: tc.egressRanges}}
+			if diff := deep.Equal(AllowedSourceRanges(cluster, seed, tc.nodeAddresses), tc.expected); diff != nil {
+				t.Errorf("Got unexpected source ranges, diff: %v", diff)
+			}
+		})
+	}
+}
+
+func TestGetAllowedSourceRangesKeepsAppliedRanges(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		Spec: kubermaticv1.ClusterSpec{
+			ExposeStrategy:               corev1.ServiceTypeNodePort,
+			APIServerAllowedSourceRanges: []string{"10.0.0.0/8", "192.168.0.0/16"},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName:  "cluster-test",
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{Apiserver: kubermaticv1.HealthStatusUp},
+		},
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "cluster-test",
+			Name:      resources.ApiserverExternalServiceName,
+			Annotations: map[string]string{
+				nodeportproxy.AllowedSourceRangesAnnotationKey: "10.0.0.0/8,172.16.0.10/32",
+			},
+		},
+	}
+	userClusterClientGetter := func() (ctrlruntimeclient.Client, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	ranges, err := GetAllowedSourceRanges(context.Background(), zap.NewNop().Sugar(), fakectrlruntimeclient.NewFakeClient(service), userClusterClientGetter, &kubermaticv1.Seed{}, cluster)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{"10.0.0.0/8", "172.16.0.10/32", "192.168.0.0/16"}
+	if diff := deep.Equal(ranges, expected); diff != nil {
+		t.Errorf("Got unexpected source ranges, diff: %v", diff)
+	}
+}
@@ -0,0 +1,456 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/resources"
+	"github.com/kubermatic/kubermatic/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	auditPolicyKey = "policy.yaml"
+
+	// auditLogFileSize is the size in megabytes at which the apiserver rotates the audit log
+	auditLogFileSize = 100
+	// auditLogReservedSize is the part of the audit log volume which is reserved for the position database of fluent-bit
+	auditLogReservedSize = "16Mi"
+
+	auditSinkMountPath = "/var/log/kubernetes/audit-sink"
+
+	defaultAuditFileSinkSize = "10Gi"
+
+	// auditPolicyManagedAnnotation marks an audit policy which got written from a preset or a custom
+	// policy ConfigMap, so it can be reverted once neither is configured anymore
+	auditPolicyManagedAnnotation = "kubermatic.io/audit-policy-managed"
+)
+
+var auditPolicyPresets = map[kubermaticv1.AuditPolicyPreset]string{
+	kubermaticv1.AuditPolicyPresetMetadata: `apiVersion: audit.k8s.io/v1
+kind: Policy
+rules:
+- level: Metadata
+`,
+	kubermaticv1.AuditPolicyPresetMinimal: `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages:
+- RequestReceived
+rules:
+- level: None
+  verbs: ["get", "list", "watch"]
+- level: Metadata
+`,
+	kubermaticv1.AuditPolicyPresetRecommended: `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages:
+- RequestReceived
+rules:
+- level: None
+  users: ["system:kube-proxy"]
+  verbs: ["watch"]
+  resources:
+  - group: ""
+    resources: ["endpoints", "services", "services/status"]
+- level: None
+  userGroups: ["system:nodes"]
+  verbs: ["get"]
+  resources:
+  - group: ""
+    resources: ["nodes", "nodes/status"]
+- level: None
+  users:
+  - system:kube-controller-manager
+  - system:kube-scheduler
+  - system:serviceaccount:kube-system:endpoint-controller
+  verbs: ["get", "update"]
+  namespaces: ["kube-system"]
+  resources:
+  - group: ""
+    resources: ["endpoints"]
+  - group: "coordination.k8s.io"
+    resources: ["leases"]
+- level: None
+  nonResourceURLs:
+  - /healthz*
+  - /livez*
+  - /readyz*
+  - /version
+  - /swagger*
+- level: None
+  resources:
+  - group: ""
+    resources: ["events"]
+  - group: "events.k8s.io"
+    resources: ["events"]
+- level: Metadata
+  resources:
+  - group: ""
+    resources: ["secrets", "configmaps", "serviceaccounts/token"]
+  - group: "authentication.k8s.io"
+    resources: ["tokenreviews"]
+- level: Metadata
+  verbs: ["get", "list", "watch"]
+- level: Request
+  verbs: ["create", "update", "patch", "delete", "deletecollection"]
+- level: Metadata
+`,
+}
+
+// AuditLoggingSettings returns the effective audit logging settings of the cluster, taking the
+// defaults and the enforcement of its datacenter into account. It returns nil if audit logging is disabled.
+func AuditLoggingSettings(data *resources.TemplateData) *kubermaticv1.AuditLoggingSettings {
+	var dcSettings *kubermaticv1.AuditLoggingSettings
+	var enforce bool
+	if data.DC() != nil {
+		dcSettings = data.DC().Spec.AuditLogging
+		enforce = data.DC().Spec.EnforceAuditLogging
+	}
+	settings := kubermaticv1.MergeAuditLoggingSettings(data.Cluster().Spec.AuditLogging, dcSettings, enforce)
+	if settings == nil || !settings.Enabled {
+		return nil
+	}
+	return settings
+}
+
+// AuditPolicy returns the audit policy for the given preset
+func AuditPolicy(preset kubermaticv1.AuditPolicyPreset) (string, error) {
+	if preset == "" {
+		preset = kubermaticv1.AuditPolicyPresetMetadata
+	}
+	policy, ok := auditPolicyPresets[preset]
+	if !ok {
+		return "", fmt.Errorf("unknown audit policy preset %q", preset)
+	}
+	return policy, nil
+}
+
+// AuditConfigMapCreator returns the function to create and update the ConfigMap containing the audit policy.
+// If neither a policy preset nor a custom policy is configured, the metadata policy is used. A policy which
+// was edited manually is left untouched in that case.
+func AuditConfigMapCreator(data *resources.TemplateData) reconciling.NamedConfigMapCreatorGetter {
+	return func() (string, reconciling.ConfigMapCreator) {
+		return resources.AuditConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			settings := AuditLoggingSettings(data)
+
+			var policy string
+			managed := true
+			switch {
+			case settings != nil && settings.PolicyConfigMap != "":
+				policyConfigMap, err := data.SeedConfigMap(settings.PolicyConfigMap)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get audit policy ConfigMap %q: %v", settings.PolicyConfigMap, err)
+				}
+				policy = policyConfigMap.Data[auditPolicyKey]
+				if policy == "" {
+					return nil, fmt.Errorf("audit policy ConfigMap %q has no %q key", settings.PolicyConfigMap, auditPolicyKey)
+				}
+			case settings != nil && settings.PolicyPreset != "":
+				var err error
+				if policy, err = AuditPolicy(settings.PolicyPreset); err != nil {
+					return nil, err
+				}
+			case cm.Data == nil || cm.Annotations[auditPolicyManagedAnnotation] != "":
+				policy, _ = AuditPolicy(kubermaticv1.AuditPolicyPresetMetadata)
+				managed = false
+			default:
+				return cm, nil
+			}
+
+			if cm.Data == nil {
+				cm.Data = map[string]string{}
+			}
+			cm.Data[auditPolicyKey] = policy
+
+			if managed {
+				if cm.Annotations == nil {
+					cm.Annotations = map[string]string{}
+				}
+				cm.Annotations[auditPolicyManagedAnnotation] = "true"
+			} else {
+				delete(cm.Annotations, auditPolicyManagedAnnotation)
+			}
+			return cm, nil
+		}
+	}
+}
+
+// AuditSinkPVCCreator returns the function to create the PersistentVolumeClaim used by the audit file sink.
+// The spec of a PersistentVolumeClaim is immutable, so it is only set on creation. Changes of the sink are
+// rejected by the validation instead.
+func AuditSinkPVCCreator(sink *kubermaticv1.AuditFileSink) reconciling.NamedPersistentVolumeClaimCreatorGetter {
+	return func() (string, reconciling.PersistentVolumeClaimCreator) {
+		return resources.AuditLogSinkVolumeName, func(pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error) {
+			pvc.Labels = resources.BaseAppLabels(name, nil)
+			if !pvc.CreationTimestamp.IsZero() {
+				return pvc, nil
+			}
+
+			size, err := auditSinkSize(sink)
+			if err != nil {
+				return nil, err
+			}
+
+			pvc.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{auditSinkAccessMode(sink)}
+			pvc.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: size}
+			if sink.StorageClassName != "" {
+				pvc.Spec.StorageClassName = resources.String(sink.StorageClassName)
+			}
+			return pvc, nil
+		}
+	}
+}
+
+// RemoveAuditSinkPVC deletes the PersistentVolumeClaim of the audit file sink once the sink is not
+// configured anymore. Claims which were not created by us are left alone.
+func RemoveAuditSinkPVC(ctx context.Context, client ctrlruntimeclient.Client, namespace string) error {
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: resources.AuditLogSinkVolumeName}, pvc); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get audit sink PersistentVolumeClaim: %v", err)
+	}
+	if pvc.Labels[resources.AppLabelKey] != name || pvc.DeletionTimestamp != nil {
+		return nil
+	}
+	// The claim stays around until the apiserver pods which still use it are gone
+	if err := client.Delete(ctx, pvc); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete audit sink PersistentVolumeClaim: %v", err)
+	}
+	return nil
+}
+
+func auditSinkSize(sink *kubermaticv1.AuditFileSink) (resource.Quantity, error) {
+	size := sink.Size
+	if size == "" {
+		size = defaultAuditFileSinkSize
+	}
+	quantity, err := resource.ParseQuantity(size)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("failed to parse audit file sink size %q: %v", size, err)
+	}
+	return quantity, nil
+}
+
+func auditSinkAccessMode(sink *kubermaticv1.AuditFileSink) corev1.PersistentVolumeAccessMode {
+	if sink.AccessMode == "" {
+		return corev1.ReadWriteOnce
+	}
+	return sink.AccessMode
+}
+
+// auditSinkAffinity returns the affinity which schedules all apiserver replicas to the same node,
+// as a ReadWriteOnce volume can only be attached to a single node
+func auditSinkAffinity(clusterName string) *corev1.Affinity {
+	return &corev1.Affinity{
+		PodAffinity: &corev1.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							resources.AppLabelKey:     name,
+							resources.ClusterLabelKey: clusterName,
+						},
+					},
+					TopologyKey: resources.TopologyKeyHostname,
+				},
+			},
+		},
+	}
+}
+
+// auditLogFileRetention returns the number of rotated audit log files every apiserver replica keeps.
+// Without a file sink the audit log is kept in an emptyDir and the apiserver keeps 3 rotated files. With a
+// file sink every replica keeps its files in its own directory of the sink volume. One more directory than
+// there are replicas has to fit, as the directory of a replaced pod is only removed when its successor starts.
+func auditLogFileRetention(settings *kubermaticv1.AuditLoggingSettings, replicas int32) (int64, error) {
+	if settings == nil || settings.Sink == nil || settings.Sink.File == nil {
+		return 3, nil
+	}
+
+	size, err := auditSinkSize(settings.Sink.File)
+	if err != nil {
+		return 0, err
+	}
+
+	// The current file and the oldest rotated file, which gets removed shortly after a rotation, need
+	// to fit on the volume as well
+	reserved := resource.MustParse(auditLogReservedSize)
+	usable := size.Value()/int64(replicas+1) - reserved.Value()
+	maxBackups := usable/(auditLogFileSize*1024*1024) - 2
+	if maxBackups < 1 {
+		minSize := resource.MustParse(resources.AuditFileSinkMinSize)
+		minSize.Set(minSize.Value() * int64(replicas+1))
+		return 0, fmt.Errorf("audit file sink size %q is too small for %d apiserver replicas, it must be at least %s", size.String(), replicas, minSize.String())
+	}
+	return maxBackups, nil
+}
+
+// auditSinkCleanupContainer returns the init container which removes the directories of replaced apiserver
+// pods from the audit file sink volume. The directories of the other replicas, whose audit log was written
+// most recently, are kept.
+func auditSinkCleanupContainer(data *resources.TemplateData, replicas int32) corev1.Container {
+	return corev1.Container{
+		Name:    "audit-sink-cleanup",
+		Image:   data.ImageRegistry(resources.RegistryGCR) + "/google_containers/hyperkube-amd64:v" + data.Cluster().ComponentVersion(kubermaticv1.ControlPlaneComponentApiserver).String(),
+		Command: []string{"/bin/sh", "-ec"},
+		Args: []string{fmt.Sprintf(
+			`cd %s && ls -1t */audit.log 2>/dev/null | xargs -r -n1 dirname | grep -vx "$POD_NAME" | tail -n +%d | xargs -r rm -rf`,
+			auditSinkMountPath, replicas+1,
+		)},
+		Env: []corev1.EnvVar{auditSinkPodNameEnvVar()},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      resources.AuditLogVolumeName,
+				MountPath: auditSinkMountPath,
+			},
+		},
+	}
+}
+
+func auditSinkPodNameEnvVar() corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: "POD_NAME",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				APIVersion: "v1",
+				FieldPath:  "metadata.name",
+			},
+		},
+	}
+}
+
+// withAuditSink mounts the directory of the pod on the audit file sink volume as the audit log volume of the container
+func withAuditSink(container *corev1.Container) {
+	mounted := false
+	for i := range container.VolumeMounts {
+		if container.VolumeMounts[i].Name == resources.AuditLogVolumeName {
+			container.VolumeMounts[i].SubPathExpr = "$(POD_NAME)"
+			mounted = true
+		}
+	}
+	if mounted {
+		container.Env = append(container.Env, auditSinkPodNameEnvVar())
+	}
+}
+
+// auditLogsSidecar returns the fluent-bit container which tails the audit log of the apiserver
+// and forwards it to the configured sinks
+func auditLogsSidecar(data *resources.TemplateData, settings *kubermaticv1.AuditLoggingSettings) (*corev1.Container, error) {
+	args := []string{
+		"-i", "tail",
+		"-p", "path=/var/log/kubernetes/audit/audit.log",
+		"-p", "db=/var/log/kubernetes/audit/fluentbit.db",
+		"-o", "stdout",
+	}
+	container := &corev1.Container{
+		Name:    "audit-logs",
+		Image:   data.ImageRegistry(resources.RegistryDocker) + "/fluent/fluent-bit:1.8.15",
+		Command: []string{"/fluent-bit/bin/fluent-bit"},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      resources.AuditLogVolumeName,
+				MountPath: "/var/log/kubernetes/audit",
+				ReadOnly:  false,
+			},
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("10Mi"),
+				corev1.ResourceCPU:    resource.MustParse("5m"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("60Mi"),
+				corev1.ResourceCPU:    resource.MustParse("50m"),
+			},
+		},
+	}
+	if sink := settings.Sink; sink != nil {
+		if sink.Syslog != nil {
+			protocol := sink.Syslog.Protocol
+			if protocol == "" {
+				protocol = "udp"
+			}
+			args = append(args,
+				"-o", "syslog",
+				"-p", "match=*",
+				"-p", "host="+sink.Syslog.Host,
+				"-p", fmt.Sprintf("port=%d", sink.Syslog.Port),
+				"-p", "mode="+protocol,
+				"-p", "syslog_format=rfc5424",
+				"-p", "syslog_message_key=log",
+			)
+		}
+
+		if sink.Webhook != nil {
+			webhookArgs, err := auditWebhookArgs(sink.Webhook.URL)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, webhookArgs...)
+		}
+	}
+
+	container.Args = args
+	return container, nil
+}
+
+func auditWebhookArgs(rawURL string) ([]string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse audit webhook URL %q: %v", rawURL, err)
+	}
+
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("audit webhook URL %q has no host", rawURL)
+	}
+
+	port := u.Port()
+	switch {
+	case port != "":
+	case u.Scheme == "https":
+		port = "443"
+	case u.Scheme == "http":
+		port = "80"
+	default:
+		return nil, fmt.Errorf("audit webhook URL %q must use the http or https scheme", rawURL)
+	}
+
+	args := []string{
+		"-o", "http",
+		"-p", "match=*",
+		"-p", "host=" + u.Hostname(),
+		"-p", "port=" + port,
+		"-p", "uri=" + u.RequestURI(),
+		"-p", "format=json",
+	}
+	if u.Scheme == "https" {
+		args = append(args, "-p", "tls=on")
+	}
+	return args, nil
+}
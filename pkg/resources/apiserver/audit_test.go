@@ -0,0 +1,338 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAuditPolicyPresets(t *testing.T) {
+	for _, preset := range kubermaticv1.AllAuditPolicyPresets {
+		policy, err := AuditPolicy(preset)
+		if err != nil {
+			t.Fatalf("failed to get policy for preset %q: %v", preset, err)
+		}
+		if !strings.HasPrefix(policy, "apiVersion: audit.k8s.io/v1\nkind: Policy\n") {
+			t.Errorf("policy for preset %q is not an audit policy:\n%s", preset, policy)
+		}
+	}
+
+	if _, err := AuditPolicy("verbose"); err == nil {
+		t.Error("expected an error for an unknown preset")
+	}
+}
+
+func TestAuditLogsSidecar(t *testing.T) {
+	testCases := []struct {
+		name            string
+		sink            *kubermaticv1.AuditSinkSettings
+		expectedOutputs []string
+		expectedArgs    []string
+		errExpected     bool
+	}{
+		{
+			name:            "no sink writes to stdout only",
+			expectedOutputs: []string{"stdout"},
+		},
+		{
+			name: "file sink is kept by the apiserver",
+			sink: &kubermaticv1.AuditSinkSettings{
+				File: &kubermaticv1.AuditFileSink{},
+			},
+			expectedOutputs: []string{"stdout"},
+		},
+		{
+			name: "syslog sink defaults to udp",
+			sink: &kubermaticv1.AuditSinkSettings{
+				Syslog: &kubermaticv1.AuditSyslogSink{Host: "syslog.example.com", Port: 514},
+			},
+			expectedOutputs: []string{"stdout", "syslog"},
+			expectedArgs:    []string{"host=syslog.example.com", "port=514", "mode=udp"},
+		},
+		{
+			name: "https webhook sink",
+			sink: &kubermaticv1.AuditSinkSettings{
+				Webhook: &kubermaticv1.AuditWebhookSink{URL: "https://audit.example.com/events?source=kubermatic"},
+			},
+			expectedOutputs: []string{"stdout", "http"},
+			expectedArgs:    []string{"host=audit.example.com", "port=443", "uri=/events?source=kubermatic", "tls=on"},
+		},
+		{
+			name: "webhook sink with invalid scheme",
+			sink: &kubermaticv1.AuditSinkSettings{
+				Webhook: &kubermaticv1.AuditWebhookSink{URL: "ftp://audit.example.com"},
+			},
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			settings := &kubermaticv1.AuditLoggingSettings{Enabled: true, Sink: tc.sink}
+			data := auditTemplateData(settings)
+			data.OverwriteRegistry = "registry.example.com"
+
+			container, err := auditLogsSidecar(data, settings)
+			if (err != nil) != tc.errExpected {
+				t.Fatalf("Expected err: %t, but got err %v", tc.errExpected, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if expectedImage := "registry.example.com/fluent/fluent-bit:1.8.15"; container.Image != expectedImage {
+				t.Errorf("expected image %q, got %q", expectedImage, container.Image)
+			}
+
+			var outputs []string
+			for i, arg := range container.Args {
+				if arg == "-o" && i+1 < len(container.Args) {
+					outputs = append(outputs, container.Args[i+1])
+				}
+			}
+			if strings.Join(outputs, ",") != strings.Join(tc.expectedOutputs, ",") {
+				t.Errorf("expected outputs %v, got %v", tc.expectedOutputs, outputs)
+			}
+
+			args := strings.Join(container.Args, " ")
+			for _, expected := range tc.expectedArgs {
+				if !strings.Contains(args, expected) {
+					t.Errorf("expected args to contain %q, got %q", expected, args)
+				}
+			}
+		})
+	}
+}
+
+func auditTemplateData(settings *kubermaticv1.AuditLoggingSettings) *resources.TemplateData {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec:       kubermaticv1.ClusterSpec{AuditLogging: settings},
+		Status:     kubermaticv1.ClusterStatus{NamespaceName: "cluster-test"},
+	}
+	return resources.NewTemplateData(context.Background(), fakectrlruntimeclient.NewFakeClient(), cluster, &kubermaticv1.Datacenter{}, &kubermaticv1.Seed{},
+		"", "", "", resource.Quantity{}, "", "", false, false, "", "", "", "", false, "", "", false)
+}
+
+func TestAuditConfigMapCreator(t *testing.T) {
+	metadataPolicy, _ := AuditPolicy(kubermaticv1.AuditPolicyPresetMetadata)
+	recommendedPolicy, _ := AuditPolicy(kubermaticv1.AuditPolicyPresetRecommended)
+	customPolicy := "apiVersion: audit.k8s.io/v1\nkind: Policy\nrules:\n- level: RequestResponse\n"
+
+	testCases := []struct {
+		name            string
+		settings        *kubermaticv1.AuditLoggingSettings
+		existing        *corev1.ConfigMap
+		expectedPolicy  string
+		expectedManaged bool
+	}{
+		{
+			name:           "metadata policy by default",
+			existing:       &corev1.ConfigMap{},
+			expectedPolicy: metadataPolicy,
+		},
+		{
+			name:            "preset",
+			settings:        &kubermaticv1.AuditLoggingSettings{Enabled: true, PolicyPreset: kubermaticv1.AuditPolicyPresetRecommended},
+			existing:        &corev1.ConfigMap{},
+			expectedPolicy:  recommendedPolicy,
+			expectedManaged: true,
+		},
+		{
+			name:     "cleared preset reverts to the metadata policy",
+			settings: &kubermaticv1.AuditLoggingSettings{Enabled: true},
+			existing: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{auditPolicyManagedAnnotation: "true"}},
+				Data:       map[string]string{auditPolicyKey: recommendedPolicy},
+			},
+			expectedPolicy: metadataPolicy,
+		},
+		{
+			name:     "manually edited policy is kept",
+			settings: &kubermaticv1.AuditLoggingSettings{Enabled: true},
+			existing: &corev1.ConfigMap{
+				Data: map[string]string{auditPolicyKey: customPolicy},
+			},
+			expectedPolicy: customPolicy,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, create := AuditConfigMapCreator(auditTemplateData(tc.settings))()
+			cm, err := create(tc.existing)
+			if err != nil {
+				t.Fatalf("failed to create ConfigMap: %v", err)
+			}
+			if cm.Data[auditPolicyKey] != tc.expectedPolicy {
+				t.Errorf("expected policy\n%s\ngot\n%s", tc.expectedPolicy, cm.Data[auditPolicyKey])
+			}
+			if managed := cm.Annotations[auditPolicyManagedAnnotation] != ""; managed != tc.expectedManaged {
+				t.Errorf("expected managed=%t, got annotations %v", tc.expectedManaged, cm.Annotations)
+			}
+		})
+	}
+}
+
+func TestAuditLogFileRetention(t *testing.T) {
+	testCases := []struct {
+		name               string
+		sink               *kubermaticv1.AuditSinkSettings
+		replicas           int32
+		expectedMaxBackups int64
+		errExpected        bool
+	}{
+		{
+			name:               "emptyDir without file sink",
+			replicas:           2,
+			expectedMaxBackups: 3,
+		},
+		{
+			name:               "default size",
+			sink:               &kubermaticv1.AuditSinkSettings{File: &kubermaticv1.AuditFileSink{}},
+			replicas:           1,
+			expectedMaxBackups: 49,
+		},
+		{
+			name:               "default size shared by 3 replicas",
+			sink:               &kubermaticv1.AuditSinkSettings{File: &kubermaticv1.AuditFileSink{}},
+			replicas:           3,
+			expectedMaxBackups: 23,
+		},
+		{
+			name:               "minimum size",
+			sink:               &kubermaticv1.AuditSinkSettings{File: &kubermaticv1.AuditFileSink{Size: "1Gi"}},
+			replicas:           1,
+			expectedMaxBackups: 2,
+		},
+		{
+			name:        "too small for the replicas",
+			sink:        &kubermaticv1.AuditSinkSettings{File: &kubermaticv1.AuditFileSink{Size: "1Gi"}},
+			replicas:    3,
+			errExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			maxBackups, err := auditLogFileRetention(&kubermaticv1.AuditLoggingSettings{Enabled: true, Sink: tc.sink}, tc.replicas)
+			if (err != nil) != tc.errExpected {
+				t.Fatalf("Expected err: %t, but got err %v", tc.errExpected, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if maxBackups != tc.expectedMaxBackups {
+				t.Errorf("expected %d rotated files, got %d", tc.expectedMaxBackups, maxBackups)
+			}
+		})
+	}
+}
+
+func TestAuditSinkPVCCreator(t *testing.T) {
+	sink := &kubermaticv1.AuditFileSink{Size: "5Gi", StorageClassName: "kubermatic-fast"}
+	_, create := AuditSinkPVCCreator(sink)()
+
+	pvc, err := create(&corev1.PersistentVolumeClaim{})
+	if err != nil {
+		t.Fatalf("failed to create PersistentVolumeClaim: %v", err)
+	}
+	if size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; size.Cmp(resource.MustParse("5Gi")) != 0 {
+		t.Errorf("expected size 5Gi, got %s", size.String())
+	}
+	if len(pvc.Spec.AccessModes) != 1 || pvc.Spec.AccessModes[0] != corev1.ReadWriteOnce {
+		t.Errorf("expected access mode %s, got %v", corev1.ReadWriteOnce, pvc.Spec.AccessModes)
+	}
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName != "kubermatic-fast" {
+		t.Errorf("expected storage class kubermatic-fast, got %v", pvc.Spec.StorageClassName)
+	}
+
+	// The spec of an existing claim is immutable
+	existing := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	}
+	pvc, err = create(existing)
+	if err != nil {
+		t.Fatalf("failed to update PersistentVolumeClaim: %v", err)
+	}
+	if size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; size.Cmp(resource.MustParse("1Gi")) != 0 {
+		t.Errorf("expected the size of the existing claim to be kept, got %s", size.String())
+	}
+}
+
+func TestRemoveAuditSinkPVC(t *testing.T) {
+	testCases := []struct {
+		name            string
+		labels          map[string]string
+		expectedDeleted bool
+	}{
+		{
+			name:            "claim of the audit sink gets deleted",
+			labels:          resources.BaseAppLabels(name, nil),
+			expectedDeleted: true,
+		},
+		{
+			name:   "foreign claim is kept",
+			labels: map[string]string{"app": "something-else"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "cluster-test",
+					Name:      resources.AuditLogSinkVolumeName,
+					Labels:    tc.labels,
+				},
+			}
+			client := fakectrlruntimeclient.NewFakeClient(pvc)
+			ctx := context.Background()
+
+			if err := RemoveAuditSinkPVC(ctx, client, "cluster-test"); err != nil {
+				t.Fatalf("failed to remove PersistentVolumeClaim: %v", err)
+			}
+
+			err := client.Get(ctx, types.NamespacedName{Namespace: "cluster-test", Name: resources.AuditLogSinkVolumeName}, &corev1.PersistentVolumeClaim{})
+			if deleted := kerrors.IsNotFound(err); deleted != tc.expectedDeleted {
+				t.Errorf("expected deleted=%t, got err %v", tc.expectedDeleted, err)
+			}
+		})
+	}
+
+	if err := RemoveAuditSinkPVC(context.Background(), fakectrlruntimeclient.NewFakeClient(), "cluster-test"); err != nil {
+		t.Errorf("expected no error without a claim, got %v", err)
+	}
+}
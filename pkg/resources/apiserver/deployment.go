@@ -18,6 +18,7 @@ package apiserver
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
@@ -54,22 +55,6 @@ const (
 	defaultNodePortRange = "30000-32767"
 )
 
-func AuditConfigMapCreator() reconciling.NamedConfigMapCreatorGetter {
-	return func() (string, reconciling.ConfigMapCreator) {
-		return resources.AuditConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
-			if cm.Data == nil {
-				cm.Data = map[string]string{
-					"policy.yaml": `apiVersion: audit.k8s.io/v1
-kind: Policy
-rules:
-- level: Metadata
-`}
-			}
-			return cm, nil
-		}
-	}
-}
-
 // DeploymentCreator returns the function to create and update the API server deployment
 func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bool) reconciling.NamedDeploymentCreatorGetter {
 	return func() (string, reconciling.DeploymentCreator) {
@@ -90,6 +75,35 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 			volumes := getVolumes()
 			volumeMounts := getVolumeMounts()
 
+			auditLogging := AuditLoggingSettings(data)
+			var auditLogsContainer *corev1.Container
+			if auditLogging != nil {
+				var err error
+				auditLogsContainer, err = auditLogsSidecar(data, auditLogging)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get audit-logs sidecar: %v", err)
+				}
+			}
+
+			auditLogMaxBackups, err := auditLogFileRetention(auditLogging, *dep.Spec.Replicas)
+			if err != nil {
+				return nil, err
+			}
+
+			// The audit log is only kept on a persistent volume if a file sink is configured
+			auditSink := auditLogging != nil && auditLogging.Sink != nil && auditLogging.Sink.File != nil
+			if auditSink {
+				for i := range volumes {
+					if volumes[i].Name == resources.AuditLogVolumeName {
+						volumes[i].VolumeSource = corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: resources.AuditLogSinkVolumeName,
+							},
+						}
+					}
+				}
+			}
+
 			if enableOIDCAuthentication && len(data.OIDCCAFile()) > 0 {
 				volumes = append(volumes, getDexCASecretVolume())
 				volumeMounts = append(volumeMounts, corev1.VolumeMount{
@@ -113,7 +127,7 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 				},
 			}
 
-			etcdEndpoints := etcd.GetClientEndpoints(data.Cluster().Status.NamespaceName)
+			etcdEndpoints := etcd.GetClientEndpoints(data.Cluster())
 
 			// Configure user cluster DNS resolver for this pod.
 			dep.Spec.Template.Spec.DNSPolicy, dep.Spec.Template.Spec.DNSConfig, err = resources.UserClusterDNSPolicyAndConfig(data)
@@ -124,6 +138,9 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 			dep.Spec.Template.Spec.InitContainers = []corev1.Container{
 				etcdrunning.Container(etcdEndpoints, data),
 			}
+			if auditSink {
+				dep.Spec.Template.Spec.InitContainers = append(dep.Spec.Template.Spec.InitContainers, auditSinkCleanupContainer(data, *dep.Spec.Replicas))
+			}
 
 			openvpnSidecar, err := vpnsidecar.OpenVPNSidecarContainer(data, "openvpn-client")
 			if err != nil {
@@ -138,12 +155,12 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 			if err != nil {
 				return nil, fmt.Errorf("failed to get dnat-controller sidecar: %v", err)
 			}
-			auditLogEnabled := data.Cluster().Spec.AuditLogging != nil && data.Cluster().Spec.AuditLogging.Enabled
+			auditLogEnabled := auditLogging != nil
 			endpointReconcilingDisabled := false
 			if data.Cluster().Spec.ComponentsOverride.Apiserver.EndpointReconcilingDisabled != nil {
 				endpointReconcilingDisabled = *data.Cluster().Spec.ComponentsOverride.Apiserver.EndpointReconcilingDisabled
 			}
-			flags, err := getApiserverFlags(data, etcdEndpoints, enableOIDCAuthentication, auditLogEnabled, endpointReconcilingDisabled)
+			flags, err := getApiserverFlags(data, etcdEndpoints, enableOIDCAuthentication, auditLogEnabled, auditLogMaxBackups, endpointReconcilingDisabled)
 			if err != nil {
 				return nil, err
 			}
@@ -158,7 +175,7 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 				*dnatControllerSidecar,
 				{
 					Name:    resources.ApiserverDeploymentName,
-					Image:   data.ImageRegistry(resources.RegistryGCR) + "/google_containers/hyperkube-amd64:v" + data.Cluster().Spec.Version.String(),
+					Image:   data.ImageRegistry(resources.RegistryGCR) + "/google_containers/hyperkube-amd64:v" + data.Cluster().ComponentVersion(kubermaticv1.ControlPlaneComponentApiserver).String(),
 					Command: []string{"/hyperkube", "kube-apiserver"},
 					Env:     envVars,
 					Args:    flags,
@@ -209,42 +226,27 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 				return nil, fmt.Errorf("failed to set resource requirements: %v", err)
 			}
 
-			if data.Cluster().Spec.AuditLogging != nil && data.Cluster().Spec.AuditLogging.Enabled {
-				dep.Spec.Template.Spec.Containers = append(dep.Spec.Template.Spec.Containers,
-					corev1.Container{
-						Name:    "audit-logs",
-						Image:   "docker.io/fluent/fluent-bit:1.2.2",
-						Command: []string{"/fluent-bit/bin/fluent-bit"},
-						Args:    []string{"-i", "tail", "-p", "path=/var/log/kubernetes/audit/audit.log", "-p", "db=/var/log/kubernetes/audit/fluentbit.db", "-o", "stdout"},
-						VolumeMounts: []corev1.VolumeMount{
-							{
-								Name:      resources.AuditLogVolumeName,
-								MountPath: "/var/log/kubernetes/audit",
-								ReadOnly:  false,
-							},
-						},
-						Resources: corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceMemory: resource.MustParse("10Mi"),
-								corev1.ResourceCPU:    resource.MustParse("5m"),
-							},
-							Limits: corev1.ResourceList{
-								corev1.ResourceMemory: resource.MustParse("60Mi"),
-								corev1.ResourceCPU:    resource.MustParse("50m"),
-							},
-						},
-					},
-				)
+			if auditLogging != nil {
+				dep.Spec.Template.Spec.Containers = append(dep.Spec.Template.Spec.Containers, *auditLogsContainer)
 			}
 
 			dep.Spec.Template.Spec.Affinity = resources.HostnameAntiAffinity(name, data.Cluster().Name)
+			if auditSink {
+				// Every replica writes to its own directory of the sink volume
+				for i := range dep.Spec.Template.Spec.Containers {
+					withAuditSink(&dep.Spec.Template.Spec.Containers[i])
+				}
+				if auditSinkAccessMode(auditLogging.Sink.File) == corev1.ReadWriteOnce {
+					dep.Spec.Template.Spec.Affinity = auditSinkAffinity(data.Cluster().Name)
+				}
+			}
 
 			return dep, nil
 		}
 	}
 }
 
-func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, enableOIDCAuthentication, auditLogEnabled, endpointReconcilingDisabled bool) ([]string, error) {
+func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, enableOIDCAuthentication, auditLogEnabled bool, auditLogMaxBackups int64, endpointReconcilingDisabled bool) ([]string, error) {
 	nodePortRange := data.NodePortRange()
 	if nodePortRange == "" {
 		nodePortRange = defaultNodePortRange
@@ -290,8 +292,8 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 		"--service-node-port-range", nodePortRange,
 		"--allow-privileged",
 		"--audit-log-maxage", "30",
-		"--audit-log-maxbackup", "3",
-		"--audit-log-maxsize", "100",
+		"--audit-log-maxbackup", strconv.FormatInt(auditLogMaxBackups, 10),
+		"--audit-log-maxsize", strconv.Itoa(auditLogFileSize),
 		"--audit-log-path", "/var/log/kubernetes/audit/audit.log",
 		"--tls-cert-file", "/etc/kubernetes/tls/apiserver-tls.crt",
 		"--tls-private-key-file", "/etc/kubernetes/tls/apiserver-tls.key",
@@ -18,7 +18,9 @@ package apiserver
 
 import (
 	"fmt"
+	"strings"
 
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/resources"
 	"github.com/kubermatic/kubermatic/pkg/resources/nodeportproxy"
 	"github.com/kubermatic/kubermatic/pkg/resources/reconciling"
@@ -50,8 +52,11 @@ func InternalServiceCreator() reconciling.NamedServiceCreatorGetter {
 	}
 }
 
-// ExternalServiceCreator returns the function to reconcile the external API server service
-func ExternalServiceCreator(exposeStrategy corev1.ServiceType) reconciling.NamedServiceCreatorGetter {
+// ExternalServiceCreator returns the function to reconcile the external API server service.
+// The externalName is the hostname the service gets routed by when using exposeStrategy==SNI.
+// The allowedSourceRanges are enforced by the seed NodeportProxy, with exposeStrategy==LoadBalancer
+// they are set on the fronting LoadBalancer instead.
+func ExternalServiceCreator(exposeStrategy corev1.ServiceType, externalName string, allowedSourceRanges []string) reconciling.NamedServiceCreatorGetter {
 	return func() (string, reconciling.ServiceCreator) {
 		return resources.ApiserverExternalServiceName, func(se *corev1.Service) (*corev1.Service, error) {
 			// Always set it to NodePort. Even when using exposeStrategy==LoadBalancer, we create
@@ -63,15 +68,35 @@ func ExternalServiceCreator(exposeStrategy corev1.ServiceType) reconciling.Named
 			if se.Annotations == nil {
 				se.Annotations = map[string]string{}
 			}
-			if exposeStrategy != corev1.ServiceTypeNodePort && exposeStrategy != corev1.ServiceTypeLoadBalancer {
-				return nil, fmt.Errorf("exposeStrategy on the cluster must be one of `NodePort` or `LoadBalancer, got %q`", exposeStrategy)
+			if exposeStrategy != corev1.ServiceTypeNodePort && exposeStrategy != corev1.ServiceTypeLoadBalancer && exposeStrategy != kubermaticv1.ExposeStrategySNI {
+				return nil, fmt.Errorf("exposeStrategy on the cluster must be one of `NodePort`, `LoadBalancer` or `SNI`, got %q", exposeStrategy)
 			}
-			if exposeStrategy == corev1.ServiceTypeNodePort {
+			switch exposeStrategy {
+			case corev1.ServiceTypeLoadBalancer:
+				se.Annotations[nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey] = "true"
+				delete(se.Annotations, "nodeport-proxy.k8s.io/expose")
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeInClusterAnnotationKey)
+			case kubermaticv1.ExposeStrategySNI:
+				// External clients only reach the apiserver on port 443 of the SNI listener. Clients in
+				// the user cluster connect to the apiserver by its IP and thus do not send a server name,
+				// the NodePort is exposed for them on the in-cluster LoadBalancer instead.
+				se.Annotations[nodeportproxy.NodePortProxyExposeInClusterAnnotationKey] = "true"
+				delete(se.Annotations, "nodeport-proxy.k8s.io/expose")
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+			default:
 				se.Annotations["nodeport-proxy.k8s.io/expose"] = "true"
 				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeInClusterAnnotationKey)
+			}
+			if exposeStrategy == kubermaticv1.ExposeStrategySNI && externalName != "" {
+				se.Annotations[nodeportproxy.SNIHostnameAnnotationKey] = externalName
 			} else {
-				se.Annotations[nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey] = "true"
-				delete(se.Annotations, "nodeport-proxy.k8s.io/expose")
+				delete(se.Annotations, nodeportproxy.SNIHostnameAnnotationKey)
+			}
+			if exposeStrategy != corev1.ServiceTypeLoadBalancer && len(allowedSourceRanges) > 0 {
+				se.Annotations[nodeportproxy.AllowedSourceRangesAnnotationKey] = strings.Join(allowedSourceRanges, ",")
+			} else {
+				delete(se.Annotations, nodeportproxy.AllowedSourceRangesAnnotationKey)
 			}
 
 			se.Spec.Selector = map[string]string{
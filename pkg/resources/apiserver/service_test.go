@@ -19,6 +19,11 @@ package apiserver
 import (
 	"testing"
 
+	"github.com/go-test/deep"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/resources/nodeportproxy"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -37,6 +42,10 @@ func TestExternalServiceCreatorRequiresExposeStrategy(t *testing.T) {
 			name:           "LoadBalancer is accepted as exposeStrategy",
 			exposeStrategy: corev1.ServiceTypeLoadBalancer,
 		},
+		{
+			name:           "SNI is accepted as exposeStrategy",
+			exposeStrategy: kubermaticv1.ExposeStrategySNI,
+		},
 		{
 			name:        "Empty is not accepted as exposeStrategy",
 			errExpected: true,
@@ -45,7 +54,7 @@ func TestExternalServiceCreatorRequiresExposeStrategy(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, creator := ExternalServiceCreator(tc.exposeStrategy)()
+			_, creator := ExternalServiceCreator(tc.exposeStrategy, "", nil)()
 			_, err := creator(&corev1.Service{})
 			if (err != nil) != tc.errExpected {
 				t.Errorf("Expected err: %t, but got err %v", tc.errExpected, err)
@@ -104,7 +113,7 @@ func TestExternalServiceCreatorSetsPort(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, creator := ExternalServiceCreator(tc.inService.Spec.Type)()
+			_, creator := ExternalServiceCreator(tc.inService.Spec.Type, "", nil)()
 			svc, err := creator(tc.inService)
 			if err != nil {
 				t.Fatalf("Unexpected error: %v", err)
@@ -127,3 +136,77 @@ func TestExternalServiceCreatorSetsPort(t *testing.T) {
 		})
 	}
 }
+
+func TestExternalServiceCreatorSetsAnnotations(t *testing.T) {
+	const externalName = "test-cluster.europe-west3-c.dev.kubermatic.io"
+
+	testCases := []struct {
+		name                string
+		exposeStrategy      corev1.ServiceType
+		allowedSourceRanges []string
+		expectedAnnotations map[string]string
+	}{
+		{
+			name:           "NodePort is exposed by the nodeport-proxy",
+			exposeStrategy: corev1.ServiceTypeNodePort,
+			expectedAnnotations: map[string]string{
+				"nodeport-proxy.k8s.io/expose": "true",
+			},
+		},
+		{
+			name:           "LoadBalancer is exposed by the namespaced nodeport-proxy",
+			exposeStrategy: corev1.ServiceTypeLoadBalancer,
+			expectedAnnotations: map[string]string{
+				nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey: "true",
+			},
+		},
+		{
+			name:           "SNI is routed by the external name and exposed in-cluster by the nodeport-proxy",
+			exposeStrategy: kubermaticv1.ExposeStrategySNI,
+			expectedAnnotations: map[string]string{
+				nodeportproxy.NodePortProxyExposeInClusterAnnotationKey: "true",
+				nodeportproxy.SNIHostnameAnnotationKey:                  externalName,
+			},
+		},
+		{
+			name:                "NodePort restricts the source ranges",
+			exposeStrategy:      corev1.ServiceTypeNodePort,
+			allowedSourceRanges: []string{"10.0.0.0/8", "192.168.1.1/32"},
+			expectedAnnotations: map[string]string{
+				"nodeport-proxy.k8s.io/expose":                 "true",
+				nodeportproxy.AllowedSourceRangesAnnotationKey: "10.0.0.0/8,192.168.1.1/32",
+			},
+		},
+		{
+			name:                "LoadBalancer leaves the source ranges to the LoadBalancer",
+			exposeStrategy:      corev1.ServiceTypeLoadBalancer,
+			allowedSourceRanges: []string{"10.0.0.0/8"},
+			expectedAnnotations: map[string]string{
+				nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey: "true",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The service starts with the annotations of all strategies to make sure the stale ones get removed
+			inService := &corev1.Service{}
+			inService.Annotations = map[string]string{
+				"nodeport-proxy.k8s.io/expose":                           "true",
+				nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey: "true",
+				nodeportproxy.NodePortProxyExposeInClusterAnnotationKey:  "true",
+				nodeportproxy.SNIHostnameAnnotationKey:                   "old.dev.kubermatic.io",
+				nodeportproxy.AllowedSourceRangesAnnotationKey:           "172.16.0.0/12",
+			}
+
+			_, creator := ExternalServiceCreator(tc.exposeStrategy, externalName, tc.allowedSourceRanges)()
+			svc, err := creator(inService)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := deep.Equal(svc.Annotations, tc.expectedAnnotations); diff != nil {
+				t.Errorf("Got unexpected annotations, diff: %v", diff)
+			}
+		})
+	}
+}
@@ -42,6 +42,9 @@ func Spec(apiCluster apiv1.Cluster, dc *kubermaticv1.Datacenter, secretKeyGetter
 		AuditLogging:                        apiCluster.Spec.AuditLogging,
 		Openshift:                           apiCluster.Spec.Openshift,
 		AdmissionPlugins:                    apiCluster.Spec.AdmissionPlugins,
+		EtcdBackup:                          apiCluster.Spec.EtcdBackup,
+		Hibernation:                         apiCluster.Spec.Hibernation,
+		APIServerAllowedSourceRanges:        apiCluster.Spec.APIServerAllowedSourceRanges,
 	}
 
 	providerName, err := provider.ClusterCloudProviderName(spec.Cloud)
@@ -17,6 +17,7 @@ limitations under the License.
 package clusterautoscaler
 
 import (
+	"context"
 	"fmt"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
@@ -26,10 +27,13 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var (
@@ -155,6 +159,33 @@ func DeploymentCreator(data clusterautoscalerData) reconciling.NamedDeploymentCr
 	}
 }
 
+// IsSupported returns whether there is a cluster-autoscaler image for the version of the cluster
+func IsSupported(cluster *kubermaticv1.Cluster) bool {
+	return getTag(cluster) != ""
+}
+
+// CleanupDeployment deletes the cluster-autoscaler deployment of clusters which have the autoscaler disabled.
+// The deployment is only reconciled while the autoscaler is enabled, so it has to be removed explicitly.
+// It is looked up first, so clusters without a deployment don't cause a request to the apiserver.
+func CleanupDeployment(ctx context.Context, client ctrlruntimeclient.Client, cluster *kubermaticv1.Cluster) error {
+	if cluster.Annotations[kubermaticv1.AnnotationNameClusterAutoscalerEnabled] != "" || cluster.Status.NamespaceName == "" {
+		return nil
+	}
+
+	deployment := &appsv1.Deployment{}
+	name := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.ClusterAutoscalerDeploymentName}
+	if err := client.Get(ctx, name, deployment); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get the cluster-autoscaler deployment: %v", err)
+	}
+	if err := client.Delete(ctx, deployment); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the cluster-autoscaler deployment: %v", err)
+	}
+	return nil
+}
+
 // getTag returns the correct tag for the cluster version. We need to have a distinct CA
 // version for each Kubernetes version, because the CA imports the scheduler code and the
 // behaviour of that imported code has to match with what the actual scheduler does
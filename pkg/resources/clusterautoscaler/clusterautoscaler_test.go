@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterautoscaler
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	"github.com/kubermatic/kubermatic/pkg/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// deleteCountingClient counts the delete requests sent through it
+type deleteCountingClient struct {
+	ctrlruntimeclient.Client
+	deletes int
+}
+
+func (c *deleteCountingClient) Delete(ctx context.Context, obj runtime.Object, opts ...ctrlruntimeclient.DeleteOption) error {
+	c.deletes++
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func TestCleanupDeployment(t *testing.T) {
+	ctx := context.Background()
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		Status:     kubermaticv1.ClusterStatus{NamespaceName: "cluster-test-cluster"},
+	}
+
+	client := &deleteCountingClient{Client: ctrlruntimefakeclient.NewFakeClient()}
+	if err := CleanupDeployment(ctx, client, cluster); err != nil {
+		t.Fatalf("failed to clean up: %v", err)
+	}
+	if client.deletes != 0 {
+		t.Errorf("expected no delete request without a deployment, got %d", client.deletes)
+	}
+
+	name := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.ClusterAutoscalerDeploymentName}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: name.Namespace, Name: name.Name}}
+	if err := client.Create(ctx, deployment); err != nil {
+		t.Fatalf("failed to create deployment: %v", err)
+	}
+	if err := CleanupDeployment(ctx, client, cluster); err != nil {
+		t.Fatalf("failed to clean up: %v", err)
+	}
+	if client.deletes != 1 {
+		t.Errorf("expected one delete request, got %d", client.deletes)
+	}
+	if err := client.Get(ctx, name, &appsv1.Deployment{}); !kerrors.IsNotFound(err) {
+		t.Errorf("expected the deployment to be deleted, got %v", err)
+	}
+}
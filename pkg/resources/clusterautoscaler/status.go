@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterautoscaler
+
+import (
+	"bufio"
+	"strings"
+	"time"
+)
+
+const (
+	// StatusConfigMapName is the name of the ConfigMap in the kube-system namespace of the user cluster
+	// the cluster-autoscaler writes its status to
+	StatusConfigMapName = "cluster-autoscaler-status"
+	// StatusConfigMapKey is the key of the status in the StatusConfigMapName ConfigMap
+	StatusConfigMapKey = "status"
+
+	// statusTimeLayout is the layout the cluster-autoscaler uses for the times in its status
+	statusTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+)
+
+// NodeGroupStatus is the status the cluster-autoscaler reports for a single node group
+type NodeGroupStatus struct {
+	Health    *Condition
+	ScaleUp   *Condition
+	ScaleDown *Condition
+}
+
+// Condition is a single condition of a node group, e.g. its health
+type Condition struct {
+	// Status is the short status, e.g. Healthy or InProgress
+	Status string
+	// Message contains the details the cluster-autoscaler gives for the status
+	Message            string
+	LastTransitionTime time.Time
+}
+
+// ParseNodeGroupStatuses parses the node group section of the status the cluster-autoscaler writes into
+// the StatusConfigMapName ConfigMap. The returned map is keyed by the name of the node groups, which is
+// "MachineDeployment/<namespace>/<name>" or "<namespace>/<name>" depending on the autoscaler version.
+func ParseNodeGroupStatuses(status string) map[string]NodeGroupStatus {
+	statuses := map[string]NodeGroupStatus{}
+
+	var (
+		inNodeGroups bool
+		name         string
+		current      *Condition
+	)
+	scanner := bufio.NewScanner(strings.NewReader(status))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !inNodeGroups {
+			inNodeGroups = line == "NodeGroups:"
+			continue
+		}
+
+		key, value := splitStatusLine(line)
+		switch key {
+		case "Name":
+			name = value
+			current = nil
+			statuses[name] = NodeGroupStatus{}
+		case "Health", "ScaleUp", "ScaleDown":
+			if name == "" {
+				continue
+			}
+			current = parseCondition(value)
+			groupStatus := statuses[name]
+			switch key {
+			case "Health":
+				groupStatus.Health = current
+			case "ScaleUp":
+				groupStatus.ScaleUp = current
+			case "ScaleDown":
+				groupStatus.ScaleDown = current
+			}
+			statuses[name] = groupStatus
+		case "LastTransitionTime":
+			if current == nil {
+				continue
+			}
+			if t, err := parseStatusTime(value); err == nil {
+				current.LastTransitionTime = t
+			}
+		}
+	}
+
+	return statuses
+}
+
+// NodeGroupName returns the name of the node group of a MachineDeployment as it is found in the status
+// returned by ParseNodeGroupStatuses
+func NodeGroupName(namespace, name string) string {
+	return "MachineDeployment/" + namespace + "/" + name
+}
+
+// GetNodeGroupStatus returns the status of the node group of a MachineDeployment
+func GetNodeGroupStatus(statuses map[string]NodeGroupStatus, namespace, name string) (NodeGroupStatus, bool) {
+	if status, ok := statuses[NodeGroupName(namespace, name)]; ok {
+		return status, true
+	}
+	status, ok := statuses[namespace+"/"+name]
+	return status, ok
+}
+
+func splitStatusLine(line string) (string, string) {
+	parts := strings.SplitN(line, ":", 2)
+	if len(parts) != 2 {
+		return "", ""
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+}
+
+// parseCondition parses conditions like "Healthy (ready=1 unready=0 registered=1)"
+func parseCondition(value string) *Condition {
+	condition := &Condition{Status: value}
+	if i := strings.Index(value, " ("); i >= 0 {
+		condition.Status = value[:i]
+		condition.Message = strings.TrimSuffix(value[i+2:], ")")
+	}
+	return condition
+}
+
+func parseStatusTime(value string) (time.Time, error) {
+	// Times which still carry a monotonic clock reading look like "... +0000 UTC m=+12.345"
+	if i := strings.Index(value, " m="); i >= 0 {
+		value = value[:i]
+	}
+	return time.Parse(statusTimeLayout, value)
+}
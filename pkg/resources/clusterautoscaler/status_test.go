@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterautoscaler
+
+import (
+	"testing"
+	"time"
+)
+
+const testStatus = `Cluster-autoscaler status at 2020-05-12 10:20:31.452930284 +0000 UTC:
+Cluster-wide:
+  Health:      Healthy (ready=3 unready=0 notStarted=0 longNotStarted=0 registered=3 longUnregistered=0)
+               LastProbeTime:      2020-05-12 10:20:31.212356703 +0000 UTC m=+3600.125
+               LastTransitionTime: 2020-05-12 09:20:12.163415103 +0000 UTC m=+12.345
+  ScaleUp:     NoActivity (ready=3 registered=3)
+               LastProbeTime:      2020-05-12 10:20:31.212356703 +0000 UTC m=+3600.125
+               LastTransitionTime: 2020-05-12 09:20:12.163415103 +0000 UTC m=+12.345
+  ScaleDown:   NoCandidates (candidates=0)
+               LastProbeTime:      2020-05-12 10:20:31.212356703 +0000 UTC m=+3600.125
+               LastTransitionTime: 2020-05-12 09:20:12.163415103 +0000 UTC m=+12.345
+
+NodeGroups:
+  Name:        MachineDeployment/kube-system/workers
+  Health:      Healthy (ready=2 unready=0 notStarted=0 longNotStarted=0 registered=2 longUnregistered=0 cloudProviderTarget=2 (minSize=1, maxSize=5))
+               LastProbeTime:      2020-05-12 10:20:31.212356703 +0000 UTC m=+3600.125
+               LastTransitionTime: 2020-05-12 09:20:12.163415103 +0000 UTC m=+12.345
+  ScaleUp:     InProgress (ready=2 cloudProviderTarget=3)
+               LastProbeTime:      2020-05-12 10:20:31.212356703 +0000 UTC m=+3600.125
+               LastTransitionTime: 2020-05-12 10:19:45.5 +0000 UTC m=+3573.1
+  ScaleDown:   NoCandidates (candidates=0)
+               LastProbeTime:      2020-05-12 10:20:31.212356703 +0000 UTC m=+3600.125
+               LastTransitionTime: 2020-05-12 09:20:12.163415103 +0000 UTC m=+12.345
+
+  Name:        kube-system/gpu
+  Health:      Healthy (ready=1 unready=0 notStarted=0 longNotStarted=0 registered=1 longUnregistered=0 cloudProviderTarget=1 (minSize=0, maxSize=2))
+               LastProbeTime:      2020-05-12 10:20:31.212356703 +0000 UTC
+               LastTransitionTime: 2020-05-12 09:20:12.163415103 +0000 UTC
+`
+
+func TestParseNodeGroupStatuses(t *testing.T) {
+	statuses := ParseNodeGroupStatuses(testStatus)
+	if len(statuses) != 2 {
+		t.Fatalf("expected the status of 2 node groups, got %d", len(statuses))
+	}
+
+	workers, ok := GetNodeGroupStatus(statuses, "kube-system", "workers")
+	if !ok {
+		t.Fatal("expected to find the status of the workers node group")
+	}
+	if workers.Health == nil || workers.Health.Status != "Healthy" {
+		t.Errorf("expected the workers to be healthy, got %+v", workers.Health)
+	}
+	if workers.ScaleUp == nil || workers.ScaleUp.Status != "InProgress" || workers.ScaleUp.Message != "ready=2 cloudProviderTarget=3" {
+		t.Errorf("expected a scale up of the workers to be in progress, got %+v", workers.ScaleUp)
+	}
+	expectedTransition := time.Date(2020, 5, 12, 10, 19, 45, 500000000, time.UTC)
+	if workers.ScaleUp != nil && !workers.ScaleUp.LastTransitionTime.Equal(expectedTransition) {
+		t.Errorf("expected the scale up to have started at %v, got %v", expectedTransition, workers.ScaleUp.LastTransitionTime)
+	}
+	if workers.ScaleDown == nil || workers.ScaleDown.Status != "NoCandidates" {
+		t.Errorf("expected no scale down candidates for the workers, got %+v", workers.ScaleDown)
+	}
+
+	gpu, ok := GetNodeGroupStatus(statuses, "kube-system", "gpu")
+	if !ok {
+		t.Fatal("expected to find the status of the gpu node group")
+	}
+	if gpu.Health == nil || gpu.ScaleUp != nil || gpu.ScaleDown != nil {
+		t.Errorf("expected only the health of the gpu node group, got %+v", gpu)
+	}
+
+	if _, ok := GetNodeGroupStatus(statuses, "kube-system", "unknown"); ok {
+		t.Error("expected no status for an unknown node group")
+	}
+}
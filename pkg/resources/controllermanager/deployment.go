@@ -171,7 +171,7 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 				*openvpnSidecar,
 				{
 					Name:    resources.ControllerManagerDeploymentName,
-					Image:   data.ImageRegistry(resources.RegistryGCR) + "/google_containers/hyperkube-amd64:v" + data.Cluster().Spec.Version.String(),
+					Image:   data.ImageRegistry(resources.RegistryGCR) + "/google_containers/hyperkube-amd64:v" + data.Cluster().ComponentVersion(kubermaticv1.ControlPlaneComponentControllerManager).String(),
 					Command: []string{"/hyperkube", "kube-controller-manager"},
 					Args:    flags,
 					Env:     envVars,
@@ -242,7 +242,7 @@ func getFlags(data *resources.TemplateData) ([]string, error) {
 	if cloudProviderName != "" && cloudProviderName != "external" {
 		flags = append(flags, "--cloud-provider", cloudProviderName)
 		flags = append(flags, "--cloud-config", "/etc/kubernetes/cloud/config")
-		if cloudProviderName == "azure" && data.Cluster().Spec.Version.Semver().Minor() >= 15 {
+		if cloudProviderName == "azure" && data.Cluster().ComponentVersion(kubermaticv1.ControlPlaneComponentControllerManager).Minor() >= 15 {
 			// Required so multiple clusters using the same resource group can allocate public IPs.
 			// Ref: https://github.com/kubernetes/kubernetes/pull/77630
 			flags = append(flags, "--cluster-name", data.Cluster().Name)
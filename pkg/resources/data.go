@@ -339,3 +339,12 @@ func GetKubernetesCloudProviderName(cluster *kubermaticv1.Cluster) string {
 func (d *TemplateData) Seed() *kubermaticv1.Seed {
 	return d.seed
 }
+
+// SeedConfigMap returns the ConfigMap with the given name from the namespace the seed is configured in
+func (d *TemplateData) SeedConfigMap(name string) (*corev1.ConfigMap, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := d.client.Get(d.ctx, types.NamespacedName{Namespace: d.seed.Namespace, Name: name}, configMap); err != nil {
+		return nil, err
+	}
+	return configMap, nil
+}
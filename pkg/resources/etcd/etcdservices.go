@@ -68,11 +68,11 @@ func ServiceCreator(data serviceCreatorData) reconciling.NamedServiceCreatorGett
 }
 
 // GetClientEndpoints returns the slice with the etcd endpoints for client communication
-func GetClientEndpoints(namespace string) []string {
+func GetClientEndpoints(cluster *kubermaticv1.Cluster) []string {
 	var endpoints []string
-	for i := 0; i < 3; i++ {
+	for i := 0; i < resources.GetEtcdClusterSize(cluster); i++ {
 		// Pod DNS name
-		serviceDNSName := resources.GetAbsoluteServiceDNSName(resources.EtcdServiceName, namespace)
+		serviceDNSName := resources.GetAbsoluteServiceDNSName(resources.EtcdServiceName, cluster.Status.NamespaceName)
 		absolutePodDNSName := fmt.Sprintf("https://etcd-%d.%s:2379", i, serviceDNSName)
 		endpoints = append(endpoints, absolutePodDNSName)
 	}
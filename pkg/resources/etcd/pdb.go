@@ -34,7 +34,7 @@ type pdbData interface {
 func PodDisruptionBudgetCreator(data pdbData) reconciling.NamedPodDisruptionBudgetCreatorGetter {
 	return func() (string, reconciling.PodDisruptionBudgetCreator) {
 		return resources.EtcdPodDisruptionBudgetName, func(pdb *policyv1beta1.PodDisruptionBudget) (*policyv1beta1.PodDisruptionBudget, error) {
-			minAvailable := intstr.FromInt((resources.EtcdClusterSize / 2) + 1)
+			minAvailable := intstr.FromInt(resources.GetEtcdQuorumSize(data.Cluster()))
 			pdb.Spec = policyv1beta1.PodDisruptionBudgetSpec{
 				Selector: &metav1.LabelSelector{
 					MatchLabels: getBasePodLabels(data.Cluster()),
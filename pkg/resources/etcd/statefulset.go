@@ -73,7 +73,7 @@ func StatefulSetCreator(data etcdStatefulSetCreatorData, enableDataCorruptionChe
 		return resources.EtcdStatefulSetName, func(set *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
 			set.Name = resources.EtcdStatefulSetName
 
-			set.Spec.Replicas = resources.Int32(resources.EtcdClusterSize)
+			set.Spec.Replicas = resources.Int32(int32(resources.GetEtcdClusterSize(data.Cluster())))
 			set.Spec.UpdateStrategy.Type = appsv1.RollingUpdateStatefulSetStrategyType
 			set.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
 			set.Spec.ServiceName = resources.EtcdServiceName
@@ -139,7 +139,7 @@ func StatefulSetCreator(data etcdStatefulSetCreatorData, enableDataCorruptionChe
 						},
 						{
 							Name:  "ECTD_CLUSTER_SIZE",
-							Value: strconv.Itoa(resources.EtcdClusterSize),
+							Value: strconv.Itoa(resources.GetEtcdClusterSize(data.Cluster())),
 						},
 						{
 							Name:  "ENABLE_CORRUPTION_CHECK",
@@ -300,17 +300,25 @@ func getBasePodLabels(cluster *kubermaticv1.Cluster) map[string]string {
 // ImageTag returns the correct etcd image tag for a given Cluster
 // TODO: Other functions use this function, swtich them to getLauncherImage
 func ImageTag(c *kubermaticv1.Cluster) string {
-	if c.IsOpenshift() || c.Spec.Version.Minor() < 17 {
+	if c.IsOpenshift() || c.ComponentVersion(kubermaticv1.ControlPlaneComponentEtcd).Minor() < 17 {
 		return etcdImageTagV33
 	}
 	return etcdImageTagV34
 }
 
-func getLauncherImage(data etcdStatefulSetCreatorData) (string, error) {
-	etcdTag := ImageTag(data.Cluster())
-	baseTag, ok := baseTags[etcdTag]
+// LauncherImageName returns the name of the etcd-launcher image for a given Cluster, without registry and tag
+func LauncherImageName(c *kubermaticv1.Cluster) (string, error) {
+	baseTag, ok := baseTags[ImageTag(c)]
 	if !ok {
 		return "", errors.New("unknown etcd tag")
 	}
-	return data.ImageRegistry(resources.RegistryQuay) + "/kubermatic/etcd-launcher-" + baseTag + ":" + resources.KUBERMATICCOMMIT, nil
+	return "kubermatic/etcd-launcher-" + baseTag, nil
+}
+
+func getLauncherImage(data etcdStatefulSetCreatorData) (string, error) {
+	imageName, err := LauncherImageName(data.Cluster())
+	if err != nil {
+		return "", err
+	}
+	return data.ImageRegistry(resources.RegistryQuay) + "/" + imageName + ":" + resources.KUBERMATICCOMMIT, nil
 }
@@ -53,7 +53,7 @@ func TLSCertificateCreator(data tlsCertificateCreatorData) reconciling.NamedSecr
 				},
 			}
 
-			for i := 0; i < 3; i++ {
+			for i := 0; i < resources.GetEtcdClusterSize(data.Cluster()); i++ {
 				// Member name
 				podName := fmt.Sprintf("etcd-%d", i)
 				altNames.DNSNames = append(altNames.DNSNames, podName)
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+)
+
+const (
+	// AutoscalerMinSizeAnnotation is the annotation the cluster-autoscaler reads the minimum
+	// size of the node group of a MachineDeployment from
+	AutoscalerMinSizeAnnotation = "cluster.k8s.io/cluster-api-autoscaler-node-group-min-size"
+	// AutoscalerMaxSizeAnnotation is the annotation the cluster-autoscaler reads the maximum
+	// size of the node group of a MachineDeployment from
+	AutoscalerMaxSizeAnnotation = "cluster.k8s.io/cluster-api-autoscaler-node-group-max-size"
+)
+
+// HasAutoscalingBounds returns whether the node deployment is scaled by the cluster-autoscaler
+func HasAutoscalingBounds(spec *apiv1.NodeDeploymentSpec) bool {
+	return spec.MinReplicas != nil && spec.MaxReplicas != nil
+}
+
+// ValidateAutoscalingBounds checks that the bounds are set together and contain the replicas
+func ValidateAutoscalingBounds(spec *apiv1.NodeDeploymentSpec) error {
+	if spec.MinReplicas == nil && spec.MaxReplicas == nil {
+		return nil
+	}
+	if spec.MinReplicas == nil || spec.MaxReplicas == nil {
+		return errors.New("minReplicas and maxReplicas have to be set together")
+	}
+	if *spec.MinReplicas < 0 {
+		return errors.New("minReplicas cannot be negative")
+	}
+	if *spec.MinReplicas > *spec.MaxReplicas {
+		return fmt.Errorf("minReplicas %d cannot be greater than maxReplicas %d", *spec.MinReplicas, *spec.MaxReplicas)
+	}
+	if spec.Replicas < *spec.MinReplicas || spec.Replicas > *spec.MaxReplicas {
+		return fmt.Errorf("replicas %d have to be between minReplicas %d and maxReplicas %d", spec.Replicas, *spec.MinReplicas, *spec.MaxReplicas)
+	}
+	return nil
+}
+
+// SetAutoscalingAnnotations sets the annotations of the cluster-autoscaler on the MachineDeployment
+// from the bounds of the node deployment, or removes them if the node deployment has no bounds
+func SetAutoscalingAnnotations(md *clusterv1alpha1.MachineDeployment, spec *apiv1.NodeDeploymentSpec) {
+	if !HasAutoscalingBounds(spec) {
+		delete(md.Annotations, AutoscalerMinSizeAnnotation)
+		delete(md.Annotations, AutoscalerMaxSizeAnnotation)
+		return
+	}
+
+	if md.Annotations == nil {
+		md.Annotations = map[string]string{}
+	}
+	md.Annotations[AutoscalerMinSizeAnnotation] = strconv.Itoa(int(*spec.MinReplicas))
+	md.Annotations[AutoscalerMaxSizeAnnotation] = strconv.Itoa(int(*spec.MaxReplicas))
+}
+
+// GetAutoscalingBounds returns the bounds of the cluster-autoscaler set on the MachineDeployment.
+// Both are nil unless the MachineDeployment has valid values for both annotations.
+func GetAutoscalingBounds(md *clusterv1alpha1.MachineDeployment) (*int32, *int32) {
+	minSize, err := strconv.ParseInt(md.Annotations[AutoscalerMinSizeAnnotation], 10, 32)
+	if err != nil {
+		return nil, nil
+	}
+	maxSize, err := strconv.ParseInt(md.Annotations[AutoscalerMaxSizeAnnotation], 10, 32)
+	if err != nil {
+		return nil, nil
+	}
+
+	minReplicas, maxReplicas := int32(minSize), int32(maxSize)
+	return &minReplicas, &maxReplicas
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"fmt"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+)
+
+// OperatingSystemImageAnnotation is the annotation on a MachineDeployment which holds the name of the
+// image from the operating system image catalog of the datacenter the MachineDeployment uses
+const OperatingSystemImageAnnotation = "kubermatic.io/operating-system-image"
+
+// providerImage returns the field of the cloud spec which holds the image or nil if the images
+// of the provider can not be picked
+func providerImage(cloud *apiv1.NodeCloudSpec) *string {
+	switch {
+	case cloud.AWS != nil:
+		return &cloud.AWS.AMI
+	case cloud.Azure != nil:
+		return &cloud.Azure.ImageID
+	case cloud.GCP != nil:
+		return &cloud.GCP.CustomImage
+	case cloud.Openstack != nil:
+		return &cloud.Openstack.Image
+	case cloud.VSphere != nil:
+		return &cloud.VSphere.Template
+	}
+	return nil
+}
+
+func findOperatingSystemImage(dc *kubermaticv1.Datacenter, name string) *kubermaticv1.OperatingSystemImage {
+	for i, image := range dc.Spec.OperatingSystemImages {
+		if image.Name == name {
+			return &dc.Spec.OperatingSystemImages[i]
+		}
+	}
+	return nil
+}
+
+func defaultOperatingSystemImage(dc *kubermaticv1.Datacenter, os providerconfig.OperatingSystem) *kubermaticv1.OperatingSystemImage {
+	for i, image := range dc.Spec.OperatingSystemImages {
+		if image.OperatingSystem == os && image.Default {
+			return &dc.Spec.OperatingSystemImages[i]
+		}
+	}
+	return nil
+}
+
+// ValidateOperatingSystemImage checks that the image the node spec picks from the catalog of the
+// datacenter exists and contains the operating system of the node spec. Deprecated images can only
+// be kept by node deployments which already use them, i.e. if they are the previous image.
+func ValidateOperatingSystemImage(spec *apiv1.NodeSpec, dc *kubermaticv1.Datacenter, previous string) error {
+	if spec.OperatingSystemImage == "" {
+		return nil
+	}
+
+	image := findOperatingSystemImage(dc, spec.OperatingSystemImage)
+	if image == nil {
+		return fmt.Errorf("operating system image %q does not exist in the datacenter", spec.OperatingSystemImage)
+	}
+	os, err := getOsName(*spec)
+	if err != nil {
+		return err
+	}
+	if image.OperatingSystem != os {
+		return fmt.Errorf("operating system image %q contains %s, not %s", image.Name, image.OperatingSystem, os)
+	}
+	if providerImage(&spec.Cloud) == nil {
+		return fmt.Errorf("the images of the provider can not be picked")
+	}
+	if image.Deprecated && image.Name != previous {
+		return fmt.Errorf("operating system image %q is deprecated", image.Name)
+	}
+	return nil
+}
+
+// SetOperatingSystemImage sets the image in the cloud spec of the node spec to the image it picks from
+// the catalog of the datacenter.
+func SetOperatingSystemImage(spec *apiv1.NodeSpec, dc *kubermaticv1.Datacenter) error {
+	if spec.OperatingSystemImage == "" {
+		return nil
+	}
+
+	image := findOperatingSystemImage(dc, spec.OperatingSystemImage)
+	if image == nil {
+		return fmt.Errorf("operating system image %q does not exist in the datacenter", spec.OperatingSystemImage)
+	}
+	field := providerImage(&spec.Cloud)
+	if field == nil {
+		return fmt.Errorf("the images of the provider can not be picked")
+	}
+	*field = image.Image
+	return nil
+}
+
+// SetDefaultOperatingSystemImage picks the default image of the operating system from the catalog of
+// the datacenter if the node spec picks none and has no image in its cloud spec either. This only happens
+// for new node deployments, i.e. if there is no previous node spec, or if the operating system changes,
+// otherwise a patch would replace the machines of a node deployment that never used the catalog.
+func SetDefaultOperatingSystemImage(spec *apiv1.NodeSpec, dc *kubermaticv1.Datacenter, previous *apiv1.NodeSpec) error {
+	if spec.OperatingSystemImage != "" || len(dc.Spec.OperatingSystemImages) == 0 {
+		return nil
+	}
+	field := providerImage(&spec.Cloud)
+	if field == nil || *field != "" {
+		return nil
+	}
+
+	os, err := getOsName(*spec)
+	if err != nil {
+		return err
+	}
+	if previous != nil {
+		if previousOS, err := getOsName(*previous); err == nil && previousOS == os {
+			return nil
+		}
+	}
+	if image := defaultOperatingSystemImage(dc, os); image != nil {
+		*field = image.Image
+		spec.OperatingSystemImage = image.Name
+	}
+	return nil
+}
+
+// SetOperatingSystemImageAnnotation records the image the node spec picked from the catalog on the
+// MachineDeployment, or removes the annotation if it picked none
+func SetOperatingSystemImageAnnotation(md *clusterv1alpha1.MachineDeployment, spec *apiv1.NodeSpec) {
+	if spec.OperatingSystemImage == "" {
+		delete(md.Annotations, OperatingSystemImageAnnotation)
+		return
+	}
+
+	if md.Annotations == nil {
+		md.Annotations = map[string]string{}
+	}
+	md.Annotations[OperatingSystemImageAnnotation] = spec.OperatingSystemImage
+}
+
+// OperatingSystemImageWarnings returns warnings if the node spec uses an image which got deprecated or
+// removed from the catalog of the datacenter. Node specs which set the image in their cloud spec get
+// a warning as well if it matches a deprecated image of the catalog.
+func OperatingSystemImageWarnings(spec *apiv1.NodeSpec, dc *kubermaticv1.Datacenter) []string {
+	image := findOperatingSystemImage(dc, spec.OperatingSystemImage)
+	if spec.OperatingSystemImage != "" && image == nil {
+		return []string{fmt.Sprintf("operating system image %q is not offered by the datacenter anymore", spec.OperatingSystemImage)}
+	}
+
+	os, err := getOsName(*spec)
+	if err != nil {
+		return nil
+	}
+	if image == nil {
+		field := providerImage(&spec.Cloud)
+		if field == nil || *field == "" {
+			return nil
+		}
+		for i, candidate := range dc.Spec.OperatingSystemImages {
+			if candidate.OperatingSystem == os && candidate.Image == *field {
+				image = &dc.Spec.OperatingSystemImages[i]
+				break
+			}
+		}
+	}
+	if image == nil || !image.Deprecated {
+		return nil
+	}
+
+	warning := fmt.Sprintf("operating system image %q is deprecated", image.Name)
+	if replacement := defaultOperatingSystemImage(dc, os); replacement != nil {
+		warning += fmt.Sprintf(", consider switching to %q", replacement.Name)
+	}
+	return []string{warning}
+}
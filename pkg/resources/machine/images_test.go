@@ -0,0 +1,219 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+
+	apiv1 "github.com/kubermatic/kubermatic/pkg/api/v1"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+)
+
+func testImageDatacenter() *kubermaticv1.Datacenter {
+	return &kubermaticv1.Datacenter{
+		Spec: kubermaticv1.DatacenterSpec{
+			OperatingSystemImages: []kubermaticv1.OperatingSystemImage{
+				{Name: "ubuntu-18.04", OperatingSystem: providerconfig.OperatingSystemUbuntu, Image: "ami-bionic", Deprecated: true},
+				{Name: "ubuntu-20.04", OperatingSystem: providerconfig.OperatingSystemUbuntu, Image: "ami-focal", Default: true},
+				{Name: "centos-7", OperatingSystem: providerconfig.OperatingSystemCentOS, Image: "ami-centos"},
+			},
+		},
+	}
+}
+
+func testImageNodeSpec(image, ami string) *apiv1.NodeSpec {
+	return &apiv1.NodeSpec{
+		OperatingSystemImage: image,
+		OperatingSystem:      apiv1.OperatingSystemSpec{Ubuntu: &apiv1.UbuntuSpec{}},
+		Cloud:                apiv1.NodeCloudSpec{AWS: &apiv1.AWSNodeSpec{AMI: ami}},
+	}
+}
+
+func TestValidateOperatingSystemImage(t *testing.T) {
+	tests := []struct {
+		name     string
+		image    string
+		previous string
+		valid    bool
+	}{
+		{
+			name:  "no image",
+			valid: true,
+		},
+		{
+			name:  "image from the catalog",
+			image: "ubuntu-20.04",
+			valid: true,
+		},
+		{
+			name:  "unknown image",
+			image: "ubuntu-16.04",
+			valid: false,
+		},
+		{
+			name:  "image of another operating system",
+			image: "centos-7",
+			valid: false,
+		},
+		{
+			name:  "deprecated image",
+			image: "ubuntu-18.04",
+			valid: false,
+		},
+		{
+			name:     "deprecated image which is already used",
+			image:    "ubuntu-18.04",
+			previous: "ubuntu-18.04",
+			valid:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateOperatingSystemImage(testImageNodeSpec(test.image, ""), testImageDatacenter(), test.previous)
+			if (err == nil) != test.valid {
+				t.Errorf("Expected valid=%v, got err=%v", test.valid, err)
+			}
+		})
+	}
+}
+
+func TestSetOperatingSystemImage(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          *apiv1.NodeSpec
+		expectedImage string
+		expectedAMI   string
+	}{
+		{
+			name:          "image from the catalog overrides the AMI",
+			spec:          testImageNodeSpec("ubuntu-18.04", "ami-custom"),
+			expectedImage: "ubuntu-18.04",
+			expectedAMI:   "ami-bionic",
+		},
+		{
+			name: "no image without an AMI",
+			spec: testImageNodeSpec("", ""),
+		},
+		{
+			name:        "AMI is kept",
+			spec:        testImageNodeSpec("", "ami-custom"),
+			expectedAMI: "ami-custom",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := SetOperatingSystemImage(test.spec, testImageDatacenter()); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if test.spec.OperatingSystemImage != test.expectedImage {
+				t.Errorf("Expected image %q, got %q", test.expectedImage, test.spec.OperatingSystemImage)
+			}
+			if test.spec.Cloud.AWS.AMI != test.expectedAMI {
+				t.Errorf("Expected AMI %q, got %q", test.expectedAMI, test.spec.Cloud.AWS.AMI)
+			}
+		})
+	}
+}
+
+func TestSetDefaultOperatingSystemImage(t *testing.T) {
+	centOS := testImageNodeSpec("", "")
+	centOS.OperatingSystem = apiv1.OperatingSystemSpec{CentOS: &apiv1.CentOSSpec{}}
+
+	tests := []struct {
+		name          string
+		spec          *apiv1.NodeSpec
+		previous      *apiv1.NodeSpec
+		expectedImage string
+		expectedAMI   string
+	}{
+		{
+			name:          "default image for a new node deployment",
+			spec:          testImageNodeSpec("", ""),
+			expectedImage: "ubuntu-20.04",
+			expectedAMI:   "ami-focal",
+		},
+		{
+			name:     "no default image when patching a node deployment",
+			spec:     testImageNodeSpec("", ""),
+			previous: testImageNodeSpec("", ""),
+		},
+		{
+			name:          "default image when the operating system changes",
+			spec:          testImageNodeSpec("", ""),
+			previous:      centOS,
+			expectedImage: "ubuntu-20.04",
+			expectedAMI:   "ami-focal",
+		},
+		{
+			name:        "AMI is kept",
+			spec:        testImageNodeSpec("", "ami-custom"),
+			expectedAMI: "ami-custom",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := SetDefaultOperatingSystemImage(test.spec, testImageDatacenter(), test.previous); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if test.spec.OperatingSystemImage != test.expectedImage {
+				t.Errorf("Expected image %q, got %q", test.expectedImage, test.spec.OperatingSystemImage)
+			}
+			if test.spec.Cloud.AWS.AMI != test.expectedAMI {
+				t.Errorf("Expected AMI %q, got %q", test.expectedAMI, test.spec.Cloud.AWS.AMI)
+			}
+		})
+	}
+}
+
+func TestOperatingSystemImageWarnings(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     *apiv1.NodeSpec
+		expected []string
+	}{
+		{
+			name: "current image",
+			spec: testImageNodeSpec("ubuntu-20.04", "ami-focal"),
+		},
+		{
+			name:     "deprecated image",
+			spec:     testImageNodeSpec("ubuntu-18.04", "ami-bionic"),
+			expected: []string{`operating system image "ubuntu-18.04" is deprecated, consider switching to "ubuntu-20.04"`},
+		},
+		{
+			name:     "AMI of a deprecated image",
+			spec:     testImageNodeSpec("", "ami-bionic"),
+			expected: []string{`operating system image "ubuntu-18.04" is deprecated, consider switching to "ubuntu-20.04"`},
+		},
+		{
+			name:     "removed image",
+			spec:     testImageNodeSpec("ubuntu-16.04", "ami-xenial"),
+			expected: []string{`operating system image "ubuntu-16.04" is not offered by the datacenter anymore`},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := deep.Equal(OperatingSystemImageWarnings(test.spec, testImageDatacenter()), test.expected); diff != nil {
+				t.Errorf("Got unexpected warnings, diff: %v", diff)
+			}
+		})
+	}
+}
@@ -111,6 +111,13 @@ func Deployment(c *kubermaticv1.Cluster, nd *apiv1.NodeDeployment, dc *kubermati
 		md.Spec.Paused = *nd.Spec.Paused
 	}
 
+	SetAutoscalingAnnotations(md, &nd.Spec)
+
+	if err := SetOperatingSystemImage(&nd.Spec.Template, dc); err != nil {
+		return nil, err
+	}
+	SetOperatingSystemImageAnnotation(md, &nd.Spec.Template)
+
 	config, err := getProviderConfig(c, nd, dc, keys, data)
 	if err != nil {
 		return nil, err
@@ -331,5 +338,9 @@ func Validate(nd *apiv1.NodeDeployment, controlPlaneVersion *semver.Version) (*a
 		}
 	}
 
+	if err := ValidateAutoscalingBounds(&nd.Spec); err != nil {
+		return nil, err
+	}
+
 	return nd, nil
 }
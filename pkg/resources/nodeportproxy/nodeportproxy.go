@@ -44,6 +44,26 @@ const (
 	// We use it when clusters get exposed via a LoadBalancer, to allow re-using that LoadBalancer
 	// for both the kube-apiserver and the openVPN server
 	NodePortProxyExposeNamespacedAnnotationKey = "nodeport-proxy.k8s.io/expose-namespaced"
+
+	// NodePortProxyExposeInClusterAnnotationKey is the annotation key used to indicate that a
+	// service should be exposed on the in-cluster LoadBalancer of the seed NodeportProxy.
+	// Clusters using the SNI expose strategy use it for the connections from inside the user
+	// cluster which carry no server name (the in-cluster apiserver endpoint and openVPN), so
+	// that they do not need a port on the LoadBalancer which serves the SNI listener.
+	NodePortProxyExposeInClusterAnnotationKey = "nodeport-proxy.k8s.io/expose-in-cluster"
+
+	// InClusterServiceName is the name of the in-cluster LoadBalancer service of the seed
+	// NodeportProxy, it lives in the namespace of the Seed resource.
+	InClusterServiceName = "nodeport-proxy-in-cluster"
+
+	// SNIHostnameAnnotationKey is the annotation key used to indicate that the first port of a
+	// service should be reachable on the SNI listener of the seed NodeportProxy for TLS
+	// connections with the given server name.
+	SNIHostnameAnnotationKey = "nodeport-proxy.k8s.io/sni-hostname"
+
+	// AllowedSourceRangesAnnotationKey is the annotation key used to restrict the source addresses
+	// of connections to an exposed service to a comma-separated list of CIDRs.
+	AllowedSourceRangesAnnotationKey = "nodeport-proxy.k8s.io/allowed-source-ranges"
 )
 
 var (
@@ -333,8 +353,9 @@ func podDisruptionBudget() reconciling.NamedPodDisruptionBudgetCreatorGetter {
 }
 
 // FrontLoadBalancerServiceCreator returns the creator for the LoadBalancer that fronts apiserver
-// and openVPN when using exposeStrategy=LoadBalancer
-func FrontLoadBalancerServiceCreator() reconciling.NamedServiceCreatorGetter {
+// and openVPN when using exposeStrategy=LoadBalancer. The LoadBalancer only accepts connections
+// from the given source ranges if there are any.
+func FrontLoadBalancerServiceCreator(allowedSourceRanges []string) reconciling.NamedServiceCreatorGetter {
 	return func() (string, reconciling.ServiceCreator) {
 		return resources.FrontLoadBalancerServiceName, func(s *corev1.Service) (*corev1.Service, error) {
 			// We don't actually manage this service, that is done by the nodeport proxy, we just
@@ -352,6 +373,7 @@ func FrontLoadBalancerServiceCreator() reconciling.NamedServiceCreatorGetter {
 				}
 			}
 
+			s.Spec.LoadBalancerSourceRanges = allowedSourceRanges
 			s.Spec.Selector = resources.BaseAppLabels(envoyAppLabelValue, nil)
 			return s, nil
 		}
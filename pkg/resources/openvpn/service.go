@@ -17,6 +17,7 @@ limitations under the License.
 package openvpn
 
 import (
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/resources"
 	"github.com/kubermatic/kubermatic/pkg/resources/nodeportproxy"
 	"github.com/kubermatic/kubermatic/pkg/resources/reconciling"
@@ -35,12 +36,20 @@ func ServiceCreator(exposeStrategy corev1.ServiceType) reconciling.NamedServiceC
 			if se.Annotations == nil {
 				se.Annotations = map[string]string{}
 			}
-			if exposeStrategy == corev1.ServiceTypeNodePort {
-				se.Annotations["nodeport-proxy.k8s.io/expose"] = "true"
-				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
-			} else {
+			switch exposeStrategy {
+			case corev1.ServiceTypeLoadBalancer:
 				se.Annotations[nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey] = "true"
 				delete(se.Annotations, "nodeport-proxy.k8s.io/expose")
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeInClusterAnnotationKey)
+			case kubermaticv1.ExposeStrategySNI:
+				// OpenVPN connections carry no server name, so they can not be routed by the SNI listener
+				se.Annotations[nodeportproxy.NodePortProxyExposeInClusterAnnotationKey] = "true"
+				delete(se.Annotations, "nodeport-proxy.k8s.io/expose")
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+			default:
+				se.Annotations["nodeport-proxy.k8s.io/expose"] = "true"
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
+				delete(se.Annotations, nodeportproxy.NodePortProxyExposeInClusterAnnotationKey)
 			}
 			se.Spec.Selector = map[string]string{
 				resources.AppLabelKey: name,
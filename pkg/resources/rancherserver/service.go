@@ -34,12 +34,12 @@ func ServiceCreator(exposeStrategy corev1.ServiceType) reconciling.NamedServiceC
 			if s.Annotations == nil {
 				s.Annotations = map[string]string{}
 			}
-			if exposeStrategy == corev1.ServiceTypeNodePort {
-				s.Annotations["nodeport-proxy.k8s.io/expose"] = "true"
-				delete(s.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
-			} else {
+			if exposeStrategy == corev1.ServiceTypeLoadBalancer {
 				s.Annotations[nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey] = "true"
 				delete(s.Annotations, "nodeport-proxy.k8s.io/expose")
+			} else {
+				s.Annotations["nodeport-proxy.k8s.io/expose"] = "true"
+				delete(s.Annotations, nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey)
 			}
 			s.Spec.Selector = resources.BaseAppLabels(resources.RancherStatefulSetName, nil)
 			s.Spec.Type = corev1.ServiceTypeNodePort
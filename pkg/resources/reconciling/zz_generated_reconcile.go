@@ -171,6 +171,43 @@ func ReconcileConfigMaps(ctx context.Context, namedGetters []NamedConfigMapCreat
 	return nil
 }
 
+// PersistentVolumeClaimCreator defines an interface to create/update PersistentVolumeClaims
+type PersistentVolumeClaimCreator = func(existing *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error)
+
+// NamedPersistentVolumeClaimCreatorGetter returns the name of the resource and the corresponding creator function
+type NamedPersistentVolumeClaimCreatorGetter = func() (name string, create PersistentVolumeClaimCreator)
+
+// PersistentVolumeClaimObjectWrapper adds a wrapper so the PersistentVolumeClaimCreator matches ObjectCreator.
+// This is needed as Go does not support function interface matching.
+func PersistentVolumeClaimObjectWrapper(create PersistentVolumeClaimCreator) ObjectCreator {
+	return func(existing runtime.Object) (runtime.Object, error) {
+		if existing != nil {
+			return create(existing.(*corev1.PersistentVolumeClaim))
+		}
+		return create(&corev1.PersistentVolumeClaim{})
+	}
+}
+
+// ReconcilePersistentVolumeClaims will create and update the PersistentVolumeClaims coming from the passed PersistentVolumeClaimCreator slice
+func ReconcilePersistentVolumeClaims(ctx context.Context, namedGetters []NamedPersistentVolumeClaimCreatorGetter, namespace string, client ctrlruntimeclient.Client, objectModifiers ...ObjectModifier) error {
+	for _, get := range namedGetters {
+		name, create := get()
+		createObject := PersistentVolumeClaimObjectWrapper(create)
+		createObject = createWithNamespace(createObject, namespace)
+		createObject = createWithName(createObject, name)
+
+		for _, objectModifier := range objectModifiers {
+			createObject = objectModifier(createObject)
+		}
+
+		if err := EnsureNamedObject(ctx, types.NamespacedName{Namespace: namespace, Name: name}, createObject, client, &corev1.PersistentVolumeClaim{}, false); err != nil {
+			return fmt.Errorf("failed to ensure PersistentVolumeClaim %s/%s: %v", namespace, name, err)
+		}
+	}
+
+	return nil
+}
+
 // ServiceAccountCreator defines an interface to create/update ServiceAccounts
 type ServiceAccountCreator = func(existing *corev1.ServiceAccount) (*corev1.ServiceAccount, error)
 
@@ -804,6 +841,43 @@ func ReconcileSeeds(ctx context.Context, namedGetters []NamedSeedCreatorGetter,
 	return nil
 }
 
+// AddonConfigCreator defines an interface to create/update AddonConfigs
+type AddonConfigCreator = func(existing *kubermaticv1.AddonConfig) (*kubermaticv1.AddonConfig, error)
+
+// NamedAddonConfigCreatorGetter returns the name of the resource and the corresponding creator function
+type NamedAddonConfigCreatorGetter = func() (name string, create AddonConfigCreator)
+
+// AddonConfigObjectWrapper adds a wrapper so the AddonConfigCreator matches ObjectCreator.
+// This is needed as Go does not support function interface matching.
+func AddonConfigObjectWrapper(create AddonConfigCreator) ObjectCreator {
+	return func(existing runtime.Object) (runtime.Object, error) {
+		if existing != nil {
+			return create(existing.(*kubermaticv1.AddonConfig))
+		}
+		return create(&kubermaticv1.AddonConfig{})
+	}
+}
+
+// ReconcileAddonConfigs will create and update the AddonConfigs coming from the passed AddonConfigCreator slice
+func ReconcileAddonConfigs(ctx context.Context, namedGetters []NamedAddonConfigCreatorGetter, namespace string, client ctrlruntimeclient.Client, objectModifiers ...ObjectModifier) error {
+	for _, get := range namedGetters {
+		name, create := get()
+		createObject := AddonConfigObjectWrapper(create)
+		createObject = createWithNamespace(createObject, namespace)
+		createObject = createWithName(createObject, name)
+
+		for _, objectModifier := range objectModifiers {
+			createObject = objectModifier(createObject)
+		}
+
+		if err := EnsureNamedObject(ctx, types.NamespacedName{Namespace: namespace, Name: name}, createObject, client, &kubermaticv1.AddonConfig{}, false); err != nil {
+			return fmt.Errorf("failed to ensure AddonConfig %s/%s: %v", namespace, name, err)
+		}
+	}
+
+	return nil
+}
+
 // CertificateCreator defines an interface to create/update Certificates
 type CertificateCreator = func(existing *certmanagerv1alpha2.Certificate) (*certmanagerv1alpha2.Certificate, error)
 
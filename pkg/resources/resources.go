@@ -115,7 +115,7 @@ const (
 	MetricsServerExternalNameServiceName = "metrics-server"
 	//EtcdServiceName is the name for the etcd service
 	EtcdServiceName = "etcd"
-	//EtcdDefragCronJobName is the name for the defrag cronjob deployment
+	//EtcdDefragCronJobName is the name of the defrag cronjob older versions created, it only exists to clean it up
 	EtcdDefragCronJobName = "etcd-defragger"
 	//OpenVPNServerServiceName is the name for the openvpn server service
 	OpenVPNServerServiceName = "openvpn-server"
@@ -199,6 +199,10 @@ const (
 	GoogleServiceAccountVolumeName = "google-service-account-volume"
 	// AuditLogVolumeName is the name of the volume that hold the audit log of the apiserver.
 	AuditLogVolumeName = "audit-log"
+	// AuditLogSinkVolumeName is the name of the PersistentVolumeClaim of the audit file sink.
+	AuditLogSinkVolumeName = "audit-log-sink"
+	// AuditFileSinkMinSize is the part of the audit file sink every apiserver replica needs to fit the files of the audit log rotation.
+	AuditFileSinkMinSize = "512Mi"
 	// KubernetesDashboardKeyHolderSecretName is the name of the secret that contains JWE token encryption key
 	// used by the Kubernetes Dashboard
 	KubernetesDashboardKeyHolderSecretName = "kubernetes-dashboard-key-holder"
@@ -321,8 +325,10 @@ const (
 	// ClusterLabelKey defines the label key for the cluster name
 	ClusterLabelKey = "cluster"
 
-	// EtcdClusterSize defines the size of the etcd to use
+	// EtcdClusterSize defines the default size of the etcd to use
 	EtcdClusterSize = 3
+	// MaxEtcdClusterSize defines the maximum size of the etcd to use
+	MaxEtcdClusterSize = 5
 
 	// RegistryGCR defines the kubernetes docker registry at google
 	RegistryGCR = "gcr.io"
@@ -529,6 +535,26 @@ func GetClusterRef(cluster *kubermaticv1.Cluster) metav1.OwnerReference {
 	return *metav1.NewControllerRef(cluster, gv.WithKind("Cluster"))
 }
 
+// GetEtcdClusterSize returns the number of etcd members of the cluster. Invalid sizes are rejected by
+// validation.ValidateEtcdClusterSize before any resource is reconciled, they are only limited here to
+// the range the etcd-launcher supports.
+func GetEtcdClusterSize(cluster *kubermaticv1.Cluster) int {
+	size := cluster.Spec.ComponentsOverride.Etcd.ClusterSize
+	if size < EtcdClusterSize {
+		return EtcdClusterSize
+	}
+	if size > MaxEtcdClusterSize {
+		return MaxEtcdClusterSize
+	}
+	return size
+}
+
+// GetEtcdQuorumSize returns the number of etcd members of the cluster which must be available for the
+// etcd cluster to have a quorum
+func GetEtcdQuorumSize(cluster *kubermaticv1.Cluster) int {
+	return GetEtcdClusterSize(cluster)/2 + 1
+}
+
 // Int32 returns a pointer to the int32 value passed in.
 func Int32(v int32) *int32 {
 	return &v
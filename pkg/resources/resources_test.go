@@ -62,6 +62,54 @@ func TestInClusterApiserverIP(t *testing.T) {
 	}
 }
 
+func TestGetEtcdClusterSize(t *testing.T) {
+	testCases := []struct {
+		name           string
+		clusterSize    int
+		expectedResult int
+		expectedQuorum int
+	}{
+		{
+			name:           "Default when unset",
+			clusterSize:    0,
+			expectedResult: 3,
+			expectedQuorum: 2,
+		},
+		{
+			name:           "Configured size",
+			clusterSize:    5,
+			expectedResult: 5,
+			expectedQuorum: 3,
+		},
+		{
+			name:           "Limited to the minimum",
+			clusterSize:    1,
+			expectedResult: 3,
+			expectedQuorum: 2,
+		},
+		{
+			name:           "Limited to the maximum",
+			clusterSize:    7,
+			expectedResult: 5,
+			expectedQuorum: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{}
+			cluster.Spec.ComponentsOverride.Etcd.ClusterSize = tc.clusterSize
+
+			if result := GetEtcdClusterSize(cluster); result != tc.expectedResult {
+				t.Errorf("wrong result, expected: %d, result: %d", tc.expectedResult, result)
+			}
+			if quorum := GetEtcdQuorumSize(cluster); quorum != tc.expectedQuorum {
+				t.Errorf("wrong quorum, expected: %d, result: %d", tc.expectedQuorum, quorum)
+			}
+		})
+	}
+}
+
 func TestUserClusterDNSResolverIP(t *testing.T) {
 	testCases := []struct {
 		name           string
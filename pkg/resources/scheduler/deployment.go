@@ -19,6 +19,7 @@ package scheduler
 import (
 	"fmt"
 
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/resources/apiserver"
 	"github.com/kubermatic/kubermatic/pkg/resources/reconciling"
 
@@ -119,7 +120,7 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 				*openvpnSidecar,
 				{
 					Name:    resources.SchedulerDeploymentName,
-					Image:   data.ImageRegistry(resources.RegistryGCR) + "/google_containers/hyperkube-amd64:v" + data.Cluster().Spec.Version.String(),
+					Image:   data.ImageRegistry(resources.RegistryGCR) + "/google_containers/hyperkube-amd64:v" + data.Cluster().ComponentVersion(kubermaticv1.ControlPlaneComponentScheduler).String(),
 					Command: []string{"/hyperkube", "kube-scheduler"},
 					Args:    flags,
 					VolumeMounts: []corev1.VolumeMount{
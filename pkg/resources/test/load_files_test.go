@@ -42,7 +42,6 @@ import (
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 
 	appsv1 "k8s.io/api/apps/v1"
-	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -613,7 +612,7 @@ func TestLoadFiles(t *testing.T) {
 					checkTestResult(t, fixturePath, res)
 				}
 
-				serviceCreators := kubernetescontroller.GetServiceCreators(data)
+				serviceCreators := kubernetescontroller.GetServiceCreators(data, nil)
 				for _, creatorGetter := range serviceCreators {
 					name, create := creatorGetter()
 					res, err := create(&corev1.Service{})
@@ -664,26 +663,6 @@ func TestLoadFiles(t *testing.T) {
 
 					checkTestResult(t, fixturePath, res)
 				}
-
-				for _, creatorGetter := range kubernetescontroller.GetCronJobCreators(data) {
-					_, create := creatorGetter()
-					res, err := create(&batchv1beta1.CronJob{})
-					if err != nil {
-						t.Fatalf("failed to create CronJob: %v", err)
-					}
-
-					fixturePath := fmt.Sprintf("cronjob-%s-%s-%s", prov, ver.Version.String(), res.Name)
-					if err != nil {
-						t.Fatalf("failed to create CronJob for %s: %v", fixturePath, err)
-					}
-
-					// Verify that every CronJob has the ImagePullSecret set
-					if len(res.Spec.JobTemplate.Spec.Template.Spec.ImagePullSecrets) == 0 {
-						t.Errorf("CronJob %s is missing the ImagePullSecret on the PodTemplate", res.Name)
-					}
-
-					checkTestResult(t, fixturePath, res)
-				}
 			})
 		}
 	}
@@ -132,6 +132,12 @@ func DeploymentCreator(data userclusterControllerData, openshift bool) reconcili
 				"-owner-email", data.Cluster().Status.UserEmail,
 			}, getNetworkArgs(data)...)
 
+			// The external name of clusters using SNI does not serve the openVPN port, the
+			// in-cluster LoadBalancer of the seed does
+			if data.Cluster().Spec.ExposeStrategy == kubermaticv1.ExposeStrategySNI {
+				args = append(args, "-openvpn-server-host", data.Cluster().Address.IP)
+			}
+
 			if openshiftConsoleCallbackURI := data.Cluster().Address.OpenshiftConsoleCallBack; openshiftConsoleCallbackURI != "" {
 				args = append(args, "-openshift-console-callback-uri", openshiftConsoleCallbackURI)
 			}
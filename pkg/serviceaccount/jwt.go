@@ -18,15 +18,32 @@ package serviceaccount
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"gopkg.in/square/go-jose.v2"
 	"gopkg.in/square/go-jose.v2/jwt"
+
+	"k8s.io/apimachinery/pkg/util/rand"
 )
 
 // Now stubbed out to allow testing
 var Now = time.Now
 
+const (
+	// DefaultRotationGracePeriod is the time a token is still accepted after it has been rotated
+	DefaultRotationGracePeriod = time.Hour
+	// MaxRotationGracePeriod is the maximum time a token is still accepted after it has been rotated
+	MaxRotationGracePeriod = 7 * 24 * time.Hour
+
+	// TokenKey is the key of the secret data the token is stored under
+	TokenKey = "token"
+	// PreviousTokenKey is the key of the secret data the token replaced by the latest rotation is stored under
+	PreviousTokenKey = "previous-token"
+	// PreviousTokenExpiryKey is the key of the secret data which holds the time until the previous token is accepted, in RFC3339 format
+	PreviousTokenExpiryKey = "previous-token-expiry"
+)
+
 // TokenGenerator declares the method to generate JWT token
 type TokenGenerator interface {
 	// Generate generates a token which will identify the given
@@ -42,6 +59,9 @@ type TokenGenerator interface {
 type TokenAuthenticator interface {
 	// Authenticate checks given token and transform it to custom claim object
 	Authenticate(tokenData string) (*jwt.Claims, *CustomTokenClaim, error)
+	// Parse checks the signature of the given token and transform it to custom claim object,
+	// unlike Authenticate it doesn't check if the token expired
+	Parse(tokenData string) (*jwt.Claims, *CustomTokenClaim, error)
 }
 
 // CustomTokenClaim represents authenticated user
@@ -49,19 +69,88 @@ type CustomTokenClaim struct {
 	Email     string `json:"email,omitempty"`
 	ProjectID string `json:"project_id,omitempty"`
 	TokenID   string `json:"token_id,omitempty"`
+	// Scopes restrict the requests the token can be used for, a token without scopes can be used for all requests
+	Scopes []TokenScope `json:"scopes,omitempty"`
 }
 
+// TokenScope allows a token to be used for the given HTTP methods of an endpoint
+type TokenScope struct {
+	// Methods are the allowed HTTP methods, e.g. GET, all methods are allowed when empty
+	Methods []string `json:"methods,omitempty"`
+	// Endpoint is the path template of the endpoint, e.g. /api/v1/projects/{project_id}/clusters,
+	// a trailing * matches all endpoints with the given prefix, all endpoints are allowed when empty
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// Allows checks if the scope allows the given HTTP method on the endpoint with the given path template
+func (s TokenScope) Allows(method, endpoint string) bool {
+	if len(s.Methods) > 0 {
+		found := false
+		for _, m := range s.Methods {
+			if strings.EqualFold(m, method) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if strings.HasSuffix(s.Endpoint, "*") {
+		return strings.HasPrefix(endpoint, strings.TrimSuffix(s.Endpoint, "*"))
+	}
+	return s.Endpoint == "" || s.Endpoint == endpoint
+}
+
+// ScopesAllow checks if one of the scopes allows the given request, there are no restrictions without scopes
+func ScopesAllow(scopes []TokenScope, method, endpoint string) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	for _, scope := range scopes {
+		if scope.Allows(method, endpoint) {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenOptions restrict the lifetime and the permissions of a token
+type TokenOptions struct {
+	// Expiry is the time the token expires, DefaultExpiry is used when it is zero
+	Expiry time.Time
+	// Scopes restrict the requests the token can be used for
+	Scopes []TokenScope
+}
+
+// DefaultExpiry returns the expiry of tokens which are created without an expiry, tokens can't expire later
+func DefaultExpiry() time.Time {
+	return Now().AddDate(3, 0, 0)
+}
+
+// Claims returns the claims of an unrestricted token with the default expiry
 func Claims(email, projectID, tokenID string) (*jwt.Claims, *CustomTokenClaim) {
+	return ClaimsWithOptions(email, projectID, tokenID, TokenOptions{})
+}
 
+// ClaimsWithOptions returns the claims of a token which is restricted by the given options
+func ClaimsWithOptions(email, projectID, tokenID string, options TokenOptions) (*jwt.Claims, *CustomTokenClaim) {
+	expiry := options.Expiry
+	if expiry.IsZero() {
+		expiry = DefaultExpiry()
+	}
 	sc := &jwt.Claims{
+		// the ID makes tokens unique, even when they are regenerated within the same second
+		ID:        rand.String(10),
 		IssuedAt:  jwt.NewNumericDate(Now()),
 		NotBefore: jwt.NewNumericDate(Now()),
-		Expiry:    jwt.NewNumericDate(Now().AddDate(3, 0, 0)),
+		Expiry:    jwt.NewNumericDate(expiry),
 	}
 	pc := &CustomTokenClaim{
 		Email:     email,
 		ProjectID: projectID,
 		TokenID:   tokenID,
+		Scopes:    options.Scopes,
 	}
 
 	return sc, pc
@@ -107,19 +196,11 @@ func JWTTokenAuthenticator(privateKey []byte) TokenAuthenticator {
 
 // Authenticate decrypts signed token data to CustomTokenClaim object and checks if token expired
 func (a *jwtTokenAuthenticator) Authenticate(tokenData string) (*jwt.Claims, *CustomTokenClaim, error) {
-
-	tok, err := jwt.ParseSigned(tokenData)
+	public, customClaims, err := a.Parse(tokenData)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	public := &jwt.Claims{}
-	customClaims := &CustomTokenClaim{}
-
-	if err := tok.Claims(a.key, customClaims, public); err != nil {
-		return nil, nil, err
-	}
-
 	err = public.Validate(jwt.Expected{
 		Time: Now(),
 	})
@@ -134,6 +215,22 @@ func (a *jwtTokenAuthenticator) Authenticate(tokenData string) (*jwt.Claims, *Cu
 	return public, customClaims, nil
 }
 
+// Parse decrypts signed token data to CustomTokenClaim object
+func (a *jwtTokenAuthenticator) Parse(tokenData string) (*jwt.Claims, *CustomTokenClaim, error) {
+	tok, err := jwt.ParseSigned(tokenData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	public := &jwt.Claims{}
+	customClaims := &CustomTokenClaim{}
+
+	if err := tok.Claims(a.key, customClaims, public); err != nil {
+		return nil, nil, err
+	}
+	return public, customClaims, nil
+}
+
 func ValidateKey(privateKey []byte) error {
 	if len(privateKey) == 0 {
 		return fmt.Errorf("the signing key can not be empty")
@@ -21,6 +21,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-test/deep"
+
 	"github.com/kubermatic/kubermatic/pkg/handler/test"
 	"github.com/kubermatic/kubermatic/pkg/serviceaccount"
 )
@@ -85,3 +87,90 @@ func formatTime(t time.Time) string {
 	return fmt.Sprintf("%d-%02d-%02d",
 		t.Year(), t.Month(), t.Day())
 }
+
+func TestServiceAccountIssuerWithOptions(t *testing.T) {
+	tokenGenerator, err := serviceaccount.JWTTokenGenerator([]byte(test.TestServiceAccountHashKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiry := serviceaccount.Now().Add(24 * time.Hour)
+	scopes := []serviceaccount.TokenScope{{Methods: []string{"GET"}, Endpoint: "/api/v1/projects/{project_id}"}}
+	token, err := tokenGenerator.Generate(serviceaccount.ClaimsWithOptions("test@example.com", "testProject", "testToken", serviceaccount.TokenOptions{Expiry: expiry, Scopes: scopes}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenAuthenticator := serviceaccount.JWTTokenAuthenticator([]byte(test.TestServiceAccountHashKey))
+	public, custom, err := tokenAuthenticator.Authenticate(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if public.Expiry.Time().Unix() != expiry.Unix() {
+		t.Fatalf("expected expiry %v got %v", expiry, public.Expiry.Time())
+	}
+	if diff := deep.Equal(custom.Scopes, scopes); diff != nil {
+		t.Fatalf("got unexpected scopes, diff = %v", diff)
+	}
+
+	now := serviceaccount.Now
+	defer func() { serviceaccount.Now = now }()
+	serviceaccount.Now = func() time.Time { return expiry.Add(time.Minute) }
+	if _, _, err := tokenAuthenticator.Authenticate(token); err == nil {
+		t.Fatal("expected the token to be expired")
+	}
+	if _, _, err := tokenAuthenticator.Parse(token); err != nil {
+		t.Fatalf("expected the expired token to be parsed, got %v", err)
+	}
+}
+
+func TestScopesAllow(t *testing.T) {
+	testcases := []struct {
+		name     string
+		scopes   []serviceaccount.TokenScope
+		method   string
+		endpoint string
+		expected bool
+	}{
+		{
+			name:     "scenario 1: a token without scopes is allowed everything",
+			method:   "DELETE",
+			endpoint: "/api/v1/projects/{project_id}",
+			expected: true,
+		},
+		{
+			name:     "scenario 2: a scope restricts the methods",
+			scopes:   []serviceaccount.TokenScope{{Methods: []string{"get"}}},
+			method:   "DELETE",
+			endpoint: "/api/v1/projects/{project_id}",
+			expected: false,
+		},
+		{
+			name:     "scenario 3: a scope restricts the endpoint",
+			scopes:   []serviceaccount.TokenScope{{Endpoint: "/api/v1/projects/{project_id}/clusters"}},
+			method:   "GET",
+			endpoint: "/api/v1/projects/{project_id}",
+			expected: false,
+		},
+		{
+			name:     "scenario 4: a trailing wildcard matches all endpoints with the prefix",
+			scopes:   []serviceaccount.TokenScope{{Methods: []string{"GET"}, Endpoint: "/api/v1/projects/{project_id}/*"}},
+			method:   "GET",
+			endpoint: "/api/v1/projects/{project_id}/dc/{dc}/clusters",
+			expected: true,
+		},
+		{
+			name:     "scenario 5: one matching scope is enough",
+			scopes:   []serviceaccount.TokenScope{{Methods: []string{"POST"}}, {Methods: []string{"GET"}, Endpoint: "/api/v1/projects/{project_id}"}},
+			method:   "GET",
+			endpoint: "/api/v1/projects/{project_id}",
+			expected: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if allowed := serviceaccount.ScopesAllow(tc.scopes, tc.method, tc.endpoint); allowed != tc.expected {
+				t.Fatalf("expected %v got %v", tc.expected, allowed)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package storeuploader
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path"
 	"sort"
@@ -34,6 +35,9 @@ import (
 // is an empty string
 const prefixSeparator = "storeuploader"
 
+// terminationMessagePath is the default path Kubernetes reads the termination message of a container from
+const terminationMessagePath = "/dev/termination-log"
+
 // StoreUploader is the configuration
 // for the StoreUploader
 type StoreUploader struct {
@@ -84,8 +88,30 @@ func (u *StoreUploader) Store(file, bucket, prefix string, createBucket bool) er
 	objectName := fmt.Sprintf("%s-%s-%s-%s", prefix, prefixSeparator, time.Now().Format("2006-01-02T15:04:05"), path.Base(file))
 	logger.Infow("Uploading file", "src", file, "dst", objectName)
 
-	_, err := u.client.FPutObject(bucket, objectName, file, minio.PutObjectOptions{})
-	return err
+	if _, err := u.client.FPutObject(bucket, objectName, file, minio.PutObjectOptions{}); err != nil {
+		return err
+	}
+
+	// When running in a pod, the name of the uploaded object is reported as termination message,
+	// so controllers like the cluster migration can find the snapshot they requested
+	if _, err := os.Stat(terminationMessagePath); err == nil {
+		if err := ioutil.WriteFile(terminationMessagePath, []byte(objectName), 0644); err != nil {
+			logger.Warnw("Failed to write the object name to the termination message", zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// Download fetches the given object from S3 and writes it to file
+func (u *StoreUploader) Download(bucket, objectName, file string) error {
+	if len(objectName) == 0 {
+		return errors.New("object name cannot be empty")
+	}
+
+	logger := u.logger.With("bucket", bucket)
+	logger.Infow("Downloading file", "src", objectName, "dst", file)
+
+	return u.client.FGetObject(bucket, objectName, file, minio.GetObjectOptions{})
 }
 
 // DeleteOldBackups deletes revisions of all files of the given prefix which are older than max-revisions
@@ -25,8 +25,12 @@ type Client struct {
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	CreateProjectRole(params *CreateProjectRoleParams, authInfo runtime.ClientAuthInfoWriter) (*CreateProjectRoleCreated, error)
+
 	DeleteAdmissionPlugin(params *DeleteAdmissionPluginParams, authInfo runtime.ClientAuthInfoWriter) (*DeleteAdmissionPluginOK, error)
 
+	DeleteProjectRole(params *DeleteProjectRoleParams, authInfo runtime.ClientAuthInfoWriter) (*DeleteProjectRoleOK, error)
+
 	DeleteSeed(params *DeleteSeedParams, authInfo runtime.ClientAuthInfoWriter) (*DeleteSeedOK, error)
 
 	GetAdmins(params *GetAdminsParams, authInfo runtime.ClientAuthInfoWriter) (*GetAdminsOK, error)
@@ -37,10 +41,14 @@ type ClientService interface {
 
 	GetKubermaticSettings(params *GetKubermaticSettingsParams, authInfo runtime.ClientAuthInfoWriter) (*GetKubermaticSettingsOK, error)
 
+	GetPricingCatalog(params *GetPricingCatalogParams, authInfo runtime.ClientAuthInfoWriter) (*GetPricingCatalogOK, error)
+
 	GetSeed(params *GetSeedParams, authInfo runtime.ClientAuthInfoWriter) (*GetSeedOK, error)
 
 	ListAdmissionPlugins(params *ListAdmissionPluginsParams, authInfo runtime.ClientAuthInfoWriter) (*ListAdmissionPluginsOK, error)
 
+	ListProjectAuditEntries(params *ListProjectAuditEntriesParams, authInfo runtime.ClientAuthInfoWriter) (*ListProjectAuditEntriesOK, error)
+
 	ListSeeds(params *ListSeedsParams, authInfo runtime.ClientAuthInfoWriter) (*ListSeedsOK, error)
 
 	PatchKubermaticSettings(params *PatchKubermaticSettingsParams, authInfo runtime.ClientAuthInfoWriter) (*PatchKubermaticSettingsOK, error)
@@ -49,11 +57,49 @@ type ClientService interface {
 
 	UpdateAdmissionPlugin(params *UpdateAdmissionPluginParams, authInfo runtime.ClientAuthInfoWriter) (*UpdateAdmissionPluginOK, error)
 
+	UpdatePricingCatalog(params *UpdatePricingCatalogParams, authInfo runtime.ClientAuthInfoWriter) (*UpdatePricingCatalogOK, error)
+
+	UpdateProjectRole(params *UpdateProjectRoleParams, authInfo runtime.ClientAuthInfoWriter) (*UpdateProjectRoleOK, error)
+
 	UpdateSeed(params *UpdateSeedParams, authInfo runtime.ClientAuthInfoWriter) (*UpdateSeedOK, error)
 
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+  CreateProjectRole creates a custom project role members of projects can be assigned to it
+*/
+func (a *Client) CreateProjectRole(params *CreateProjectRoleParams, authInfo runtime.ClientAuthInfoWriter) (*CreateProjectRoleCreated, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewCreateProjectRoleParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "createProjectRole",
+		Method:             "POST",
+		PathPattern:        "/api/v1/admin/projectroles",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &CreateProjectRoleReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*CreateProjectRoleCreated)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*CreateProjectRoleDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   DeleteAdmissionPlugin deletes the admission plugin
 */
@@ -88,6 +134,40 @@ func (a *Client) DeleteAdmissionPlugin(params *DeleteAdmissionPluginParams, auth
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  DeleteProjectRole deletes the custom project role roles which are assigned to members can t be deleted
+*/
+func (a *Client) DeleteProjectRole(params *DeleteProjectRoleParams, authInfo runtime.ClientAuthInfoWriter) (*DeleteProjectRoleOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDeleteProjectRoleParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "deleteProjectRole",
+		Method:             "DELETE",
+		PathPattern:        "/api/v1/admin/projectroles/{name}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &DeleteProjectRoleReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*DeleteProjectRoleOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*DeleteProjectRoleDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   DeleteSeed deletes the seed c r d object from the kubermatic
 */
@@ -258,6 +338,40 @@ func (a *Client) GetKubermaticSettings(params *GetKubermaticSettingsParams, auth
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  GetPricingCatalog gets the pricing catalog used to estimate the costs of clusters
+*/
+func (a *Client) GetPricingCatalog(params *GetPricingCatalogParams, authInfo runtime.ClientAuthInfoWriter) (*GetPricingCatalogOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetPricingCatalogParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getPricingCatalog",
+		Method:             "GET",
+		PathPattern:        "/api/v1/admin/pricing",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetPricingCatalogReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetPricingCatalogOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetPricingCatalogDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   GetSeed returns the seed object
 */
@@ -326,6 +440,43 @@ func (a *Client) ListAdmissionPlugins(params *ListAdmissionPluginsParams, authIn
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  ListProjectAuditEntries lists the most recent actions taken through the API in the given project newest first
+
+The entries are read from the audit log directory all API replicas share. Returns 501 if the API
+is not configured with an audit log directory.
+*/
+func (a *Client) ListProjectAuditEntries(params *ListProjectAuditEntriesParams, authInfo runtime.ClientAuthInfoWriter) (*ListProjectAuditEntriesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListProjectAuditEntriesParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "listProjectAuditEntries",
+		Method:             "GET",
+		PathPattern:        "/api/v1/admin/projects/{project_id}/audit",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListProjectAuditEntriesReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListProjectAuditEntriesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ListProjectAuditEntriesDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   ListSeeds returns all seeds from the c r ds
 */
@@ -462,6 +613,74 @@ func (a *Client) UpdateAdmissionPlugin(params *UpdateAdmissionPluginParams, auth
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  UpdatePricingCatalog replaces the prices of the pricing catalog only available for admins
+*/
+func (a *Client) UpdatePricingCatalog(params *UpdatePricingCatalogParams, authInfo runtime.ClientAuthInfoWriter) (*UpdatePricingCatalogOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewUpdatePricingCatalogParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "updatePricingCatalog",
+		Method:             "PUT",
+		PathPattern:        "/api/v1/admin/pricing",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &UpdatePricingCatalogReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*UpdatePricingCatalogOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*UpdatePricingCatalogDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+  UpdateProjectRole updates the custom project role the default roles can t be changed
+*/
+func (a *Client) UpdateProjectRole(params *UpdateProjectRoleParams, authInfo runtime.ClientAuthInfoWriter) (*UpdateProjectRoleOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewUpdateProjectRoleParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "updateProjectRole",
+		Method:             "PATCH",
+		PathPattern:        "/api/v1/admin/projectroles/{name}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &UpdateProjectRoleReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*UpdateProjectRoleOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*UpdateProjectRoleDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   UpdateSeed updates the seed
 */
@@ -25,6 +25,7 @@ import (
 	"github.com/kubermatic/kubermatic/pkg/test/e2e/api/utils/apiclient/client/operations"
 	"github.com/kubermatic/kubermatic/pkg/test/e2e/api/utils/apiclient/client/packet"
 	"github.com/kubermatic/kubermatic/pkg/test/e2e/api/utils/apiclient/client/project"
+	"github.com/kubermatic/kubermatic/pkg/test/e2e/api/utils/apiclient/client/projectroles"
 	"github.com/kubermatic/kubermatic/pkg/test/e2e/api/utils/apiclient/client/seed"
 	"github.com/kubermatic/kubermatic/pkg/test/e2e/api/utils/apiclient/client/serviceaccounts"
 	"github.com/kubermatic/kubermatic/pkg/test/e2e/api/utils/apiclient/client/settings"
@@ -91,6 +92,7 @@ func New(transport runtime.ClientTransport, formats strfmt.Registry) *Kubermatic
 	cli.Operations = operations.New(transport, formats)
 	cli.Packet = packet.New(transport, formats)
 	cli.Project = project.New(transport, formats)
+	cli.Projectroles = projectroles.New(transport, formats)
 	cli.Seed = seed.New(transport, formats)
 	cli.Serviceaccounts = serviceaccounts.New(transport, formats)
 	cli.Settings = settings.New(transport, formats)
@@ -172,6 +174,8 @@ type Kubermatic struct {
 
 	Project project.ClientService
 
+	Projectroles projectroles.ClientService
+
 	Seed seed.ClientService
 
 	Serviceaccounts serviceaccounts.ClientService
@@ -207,6 +211,7 @@ func (c *Kubermatic) SetTransport(transport runtime.ClientTransport) {
 	c.Operations.SetTransport(transport)
 	c.Packet.SetTransport(transport)
 	c.Project.SetTransport(transport)
+	c.Projectroles.SetTransport(transport)
 	c.Seed.SetTransport(transport)
 	c.Serviceaccounts.SetTransport(transport)
 	c.Settings.SetTransport(transport)
@@ -21,6 +21,11 @@ type AddonConfigSpec struct {
 	// Controls that can be set for configured addon
 	Controls []*AddonFormControl `json:"formSpec"`
 
+	// Dependencies are the names of the addons which must be installed and healthy before the
+	// configured addon gets installed or upgraded. When addons are removed together, the
+	// configured addon gets removed before its dependencies.
+	Dependencies []string `json:"dependencies"`
+
 	// Description of the configured addon, it will be displayed in the addon overview in the UI
 	Description string `json:"description,omitempty"`
 
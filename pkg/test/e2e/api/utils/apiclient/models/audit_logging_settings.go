@@ -6,6 +6,7 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 )
@@ -17,10 +18,67 @@ type AuditLoggingSettings struct {
 
 	// enabled
 	Enabled bool `json:"enabled,omitempty"`
+
+	// PolicyConfigMap is the name of a ConfigMap in the namespace of the seed containing a custom
+	// audit policy in its "policy.yaml" key. It takes precedence over the PolicyPreset.
+	PolicyConfigMap string `json:"policyConfigMap,omitempty"`
+
+	// policy preset
+	PolicyPreset AuditPolicyPreset `json:"policyPreset,omitempty"`
+
+	// sink
+	Sink *AuditSinkSettings `json:"sink,omitempty"`
 }
 
 // Validate validates this audit logging settings
 func (m *AuditLoggingSettings) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validatePolicyPreset(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSink(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *AuditLoggingSettings) validatePolicyPreset(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.PolicyPreset) { // not required
+		return nil
+	}
+
+	if err := m.PolicyPreset.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("policyPreset")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (m *AuditLoggingSettings) validateSink(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Sink) { // not required
+		return nil
+	}
+
+	if m.Sink != nil {
+		if err := m.Sink.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("sink")
+			}
+			return err
+		}
+	}
+
 	return nil
 }
 
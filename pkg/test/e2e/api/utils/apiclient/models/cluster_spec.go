@@ -18,6 +18,12 @@ import (
 // swagger:model ClusterSpec
 type ClusterSpec struct {
 
+	// APIServerAllowedSourceRanges optionally restricts the CIDRs the apiserver is reachable from.
+	// The addresses of the nodes, the machine networks and the egress ranges of the seed are always
+	// allowed. Nodes which connect through a NAT gateway do so from its address, which must be part
+	// of the ranges.
+	APIServerAllowedSourceRanges []string `json:"apiServerAllowedSourceRanges"`
+
 	// Additional Admission Controller plugins
 	AdmissionPlugins []string `json:"admissionPlugins"`
 
@@ -36,6 +42,12 @@ type ClusterSpec struct {
 	// cloud
 	Cloud *CloudSpec `json:"cloud,omitempty"`
 
+	// etcd backup
+	EtcdBackup *EtcdBackupSettings `json:"etcdBackup,omitempty"`
+
+	// hibernation
+	Hibernation *HibernationSettings `json:"hibernation,omitempty"`
+
 	// oidc
 	Oidc *OIDCSettings `json:"oidc,omitempty"`
 
@@ -65,6 +77,14 @@ func (m *ClusterSpec) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateEtcdBackup(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateHibernation(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateOidc(formats); err != nil {
 		res = append(res, err)
 	}
@@ -144,6 +164,42 @@ func (m *ClusterSpec) validateCloud(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ClusterSpec) validateEtcdBackup(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.EtcdBackup) { // not required
+		return nil
+	}
+
+	if m.EtcdBackup != nil {
+		if err := m.EtcdBackup.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("etcdBackup")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *ClusterSpec) validateHibernation(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Hibernation) { // not required
+		return nil
+	}
+
+	if m.Hibernation != nil {
+		if err := m.Hibernation.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("hibernation")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *ClusterSpec) validateOidc(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Oidc) { // not required
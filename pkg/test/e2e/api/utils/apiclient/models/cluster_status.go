@@ -6,6 +6,7 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 )
@@ -18,12 +19,40 @@ type ClusterStatus struct {
 	// URL specifies the address at which the cluster is available
 	URL string `json:"url,omitempty"`
 
+	// hibernation
+	Hibernation HibernationPhase `json:"hibernation,omitempty"`
+
 	// version
 	Version Semver `json:"version,omitempty"`
 }
 
 // Validate validates this cluster status
 func (m *ClusterStatus) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateHibernation(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ClusterStatus) validateHibernation(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Hibernation) { // not required
+		return nil
+	}
+
+	if err := m.Hibernation.Validate(formats); err != nil {
+		if ve, ok := err.(*errors.Validation); ok {
+			return ve.ValidateName("hibernation")
+		}
+		return err
+	}
+
 	return nil
 }
 
@@ -6,6 +6,8 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
@@ -16,6 +18,10 @@ import (
 // swagger:model DatacenterSpec
 type DatacenterSpec struct {
 
+	// AlternativeDatacenters are equivalent datacenters of other seeds, new clusters are created
+	// in one of them when the seed of this datacenter is full.
+	AlternativeDatacenters []string `json:"alternativeDatacenters"`
+
 	// Optional: Country of the seed as ISO-3166 two-letter code, e.g. DE or UK.
 	// It is used for informational purposes.
 	Country string `json:"country,omitempty"`
@@ -32,6 +38,10 @@ type DatacenterSpec struct {
 	// It is used for informational purposes.
 	Location string `json:"location,omitempty"`
 
+	// OperatingSystemImages is the catalog of operating system images node deployments in this
+	// datacenter can pick from.
+	OperatingSystemImages []*OperatingSystemImage `json:"operatingSystemImages"`
+
 	// Name of the datacenter provider. Extracted based on which provider is defined in the spec.
 	// It is used for informational purposes.
 	Provider string `json:"provider,omitempty"`
@@ -48,6 +58,9 @@ type DatacenterSpec struct {
 	// alibaba
 	Alibaba *DatacenterSpecAlibaba `json:"alibaba,omitempty"`
 
+	// audit logging
+	AuditLogging *AuditLoggingSettings `json:"auditLogging,omitempty"`
+
 	// aws
 	Aws *DatacenterSpecAWS `json:"aws,omitempty"`
 
@@ -89,10 +102,18 @@ type DatacenterSpec struct {
 func (m *DatacenterSpec) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateOperatingSystemImages(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateAlibaba(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateAuditLogging(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateAws(formats); err != nil {
 		res = append(res, err)
 	}
@@ -139,6 +160,31 @@ func (m *DatacenterSpec) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *DatacenterSpec) validateOperatingSystemImages(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.OperatingSystemImages) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.OperatingSystemImages); i++ {
+		if swag.IsZero(m.OperatingSystemImages[i]) { // not required
+			continue
+		}
+
+		if m.OperatingSystemImages[i] != nil {
+			if err := m.OperatingSystemImages[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("operatingSystemImages" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *DatacenterSpec) validateAlibaba(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Alibaba) { // not required
@@ -157,6 +203,24 @@ func (m *DatacenterSpec) validateAlibaba(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *DatacenterSpec) validateAuditLogging(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.AuditLogging) { // not required
+		return nil
+	}
+
+	if m.AuditLogging != nil {
+		if err := m.AuditLogging.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("auditLogging")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *DatacenterSpec) validateAws(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Aws) { // not required
@@ -31,6 +31,13 @@ type NodeDeployment struct {
 	// Name represents human readable name for the resource
 	Name string `json:"name,omitempty"`
 
+	// Warnings point out problems which do not break the node deployment yet, e.g. that it
+	// uses a deprecated operating system image
+	Warnings []string `json:"warnings"`
+
+	// autoscaler status
+	AutoscalerStatus *NodeDeploymentAutoscalerStatus `json:"autoscalerStatus,omitempty"`
+
 	// spec
 	Spec *NodeDeploymentSpec `json:"spec,omitempty"`
 
@@ -50,6 +57,10 @@ func (m *NodeDeployment) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateAutoscalerStatus(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateSpec(formats); err != nil {
 		res = append(res, err)
 	}
@@ -90,6 +101,24 @@ func (m *NodeDeployment) validateDeletionTimestamp(formats strfmt.Registry) erro
 	return nil
 }
 
+func (m *NodeDeployment) validateAutoscalerStatus(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.AutoscalerStatus) { // not required
+		return nil
+	}
+
+	if m.AutoscalerStatus != nil {
+		if err := m.AutoscalerStatus.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("autoscalerStatus")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *NodeDeployment) validateSpec(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Spec) { // not required
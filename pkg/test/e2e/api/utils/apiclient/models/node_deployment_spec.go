@@ -20,6 +20,14 @@ type NodeDeploymentSpec struct {
 	// dynamic config
 	DynamicConfig bool `json:"dynamicConfig,omitempty"`
 
+	// MaxReplicas is the upper bound the cluster-autoscaler scales the node deployment to.
+	// It has to be set together with MinReplicas.
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+
+	// MinReplicas is the lower bound the cluster-autoscaler scales the node deployment to.
+	// It has to be set together with MaxReplicas.
+	MinReplicas int32 `json:"minReplicas,omitempty"`
+
 	// paused
 	Paused bool `json:"paused,omitempty"`
 
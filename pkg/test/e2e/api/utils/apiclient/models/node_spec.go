@@ -23,6 +23,11 @@ type NodeSpec struct {
 	// It will be applied to Nodes allowing users run their apps on specific Node using labelSelector.
 	Labels map[string]string `json:"labels,omitempty"`
 
+	// Name of an image from the operating system image catalog of the datacenter. It takes
+	// precedence over the image set in the cloud spec. If neither is set, the default image
+	// of the operating system from the catalog is used.
+	OperatingSystemImage string `json:"operatingSystemImage,omitempty"`
+
 	// SSH user name
 	SSHUserName string `json:"sshUserName,omitempty"`
 
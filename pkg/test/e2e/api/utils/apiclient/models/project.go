@@ -39,11 +39,20 @@ type Project struct {
 	// Name represents human readable name for the resource
 	Name string `json:"name,omitempty"`
 
+	// OIDCGroups grant the members of groups of the identity provider access to the project, they can only be changed by owners
+	OIDCGroups []*ProjectOIDCGroup `json:"oidcGroups"`
+
 	// Owners an optional owners list for the given project
 	Owners []*User `json:"owners"`
 
 	// status
 	Status string `json:"status,omitempty"`
+
+	// quota
+	Quota *ProjectQuota `json:"quota,omitempty"`
+
+	// usage
+	Usage *ProjectResourceUsage `json:"usage,omitempty"`
 }
 
 // Validate validates this project
@@ -58,10 +67,22 @@ func (m *Project) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateOIDCGroups(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateOwners(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateQuota(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateUsage(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -94,6 +115,31 @@ func (m *Project) validateDeletionTimestamp(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Project) validateOIDCGroups(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.OIDCGroups) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.OIDCGroups); i++ {
+		if swag.IsZero(m.OIDCGroups[i]) { // not required
+			continue
+		}
+
+		if m.OIDCGroups[i] != nil {
+			if err := m.OIDCGroups[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("oidcGroups" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *Project) validateOwners(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Owners) { // not required
@@ -119,6 +165,42 @@ func (m *Project) validateOwners(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Project) validateQuota(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Quota) { // not required
+		return nil
+	}
+
+	if m.Quota != nil {
+		if err := m.Quota.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("quota")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Project) validateUsage(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Usage) { // not required
+		return nil
+	}
+
+	if m.Usage != nil {
+		if err := m.Usage.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("usage")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *Project) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -6,6 +6,8 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
@@ -26,6 +28,7 @@ type PublicServiceAccountToken struct {
 	DeletionTimestamp strfmt.DateTime `json:"deletionTimestamp,omitempty"`
 
 	// Expiry is a timestamp representing the time when this token will expire.
+	// It can be set when the token is created, tokens expire after three years by default.
 	// Format: date-time
 	Expiry strfmt.DateTime `json:"expiry,omitempty"`
 
@@ -34,6 +37,10 @@ type PublicServiceAccountToken struct {
 
 	// Name represents human readable name for the resource
 	Name string `json:"name,omitempty"`
+
+	// Scopes restrict the requests the token can be used for, they can only be set when the token is created.
+	// A token without scopes can be used for all requests its service account is allowed to make.
+	Scopes []*ServiceAccountTokenScope `json:"scopes"`
 }
 
 // Validate validates this public service account token
@@ -52,6 +59,10 @@ func (m *PublicServiceAccountToken) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateScopes(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -97,6 +108,31 @@ func (m *PublicServiceAccountToken) validateExpiry(formats strfmt.Registry) erro
 	return nil
 }
 
+func (m *PublicServiceAccountToken) validateScopes(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Scopes) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Scopes); i++ {
+		if swag.IsZero(m.Scopes[i]) { // not required
+			continue
+		}
+
+		if m.Scopes[i] != nil {
+			if err := m.Scopes[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("scopes" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *PublicServiceAccountToken) MarshalBinary() ([]byte, error) {
 	if m == nil {
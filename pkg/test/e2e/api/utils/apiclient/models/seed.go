@@ -37,6 +37,9 @@ type Seed struct {
 	// across all seeds).
 	SeedDatacenters map[string]Datacenter `json:"datacenters,omitempty"`
 
+	// capacity
+	Capacity *SeedCapacity `json:"capacity,omitempty"`
+
 	// expose strategy
 	ExposeStrategy ServiceType `json:"expose_strategy,omitempty"`
 
@@ -45,6 +48,9 @@ type Seed struct {
 
 	// proxy settings
 	ProxySettings *ProxySettings `json:"proxy_settings,omitempty"`
+
+	// usage
+	Usage *SeedUsage `json:"usage,omitempty"`
 }
 
 // Validate validates this seed
@@ -55,6 +61,10 @@ func (m *Seed) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateCapacity(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateExposeStrategy(formats); err != nil {
 		res = append(res, err)
 	}
@@ -67,6 +77,10 @@ func (m *Seed) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateUsage(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -95,6 +109,24 @@ func (m *Seed) validateSeedDatacenters(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Seed) validateCapacity(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Capacity) { // not required
+		return nil
+	}
+
+	if m.Capacity != nil {
+		if err := m.Capacity.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("capacity")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Seed) validateExposeStrategy(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.ExposeStrategy) { // not required
@@ -147,6 +179,24 @@ func (m *Seed) validateProxySettings(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Seed) validateUsage(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Usage) { // not required
+		return nil
+	}
+
+	if m.Usage != nil {
+		if err := m.Usage.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("usage")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *Seed) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -34,6 +34,9 @@ type SeedSpec struct {
 	// across all seeds).
 	SeedDatacenters map[string]Datacenter `json:"datacenters,omitempty"`
 
+	// capacity
+	Capacity *SeedCapacity `json:"capacity,omitempty"`
+
 	// expose strategy
 	ExposeStrategy ServiceType `json:"expose_strategy,omitempty"`
 
@@ -52,6 +55,10 @@ func (m *SeedSpec) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateCapacity(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateExposeStrategy(formats); err != nil {
 		res = append(res, err)
 	}
@@ -92,6 +99,24 @@ func (m *SeedSpec) validateSeedDatacenters(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *SeedSpec) validateCapacity(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Capacity) { // not required
+		return nil
+	}
+
+	if m.Capacity != nil {
+		if err := m.Capacity.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("capacity")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *SeedSpec) validateExposeStrategy(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.ExposeStrategy) { // not required
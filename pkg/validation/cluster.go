@@ -21,6 +21,8 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
+	"time"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	kuberneteshelper "github.com/kubermatic/kubermatic/pkg/kubernetes"
@@ -30,7 +32,10 @@ import (
 	"github.com/kubermatic/kubermatic/pkg/resources"
 
 	"github.com/coreos/locksmith/pkg/timeutil"
+	"github.com/robfig/cron"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	utilerror "k8s.io/apimachinery/pkg/util/errors"
 )
 
@@ -61,6 +66,10 @@ func ValidateCreateClusterSpec(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.
 		return fmt.Errorf("machine network validation failed, see: %v", err)
 	}
 
+	if err := ValidateEtcdClusterSize(spec.ComponentsOverride.Etcd.ClusterSize, 0); err != nil {
+		return fmt.Errorf("invalid etcd settings: %v", err)
+	}
+
 	return nil
 }
 
@@ -213,6 +222,14 @@ func ValidateUpdateCluster(ctx context.Context, newCluster, oldCluster *kubermat
 		return fmt.Errorf("invalid cloud spec modification: %v", err)
 	}
 
+	if err := ValidateEtcdClusterSize(newCluster.Spec.ComponentsOverride.Etcd.ClusterSize, resources.GetEtcdClusterSize(oldCluster)); err != nil {
+		return fmt.Errorf("invalid etcd settings: %v", err)
+	}
+
+	if err := ValidateAuditFileSinkUpdate(newCluster.Spec.AuditLogging, oldCluster.Spec.AuditLogging); err != nil {
+		return fmt.Errorf("invalid audit logging settings: %v", err)
+	}
+
 	return nil
 }
 
@@ -464,3 +481,161 @@ func ValidateUpdateWindow(updateWindow *kubermaticv1.UpdateWindow) error {
 	}
 	return nil
 }
+
+// ValidateEtcdBackupSettings validates the per-cluster etcd backup settings
+func ValidateEtcdBackupSettings(settings *kubermaticv1.EtcdBackupSettings) error {
+	if settings == nil {
+		return nil
+	}
+	if settings.Schedule != "" {
+		// The cronjob controller only validates the schedule inside its sync loop,
+		// so an invalid schedule would silently result in no backups at all
+		if _, err := cron.ParseStandard(settings.Schedule); err != nil {
+			return fmt.Errorf("invalid schedule %q: %v", settings.Schedule, err)
+		}
+	}
+	if settings.RevisionsToKeep != nil && *settings.RevisionsToKeep < 1 {
+		return errors.New("revisionsToKeep must be at least 1")
+	}
+	return nil
+}
+
+// ValidateEtcdClusterSize validates the number of etcd members of a cluster whose etcd currently has
+// currentSize members. Etcd can't be scaled down, the removed members count towards the quorum until
+// the remaining ones removed them, so a single failing member could make the cluster lose its quorum.
+func ValidateEtcdClusterSize(size, currentSize int) error {
+	if size == 0 {
+		size = resources.EtcdClusterSize
+	}
+	if size < resources.EtcdClusterSize || size > resources.MaxEtcdClusterSize || size%2 == 0 {
+		return fmt.Errorf("the cluster size must be an odd number between %d and %d, got %d", resources.EtcdClusterSize, resources.MaxEtcdClusterSize, size)
+	}
+	if size < currentSize {
+		return fmt.Errorf("scaling down from %d to %d members is not supported", currentSize, size)
+	}
+	return nil
+}
+
+// ValidateHibernationSettings validates the hibernation settings of a cluster.
+// Openshift clusters can not be hibernated, they may neither enable hibernation nor have schedules.
+func ValidateHibernationSettings(settings *kubermaticv1.HibernationSettings, openshift bool) error {
+	if settings == nil {
+		return nil
+	}
+	if openshift && (settings.Enabled || len(settings.Schedules) > 0) {
+		return errors.New("hibernation is not supported for openshift clusters")
+	}
+	if settings.TimeZone != "" {
+		if _, err := time.LoadLocation(settings.TimeZone); err != nil {
+			return fmt.Errorf("invalid time zone %q: %v", settings.TimeZone, err)
+		}
+	}
+	for _, schedule := range settings.Schedules {
+		if _, err := timeutil.ParsePeriodic(schedule.Start, schedule.Length); err != nil {
+			return fmt.Errorf("invalid schedule %q/%q: %v", schedule.Start, schedule.Length, err)
+		}
+	}
+	return nil
+}
+
+// ValidateAPIServerAllowedSourceRanges validates the CIDRs the apiserver of a cluster may be restricted to.
+// Apiservers which are not exposed by their own LoadBalancer can only be restricted if the nodeport-proxy
+// of the seed preserves the client addresses.
+func ValidateAPIServerAllowedSourceRanges(ranges []string, exposeStrategy corev1.ServiceType, seed *kubermaticv1.Seed) error {
+	for _, r := range ranges {
+		if _, _, err := net.ParseCIDR(r); err != nil {
+			return fmt.Errorf("invalid CIDR %q: %v", r, err)
+		}
+	}
+	if len(ranges) > 0 && exposeStrategy != corev1.ServiceTypeLoadBalancer && seed.Spec.NodeportProxy.ExternalTrafficPolicy != corev1.ServiceExternalTrafficPolicyTypeLocal {
+		return fmt.Errorf("the nodeport-proxy of seed %q does not preserve the client addresses, its external traffic policy must be %s", seed.Name, corev1.ServiceExternalTrafficPolicyTypeLocal)
+	}
+	return nil
+}
+
+// ValidateAuditFileSinkUpdate validates that the volume of an existing audit file sink is not changed,
+// as the PersistentVolumeClaim can not be updated. The sink has to be removed first.
+func ValidateAuditFileSinkUpdate(newSettings, oldSettings *kubermaticv1.AuditLoggingSettings) error {
+	if newSettings == nil || newSettings.Sink == nil || newSettings.Sink.File == nil {
+		return nil
+	}
+	if oldSettings == nil || oldSettings.Sink == nil || oldSettings.Sink.File == nil {
+		return nil
+	}
+	newSink, oldSink := newSettings.Sink.File, oldSettings.Sink.File
+	if newSink.Size != oldSink.Size {
+		return fmt.Errorf("changing the size of the file sink from %q to %q is not allowed, the sink has to be removed first", oldSink.Size, newSink.Size)
+	}
+	if newSink.StorageClassName != oldSink.StorageClassName {
+		return errors.New("changing the storage class of the file sink is not allowed, the sink has to be removed first")
+	}
+	if newSink.AccessMode != oldSink.AccessMode {
+		return errors.New("changing the access mode of the file sink is not allowed, the sink has to be removed first")
+	}
+	return nil
+}
+
+// ValidateAuditLoggingSettings validates the audit policy and sinks of a cluster or datacenter
+func ValidateAuditLoggingSettings(settings *kubermaticv1.AuditLoggingSettings) error {
+	if settings == nil {
+		return nil
+	}
+	if settings.PolicyPreset != "" {
+		valid := false
+		for _, preset := range kubermaticv1.AllAuditPolicyPresets {
+			if settings.PolicyPreset == preset {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("unknown policy preset %q, must be one of %v", settings.PolicyPreset, kubermaticv1.AllAuditPolicyPresets)
+		}
+	}
+	if settings.PolicyPreset != "" && settings.PolicyConfigMap != "" {
+		return errors.New("policyPreset and policyConfigMap are mutually exclusive")
+	}
+
+	sink := settings.Sink
+	if sink == nil {
+		return nil
+	}
+	if sink.File != nil && sink.File.Size != "" {
+		size, err := resource.ParseQuantity(sink.File.Size)
+		if err != nil {
+			return fmt.Errorf("invalid file sink size %q: %v", sink.File.Size, err)
+		}
+		// A single apiserver replica and the one replacing it during a rollout need to fit
+		minSize := resource.MustParse(resources.AuditFileSinkMinSize)
+		minSize.Set(2 * minSize.Value())
+		if size.Cmp(minSize) < 0 {
+			return fmt.Errorf("file sink size %q must be at least %s", sink.File.Size, minSize.String())
+		}
+	}
+	if sink.File != nil {
+		if mode := sink.File.AccessMode; mode != "" && mode != corev1.ReadWriteOnce && mode != corev1.ReadWriteMany {
+			return fmt.Errorf("invalid file sink access mode %q, must be %s or %s", mode, corev1.ReadWriteOnce, corev1.ReadWriteMany)
+		}
+	}
+	if sink.Syslog != nil {
+		if sink.Syslog.Host == "" {
+			return errors.New("syslog sink host must not be empty")
+		}
+		if sink.Syslog.Port < 1 || sink.Syslog.Port > 65535 {
+			return fmt.Errorf("invalid syslog sink port %d", sink.Syslog.Port)
+		}
+		if protocol := sink.Syslog.Protocol; protocol != "" && protocol != "udp" && protocol != "tcp" {
+			return fmt.Errorf("invalid syslog sink protocol %q, must be udp or tcp", protocol)
+		}
+	}
+	if sink.Webhook != nil {
+		u, err := url.Parse(sink.Webhook.URL)
+		if err != nil {
+			return fmt.Errorf("invalid webhook sink URL %q: %v", sink.Webhook.URL, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+			return fmt.Errorf("invalid webhook sink URL %q, must be an absolute http or https URL", sink.Webhook.URL)
+		}
+	}
+	return nil
+}
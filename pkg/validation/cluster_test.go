@@ -22,6 +22,8 @@ import (
 	"testing"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
 )
 
 var (
@@ -152,3 +154,399 @@ func TestValidateUpdateWindow(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateEtcdBackupSettings(t *testing.T) {
+	zero := int32(0)
+	thirtyDays := int32(720)
+	tests := []struct {
+		name     string
+		settings *kubermaticv1.EtcdBackupSettings
+		valid    bool
+	}{
+		{
+			name:  "no settings",
+			valid: true,
+		},
+		{
+			name: "hourly backups kept for 30 days",
+			settings: &kubermaticv1.EtcdBackupSettings{
+				Schedule:        "0 * * * *",
+				RevisionsToKeep: &thirtyDays,
+			},
+			valid: true,
+		},
+		{
+			name: "disabled backups",
+			settings: &kubermaticv1.EtcdBackupSettings{
+				Disabled: true,
+			},
+			valid: true,
+		},
+		{
+			name: "invalid schedule",
+			settings: &kubermaticv1.EtcdBackupSettings{
+				Schedule: "every hour",
+			},
+			valid: false,
+		},
+		{
+			name: "no revisions to keep",
+			settings: &kubermaticv1.EtcdBackupSettings{
+				RevisionsToKeep: &zero,
+			},
+			valid: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateEtcdBackupSettings(test.settings)
+			if (err == nil) != test.valid {
+				t.Errorf("Expected valid=%v, got err=%v", test.valid, err)
+			}
+		})
+	}
+}
+
+func TestValidateEtcdClusterSize(t *testing.T) {
+	tests := []struct {
+		name        string
+		size        int
+		currentSize int
+		valid       bool
+	}{
+		{
+			name:  "default size",
+			valid: true,
+		},
+		{
+			name:  "new cluster with five members",
+			size:  5,
+			valid: true,
+		},
+		{
+			name:  "even size",
+			size:  4,
+			valid: false,
+		},
+		{
+			name:  "too small",
+			size:  1,
+			valid: false,
+		},
+		{
+			name:  "too large",
+			size:  7,
+			valid: false,
+		},
+		{
+			name:        "scale up",
+			size:        5,
+			currentSize: 3,
+			valid:       true,
+		},
+		{
+			name:        "scale down",
+			size:        3,
+			currentSize: 5,
+			valid:       false,
+		},
+		{
+			name:        "scale down to the default size",
+			currentSize: 5,
+			valid:       false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateEtcdClusterSize(test.size, test.currentSize)
+			if (err == nil) != test.valid {
+				t.Errorf("Expected valid=%v, got err=%v", test.valid, err)
+			}
+		})
+	}
+}
+
+func TestValidateHibernationSettings(t *testing.T) {
+	tests := []struct {
+		name      string
+		settings  *kubermaticv1.HibernationSettings
+		openshift bool
+		valid     bool
+	}{
+		{
+			name:  "no settings",
+			valid: true,
+		},
+		{
+			name: "nightly and weekend schedules",
+			settings: &kubermaticv1.HibernationSettings{
+				TimeZone: "Europe/Berlin",
+				Schedules: []kubermaticv1.HibernationSchedule{
+					{Start: "19:00", Length: "12h"},
+					{Start: "Fri 19:00", Length: "60h"},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid time zone",
+			settings: &kubermaticv1.HibernationSettings{
+				TimeZone: "Berlin",
+			},
+			valid: false,
+		},
+		{
+			name: "invalid start",
+			settings: &kubermaticv1.HibernationSettings{
+				Schedules: []kubermaticv1.HibernationSchedule{{Start: "7pm", Length: "12h"}},
+			},
+			valid: false,
+		},
+		{
+			name: "daily schedule longer than a day",
+			settings: &kubermaticv1.HibernationSettings{
+				Schedules: []kubermaticv1.HibernationSchedule{{Start: "19:00", Length: "25h"}},
+			},
+			valid: false,
+		},
+		{
+			name: "openshift cluster with schedules",
+			settings: &kubermaticv1.HibernationSettings{
+				Schedules: []kubermaticv1.HibernationSchedule{{Start: "19:00", Length: "12h"}},
+			},
+			openshift: true,
+			valid:     false,
+		},
+		{
+			name:      "openshift cluster with hibernation enabled",
+			settings:  &kubermaticv1.HibernationSettings{Enabled: true},
+			openshift: true,
+			valid:     false,
+		},
+		{
+			name:      "openshift cluster with only a time zone",
+			settings:  &kubermaticv1.HibernationSettings{TimeZone: "Europe/Berlin"},
+			openshift: true,
+			valid:     true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateHibernationSettings(test.settings, test.openshift)
+			if (err == nil) != test.valid {
+				t.Errorf("Expected valid=%v, got err=%v", test.valid, err)
+			}
+		})
+	}
+}
+
+func TestValidateAPIServerAllowedSourceRanges(t *testing.T) {
+	localSeed := &kubermaticv1.Seed{}
+	localSeed.Spec.NodeportProxy.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeLocal
+
+	tests := []struct {
+		name           string
+		ranges         []string
+		exposeStrategy corev1.ServiceType
+		seed           *kubermaticv1.Seed
+		valid          bool
+	}{
+		{
+			name:           "no ranges",
+			exposeStrategy: corev1.ServiceTypeNodePort,
+			seed:           &kubermaticv1.Seed{},
+			valid:          true,
+		},
+		{
+			name:           "IPv4 and IPv6 ranges",
+			ranges:         []string{"10.0.0.0/8", "192.168.1.1/32", "2001:db8::/32"},
+			exposeStrategy: corev1.ServiceTypeNodePort,
+			seed:           localSeed,
+			valid:          true,
+		},
+		{
+			name:           "address without prefix length",
+			ranges:         []string{"192.168.1.1"},
+			exposeStrategy: corev1.ServiceTypeNodePort,
+			seed:           localSeed,
+			valid:          false,
+		},
+		{
+			name:           "invalid range",
+			ranges:         []string{"10.0.0.0/8", "10.0.0.0/33"},
+			exposeStrategy: corev1.ServiceTypeNodePort,
+			seed:           localSeed,
+			valid:          false,
+		},
+		{
+			name:           "nodeport-proxy does not preserve the client addresses",
+			ranges:         []string{"10.0.0.0/8"},
+			exposeStrategy: corev1.ServiceTypeNodePort,
+			seed:           &kubermaticv1.Seed{},
+			valid:          false,
+		},
+		{
+			name:           "LoadBalancer does not depend on the nodeport-proxy",
+			ranges:         []string{"10.0.0.0/8"},
+			exposeStrategy: corev1.ServiceTypeLoadBalancer,
+			seed:           &kubermaticv1.Seed{},
+			valid:          true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateAPIServerAllowedSourceRanges(test.ranges, test.exposeStrategy, test.seed)
+			if (err == nil) != test.valid {
+				t.Errorf("Expected valid=%v, got err=%v", test.valid, err)
+			}
+		})
+	}
+}
+
+func TestValidateAuditLoggingSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings *kubermaticv1.AuditLoggingSettings
+		valid    bool
+	}{
+		{
+			name:  "no settings",
+			valid: true,
+		},
+		{
+			name: "preset with all sinks",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Enabled:      true,
+				PolicyPreset: kubermaticv1.AuditPolicyPresetRecommended,
+				Sink: &kubermaticv1.AuditSinkSettings{
+					File:    &kubermaticv1.AuditFileSink{Size: "5Gi"},
+					Syslog:  &kubermaticv1.AuditSyslogSink{Host: "syslog.example.com", Port: 514, Protocol: "tcp"},
+					Webhook: &kubermaticv1.AuditWebhookSink{URL: "https://audit.example.com/events"},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "unknown preset",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				PolicyPreset: "verbose",
+			},
+			valid: false,
+		},
+		{
+			name: "preset and custom policy",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				PolicyPreset:    kubermaticv1.AuditPolicyPresetMinimal,
+				PolicyConfigMap: "audit-policy",
+			},
+			valid: false,
+		},
+		{
+			name: "invalid file sink size",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Sink: &kubermaticv1.AuditSinkSettings{File: &kubermaticv1.AuditFileSink{Size: "lots"}},
+			},
+			valid: false,
+		},
+		{
+			name: "file sink too small",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Sink: &kubermaticv1.AuditSinkSettings{File: &kubermaticv1.AuditFileSink{Size: "100Mi"}},
+			},
+			valid: false,
+		},
+		{
+			name: "file sink with unknown access mode",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Sink: &kubermaticv1.AuditSinkSettings{File: &kubermaticv1.AuditFileSink{AccessMode: corev1.ReadOnlyMany}},
+			},
+			valid: false,
+		},
+		{
+			name: "syslog sink without port",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Sink: &kubermaticv1.AuditSinkSettings{Syslog: &kubermaticv1.AuditSyslogSink{Host: "syslog.example.com"}},
+			},
+			valid: false,
+		},
+		{
+			name: "syslog sink with unknown protocol",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Sink: &kubermaticv1.AuditSinkSettings{Syslog: &kubermaticv1.AuditSyslogSink{Host: "syslog.example.com", Port: 514, Protocol: "tls"}},
+			},
+			valid: false,
+		},
+		{
+			name: "webhook sink without scheme",
+			settings: &kubermaticv1.AuditLoggingSettings{
+				Sink: &kubermaticv1.AuditSinkSettings{Webhook: &kubermaticv1.AuditWebhookSink{URL: "audit.example.com/events"}},
+			},
+			valid: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateAuditLoggingSettings(test.settings)
+			if (err == nil) != test.valid {
+				t.Errorf("Expected valid=%v, got err=%v", test.valid, err)
+			}
+		})
+	}
+}
+
+func TestValidateAuditFileSinkUpdate(t *testing.T) {
+	fileSink := func(sink *kubermaticv1.AuditFileSink) *kubermaticv1.AuditLoggingSettings {
+		return &kubermaticv1.AuditLoggingSettings{Enabled: true, Sink: &kubermaticv1.AuditSinkSettings{File: sink}}
+	}
+
+	tests := []struct {
+		name        string
+		newSettings *kubermaticv1.AuditLoggingSettings
+		oldSettings *kubermaticv1.AuditLoggingSettings
+		valid       bool
+	}{
+		{
+			name:        "adding a file sink",
+			newSettings: fileSink(&kubermaticv1.AuditFileSink{Size: "5Gi"}),
+			valid:       true,
+		},
+		{
+			name:        "removing a file sink",
+			oldSettings: fileSink(&kubermaticv1.AuditFileSink{Size: "5Gi"}),
+			newSettings: &kubermaticv1.AuditLoggingSettings{Enabled: true},
+			valid:       true,
+		},
+		{
+			name:        "unchanged file sink",
+			oldSettings: fileSink(&kubermaticv1.AuditFileSink{Size: "5Gi", StorageClassName: "kubermatic-fast"}),
+			newSettings: fileSink(&kubermaticv1.AuditFileSink{Size: "5Gi", StorageClassName: "kubermatic-fast"}),
+			valid:       true,
+		},
+		{
+			name:        "resized file sink",
+			oldSettings: fileSink(&kubermaticv1.AuditFileSink{Size: "5Gi"}),
+			newSettings: fileSink(&kubermaticv1.AuditFileSink{Size: "10Gi"}),
+			valid:       false,
+		},
+		{
+			name:        "file sink with another storage class",
+			oldSettings: fileSink(&kubermaticv1.AuditFileSink{}),
+			newSettings: fileSink(&kubermaticv1.AuditFileSink{StorageClassName: "kubermatic-fast"}),
+			valid:       false,
+		},
+		{
+			name:        "file sink with another access mode",
+			oldSettings: fileSink(&kubermaticv1.AuditFileSink{}),
+			newSettings: fileSink(&kubermaticv1.AuditFileSink{AccessMode: corev1.ReadWriteMany}),
+			valid:       false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateAuditFileSinkUpdate(test.newSettings, test.oldSettings)
+			if (err == nil) != test.valid {
+				t.Errorf("Expected valid=%v, got err=%v", test.valid, err)
+			}
+		})
+	}
+}
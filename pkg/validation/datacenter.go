@@ -0,0 +1,70 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+)
+
+// ValidateOperatingSystemImages validates the operating system image catalog of a datacenter
+func ValidateOperatingSystemImages(images []kubermaticv1.OperatingSystemImage) error {
+	names := map[string]struct{}{}
+	defaults := map[providerconfig.OperatingSystem]string{}
+
+	for _, image := range images {
+		if image.Name == "" {
+			return fmt.Errorf("image %q has no name", image.Image)
+		}
+		if _, ok := names[image.Name]; ok {
+			return fmt.Errorf("image name %q is used more than once", image.Name)
+		}
+		names[image.Name] = struct{}{}
+
+		if image.Image == "" {
+			return fmt.Errorf("image %q does not reference a provider image", image.Name)
+		}
+		if !isSupportedOperatingSystem(image.OperatingSystem) {
+			return fmt.Errorf("image %q has unsupported operating system %q, must be one of %v", image.Name, image.OperatingSystem, providerconfig.AllOperatingSystems)
+		}
+
+		if !image.Default {
+			continue
+		}
+		if image.Deprecated {
+			return fmt.Errorf("image %q cannot be the default, it is deprecated", image.Name)
+		}
+		if other, ok := defaults[image.OperatingSystem]; ok {
+			return fmt.Errorf("images %q and %q are both the default for %s", other, image.Name, image.OperatingSystem)
+		}
+		defaults[image.OperatingSystem] = image.Name
+	}
+
+	return nil
+}
+
+func isSupportedOperatingSystem(os providerconfig.OperatingSystem) bool {
+	for _, supported := range providerconfig.AllOperatingSystems {
+		if os == supported {
+			return true
+		}
+	}
+	return false
+}
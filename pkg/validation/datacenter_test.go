@@ -0,0 +1,105 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+)
+
+func TestValidateOperatingSystemImages(t *testing.T) {
+	ubuntu1804 := kubermaticv1.OperatingSystemImage{Name: "ubuntu-18.04", OperatingSystem: providerconfig.OperatingSystemUbuntu, Version: "18.04", Image: "ubuntu-bionic"}
+	ubuntu2004 := kubermaticv1.OperatingSystemImage{Name: "ubuntu-20.04", OperatingSystem: providerconfig.OperatingSystemUbuntu, Version: "20.04", Image: "ubuntu-focal", Default: true}
+	centos := kubermaticv1.OperatingSystemImage{Name: "centos-7", OperatingSystem: providerconfig.OperatingSystemCentOS, Image: "centos-7", Default: true}
+
+	tests := []struct {
+		name   string
+		images func() []kubermaticv1.OperatingSystemImage
+		valid  bool
+	}{
+		{
+			name:   "no images",
+			images: func() []kubermaticv1.OperatingSystemImage { return nil },
+			valid:  true,
+		},
+		{
+			name: "one default per operating system",
+			images: func() []kubermaticv1.OperatingSystemImage {
+				deprecated := ubuntu1804
+				deprecated.Deprecated = true
+				return []kubermaticv1.OperatingSystemImage{deprecated, ubuntu2004, centos}
+			},
+			valid: true,
+		},
+		{
+			name: "duplicate name",
+			images: func() []kubermaticv1.OperatingSystemImage {
+				duplicate := ubuntu1804
+				duplicate.Name = ubuntu2004.Name
+				return []kubermaticv1.OperatingSystemImage{duplicate, ubuntu2004}
+			},
+			valid: false,
+		},
+		{
+			name: "missing provider image",
+			images: func() []kubermaticv1.OperatingSystemImage {
+				missing := ubuntu1804
+				missing.Image = ""
+				return []kubermaticv1.OperatingSystemImage{missing}
+			},
+			valid: false,
+		},
+		{
+			name: "unsupported operating system",
+			images: func() []kubermaticv1.OperatingSystemImage {
+				unsupported := centos
+				unsupported.OperatingSystem = "windows"
+				return []kubermaticv1.OperatingSystemImage{unsupported}
+			},
+			valid: false,
+		},
+		{
+			name: "two defaults for the same operating system",
+			images: func() []kubermaticv1.OperatingSystemImage {
+				secondDefault := ubuntu1804
+				secondDefault.Default = true
+				return []kubermaticv1.OperatingSystemImage{secondDefault, ubuntu2004}
+			},
+			valid: false,
+		},
+		{
+			name: "deprecated default",
+			images: func() []kubermaticv1.OperatingSystemImage {
+				deprecated := ubuntu2004
+				deprecated.Deprecated = true
+				return []kubermaticv1.OperatingSystemImage{deprecated}
+			},
+			valid: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateOperatingSystemImages(test.images())
+			if (err == nil) != test.valid {
+				t.Errorf("Expected valid=%v, got err=%v", test.valid, err)
+			}
+		})
+	}
+}
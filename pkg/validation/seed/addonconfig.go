@@ -0,0 +1,76 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seed
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	addonutils "github.com/kubermatic/kubermatic/pkg/addon"
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+)
+
+// AddonConfigValidationPath is the path the webhook server validates AddonConfigs on
+const AddonConfigValidationPath = "/addonconfigs"
+
+func (s *Server) handleAddonConfigValidationRequests(resp http.ResponseWriter, req *http.Request) {
+	admissionRequest, validationErr := s.handleAddonConfig(req)
+	if validationErr != nil {
+		s.log.Warnw("AddonConfig admission failed", zap.Error(validationErr))
+	}
+
+	if s.writeResponse(resp, admissionRequest, validationErr) {
+		s.log.Debug("Successfully validated AddonConfig")
+	}
+}
+
+func (s *Server) handleAddonConfig(req *http.Request) (*admissionv1beta1.AdmissionRequest, error) {
+	admissionReview, err := readAdmissionReview(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Removing an AddonConfig can not create a dependency cycle
+	if admissionReview.Request.Operation == admissionv1beta1.Delete {
+		return admissionReview.Request, nil
+	}
+
+	config := &kubermaticv1.AddonConfig{}
+	if err := json.Unmarshal(admissionReview.Request.Object.Raw, config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal object from request into an AddonConfig: %v", err)
+	}
+
+	return admissionReview.Request, s.validateAddonConfig(config)
+}
+
+// validateAddonConfig validates the dependencies of the AddonConfig against the dependencies
+// of all other AddonConfigs
+func (s *Server) validateAddonConfig(config *kubermaticv1.AddonConfig) error {
+	graph, err := addonutils.GetDependencyGraph(s.ctx, s.client)
+	if err != nil {
+		return err
+	}
+	if err := graph.ValidateDependencies(config.Name, config.Spec.Dependencies); err != nil {
+		return fmt.Errorf("invalid dependencies: %v", err)
+	}
+	return nil
+}
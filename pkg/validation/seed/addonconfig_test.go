@@ -0,0 +1,78 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seed
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidateAddonConfig(t *testing.T) {
+	s := &Server{
+		ctx: context.Background(),
+		client: fakectrlruntimeclient.NewFakeClient(
+			genAddonConfig("ingress", "cert-manager"),
+			genAddonConfig("cert-manager", "rbac"),
+		),
+	}
+
+	testCases := []struct {
+		name        string
+		config      *kubermaticv1.AddonConfig
+		expectedErr bool
+	}{
+		{
+			name:   "new AddonConfig depending on existing addons",
+			config: genAddonConfig("registry", "ingress"),
+		},
+		{
+			name:   "updated AddonConfig",
+			config: genAddonConfig("cert-manager"),
+		},
+		{
+			name:        "AddonConfig closing a cycle",
+			config:      genAddonConfig("rbac", "ingress"),
+			expectedErr: true,
+		},
+		{
+			name:        "AddonConfig depending on itself",
+			config:      genAddonConfig("rbac", "rbac"),
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := s.validateAddonConfig(tc.config)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error to be %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func genAddonConfig(name string, dependencies ...string) *kubermaticv1.AddonConfig {
+	return &kubermaticv1.AddonConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       kubermaticv1.AddonConfigSpec{Dependencies: dependencies},
+	}
+}
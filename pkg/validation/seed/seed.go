@@ -19,11 +19,14 @@ package seed
 import (
 	"context"
 	"fmt"
+	"net"
 	"sync"
 
 	kubermaticv1 "github.com/kubermatic/kubermatic/pkg/crd/kubermatic/v1"
 	"github.com/kubermatic/kubermatic/pkg/provider"
+	"github.com/kubermatic/kubermatic/pkg/validation"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -125,6 +128,36 @@ func (sv *seedValidator) validate(subject *kubermaticv1.Seed, seedClient ctrlrun
 		}
 	}
 
+	if !isDelete {
+		// check if the alternative datacenters are datacenters of other seeds using the same provider
+		for dcName, dc := range subject.Spec.Datacenters {
+			for _, alternative := range dc.Spec.AlternativeDatacenters {
+				if err := validateAlternativeDatacenter(dcName, &dc, alternative, subject, existingSeeds); err != nil {
+					return err
+				}
+			}
+			if err := validation.ValidateOperatingSystemImages(dc.Spec.OperatingSystemImages); err != nil {
+				return fmt.Errorf("datacenter %q has invalid operating system images: %v", dcName, err)
+			}
+		}
+
+		if err := validateCapacity(subject.Spec.Capacity); err != nil {
+			return err
+		}
+
+		for _, cidr := range subject.Spec.EgressRanges {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return fmt.Errorf("invalid egress range %q: %v", cidr, err)
+			}
+		}
+
+		switch policy := subject.Spec.NodeportProxy.ExternalTrafficPolicy; policy {
+		case "", corev1.ServiceExternalTrafficPolicyTypeCluster, corev1.ServiceExternalTrafficPolicyTypeLocal:
+		default:
+			return fmt.Errorf("invalid nodeport-proxy external traffic policy %q, must be %s or %s", policy, corev1.ServiceExternalTrafficPolicyTypeCluster, corev1.ServiceExternalTrafficPolicyTypeLocal)
+		}
+	}
+
 	// check if there are still clusters using DCs not defined anymore
 	clusters := &kubermaticv1.ClusterList{}
 	if err := seedClient.List(sv.ctx, clusters, sv.listOpts); err != nil {
@@ -142,3 +175,38 @@ func (sv *seedValidator) validate(subject *kubermaticv1.Seed, seedClient ctrlrun
 
 	return nil
 }
+
+func validateAlternativeDatacenter(dcName string, dc *kubermaticv1.Datacenter, alternative string, subject *kubermaticv1.Seed, existingSeeds map[string]*kubermaticv1.Seed) error {
+	if _, ok := subject.Spec.Datacenters[alternative]; ok {
+		return fmt.Errorf("alternative datacenter %q of datacenter %q must belong to another seed", alternative, dcName)
+	}
+	for _, existingSeed := range existingSeeds {
+		alternativeDC, ok := existingSeed.Spec.Datacenters[alternative]
+		if !ok {
+			continue
+		}
+		providerName, _ := provider.DatacenterCloudProviderName(&dc.Spec)
+		alternativeProvider, _ := provider.DatacenterCloudProviderName(&alternativeDC.Spec)
+		if providerName != alternativeProvider {
+			return fmt.Errorf("alternative datacenter %q of datacenter %q uses provider %q instead of %q", alternative, dcName, alternativeProvider, providerName)
+		}
+		return nil
+	}
+	return fmt.Errorf("alternative datacenter %q of datacenter %q does not exist", alternative, dcName)
+}
+
+func validateCapacity(capacity *kubermaticv1.SeedCapacity) error {
+	if capacity == nil {
+		return nil
+	}
+	if capacity.MaxClusters != nil && *capacity.MaxClusters < 0 {
+		return fmt.Errorf("the maximum number of clusters must not be negative")
+	}
+	if capacity.ControlPlaneCPU != nil && capacity.ControlPlaneCPU.Sign() < 0 {
+		return fmt.Errorf("the control plane CPU budget must not be negative")
+	}
+	if capacity.ControlPlaneMemory != nil && capacity.ControlPlaneMemory.Sign() < 0 {
+		return fmt.Errorf("the control plane memory budget must not be negative")
+	}
+	return nil
+}
@@ -32,6 +32,7 @@ func TestValidate(t *testing.T) {
 	fakeProviderSpec := kubermaticv1.DatacenterSpec{
 		Fake: &kubermaticv1.DatacenterSpecFake{},
 	}
+	negativeMaxClusters := int64(-1)
 
 	testCases := []struct {
 		name             string
@@ -358,6 +359,157 @@ func TestValidate(t *testing.T) {
 			},
 			errExpected: true,
 		},
+		{
+			name: "Alternative datacenters of other seeds with the same provider are allowed",
+			existingSeeds: map[string]*kubermaticv1.Seed{
+				"existing-seed": {
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "existing-seed",
+					},
+					Spec: kubermaticv1.SeedSpec{
+						Datacenters: map[string]kubermaticv1.Datacenter{
+							"dc1": {
+								Spec: fakeProviderSpec,
+							},
+						},
+					},
+				},
+			},
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					Datacenters: map[string]kubermaticv1.Datacenter{
+						"dc2": {
+							Spec: kubermaticv1.DatacenterSpec{
+								Fake:                   &kubermaticv1.DatacenterSpecFake{},
+								AlternativeDatacenters: []string{"dc1"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Alternative datacenters must use the same provider",
+			existingSeeds: map[string]*kubermaticv1.Seed{
+				"existing-seed": {
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "existing-seed",
+					},
+					Spec: kubermaticv1.SeedSpec{
+						Datacenters: map[string]kubermaticv1.Datacenter{
+							"dc1": {
+								Spec: kubermaticv1.DatacenterSpec{
+									AWS: &kubermaticv1.DatacenterSpecAWS{},
+								},
+							},
+						},
+					},
+				},
+			},
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					Datacenters: map[string]kubermaticv1.Datacenter{
+						"dc2": {
+							Spec: kubermaticv1.DatacenterSpec{
+								Fake:                   &kubermaticv1.DatacenterSpecFake{},
+								AlternativeDatacenters: []string{"dc1"},
+							},
+						},
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Alternative datacenters must belong to another seed",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					Datacenters: map[string]kubermaticv1.Datacenter{
+						"dc1": {
+							Spec: fakeProviderSpec,
+						},
+						"dc2": {
+							Spec: kubermaticv1.DatacenterSpec{
+								Fake:                   &kubermaticv1.DatacenterSpecFake{},
+								AlternativeDatacenters: []string{"dc1"},
+							},
+						},
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Operating system images must be unique",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					Datacenters: map[string]kubermaticv1.Datacenter{
+						"dc1": {
+							Spec: kubermaticv1.DatacenterSpec{
+								Fake: &kubermaticv1.DatacenterSpecFake{},
+								OperatingSystemImages: []kubermaticv1.OperatingSystemImage{
+									{Name: "ubuntu-20.04", OperatingSystem: "ubuntu", Image: "ubuntu-focal"},
+									{Name: "ubuntu-20.04", OperatingSystem: "ubuntu", Image: "ubuntu-focal-minimal"},
+								},
+							},
+						},
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "The seed capacity must not be negative",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					Capacity: &kubermaticv1.SeedCapacity{
+						MaxClusters: &negativeMaxClusters,
+					},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "Egress ranges must be CIDRs",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					EgressRanges: []string{"203.0.113.0/24", "203.0.113.10"},
+				},
+			},
+			errExpected: true,
+		},
+		{
+			name: "The nodeport-proxy external traffic policy must be valid",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					NodeportProxy: kubermaticv1.NodeportProxyConfig{
+						ExternalTrafficPolicy: "Remote",
+					},
+				},
+			},
+			errExpected: true,
+		},
 		{
 			name:           "Shuld be able to delete empty seeds",
 			seedToValidate: &kubermaticv1.Seed{},
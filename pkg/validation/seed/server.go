@@ -50,9 +50,10 @@ func (opts *WebhookOpts) AddFlags(fs *flag.FlagSet) {
 	fs.StringVar(&opts.KeyFile, "seed-admissionwebhook-key-file", "", "The location of the certificate key file")
 }
 
-// Server returns a Server that validates AdmissionRequests for Seed CRs.
+// Server returns a Server that validates AdmissionRequests for Seed CRs and AddonConfigs.
 // When migrationModeEnabled is enabled, only creating new seeds is allowed, not
-// changing or deleting existing.
+// changing or deleting existing. The client is used to read the AddonConfigs of the cluster
+// the webhook is running in.
 func (opts *WebhookOpts) Server(
 	ctx context.Context,
 	log *zap.SugaredLogger,
@@ -60,6 +61,7 @@ func (opts *WebhookOpts) Server(
 	workerName string,
 	seedsGetter provider.SeedsGetter,
 	seedClientGetter provider.SeedClientGetter,
+	client ctrlruntimeclient.Reader,
 	migrationModeEnabled bool) (*Server, error) {
 
 	labelSelector, err := workerlabel.LabelSelector(workerName)
@@ -81,11 +83,14 @@ func (opts *WebhookOpts) Server(
 		certFile:             opts.CertFile,
 		keyFile:              opts.KeyFile,
 		validator:            newValidator(ctx, seedsGetter, seedClientGetter, listOpts),
+		ctx:                  ctx,
+		client:               client,
 		namespace:            namespace,
 		migrationModeEnabled: migrationModeEnabled,
 	}
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", server.handleSeedValidationRequests)
+	mux.HandleFunc(AddonConfigValidationPath, server.handleAddonConfigValidationRequests)
 	server.Handler = mux
 
 	return server, nil
@@ -98,6 +103,8 @@ type Server struct {
 	certFile             string
 	keyFile              string
 	validator            *seedValidator
+	ctx                  context.Context
+	client               ctrlruntimeclient.Reader
 	namespace            string
 	migrationModeEnabled bool
 }
@@ -121,6 +128,13 @@ func (s *Server) handleSeedValidationRequests(resp http.ResponseWriter, req *htt
 		s.log.Warnw("Seed admission failed", zap.Error(validationErr))
 	}
 
+	if s.writeResponse(resp, admissionRequest, validationErr) {
+		s.log.Debug("Successfully validated seed")
+	}
+}
+
+// writeResponse writes the result of the validation as AdmissionReview and returns whether it succeeded
+func (s *Server) writeResponse(resp http.ResponseWriter, admissionRequest *admissionv1beta1.AdmissionRequest, validationErr error) bool {
 	var uid types.UID
 	if admissionRequest != nil {
 		uid = admissionRequest.UID
@@ -139,17 +153,17 @@ func (s *Server) handleSeedValidationRequests(resp http.ResponseWriter, req *htt
 	if err != nil {
 		s.log.Errorw("Failed to serialize admission response", zap.Error(err))
 		http.Error(resp, "failed to serialize response", http.StatusInternalServerError)
-		return
+		return false
 	}
 	resp.WriteHeader(http.StatusOK)
 	if _, err := resp.Write(serializedAdmissionResponse); err != nil {
 		s.log.Errorw("Failed to write response body", zap.Error(err))
-		return
+		return false
 	}
-	s.log.Debug("Successfully validated seed")
+	return true
 }
 
-func (s *Server) handle(req *http.Request) (*admissionv1beta1.AdmissionRequest, error) {
+func readAdmissionReview(req *http.Request) (*admissionv1beta1.AdmissionReview, error) {
 	body := bytes.NewBuffer([]byte{})
 	if _, err := body.ReadFrom(req.Body); err != nil {
 		return nil, fmt.Errorf("failed to read request body: %v", err)
@@ -163,6 +177,14 @@ func (s *Server) handle(req *http.Request) (*admissionv1beta1.AdmissionRequest,
 	if admissionReview.Request == nil {
 		return nil, errors.New("received malformed admission review: no request defined")
 	}
+	return admissionReview, nil
+}
+
+func (s *Server) handle(req *http.Request) (*admissionv1beta1.AdmissionRequest, error) {
+	admissionReview, err := readAdmissionReview(req)
+	if err != nil {
+		return nil, err
+	}
 
 	s.log.Debugw(
 		"Received admission request",